@@ -4,23 +4,37 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/TryCadence/Cadence/internal/ai"
 	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
 	"github.com/TryCadence/Cadence/internal/config"
 )
 
-func performAIAnalysisUnified(report *analysis.AnalysisReport, aiConfig *config.AIConfig) error {
+// newAIAnalyzer builds the analyzer for the configured provider.
+func newAIAnalyzer(aiConfig *config.AIConfig) (ai.Analyzer, error) {
 	aiAnalyzer, err := ai.NewAnalyzer(&ai.Config{
-		Enabled:   aiConfig.Enabled,
-		Provider:  aiConfig.Provider,
-		APIKey:    aiConfig.APIKey,
-		Model:     aiConfig.Model,
-		MaxTokens: 500,
+		Enabled:        aiConfig.Enabled,
+		Provider:       aiConfig.Provider,
+		APIKey:         aiConfig.APIKey,
+		Model:          aiConfig.Model,
+		MaxTokens:      500,
+		BaseURL:        aiConfig.BaseURL,
+		MaxRetries:     aiConfig.MaxRetries,
+		RetryBaseDelay: time.Duration(aiConfig.RetryBaseDelayMs) * time.Millisecond,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create AI analyzer: %w", err)
+		return nil, fmt.Errorf("failed to create AI analyzer: %w", err)
+	}
+	return aiAnalyzer, nil
+}
+
+func performAIAnalysisUnified(report *analysis.AnalysisReport, aiConfig *config.AIConfig) error {
+	aiAnalyzer, err := newAIAnalyzer(aiConfig)
+	if err != nil {
+		return err
 	}
 
 	if !aiAnalyzer.IsConfigured() {
@@ -30,28 +44,74 @@ func performAIAnalysisUnified(report *analysis.AnalysisReport, aiConfig *config.
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
 	defer cancel()
 
-	for i, detection := range report.Detections {
-		if !detection.Detected {
+	probabilities := reviewDetections(ctx, aiAnalyzer, report)
+	report.ApplyAIScore(probabilities, aiConfig.BlendWeight)
+	if len(probabilities) > 0 {
+		fmt.Fprintf(os.Stderr, "  Blended score: %.1f%% (heuristic %.1f%%, AI %.1f%%, AI weight %.2f)\n",
+			report.Scores.Blended, report.Scores.Heuristic, report.Scores.AI, report.Scores.AIWeight)
+	}
+
+	return nil
+}
+
+// reviewDetections asks the analyzer about every fired detection that has
+// content to review, appends its verdict to the detection's description and
+// returns, per verdict, the probability that the content is AI-generated.
+func reviewDetections(ctx context.Context, aiAnalyzer ai.Analyzer, report *analysis.AnalysisReport) []float64 {
+	diffs := commitDiffs(report)
+	var probabilities []float64
+	for i := range report.Detections {
+		detection := &report.Detections[i]
+		if !detection.Detected || len(detection.Examples) == 0 {
 			continue
 		}
 
-		if len(detection.Examples) == 0 {
+		id, snippet := reviewContent(detection, diffs)
+		if snippet == "" {
 			continue
 		}
 
 		fmt.Fprintf(os.Stderr, "  Analyzing detection %d/%d: %s...\n", i+1, len(report.Detections), detection.Strategy)
 
-		codeSnippet := detection.Examples[0]
-		analysis, err := aiAnalyzer.AnalyzeSuspiciousCode(ctx, detection.Strategy, codeSnippet)
+		result, err := aiAnalyzer.AnalyzeCode(ctx, id, snippet)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "    Warning: AI analysis failed for %s: %v\n", detection.Strategy, err)
 			continue
 		}
-
-		if analysis != "" {
-			detection.Description = detection.Description + " - AI: " + analysis
+		if result == nil {
+			continue
 		}
+
+		detection.Description = detection.Description + " - AI: " + ai.FormatAnalysisResult(result)
+		probabilities = append(probabilities, result.AIProbability())
 	}
+	return probabilities
+}
 
-	return nil
+// reviewContent returns the identifier and content the analyzer reviews for
+// a detection. A git commit detection, whose first example is the commit
+// hash, is reviewed by its diff and skipped without one; other detections
+// are reviewed by their quoted examples.
+func reviewContent(detection *analysis.Detection, diffs map[string]string) (id, snippet string) {
+	if len(detection.Strategies) > 0 {
+		hash := detection.Examples[0]
+		return hash, diffs[hash]
+	}
+	return detection.Strategy, strings.Join(detection.Examples, "\n")
+}
+
+// commitDiffs maps commit hashes to their diffs from the commit pairs a git
+// source leaves in the report metrics, including a multi-source report's
+// "git." metrics.
+func commitDiffs(report *analysis.AnalysisReport) map[string]string {
+	diffs := make(map[string]string)
+	for _, key := range []string{"commit_pairs", string(analysis.SourceTypeGit) + ".commit_pairs"} {
+		pairs, _ := report.Metrics[key].([]*git.CommitPair)
+		for _, p := range pairs {
+			if p != nil && p.Current != nil && p.DiffContent != "" {
+				diffs[p.Current.Hash] = p.DiffContent
+			}
+		}
+	}
+	return diffs
 }
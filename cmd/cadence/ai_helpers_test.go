@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/ai"
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// verdictAnalyzer answers AnalyzeCode with a fixed verdict and records what
+// it was asked about.
+type verdictAnalyzer struct {
+	ai.Analyzer
+	verdict  ai.AnalysisResult
+	ids      []string
+	snippets []string
+}
+
+func (a *verdictAnalyzer) AnalyzeCode(_ context.Context, id, snippet string) (*ai.AnalysisResult, error) {
+	a.ids = append(a.ids, id)
+	a.snippets = append(a.snippets, snippet)
+	result := a.verdict
+	return &result, nil
+}
+
+func TestReviewDetections(t *testing.T) {
+	newReport := func() *analysis.AnalysisReport {
+		return &analysis.AnalysisReport{
+			Detections: []analysis.Detection{
+				{Strategy: "git-size", Detected: true, Examples: []string{"abc123", "Large commit"}, Strategies: []string{"size_analysis"}},
+				{Strategy: "git-size", Detected: true, Examples: []string{"nodiff", "Large commit"}, Strategies: []string{"size_analysis"}},
+				{Strategy: "git-size", Detected: false, Examples: []string{"passed"}},
+			},
+			Metrics: map[string]interface{}{
+				"commit_pairs": []*git.CommitPair{{Current: &git.Commit{Hash: "abc123"}, DiffContent: "+func main() {}"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		verdict ai.AnalysisResult
+		want    float64
+	}{
+		{"confident AI verdict", ai.AnalysisResult{Assessment: "likely AI-generated", Confidence: 0.9}, 0.9},
+		{"confident human verdict", ai.AnalysisResult{Assessment: "unlikely AI-generated", Confidence: 0.9}, 0.1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer := &verdictAnalyzer{verdict: tt.verdict}
+			probabilities := reviewDetections(context.Background(), analyzer, newReport())
+
+			if len(analyzer.snippets) != 1 || analyzer.ids[0] != "abc123" || analyzer.snippets[0] != "+func main() {}" {
+				t.Fatalf("reviewed %q with %q, want only commit abc123 with its diff", analyzer.ids, analyzer.snippets)
+			}
+			if len(probabilities) != 1 || math.Abs(probabilities[0]-tt.want) > 1e-9 {
+				t.Errorf("probabilities = %v, want [%v]", probabilities, tt.want)
+			}
+		})
+	}
+
+	report := newReport()
+	report.ApplyAIScore(reviewDetections(context.Background(), &verdictAnalyzer{verdict: tests[1].verdict}, report), 1)
+	if report.OverallScore > 10+1e-9 {
+		t.Errorf("OverallScore = %v after a confident human verdict, want it lowered to 10", report.OverallScore)
+	}
+}
@@ -2,30 +2,51 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	gogit "github.com/go-git/go-git/v5"
 	"github.com/spf13/cobra"
 
 	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
 	"github.com/TryCadence/Cadence/internal/analysis/detectors"
 	"github.com/TryCadence/Cadence/internal/analysis/sources"
 	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/logging"
 	"github.com/TryCadence/Cadence/internal/reporter"
 )
 
 var (
 	analyzeOutput              string
+	analyzeFormat              string
+	analyzePreset              string
 	analyzeSuspiciousAdditions int64
 	analyzeSuspiciousDeletions int64
 	analyzeMaxAdditionsMin     float64
 	analyzeMaxDeletionsMin     float64
 	analyzeMinTimeDelta        int64
 	analyzeBranch              string
+	analyzeFrom                string
+	analyzeTo                  string
 	analyzeExcludeFiles        []string
+	analyzeIgnoreAuthors       []string
+	analyzeMergeStrategy       string
+	analyzeExplain             bool
+	analyzeIncludePassed       bool
+	analyzeStabilityRuns       int
+	analyzeStabilityJitter     float64
+	analyzeBaselineFile        string
+	analyzeReposFile           string
+	analyzeConcurrency         int
+	analyzeRepo                string
+	analyzeSite                string
 )
 
 var analyzeCmd = &cobra.Command{
@@ -35,51 +56,142 @@ var analyzeCmd = &cobra.Command{
 
 The repository argument should be a local directory path to a git repository
 
+With --repos-file, analyzes every repository listed in the file instead and
+writes one combined report
+
+With --site, also analyzes the project's website and merges both analyses
+into one report whose score weights the two (multi_source in the config)
+
 Requires threshold configuration via flags or config file`,
-	Args: cobra.ExactArgs(1),
+	Args: cobra.MaximumNArgs(1),
 	RunE: runAnalyze,
 }
 
 func init() {
-	analyzeCmd.Flags().StringVarP(&analyzeOutput, "output", "o", "", "output file path (required, format detected from extension: .txt or .json)")
-	_ = analyzeCmd.MarkFlagRequired("output")
+	analyzeCmd.Flags().StringVarP(&analyzeOutput, "output", "o", "", "write the report to this file in the reports/ directory instead of stdout")
+	analyzeCmd.Flags().StringVarP(&analyzeFormat, "format", "f", "", "report format: "+strings.Join(reporter.Formats, "|")+" (default: from --output extension, else text)")
+	analyzeCmd.Flags().StringVar(&analyzePreset, "preset", "", "threshold preset: "+strings.Join(config.PresetNames(), "|")+" (thresholds set in the config file or by flags override it)")
 	analyzeCmd.Flags().Int64Var(&analyzeSuspiciousAdditions, "suspicious-additions", 0, "flag commits with more than this many additions (0 to disable)")
 	analyzeCmd.Flags().Int64Var(&analyzeSuspiciousDeletions, "suspicious-deletions", 0, "flag commits with more than this many deletions (0 to disable)")
 	analyzeCmd.Flags().Float64Var(&analyzeMaxAdditionsMin, "max-additions-pm", 0, "max additions per minute (0 to disable)")
 	analyzeCmd.Flags().Float64Var(&analyzeMaxDeletionsMin, "max-deletions-pm", 0, "max deletions per minute (0 to disable)")
 	analyzeCmd.Flags().Int64Var(&analyzeMinTimeDelta, "min-time-delta", 0, "min seconds between commits (0 to disable)")
 	analyzeCmd.Flags().StringVar(&analyzeBranch, "branch", "", "branch to analyze")
+	analyzeCmd.Flags().StringVar(&analyzeFrom, "from", "", "only analyze commits after this ref (branch, tag or hash; exclusive)")
+	analyzeCmd.Flags().StringVar(&analyzeTo, "to", "", "analyze commits reachable from this ref instead of the branch head (inclusive)")
 	analyzeCmd.Flags().StringSliceVar(&analyzeExcludeFiles, "exclude-files", []string{}, "file patterns to exclude (e.g., *.log,*.tmp)")
+	analyzeCmd.Flags().StringSliceVar(&analyzeIgnoreAuthors, "ignore-authors", []string{}, "skip commits whose author name or email matches these patterns (e.g., dependabot*)")
+	analyzeCmd.Flags().StringVar(&analyzeMergeStrategy, "merge-strategy", "", "merge commits: skip, first-parent or both (default: analysis.merge_strategy, else skip)")
+	analyzeCmd.Flags().StringVar(&analyzeBaselineFile, "baseline-file", "", "score against the baseline profile saved in this file by an earlier run, then save this run's baseline to it")
+	analyzeCmd.Flags().StringVar(&analyzeReposFile, "repos-file", "", "analyze every repository (URL or path) in this file, one per line or a JSON list, into one combined report")
+	analyzeCmd.Flags().StringVar(&analyzeRepo, "repo", "", "repository to analyze (URL or path), instead of the argument")
+	analyzeCmd.Flags().StringVar(&analyzeSite, "site", "", "also analyze this website and merge it with the repository into one multi-source report")
+	analyzeCmd.Flags().IntVar(&analyzeConcurrency, "concurrency", 1, "with --repos-file, how many repositories to analyze at once")
+	analyzeCmd.Flags().BoolVar(&analyzeIncludePassed, "include-passed", false, "also report strategies that ran and fired on no commit (default: analysis.include_passed)")
+	analyzeCmd.Flags().IntVar(&analyzeStabilityRuns, "stability-runs", 0, "rerun the analysis this many times with thresholds spread across ±--stability-jitter and report how often the verdict holds")
+	analyzeCmd.Flags().Float64Var(&analyzeStabilityJitter, "stability-jitter", analysis.DefaultStabilityJitter, "with --stability-runs, the largest relative threshold change tried (0-1)")
+	analyzeCmd.Flags().BoolVar(&analyzeExplain, "explain", false, "print which strategies fire on each commit, with their inputs and thresholds, instead of writing a report")
 }
 
 func runAnalyze(cmd *cobra.Command, args []string) error {
-	repoPath := args[0]
-	var cleanup func() error
+	if analyzeStabilityRuns != 0 {
+		if err := validateStabilityFlags(); err != nil {
+			return err
+		}
+	}
+	if analyzeReposFile != "" {
+		if analyzeRepo != "" || analyzeSite != "" {
+			return fmt.Errorf("--repo and --site cannot be used with --repos-file")
+		}
+		return runBatchAnalyze(cmd, args)
+	}
+	repoArg, err := resolveAnalyzeRepo(args, analyzeRepo)
+	if err != nil {
+		return err
+	}
+	if analyzeSite != "" {
+		return runMultiSourceAnalyze(cmd, repoArg, analyzeSite)
+	}
 
-	outputFormat, err := detectFormatFromExtension(analyzeOutput)
+	outputFormat, err := resolveOutputFormat(analyzeFormat, cmd.Flags().Changed("format"), analyzeOutput)
+	if err != nil {
+		return err
+	}
+	if err := checkBinaryOutput(outputFormat, analyzeOutput, stdoutIsTerminal()); err != nil {
+		return err
+	}
+	formatter, err := reporter.NewAnalysisFormatter(outputFormat)
 	if err != nil {
 		return err
 	}
 
-	if isRemoteRepo(repoPath) {
-		gitURL, extractedBranch := parseGitHubURL(repoPath)
+	cfg, err := loadAnalyzeConfig(cmd)
+	if err != nil {
+		return err
+	}
+	formatter = outputFormatter(formatter, cfg)
 
-		if analyzeBranch == "" && extractedBranch != "" {
-			analyzeBranch = extractedBranch
+	var historical *analysis.RepositoryBaseline
+	if analyzeBaselineFile != "" {
+		if historical, err = loadBaselineFile(analyzeBaselineFile); err != nil {
+			return err
 		}
+	}
 
-		fmt.Fprintln(os.Stderr, "Cloning repository...")
-		repoPath, cleanup, err = cloneRemoteRepo(gitURL)
-		if err != nil {
-			return fmt.Errorf("failed to clone repository: %w", err)
+	var report *analysis.AnalysisReport
+	var gitDetector *detectors.GitDetector
+	if analyzeStabilityRuns > 0 {
+		report, gitDetector, err = analyzeRepositoryStability(context.Background(), cfg, repoArg, historical)
+	} else {
+		report, gitDetector, err = analyzeRepository(context.Background(), cfg, repoArg, historical)
+	}
+	if err != nil {
+		return err
+	}
+
+	if analyzeBaselineFile != "" {
+		if err := saveBaselineFile(analyzeBaselineFile, repoArg, gitDetector, report); err != nil {
+			return err
 		}
-		defer func() {
-			if cleanup != nil {
-				_ = cleanup()
-			}
-		}()
 	}
 
+	if analyzeExplain {
+		printExplain(os.Stdout, gitDetector.Traces)
+		return nil
+	}
+
+	if cfg.AI.Enabled && report.DetectionCount > 0 {
+		fmt.Fprintf(os.Stderr, "Performing AI analysis on %d suspicious commits...\n", report.DetectionCount)
+		if err := performAIAnalysisUnified(report, &cfg.AI); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: AI analysis failed: %v\n", err)
+		}
+	}
+
+	reportStr, err := formatter.FormatAnalysis(report)
+	if err != nil {
+		return fmt.Errorf("failed to format report: %w", err)
+	}
+	return writeAnalyzeOutput(reportStr, outputFormat)
+}
+
+// resolveAnalyzeRepo returns the repository to analyze, given either as the
+// argument or with --repo.
+func resolveAnalyzeRepo(args []string, repoFlag string) (string, error) {
+	switch {
+	case len(args) == 1 && repoFlag != "":
+		return "", fmt.Errorf("give the repository as the argument or with --repo, not both")
+	case len(args) == 1:
+		return args[0], nil
+	case repoFlag != "":
+		return repoFlag, nil
+	default:
+		return "", fmt.Errorf("analyze needs a repository argument, or --repos-file for a batch")
+	}
+}
+
+// loadAnalyzeConfig loads the config file and applies the threshold and
+// filter flags given on the command line.
+func loadAnalyzeConfig(cmd *cobra.Command) (*config.Config, error) {
 	cfgPath := configFile
 	if cfgPath == "" {
 		if _, err := os.Stat("cadence.yml"); err == nil {
@@ -89,9 +201,15 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 
 	cfg, err := config.Load(cfgPath)
 	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
+		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
+	logging.SetDefault(cfg.Logging.Logger())
 
+	if cmd.Flags().Changed("preset") {
+		if err := cfg.ApplyThresholdPreset(analyzePreset); err != nil {
+			return nil, fmt.Errorf("invalid --preset: %w", err)
+		}
+	}
 	if cmd.Flags().Changed("suspicious-additions") {
 		cfg.Thresholds.SuspiciousAdditions = analyzeSuspiciousAdditions
 	}
@@ -110,36 +228,101 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	if cmd.Flags().Changed("exclude-files") {
 		cfg.ExcludeFiles = analyzeExcludeFiles
 	}
+	if cmd.Flags().Changed("ignore-authors") {
+		cfg.IgnoreAuthors = analyzeIgnoreAuthors
+	}
+	if cmd.Flags().Changed("merge-strategy") {
+		if cfg.Analysis.MergeStrategy, err = git.ParseMergeStrategy(analyzeMergeStrategy); err != nil {
+			return nil, fmt.Errorf("invalid --merge-strategy: %w", err)
+		}
+	}
+	if cmd.Flags().Changed("include-passed") {
+		cfg.Analysis.IncludePassed = analyzeIncludePassed
+	}
 
 	if cfg.Thresholds.IsZero() {
-		return fmt.Errorf("no thresholds configured - please set thresholds via config file or flags")
+		return nil, fmt.Errorf("no thresholds configured - please set thresholds via config file or flags")
 	}
+	return cfg, nil
+}
 
-	source := sources.NewGitRepositorySource(repoPath, analyzeBranch)
-	gitDetector := detectors.NewGitDetectorWithConfig(&cfg.Thresholds, &cfg.Strategies)
+// analyzeRepository clones repoArg if it is a URL and runs the git
+// detectors over it, scoring against historical when it is set. It returns
+// the detector too, for its baseline and explain traces.
+func analyzeRepository(ctx context.Context, cfg *config.Config, repoArg string, historical *analysis.RepositoryBaseline) (*analysis.AnalysisReport, *detectors.GitDetector, error) {
+	source, gitDetector, cleanup, err := prepareRepository(cfg, repoArg, historical)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cleanup()
 	runner := analysis.NewDefaultDetectionRunner()
 
-	fmt.Fprintln(os.Stderr, "Analyzing repository...")
-	report, err := runner.Run(context.Background(), source, gitDetector)
+	fmt.Fprintf(os.Stderr, "Analyzing repository %s...\n", repoArg)
+	report, err := runner.Run(ctx, source, gitDetector)
 	if err != nil {
-		return fmt.Errorf("analysis failed: %w", err)
+		return nil, nil, fmt.Errorf("analysis failed: %w", err)
 	}
+	return report, gitDetector, nil
+}
 
-	if cfg.AI.Enabled && report.DetectionCount > 0 {
-		fmt.Fprintf(os.Stderr, "Performing AI analysis on %d suspicious commits...\n", report.DetectionCount)
-		if err := performAIAnalysisUnified(report, &cfg.AI); err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: AI analysis failed: %v\n", err)
+// prepareRepository clones repoArg if it is a URL and builds the git source
+// and detector for it from cfg. cleanup removes the clone.
+func prepareRepository(cfg *config.Config, repoArg string, historical *analysis.RepositoryBaseline) (*sources.GitRepositorySource, *detectors.GitDetector, func(), error) {
+	cleanup := func() {}
+	repoPath := repoArg
+	branch := analyzeBranch
+	if isRemoteRepo(repoArg) {
+		gitURL, extractedBranch := parseGitHubURL(repoArg)
+		if branch == "" && extractedBranch != "" {
+			branch = extractedBranch
 		}
-	}
 
-	formatter, err := reporter.NewAnalysisFormatter(outputFormat)
-	if err != nil {
-		return fmt.Errorf("failed to create formatter: %w", err)
+		fmt.Fprintf(os.Stderr, "Cloning repository %s...\n", repoArg)
+		clonePath, removeClone, err := cloneRemoteRepo(gitURL)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to clone repository: %w", err)
+		}
+		cleanup = func() { _ = removeClone() }
+		repoPath = clonePath
 	}
 
-	reportStr, err := formatter.FormatAnalysis(report)
-	if err != nil {
-		return fmt.Errorf("failed to format report: %w", err)
+	source := sources.NewGitRepositorySource(repoPath, branch)
+	source.ExcludeFiles = cfg.ExcludeFiles
+	source.GeneratedFiles = cfg.GeneratedFiles.Patterns
+	source.CountGeneratedFiles = !cfg.GeneratedFiles.Enabled
+	source.IgnoreAuthors = cfg.IgnoreAuthors
+	source.Identity = cfg.AuthorIdentity
+	source.FromRef = analyzeFrom
+	source.ToRef = analyzeTo
+	source.MaxCommits = cfg.Analysis.MaxCommits
+	source.MaxDiffBytes = cfg.Analysis.MaxDiffBytes
+	source.DiffWorkers = cfg.Analysis.DiffWorkers
+	source.MergeStrategy = cfg.Analysis.MergeStrategy
+	gitDetector := detectors.NewGitDetectorWithConfig(&cfg.Thresholds, &cfg.Strategies)
+	gitDetector.DependencyManifests = cfg.DependencyManifests
+	gitDetector.AIAssistants = cfg.AIAssistants
+	gitDetector.NGramMaxCoverage = cfg.NGramRepetition.GitMaxCoverage
+	gitDetector.Churn = cfg.Churn.Options()
+	gitDetector.SampleSizeGate = cfg.Baseline.Gate()
+	gitDetector.LanguageProfiles = cfg.LanguageProfiles
+	gitDetector.Explain = analyzeExplain
+	gitDetector.HistoricalBaseline = historical
+	gitDetector.MinReportConfidence = cfg.Analysis.MinReportConfidence
+	gitDetector.IncludePassed = cfg.Analysis.IncludePassed
+	return source, gitDetector, cleanup, nil
+}
+
+// writeAnalyzeOutput prints the report in format, or writes it to --output
+// in the reports/ directory.
+func writeAnalyzeOutput(reportStr, format string) error {
+	if analyzeOutput == "" {
+		if format == "bson" {
+			// No trailing newline: the document is read back byte for byte.
+			fmt.Print(reportStr)
+		} else {
+			fmt.Println(reportStr)
+		}
+		return nil
 	}
 
 	reportsDir := "reports"
@@ -156,6 +339,98 @@ func runAnalyze(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// loadBaselineFile reads the baseline profile --baseline-file points to. A
+// missing file is not an error: the first run creates it.
+func loadBaselineFile(path string) (*analysis.RepositoryBaseline, error) {
+	profile, err := analysis.LoadBaselineProfile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		fmt.Fprintf(os.Stderr, "No baseline profile at %s yet; this run will create it\n", path)
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load baseline profile: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Scoring against baseline profile from %s (%d commits)\n",
+		profile.CreatedAt.Format(time.RFC3339), profile.Baseline.SampleSize)
+	return profile.Baseline, nil
+}
+
+// saveBaselineFile reports drift from the loaded profile and replaces it
+// with this run's baseline.
+func saveBaselineFile(path, source string, det *detectors.GitDetector, report *analysis.AnalysisReport) error {
+	if drifts, ok := report.Metrics["baseline_drift"].([]analysis.BaselineDrift); ok {
+		for _, d := range drifts {
+			fmt.Fprintf(os.Stderr, "Baseline drift: %s\n", d)
+		}
+	}
+
+	if det.Baseline == nil || det.Baseline.SampleSize == 0 {
+		fmt.Fprintln(os.Stderr, "No commits to build a baseline from; baseline profile left unchanged")
+		return nil
+	}
+	if err := analysis.SaveBaselineProfile(path, analysis.NewBaselineProfile(source, det.Baseline)); err != nil {
+		return fmt.Errorf("failed to save baseline profile: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Baseline profile saved to %s\n", path)
+	return nil
+}
+
+// printExplain writes one block per commit listing every strategy, whether
+// it fired, and the inputs it compared against its thresholds.
+func printExplain(w io.Writer, traces []detectors.CommitTrace) {
+	for _, t := range traces {
+		fmt.Fprintf(w, "%s %s\n", shortHash(t.Hash), firstLine(t.Message))
+		if t.Skipped != "" {
+			fmt.Fprintf(w, "  skipped: %s\n\n", t.Skipped)
+			continue
+		}
+		for _, st := range t.Strategies {
+			mark := "pass"
+			if st.Fired {
+				mark = "FIRE"
+			}
+			fmt.Fprintf(w, "  [%s] %s", mark, st.Strategy)
+			inputs := make([]string, 0, len(st.Inputs))
+			for _, in := range st.Inputs {
+				if in.Operator != "" {
+					inputs = append(inputs, fmt.Sprintf("%s=%s (fires %s %s)", in.Name, formatTraceValue(in.Value), in.Operator, formatTraceValue(in.Threshold)))
+				} else {
+					inputs = append(inputs, fmt.Sprintf("%s=%s", in.Name, formatTraceValue(in.Value)))
+				}
+			}
+			if len(inputs) > 0 {
+				fmt.Fprintf(w, ": %s", strings.Join(inputs, ", "))
+			}
+			fmt.Fprintln(w)
+			if st.Fired && st.Reason != "" {
+				fmt.Fprintf(w, "         %s\n", st.Reason)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+func formatTraceValue(v float64) string {
+	if v == float64(int64(v)) {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	return strconv.FormatFloat(v, 'f', 3, 64)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
 func isRemoteRepo(path string) bool {
 	return len(path) > 7 && (path[:7] == "http://" || (len(path) > 8 && path[:8] == "https://"))
 }
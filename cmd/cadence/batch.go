@@ -0,0 +1,307 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/reporter"
+)
+
+// batchFormats are the report formats --repos-file can combine; the others
+// are single-document formats that do not concatenate.
+var batchFormats = []string{"text", "json", "markdown"}
+
+// batchResult is one repository's outcome in a batch.
+type batchResult struct {
+	Repository string
+	Report     *analysis.AnalysisReport
+	Err        error
+}
+
+// batchSummary rolls metrics up across the repositories of a batch.
+// Scores and counts cover only the repositories that were analyzed.
+type batchSummary struct {
+	Repositories           int     `json:"repositories"`
+	Analyzed               int     `json:"analyzed"`
+	Failed                 int     `json:"failed"`
+	CommitsAnalyzed        int     `json:"commits_analyzed"`
+	CommitsFlagged         int     `json:"commits_flagged"`
+	TotalDetections        int     `json:"total_detections"`
+	DetectionCount         int     `json:"detection_count"`
+	HighSeverity           int     `json:"high_severity"`
+	MediumSeverity         int     `json:"medium_severity"`
+	LowSeverity            int     `json:"low_severity"`
+	AverageScore           float64 `json:"average_score"` // mean overall score
+	HighestScore           float64 `json:"highest_score"`
+	HighestScoreRepository string  `json:"highest_score_repository,omitempty"`
+	DurationMs             int64   `json:"duration_ms"`
+}
+
+func summarizeBatch(results []batchResult) batchSummary {
+	summary := batchSummary{Repositories: len(results)}
+	var scoreSum float64
+	for _, r := range results {
+		if r.Err != nil {
+			summary.Failed++
+			continue
+		}
+		report := r.Report
+		summary.Analyzed++
+		summary.CommitsAnalyzed += report.SourceMetrics.ItemsAnalyzed
+		summary.CommitsFlagged += report.SourceMetrics.ItemsFlagged
+		summary.TotalDetections += report.TotalDetections
+		summary.DetectionCount += report.DetectionCount
+		summary.HighSeverity += report.HighSeverityCount
+		summary.MediumSeverity += report.MediumSeverityCount
+		summary.LowSeverity += report.LowSeverityCount
+		summary.DurationMs += report.Duration.Milliseconds()
+		scoreSum += report.OverallScore
+		if summary.HighestScoreRepository == "" || report.OverallScore > summary.HighestScore {
+			summary.HighestScore = report.OverallScore
+			summary.HighestScoreRepository = r.Repository
+		}
+	}
+	if summary.Analyzed > 0 {
+		summary.AverageScore = scoreSum / float64(summary.Analyzed)
+	}
+	return summary
+}
+
+// readReposFile reads the repositories to analyze: a JSON list of strings,
+// or one per line with blank lines and # comments ignored.
+func readReposFile(path string) ([]string, error) {
+	return readListFile(path, "repos", "repositories")
+}
+
+// readListFile reads a JSON list of strings, or one entry per line with blank
+// lines and # comments ignored. kind names the file and items its entries in
+// errors.
+func readListFile(path, kind, items string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s file: %w", kind, err)
+	}
+
+	var entries []string
+	if content := strings.TrimSpace(string(data)); strings.HasPrefix(content, "[") {
+		if err := json.Unmarshal([]byte(content), &entries); err != nil {
+			return nil, fmt.Errorf("invalid %s file %s: %w", kind, path, err)
+		}
+	} else {
+		entries = strings.Split(content, "\n")
+	}
+
+	kept := entries[:0]
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry != "" && !strings.HasPrefix(entry, "#") {
+			kept = append(kept, entry)
+		}
+	}
+	if len(kept) == 0 {
+		return nil, fmt.Errorf("%s file %s lists no %s", kind, path, items)
+	}
+	return kept, nil
+}
+
+func runBatchAnalyze(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("--repos-file replaces the repository argument; give one or the other")
+	}
+	if analyzeExplain || analyzeBaselineFile != "" {
+		return fmt.Errorf("--explain and --baseline-file analyze a single repository and cannot be used with --repos-file")
+	}
+	if analyzeConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	outputFormat, err := resolveOutputFormat(analyzeFormat, cmd.Flags().Changed("format"), analyzeOutput)
+	if err != nil {
+		return err
+	}
+	if outputFormat == "md" {
+		outputFormat = "markdown"
+	}
+	if !containsFormat(batchFormats, outputFormat) {
+		return fmt.Errorf("--repos-file supports the %s formats, not %s", strings.Join(batchFormats, ", "), outputFormat)
+	}
+	formatter, err := reporter.NewAnalysisFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	repos, err := readReposFile(analyzeReposFile)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadAnalyzeConfig(cmd)
+	if err != nil {
+		return err
+	}
+	formatter = outputFormatter(formatter, cfg)
+
+	results := runBatch(context.Background(), cfg, repos, analyzeConcurrency)
+	summary := summarizeBatch(results)
+
+	out, err := formatBatch(outputFormat, formatter, summary, results)
+	if err != nil {
+		return err
+	}
+	if err := writeAnalyzeOutput(out, outputFormat); err != nil {
+		return err
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d of %d repositories failed to analyze", summary.Failed, summary.Repositories)
+	}
+	return nil
+}
+
+// runBatch analyzes repos with at most concurrency running at once. A
+// failure is recorded on its result and the batch carries on; results keep
+// the order of repos.
+func runBatch(ctx context.Context, cfg *config.Config, repos []string, concurrency int) []batchResult {
+	results := make([]batchResult, len(repos))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, repo := range repos {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(i int, repo string) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s\n", i+1, len(repos), repo)
+			report, _, err := analyzeRepository(ctx, cfg, repo, nil)
+			if err == nil && cfg.AI.Enabled && report.DetectionCount > 0 {
+				if aiErr := performAIAnalysisUnified(report, &cfg.AI); aiErr != nil {
+					fmt.Fprintf(os.Stderr, "Warning: AI analysis failed for %s: %v\n", repo, aiErr)
+				}
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %s failed: %v\n", repo, err)
+			}
+			results[i] = batchResult{Repository: repo, Report: report, Err: err}
+		}(i, repo)
+	}
+	wg.Wait()
+	return results
+}
+
+func containsFormat(formats []string, format string) bool {
+	for _, f := range formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// batchJSON is the combined JSON report of a batch.
+type batchJSON struct {
+	Summary batchSummary      `json:"summary"`
+	Results []batchResultJSON `json:"results"`
+}
+
+type batchResultJSON struct {
+	Repository string          `json:"repository"`
+	Status     string          `json:"status"` // "analyzed" or "failed"
+	Error      string          `json:"error,omitempty"`
+	Report     json.RawMessage `json:"report,omitempty"`
+}
+
+// formatBatch renders the combined report: the summary and failures, then
+// each repository's report in the chosen format.
+func formatBatch(format string, formatter reporter.AnalysisFormatter, summary batchSummary, results []batchResult) (string, error) {
+	reports := make([]string, len(results))
+	for i, r := range results {
+		if r.Err != nil {
+			continue
+		}
+		out, err := formatter.FormatAnalysis(r.Report)
+		if err != nil {
+			return "", fmt.Errorf("failed to format report for %s: %w", r.Repository, err)
+		}
+		reports[i] = out
+	}
+
+	switch format {
+	case "json":
+		combined := batchJSON{Summary: summary, Results: make([]batchResultJSON, len(results))}
+		for i, r := range results {
+			combined.Results[i] = batchResultJSON{Repository: r.Repository, Status: "analyzed"}
+			if r.Err != nil {
+				combined.Results[i].Status = "failed"
+				combined.Results[i].Error = r.Err.Error()
+				continue
+			}
+			combined.Results[i].Report = json.RawMessage(reports[i])
+		}
+		out, err := json.MarshalIndent(combined, "", "  ")
+		if err != nil {
+			return "", fmt.Errorf("failed to format batch report: %w", err)
+		}
+		return string(out), nil
+
+	case "markdown":
+		var sb strings.Builder
+		sb.WriteString("# Cadence Batch Analysis\n\n")
+		sb.WriteString("| Metric | Value |\n|--------|-------|\n")
+		sb.WriteString(fmt.Sprintf("| Repositories | %d (%d analyzed, %d failed) |\n", summary.Repositories, summary.Analyzed, summary.Failed))
+		sb.WriteString(fmt.Sprintf("| Commits analyzed | %d (%d flagged) |\n", summary.CommitsAnalyzed, summary.CommitsFlagged))
+		sb.WriteString(fmt.Sprintf("| Detections | %d of %d (%d high, %d medium, %d low) |\n",
+			summary.DetectionCount, summary.TotalDetections, summary.HighSeverity, summary.MediumSeverity, summary.LowSeverity))
+		sb.WriteString(fmt.Sprintf("| Average score | %.1f%% |\n", summary.AverageScore))
+		if summary.HighestScoreRepository != "" {
+			sb.WriteString(fmt.Sprintf("| Highest score | %.1f%% (%s) |\n", summary.HighestScore, summary.HighestScoreRepository))
+		}
+		for i, r := range results {
+			sb.WriteString(fmt.Sprintf("\n---\n\n## %s\n\n", r.Repository))
+			if r.Err != nil {
+				sb.WriteString(fmt.Sprintf("**Failed:** %s\n", r.Err))
+				continue
+			}
+			sb.WriteString(reports[i])
+			sb.WriteString("\n")
+		}
+		return sb.String(), nil
+
+	default:
+		var sb strings.Builder
+		sb.WriteString("CADENCE BATCH ANALYSIS\n")
+		sb.WriteString(fmt.Sprintf("Repositories:     %d (%d analyzed, %d failed)\n", summary.Repositories, summary.Analyzed, summary.Failed))
+		sb.WriteString(fmt.Sprintf("Commits analyzed: %d (%d flagged)\n", summary.CommitsAnalyzed, summary.CommitsFlagged))
+		sb.WriteString(fmt.Sprintf("Detections:       %d of %d (%d high, %d medium, %d low)\n",
+			summary.DetectionCount, summary.TotalDetections, summary.HighSeverity, summary.MediumSeverity, summary.LowSeverity))
+		sb.WriteString(fmt.Sprintf("Average score:    %.1f%%\n", summary.AverageScore))
+		if summary.HighestScoreRepository != "" {
+			sb.WriteString(fmt.Sprintf("Highest score:    %.1f%% (%s)\n", summary.HighestScore, summary.HighestScoreRepository))
+		}
+		if summary.Failed > 0 {
+			sb.WriteString("\nFailed:\n")
+			for _, r := range results {
+				if r.Err != nil {
+					sb.WriteString(fmt.Sprintf("  %s: %s\n", r.Repository, r.Err))
+				}
+			}
+		}
+		for i, r := range results {
+			if r.Err != nil {
+				continue
+			}
+			sb.WriteString(fmt.Sprintf("\n===== %s =====\n", r.Repository))
+			sb.WriteString(reports[i])
+			sb.WriteString("\n")
+		}
+		return sb.String(), nil
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/reporter"
+)
+
+func TestReadReposFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected []string
+		wantErr  bool
+	}{
+		{
+			name:     "lines",
+			content:  "# team repos\nhttps://github.com/example/one\n\n  ./local/repo  \n",
+			expected: []string{"https://github.com/example/one", "./local/repo"},
+		},
+		{
+			name:     "json list",
+			content:  `["https://github.com/example/one", " ", "/srv/repo"]`,
+			expected: []string{"https://github.com/example/one", "/srv/repo"},
+		},
+		{
+			name:    "invalid json",
+			content: `["https://github.com/example/one",`,
+			wantErr: true,
+		},
+		{
+			name:    "only comments",
+			content: "# nothing yet\n\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "repos.txt")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			repos, err := readReposFile(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", repos)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(repos, tt.expected) {
+				t.Errorf("repos = %q, want %q", repos, tt.expected)
+			}
+		})
+	}
+
+	if _, err := readReposFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func batchFixture() []batchResult {
+	return []batchResult{
+		{Repository: "one", Report: &analysis.AnalysisReport{
+			SourceType:        analysis.SourceTypeGit,
+			OverallScore:      20,
+			TotalDetections:   10,
+			DetectionCount:    2,
+			HighSeverityCount: 1,
+			LowSeverityCount:  1,
+			SourceMetrics:     analysis.SourceMetrics{ItemsAnalyzed: 40, ItemsFlagged: 2},
+		}},
+		{Repository: "broken", Err: errors.New("clone failed")},
+		{Repository: "two", Report: &analysis.AnalysisReport{
+			SourceType:          analysis.SourceTypeGit,
+			OverallScore:        60,
+			TotalDetections:     10,
+			DetectionCount:      5,
+			MediumSeverityCount: 5,
+			SourceMetrics:       analysis.SourceMetrics{ItemsAnalyzed: 10, ItemsFlagged: 5},
+		}},
+	}
+}
+
+func TestSummarizeBatch(t *testing.T) {
+	summary := summarizeBatch(batchFixture())
+
+	expected := batchSummary{
+		Repositories:           3,
+		Analyzed:               2,
+		Failed:                 1,
+		CommitsAnalyzed:        50,
+		CommitsFlagged:         7,
+		TotalDetections:        20,
+		DetectionCount:         7,
+		HighSeverity:           1,
+		MediumSeverity:         5,
+		LowSeverity:            1,
+		AverageScore:           40,
+		HighestScore:           60,
+		HighestScoreRepository: "two",
+	}
+	if summary != expected {
+		t.Errorf("summary = %+v, want %+v", summary, expected)
+	}
+
+	if empty := summarizeBatch([]batchResult{{Repository: "broken", Err: errors.New("nope")}}); empty.AverageScore != 0 || empty.HighestScoreRepository != "" {
+		t.Errorf("a batch with no analyzed repositories should have no scores, got %+v", empty)
+	}
+}
+
+func TestFormatBatch_JSON(t *testing.T) {
+	results := batchFixture()
+	formatter, err := reporter.NewAnalysisFormatter("json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := formatBatch("json", formatter, summarizeBatch(results), results)
+	if err != nil {
+		t.Fatalf("formatBatch: %v", err)
+	}
+
+	var combined struct {
+		Summary batchSummary `json:"summary"`
+		Results []struct {
+			Repository string                 `json:"repository"`
+			Status     string                 `json:"status"`
+			Error      string                 `json:"error"`
+			Report     map[string]interface{} `json:"report"`
+		} `json:"results"`
+	}
+	if err := json.Unmarshal([]byte(out), &combined); err != nil {
+		t.Fatalf("combined report is not JSON: %v\n%s", err, out)
+	}
+	if combined.Summary.Failed != 1 || len(combined.Results) != 3 {
+		t.Fatalf("unexpected combined report: %+v", combined)
+	}
+	if r := combined.Results[1]; r.Repository != "broken" || r.Status != "failed" || r.Error != "clone failed" || r.Report != nil {
+		t.Errorf("failed result = %+v", r)
+	}
+	if r := combined.Results[2]; r.Status != "analyzed" || r.Report == nil {
+		t.Errorf("analyzed result should embed its report, got %+v", r)
+	}
+}
+
+func TestFormatBatch_Text(t *testing.T) {
+	results := batchFixture()
+	formatter, err := reporter.NewAnalysisFormatter("text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := formatBatch("text", formatter, summarizeBatch(results), results)
+	if err != nil {
+		t.Fatalf("formatBatch: %v", err)
+	}
+
+	for _, want := range []string{
+		"Repositories:     3 (2 analyzed, 1 failed)",
+		"Highest score:    60.0% (two)",
+		"broken: clone failed",
+		"===== one =====",
+		"===== two =====",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("text report missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "===== one =====") > strings.Index(out, "===== two =====") {
+		t.Error("per-repository reports should keep the input order")
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/reporter/formats"
+)
+
+var diffFormat string
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <before.json> <after.json>",
+	Short: "Compare two JSON analysis reports",
+	Long: `Compare two reports written by 'cadence analyze --format json' and show
+what changed: the overall score, strategies that started or stopped firing,
+and the commits whose findings changed.
+
+Findings are matched by strategy and commit hash, so the reports may list
+their detections in any order.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDiff,
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffFormat, "format", "f", "text", "output format: text|json")
+}
+
+func runDiff(cmd *cobra.Command, args []string) error {
+	format := strings.ToLower(strings.TrimSpace(diffFormat))
+	if format != "text" && format != "json" {
+		return fmt.Errorf("unsupported diff format %q (use text or json)", diffFormat)
+	}
+
+	before, err := loadJSONReport(args[0])
+	if err != nil {
+		return err
+	}
+	after, err := loadJSONReport(args[1])
+	if err != nil {
+		return err
+	}
+
+	diff := analysis.DiffReports(before, after)
+	if format == "json" {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	printReportDiff(os.Stdout, diff)
+	return nil
+}
+
+// loadJSONReport reads a report written by the JSON reporter.
+func loadJSONReport(path string) (*analysis.AnalysisReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+	report, err := formats.ParseJSONReport(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not a JSON report: %w", path, err)
+	}
+	if report.SchemaVersion != formats.JSONSchemaVersion {
+		return nil, fmt.Errorf("%s has report schema %q, want %q", path, report.SchemaVersion, formats.JSONSchemaVersion)
+	}
+	return report.AnalysisReport(), nil
+}
+
+// printReportDiff writes the score change, the strategies that started or
+// stopped firing and one line per changed commit.
+func printReportDiff(w io.Writer, d *analysis.ReportDiff) {
+	fmt.Fprintf(w, "Score: %.1f -> %.1f (%+.1f)\n", d.ScoreBefore, d.ScoreAfter, d.ScoreDelta)
+	if d.AssessmentBefore != d.AssessmentAfter {
+		fmt.Fprintf(w, "Assessment: %s -> %s\n", d.AssessmentBefore, d.AssessmentAfter)
+	}
+	if d.Unchanged() {
+		fmt.Fprintln(w, "No changes in findings")
+		return
+	}
+	if len(d.NewlyFiring) > 0 {
+		fmt.Fprintf(w, "Newly firing: %s\n", strings.Join(d.NewlyFiring, ", "))
+	}
+	if len(d.StoppedFiring) > 0 {
+		fmt.Fprintf(w, "Stopped firing: %s\n", strings.Join(d.StoppedFiring, ", "))
+	}
+	for _, k := range d.Added {
+		if k.Commit == "" {
+			fmt.Fprintf(w, "  + %s\n", k.Strategy)
+		}
+	}
+	for _, k := range d.Removed {
+		if k.Commit == "" {
+			fmt.Fprintf(w, "  - %s\n", k.Strategy)
+		}
+	}
+
+	if len(d.Commits) == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\nCommits (%d changed):\n", len(d.Commits))
+	for _, c := range d.Commits {
+		fmt.Fprintf(w, "  %s %-8s %.2f -> %.2f", shortHash(c.Commit), c.Status, c.ScoreBefore, c.ScoreAfter)
+		changes := make([]string, 0, len(c.Added)+len(c.Removed))
+		for _, s := range c.Added {
+			changes = append(changes, "+"+s)
+		}
+		for _, s := range c.Removed {
+			changes = append(changes, "-"+s)
+		}
+		if len(changes) > 0 {
+			fmt.Fprintf(w, "  %s", strings.Join(changes, " "))
+		}
+		fmt.Fprintln(w)
+	}
+}
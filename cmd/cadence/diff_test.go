@@ -0,0 +1,70 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/reporter/formats"
+)
+
+func TestLoadJSONReport(t *testing.T) {
+	out, err := (&formats.JSONReporter{}).FormatAnalysis(&analysis.AnalysisReport{
+		SourceID:     "repo",
+		OverallScore: 40,
+		Detections: []analysis.Detection{
+			{Strategy: "git-velocity-analysis", Detected: true, Examples: []string{"abc"}, Strategies: []string{"size_analysis"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := os.WriteFile(path, []byte(out), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := loadJSONReport(path)
+	if err != nil {
+		t.Fatalf("loadJSONReport() unexpected error = %v", err)
+	}
+	if report.OverallScore != 40 || len(report.Detections) != 1 || report.Detections[0].Strategies[0] != "size_analysis" {
+		t.Errorf("loadJSONReport() = %+v", report)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"schema_version": "0"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadJSONReport(path); err == nil {
+		t.Error("loadJSONReport() should reject another schema version")
+	}
+}
+
+func TestPrintReportDiff(t *testing.T) {
+	var b strings.Builder
+	printReportDiff(&b, &analysis.ReportDiff{
+		ScoreBefore:      30,
+		ScoreAfter:       45,
+		ScoreDelta:       15,
+		AssessmentBefore: "Low Suspicion",
+		AssessmentAfter:  "Moderate Suspicion",
+		NewlyFiring:      []string{"emoji_pattern_analysis"},
+		Added:            []analysis.DetectionKey{{Strategy: "emoji_pattern_analysis", Commit: "0123456789ab"}},
+		Commits: []analysis.CommitChange{
+			{Commit: "0123456789ab", Status: analysis.CommitNewlyFlagged, ScoreAfter: 0.5, Added: []string{"emoji_pattern_analysis"}},
+		},
+	})
+
+	for _, want := range []string{
+		"Score: 30.0 -> 45.0 (+15.0)",
+		"Assessment: Low Suspicion -> Moderate Suspicion",
+		"Newly firing: emoji_pattern_analysis",
+		"01234567 new      0.00 -> 0.50  +emoji_pattern_analysis",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, b.String())
+		}
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	// Register AI providers
 	_ "github.com/TryCadence/Cadence/internal/ai/providers/anthropic"
+	_ "github.com/TryCadence/Cadence/internal/ai/providers/gemini"
+	_ "github.com/TryCadence/Cadence/internal/ai/providers/ollama"
 	_ "github.com/TryCadence/Cadence/internal/ai/providers/openai"
 
 	"github.com/spf13/cobra"
@@ -38,5 +40,5 @@ Use 'cadence --help' to see all available commands.`,
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file path")
-	rootCmd.AddCommand(analyzeCmd, webCmd, configCmd, versionCmd, webhookCmd)
+	rootCmd.AddCommand(analyzeCmd, webCmd, monitorCmd, diffCmd, configCmd, versionCmd, webhookCmd, selftestCmd)
 }
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
+	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/logging"
+	"github.com/TryCadence/Cadence/internal/monitor"
+	"github.com/TryCadence/Cadence/internal/reporter"
+	"github.com/TryCadence/Cadence/internal/webhook"
+)
+
+var (
+	monitorURLsFile string
+	monitorInterval time.Duration
+	monitorOnce     bool
+	monitorJSON     bool
+)
+
+var monitorCmd = &cobra.Command{
+	Use:   "monitor",
+	Short: "Watch websites and re-analyze pages when their content changes",
+	Long: `Fetch each website in a URLs file on an interval and analyze a page only
+when its main text has changed since the last check.
+
+The hash of each page's extracted text and the last verdict are kept in the
+configured job store (webhook.job_store), so use the sqlite store to remember
+pages across runs. Reports are printed only for pages that changed; a summary
+of each round is written to stderr.
+
+The URLs file is a JSON list of strings, or one URL per line with blank lines
+and # comments ignored.
+
+Examples:
+  # Check every hour until interrupted
+  cadence monitor --urls-file sites.txt
+
+  # Check every 15 minutes with JSON reports
+  cadence monitor --urls-file sites.txt --interval 15m --json
+
+  # Check once, e.g. from cron
+  cadence monitor --urls-file sites.txt --once`,
+	Args: cobra.NoArgs,
+	RunE: runMonitor,
+}
+
+func init() {
+	monitorCmd.Flags().StringVar(&monitorURLsFile, "urls-file", "", "file listing the website URLs to monitor")
+	monitorCmd.Flags().DurationVar(&monitorInterval, "interval", time.Hour, "time between checks")
+	monitorCmd.Flags().BoolVar(&monitorOnce, "once", false, "check every URL once and exit")
+	monitorCmd.Flags().BoolVarP(&monitorJSON, "json", "j", false, "output reports in JSON format")
+	_ = monitorCmd.MarkFlagRequired("urls-file")
+}
+
+func runMonitor(cmd *cobra.Command, args []string) error {
+	if monitorInterval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	urls, err := readListFile(monitorURLsFile, "URLs", "URLs")
+	if err != nil {
+		return err
+	}
+
+	cfgPath := configFile
+	if cfgPath == "" {
+		if _, err := os.Stat("cadence.yml"); err == nil {
+			cfgPath = "cadence.yml"
+		}
+	}
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	logging.SetDefault(cfg.Logging.Logger())
+
+	store, err := webhook.OpenJobStore(cfg.Webhook.JobStore, cfg.Webhook.JobStorePath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+	states, ok := store.(monitor.Store)
+	if !ok {
+		return fmt.Errorf("job store %q cannot keep monitor states", cfg.Webhook.JobStore)
+	}
+	if monitorOnce && isMemoryJobStore(cfg.Webhook.JobStore) {
+		fmt.Fprintln(os.Stderr, "Warning: the memory job store forgets page hashes on exit, so every page is reported as changed; "+
+			"set webhook.job_store to sqlite to compare with the previous run")
+	}
+
+	outputFormat := "text"
+	if monitorJSON {
+		outputFormat = "json"
+	}
+	formatter, err := reporter.NewAnalysisFormatter(outputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to create formatter: %w", err)
+	}
+	formatter = outputFormatter(formatter, cfg)
+
+	fetcher := web.NewFetcher(30*time.Second, cfg.Web.FetcherOptions()...)
+	m := monitor.New(states, fetcher, newWebDetector(cfg))
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if monitorOnce {
+		results := m.CheckAll(ctx, urls)
+		if failed := printMonitorRound(formatter, results); failed > 0 {
+			return fmt.Errorf("%d of %d URLs failed to check", failed, len(results))
+		}
+		return nil
+	}
+
+	fmt.Fprintf(os.Stderr, "Monitoring %d URLs every %s\n", len(urls), monitorInterval)
+	m.Run(ctx, urls, monitorInterval, func(results []monitor.Result) {
+		printMonitorRound(formatter, results)
+	})
+	return nil
+}
+
+// isMemoryJobStore reports whether kind selects the in-memory job store.
+func isMemoryJobStore(kind string) bool {
+	return kind == "" || kind == webhook.JobStoreMemory
+}
+
+// printMonitorRound prints the reports of the pages that changed, reports
+// failures and a summary on stderr, and returns the number of failures.
+func printMonitorRound(formatter reporter.AnalysisFormatter, results []monitor.Result) int {
+	var changed, failed int
+	for _, r := range results {
+		switch {
+		case r.Err != nil:
+			failed++
+			fmt.Fprintf(os.Stderr, "Error: %v\n", r.Err)
+		case r.Changed:
+			changed++
+			out, err := formatter.FormatAnalysis(r.Report)
+			if err != nil {
+				failed++
+				fmt.Fprintf(os.Stderr, "Error: failed to format report for %s: %v\n", r.URL, err)
+				continue
+			}
+			fmt.Println(out)
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Checked %d URLs at %s: %d changed, %d unchanged, %d failed\n",
+		len(results), time.Now().Format(time.RFC3339), changed, len(results)-changed-failed, failed)
+	return failed
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMonitorCommandFlags(t *testing.T) {
+	for _, name := range []string{"urls-file", "interval", "once", "json"} {
+		if monitorCmd.Flags().Lookup(name) == nil {
+			t.Errorf("flag %q not found", name)
+		}
+	}
+	if got := monitorCmd.Flags().Lookup("interval").DefValue; got != time.Hour.String() {
+		t.Errorf("default interval = %s, want %s", got, time.Hour)
+	}
+	if monitorCmd.RunE == nil {
+		t.Error("expected RunE function, got nil")
+	}
+}
+
+func TestIsMemoryJobStore(t *testing.T) {
+	for kind, want := range map[string]bool{"": true, "memory": true, "sqlite": false} {
+		if got := isMemoryJobStore(kind); got != want {
+			t.Errorf("isMemoryJobStore(%q) = %v, want %v", kind, got, want)
+		}
+	}
+}
+
+func TestReadListFile_URLs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sites.txt")
+	if err := os.WriteFile(path, []byte("# sites\nhttps://a.example/\n\nhttps://b.example/\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	urls, err := readListFile(path, "URLs", "URLs")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"https://a.example/", "https://b.example/"}; !reflect.DeepEqual(urls, want) {
+		t.Errorf("urls = %q, want %q", urls, want)
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(empty, []byte("# none\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readListFile(empty, "URLs", "URLs"); err == nil || err.Error() != "URLs file "+empty+" lists no URLs" {
+		t.Errorf("error = %v, want the empty URLs file to be named", err)
+	}
+}
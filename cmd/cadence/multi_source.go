@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/reporter"
+)
+
+// runMultiSourceAnalyze analyzes a repository and a website and writes one
+// merged report, scored with the multi_source weights.
+func runMultiSourceAnalyze(cmd *cobra.Command, repoArg, siteURL string) error {
+	if analyzeExplain || analyzeBaselineFile != "" {
+		return fmt.Errorf("--explain and --baseline-file analyze a repository alone and cannot be used with --site")
+	}
+
+	outputFormat, err := resolveOutputFormat(analyzeFormat, cmd.Flags().Changed("format"), analyzeOutput)
+	if err != nil {
+		return err
+	}
+	if err := checkBinaryOutput(outputFormat, analyzeOutput, stdoutIsTerminal()); err != nil {
+		return err
+	}
+	formatter, err := reporter.NewAnalysisFormatter(outputFormat)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadAnalyzeConfig(cmd)
+	if err != nil {
+		return err
+	}
+	formatter = outputFormatter(formatter, cfg)
+
+	gitSource, gitDetector, cleanup, err := prepareRepository(cfg, repoArg, nil)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	fmt.Fprintf(os.Stderr, "Analyzing repository %s and website %s...\n", repoArg, siteURL)
+	report, err := analysis.RunMultiSource(context.Background(), analysis.NewDefaultDetectionRunner(), cfg.MultiSource.Weights(),
+		analysis.SourceAnalysis{Source: gitSource, Detectors: []analysis.Detector{gitDetector}},
+		analysis.SourceAnalysis{Source: newWebSource(cfg, siteURL, false), Detectors: []analysis.Detector{newWebDetector(cfg)}},
+	)
+	if err != nil {
+		return fmt.Errorf("analysis failed: %w", err)
+	}
+
+	if cfg.AI.Enabled && report.DetectionCount > 0 {
+		fmt.Fprintf(os.Stderr, "Performing AI analysis on %d detections...\n", report.DetectionCount)
+		if err := performAIAnalysisUnified(report, &cfg.AI); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: AI analysis failed: %v\n", err)
+		}
+	}
+
+	reportStr, err := formatter.FormatAnalysis(report)
+	if err != nil {
+		return fmt.Errorf("failed to format report: %w", err)
+	}
+	return writeAnalyzeOutput(reportStr, outputFormat)
+}
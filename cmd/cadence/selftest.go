@@ -0,0 +1,136 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TryCadence/Cadence/internal/calibration"
+	"github.com/TryCadence/Cadence/internal/config"
+)
+
+var (
+	selftestMinPrecision float64
+	selftestMinRecall    float64
+	selftestJSON         bool
+)
+
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check detection accuracy against bundled fixtures",
+	Long: `Run the full detector pipeline against bundled known-AI and known-human
+fixtures and report precision and recall at the currently configured thresholds.
+
+The results are compared with Cadence's built-in defaults so a config change
+that degrades accuracy is flagged. Exits non-zero when precision or recall fall
+below the configured minimums.`,
+	Args: cobra.NoArgs,
+	RunE: runSelftest,
+}
+
+func init() {
+	selftestCmd.Flags().Float64Var(&selftestMinPrecision, "min-precision", 0.75, "fail if precision falls below this value")
+	selftestCmd.Flags().Float64Var(&selftestMinRecall, "min-recall", 0.75, "fail if recall falls below this value")
+	selftestCmd.Flags().BoolVar(&selftestJSON, "json", false, "print results as JSON")
+}
+
+type selftestOutput struct {
+	Precision         float64             `json:"precision"`
+	Recall            float64             `json:"recall"`
+	Accuracy          float64             `json:"accuracy"`
+	BaselinePrecision float64             `json:"baseline_precision"`
+	BaselineRecall    float64             `json:"baseline_recall"`
+	Degraded          bool                `json:"degraded"`
+	Result            *calibration.Result `json:"result"`
+}
+
+func runSelftest(cmd *cobra.Command, args []string) error {
+	cfgPath := configFile
+	if cfgPath == "" {
+		if _, err := os.Stat("cadence.yml"); err == nil {
+			cfgPath = "cadence.yml"
+		}
+	}
+
+	cfg, err := config.Load(cfgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	defaults, err := config.Load("")
+	if err != nil {
+		return fmt.Errorf("failed to load default config: %w", err)
+	}
+
+	ctx := context.Background()
+
+	current, err := calibration.Run(ctx, calibration.Options{
+		Thresholds:          &cfg.Thresholds,
+		Strategies:          &cfg.Strategies,
+		DependencyManifests: cfg.DependencyManifests,
+		AIAssistants:        cfg.AIAssistants,
+		NGramRepetition:     cfg.NGramRepetition,
+		Churn:               cfg.Churn,
+		Baseline:            cfg.Baseline,
+		WebVocabulary:       cfg.Web.Vocabulary,
+	})
+	if err != nil {
+		return fmt.Errorf("selftest failed: %w", err)
+	}
+
+	baseline, err := calibration.Run(ctx, calibration.Options{Thresholds: &defaults.Thresholds})
+	if err != nil {
+		return fmt.Errorf("selftest baseline failed: %w", err)
+	}
+
+	out := selftestOutput{
+		Precision:         current.Precision(),
+		Recall:            current.Recall(),
+		Accuracy:          current.Accuracy(),
+		BaselinePrecision: baseline.Precision(),
+		BaselineRecall:    baseline.Recall(),
+		Result:            current,
+	}
+	out.Degraded = out.Precision < out.BaselinePrecision || out.Recall < out.BaselineRecall
+
+	if selftestJSON {
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		printSelftest(out)
+	}
+
+	if out.Precision < selftestMinPrecision || out.Recall < selftestMinRecall {
+		return fmt.Errorf("selftest below minimum accuracy: precision %.2f (min %.2f), recall %.2f (min %.2f)",
+			out.Precision, selftestMinPrecision, out.Recall, selftestMinRecall)
+	}
+
+	return nil
+}
+
+func printSelftest(out selftestOutput) {
+	fmt.Printf("%-24s %-5s %-6s %-10s %s\n", "FIXTURE", "KIND", "LABEL", "PREDICTED", "FLAGGED")
+	for _, s := range out.Result.Samples {
+		mark := "ok"
+		if !s.Correct {
+			mark = "MISS"
+		}
+		fmt.Printf("%-24s %-5s %-6s %-10s %d/%d (%.0f%%) %s\n",
+			s.Name, s.Kind, s.Label, s.Predicted, s.Flagged, s.Total, s.Score*100, mark)
+	}
+
+	fmt.Println()
+	fmt.Printf("Precision: %.2f (defaults: %.2f)\n", out.Precision, out.BaselinePrecision)
+	fmt.Printf("Recall:    %.2f (defaults: %.2f)\n", out.Recall, out.BaselineRecall)
+	fmt.Printf("Accuracy:  %.2f\n", out.Accuracy)
+
+	if out.Degraded {
+		fmt.Println("\nWarning: accuracy is worse than with the built-in defaults - a recent config change may have degraded detection.")
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/detectors"
+	"github.com/TryCadence/Cadence/internal/config"
+)
+
+// validateStabilityFlags checks --stability-runs and --stability-jitter and
+// rejects the modes they do not apply to.
+func validateStabilityFlags() error {
+	if analyzeStabilityRuns < 2 {
+		return fmt.Errorf("--stability-runs must be at least 2")
+	}
+	if analyzeStabilityJitter <= 0 || analyzeStabilityJitter >= 1 {
+		return fmt.Errorf("--stability-jitter must be between 0 and 1")
+	}
+	if analyzeReposFile != "" || analyzeSite != "" || analyzeExplain {
+		return fmt.Errorf("--stability-runs cannot be used with --repos-file, --site or --explain")
+	}
+	return nil
+}
+
+// analyzeRepositoryStability is analyzeRepository followed by
+// --stability-runs reruns with the thresholds scaled across
+// ±--stability-jitter. It prints the verdict spread to stderr; the report
+// is the unperturbed run's.
+func analyzeRepositoryStability(ctx context.Context, cfg *config.Config, repoArg string, historical *analysis.RepositoryBaseline) (*analysis.AnalysisReport, *detectors.GitDetector, error) {
+	source, gitDetector, cleanup, err := prepareRepository(cfg, repoArg, historical)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cleanup()
+
+	fmt.Fprintf(os.Stderr, "Analyzing repository %s (%d stability runs)...\n", repoArg, analyzeStabilityRuns)
+	report, result, err := analysis.CheckStability(ctx, source, analyzeStabilityRuns, analyzeStabilityJitter, func(factor float64) analysis.Detector {
+		if factor == 1 {
+			return gitDetector
+		}
+		rerun := *gitDetector
+		rerun.Thresholds = gitDetector.Thresholds.Scaled(factor)
+		return &rerun
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("analysis failed: %w", err)
+	}
+	printStability(os.Stderr, result)
+	return report, gitDetector, nil
+}
+
+// printStability summarizes a stability check: how many runs kept the
+// verdict, what the others concluded, and the score range.
+func printStability(w io.Writer, r *analysis.StabilityResult) {
+	fmt.Fprintf(w, "Verdict stability: %.0f%% of %d runs with thresholds within ±%.0f%% agree on %q (score %.1f-%.1f)\n",
+		r.Score*100, r.Runs, r.Jitter*100, r.Verdict, r.MinScore, r.MaxScore)
+
+	others := make([]string, 0, len(r.Verdicts))
+	for verdict := range r.Verdicts {
+		if verdict != r.Verdict {
+			others = append(others, verdict)
+		}
+	}
+	if len(others) == 0 {
+		return
+	}
+	sort.Strings(others)
+	for i, verdict := range others {
+		others[i] = fmt.Sprintf("%q in %d", verdict, r.Verdicts[verdict])
+	}
+	fmt.Fprintf(w, "  Other verdicts: %s; the result sits close to a classification boundary\n", strings.Join(others, ", "))
+}
@@ -2,10 +2,43 @@ package main
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/reporter"
 )
 
+// resolveOutputFormat picks the report format for analyze: an explicit
+// --format always wins, otherwise it follows the --output extension, and
+// reports printed to stdout default to text.
+func resolveOutputFormat(format string, formatSet bool, output string) (string, error) {
+	if formatSet {
+		return strings.ToLower(strings.TrimSpace(format)), nil
+	}
+	if output == "" {
+		return "text", nil
+	}
+	return detectFormatFromExtension(output)
+}
+
+// checkBinaryOutput refuses to print a binary report format (bson) to a
+// terminal. It can still be written with --output or piped to another program.
+func checkBinaryOutput(format, output string, stdoutIsTerminal bool) error {
+	if format != "bson" || output != "" || !stdoutIsTerminal {
+		return nil
+	}
+	return fmt.Errorf("the bson format is binary: write it to a file with --output or pipe stdout to another program")
+}
+
+// stdoutIsTerminal reports whether stdout is a character device such as a
+// terminal rather than a file or pipe.
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 func detectFormatFromExtension(filePath string) (string, error) {
 	ext := strings.ToLower(filepath.Ext(filePath))
 
@@ -14,9 +47,29 @@ func detectFormatFromExtension(filePath string) (string, error) {
 		return "json", nil
 	case ".txt", ".text":
 		return "text", nil
+	case ".html", ".htm":
+		return "html", nil
+	case ".md", ".markdown":
+		return "markdown", nil
+	case ".yaml", ".yml":
+		return "yaml", nil
+	case ".csv":
+		return "csv", nil
+	case ".sarif":
+		return "sarif", nil
+	case ".slack":
+		return "slack", nil
 	case "":
 		return "text", nil
 	default:
 		return "", fmt.Errorf("unsupported file extension: %s", ext)
 	}
 }
+
+// outputFormatter applies the output settings of cfg to formatter: the
+// report.max_examples and report.redact example policy and the
+// assessment_labels wording.
+func outputFormatter(formatter reporter.AnalysisFormatter, cfg *config.Config) reporter.AnalysisFormatter {
+	formatter = reporter.WithExamplePolicy(formatter, cfg.Report.ExamplePolicy())
+	return reporter.WithAssessmentLabels(formatter, cfg.AssessmentLabels)
+}
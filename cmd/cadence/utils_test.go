@@ -42,10 +42,34 @@ func TestDetectFormatFromExtension(t *testing.T) {
 			shouldError: false,
 		},
 		{
-			filePath:      "report.csv",
-			expected:      "",
-			shouldError:   true,
-			errorContains: "unsupported file extension",
+			filePath:    "report.csv",
+			expected:    "csv",
+			shouldError: false,
+		},
+		{
+			filePath:    "results.sarif",
+			expected:    "sarif",
+			shouldError: false,
+		},
+		{
+			filePath:    "report.html",
+			expected:    "html",
+			shouldError: false,
+		},
+		{
+			filePath:    "report.md",
+			expected:    "markdown",
+			shouldError: false,
+		},
+		{
+			filePath:    "report.yml",
+			expected:    "yaml",
+			shouldError: false,
+		},
+		{
+			filePath:    "summary.slack",
+			expected:    "slack",
+			shouldError: false,
 		},
 		{
 			filePath:      "report.pdf",
@@ -102,6 +126,61 @@ func TestDetectFormatFromExtension(t *testing.T) {
 	}
 }
 
+func TestResolveOutputFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		formatSet bool
+		output    string
+		expected  string
+	}{
+		{name: "stdout defaults to text", expected: "text"},
+		{name: "follows output extension", output: "report.json", expected: "json"},
+		{name: "flag overrides extension", format: "sarif", formatSet: true, output: "report.json", expected: "sarif"},
+		{name: "flag is normalized", format: " Markdown ", formatSet: true, expected: "markdown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveOutputFormat(tt.format, tt.formatSet, tt.output)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("expected format %q, got %q", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := resolveOutputFormat("", false, "report.pdf"); err == nil {
+		t.Error("expected error for unknown output extension")
+	}
+}
+
+func TestCheckBinaryOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		output   string
+		terminal bool
+		wantErr  bool
+	}{
+		{name: "bson to a terminal", format: "bson", terminal: true, wantErr: true},
+		{name: "bson to a pipe", format: "bson"},
+		{name: "bson to a file", format: "bson", output: "report.bson", terminal: true},
+		{name: "text to a terminal", format: "text", terminal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkBinaryOutput(tt.format, tt.output, tt.terminal)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("checkBinaryOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestIsRemoteRepo(t *testing.T) {
 	tests := []struct {
 		path     string
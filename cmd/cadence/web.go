@@ -9,9 +9,11 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
 	"github.com/TryCadence/Cadence/internal/analysis/detectors"
 	"github.com/TryCadence/Cadence/internal/analysis/sources"
 	"github.com/TryCadence/Cadence/internal/config"
+	"github.com/TryCadence/Cadence/internal/logging"
 	"github.com/TryCadence/Cadence/internal/reporter"
 )
 
@@ -20,6 +22,7 @@ var (
 	verbose    bool
 	outputFile string
 	jsonFormat bool
+	renderJS   bool
 )
 
 var webCmd = &cobra.Command{
@@ -51,7 +54,10 @@ Examples:
   cadence web https://example.com --json --output report.json
 
   # Verbose output with content quality metrics
-  cadence web https://example.com --verbose`,
+  cadence web https://example.com --verbose
+
+  # Client-side rendered site (binary built with -tags chromedp)
+  cadence web https://app.example.com --render-js`,
 	Args: cobra.ExactArgs(1),
 	RunE: runWebAnalyze,
 }
@@ -61,6 +67,7 @@ func init() {
 	webCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "show detailed analysis information")
 	webCmd.Flags().StringVarP(&outputFile, "output", "o", "", "write report to file (saved in reports/ directory)")
 	webCmd.Flags().BoolVarP(&jsonFormat, "json", "j", false, "output in JSON format")
+	webCmd.Flags().BoolVar(&renderJS, "render-js", false, "render the page in headless Chrome before analysis (requires a build with -tags chromedp)")
 }
 
 func runWebAnalyze(cmd *cobra.Command, args []string) error {
@@ -68,8 +75,22 @@ func runWebAnalyze(cmd *cobra.Command, args []string) error {
 
 	fmt.Fprintf(os.Stderr, "Analyzing website content from %s...\n", url)
 
-	source := sources.NewWebsiteSource(url)
-	webDetector := detectors.NewWebDetector()
+	cfgPath := configFile
+	if cfgPath == "" {
+		if _, err := os.Stat("cadence.yml"); err == nil {
+			cfgPath = "cadence.yml"
+		}
+	}
+
+	cfg, cfgErr := config.Load(cfgPath)
+
+	if cfgErr != nil {
+		cfg = nil
+	} else {
+		logging.SetDefault(cfg.Logging.Logger())
+	}
+	source := newWebSource(cfg, url, renderJS)
+	webDetector := newWebDetector(cfg)
 	runner := analysis.NewDefaultDetectionRunner()
 
 	report, err := runner.Run(context.Background(), source, webDetector)
@@ -81,15 +102,7 @@ func runWebAnalyze(cmd *cobra.Command, args []string) error {
 		fmt.Fprintf(os.Stderr, "Analysis complete: %d detections found\n", report.DetectionCount)
 	}
 
-	cfgPath := configFile
-	if cfgPath == "" {
-		if _, err := os.Stat("cadence.yml"); err == nil {
-			cfgPath = "cadence.yml"
-		}
-	}
-
-	cfg, err := config.Load(cfgPath)
-	if err == nil && cfg.AI.Enabled && report.DetectionCount > 0 {
+	if cfgErr == nil && cfg.AI.Enabled && report.DetectionCount > 0 {
 		fmt.Fprintf(os.Stderr, "Performing AI analysis...\n")
 		if err := performAIAnalysisUnified(report, &cfg.AI); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: AI analysis failed: %v\n", err)
@@ -105,6 +118,9 @@ func runWebAnalyze(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to create formatter: %w", err)
 	}
+	if cfg != nil {
+		formatter = outputFormatter(formatter, cfg)
+	}
 
 	reportStr, err := formatter.FormatAnalysis(report)
 	if err != nil {
@@ -128,3 +144,30 @@ func runWebAnalyze(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// newWebSource creates the source for a website, fetched with the HTTP
+// settings from cfg (if any) and rendered in headless Chrome first when
+// renderJS is set.
+func newWebSource(cfg *config.Config, url string, renderJS bool) *sources.WebsiteSource {
+	source := sources.NewWebsiteSource(url)
+	if cfg != nil {
+		source.FetcherOptions = append(source.FetcherOptions, cfg.Web.FetcherOptions()...)
+	}
+	if renderJS {
+		source.FetcherOptions = append(source.FetcherOptions, web.WithRenderJS(true))
+	}
+	return source
+}
+
+// newWebDetector creates the web detector with the settings from cfg, or the
+// defaults when cfg is nil.
+func newWebDetector(cfg *config.Config) *detectors.WebDetector {
+	webDetector := detectors.NewWebDetector()
+	if cfg != nil {
+		webDetector.NGramMaxCoverage = cfg.NGramRepetition.WebMaxCoverage
+		webDetector.MinWordCount = cfg.Web.MinWordCount
+		webDetector.Vocabulary = cfg.Web.Vocabulary
+		webDetector.MinReportConfidence = cfg.Analysis.MinReportConfidence
+	}
+	return webDetector
+}
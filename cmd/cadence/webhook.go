@@ -1,9 +1,14 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
+	"github.com/TryCadence/Cadence/internal/analysis"
 	"github.com/TryCadence/Cadence/internal/config"
 	"github.com/TryCadence/Cadence/internal/logging"
 	"github.com/TryCadence/Cadence/internal/webhook"
@@ -31,6 +36,13 @@ var webhookFlags struct {
 	maxWorkers   int
 	readTimeout  int
 	writeTimeout int
+	recordEvents bool
+	keepParams   bool
+	cloneTimeout int
+	cloneDepth   int
+	jobStore     string
+	jobStorePath string
+	drainTimeout int
 }
 
 func init() {
@@ -40,6 +52,13 @@ func init() {
 	webhookCmd.Flags().IntVar(&webhookFlags.maxWorkers, "workers", 0, "number of concurrent workers (default: 4)")
 	webhookCmd.Flags().IntVar(&webhookFlags.readTimeout, "read-timeout", 0, "request read timeout in seconds (default: 30)")
 	webhookCmd.Flags().IntVar(&webhookFlags.writeTimeout, "write-timeout", 0, "request write timeout in seconds (default: 30)")
+	webhookCmd.Flags().BoolVar(&webhookFlags.keepParams, "keep-tracking-params", false, "keep utm_* and similar query params when normalizing URLs for caching/dedup")
+	webhookCmd.Flags().IntVar(&webhookFlags.cloneTimeout, "clone-timeout", 0, "repository clone timeout in seconds (default: 120)")
+	webhookCmd.Flags().IntVar(&webhookFlags.cloneDepth, "clone-depth", 0, "shallow-clone repositories to this many commits (default: full history)")
+	webhookCmd.Flags().StringVar(&webhookFlags.jobStore, "job-store", "", "job store backend: memory or sqlite (default: memory)")
+	webhookCmd.Flags().StringVar(&webhookFlags.jobStorePath, "job-store-path", "", "SQLite job store file (default: cadence-jobs.db)")
+	webhookCmd.Flags().IntVar(&webhookFlags.drainTimeout, "shutdown-timeout", 0, "seconds to let running jobs finish after SIGINT/SIGTERM (default: 30)")
+	webhookCmd.Flags().BoolVar(&webhookFlags.recordEvents, "record-events", false, "record SSE events of streaming analyses for replay via /jobs/:id/events")
 }
 
 func runWebhookServer(cmd *cobra.Command, args []string) error {
@@ -48,6 +67,8 @@ func runWebhookServer(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	logger := cfg.Logging.Logger()
+	logging.SetDefault(logger)
 
 	// Override config with CLI flags
 	webhookCfg := cfg.Webhook
@@ -69,6 +90,27 @@ func runWebhookServer(cmd *cobra.Command, args []string) error {
 	if webhookFlags.writeTimeout > 0 {
 		webhookCfg.WriteTimeout = webhookFlags.writeTimeout
 	}
+	if webhookFlags.recordEvents {
+		webhookCfg.RecordEvents = true
+	}
+	if webhookFlags.keepParams {
+		webhookCfg.StripTrackingParams = false
+	}
+	if webhookFlags.cloneTimeout > 0 {
+		webhookCfg.CloneTimeout = webhookFlags.cloneTimeout
+	}
+	if webhookFlags.cloneDepth > 0 {
+		webhookCfg.CloneDepth = webhookFlags.cloneDepth
+	}
+	if webhookFlags.jobStore != "" {
+		webhookCfg.JobStore = webhookFlags.jobStore
+	}
+	if webhookFlags.jobStorePath != "" {
+		webhookCfg.JobStorePath = webhookFlags.jobStorePath
+	}
+	if webhookFlags.drainTimeout > 0 {
+		webhookCfg.ShutdownTimeout = webhookFlags.drainTimeout
+	}
 
 	// Validate configuration
 	if webhookCfg.Secret == "" {
@@ -77,18 +119,112 @@ func runWebhookServer(cmd *cobra.Command, args []string) error {
 
 	// Create server configuration
 	serverCfg := &webhook.ServerConfig{
+		Logger:        logger,
 		Host:          webhookCfg.Host,
 		Port:          webhookCfg.Port,
 		WebhookSecret: webhookCfg.Secret,
+		APIToken:      webhookCfg.APIToken,
 		MaxWorkers:    webhookCfg.MaxWorkers,
 		ReadTimeout:   time.Duration(webhookCfg.ReadTimeout) * time.Second,
 		WriteTimeout:  time.Duration(webhookCfg.WriteTimeout) * time.Second,
+
+		RecordStreamEvents: webhookCfg.RecordEvents,
+		StreamResumeGrace:  time.Duration(webhookCfg.StreamResumeGrace) * time.Second,
+		DisableCompression: !webhookCfg.CompressResponses,
+		KeepTrackingParams: !webhookCfg.StripTrackingParams,
+		DisableJobDedup:    !webhookCfg.DedupJobs,
+
+		MaxConcurrentClones: webhookCfg.MaxConcurrentClones,
+
+		CallbackMaxAttempts: webhookCfg.CallbackMaxAttempts,
+		JobStore:            webhookCfg.JobStore,
+		JobStorePath:        webhookCfg.JobStorePath,
+		RateLimitPerMinute:  cfg.RateLimit.RequestsPerMinute,
+		RateLimitBurst:      cfg.RateLimit.Burst,
+		ReadyMaxQueueDepth:  cfg.Webhook.ReadyMaxQueueDepth,
+		DisableCache:        !cfg.Cache.Enabled,
+		CacheMaxEntries:     cfg.Cache.MaxEntries,
+		CacheTTL:            time.Duration(cfg.Cache.TTLSeconds) * time.Second,
+		PluginManifest:      cfg.Webhook.PluginManifest,
+		WASMPluginDir:       cfg.Webhook.WASMPluginDir,
+		WASMPluginOptions: analysis.WASMOptions{
+			Timeout:       time.Duration(cfg.Webhook.WASMPluginTimeout) * time.Second,
+			MemoryLimitMB: cfg.Webhook.WASMPluginMemoryMB,
+		},
+		AIPricing: cfg.AI.Pricing,
+		Branches: webhook.BranchFilter{
+			Include: webhookCfg.Branches.Include,
+			Exclude: webhookCfg.Branches.Exclude,
+		},
+		AllowedGitHosts: webhookCfg.AllowedGitHosts,
+	}
+
+	// The processor reads 0 as "use the default" and negative as "no limit".
+	maxCommits := cfg.Analysis.MaxCommits
+	if maxCommits == 0 {
+		maxCommits = -1
 	}
 
 	// Create analysis processor
 	processor := &webhook.AnalysisProcessor{
 		DetectorThresholds: &cfg.Thresholds,
-		Logger:             logging.Default().With("component", "processor"),
+		Logger:             logger.With("component", "processor"),
+		Clone: webhook.CloneOptions{
+			Timeout:      time.Duration(webhookCfg.CloneTimeout) * time.Second,
+			Depth:        webhookCfg.CloneDepth,
+			SingleBranch: webhookCfg.CloneSingleBranch,
+		},
+		Classification: webhook.ClassificationThresholds{
+			High:   cfg.Classification.High,
+			Medium: cfg.Classification.Medium,
+			Labels: cfg.AssessmentLabels,
+		},
+		AllowPrivateHosts: webhookCfg.AllowPrivateHosts,
+		AllowLocalPaths:   webhookCfg.AllowLocalPaths,
+		LocalPathRoot:     webhookCfg.LocalPathRoot,
+		MaxCommits:        maxCommits,
+		MaxDiffBytes:      cfg.Analysis.MaxDiffBytes,
+		DiffWorkers:       cfg.Analysis.DiffWorkers,
+		MergeStrategy:     cfg.Analysis.MergeStrategy,
+		AuthorIdentity:    &cfg.AuthorIdentity,
+		SourceFilters: webhook.GitSourceFilters{
+			ExcludeFiles:        cfg.ExcludeFiles,
+			GeneratedFiles:      cfg.GeneratedFiles.Patterns,
+			CountGeneratedFiles: !cfg.GeneratedFiles.Enabled,
+			IgnoreAuthors:       cfg.IgnoreAuthors,
+		},
+		StrategyOptions: webhook.GitStrategyOptions{
+			DisabledStrategies:  cfg.Strategies.DisabledStrategies,
+			DependencyManifests: cfg.DependencyManifests,
+			AIAssistants:        cfg.AIAssistants,
+			NGramMaxCoverage:    cfg.NGramRepetition.GitMaxCoverage,
+			Churn:               cfg.Churn.Options(),
+			LanguageProfiles:    cfg.LanguageProfiles,
+			SampleSizeGate:      cfg.Baseline.Gate(),
+		},
+		MinWordCount:        cfg.Web.MinWordCount,
+		Vocabulary:          cfg.Web.Vocabulary,
+		FetcherOptions:      cfg.Web.FetcherOptions(),
+		MinReportConfidence: cfg.Analysis.MinReportConfidence,
+		ExamplePolicy:       cfg.Report.ExamplePolicy(),
+		IncludePassed:       cfg.Analysis.IncludePassed,
+		AnalysisTimeout:     time.Duration(cfg.Analysis.TimeoutSeconds) * time.Second,
+		ConfigFingerprint:   cfg.Fingerprint(),
+		TriageLimit:         cfg.AI.TriageLimit,
+	}
+	if cfg.AI.Enabled {
+		aiAnalyzer, err := newAIAnalyzer(&cfg.AI)
+		if err != nil {
+			return err
+		}
+		processor.Analyzer = aiAnalyzer
+	}
+	if webhookCfg.RepoCacheDir != "" {
+		repoCache, err := webhook.NewRepoCache(webhookCfg.RepoCacheDir, int64(webhookCfg.RepoCacheMaxMB)<<20)
+		if err != nil {
+			return err
+		}
+		processor.RepoCache = repoCache
 	}
 
 	// Create and start server
@@ -97,12 +233,38 @@ func runWebhookServer(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to create webhook server: %w", err)
 	}
 
-	log := logging.Default().With("component", "webhook_cmd")
+	log := logger.With("component", "webhook_cmd")
 	log.Info("starting Cadence webhook server",
 		"host", webhookCfg.Host,
 		"port", webhookCfg.Port,
 		"workers", webhookCfg.MaxWorkers,
 	)
 
-	return server.Start()
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Start()
+	}()
+
+	sigCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-sigCtx.Done():
+	}
+	stop()
+
+	drainTimeout := time.Duration(webhookCfg.ShutdownTimeout) * time.Second
+	log.Info("shutting down, draining running jobs", "timeout", drainTimeout)
+	ctx, cancel := context.WithTimeout(context.Background(), drainTimeout)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		return fmt.Errorf("graceful shutdown failed: %w", err)
+	}
+	if err := <-serveErr; err != nil {
+		return err
+	}
+	log.Info("webhook server stopped")
+	return nil
 }
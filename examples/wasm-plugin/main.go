@@ -0,0 +1,108 @@
+//go:build wasip1
+
+// Command wasm-plugin is a minimal Cadence strategy plugin compiled to
+// WebAssembly. It flags sources whose content mentions "TODO" more than
+// three times. Build it with:
+//
+//	GOOS=wasip1 GOARCH=wasm go build -buildmode=c-shared -o todo.wasm ./examples/wasm-plugin
+//
+// and drop todo.wasm into the server's webhook.wasm_plugin_dir.
+//
+// A plugin module exports its memory and three functions. Buffers are
+// passed as a pointer and length into that memory; results come back
+// packed as ptr<<32 | len. The host instantiates the module afresh for
+// every call, so nothing needs to be freed.
+//
+//	alloc(size u32) u32       reserve size bytes for the host to write into
+//	info() u64                JSON strategy info: name, category, confidence, description, source_types
+//	detect(ptr, len u32) u64  JSON source {id, type, content, metadata} in, JSON detections out
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// buffers keeps the memory handed to the host reachable for the call.
+var buffers = map[uint32][]byte{}
+
+//go:wasmexport alloc
+func alloc(size uint32) uint32 {
+	buf := make([]byte, max(size, 1))
+	ptr := uint32(uintptr(unsafe.Pointer(&buf[0])))
+	buffers[ptr] = buf
+	return ptr
+}
+
+// result stores data in a fresh buffer and packs its location for the host.
+func result(data []byte) uint64 {
+	ptr := alloc(uint32(len(data)))
+	copy(buffers[ptr], data)
+	return uint64(ptr)<<32 | uint64(len(data))
+}
+
+type strategyInfo struct {
+	Name        string   `json:"name"`
+	Category    string   `json:"category"`
+	Confidence  float64  `json:"confidence"`
+	Description string   `json:"description"`
+	SourceTypes []string `json:"source_types"`
+}
+
+type sourceData struct {
+	ID       string          `json:"id"`
+	Type     string          `json:"type"`
+	Content  json.RawMessage `json:"content"`
+	Metadata map[string]any  `json:"metadata"`
+}
+
+type detection struct {
+	Strategy    string   `json:"strategy"`
+	Detected    bool     `json:"detected"`
+	Severity    string   `json:"severity"`
+	Score       float64  `json:"score"`
+	Confidence  float64  `json:"confidence"`
+	Category    string   `json:"category"`
+	Description string   `json:"description"`
+	Examples    []string `json:"examples,omitempty"`
+}
+
+//go:wasmexport info
+func info() uint64 {
+	data, _ := json.Marshal(strategyInfo{
+		Name:        "wasm_todo_markers",
+		Category:    "pattern",
+		Confidence:  0.3,
+		Description: "Detects content littered with TODO markers",
+		SourceTypes: []string{"git", "web"},
+	})
+	return result(data)
+}
+
+//go:wasmexport detect
+func detect(ptr, size uint32) uint64 {
+	var source sourceData
+	if err := json.Unmarshal(buffers[ptr][:size], &source); err != nil {
+		return result([]byte("[]"))
+	}
+
+	todos := strings.Count(string(source.Content), "TODO")
+	detections := []detection{}
+	if todos > 3 {
+		detections = append(detections, detection{
+			Strategy:    "wasm_todo_markers",
+			Detected:    true,
+			Severity:    "low",
+			Score:       min(float64(todos)/10, 1),
+			Confidence:  0.3,
+			Category:    "pattern",
+			Description: fmt.Sprintf("%d TODO markers in %s", todos, source.ID),
+		})
+	}
+	data, _ := json.Marshal(detections)
+	return result(data)
+}
+
+func main() {}
@@ -2,8 +2,12 @@ package ai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"strings"
+	"time"
 
 	"github.com/TryCadence/Cadence/internal/ai/prompts"
 )
@@ -11,11 +15,18 @@ import (
 type Analyzer interface {
 	AnalyzeSuspiciousCode(ctx context.Context, commitHash string, additions string) (string, error)
 
+	// AnalyzeCode returns the structured verdict behind AnalyzeSuspiciousCode.
+	AnalyzeCode(ctx context.Context, commitHash string, additions string) (*AnalysisResult, error)
+
 	AnalyzeWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error)
 
 	// RunSkill executes a named skill with the given input.
 	RunSkill(ctx context.Context, skillName string, input interface{}) (*SkillResult, error)
 
+	// RunSkillStream is RunSkill with onToken called for each fragment of
+	// the model's response as it is generated.
+	RunSkillStream(ctx context.Context, skillName string, input interface{}, onToken func(string)) (*SkillResult, error)
+
 	IsConfigured() bool
 
 	ProviderName() string
@@ -25,6 +36,7 @@ type DefaultAnalyzer struct {
 	provider    Provider
 	config      *Config
 	skillRunner *SkillRunner
+	usage       UsageRecorder
 }
 
 func NewDefaultAnalyzer(provider Provider, cfg *Config) *DefaultAnalyzer {
@@ -36,7 +48,7 @@ func NewDefaultAnalyzer(provider Provider, cfg *Config) *DefaultAnalyzer {
 }
 
 func NewAnalyzer(cfg *Config) (Analyzer, error) {
-	if !cfg.Enabled || cfg.APIKey == "" {
+	if !cfg.Enabled || (cfg.APIKey == "" && requiresAPIKey(cfg.Provider)) {
 		return &NoOpAnalyzer{}, nil
 	}
 
@@ -55,17 +67,40 @@ func NewAnalyzer(cfg *Config) (Analyzer, error) {
 	}, nil
 }
 
+// WithUsageRecorder makes the analyzer, including its skills, report the
+// tokens each call uses to recorder.
+func (a *DefaultAnalyzer) WithUsageRecorder(recorder UsageRecorder) *DefaultAnalyzer {
+	a.usage = recorder
+	a.skillRunner.usage = recorder
+	return a
+}
+
+// requiresAPIKey reports whether a provider needs an API key to be usable.
+// Local providers such as ollama run without one.
+func requiresAPIKey(provider string) bool {
+	return provider != "ollama"
+}
+
 func (a *DefaultAnalyzer) AnalyzeSuspiciousCode(ctx context.Context, commitHash, additions string) (string, error) {
 	result, err := a.analyzeWithReasoning(ctx, commitHash, additions)
 	if err != nil {
 		return "", err
 	}
+	return FormatAnalysisResult(result), nil
+}
+
+func (a *DefaultAnalyzer) AnalyzeCode(ctx context.Context, commitHash, additions string) (*AnalysisResult, error) {
+	return a.analyzeWithReasoning(ctx, commitHash, additions)
+}
 
+// FormatAnalysisResult renders a verdict as AnalyzeSuspiciousCode reports it:
+// the assessment, its confidence and any reasoning.
+func FormatAnalysisResult(result *AnalysisResult) string {
 	output := fmt.Sprintf("%s (confidence: %.0f%%)", result.Assessment, result.Confidence*100)
 	if result.Reasoning != "" {
 		output += fmt.Sprintf("\nReasoning: %s", result.Reasoning)
 	}
-	return output, nil
+	return output
 }
 
 func (a *DefaultAnalyzer) AnalyzeWithSystemPrompt(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
@@ -79,13 +114,101 @@ func (a *DefaultAnalyzer) AnalyzeWithSystemPrompt(ctx context.Context, systemPro
 		maxTokens = 1024
 	}
 
-	return a.provider.Complete(ctx, CompletionRequest{
+	raw, usage, _, err := completeWithRetry(ctx, a.provider, a.config, CompletionRequest{
 		SystemPrompt: systemPrompt,
 		UserPrompt:   userPrompt,
 		Model:        model,
 		MaxTokens:    maxTokens,
 		Temperature:  0.3,
 	})
+	recordUsage(a.usage, a.provider.Name(), model, usage)
+	return raw, err
+}
+
+// maxRetryDelay caps the exponential backoff between attempts.
+const maxRetryDelay = 30 * time.Second
+
+// completeWithRetry calls provider.Complete, retrying retryable failures with
+// exponential backoff (base, 2×base, 4×base, ...) up to cfg's retry limit. It
+// returns the response, the token usage the provider reported for it, how
+// many retries were needed, and the last error.
+func completeWithRetry(ctx context.Context, provider Provider, cfg *Config, req CompletionRequest) (string, Usage, int, error) {
+	var raw string
+	var usage Usage
+	retries, err := withRetry(ctx, cfg, func() error {
+		var err error
+		raw, usage, err = completeWithUsage(ctx, provider, req)
+		return err
+	})
+	if err != nil {
+		return "", Usage{}, retries, err
+	}
+	return raw, usage, retries, nil
+}
+
+// streamWithRetry is completeWithRetry for provider.CompleteStream. Only
+// starting the stream is retried; once tokens flow a failure ends it with an
+// error chunk.
+func streamWithRetry(ctx context.Context, provider Provider, cfg *Config, req CompletionRequest) (<-chan StreamChunk, int, error) {
+	var tokens <-chan StreamChunk
+	retries, err := withRetry(ctx, cfg, func() error {
+		var err error
+		tokens, err = provider.CompleteStream(ctx, req)
+		return err
+	})
+	return tokens, retries, err
+}
+
+// withRetry runs call until it succeeds, fails permanently, runs out of
+// retries or ctx is done, backing off exponentially between attempts. It
+// returns how many retries were needed and the last error.
+func withRetry(ctx context.Context, cfg *Config, call func() error) (int, error) {
+	maxRetries := cfg.maxRetries()
+	delay := cfg.retryBaseDelay()
+
+	for retries := 0; ; retries++ {
+		err := call()
+		if err == nil {
+			return retries, nil
+		}
+		if retries >= maxRetries || !isRetryable(ctx, err) {
+			return retries, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return retries, err
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if delay > maxRetryDelay {
+			delay = maxRetryDelay
+		}
+	}
+}
+
+// isRetryable reports whether a Complete error is transient: a rate limit,
+// request timeout or 5xx status, or a network timeout while ctx is still live.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode == http.StatusRequestTimeout ||
+			statusErr.StatusCode >= http.StatusInternalServerError
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
 func (a *DefaultAnalyzer) analyzeWithReasoning(ctx context.Context, commitHash, additions string) (*AnalysisResult, error) {
@@ -113,6 +236,10 @@ func (a *DefaultAnalyzer) RunSkill(ctx context.Context, skillName string, input
 	return a.skillRunner.RunByName(ctx, skillName, input)
 }
 
+func (a *DefaultAnalyzer) RunSkillStream(ctx context.Context, skillName string, input interface{}, onToken func(string)) (*SkillResult, error) {
+	return a.skillRunner.RunStreamByName(ctx, skillName, input, onToken)
+}
+
 func (a *DefaultAnalyzer) IsConfigured() bool {
 	return a.provider != nil && a.provider.IsAvailable()
 }
@@ -150,6 +277,10 @@ func (n *NoOpAnalyzer) AnalyzeSuspiciousCode(_ context.Context, _, _ string) (st
 	return "", nil
 }
 
+func (n *NoOpAnalyzer) AnalyzeCode(_ context.Context, _, _ string) (*AnalysisResult, error) {
+	return nil, nil
+}
+
 func (n *NoOpAnalyzer) AnalyzeWithSystemPrompt(_ context.Context, _, _ string) (string, error) {
 	return "", nil
 }
@@ -158,6 +289,10 @@ func (n *NoOpAnalyzer) RunSkill(_ context.Context, _ string, _ interface{}) (*Sk
 	return nil, fmt.Errorf("AI is not configured")
 }
 
+func (n *NoOpAnalyzer) RunSkillStream(_ context.Context, _ string, _ interface{}, _ func(string)) (*SkillResult, error) {
+	return nil, fmt.Errorf("AI is not configured")
+}
+
 func (n *NoOpAnalyzer) IsConfigured() bool {
 	return false
 }
@@ -14,6 +14,8 @@ type mockProvider struct {
 	defaultModel string
 	available    bool
 	response     string
+	chunks       []string // streamed by CompleteStream instead of response when set
+	streamErr    error    // ends the stream after chunks when set
 	err          error
 }
 
@@ -23,6 +25,23 @@ func (m *mockProvider) IsAvailable() bool    { return m.available }
 func (m *mockProvider) Complete(_ context.Context, _ CompletionRequest) (string, error) {
 	return m.response, m.err
 }
+func (m *mockProvider) CompleteStream(ctx context.Context, req CompletionRequest) (<-chan StreamChunk, error) {
+	if m.chunks == nil {
+		return CompleteAsStream(ctx, m, req)
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	tokens := make(chan StreamChunk, len(m.chunks)+1)
+	for _, c := range m.chunks {
+		tokens <- StreamChunk{Text: c}
+	}
+	if m.streamErr != nil {
+		tokens <- StreamChunk{Err: m.streamErr}
+	}
+	close(tokens)
+	return tokens, nil
+}
 
 func TestNewAnalyzerDisabled(t *testing.T) {
 	// Register a dummy provider so the lookup doesn't fail
@@ -68,6 +87,21 @@ func TestNewAnalyzerDisabled(t *testing.T) {
 	}
 }
 
+func TestNewAnalyzerKeylessProvider(t *testing.T) {
+	ResetProviders()
+	RegisterProvider("ollama", func(cfg *Config) (Provider, error) {
+		return &mockProvider{name: "ollama", defaultModel: "llama3.2", available: true}, nil
+	})
+
+	analyzer, err := NewAnalyzer(&Config{Enabled: true, Provider: "ollama"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, isNoop := analyzer.(*NoOpAnalyzer); isNoop {
+		t.Error("ollama without an API key should not be treated as disabled")
+	}
+}
+
 func TestNewAnalyzerUnknownProvider(t *testing.T) {
 	ResetProviders()
 
@@ -213,6 +247,28 @@ func TestDefaultAnalyzerAnalyzeSuspiciousCode(t *testing.T) {
 	}
 }
 
+func TestDefaultAnalyzerAnalyzeCode(t *testing.T) {
+	mock := &mockProvider{
+		name:         "mock",
+		defaultModel: "test-model",
+		available:    true,
+		response:     `{"assessment": "unlikely AI-generated", "confidence": 0.15, "reasoning": "Idiosyncratic naming"}`,
+	}
+
+	a := NewDefaultAnalyzer(mock, &Config{Model: "test", MaxTokens: 512})
+
+	result, err := a.AnalyzeCode(context.Background(), "abc123def456", "func hello() { return }")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Assessment != "unlikely AI-generated" || result.Confidence != 0.15 {
+		t.Errorf("AnalyzeCode() = %q at %v, want unlikely AI-generated at 0.15", result.Assessment, result.Confidence)
+	}
+	if result.Reasoning != "Idiosyncratic naming" {
+		t.Errorf("Reasoning = %q", result.Reasoning)
+	}
+}
+
 func TestDefaultAnalyzerTruncatesLongCode(t *testing.T) {
 	// Create code longer than 2000 chars
 	longCode := ""
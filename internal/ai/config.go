@@ -2,26 +2,60 @@ package ai
 
 import (
 	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is how many times a retryable Complete failure is
+	// retried when Config.MaxRetries is zero.
+	DefaultMaxRetries = 3
+	// DefaultRetryBaseDelay is the first backoff delay when
+	// Config.RetryBaseDelay is zero; each further retry doubles it.
+	DefaultRetryBaseDelay = 500 * time.Millisecond
 )
 
 // Config holds the configuration for AI-powered code analysis.
 type Config struct {
-	Enabled   bool
-	Provider  string // "openai", "anthropic", or empty (defaults to "openai")
-	APIKey    string
-	Model     string // Provider-specific model name; uses provider default if empty
-	MaxTokens int
+	Enabled        bool
+	Provider       string // "openai", "anthropic", "gemini", "ollama", or empty (defaults to "openai")
+	APIKey         string
+	Model          string // Provider-specific model name; uses provider default if empty
+	MaxTokens      int
+	BaseURL        string        // Server URL for self-hosted providers (ollama); ignored by hosted providers
+	MaxRetries     int           // Retries for rate limits, timeouts and 5xx; 0 uses DefaultMaxRetries, negative disables
+	RetryBaseDelay time.Duration // First backoff delay; 0 uses DefaultRetryBaseDelay
 }
 
 // LoadConfig creates a Config from environment variables.
 func LoadConfig() *Config {
 	return &Config{
-		Enabled:   os.Getenv("CADENCE_AI_ENABLED") == "true",
-		Provider:  os.Getenv("CADENCE_AI_PROVIDER"),
-		APIKey:    os.Getenv("CADENCE_AI_KEY"),
-		Model:     getEnvOrDefault("CADENCE_AI_MODEL", ""),
-		MaxTokens: 500,
+		Enabled:    os.Getenv("CADENCE_AI_ENABLED") == "true",
+		Provider:   os.Getenv("CADENCE_AI_PROVIDER"),
+		APIKey:     os.Getenv("CADENCE_AI_KEY"),
+		Model:      getEnvOrDefault("CADENCE_AI_MODEL", ""),
+		MaxTokens:  500,
+		BaseURL:    os.Getenv("CADENCE_AI_BASE_URL"),
+		MaxRetries: getEnvInt("CADENCE_AI_MAX_RETRIES", 0),
+	}
+}
+
+func (c *Config) maxRetries() int {
+	switch {
+	case c == nil || c.MaxRetries == 0:
+		return DefaultMaxRetries
+	case c.MaxRetries < 0:
+		return 0
+	default:
+		return c.MaxRetries
+	}
+}
+
+func (c *Config) retryBaseDelay() time.Duration {
+	if c == nil || c.RetryBaseDelay <= 0 {
+		return DefaultRetryBaseDelay
 	}
+	return c.RetryBaseDelay
 }
 
 func getEnvOrDefault(key, defaultVal string) string {
@@ -30,3 +64,10 @@ func getEnvOrDefault(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+func getEnvInt(key string, defaultVal int) int {
+	if n, err := strconv.Atoi(os.Getenv(key)); err == nil {
+		return n
+	}
+	return defaultVal
+}
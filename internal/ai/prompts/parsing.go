@@ -1,6 +1,10 @@
 package prompts
 
-import "strings"
+import (
+	"math"
+	"strconv"
+	"strings"
+)
 
 // AnalysisResult holds the structured output from an AI code analysis.
 type AnalysisResult struct {
@@ -10,6 +14,23 @@ type AnalysisResult struct {
 	Indicators []string // Specific patterns detected
 }
 
+// IsAIGenerated reports whether the verdict leans toward AI generation, i.e.
+// it is "likely" or "possibly" rather than "unlikely" AI-generated.
+func (r *AnalysisResult) IsAIGenerated() bool {
+	return r.Assessment != "" && !strings.HasPrefix(r.Assessment, "unlikely")
+}
+
+// AIProbability reads the verdict as the probability that the code is
+// AI-generated: Confidence is how sure the model is of its own verdict, so a
+// confident "unlikely" verdict means a low probability.
+func (r *AnalysisResult) AIProbability() float64 {
+	c := min(max(r.Confidence, 0), 1)
+	if r.IsAIGenerated() {
+		return c
+	}
+	return 1 - c
+}
+
 // ParseAnalysisResult extracts an AnalysisResult from raw AI response text.
 // It attempts to find and parse JSON in the response, falling back to text heuristics.
 func ParseAnalysisResult(responseText string) (*AnalysisResult, error) {
@@ -54,6 +75,8 @@ func ParseAnalysisResult(responseText string) (*AnalysisResult, error) {
 // GetAssessmentFromText determines the assessment and default confidence from free text.
 func GetAssessmentFromText(text string) (assessment string, confidence float64) {
 	switch {
+	case strings.Contains(text, "unlikely"):
+		return "unlikely AI-generated", 0.2
 	case strings.Contains(text, "likely"):
 		return "likely AI-generated", 0.8
 	case strings.Contains(text, "possibly"):
@@ -63,19 +86,14 @@ func GetAssessmentFromText(text string) (assessment string, confidence float64)
 	}
 }
 
-// ParseConfidence converts a confidence string to a float64 value.
+// ParseConfidence converts a confidence string to a float64 value clamped to
+// [0, 1], falling back to 0.5 when it is not a number.
 func ParseConfidence(confStr string) float64 {
-	switch confStr {
-	case "1", "1.0":
-		return 1.0
-	case "0", "0.0":
-		return 0.0
-	default:
-		if confStr != "" && confStr[0:1] == "0" {
-			return 0.5
-		}
+	conf, err := strconv.ParseFloat(strings.Trim(confStr, `" `), 64)
+	if err != nil || math.IsNaN(conf) {
 		return 0.5
 	}
+	return min(max(conf, 0), 1)
 }
 
 func intMin(a, b int) int {
@@ -93,6 +93,7 @@ func TestGetAssessmentFromText(t *testing.T) {
 	}{
 		{"this code is likely AI-generated", "likely AI-generated", 0.8},
 		{"this code is possibly AI-generated", "possibly AI-generated", 0.5},
+		{"this code is unlikely AI-generated", "unlikely AI-generated", 0.2},
 		{"this code was probably written by a human", "unlikely AI-generated", 0.2},
 		{"", "unlikely AI-generated", 0.2},
 		{"LIKELY AI", "unlikely AI-generated", 0.2},
@@ -122,6 +123,8 @@ func TestParseConfidence(t *testing.T) {
 		{name: "string 0", input: "0", expected: 0.0},
 		{name: "string 0.0", input: "0.0", expected: 0.0},
 		{name: "string starting with 0", input: "0.5", expected: 0.5},
+		{name: "fraction", input: "0.85", expected: 0.85},
+		{name: "out of range", input: "1.5", expected: 1.0},
 		{name: "empty string", input: "", expected: 0.5},
 		{name: "other string", input: "unknown", expected: 0.5},
 	}
@@ -176,3 +179,27 @@ func TestAnalysisResultStruct(t *testing.T) {
 		t.Errorf("expected 2 indicators, got %d", len(result.Indicators))
 	}
 }
+
+func TestAnalysisResult_AIProbability(t *testing.T) {
+	tests := []struct {
+		assessment string
+		confidence float64
+		wantAI     bool
+		want       float64
+	}{
+		{"likely AI-generated", 0.9, true, 0.9},
+		{"possibly AI-generated", 0.6, true, 0.6},
+		{"unlikely AI-generated", 0.9, false, 0.1},
+		{"unlikely AI-generated", 0.2, false, 0.8},
+		{"", 0.7, false, 0.3},
+	}
+	for _, tt := range tests {
+		r := &AnalysisResult{Assessment: tt.assessment, Confidence: tt.confidence}
+		if got := r.IsAIGenerated(); got != tt.wantAI {
+			t.Errorf("IsAIGenerated(%q) = %v, want %v", tt.assessment, got, tt.wantAI)
+		}
+		if got := r.AIProbability(); got < tt.want-1e-9 || got > tt.want+1e-9 {
+			t.Errorf("AIProbability(%q, %v) = %v, want %v", tt.assessment, tt.confidence, got, tt.want)
+		}
+	}
+}
@@ -10,10 +10,26 @@ import (
 type Provider interface {
 	Name() string
 	Complete(ctx context.Context, req CompletionRequest) (string, error)
+	// CompleteStream is Complete with the response delivered as it is
+	// generated: the channel yields text fragments in order and is closed
+	// when the response ends or ctx is done. A response that fails mid-way
+	// ends with a chunk carrying the error. Errors sending the request are
+	// returned directly. Providers without a streaming endpoint use
+	// CompleteAsStream.
+	CompleteStream(ctx context.Context, req CompletionRequest) (<-chan StreamChunk, error)
 	IsAvailable() bool
 	DefaultModel() string
 }
 
+// StreamChunk is one element of a streamed response: a text fragment, the
+// token usage the provider reported for the response or, as the last chunk
+// of a response that was cut short, the error that ended it.
+type StreamChunk struct {
+	Text  string
+	Usage Usage
+	Err   error
+}
+
 type CompletionRequest struct {
 	SystemPrompt string
 	UserPrompt   string
@@ -22,6 +38,39 @@ type CompletionRequest struct {
 	Temperature  float32
 }
 
+// StatusError is returned by providers when the API answers with a non-success
+// HTTP status, so callers can tell rate limits and server errors apart from
+// permanent failures.
+type StatusError struct {
+	Provider   string
+	StatusCode int
+	Body       string
+	Err        error // underlying SDK error, if any
+}
+
+func (e *StatusError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: API call failed (status %d): %v", e.Provider, e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("%s: API returned status %d: %s", e.Provider, e.StatusCode, e.Body)
+}
+
+func (e *StatusError) Unwrap() error { return e.Err }
+
+// CompleteAsStream implements CompleteStream for providers that cannot
+// stream: it waits for p.Complete (or CompleteWithUsage) and delivers the
+// whole response as a single fragment with its usage.
+func CompleteAsStream(ctx context.Context, p Provider, req CompletionRequest) (<-chan StreamChunk, error) {
+	text, usage, err := completeWithUsage(ctx, p, req)
+	if err != nil {
+		return nil, err
+	}
+	tokens := make(chan StreamChunk, 1)
+	tokens <- StreamChunk{Text: text, Usage: usage}
+	close(tokens)
+	return tokens, nil
+}
+
 type ProviderFactory func(cfg *Config) (Provider, error)
 
 var (
@@ -3,12 +3,14 @@
 package anthropic
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/TryCadence/Cadence/internal/ai"
 )
@@ -63,6 +65,7 @@ type messagesRequest struct {
 	System      string         `json:"system,omitempty"`
 	Messages    []messageParam `json:"messages"`
 	Temperature float32        `json:"temperature,omitempty"`
+	Stream      bool           `json:"stream,omitempty"`
 }
 
 type messageParam struct {
@@ -77,9 +80,15 @@ type messagesResponse struct {
 	Role    string         `json:"role"`
 	Content []contentBlock `json:"content"`
 	Model   string         `json:"model"`
+	Usage   usage          `json:"usage"`
 	Error   *apiError      `json:"error,omitempty"`
 }
 
+type usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
 type contentBlock struct {
 	Type string `json:"type"`
 	Text string `json:"text"`
@@ -90,8 +99,26 @@ type apiError struct {
 	Message string `json:"message"`
 }
 
-// Complete sends a chat completion request to the Anthropic Messages API.
-func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (string, error) {
+// streamEvent is the data of one server-sent event from a streaming
+// Messages request. Text arrives in content_block_delta events; the input
+// token count in message_start and the running output count in
+// message_delta.
+type streamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Message struct {
+		Usage usage `json:"usage"`
+	} `json:"message"`
+	Usage usage    `json:"usage"`
+	Error apiError `json:"error"`
+}
+
+// send posts a Messages request for req and returns the response once the
+// API has answered with 200 OK.
+func (p *Provider) send(ctx context.Context, req ai.CompletionRequest, stream bool) (*http.Response, error) {
 	model := req.Model
 	if model == "" {
 		model = defaultModel
@@ -110,16 +137,17 @@ func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (stri
 			{Role: "user", Content: req.UserPrompt},
 		},
 		Temperature: req.Temperature,
+		Stream:      stream,
 	}
 
 	payload, err := json.Marshal(body)
 	if err != nil {
-		return "", fmt.Errorf("anthropic: failed to marshal request: %w", err)
+		return nil, fmt.Errorf("anthropic: failed to marshal request: %w", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/v1/messages", bytes.NewReader(payload))
 	if err != nil {
-		return "", fmt.Errorf("anthropic: failed to create request: %w", err)
+		return nil, fmt.Errorf("anthropic: failed to create request: %w", err)
 	}
 
 	httpReq.Header.Set("Content-Type", "application/json")
@@ -128,36 +156,125 @@ func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (stri
 
 	resp, err := p.httpClient.Do(httpReq)
 	if err != nil {
-		return "", fmt.Errorf("anthropic: API call failed: %w", err)
+		return nil, fmt.Errorf("anthropic: API call failed: %w", err)
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, &ai.StatusError{Provider: providerName, StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	return resp, nil
+}
+
+// Complete sends a chat completion request to the Anthropic Messages API.
+func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (string, error) {
+	text, _, err := p.CompleteWithUsage(ctx, req)
+	return text, err
+}
+
+// CompleteWithUsage is Complete that also returns the token usage the API
+// reported.
+func (p *Provider) CompleteWithUsage(ctx context.Context, req ai.CompletionRequest) (string, ai.Usage, error) {
+	resp, err := p.send(ctx, req, false)
 	if err != nil {
-		return "", fmt.Errorf("anthropic: failed to read response: %w", err)
+		return "", ai.Usage{}, err
 	}
+	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("anthropic: API returned status %d: %s", resp.StatusCode, string(respBody))
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", ai.Usage{}, fmt.Errorf("anthropic: failed to read response: %w", err)
 	}
 
 	var msgResp messagesResponse
 	if err := json.Unmarshal(respBody, &msgResp); err != nil {
-		return "", fmt.Errorf("anthropic: failed to parse response: %w", err)
+		return "", ai.Usage{}, fmt.Errorf("anthropic: failed to parse response: %w", err)
 	}
 
 	if msgResp.Error != nil {
-		return "", fmt.Errorf("anthropic: API error (%s): %s", msgResp.Error.Type, msgResp.Error.Message)
+		return "", ai.Usage{}, fmt.Errorf("anthropic: API error (%s): %s", msgResp.Error.Type, msgResp.Error.Message)
 	}
 
+	usage := ai.Usage{PromptTokens: msgResp.Usage.InputTokens, CompletionTokens: msgResp.Usage.OutputTokens}
+
 	// Extract text from the first text content block
 	for _, block := range msgResp.Content {
 		if block.Type == "text" {
-			return block.Text, nil
+			return block.Text, usage, nil
 		}
 	}
 
-	return "", fmt.Errorf("anthropic: no text content in response")
+	return "", usage, fmt.Errorf("anthropic: no text content in response")
+}
+
+// CompleteStream sends a streaming Messages request and forwards the text
+// of each content_block_delta event as it arrives, followed by the usage
+// reported in message_start and message_delta once message_stop arrives.
+// An error event, a read failure or a stream that ends before message_stop
+// is sent as the final chunk.
+func (p *Provider) CompleteStream(ctx context.Context, req ai.CompletionRequest) (<-chan ai.StreamChunk, error) {
+	resp, err := p.send(ctx, req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make(chan ai.StreamChunk)
+	go func() {
+		defer close(tokens)
+		defer resp.Body.Close()
+
+		send := func(chunk ai.StreamChunk) bool {
+			select {
+			case tokens <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		var tokenUsage ai.Usage
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for scanner.Scan() {
+			data, ok := strings.CutPrefix(scanner.Text(), "data:")
+			if !ok {
+				continue
+			}
+			var event streamEvent
+			if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &event); err != nil {
+				continue
+			}
+			switch event.Type {
+			case "content_block_delta":
+				if event.Delta.Type != "text_delta" || event.Delta.Text == "" {
+					continue
+				}
+				if !send(ai.StreamChunk{Text: event.Delta.Text}) {
+					return
+				}
+			case "message_start":
+				tokenUsage.PromptTokens = event.Message.Usage.InputTokens
+				tokenUsage.CompletionTokens = event.Message.Usage.OutputTokens
+			case "message_delta":
+				// output_tokens is cumulative.
+				tokenUsage.CompletionTokens = event.Usage.OutputTokens
+			case "message_stop":
+				if tokenUsage != (ai.Usage{}) {
+					send(ai.StreamChunk{Usage: tokenUsage})
+				}
+				return
+			case "error":
+				send(ai.StreamChunk{Err: fmt.Errorf("anthropic: stream error: %s: %s", event.Error.Type, event.Error.Message)})
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			send(ai.StreamChunk{Err: fmt.Errorf("anthropic: stream interrupted: %w", err)})
+			return
+		}
+		send(ai.StreamChunk{Err: fmt.Errorf("anthropic: stream ended before message_stop")})
+	}()
+	return tokens, nil
 }
 
 // IsAvailable reports whether the provider has a valid API key.
@@ -3,8 +3,10 @@ package anthropic
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/TryCadence/Cadence/internal/ai"
@@ -115,6 +117,7 @@ func TestProviderComplete(t *testing.T) {
 				{Type: "text", Text: "Hello from Claude!"},
 			},
 			Model: "claude-sonnet-4-20250514",
+			Usage: usage{InputTokens: 12, OutputTokens: 5},
 		}
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(resp)
@@ -143,6 +146,18 @@ func TestProviderComplete(t *testing.T) {
 	if result != "Hello from Claude!" {
 		t.Errorf("expected 'Hello from Claude!', got %q", result)
 	}
+
+	_, got, err := p.CompleteWithUsage(context.Background(), ai.CompletionRequest{
+		SystemPrompt: "You are a test assistant",
+		UserPrompt:   "Hello",
+		Model:        "claude-sonnet-4-20250514",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != (ai.Usage{PromptTokens: 12, CompletionTokens: 5}) {
+		t.Errorf("expected usage 12+5, got %+v", got)
+	}
 }
 
 func TestProviderCompleteAPIError(t *testing.T) {
@@ -172,6 +187,10 @@ func TestProviderCompleteAPIError(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error for unauthorized request")
 	}
+	var statusErr *ai.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected *ai.StatusError with status 401, got %v", err)
+	}
 }
 
 func TestProviderCompleteNoTextContent(t *testing.T) {
@@ -205,6 +224,138 @@ func TestProviderCompleteNoTextContent(t *testing.T) {
 	}
 }
 
+func TestProviderCompleteStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req messagesRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if !req.Stream {
+			t.Error("expected a streaming request")
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range []string{
+			`event: message_start` + "\n" + `data: {"type": "message_start", "message": {"id": "msg_test", "usage": {"input_tokens": 25, "output_tokens": 1}}}`,
+			`event: content_block_start` + "\n" + `data: {"type": "content_block_start", "index": 0}`,
+			`event: ping` + "\n" + `data: {"type": "ping"}`,
+			`event: content_block_delta` + "\n" + `data: {"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "Hello"}}`,
+			`event: content_block_delta` + "\n" + `data: {"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": " from Claude!"}}`,
+			`event: message_delta` + "\n" + `data: {"type": "message_delta", "delta": {"stop_reason": "end_turn"}, "usage": {"output_tokens": 15}}`,
+			`event: message_stop` + "\n" + `data: {"type": "message_stop"}`,
+			`event: content_block_delta` + "\n" + `data: {"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "after stop"}}`,
+		} {
+			w.Write([]byte(event + "\n\n"))
+		}
+	}))
+	defer server.Close()
+
+	p := &Provider{
+		apiKey:     "test-key",
+		baseURL:    server.URL,
+		apiVersion: defaultAPIVersion,
+		httpClient: server.Client(),
+		config:     &ai.Config{APIKey: "test-key"},
+	}
+
+	tokens, err := p.CompleteStream(context.Background(), ai.CompletionRequest{UserPrompt: "Hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	var usage ai.Usage
+	for chunk := range tokens {
+		if chunk.Err != nil {
+			t.Fatalf("unexpected stream error: %v", chunk.Err)
+		}
+		if chunk.Usage != (ai.Usage{}) {
+			usage = chunk.Usage
+			continue
+		}
+		got = append(got, chunk.Text)
+	}
+	if len(got) != 2 || got[0] != "Hello" || got[1] != " from Claude!" {
+		t.Errorf("expected the two text deltas before message_stop, got %q", got)
+	}
+	if usage != (ai.Usage{PromptTokens: 25, CompletionTokens: 15}) {
+		t.Errorf("expected usage 25+15 from message_start and message_delta, got %+v", usage)
+	}
+}
+
+func TestProviderCompleteStreamInterrupted(t *testing.T) {
+	tests := []struct {
+		name    string
+		events  []string
+		wantErr string
+	}{
+		{
+			name:    "error event",
+			events:  []string{`event: error` + "\n" + `data: {"type": "error", "error": {"type": "overloaded_error", "message": "Overloaded"}}`},
+			wantErr: "overloaded_error",
+		},
+		{
+			name:    "no message_stop",
+			wantErr: "before message_stop",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				events := append([]string{
+					`event: content_block_delta` + "\n" + `data: {"type": "content_block_delta", "index": 0, "delta": {"type": "text_delta", "text": "Hello"}}`,
+				}, tt.events...)
+				for _, event := range events {
+					w.Write([]byte(event + "\n\n"))
+				}
+			}))
+			defer server.Close()
+
+			p := &Provider{
+				apiKey:     "test-key",
+				baseURL:    server.URL,
+				apiVersion: defaultAPIVersion,
+				httpClient: server.Client(),
+				config:     &ai.Config{APIKey: "test-key"},
+			}
+
+			tokens, err := p.CompleteStream(context.Background(), ai.CompletionRequest{UserPrompt: "Hello"})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var chunks []ai.StreamChunk
+			for chunk := range tokens {
+				chunks = append(chunks, chunk)
+			}
+			if len(chunks) != 2 || chunks[0].Text != "Hello" || chunks[1].Err == nil || !strings.Contains(chunks[1].Err.Error(), tt.wantErr) {
+				t.Errorf("expected the delta followed by an error mentioning %q, got %+v", tt.wantErr, chunks)
+			}
+		})
+	}
+}
+
+func TestProviderCompleteStreamAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"type": "error", "error": {"type": "rate_limit_error", "message": "slow down"}}`))
+	}))
+	defer server.Close()
+
+	p := &Provider{
+		apiKey:     "test-key",
+		baseURL:    server.URL,
+		apiVersion: defaultAPIVersion,
+		httpClient: server.Client(),
+		config:     &ai.Config{APIKey: "test-key"},
+	}
+
+	_, err := p.CompleteStream(context.Background(), ai.CompletionRequest{UserPrompt: "Hello"})
+	var statusErr *ai.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected *ai.StatusError with status 429, got %v", err)
+	}
+}
+
 func TestProviderImplementsInterface(t *testing.T) {
 	p, err := New(&ai.Config{APIKey: "test"})
 	if err != nil {
@@ -0,0 +1,181 @@
+// Package gemini implements the AI Provider interface for Google's Gemini
+// generateContent API. It uses a plain HTTP client — no external SDK dependency required.
+package gemini
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/ai"
+)
+
+const (
+	providerName   = "gemini"
+	defaultModel   = "gemini-1.5-flash"
+	defaultBaseURL = "https://generativelanguage.googleapis.com"
+)
+
+func init() {
+	ai.RegisterProvider(providerName, New)
+}
+
+type Provider struct {
+	apiKey     string
+	baseURL    string
+	httpClient *http.Client
+	config     *ai.Config
+}
+
+func New(cfg *ai.Config) (ai.Provider, error) {
+	if cfg.APIKey == "" {
+		return nil, fmt.Errorf("gemini: API key is required")
+	}
+
+	return &Provider{
+		apiKey:     cfg.APIKey,
+		baseURL:    defaultBaseURL,
+		httpClient: &http.Client{},
+		config:     cfg,
+	}, nil
+}
+
+// Name returns "gemini".
+func (p *Provider) Name() string {
+	return providerName
+}
+
+// DefaultModel returns the default Gemini model.
+func (p *Provider) DefaultModel() string {
+	return defaultModel
+}
+
+// generateContentRequest is the request body for the generateContent API.
+type generateContentRequest struct {
+	SystemInstruction *content         `json:"systemInstruction,omitempty"`
+	Contents          []content        `json:"contents"`
+	GenerationConfig  generationConfig `json:"generationConfig"`
+}
+
+type content struct {
+	Role  string `json:"role,omitempty"`
+	Parts []part `json:"parts"`
+}
+
+type part struct {
+	Text string `json:"text"`
+}
+
+type generationConfig struct {
+	MaxOutputTokens int     `json:"maxOutputTokens,omitempty"`
+	Temperature     float32 `json:"temperature,omitempty"`
+}
+
+// generateContentResponse is the response body from the generateContent API.
+type generateContentResponse struct {
+	Candidates []candidate `json:"candidates"`
+	Error      *apiError   `json:"error,omitempty"`
+}
+
+type candidate struct {
+	Content      content `json:"content"`
+	FinishReason string  `json:"finishReason"`
+}
+
+type apiError struct {
+	Code    int    `json:"code"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Complete sends a generateContent request to the Gemini API.
+func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (string, error) {
+	model := req.Model
+	if model == "" {
+		model = defaultModel
+	}
+
+	maxTokens := req.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 1024
+	}
+
+	body := generateContentRequest{
+		Contents: []content{
+			{Role: "user", Parts: []part{{Text: req.UserPrompt}}},
+		},
+		GenerationConfig: generationConfig{
+			MaxOutputTokens: maxTokens,
+			Temperature:     req.Temperature,
+		},
+	}
+	if req.SystemPrompt != "" {
+		body.SystemInstruction = &content{Parts: []part{{Text: req.SystemPrompt}}}
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("gemini: failed to marshal request: %w", err)
+	}
+
+	endpoint := p.baseURL + "/v1beta/models/" + url.PathEscape(model) + ":generateContent"
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("gemini: failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("x-goog-api-key", p.apiKey)
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("gemini: API call failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("gemini: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &ai.StatusError{Provider: providerName, StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var genResp generateContentResponse
+	if err := json.Unmarshal(respBody, &genResp); err != nil {
+		return "", fmt.Errorf("gemini: failed to parse response: %w", err)
+	}
+
+	if genResp.Error != nil {
+		return "", fmt.Errorf("gemini: API error (%s): %s", genResp.Error.Status, genResp.Error.Message)
+	}
+
+	// Concatenate the text parts of the first candidate
+	if len(genResp.Candidates) > 0 {
+		var sb strings.Builder
+		for _, pt := range genResp.Candidates[0].Content.Parts {
+			sb.WriteString(pt.Text)
+		}
+		if sb.Len() > 0 {
+			return sb.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("gemini: no text content in response")
+}
+
+// CompleteStream delivers Complete's response as a single fragment.
+func (p *Provider) CompleteStream(ctx context.Context, req ai.CompletionRequest) (<-chan ai.StreamChunk, error) {
+	return ai.CompleteAsStream(ctx, p, req)
+}
+
+// IsAvailable reports whether the provider has a valid API key.
+func (p *Provider) IsAvailable() bool {
+	return p.config != nil && p.apiKey != ""
+}
@@ -0,0 +1,219 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/ai"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *ai.Config
+		expectError bool
+	}{
+		{
+			name:        "valid config",
+			config:      &ai.Config{APIKey: "gm-test", Model: "gemini-1.5-flash"},
+			expectError: false,
+		},
+		{
+			name:        "empty API key",
+			config:      &ai.Config{APIKey: "", Model: "gemini-1.5-flash"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.config)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if p == nil {
+				t.Fatal("expected provider but got nil")
+			}
+		})
+	}
+}
+
+func TestRegisteredProvider(t *testing.T) {
+	p, err := ai.NewProvider(&ai.Config{Provider: "gemini", APIKey: "gm-test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "gemini" {
+		t.Errorf("expected 'gemini', got %q", p.Name())
+	}
+
+	_, err = ai.NewProvider(&ai.Config{Provider: "gemini"})
+	if err == nil || !strings.Contains(err.Error(), "API key is required") {
+		t.Errorf("expected missing API key error, got %v", err)
+	}
+}
+
+func TestProviderName(t *testing.T) {
+	p, err := New(&ai.Config{APIKey: "gm-test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "gemini" {
+		t.Errorf("expected 'gemini', got %q", p.Name())
+	}
+}
+
+func TestProviderDefaultModel(t *testing.T) {
+	p, err := New(&ai.Config{APIKey: "gm-test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.DefaultModel() != "gemini-1.5-flash" {
+		t.Errorf("expected 'gemini-1.5-flash', got %q", p.DefaultModel())
+	}
+}
+
+func TestProviderIsAvailable(t *testing.T) {
+	p, err := New(&ai.Config{APIKey: "gm-test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !p.IsAvailable() {
+		t.Error("expected IsAvailable to be true")
+	}
+}
+
+func TestProviderComplete(t *testing.T) {
+	// Set up a mock Gemini API server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1beta/models/gemini-1.5-flash:generateContent" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if r.Header.Get("x-goog-api-key") != "test-key" {
+			t.Errorf("expected x-goog-api-key 'test-key', got %q", r.Header.Get("x-goog-api-key"))
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("expected Content-Type 'application/json', got %q", r.Header.Get("Content-Type"))
+		}
+
+		// Verify request body
+		var req generateContentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if req.SystemInstruction == nil || len(req.SystemInstruction.Parts) != 1 ||
+			req.SystemInstruction.Parts[0].Text != "You are a test assistant" {
+			t.Errorf("unexpected system instruction: %+v", req.SystemInstruction)
+		}
+		if len(req.Contents) != 1 || req.Contents[0].Role != "user" || req.Contents[0].Parts[0].Text != "Hello" {
+			t.Errorf("unexpected contents: %+v", req.Contents)
+		}
+		if req.GenerationConfig.MaxOutputTokens != 256 {
+			t.Errorf("expected maxOutputTokens 256, got %d", req.GenerationConfig.MaxOutputTokens)
+		}
+
+		// Return mock response
+		resp := generateContentResponse{
+			Candidates: []candidate{
+				{
+					Content:      content{Role: "model", Parts: []part{{Text: "Hello "}, {Text: "from Gemini!"}}},
+					FinishReason: "STOP",
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	p := &Provider{
+		apiKey:     "test-key",
+		baseURL:    server.URL,
+		httpClient: server.Client(),
+		config:     &ai.Config{APIKey: "test-key"},
+	}
+
+	result, err := p.Complete(context.Background(), ai.CompletionRequest{
+		SystemPrompt: "You are a test assistant",
+		UserPrompt:   "Hello",
+		MaxTokens:    256,
+		Temperature:  0.3,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != "Hello from Gemini!" {
+		t.Errorf("expected 'Hello from Gemini!', got %q", result)
+	}
+}
+
+func TestProviderCompleteAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]interface{}{
+				"code":    400,
+				"status":  "INVALID_ARGUMENT",
+				"message": "API key not valid",
+			},
+		})
+	}))
+	defer server.Close()
+
+	p := &Provider{
+		apiKey:     "bad-key",
+		baseURL:    server.URL,
+		httpClient: server.Client(),
+		config:     &ai.Config{APIKey: "bad-key"},
+	}
+
+	_, err := p.Complete(context.Background(), ai.CompletionRequest{
+		UserPrompt: "Hello",
+		MaxTokens:  100,
+	})
+	if err == nil {
+		t.Fatal("expected error for invalid request")
+	}
+}
+
+func TestProviderCompleteNoTextContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(generateContentResponse{})
+	}))
+	defer server.Close()
+
+	p := &Provider{
+		apiKey:     "test-key",
+		baseURL:    server.URL,
+		httpClient: server.Client(),
+		config:     &ai.Config{APIKey: "test-key"},
+	}
+
+	_, err := p.Complete(context.Background(), ai.CompletionRequest{
+		UserPrompt: "Hello",
+		MaxTokens:  100,
+	})
+	if err == nil {
+		t.Fatal("expected error for empty candidates")
+	}
+}
+
+func TestProviderImplementsInterface(t *testing.T) {
+	p, err := New(&ai.Config{APIKey: "test"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var _ ai.Provider = p
+}
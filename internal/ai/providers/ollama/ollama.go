@@ -0,0 +1,202 @@
+// Package ollama implements the AI Provider interface for a local Ollama
+// server's chat API, for offline analysis on networks without access to a
+// hosted provider. No API key is required.
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/ai"
+)
+
+const (
+	providerName   = "ollama"
+	defaultModel   = "llama3.2"
+	defaultBaseURL = "http://localhost:11434/api/chat"
+
+	chatPath = "/api/chat"
+	tagsPath = "/api/tags"
+
+	// pingTimeout bounds the IsAvailable check so an unreachable server does
+	// not stall analysis.
+	pingTimeout = 2 * time.Second
+)
+
+func init() {
+	ai.RegisterProvider(providerName, New)
+}
+
+type Provider struct {
+	serverURL  string
+	httpClient *http.Client
+	config     *ai.Config
+}
+
+// New creates an Ollama provider. cfg.BaseURL may be either the chat endpoint
+// (http://host:11434/api/chat) or the server root (http://host:11434); it
+// defaults to http://localhost:11434/api/chat.
+func New(cfg *ai.Config) (ai.Provider, error) {
+	baseURL := cfg.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		return nil, fmt.Errorf("ollama: base URL must be http or https, got %q", baseURL)
+	}
+
+	return &Provider{
+		serverURL:  serverURL(baseURL),
+		httpClient: &http.Client{},
+		config:     cfg,
+	}, nil
+}
+
+// serverURL strips the chat path from a configured base URL, leaving the
+// server root that both the chat and tags endpoints hang off.
+func serverURL(baseURL string) string {
+	u := strings.TrimRight(baseURL, "/")
+	return strings.TrimSuffix(u, chatPath)
+}
+
+// Name returns "ollama".
+func (p *Provider) Name() string {
+	return providerName
+}
+
+// DefaultModel returns the default local model.
+func (p *Provider) DefaultModel() string {
+	return defaultModel
+}
+
+// chatRequest is the request body for the Ollama chat API.
+type chatRequest struct {
+	Model    string        `json:"model"`
+	Messages []chatMessage `json:"messages"`
+	Stream   bool          `json:"stream"`
+	Options  chatOptions   `json:"options,omitempty"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatOptions struct {
+	NumPredict  int     `json:"num_predict,omitempty"`
+	Temperature float32 `json:"temperature,omitempty"`
+}
+
+// chatResponse is the non-streaming response body from the Ollama chat API.
+type chatResponse struct {
+	Model   string      `json:"model"`
+	Message chatMessage `json:"message"`
+	Done    bool        `json:"done"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// Complete sends a non-streaming chat request to the Ollama server.
+func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (string, error) {
+	model := req.Model
+	if model == "" {
+		model = defaultModel
+	}
+
+	maxTokens := req.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = 1024
+	}
+
+	messages := make([]chatMessage, 0, 2)
+	if req.SystemPrompt != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: req.SystemPrompt})
+	}
+	messages = append(messages, chatMessage{Role: "user", Content: req.UserPrompt})
+
+	body := chatRequest{
+		Model:    model,
+		Messages: messages,
+		Stream:   false,
+		Options: chatOptions{
+			NumPredict:  maxTokens,
+			Temperature: req.Temperature,
+		},
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", fmt.Errorf("ollama: failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.serverURL+chatPath, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("ollama: failed to create request: %w", err)
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("ollama: API call failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("ollama: failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &ai.StatusError{Provider: providerName, StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+
+	var chatResp chatResponse
+	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		return "", fmt.Errorf("ollama: failed to parse response: %w", err)
+	}
+
+	if chatResp.Error != "" {
+		return "", fmt.Errorf("ollama: API error: %s", chatResp.Error)
+	}
+
+	if chatResp.Message.Content == "" {
+		return "", fmt.Errorf("ollama: no text content in response")
+	}
+
+	return chatResp.Message.Content, nil
+}
+
+// CompleteStream delivers Complete's response as a single fragment.
+func (p *Provider) CompleteStream(ctx context.Context, req ai.CompletionRequest) (<-chan ai.StreamChunk, error) {
+	return ai.CompleteAsStream(ctx, p, req)
+}
+
+// IsAvailable reports whether the Ollama server answers on its tags endpoint.
+func (p *Provider) IsAvailable() bool {
+	if p.config == nil {
+		return false
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.serverURL+tagsPath, http.NoBody)
+	if err != nil {
+		return false
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode == http.StatusOK
+}
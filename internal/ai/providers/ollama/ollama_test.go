@@ -0,0 +1,183 @@
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/ai"
+)
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      *ai.Config
+		wantServer  string
+		expectError bool
+	}{
+		{
+			name:       "no API key uses default URL",
+			config:     &ai.Config{},
+			wantServer: "http://localhost:11434",
+		},
+		{
+			name:       "chat endpoint URL",
+			config:     &ai.Config{BaseURL: "http://gpu-box:11434/api/chat"},
+			wantServer: "http://gpu-box:11434",
+		},
+		{
+			name:       "server root URL",
+			config:     &ai.Config{BaseURL: "http://gpu-box:11434/"},
+			wantServer: "http://gpu-box:11434",
+		},
+		{
+			name:        "non-http URL",
+			config:      &ai.Config{BaseURL: "gpu-box:11434"},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(tt.config)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected error but got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := p.(*Provider).serverURL; got != tt.wantServer {
+				t.Errorf("serverURL = %q, want %q", got, tt.wantServer)
+			}
+		})
+	}
+}
+
+func TestProviderName(t *testing.T) {
+	p, err := New(&ai.Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if p.Name() != "ollama" {
+		t.Errorf("expected 'ollama', got %q", p.Name())
+	}
+	if p.DefaultModel() != defaultModel {
+		t.Errorf("expected %q, got %q", defaultModel, p.DefaultModel())
+	}
+}
+
+func TestProviderIsAvailable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/tags" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"models":[]}`))
+	}))
+
+	p, err := New(&ai.Config{BaseURL: server.URL + "/api/chat"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !p.IsAvailable() {
+		t.Error("expected IsAvailable to be true with a running server")
+	}
+
+	server.Close()
+	if p.IsAvailable() {
+		t.Error("expected IsAvailable to be false once the server is gone")
+	}
+}
+
+func TestProviderComplete(t *testing.T) {
+	// Set up a mock Ollama server
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/chat" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+
+		var req chatRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if req.Model != "codellama" {
+			t.Errorf("expected model 'codellama', got %q", req.Model)
+		}
+		if req.Stream {
+			t.Error("expected stream to be false")
+		}
+		if len(req.Messages) != 2 || req.Messages[0].Role != "system" || req.Messages[1].Content != "Hello" {
+			t.Errorf("unexpected messages: %v", req.Messages)
+		}
+		if req.Options.NumPredict != 200 {
+			t.Errorf("expected num_predict 200, got %d", req.Options.NumPredict)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(chatResponse{
+			Model:   "codellama",
+			Message: chatMessage{Role: "assistant", Content: "Hello from Ollama!"},
+			Done:    true,
+		})
+	}))
+	defer server.Close()
+
+	p, err := New(&ai.Config{BaseURL: server.URL + "/api/chat"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := p.Complete(context.Background(), ai.CompletionRequest{
+		SystemPrompt: "You are a test assistant",
+		UserPrompt:   "Hello",
+		Model:        "codellama",
+		MaxTokens:    200,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result != "Hello from Ollama!" {
+		t.Errorf("expected 'Hello from Ollama!', got %q", result)
+	}
+}
+
+func TestProviderCompleteAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"model \"missing\" not found, try pulling it first"}`))
+	}))
+	defer server.Close()
+
+	p, _ := New(&ai.Config{BaseURL: server.URL})
+	_, err := p.Complete(context.Background(), ai.CompletionRequest{UserPrompt: "Hello", Model: "missing"})
+	if err == nil {
+		t.Fatal("expected error for missing model")
+	}
+}
+
+func TestProviderCompleteNoTextContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(chatResponse{Done: true})
+	}))
+	defer server.Close()
+
+	p, _ := New(&ai.Config{BaseURL: server.URL})
+	_, err := p.Complete(context.Background(), ai.CompletionRequest{UserPrompt: "Hello"})
+	if err == nil {
+		t.Fatal("expected error for empty content")
+	}
+}
+
+func TestProviderImplementsInterface(t *testing.T) {
+	p, err := New(&ai.Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var _ ai.Provider = p
+}
@@ -3,7 +3,9 @@ package openai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 
 	"github.com/TryCadence/Cadence/internal/ai"
 	openaisdk "github.com/sashabaranov/go-openai"
@@ -47,8 +49,9 @@ func (p *Provider) DefaultModel() string {
 	return defaultModel
 }
 
-// Complete sends a chat completion request to the OpenAI API.
-func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (string, error) {
+// chatRequest builds the chat completion request for req, applying the
+// default model and token limit.
+func chatRequest(req ai.CompletionRequest) openaisdk.ChatCompletionRequest {
 	model := req.Model
 	if model == "" {
 		model = defaultModel
@@ -59,7 +62,7 @@ func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (stri
 		maxTokens = 1024
 	}
 
-	resp, err := p.client.CreateChatCompletion(ctx, openaisdk.ChatCompletionRequest{
+	return openaisdk.ChatCompletionRequest{
 		Model: model,
 		Messages: []openaisdk.ChatCompletionMessage{
 			{
@@ -73,16 +76,93 @@ func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (stri
 		},
 		MaxTokens:   maxTokens,
 		Temperature: req.Temperature,
-	})
+	}
+}
+
+// apiCallError wraps an SDK error, as a StatusError when the API answered.
+func apiCallError(err error) error {
+	var apiErr *openaisdk.APIError
+	if errors.As(err, &apiErr) && apiErr.HTTPStatusCode != 0 {
+		return &ai.StatusError{Provider: providerName, StatusCode: apiErr.HTTPStatusCode, Err: err}
+	}
+	var reqErr *openaisdk.RequestError
+	if errors.As(err, &reqErr) && reqErr.HTTPStatusCode != 0 {
+		return &ai.StatusError{Provider: providerName, StatusCode: reqErr.HTTPStatusCode, Err: err}
+	}
+	return fmt.Errorf("openai: API call failed: %w", err)
+}
+
+// Complete sends a chat completion request to the OpenAI API.
+func (p *Provider) Complete(ctx context.Context, req ai.CompletionRequest) (string, error) {
+	text, _, err := p.CompleteWithUsage(ctx, req)
+	return text, err
+}
+
+// CompleteWithUsage is Complete that also returns the token usage the API
+// reported.
+func (p *Provider) CompleteWithUsage(ctx context.Context, req ai.CompletionRequest) (string, ai.Usage, error) {
+	resp, err := p.client.CreateChatCompletion(ctx, chatRequest(req))
 	if err != nil {
-		return "", fmt.Errorf("openai: API call failed: %w", err)
+		return "", ai.Usage{}, apiCallError(err)
 	}
 
+	usage := ai.Usage{PromptTokens: resp.Usage.PromptTokens, CompletionTokens: resp.Usage.CompletionTokens}
 	if len(resp.Choices) == 0 {
-		return "", fmt.Errorf("openai: no response choices returned")
+		return "", usage, fmt.Errorf("openai: no response choices returned")
 	}
 
-	return resp.Choices[0].Message.Content, nil
+	return resp.Choices[0].Message.Content, usage, nil
+}
+
+// CompleteStream sends a streaming chat completion request and forwards
+// each content delta as it arrives, followed by the usage the API reports
+// in its last chunk. A receive error other than the end of the stream is
+// sent as the final chunk.
+func (p *Provider) CompleteStream(ctx context.Context, req ai.CompletionRequest) (<-chan ai.StreamChunk, error) {
+	chatReq := chatRequest(req)
+	chatReq.StreamOptions = &openaisdk.StreamOptions{IncludeUsage: true}
+	stream, err := p.client.CreateChatCompletionStream(ctx, chatReq)
+	if err != nil {
+		return nil, apiCallError(err)
+	}
+
+	tokens := make(chan ai.StreamChunk)
+	go func() {
+		defer close(tokens)
+		defer stream.Close()
+
+		send := func(chunk ai.StreamChunk) bool {
+			select {
+			case tokens <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				send(ai.StreamChunk{Err: fmt.Errorf("openai: stream interrupted: %w", err)})
+				return
+			}
+			if resp.Usage != nil {
+				usage := ai.Usage{PromptTokens: resp.Usage.PromptTokens, CompletionTokens: resp.Usage.CompletionTokens}
+				if !send(ai.StreamChunk{Usage: usage}) {
+					return
+				}
+			}
+			if len(resp.Choices) == 0 || resp.Choices[0].Delta.Content == "" {
+				continue
+			}
+			if !send(ai.StreamChunk{Text: resp.Choices[0].Delta.Content}) {
+				return
+			}
+		}
+	}()
+	return tokens, nil
 }
 
 // IsAvailable reports whether the provider has a valid API key.
@@ -1,9 +1,15 @@
 package openai
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/TryCadence/Cadence/internal/ai"
+	openaisdk "github.com/sashabaranov/go-openai"
 )
 
 func TestNewProvider(t *testing.T) {
@@ -85,6 +91,133 @@ func TestProviderIsAvailable(t *testing.T) {
 	}
 }
 
+func TestProviderCompleteWithUsage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(openaisdk.ChatCompletionResponse{
+			Choices: []openaisdk.ChatCompletionChoice{{Message: openaisdk.ChatCompletionMessage{Role: "assistant", Content: "Hello from GPT!"}}},
+			Usage:   openaisdk.Usage{PromptTokens: 12, CompletionTokens: 5, TotalTokens: 17},
+		})
+	}))
+	defer server.Close()
+
+	cfg := openaisdk.DefaultConfig("sk-test")
+	cfg.BaseURL = server.URL + "/v1"
+	p := &Provider{client: openaisdk.NewClientWithConfig(cfg), config: &ai.Config{APIKey: "sk-test"}}
+
+	text, usage, err := p.CompleteWithUsage(context.Background(), ai.CompletionRequest{UserPrompt: "Hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != "Hello from GPT!" || usage != (ai.Usage{PromptTokens: 12, CompletionTokens: 5}) {
+		t.Errorf("expected the reply with usage 12+5, got %q %+v", text, usage)
+	}
+}
+
+func TestProviderCompleteStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req openaisdk.ChatCompletionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("failed to decode request body: %v", err)
+		}
+		if !req.Stream || req.Model != defaultModel {
+			t.Errorf("expected a streaming request for %q, got stream=%v model=%q", defaultModel, req.Stream, req.Model)
+		}
+		if req.StreamOptions == nil || !req.StreamOptions.IncludeUsage {
+			t.Errorf("expected stream_options.include_usage, got %+v", req.StreamOptions)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, delta := range []string{"", "Hello", " from GPT!"} {
+			chunk, _ := json.Marshal(openaisdk.ChatCompletionStreamResponse{
+				Choices: []openaisdk.ChatCompletionStreamChoice{{Delta: openaisdk.ChatCompletionStreamChoiceDelta{Content: delta}}},
+			})
+			w.Write([]byte("data: " + string(chunk) + "\n\n"))
+		}
+		chunk, _ := json.Marshal(openaisdk.ChatCompletionStreamResponse{
+			Choices: []openaisdk.ChatCompletionStreamChoice{},
+			Usage:   &openaisdk.Usage{PromptTokens: 12, CompletionTokens: 5},
+		})
+		w.Write([]byte("data: " + string(chunk) + "\n\n"))
+		w.Write([]byte("data: [DONE]\n\n"))
+	}))
+	defer server.Close()
+
+	cfg := openaisdk.DefaultConfig("sk-test")
+	cfg.BaseURL = server.URL + "/v1"
+	p := &Provider{client: openaisdk.NewClientWithConfig(cfg), config: &ai.Config{APIKey: "sk-test"}}
+
+	tokens, err := p.CompleteStream(context.Background(), ai.CompletionRequest{UserPrompt: "Hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	var usage ai.Usage
+	for chunk := range tokens {
+		if chunk.Err != nil {
+			t.Fatalf("unexpected stream error: %v", chunk.Err)
+		}
+		if chunk.Usage != (ai.Usage{}) {
+			usage = chunk.Usage
+			continue
+		}
+		got = append(got, chunk.Text)
+	}
+	if len(got) != 2 || got[0] != "Hello" || got[1] != " from GPT!" {
+		t.Errorf("expected the two non-empty deltas, got %q", got)
+	}
+	if usage != (ai.Usage{PromptTokens: 12, CompletionTokens: 5}) {
+		t.Errorf("expected usage 12+5 from the last chunk, got %+v", usage)
+	}
+}
+
+func TestProviderCompleteStreamInterrupted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunk, _ := json.Marshal(openaisdk.ChatCompletionStreamResponse{
+			Choices: []openaisdk.ChatCompletionStreamChoice{{Delta: openaisdk.ChatCompletionStreamChoiceDelta{Content: "Hello"}}},
+		})
+		w.Write([]byte("data: " + string(chunk) + "\n\n"))
+		w.Write([]byte(`data: {"error": {"message": "overloaded", "type": "server_error"}}` + "\n\n"))
+	}))
+	defer server.Close()
+
+	cfg := openaisdk.DefaultConfig("sk-test")
+	cfg.BaseURL = server.URL + "/v1"
+	p := &Provider{client: openaisdk.NewClientWithConfig(cfg), config: &ai.Config{APIKey: "sk-test"}}
+
+	tokens, err := p.CompleteStream(context.Background(), ai.CompletionRequest{UserPrompt: "Hello"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var chunks []ai.StreamChunk
+	for chunk := range tokens {
+		chunks = append(chunks, chunk)
+	}
+	if len(chunks) != 2 || chunks[0].Text != "Hello" || chunks[1].Err == nil {
+		t.Errorf("expected the delta followed by an error chunk, got %+v", chunks)
+	}
+}
+
+func TestProviderCompleteStreamAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": {"message": "overloaded", "type": "server_error"}}`))
+	}))
+	defer server.Close()
+
+	cfg := openaisdk.DefaultConfig("sk-test")
+	cfg.BaseURL = server.URL + "/v1"
+	p := &Provider{client: openaisdk.NewClientWithConfig(cfg), config: &ai.Config{APIKey: "sk-test"}}
+
+	_, err := p.CompleteStream(context.Background(), ai.CompletionRequest{UserPrompt: "Hello"})
+	var statusErr *ai.StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected *ai.StatusError with status 503, got %v", err)
+	}
+}
+
 func TestProviderImplementsInterface(t *testing.T) {
 	p, err := New(&ai.Config{APIKey: "sk-test"})
 	if err != nil {
@@ -2,7 +2,9 @@ package ai
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/TryCadence/Cadence/internal/ai/skills"
 )
@@ -14,12 +16,19 @@ type SkillResult struct {
 	Parsed   interface{} `json:"parsed"`
 	Provider string      `json:"provider"`
 	Model    string      `json:"model"`
+	Retries  int         `json:"retries"` // provider calls retried after transient failures
+	// Usage is the token count the provider reported for the call.
+	Usage Usage `json:"usage"`
+	// OutputError is set when the model's response was not the JSON the
+	// skill's schema asks for and Parsed holds the skill's fallback.
+	OutputError *skills.OutputError `json:"output_error,omitempty"`
 }
 
 // SkillRunner executes skills using a Provider.
 type SkillRunner struct {
 	provider Provider
 	config   *Config
+	usage    UsageRecorder
 }
 
 // NewSkillRunner creates a SkillRunner backed by the given provider and config.
@@ -29,9 +38,72 @@ func NewSkillRunner(provider Provider, cfg *Config) *SkillRunner {
 
 // Run executes the given skill with the provided input and returns the result.
 func (r *SkillRunner) Run(ctx context.Context, skill skills.Skill, input interface{}) (*SkillResult, error) {
+	req, err := r.request(skill, input)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, usage, retries, err := completeWithRetry(ctx, r.provider, r.config, req)
+	if err != nil {
+		return nil, fmt.Errorf("skill %q: provider error: %w", skill.Name(), err)
+	}
+	recordUsage(r.usage, r.provider.Name(), req.Model, usage)
+
+	result, err := r.result(skill, req.Model, raw, retries)
+	if result != nil {
+		result.Usage = usage
+	}
+	return result, err
+}
+
+// RunStream is Run with the provider's streaming endpoint: onToken is
+// called with each fragment of the response as it arrives, and the result
+// is parsed from the full response once the stream ends. A stream the
+// provider cuts short is an error, not a truncated result.
+func (r *SkillRunner) RunStream(ctx context.Context, skill skills.Skill, input interface{}, onToken func(string)) (*SkillResult, error) {
+	req, err := r.request(skill, input)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, retries, err := streamWithRetry(ctx, r.provider, r.config, req)
+	if err != nil {
+		return nil, fmt.Errorf("skill %q: provider error: %w", skill.Name(), err)
+	}
+
+	var raw strings.Builder
+	var usage Usage
+	for chunk := range tokens {
+		if chunk.Err != nil {
+			return nil, fmt.Errorf("skill %q: provider error: %w", skill.Name(), chunk.Err)
+		}
+		usage.PromptTokens += chunk.Usage.PromptTokens
+		usage.CompletionTokens += chunk.Usage.CompletionTokens
+		if chunk.Text == "" {
+			continue
+		}
+		raw.WriteString(chunk.Text)
+		if onToken != nil {
+			onToken(chunk.Text)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("skill %q: provider error: %w", skill.Name(), err)
+	}
+	recordUsage(r.usage, r.provider.Name(), req.Model, usage)
+
+	result, err := r.result(skill, req.Model, raw.String(), retries)
+	if result != nil {
+		result.Usage = usage
+	}
+	return result, err
+}
+
+// request formats the completion request for running skill on input.
+func (r *SkillRunner) request(skill skills.Skill, input interface{}) (CompletionRequest, error) {
 	userPrompt, err := skill.FormatInput(input)
 	if err != nil {
-		return nil, fmt.Errorf("skill %q: failed to format input: %w", skill.Name(), err)
+		return CompletionRequest{}, fmt.Errorf("skill %q: failed to format input: %w", skill.Name(), err)
 	}
 
 	model := r.config.Model
@@ -44,18 +116,32 @@ func (r *SkillRunner) Run(ctx context.Context, skill skills.Skill, input interfa
 		maxTokens = 1024
 	}
 
-	raw, err := r.provider.Complete(ctx, CompletionRequest{
+	return CompletionRequest{
 		SystemPrompt: skill.SystemPrompt(),
 		UserPrompt:   userPrompt,
 		Model:        model,
 		MaxTokens:    maxTokens,
 		Temperature:  0.3,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("skill %q: provider error: %w", skill.Name(), err)
-	}
+	}, nil
+}
 
+// result parses the model's raw response into a SkillResult.
+func (r *SkillRunner) result(skill skills.Skill, model, raw string, retries int) (*SkillResult, error) {
 	parsed, err := skill.ParseOutput(raw)
+	var outputErr *skills.OutputError
+	if errors.As(err, &outputErr) && parsed != nil {
+		// The skill fell back gracefully; surface the non-conforming output
+		// on the result instead of failing the run.
+		return &SkillResult{
+			Skill:       skill.Name(),
+			Raw:         raw,
+			Parsed:      parsed,
+			Provider:    r.provider.Name(),
+			Model:       model,
+			Retries:     retries,
+			OutputError: outputErr,
+		}, nil
+	}
 	if err != nil {
 		// Return raw response even if parsing fails — the caller can still use it.
 		return &SkillResult{
@@ -64,6 +150,7 @@ func (r *SkillRunner) Run(ctx context.Context, skill skills.Skill, input interfa
 			Parsed:   nil,
 			Provider: r.provider.Name(),
 			Model:    model,
+			Retries:  retries,
 		}, fmt.Errorf("skill %q: failed to parse output (raw available): %w", skill.Name(), err)
 	}
 
@@ -73,6 +160,7 @@ func (r *SkillRunner) Run(ctx context.Context, skill skills.Skill, input interfa
 		Parsed:   parsed,
 		Provider: r.provider.Name(),
 		Model:    model,
+		Retries:  retries,
 	}, nil
 }
 
@@ -84,3 +172,12 @@ func (r *SkillRunner) RunByName(ctx context.Context, name string, input interfac
 	}
 	return r.Run(ctx, skill, input)
 }
+
+// RunStreamByName looks up a skill by name in the registry and streams it.
+func (r *SkillRunner) RunStreamByName(ctx context.Context, name string, input interface{}, onToken func(string)) (*SkillResult, error) {
+	skill, err := skills.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return r.RunStream(ctx, skill, input, onToken)
+}
@@ -3,7 +3,10 @@ package ai
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/TryCadence/Cadence/internal/ai/skills"
 )
@@ -183,6 +186,143 @@ func TestSkillRunnerReportSummary(t *testing.T) {
 	}
 }
 
+func TestSkillRunnerNonConformingOutput(t *testing.T) {
+	mock := &mockProvider{
+		name:      "mock",
+		available: true,
+		response:  `{"verdict": "probably", "reasoning": "Looks templated", "confidence": 0.6}`,
+	}
+	runner := NewSkillRunner(mock, &Config{Model: "m"})
+
+	result, err := runner.RunByName(context.Background(), "detection_triage", skills.DetectionTriageInput{})
+	if err != nil {
+		t.Fatalf("non-conforming output should fall back, not fail: %v", err)
+	}
+	if result.OutputError == nil || result.OutputError.Reason != skills.OutputSchema {
+		t.Fatalf("OutputError = %v, want a schema violation", result.OutputError)
+	}
+	triage := result.Parsed.(*skills.DetectionTriageResult)
+	if triage.Verdict != skills.TriageUncertain || triage.Reasoning != "Looks templated" {
+		t.Errorf("Parsed = %+v, want the fallback verdict with the model's reasoning", triage)
+	}
+}
+
+// usageProvider is a mockProvider whose API reports token usage.
+type usageProvider struct {
+	mockProvider
+	usage Usage
+}
+
+func (u *usageProvider) CompleteWithUsage(_ context.Context, _ CompletionRequest) (string, Usage, error) {
+	return u.response, u.usage, u.err
+}
+
+func (u *usageProvider) CompleteStream(ctx context.Context, req CompletionRequest) (<-chan StreamChunk, error) {
+	return CompleteAsStream(ctx, u, req)
+}
+
+// usageLog records RecordAITokens calls.
+type usageLog []string
+
+func (l *usageLog) RecordAITokens(provider, model string, promptTokens, completionTokens int) {
+	*l = append(*l, fmt.Sprintf("%s/%s %d+%d", provider, model, promptTokens, completionTokens))
+}
+
+func TestSkillRunnerRecordsUsage(t *testing.T) {
+	provider := &usageProvider{
+		mockProvider: mockProvider{name: "mock", available: true, response: `{"title": "Clean repo", "summary": "ok", "risk_level": "none"}`},
+		usage:        Usage{PromptTokens: 420, CompletionTokens: 37},
+	}
+	var log usageLog
+	analyzer := NewDefaultAnalyzer(provider, &Config{Model: "m"}).WithUsageRecorder(&log)
+
+	result, err := analyzer.RunSkill(context.Background(), "report_summary", skills.ReportSummaryInput{SourceType: "git"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Usage != provider.usage {
+		t.Errorf("Usage = %+v, want the call's %+v", result.Usage, provider.usage)
+	}
+	if _, err := analyzer.AnalyzeWithSystemPrompt(context.Background(), "system", "user"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	streamed, err := analyzer.RunSkillStream(context.Background(), "report_summary", skills.ReportSummaryInput{SourceType: "git"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if streamed.Usage != provider.usage {
+		t.Errorf("streamed Usage = %+v, want the call's %+v", streamed.Usage, provider.usage)
+	}
+	if len(log) != 3 || log[0] != "mock/m 420+37" || log[1] != "mock/m 420+37" || log[2] != "mock/m 420+37" {
+		t.Errorf("recorded %q, want all three calls", log)
+	}
+
+	// Providers that report no usage are not recorded.
+	log = nil
+	plain := NewDefaultAnalyzer(&mockProvider{name: "mock", available: true, response: "ok"}, &Config{Model: "m"}).WithUsageRecorder(&log)
+	if _, err := plain.AnalyzeWithSystemPrompt(context.Background(), "system", "user"); err != nil || len(log) != 0 {
+		t.Errorf("recorded %q (err %v), want nothing for a provider without usage", log, err)
+	}
+}
+
+func TestSkillRunnerRunStream(t *testing.T) {
+	mock := &mockProvider{
+		name:      "mock",
+		available: true,
+		chunks:    []string{`{"title": "Clean repo", `, `"summary": "No issues found", `, `"risk_level": "none"}`},
+	}
+	runner := NewSkillRunner(mock, &Config{Model: "m"})
+
+	var tokens []string
+	result, err := runner.RunStreamByName(context.Background(), "report_summary", skills.ReportSummaryInput{SourceType: "git"}, func(token string) {
+		tokens = append(tokens, token)
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tokens) != 3 || strings.Join(tokens, "") != result.Raw {
+		t.Errorf("tokens = %q, want the three chunks making up Raw %q", tokens, result.Raw)
+	}
+	if rsr := result.Parsed.(*skills.ReportSummaryResult); rsr.Title != "Clean repo" || rsr.RiskLevel != "none" {
+		t.Errorf("Parsed = %+v, want the streamed summary", rsr)
+	}
+}
+
+func TestSkillRunnerRunStreamInterrupted(t *testing.T) {
+	mock := &mockProvider{
+		name:      "mock",
+		available: true,
+		chunks:    []string{`{"title": "Clean repo", `},
+		streamErr: fmt.Errorf("connection reset"),
+	}
+	runner := NewSkillRunner(mock, &Config{Model: "m"})
+
+	result, err := runner.RunStreamByName(context.Background(), "report_summary", skills.ReportSummaryInput{SourceType: "git"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "connection reset") {
+		t.Fatalf("RunStream() = %+v, %v; want the stream's error", result, err)
+	}
+}
+
+func TestCompleteAsStream(t *testing.T) {
+	mock := &mockProvider{name: "mock", response: "whole response"}
+	tokens, err := CompleteAsStream(context.Background(), mock, CompletionRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for chunk := range tokens {
+		got = append(got, chunk.Text)
+	}
+	if len(got) != 1 || got[0] != "whole response" {
+		t.Errorf("tokens = %q, want the response as one fragment", got)
+	}
+
+	mock.err = fmt.Errorf("provider down")
+	if _, err := CompleteAsStream(context.Background(), mock, CompletionRequest{}); err == nil {
+		t.Error("expected Complete's error to be returned directly")
+	}
+}
+
 func TestSkillRunnerInvalidInput(t *testing.T) {
 	mock := &mockProvider{name: "mock", available: true, response: "ok"}
 	runner := NewSkillRunner(mock, &Config{Model: "m"})
@@ -193,3 +333,125 @@ func TestSkillRunnerInvalidInput(t *testing.T) {
 		t.Fatal("expected error for invalid input type")
 	}
 }
+
+// flakyProvider fails with each error in errs before returning response.
+type flakyProvider struct {
+	mockProvider
+	errs  []error
+	calls int
+}
+
+func (f *flakyProvider) Complete(_ context.Context, _ CompletionRequest) (string, error) {
+	f.calls++
+	if f.calls <= len(f.errs) {
+		return "", f.errs[f.calls-1]
+	}
+	return f.response, nil
+}
+
+func TestSkillRunnerRetriesTransientErrors(t *testing.T) {
+	mock := &flakyProvider{
+		mockProvider: mockProvider{name: "mock", available: true, response: `{"assessment": "unlikely AI-generated", "confidence": 0.2}`},
+		errs: []error{
+			&StatusError{Provider: "mock", StatusCode: http.StatusTooManyRequests},
+			&StatusError{Provider: "mock", StatusCode: http.StatusBadGateway},
+		},
+	}
+	runner := NewSkillRunner(mock, &Config{Model: "m", RetryBaseDelay: time.Millisecond})
+
+	result, err := runner.RunByName(context.Background(), "code_analysis", skills.CodeAnalysisInput{Code: "x := 1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.calls != 3 {
+		t.Errorf("expected 3 calls, got %d", mock.calls)
+	}
+	if result.Retries != 2 {
+		t.Errorf("expected Retries 2, got %d", result.Retries)
+	}
+}
+
+func TestSkillRunnerRetryLimits(t *testing.T) {
+	unavailable := &StatusError{Provider: "mock", StatusCode: http.StatusServiceUnavailable}
+
+	tests := []struct {
+		name      string
+		config    *Config
+		errs      []error
+		wantCalls int
+	}{
+		{
+			name:      "non-retryable error is not retried",
+			config:    &Config{RetryBaseDelay: time.Millisecond},
+			errs:      []error{&StatusError{Provider: "mock", StatusCode: http.StatusUnauthorized}},
+			wantCalls: 1,
+		},
+		{
+			name:      "gives up after MaxRetries",
+			config:    &Config{MaxRetries: 2, RetryBaseDelay: time.Millisecond},
+			errs:      []error{unavailable, unavailable, unavailable, unavailable},
+			wantCalls: 3,
+		},
+		{
+			name:      "negative MaxRetries disables retries",
+			config:    &Config{MaxRetries: -1},
+			errs:      []error{unavailable},
+			wantCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &flakyProvider{mockProvider: mockProvider{name: "mock", response: "ok"}, errs: tt.errs}
+			runner := NewSkillRunner(mock, tt.config)
+
+			_, err := runner.RunByName(context.Background(), "code_analysis", skills.CodeAnalysisInput{Code: "x := 1"})
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if mock.calls != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, mock.calls)
+			}
+		})
+	}
+}
+
+func TestCompleteWithRetryStopsOnCancel(t *testing.T) {
+	mock := &flakyProvider{errs: []error{&StatusError{Provider: "mock", StatusCode: http.StatusInternalServerError}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, retries, err := completeWithRetry(ctx, mock, &Config{RetryBaseDelay: time.Minute}, CompletionRequest{})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if retries != 0 || mock.calls != 1 {
+		t.Errorf("expected a single call without retries, got %d calls, %d retries", mock.calls, retries)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("cancellation was not respected during backoff (took %v)", elapsed)
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{&StatusError{StatusCode: http.StatusTooManyRequests}, true},
+		{&StatusError{StatusCode: http.StatusRequestTimeout}, true},
+		{&StatusError{StatusCode: http.StatusServiceUnavailable}, true},
+		{fmt.Errorf("wrapped: %w", &StatusError{StatusCode: http.StatusInternalServerError}), true},
+		{&StatusError{StatusCode: http.StatusBadRequest}, false},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("openai: no response choices returned"), false},
+	}
+
+	for _, tt := range tests {
+		if got := isRetryable(context.Background(), tt.err); got != tt.want {
+			t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package skills
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+func init() {
+	Register(&BatchCommitReview{})
+}
+
+const (
+	// batchMaxTokens is the response budget for one batch call.
+	batchMaxTokens = 4096
+	// batchTokensPerVerdict is roughly what one verdict object costs in the
+	// response; it caps how many commits fit in a single call.
+	batchTokensPerVerdict = 120
+	// batchPromptBudget is the combined size (in bytes) of all commit diffs
+	// included in one prompt. Each commit gets an equal share.
+	batchPromptBudget = 24000
+	// batchMinDiffShare keeps every commit's diff excerpt readable even in a
+	// full batch.
+	batchMinDiffShare = 300
+)
+
+// CommitVerdict is the assessment of one commit within a batch review.
+type CommitVerdict struct {
+	CommitHash string   `json:"commit"`
+	Assessment string   `json:"assessment"`
+	Confidence float64  `json:"confidence"`
+	Reasoning  string   `json:"reasoning"`
+	Indicators []string `json:"indicators"`
+}
+
+// BatchCommitReviewResult holds the structured output from the
+// batch_commit_review skill. Partial is set when the response was cut off
+// (typically by the token limit) and only the leading verdicts were parsed.
+type BatchCommitReviewResult struct {
+	Verdicts []CommitVerdict `json:"verdicts"`
+	Partial  bool            `json:"partial"`
+}
+
+// ByHash maps each reviewed commit hash to its verdict.
+func (r *BatchCommitReviewResult) ByHash() map[string]CommitVerdict {
+	m := make(map[string]CommitVerdict, len(r.Verdicts))
+	for _, v := range r.Verdicts {
+		m[v.CommitHash] = v
+	}
+	return m
+}
+
+// BatchCommitReview reviews several commits in one provider call. It takes a
+// []CommitReviewInput; commits beyond MaxCommits are left out of the prompt,
+// so callers with larger pushes should split them with ChunkCommitReviews.
+type BatchCommitReview struct{}
+
+func (s *BatchCommitReview) Name() string { return "batch_commit_review" }
+func (s *BatchCommitReview) Description() string {
+	return "Review multiple git commits for AI-generation indicators in a single call"
+}
+func (s *BatchCommitReview) Category() string { return "detection" }
+func (s *BatchCommitReview) MaxTokens() int   { return batchMaxTokens }
+func (s *BatchCommitReview) Schema() *Schema  { return nil }
+
+// MaxCommits is how many verdicts fit in the response token budget.
+func (s *BatchCommitReview) MaxCommits() int {
+	return batchMaxTokens / batchTokensPerVerdict
+}
+
+// ChunkCommitReviews splits commits into batches that each fit in one
+// batch_commit_review call.
+func ChunkCommitReviews(commits []CommitReviewInput) [][]CommitReviewInput {
+	size := (&BatchCommitReview{}).MaxCommits()
+	chunks := make([][]CommitReviewInput, 0, (len(commits)+size-1)/size)
+	for start := 0; start < len(commits); start += size {
+		end := start + size
+		if end > len(commits) {
+			end = len(commits)
+		}
+		chunks = append(chunks, commits[start:end])
+	}
+	return chunks
+}
+
+const batchCommitReviewSystemPrompt = `You are an expert at reviewing git commits to determine whether they were authored by a human or generated by AI.
+
+You will receive several commits. Each one starts with a line "=== COMMIT <n>/<total>: <hash> ===" and ends with "=== END COMMIT <hash> ===". Judge every commit independently, considering its message, diff shape, file scope and code quality signals, and use the other commits only as context for what is normal in this repository.
+
+Respond with a JSON array containing exactly one object per commit, in the order given:
+[
+  {
+    "commit": "<hash exactly as given>",
+    "assessment": "likely AI-generated|possibly AI-generated|unlikely AI-generated",
+    "confidence": 0.0-1.0,
+    "reasoning": "brief explanation",
+    "indicators": ["indicator1", "indicator2"]
+  }
+]`
+
+func (s *BatchCommitReview) SystemPrompt() string {
+	return batchCommitReviewSystemPrompt
+}
+
+func (s *BatchCommitReview) FormatInput(input interface{}) (string, error) {
+	commits, ok := input.([]CommitReviewInput)
+	if !ok {
+		return "", fmt.Errorf("batch_commit_review: expected []CommitReviewInput, got %T", input)
+	}
+	if len(commits) == 0 {
+		return "", fmt.Errorf("batch_commit_review: no commits to review")
+	}
+
+	omitted := 0
+	if limit := s.MaxCommits(); len(commits) > limit {
+		omitted = len(commits) - limit
+		commits = commits[:limit]
+	}
+
+	share := batchPromptBudget / len(commits)
+	if share > 2000 {
+		share = 2000
+	}
+	if share < batchMinDiffShare {
+		share = batchMinDiffShare
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Review the following %d commits.\n\n", len(commits)))
+	for i, v := range commits {
+		b.WriteString(fmt.Sprintf("=== COMMIT %d/%d: %s ===\n", i+1, len(commits), v.CommitHash))
+		if v.Author != "" {
+			b.WriteString(fmt.Sprintf("Author: %s\n", v.Author))
+		}
+		if v.Message != "" {
+			b.WriteString(fmt.Sprintf("Message: %s\n", v.Message))
+		}
+		b.WriteString(fmt.Sprintf("Stats: +%d -%d across %d files\n", v.AdditionCount, v.DeletionCount, len(v.FilesChanged)))
+
+		if len(v.FilesChanged) > 0 {
+			limit := len(v.FilesChanged)
+			if limit > 10 {
+				limit = 10
+			}
+			b.WriteString("Files: " + strings.Join(v.FilesChanged[:limit], ", "))
+			if len(v.FilesChanged) > 10 {
+				b.WriteString(fmt.Sprintf(" ... and %d more", len(v.FilesChanged)-10))
+			}
+			b.WriteString("\n")
+		}
+
+		if v.Additions != "" {
+			code := v.Additions
+			if len(code) > share {
+				code = code[:share] + "...[truncated]"
+			}
+			b.WriteString("Additions:\n" + code + "\n")
+		}
+		b.WriteString(fmt.Sprintf("=== END COMMIT %s ===\n\n", v.CommitHash))
+	}
+
+	if omitted > 0 {
+		b.WriteString(fmt.Sprintf("(%d further commits were omitted to fit the response limit.)\n\n", omitted))
+	}
+
+	b.WriteString("Provide one verdict per commit in the JSON array format specified.")
+	return b.String(), nil
+}
+
+// ParseOutput decodes the verdict array. A response that was cut off part
+// way through yields the verdicts that were complete, with Partial set.
+func (s *BatchCommitReview) ParseOutput(raw string) (interface{}, error) {
+	start := strings.Index(raw, "[")
+	if start == -1 {
+		return nil, fmt.Errorf("batch_commit_review: no JSON array in response")
+	}
+
+	result := &BatchCommitReviewResult{Verdicts: make([]CommitVerdict, 0)}
+	dec := json.NewDecoder(strings.NewReader(raw[start:]))
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("batch_commit_review: failed to parse response: %w", err)
+	}
+
+	for dec.More() {
+		var v CommitVerdict
+		if err := dec.Decode(&v); err != nil {
+			result.Partial = true
+			break
+		}
+		if v.CommitHash == "" {
+			continue
+		}
+		result.Verdicts = append(result.Verdicts, v)
+	}
+	if !result.Partial {
+		if _, err := dec.Token(); err != nil {
+			result.Partial = true
+		}
+	}
+
+	if len(result.Verdicts) == 0 {
+		return nil, fmt.Errorf("batch_commit_review: no verdicts in response")
+	}
+	return result, nil
+}
@@ -26,6 +26,7 @@ func (s *CodeAnalysis) Description() string {
 }
 func (s *CodeAnalysis) Category() string { return "detection" }
 func (s *CodeAnalysis) MaxTokens() int   { return 1024 }
+func (s *CodeAnalysis) Schema() *Schema  { return nil }
 
 func (s *CodeAnalysis) SystemPrompt() string {
 	return prompts.AnalysisSystemPrompt
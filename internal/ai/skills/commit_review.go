@@ -34,6 +34,7 @@ func (s *CommitReview) Description() string {
 }
 func (s *CommitReview) Category() string { return "detection" }
 func (s *CommitReview) MaxTokens() int   { return 1024 }
+func (s *CommitReview) Schema() *Schema  { return nil }
 
 const commitReviewSystemPrompt = `You are an expert at reviewing git commits to determine whether they were authored by a human or generated by AI.
 
@@ -0,0 +1,141 @@
+package skills
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+func init() {
+	Register(&DetectionTriage{})
+}
+
+// Triage verdicts returned by the detection_triage skill.
+const (
+	TriageFalsePositive = "false_positive"
+	TriageTruePositive  = "true_positive"
+	TriageUncertain     = "uncertain"
+)
+
+// maxTriageSnippet caps the diff or content snippet sent with a detection.
+const maxTriageSnippet = 3000
+
+// DetectionTriageInput holds the input parameters for the detection_triage skill.
+type DetectionTriageInput struct {
+	Detection analysis.Detection // The heuristic detection to critique
+	Snippet   string             // The diff or content the detection fired on
+}
+
+// DetectionTriageResult holds the structured output from the detection_triage skill.
+type DetectionTriageResult struct {
+	Verdict    string  `json:"verdict"` // false_positive, true_positive or uncertain
+	Reasoning  string  `json:"reasoning"`
+	Confidence float64 `json:"confidence"` // 0.0-1.0, confidence in the verdict
+}
+
+// DetectionTriage gives a second opinion on a heuristic detection: whether
+// the evidence really points at AI generation or the strategy misfired on
+// ordinary human work.
+type DetectionTriage struct{}
+
+func (s *DetectionTriage) Name() string { return "detection_triage" }
+func (s *DetectionTriage) Description() string {
+	return "Judge whether a heuristic detection is a false positive"
+}
+func (s *DetectionTriage) Category() string { return "detection" }
+func (s *DetectionTriage) MaxTokens() int   { return 512 }
+
+const detectionTriageSystemPrompt = `You are reviewing the output of heuristic AI-code detectors. Heuristics misfire often: large commits may be vendored code, migrations or renames; fast commits may be a rebase; generic names may be the project's own style.
+
+Given a detection and the diff or content it fired on, decide whether the detection is a false positive.
+
+- "false_positive": the evidence is explained by ordinary human work or the heuristic does not apply
+- "true_positive": the evidence really suggests AI-generated content
+- "uncertain": the snippet is not enough to tell
+
+Respond in JSON format:
+{
+  "verdict": "false_positive|true_positive|uncertain",
+  "reasoning": "brief explanation grounded in the snippet",
+  "confidence": 0.0-1.0
+}`
+
+func (s *DetectionTriage) SystemPrompt() string {
+	return detectionTriageSystemPrompt
+}
+
+func (s *DetectionTriage) FormatInput(input interface{}) (string, error) {
+	v, ok := input.(DetectionTriageInput)
+	if !ok {
+		return "", fmt.Errorf("detection_triage: expected DetectionTriageInput, got %T", input)
+	}
+
+	d := v.Detection
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Strategy: %s\n", d.Strategy))
+	if d.Category != "" {
+		b.WriteString(fmt.Sprintf("Category: %s\n", d.Category))
+	}
+	b.WriteString(fmt.Sprintf("Severity: %s\n", d.Severity))
+	b.WriteString(fmt.Sprintf("Score: %.2f\n", d.Score))
+	if d.Description != "" {
+		b.WriteString(fmt.Sprintf("Description: %s\n", d.Description))
+	}
+	if len(d.Strategies) > 0 {
+		b.WriteString(fmt.Sprintf("Strategies fired: %s\n", strings.Join(d.Strategies, ", ")))
+	}
+
+	if len(d.Examples) > 0 {
+		b.WriteString("\nEvidence:\n")
+		for _, ex := range d.Examples {
+			b.WriteString(fmt.Sprintf("  - %s\n", ex))
+		}
+	}
+
+	if v.Snippet != "" {
+		snippet := v.Snippet
+		if len(snippet) > maxTriageSnippet {
+			snippet = snippet[:maxTriageSnippet] + "\n...[truncated]"
+		}
+		b.WriteString(fmt.Sprintf("\nSnippet:\n%s\n", snippet))
+	}
+
+	b.WriteString("\nGive your verdict in the JSON format specified.")
+	return b.String(), nil
+}
+
+var detectionTriageSchema = &Schema{
+	Type:     "object",
+	Required: []string{"verdict", "reasoning", "confidence"},
+	Properties: map[string]*Schema{
+		"verdict":    {Type: "string", Enum: []string{TriageFalsePositive, TriageTruePositive, TriageUncertain}},
+		"reasoning":  {Type: "string"},
+		"confidence": {Type: "number", Minimum: bound(0), Maximum: bound(1)},
+	},
+}
+
+func (s *DetectionTriage) Schema() *Schema { return detectionTriageSchema }
+
+func (s *DetectionTriage) ParseOutput(raw string) (interface{}, error) {
+	var result DetectionTriageResult
+	err := decodeJSONObject(s.Name(), s.Schema(), raw, &result)
+	if err == nil {
+		return &result, nil
+	}
+	if err.Reason != OutputSchema {
+		return &DetectionTriageResult{Verdict: TriageUncertain, Reasoning: raw}, err
+	}
+	// Keep what conforms and coerce the rest into a usable verdict.
+	normalizeTriage(&result)
+	return &result, err
+}
+
+func normalizeTriage(result *DetectionTriageResult) {
+	switch result.Verdict {
+	case TriageFalsePositive, TriageTruePositive, TriageUncertain:
+	default:
+		result.Verdict = TriageUncertain
+	}
+	result.Confidence = min(max(result.Confidence, 0), 1)
+}
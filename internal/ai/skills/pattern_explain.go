@@ -1,7 +1,6 @@
 package skills
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -95,20 +94,27 @@ func (s *PatternExplain) FormatInput(input interface{}) (string, error) {
 	return b.String(), nil
 }
 
-func (s *PatternExplain) ParseOutput(raw string) (interface{}, error) {
-	jsonStart := strings.Index(raw, "{")
-	jsonEnd := strings.LastIndex(raw, "}")
-	if jsonStart == -1 || jsonEnd == -1 || jsonEnd <= jsonStart {
-		return &PatternExplainResult{
-			Explanation: raw,
-		}, nil
-	}
+var patternExplainSchema = &Schema{
+	Type:     "object",
+	Required: []string{"explanation", "why_it_matters", "false_positive_likelihood", "suggestions"},
+	Properties: map[string]*Schema{
+		"explanation":               {Type: "string"},
+		"why_it_matters":            {Type: "string"},
+		"false_positive_likelihood": {Type: "string", Enum: []string{"low", "medium", "high"}},
+		"suggestions":               {Type: "array", Items: &Schema{Type: "string"}},
+	},
+}
 
+func (s *PatternExplain) Schema() *Schema { return patternExplainSchema }
+
+func (s *PatternExplain) ParseOutput(raw string) (interface{}, error) {
 	var result PatternExplainResult
-	if err := json.Unmarshal([]byte(raw[jsonStart:jsonEnd+1]), &result); err != nil {
-		return &PatternExplainResult{
-			Explanation: raw,
-		}, nil
+	err := decodeJSONObject(s.Name(), s.Schema(), raw, &result)
+	if err == nil {
+		return &result, nil
+	}
+	if err.Reason != OutputSchema {
+		return &PatternExplainResult{Explanation: raw}, err
 	}
-	return &result, nil
+	return &result, err
 }
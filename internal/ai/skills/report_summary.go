@@ -1,7 +1,6 @@
 package skills
 
 import (
-	"encoding/json"
 	"fmt"
 	"strings"
 )
@@ -100,20 +99,28 @@ func (s *ReportSummary) FormatInput(input interface{}) (string, error) {
 	return b.String(), nil
 }
 
-func (s *ReportSummary) ParseOutput(raw string) (interface{}, error) {
-	jsonStart := strings.Index(raw, "{")
-	jsonEnd := strings.LastIndex(raw, "}")
-	if jsonStart == -1 || jsonEnd == -1 || jsonEnd <= jsonStart {
-		return &ReportSummaryResult{
-			Summary: raw,
-		}, nil
-	}
+var reportSummarySchema = &Schema{
+	Type:     "object",
+	Required: []string{"title", "summary", "key_findings", "risk_level", "next_steps"},
+	Properties: map[string]*Schema{
+		"title":        {Type: "string"},
+		"summary":      {Type: "string"},
+		"key_findings": {Type: "array", Items: &Schema{Type: "string"}},
+		"risk_level":   {Type: "string", Enum: []string{"none", "low", "medium", "high", "critical"}},
+		"next_steps":   {Type: "array", Items: &Schema{Type: "string"}},
+	},
+}
 
+func (s *ReportSummary) Schema() *Schema { return reportSummarySchema }
+
+func (s *ReportSummary) ParseOutput(raw string) (interface{}, error) {
 	var result ReportSummaryResult
-	if err := json.Unmarshal([]byte(raw[jsonStart:jsonEnd+1]), &result); err != nil {
-		return &ReportSummaryResult{
-			Summary: raw,
-		}, nil
+	err := decodeJSONObject(s.Name(), s.Schema(), raw, &result)
+	if err == nil {
+		return &result, nil
+	}
+	if err.Reason != OutputSchema {
+		return &ReportSummaryResult{Summary: raw}, err
 	}
-	return &result, nil
+	return &result, err
 }
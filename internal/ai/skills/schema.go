@@ -0,0 +1,184 @@
+package skills
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Schema is the subset of JSON Schema skills use to describe their JSON
+// output: types, object properties, required keys, array items, enums and
+// numeric bounds. It marshals as a standard JSON Schema document.
+type Schema struct {
+	Type        string             `json:"type,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+}
+
+// bound returns a pointer for Schema.Minimum and Schema.Maximum.
+func bound(v float64) *float64 { return &v }
+
+// Validate checks a decoded JSON value (as produced by json.Unmarshal into
+// an interface{}) against the schema and returns one message per violation,
+// each prefixed with the JSON path it applies to. A nil schema accepts
+// anything.
+func (s *Schema) Validate(value interface{}) []string {
+	var violations []string
+	s.validate("$", value, &violations)
+	return violations
+}
+
+func (s *Schema) validate(path string, value interface{}, violations *[]string) {
+	if s == nil {
+		return
+	}
+	fail := func(format string, args ...interface{}) {
+		*violations = append(*violations, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	if s.Type != "" && !schemaTypeMatches(s.Type, value) {
+		fail("expected %s, got %s", s.Type, jsonTypeName(value))
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range s.Required {
+			if _, ok := v[key]; !ok {
+				fail("missing required property %q", key)
+			}
+		}
+		keys := make([]string, 0, len(s.Properties))
+		for key := range s.Properties {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if child, ok := v[key]; ok {
+				s.Properties[key].validate(path+"."+key, child, violations)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, violations)
+		}
+	case string:
+		if len(s.Enum) > 0 && !containsString(s.Enum, v) {
+			fail("%q is not one of %s", v, strings.Join(s.Enum, ", "))
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			fail("%v is below the minimum %v", v, *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			fail("%v is above the maximum %v", v, *s.Maximum)
+		}
+	}
+}
+
+func schemaTypeMatches(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == float64(int64(f))
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonTypeName(value) == schemaType
+	}
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Reasons an OutputError reports.
+const (
+	OutputNoJSON      = "no_json"      // the response holds no JSON object
+	OutputInvalidJSON = "invalid_json" // the JSON does not parse
+	OutputSchema      = "schema"       // the JSON parses but breaks the skill's Schema
+)
+
+// OutputError reports model output that is not the JSON a skill asked for.
+// ParseOutput returns it together with a best-effort fallback result, so
+// callers can log the regression and still use the result.
+type OutputError struct {
+	Skill      string   `json:"skill"`
+	Reason     string   `json:"reason"` // OutputNoJSON, OutputInvalidJSON or OutputSchema
+	Violations []string `json:"violations,omitempty"`
+	Err        error    `json:"-"`
+}
+
+func (e *OutputError) Error() string {
+	switch e.Reason {
+	case OutputNoJSON:
+		return fmt.Sprintf("%s: response contains no JSON object", e.Skill)
+	case OutputSchema:
+		return fmt.Sprintf("%s: output does not match schema: %s", e.Skill, strings.Join(e.Violations, "; "))
+	default:
+		return fmt.Sprintf("%s: invalid JSON output: %v", e.Skill, e.Err)
+	}
+}
+
+func (e *OutputError) Unwrap() error { return e.Err }
+
+// decodeJSONObject extracts the outermost JSON object from raw, checks it
+// against schema and decodes it into dst. On a schema violation dst still
+// holds whatever decoded; on OutputNoJSON and OutputInvalidJSON the caller
+// should fall back to the raw text.
+func decodeJSONObject(skill string, schema *Schema, raw string, dst interface{}) *OutputError {
+	jsonStart := strings.Index(raw, "{")
+	jsonEnd := strings.LastIndex(raw, "}")
+	if jsonStart == -1 || jsonEnd == -1 || jsonEnd <= jsonStart {
+		return &OutputError{Skill: skill, Reason: OutputNoJSON}
+	}
+	object := []byte(raw[jsonStart : jsonEnd+1])
+
+	var doc interface{}
+	if err := json.Unmarshal(object, &doc); err != nil {
+		return &OutputError{Skill: skill, Reason: OutputInvalidJSON, Err: err}
+	}
+	violations := schema.Validate(doc)
+
+	// Type mismatches leave the offending fields zero but decode the rest;
+	// the schema reports them.
+	err := json.Unmarshal(object, dst)
+	if len(violations) > 0 {
+		return &OutputError{Skill: skill, Reason: OutputSchema, Violations: violations, Err: err}
+	}
+	if err != nil {
+		return &OutputError{Skill: skill, Reason: OutputInvalidJSON, Err: err}
+	}
+	return nil
+}
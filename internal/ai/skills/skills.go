@@ -12,8 +12,14 @@ type Skill interface {
 	Category() string
 	SystemPrompt() string
 	FormatInput(input interface{}) (string, error)
+	// ParseOutput decodes the model response. Output that is not the JSON
+	// the skill asked for yields a best-effort result along with an
+	// *OutputError.
 	ParseOutput(raw string) (interface{}, error)
 	MaxTokens() int
+	// Schema describes the JSON ParseOutput expects, or is nil for skills
+	// whose output is not validated.
+	Schema() *Schema
 }
 
 var (
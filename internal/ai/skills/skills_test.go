@@ -1,11 +1,14 @@
 package skills
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/TryCadence/Cadence/internal/ai/prompts"
+	"github.com/TryCadence/Cadence/internal/analysis"
 )
 
 // ---------------------------------------------------------------------------
@@ -13,9 +16,9 @@ import (
 // ---------------------------------------------------------------------------
 
 func TestRegistryBuiltinSkills(t *testing.T) {
-	// The init() functions should have registered all 4 built-in skills.
+	// The init() functions should have registered all 6 built-in skills.
 	names := RegisteredSkills()
-	expected := []string{"code_analysis", "commit_review", "pattern_explain", "report_summary"}
+	expected := []string{"batch_commit_review", "code_analysis", "commit_review", "detection_triage", "pattern_explain", "report_summary"}
 
 	if len(names) < len(expected) {
 		t.Fatalf("expected at least %d skills, got %d: %v", len(expected), len(names), names)
@@ -54,8 +57,8 @@ func TestRegistryGetUnknown(t *testing.T) {
 
 func TestRegistryAll(t *testing.T) {
 	all := All()
-	if len(all) < 4 {
-		t.Errorf("expected at least 4 skills, got %d", len(all))
+	if len(all) < 5 {
+		t.Errorf("expected at least 5 skills, got %d", len(all))
 	}
 	// Should be sorted by name
 	for i := 1; i < len(all); i++ {
@@ -214,6 +217,131 @@ func TestCommitReviewManyFiles(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// BatchCommitReview skill tests
+// ---------------------------------------------------------------------------
+
+func TestBatchCommitReviewMetadata(t *testing.T) {
+	s := &BatchCommitReview{}
+	if s.Name() != "batch_commit_review" {
+		t.Errorf("expected name 'batch_commit_review', got %q", s.Name())
+	}
+	if s.Category() != "detection" {
+		t.Errorf("expected category 'detection', got %q", s.Category())
+	}
+	if s.MaxCommits()*batchTokensPerVerdict > s.MaxTokens() {
+		t.Errorf("MaxCommits() = %d does not fit in MaxTokens() = %d", s.MaxCommits(), s.MaxTokens())
+	}
+}
+
+func TestBatchCommitReviewFormatInput(t *testing.T) {
+	s := &BatchCommitReview{}
+	prompt, err := s.FormatInput([]CommitReviewInput{
+		{CommitHash: "abc123", Message: "Add feature X", Additions: "func a() {}", AdditionCount: 5},
+		{CommitHash: "def456", Message: "Fix typo", AdditionCount: 1, DeletionCount: 1},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"=== COMMIT 1/2: abc123 ===", "=== END COMMIT abc123 ===",
+		"=== COMMIT 2/2: def456 ===", "=== END COMMIT def456 ===",
+		"Add feature X", "+1 -1",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected %q in prompt", want)
+		}
+	}
+
+	if _, err := s.FormatInput(CommitReviewInput{CommitHash: "abc"}); err == nil {
+		t.Error("expected error for a single CommitReviewInput")
+	}
+	if _, err := s.FormatInput([]CommitReviewInput{}); err == nil {
+		t.Error("expected error for an empty batch")
+	}
+}
+
+func TestBatchCommitReviewFormatInputLimits(t *testing.T) {
+	s := &BatchCommitReview{}
+	commits := make([]CommitReviewInput, s.MaxCommits()+6)
+	for i := range commits {
+		commits[i] = CommitReviewInput{
+			CommitHash: fmt.Sprintf("c%02d", i),
+			Additions:  strings.Repeat("x", 5000),
+		}
+	}
+
+	prompt, err := s.FormatInput(commits)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Count(prompt, "=== END COMMIT") != s.MaxCommits() {
+		t.Errorf("expected %d commits in prompt, got %d", s.MaxCommits(), strings.Count(prompt, "=== END COMMIT"))
+	}
+	if !strings.Contains(prompt, "6 further commits were omitted") {
+		t.Error("expected omitted commits note")
+	}
+	if len(prompt) > batchPromptBudget+s.MaxCommits()*300 {
+		t.Errorf("prompt length %d exceeds diff budget", len(prompt))
+	}
+
+	chunks := ChunkCommitReviews(commits)
+	if len(chunks) != 2 || len(chunks[0]) != s.MaxCommits() || len(chunks[1]) != 6 {
+		t.Errorf("unexpected chunk sizes: %d chunks", len(chunks))
+	}
+}
+
+func TestBatchCommitReviewParseOutput(t *testing.T) {
+	s := &BatchCommitReview{}
+
+	result, err := s.ParseOutput("Here are the verdicts:\n" + `[
+		{"commit": "abc123", "assessment": "likely AI-generated", "confidence": 0.9, "reasoning": "templated", "indicators": ["uniform style"]},
+		{"commit": "def456", "assessment": "unlikely AI-generated", "confidence": 0.1, "reasoning": "small fix"}
+	]`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	br, ok := result.(*BatchCommitReviewResult)
+	if !ok {
+		t.Fatalf("expected *BatchCommitReviewResult, got %T", result)
+	}
+	if br.Partial {
+		t.Error("complete response should not be partial")
+	}
+	byHash := br.ByHash()
+	if len(byHash) != 2 {
+		t.Fatalf("expected 2 verdicts, got %d", len(byHash))
+	}
+	if v := byHash["abc123"]; v.Confidence != 0.9 || v.Assessment != "likely AI-generated" || len(v.Indicators) != 1 {
+		t.Errorf("unexpected verdict for abc123: %+v", v)
+	}
+	if v := byHash["def456"]; v.Confidence != 0.1 {
+		t.Errorf("unexpected verdict for def456: %+v", v)
+	}
+}
+
+func TestBatchCommitReviewParseOutputTruncated(t *testing.T) {
+	s := &BatchCommitReview{}
+
+	result, err := s.ParseOutput(`[
+		{"commit": "abc123", "assessment": "likely AI-generated", "confidence": 0.8},
+		{"commit": "def456", "assessment": "possibly AI-gen`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	br := result.(*BatchCommitReviewResult)
+	if !br.Partial {
+		t.Error("truncated response should be marked partial")
+	}
+	if len(br.Verdicts) != 1 || br.Verdicts[0].CommitHash != "abc123" {
+		t.Errorf("expected only the complete verdict, got %+v", br.Verdicts)
+	}
+
+	if _, err := s.ParseOutput("I could not review these commits."); err == nil {
+		t.Error("expected error when the response has no array")
+	}
+}
+
 // ---------------------------------------------------------------------------
 // PatternExplain skill tests
 // ---------------------------------------------------------------------------
@@ -289,8 +417,9 @@ func TestPatternExplainParseOutput(t *testing.T) {
 
 	// Plain text fallback
 	result2, err := s.ParseOutput("This is a plain text explanation")
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	var outputErr *OutputError
+	if !errors.As(err, &outputErr) || outputErr.Reason != OutputNoJSON {
+		t.Fatalf("expected an OutputError with reason %q, got %v", OutputNoJSON, err)
 	}
 	per2, ok := result2.(*PatternExplainResult)
 	if !ok {
@@ -321,6 +450,93 @@ func TestPatternExplainExampleTruncation(t *testing.T) {
 	}
 }
 
+// ---------------------------------------------------------------------------
+// DetectionTriage skill tests
+// ---------------------------------------------------------------------------
+
+func TestDetectionTriageMetadata(t *testing.T) {
+	s := &DetectionTriage{}
+	if s.Name() != "detection_triage" {
+		t.Errorf("expected name 'detection_triage', got %q", s.Name())
+	}
+	if s.Category() != "detection" {
+		t.Errorf("expected category 'detection', got %q", s.Category())
+	}
+	if s.MaxTokens() != 512 {
+		t.Errorf("expected MaxTokens 512, got %d", s.MaxTokens())
+	}
+}
+
+func TestDetectionTriageFormatInput(t *testing.T) {
+	s := &DetectionTriage{}
+	prompt, err := s.FormatInput(DetectionTriageInput{
+		Detection: analysis.Detection{
+			Strategy:    "commit_abc123",
+			Severity:    "high",
+			Score:       0.82,
+			Category:    "git-analysis",
+			Description: "Suspicious commit abc123",
+			Examples:    []string{"abc123", "Large commit: 900 additions"},
+			Strategies:  []string{"size_analysis", "velocity_analysis"},
+		},
+		Snippet: "+func migrate() {}\n" + strings.Repeat("+INSERT INTO t VALUES (1);\n", 200),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"Severity: high", "0.82", "size_analysis, velocity_analysis", "900 additions", "+func migrate()", "[truncated]"} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("expected %q in prompt", want)
+		}
+	}
+	if len(prompt) > maxTriageSnippet+1000 {
+		t.Errorf("prompt is %d bytes, want the snippet truncated", len(prompt))
+	}
+}
+
+func TestDetectionTriageFormatInputInvalid(t *testing.T) {
+	s := &DetectionTriage{}
+	if _, err := s.FormatInput(analysis.Detection{}); err == nil {
+		t.Fatal("expected error for invalid input type")
+	}
+}
+
+func TestDetectionTriageParseOutput(t *testing.T) {
+	s := &DetectionTriage{}
+	tests := []struct {
+		name       string
+		raw        string
+		verdict    string
+		confidence float64
+		reason     string // OutputError reason, "" for conforming output
+	}{
+		{"valid JSON", `Sure: {"verdict": "false_positive", "reasoning": "Vendored SQL migration", "confidence": 0.8}`, TriageFalsePositive, 0.8, ""},
+		{"true positive", `{"verdict": "true_positive", "reasoning": "Templated handlers", "confidence": 0.65}`, TriageTruePositive, 0.65, ""},
+		{"unknown verdict", `{"verdict": "maybe", "confidence": 1.7}`, TriageUncertain, 1, OutputSchema},
+		{"plain text", "I cannot tell from this snippet", TriageUncertain, 0, OutputNoJSON},
+		{"broken JSON", `{"verdict": "true_positive",}`, TriageUncertain, 0, OutputInvalidJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.ParseOutput(tt.raw)
+			var outputErr *OutputError
+			if tt.reason == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.reason != "" && (!errors.As(err, &outputErr) || outputErr.Reason != tt.reason) {
+				t.Fatalf("expected an OutputError with reason %q, got %v", tt.reason, err)
+			}
+			triage, ok := result.(*DetectionTriageResult)
+			if !ok {
+				t.Fatalf("expected *DetectionTriageResult, got %T", result)
+			}
+			if triage.Verdict != tt.verdict || triage.Confidence != tt.confidence {
+				t.Errorf("got %q at %v, want %q at %v", triage.Verdict, triage.Confidence, tt.verdict, tt.confidence)
+			}
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // ReportSummary skill tests
 // ---------------------------------------------------------------------------
@@ -408,11 +624,86 @@ func TestReportSummaryParseOutput(t *testing.T) {
 
 	// Plain text fallback
 	result2, err := s.ParseOutput("Plain text summary")
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
+	var outputErr *OutputError
+	if !errors.As(err, &outputErr) || outputErr.Reason != OutputNoJSON {
+		t.Fatalf("expected an OutputError with reason %q, got %v", OutputNoJSON, err)
 	}
 	rsr2 := result2.(*ReportSummaryResult)
 	if rsr2.Summary != "Plain text summary" {
 		t.Errorf("expected plain text in Summary, got %q", rsr2.Summary)
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Output schema tests
+// ---------------------------------------------------------------------------
+
+func TestSchemaValidate(t *testing.T) {
+	schema := &Schema{
+		Type:     "object",
+		Required: []string{"verdict", "tags"},
+		Properties: map[string]*Schema{
+			"verdict": {Type: "string", Enum: []string{"yes", "no"}},
+			"score":   {Type: "number", Minimum: bound(0), Maximum: bound(1)},
+			"count":   {Type: "integer"},
+			"tags":    {Type: "array", Items: &Schema{Type: "string"}},
+		},
+	}
+	tests := []struct {
+		name string
+		doc  string
+		want []string
+	}{
+		{"conforming", `{"verdict": "yes", "score": 0.5, "count": 3, "tags": ["a"]}`, nil},
+		{"missing and wrong types", `{"verdict": "maybe", "score": 2, "count": 1.5, "tags": ["a", 7]}`, []string{
+			`$.count: expected integer, got number`,
+			`$.score: 2 is above the maximum 1`,
+			`$.tags[1]: expected string, got number`,
+			`$.verdict: "maybe" is not one of yes, no`,
+		}},
+		{"not an object", `["yes"]`, []string{"$: expected object, got array"}},
+		{"missing required", `{}`, []string{`$: missing required property "verdict"`, `$: missing required property "tags"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var doc interface{}
+			if err := json.Unmarshal([]byte(tt.doc), &doc); err != nil {
+				t.Fatal(err)
+			}
+			got := schema.Validate(doc)
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Validate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if got := (*Schema)(nil).Validate("anything"); got != nil {
+		t.Errorf("nil schema Validate() = %q, want no violations", got)
+	}
+}
+
+func TestSkillSchemas(t *testing.T) {
+	for _, name := range []string{"pattern_explain", "report_summary", "detection_triage"} {
+		skill, err := Get(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		schema := skill.Schema()
+		if schema == nil || schema.Type != "object" || len(schema.Required) == 0 {
+			t.Errorf("%s Schema() = %+v, want an object schema with required fields", name, schema)
+		}
+		if _, err := json.Marshal(schema); err != nil {
+			t.Errorf("%s Schema() does not marshal: %v", name, err)
+		}
+	}
+
+	// Output that parses but breaks the schema keeps the decoded fields.
+	result, err := (&ReportSummary{}).ParseOutput(`{"title": "Risky", "summary": "Many findings", "key_findings": "one", "risk_level": "severe", "next_steps": []}`)
+	var outputErr *OutputError
+	if !errors.As(err, &outputErr) || outputErr.Reason != OutputSchema || len(outputErr.Violations) != 2 {
+		t.Fatalf("ParseOutput() error = %v, want two schema violations", err)
+	}
+	if rsr := result.(*ReportSummaryResult); rsr.Title != "Risky" || rsr.Summary != "Many findings" {
+		t.Errorf("ParseOutput() = %+v, want the conforming fields decoded", rsr)
+	}
+}
@@ -0,0 +1,41 @@
+package ai
+
+import "context"
+
+// Usage is the token count of one completion as reported by the provider's
+// API. It is zero for providers that do not report usage.
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// UsageProvider is implemented by providers whose API reports token usage.
+// Analyzers call CompleteWithUsage instead of Complete when it is available.
+type UsageProvider interface {
+	CompleteWithUsage(ctx context.Context, req CompletionRequest) (string, Usage, error)
+}
+
+// UsageRecorder accumulates token usage across AI calls.
+// *analysis.AITokenMetrics implements it.
+type UsageRecorder interface {
+	RecordAITokens(provider, model string, promptTokens, completionTokens int)
+}
+
+// completeWithUsage calls the provider, through CompleteWithUsage when it
+// reports usage.
+func completeWithUsage(ctx context.Context, provider Provider, req CompletionRequest) (string, Usage, error) {
+	if up, ok := provider.(UsageProvider); ok {
+		return up.CompleteWithUsage(ctx, req)
+	}
+	text, err := provider.Complete(ctx, req)
+	return text, Usage{}, err
+}
+
+// recordUsage adds a call's usage to recorder, if there is one and the
+// provider reported any.
+func recordUsage(recorder UsageRecorder, provider, model string, usage Usage) {
+	if recorder == nil || usage == (Usage{}) {
+		return
+	}
+	recorder.RecordAITokens(provider, model, usage.PromptTokens, usage.CompletionTokens)
+}
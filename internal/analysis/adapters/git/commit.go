@@ -1,14 +1,84 @@
 package git
 
-import "time"
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	cerrors "github.com/TryCadence/Cadence/internal/errors"
+)
 
 type Commit struct {
 	Hash      string
 	Author    string
 	Email     string
 	Timestamp time.Time
-	Message   string
-	Parents   []string
+	// TZOffset is the author's UTC offset in seconds east of UTC, as
+	// recorded in the author date.
+	TZOffset int
+	Message  string
+	Parents  []string
+	// Signed reports whether the commit carries a GPG, SSH or X.509 signature.
+	Signed bool
+	// SignatureType is SignatureGPG, SignatureSSH or SignatureX509 when signed.
+	SignatureType string
+	// SignerID identifies the claimed signing key (OpenPGP fingerprint or key
+	// ID, or SSH key fingerprint). Signatures are not verified.
+	SignerID string
+	// Trailers are the "Key: value" lines closing the message, such as
+	// Co-authored-by or Signed-off-by.
+	Trailers []Trailer
+
+	// pairMerge is set by GetCommits on merge commits that are to be paired
+	// against their first parent rather than skipped.
+	pairMerge bool
+}
+
+// IsMerge reports whether the commit has more than one parent.
+func (c *Commit) IsMerge() bool {
+	return len(c.Parents) > 1
+}
+
+// Trailer is one git trailer line of a commit message.
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// String returns the trailer as it appears in the message.
+func (t Trailer) String() string {
+	return t.Key + ": " + t.Value
+}
+
+var trailerLine = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9-]*)\s*:\s*(.*)$`)
+
+// ParseTrailers returns the trailers of a commit message: its last
+// paragraph, when that is not the subject and every line in it is a
+// "Key: value" trailer or an indented continuation of one.
+func ParseTrailers(message string) []Trailer {
+	message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+	sep := strings.LastIndex(message, "\n\n")
+	if sep < 0 {
+		return nil
+	}
+
+	var trailers []Trailer
+	for _, line := range strings.Split(message[sep+2:], "\n") {
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(trailers) > 0 {
+			trailers[len(trailers)-1].Value += " " + strings.TrimSpace(line)
+			continue
+		}
+		m := trailerLine.FindStringSubmatch(line)
+		if m == nil {
+			return nil
+		}
+		trailers = append(trailers, Trailer{Key: m[1], Value: strings.TrimSpace(m[2])})
+	}
+	return trailers
 }
 
 type CommitPair struct {
@@ -17,6 +87,9 @@ type CommitPair struct {
 	TimeDelta   time.Duration
 	Stats       *DiffStats
 	DiffContent string // Actual diff content for analysis
+	// DiffSkipped is set when DiffContent was not extracted because the
+	// repository's MaxDiffBytes budget was already spent. Stats are still set.
+	DiffSkipped bool
 }
 
 type DiffStats struct {
@@ -26,9 +99,89 @@ type DiffStats struct {
 	TotalAdditions    int64
 	TotalDeletions    int64
 	FilesChangedTotal int
+	// Files breaks Additions and Deletions down by file, leaving out files
+	// matched by the exclude patterns.
+	Files []FileStat
+	// GeneratedFiles, GeneratedAdditions and GeneratedDeletions count the
+	// changes to machine-generated files, which are not part of Additions,
+	// Deletions, FilesChanged or Files.
+	GeneratedFiles     int
+	GeneratedAdditions int64
+	GeneratedDeletions int64
+}
+
+// FileStat is the lines one file gained and lost in a commit.
+type FileStat struct {
+	Path      string
+	Additions int64
+	Deletions int64
+}
+
+// TopFiles returns up to n files with the most changed lines, largest first
+// and by path on ties.
+func (s *DiffStats) TopFiles(n int) []FileStat {
+	if s == nil || n <= 0 || len(s.Files) == 0 {
+		return nil
+	}
+	files := make([]FileStat, len(s.Files))
+	copy(files, s.Files)
+	sort.SliceStable(files, func(i, j int) bool {
+		ci, cj := files[i].Additions+files[i].Deletions, files[j].Additions+files[j].Deletions
+		if ci != cj {
+			return ci > cj
+		}
+		return files[i].Path < files[j].Path
+	})
+	if len(files) > n {
+		files = files[:n]
+	}
+	return files
+}
+
+// MergeStrategy selects how merge commits are walked and paired.
+type MergeStrategy string
+
+const (
+	// MergeSkip walks every commit and leaves merge commits unpaired. It is
+	// the default.
+	MergeSkip MergeStrategy = "skip"
+	// MergeFirstParent walks only the mainline (each commit's first parent)
+	// and pairs merge commits against their first parent, so a squashed or
+	// merged pull request is analyzed as one change.
+	MergeFirstParent MergeStrategy = "first-parent"
+	// MergeBoth walks every commit and also pairs merge commits against
+	// their first parent.
+	MergeBoth MergeStrategy = "both"
+)
+
+// ParseMergeStrategy validates a merge strategy name; empty means MergeSkip.
+func ParseMergeStrategy(s string) (MergeStrategy, error) {
+	switch MergeStrategy(strings.ToLower(strings.TrimSpace(s))) {
+	case "", MergeSkip:
+		return MergeSkip, nil
+	case MergeFirstParent:
+		return MergeFirstParent, nil
+	case MergeBoth:
+		return MergeBoth, nil
+	}
+	return "", cerrors.ValidationError("unknown merge strategy").WithDetails(s + " (want skip, first-parent or both)")
 }
 
 type CommitOptions struct {
 	Branch   string
 	MaxDepth int
+	// MergeStrategy selects whether merge commits are paired against their
+	// first parent and whether side branches are walked. Empty is MergeSkip.
+	MergeStrategy MergeStrategy
+	// SinceHash stops iteration when this commit is reached; it and its
+	// ancestors are not returned. Abbreviated hashes are accepted.
+	SinceHash string
+	// FromHash is the exclusive start of a commit range: iteration stops at
+	// it, and it must be an ancestor of the walk's starting commit. Any
+	// revision git understands is accepted (hash, branch, tag, HEAD~3).
+	FromHash string
+	// ToHash is the inclusive end of a commit range and the commit the walk
+	// starts from instead of the branch head. Same revision syntax as
+	// FromHash.
+	ToHash string
 }
@@ -175,3 +175,66 @@ func TestCommitOptionsStructure(t *testing.T) {
 		}
 	})
 }
+
+func TestParseTrailers(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []Trailer
+	}{
+		{name: "subject only", message: "Fix: handle nil config"},
+		{
+			name:    "co-author",
+			message: "Add parser\n\nLonger body.\n\nCo-authored-by: GitHub Copilot <copilot@github.com>\nSigned-off-by: Jane <jane@example.com>\n",
+			want: []Trailer{
+				{Key: "Co-authored-by", Value: "GitHub Copilot <copilot@github.com>"},
+				{Key: "Signed-off-by", Value: "Jane <jane@example.com>"},
+			},
+		},
+		{
+			name:    "continuation line",
+			message: "Change\n\nAssisted-by: Claude\n  (code review)",
+			want:    []Trailer{{Key: "Assisted-by", Value: "Claude (code review)"}},
+		},
+		{name: "prose paragraph", message: "Change\n\nThis explains why.\nRefs: #12"},
+		{name: "crlf", message: "Change\r\n\r\nReviewed-by: Bob", want: []Trailer{{Key: "Reviewed-by", Value: "Bob"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseTrailers(tt.message)
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseTrailers() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("trailer %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseMergeStrategy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    MergeStrategy
+		wantErr bool
+	}{
+		{in: "", want: MergeSkip},
+		{in: "skip", want: MergeSkip},
+		{in: "first-parent", want: MergeFirstParent},
+		{in: "both", want: MergeBoth},
+		{in: "all", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseMergeStrategy(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMergeStrategy(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMergeStrategy(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package git
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+)
+
+// DefaultGeneratedFiles are the file patterns treated as machine-generated:
+// protobuf and gRPC output, minified bundles and package manager lockfiles.
+// Patterns match the base name or the full path, like ExcludeFiles.
+var DefaultGeneratedFiles = []string{
+	"*.pb.go",
+	"*.pb.gw.go",
+	"*_pb2.py",
+	"*_pb2_grpc.py",
+	"*.pb.cc",
+	"*.pb.h",
+	"*.min.*",
+	"*.bundle.js",
+	"package-lock.json",
+	"npm-shrinkwrap.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"bun.lockb",
+	"go.sum",
+	"Cargo.lock",
+	"Gemfile.lock",
+	"composer.lock",
+	"poetry.lock",
+	"Pipfile.lock",
+	"uv.lock",
+	"mix.lock",
+	"pubspec.lock",
+	"Podfile.lock",
+	"packages.lock.json",
+}
+
+// generatedHeaderLines is how many lines from the top of a file are
+// searched for a generated-code marker. Go requires the marker before the
+// package clause; other generators put it in the first comment block.
+const generatedHeaderLines = 10
+
+// generatedHeader matches the conventional generated-code markers: Go's
+// "// Code generated ... DO NOT EDIT." line in any comment syntax, and the
+// "@generated" tag used by Facebook and Rust tooling.
+var generatedHeader = regexp.MustCompile(`^\s*(?://|#|--|;|/?\*+|<!--)\s*(?:Code generated .*DO NOT EDIT|.*@generated\b)`)
+
+// HasGeneratedHeader reports whether content starts with a generated-code
+// marker within its first few lines.
+func HasGeneratedHeader(content string) bool {
+	lines := strings.SplitN(content, "\n", generatedHeaderLines+1)
+	return hasGeneratedHeader(lines[:min(len(lines), generatedHeaderLines)])
+}
+
+func hasGeneratedHeader(lines []string) bool {
+	for _, line := range lines {
+		if generatedHeader.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// isGeneratedFile reports whether a changed file is machine-generated,
+// either by name or by a marker at the top of its content. The marker is
+// read from the patch: the new version's leading lines, or the old
+// version's when the file was deleted.
+func (r *gitRepository) isGeneratedFile(filePath string, chunks []diff.Chunk, deleted bool) bool {
+	if r.countGenerated {
+		return false
+	}
+	if matchesAny(r.generatedFiles, filepath.Base(filePath)) || matchesAny(r.generatedFiles, filePath) {
+		return true
+	}
+	return hasGeneratedHeader(patchHead(chunks, deleted))
+}
+
+// patchHead returns the first lines of the file version a patch produces,
+// or of the version it removes when deleted is set.
+func patchHead(chunks []diff.Chunk, deleted bool) []string {
+	skip := diff.Delete
+	if deleted {
+		skip = diff.Add
+	}
+
+	head := make([]string, 0, generatedHeaderLines)
+	for _, chunk := range chunks {
+		if chunk.Type() == skip {
+			continue
+		}
+		lines := strings.SplitAfter(chunk.Content(), "\n")
+		for _, line := range lines {
+			if line == "" {
+				continue
+			}
+			head = append(head, strings.TrimSuffix(line, "\n"))
+			if len(head) == generatedHeaderLines {
+				return head
+			}
+		}
+	}
+	return head
+}
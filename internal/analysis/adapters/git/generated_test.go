@@ -0,0 +1,97 @@
+package git
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHasGeneratedHeader(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"go generator", "// Code generated by protoc-gen-go. DO NOT EDIT.\n// source: api.proto\n\npackage api\n", true},
+		{"go generator without period", "// Code generated by mockery v2 DO NOT EDIT\npackage mocks\n", true},
+		{"after build tags", "//go:build linux\n\n// Code generated by cmd/cgo -godefs; DO NOT EDIT.\n\npackage unix\n", true},
+		{"hash comment", "# Code generated by sqlc. DO NOT EDIT.\nSELECT 1;\n", true},
+		{"block comment", "/*\n * Code generated by openapi-generator. DO NOT EDIT.\n */\n", true},
+		{"generated tag", "// @generated by buf from api.proto\nexport const x = 1;\n", true},
+		{"hand written", "package main\n\nfunc main() {}\n", false},
+		{"marker in string", "package main\n\nconst s = \"// Code generated by x. DO NOT EDIT.\"\n", false},
+		{"marker below header window", strings.Repeat("line\n", generatedHeaderLines) + "// Code generated by x. DO NOT EDIT.\n", false},
+		{"prose mention", "// This file is not generated; DO NOT EDIT the copyright notice.\n", false},
+		{"empty", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HasGeneratedHeader(tt.content); got != tt.want {
+				t.Errorf("HasGeneratedHeader() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitRepository_GeneratedFiles(t *testing.T) {
+	header := "// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage api\n"
+	dir := createFilesRepo(t,
+		map[string]string{
+			"main.go":        "package main\n",
+			"api/service.go": header + "type A struct{}\n",
+		},
+		map[string]string{
+			"main.go":         "package main\nfunc main() {}\nvar x = 1\n",
+			"api/service.go":  header + "type A struct{}\ntype B struct{}\ntype C struct{}\n",
+			"api/client.go":   header + "type Client struct{}\n",
+			"web/app.min.js":  "var a=1;\nvar b=2;\n",
+			"yarn.lock":       strings.Repeat("dep@1.0.0:\n", 20),
+			"pkg/schema.sql":  "-- @generated by migrate\nCREATE TABLE t (id int);\n",
+			"docs/ignored.md": "excluded\n",
+		},
+	)
+
+	t.Run("generated files are counted separately", func(t *testing.T) {
+		pairs := commitPairsWith(t, dir, &RepositoryOptions{ExcludeFiles: []string{"*.md"}})
+		if len(pairs) != 1 {
+			t.Fatalf("len(pairs) = %d, want 1", len(pairs))
+		}
+		stats := pairs[0].Stats
+
+		if stats.Additions != 2 || stats.FilesChanged != 1 {
+			t.Errorf("Additions = %d, FilesChanged = %d, want only main.go's 2 lines in 1 file", stats.Additions, stats.FilesChanged)
+		}
+		if len(stats.Files) != 1 || stats.Files[0].Path != "main.go" {
+			t.Errorf("Files = %+v, want only main.go", stats.Files)
+		}
+		// service.go (header in unchanged context), client.go, app.min.js,
+		// yarn.lock and schema.sql; the excluded docs are not counted at all.
+		if stats.GeneratedFiles != 5 {
+			t.Errorf("GeneratedFiles = %d, want 5", stats.GeneratedFiles)
+		}
+		if want := int64(2 + 3 + 2 + 20 + 2); stats.GeneratedAdditions != want {
+			t.Errorf("GeneratedAdditions = %d, want %d", stats.GeneratedAdditions, want)
+		}
+		if strings.Contains(pairs[0].DiffContent, "type Client") || strings.Contains(pairs[0].DiffContent, "yarn.lock") {
+			t.Error("DiffContent should leave out generated files")
+		}
+		if !strings.Contains(pairs[0].DiffContent, "func main()") {
+			t.Error("DiffContent should keep hand-written files")
+		}
+	})
+
+	t.Run("extra patterns", func(t *testing.T) {
+		pairs := commitPairsWith(t, dir, &RepositoryOptions{ExcludeFiles: []string{"*.md"}, GeneratedFiles: []string{"main.go"}})
+		if stats := pairs[0].Stats; stats.Additions != 0 || stats.GeneratedFiles != 6 {
+			t.Errorf("Additions = %d, GeneratedFiles = %d, want main.go treated as generated", stats.Additions, stats.GeneratedFiles)
+		}
+	})
+
+	t.Run("filter disabled", func(t *testing.T) {
+		pairs := commitPairsWith(t, dir, &RepositoryOptions{ExcludeFiles: []string{"*.md"}, CountGeneratedFiles: true})
+		stats := pairs[0].Stats
+		if stats.GeneratedFiles != 0 || stats.Additions != 31 || stats.FilesChanged != 6 {
+			t.Errorf("Additions = %d, FilesChanged = %d, GeneratedFiles = %d, want every non-excluded file counted",
+				stats.Additions, stats.FilesChanged, stats.GeneratedFiles)
+		}
+	})
+}
@@ -0,0 +1,253 @@
+package git
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// githubNoreplyDomain is the domain of the private commit emails GitHub
+// hands out, e.g. 1234567+octocat@users.noreply.github.com.
+const githubNoreplyDomain = "users.noreply.github.com"
+
+// sharedMailDomains are domains used by unrelated people, so an address on
+// one says nothing about who its author is.
+var sharedMailDomains = map[string]bool{
+	githubNoreplyDomain: true,
+	"gmail.com":         true,
+	"googlemail.com":    true,
+	"outlook.com":       true,
+	"hotmail.com":       true,
+	"yahoo.com":         true,
+	"icloud.com":        true,
+	"proton.me":         true,
+	"protonmail.com":    true,
+}
+
+// IdentityRules controls how commit author emails are normalized and
+// grouped into identities, so one person committing from several addresses
+// counts as one author.
+type IdentityRules struct {
+	// Lowercase compares emails case-insensitively.
+	Lowercase bool
+	// StripPlusTag drops a "+tag" suffix from the local part
+	// (jane+ci@example.com is jane@example.com).
+	StripPlusTag bool
+	// MapNoreply reduces GitHub noreply addresses to the login they carry
+	// (1234567+jane@users.noreply.github.com is jane).
+	MapNoreply bool
+	// MatchNames also groups addresses on different domains when their
+	// local parts match and the author names agree, and addresses on the
+	// same organization domain whose authors share the same full name.
+	MatchNames bool
+}
+
+// DefaultIdentityRules is the normalization NewGitRepositorySource applies.
+var DefaultIdentityRules = IdentityRules{
+	Lowercase:    true,
+	StripPlusTag: true,
+	MapNoreply:   true,
+	MatchNames:   true,
+}
+
+// NormalizeEmail applies rules to email and returns the normalized address
+// and its local part. Noreply addresses mapped by MapNoreply keep the
+// noreply domain.
+func NormalizeEmail(email string, rules IdentityRules) (normalized, local string) {
+	email = strings.TrimSpace(email)
+	if rules.Lowercase {
+		email = strings.ToLower(email)
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email, email
+	}
+	local, domain := email[:at], email[at+1:]
+
+	if rules.MapNoreply && strings.EqualFold(domain, githubNoreplyDomain) {
+		// Newer noreply addresses are "<id>+<login>", older ones "<login>".
+		if i := strings.Index(local, "+"); i >= 0 {
+			local = local[i+1:]
+		}
+	} else if rules.StripPlusTag {
+		if i := strings.Index(local, "+"); i > 0 {
+			local = local[:i]
+		}
+	}
+	return local + "@" + domain, local
+}
+
+// AuthorIdentities maps commit author emails to the identity they belong
+// to. A nil AuthorIdentities treats every email as its own identity.
+type AuthorIdentities struct {
+	keys  map[string]string
+	count int
+}
+
+// Resolve returns the identity key for email. Emails that were not
+// clustered resolve to themselves.
+func (a *AuthorIdentities) Resolve(email string) string {
+	if a == nil {
+		return email
+	}
+	if key, ok := a.keys[email]; ok {
+		return key
+	}
+	return email
+}
+
+// Count returns the number of distinct identities.
+func (a *AuthorIdentities) Count() int {
+	if a == nil {
+		return 0
+	}
+	return a.count
+}
+
+// ClusterAuthors groups the author emails of commits into identities.
+// Emails equal after NormalizeEmail always share an identity; with
+// MatchNames, emails whose local parts match are grouped when their author
+// names agree, and authors sharing a full name of two or more words are
+// grouped when their emails are on the same domain. A shared name alone is
+// not enough, and neither is a shared public domain such as gmail.com.
+func ClusterAuthors(commits []*Commit, rules IdentityRules) *AuthorIdentities {
+	type node struct {
+		local  string
+		domain string
+		names  map[string]bool
+		parent string
+	}
+	nodes := make(map[string]*node)
+	normalized := make(map[string]string)
+
+	for _, c := range commits {
+		norm, local := NormalizeEmail(c.Email, rules)
+		normalized[c.Email] = norm
+		n, ok := nodes[norm]
+		if !ok {
+			n = &node{local: local, domain: emailDomain(norm), names: make(map[string]bool), parent: norm}
+			nodes[norm] = n
+		}
+		if name := normalizeName(c.Author); name != "" {
+			n.names[name] = true
+		}
+	}
+
+	var find func(string) string
+	find = func(key string) string {
+		n := nodes[key]
+		if n.parent != key {
+			n.parent = find(n.parent)
+		}
+		return n.parent
+	}
+	union := func(a, b string) {
+		ra, rb := find(a), find(b)
+		if ra == rb {
+			return
+		}
+		// Keep the smallest key as the root so identity keys are stable.
+		if rb < ra {
+			ra, rb = rb, ra
+		}
+		nodes[rb].parent = ra
+	}
+
+	keys := make([]string, 0, len(nodes))
+	for key := range nodes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if rules.MatchNames {
+		byLocal := make(map[string][]string)
+		byName := make(map[string][]string)
+		for _, key := range keys {
+			n := nodes[key]
+			for _, other := range byLocal[n.local] {
+				if namesAgree(n.names, nodes[other].names) {
+					union(key, other)
+				}
+			}
+			byLocal[n.local] = append(byLocal[n.local], key)
+
+			for name := range n.names {
+				if !strings.Contains(name, " ") {
+					continue
+				}
+				for _, other := range byName[name] {
+					if sameOrganization(n.domain, nodes[other].domain) {
+						union(key, other)
+					}
+				}
+				byName[name] = append(byName[name], key)
+			}
+		}
+	}
+
+	identities := &AuthorIdentities{keys: make(map[string]string, len(normalized))}
+	for email, norm := range normalized {
+		identities.keys[email] = find(norm)
+	}
+	for _, key := range keys {
+		if find(key) == key {
+			identities.count++
+		}
+	}
+	return identities
+}
+
+// emailDomain returns the part of a normalized email after the last "@", or
+// "" when there is none.
+func emailDomain(email string) string {
+	if at := strings.LastIndex(email, "@"); at >= 0 {
+		return strings.ToLower(email[at+1:])
+	}
+	return ""
+}
+
+// sameOrganization reports whether two email domains are the same and
+// specific enough to tie two authors together.
+func sameOrganization(a, b string) bool {
+	return a != "" && a == b && !sharedMailDomains[a]
+}
+
+// normalizeName lowercases name and reduces it to space-separated words of
+// letters and digits.
+func normalizeName(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), " ")
+}
+
+// namesAgree reports whether two sets of normalized author names could be
+// the same person: either side is unnamed, or one name's words are all part
+// of the other's ("jane" and "jane doe").
+func namesAgree(a, b map[string]bool) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for x := range a {
+		for y := range b {
+			if wordsWithin(x, y) || wordsWithin(y, x) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// wordsWithin reports whether every word of a appears in b.
+func wordsWithin(a, b string) bool {
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(b) {
+		words[w] = true
+	}
+	for _, w := range strings.Fields(a) {
+		if !words[w] {
+			return false
+		}
+	}
+	return true
+}
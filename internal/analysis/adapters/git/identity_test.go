@@ -0,0 +1,91 @@
+package git
+
+import "testing"
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email     string
+		rules     IdentityRules
+		want      string
+		wantLocal string
+	}{
+		{"Jane.Doe@Example.com", DefaultIdentityRules, "jane.doe@example.com", "jane.doe"},
+		{"jane+ci@example.com", DefaultIdentityRules, "jane@example.com", "jane"},
+		{"1234567+jane@users.noreply.github.com", DefaultIdentityRules, "jane@users.noreply.github.com", "jane"},
+		{"jane@users.noreply.github.com", DefaultIdentityRules, "jane@users.noreply.github.com", "jane"},
+		{"+tag@example.com", DefaultIdentityRules, "+tag@example.com", "+tag"},
+		{"Jane+ci@Example.com", IdentityRules{}, "Jane+ci@Example.com", "Jane+ci"},
+		{"1234567+jane@users.noreply.github.com", IdentityRules{StripPlusTag: true}, "1234567@users.noreply.github.com", "1234567"},
+		{"not-an-email", DefaultIdentityRules, "not-an-email", "not-an-email"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			got, local := NormalizeEmail(tt.email, tt.rules)
+			if got != tt.want || local != tt.wantLocal {
+				t.Errorf("NormalizeEmail(%q) = %q, %q, want %q, %q", tt.email, got, local, tt.want, tt.wantLocal)
+			}
+		})
+	}
+}
+
+func TestClusterAuthors(t *testing.T) {
+	aliases := []*Commit{
+		{Author: "Jane Doe", Email: "jane@work.com"},
+		{Author: "jane doe", Email: "Jane@personal.com"},
+		{Author: "jane", Email: "1234567+jane@users.noreply.github.com"},
+		{Author: "Jane Doe", Email: "jane+ci@work.com"},
+		{Author: "Jane Doe", Email: "jdoe@work.com"},
+	}
+	// A shared full name needs a shared organization domain as well.
+	others := []*Commit{
+		{Author: "John Smith", Email: "jane@elsewhere.org"},
+		{Author: "Bob", Email: "bob@work.com"},
+		{Author: "John Smith", Email: "john@acme.com"},
+		{Author: "John Smith", Email: "jsmith@globex.com"},
+		{Author: "Ann Lee", Email: "ann.lee@gmail.com"},
+		{Author: "Ann Lee", Email: "annlee84@gmail.com"},
+	}
+	commits := append(append([]*Commit{}, aliases...), others...)
+
+	t.Run("aliases collapse to one identity", func(t *testing.T) {
+		identities := ClusterAuthors(commits, DefaultIdentityRules)
+		if got := identities.Count(); got != 7 {
+			t.Errorf("Count() = %d, want 7 (Jane and each of the others)", got)
+		}
+		key := identities.Resolve(aliases[0].Email)
+		for _, c := range aliases[1:] {
+			if got := identities.Resolve(c.Email); got != key {
+				t.Errorf("Resolve(%q) = %q, want %q like the other aliases", c.Email, got, key)
+			}
+		}
+		for _, c := range others {
+			if identities.Resolve(c.Email) == key {
+				t.Errorf("Resolve(%q) grouped %s with Jane", c.Email, c.Author)
+			}
+		}
+	})
+
+	t.Run("without name matching only normalized emails merge", func(t *testing.T) {
+		rules := DefaultIdentityRules
+		rules.MatchNames = false
+		identities := ClusterAuthors(commits, rules)
+		// jane@work.com and jane+ci@work.com are the only equal pair.
+		if got := identities.Count(); got != 10 {
+			t.Errorf("Count() = %d, want 10", got)
+		}
+	})
+
+	t.Run("zero rules keep raw emails apart", func(t *testing.T) {
+		identities := ClusterAuthors(commits, IdentityRules{})
+		if got := identities.Count(); got != len(commits) {
+			t.Errorf("Count() = %d, want %d", got, len(commits))
+		}
+	})
+
+	t.Run("nil identities resolve to the email", func(t *testing.T) {
+		var identities *AuthorIdentities
+		if got := identities.Resolve("a@b.c"); got != "a@b.c" || identities.Count() != 0 {
+			t.Errorf("Resolve() = %q, Count() = %d on nil identities", got, identities.Count())
+		}
+	})
+}
@@ -104,11 +104,22 @@ func (s *CommitMessageStrategy) Detect(pair *git.CommitPair, repoStats *metrics.
 }
 
 type NamingPatternStrategy struct {
-	enabled bool
+	enabled  bool
+	profiles map[string]LanguageProfile
 }
 
 func NewNamingPatternStrategy() *NamingPatternStrategy {
-	return &NamingPatternStrategy{enabled: true}
+	return NewNamingPatternStrategyWithProfiles(nil)
+}
+
+// NewNamingPatternStrategyWithProfiles creates a naming strategy that judges
+// identifier style and error-handling density against the dominant language
+// of each diff. A nil map uses LanguageProfiles.
+func NewNamingPatternStrategyWithProfiles(profiles map[string]LanguageProfile) *NamingPatternStrategy {
+	if profiles == nil {
+		profiles = LanguageProfiles
+	}
+	return &NamingPatternStrategy{enabled: true, profiles: profiles}
 }
 
 func (s *NamingPatternStrategy) Name() string        { return "naming_pattern_analysis" }
@@ -169,6 +180,7 @@ func (s *NamingPatternStrategy) analyzeCodeContent(diffContent string) (isSuspic
 	suspiciousPatterns := 0
 	totalPatterns := 0
 
+	profile := dominantLanguageProfile(diffContent, s.profiles)
 	codeContent := strings.Join(addedLines, "\n")
 
 	genericVarPatterns := []string{
@@ -195,21 +207,23 @@ func (s *NamingPatternStrategy) analyzeCodeContent(diffContent string) (isSuspic
 			perfectCamelCaseCount++
 		}
 	}
-	if len(words) > 10 && float64(perfectCamelCaseCount)/float64(len(words)) > 0.3 {
+	// camelCase is only notable where it is not the language's own convention.
+	if !profile.CamelCaseIdentifiers && len(words) > 10 && float64(perfectCamelCaseCount)/float64(len(words)) > 0.3 {
 		suspiciousPatterns++
 	}
 
-	if strings.Contains(codeContent, "catch") || strings.Contains(codeContent, "except") {
-		errorHandlingCount := strings.Count(codeContent, "catch") + strings.Count(codeContent, "except") + strings.Count(codeContent, "try")
-		if errorHandlingCount > len(addedLines)/20 {
+	// Error handling well above the language's normal density.
+	if profile.LinesPerErrorMarker > 0 {
+		errorHandlingCount := profile.countErrorHandlingLines(strings.Split(strings.ToLower(codeContent), "\n"))
+		if errorHandlingCount > 0 && float64(errorHandlingCount) > 1.5*float64(len(addedLines))/float64(profile.LinesPerErrorMarker) {
 			suspiciousPatterns++
 		}
 	}
 
 	if suspiciousPatterns >= 2 {
 		return true, fmt.Sprintf(
-			"Code contains multiple AI-slop patterns (%d detected) - generic names, TODO comments, perfect patterns",
-			suspiciousPatterns,
+			"Code contains multiple AI-slop patterns (%d detected, %s profile) - generic names, TODO comments, perfect patterns",
+			suspiciousPatterns, profile.Name,
 		)
 	}
 
@@ -312,11 +326,22 @@ func (s *BurstPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.R
 }
 
 type ErrorHandlingPatternStrategy struct {
-	enabled bool
+	enabled  bool
+	profiles map[string]LanguageProfile
 }
 
 func NewErrorHandlingPatternStrategy() *ErrorHandlingPatternStrategy {
-	return &ErrorHandlingPatternStrategy{enabled: true}
+	return NewErrorHandlingPatternStrategyWithProfiles(nil)
+}
+
+// NewErrorHandlingPatternStrategyWithProfiles creates an error-handling
+// strategy that selects markers and expected density from the dominant
+// language of each diff. A nil map uses LanguageProfiles.
+func NewErrorHandlingPatternStrategyWithProfiles(profiles map[string]LanguageProfile) *ErrorHandlingPatternStrategy {
+	if profiles == nil {
+		profiles = LanguageProfiles
+	}
+	return &ErrorHandlingPatternStrategy{enabled: true, profiles: profiles}
 }
 
 func (s *ErrorHandlingPatternStrategy) Name() string        { return "error_handling_analysis" }
@@ -370,38 +395,28 @@ func (s *ErrorHandlingPatternStrategy) analyzeErrorHandling(diffContent string,
 		return false, ""
 	}
 
-	codeContent := strings.ToLower(strings.Join(addedLines, "\n"))
-
-	errorHandlingPatterns := 0
-
-	errorHandlingPatterns += strings.Count(codeContent, "try")
-	errorHandlingPatterns += strings.Count(codeContent, "catch")
-	errorHandlingPatterns += strings.Count(codeContent, "except")
-	errorHandlingPatterns += strings.Count(codeContent, "throw")
-	errorHandlingPatterns += strings.Count(codeContent, "throws")
-
-	errorHandlingPatterns += strings.Count(codeContent, "error")
-	errorHandlingPatterns += strings.Count(codeContent, "exception")
-	errorHandlingPatterns += strings.Count(codeContent, "handle")
-
-	errorHandlingPatterns += strings.Count(codeContent, "if err != nil")
-	errorHandlingPatterns += strings.Count(codeContent, ".catch(") // JavaScript promises
-	errorHandlingPatterns += strings.Count(codeContent, "rescue")  // Ruby
-
-	// Calculate expected error handling density
-	expectedErrorHandling := len(addedLines) / 30
+	profile := dominantLanguageProfile(diffContent, s.profiles)
+	lowered := make([]string, len(addedLines))
+	for i, line := range addedLines {
+		lowered[i] = strings.ToLower(line)
+	}
+	errorHandlingLines := profile.countErrorHandlingLines(lowered)
 
-	if additions > 100 && errorHandlingPatterns < expectedErrorHandling {
-		return true, fmt.Sprintf(
-			"Large code addition (%d lines) with insufficient error handling (%d patterns, expected ~%d) - typical AI omission",
-			additions, errorHandlingPatterns, expectedErrorHandling,
-		)
+	// Calculate expected error handling density for the language
+	if profile.LinesPerErrorMarker > 0 {
+		expectedErrorHandling := len(addedLines) / profile.LinesPerErrorMarker
+		if additions > 100 && errorHandlingLines < expectedErrorHandling {
+			return true, fmt.Sprintf(
+				"Large code addition (%d lines) with insufficient error handling (%d lines, expected ~%d for %s profile) - typical AI omission",
+				additions, errorHandlingLines, expectedErrorHandling, profile.Name,
+			)
+		}
 	}
 
-	if errorHandlingPatterns > len(addedLines)/5 {
+	if profile.MaxErrorMarkerRatio > 0 && float64(errorHandlingLines) > profile.MaxErrorMarkerRatio*float64(len(addedLines)) {
 		return true, fmt.Sprintf(
-			"Excessive error handling patterns (%d in %d lines) - may indicate AI over-compensation",
-			errorHandlingPatterns, len(addedLines),
+			"Excessive error handling patterns (%d in %d lines, %s profile) - may indicate AI over-compensation",
+			errorHandlingLines, len(addedLines), profile.Name,
 		)
 	}
 
@@ -13,6 +13,12 @@ type StatisticalAnomalyStrategy struct {
 	baselinePairs   []*git.CommitPair
 	baselineUpdated bool
 	enabled         bool
+	// historical, when set, is scored against instead of the baseline
+	// computed from the analyzed pairs.
+	historical *analysis.RepositoryBaseline
+	// gate sets how many baseline commits anomalies need (see
+	// analysis.SampleSizeGate).
+	gate analysis.SampleSizeGate
 }
 
 func NewStatisticalAnomalyStrategy() *StatisticalAnomalyStrategy {
@@ -21,6 +27,29 @@ func NewStatisticalAnomalyStrategy() *StatisticalAnomalyStrategy {
 	}
 }
 
+// NewStatisticalAnomalyStrategyWithBaseline scores commits against a
+// baseline from an earlier analysis (see analysis.LoadBaselineProfile), so
+// a repository whose commits have grown across the board still stands out.
+// An empty historical baseline falls back to the computed one.
+func NewStatisticalAnomalyStrategyWithBaseline(historical *analysis.RepositoryBaseline) *StatisticalAnomalyStrategy {
+	s := NewStatisticalAnomalyStrategy()
+	if historical != nil && historical.SampleSize > 0 {
+		s.historical = historical
+		s.baseline = historical
+	}
+	return s
+}
+
+// NewStatisticalAnomalyStrategyWithGate is NewStatisticalAnomalyStrategyWithBaseline
+// with the baseline sample sizes set by gate. Below gate.MinCommits no
+// commit is flagged; below gate.ReliableCommits only significant anomalies
+// are, with a caveat in the reason.
+func NewStatisticalAnomalyStrategyWithGate(historical *analysis.RepositoryBaseline, gate analysis.SampleSizeGate) *StatisticalAnomalyStrategy {
+	s := NewStatisticalAnomalyStrategyWithBaseline(historical)
+	s.gate = gate
+	return s
+}
+
 func (s *StatisticalAnomalyStrategy) Name() string        { return "StatisticalAnomaly" }
 func (s *StatisticalAnomalyStrategy) Category() string    { return "statistical" }
 func (s *StatisticalAnomalyStrategy) Confidence() float64 { return 0.8 }
@@ -37,7 +66,7 @@ func (s *StatisticalAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *met
 		return false, ""
 	}
 
-	anomalies := analysis.DetectStatisticalAnomalies(pair, s.baseline)
+	anomalies := s.gate.DetectStatisticalAnomalies(pair, s.baseline)
 
 	if len(anomalies) == 0 {
 		return false, ""
@@ -51,15 +80,20 @@ func (s *StatisticalAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *met
 	}
 
 	if len(significantAnomalies) > 0 {
-		return true, fmt.Sprintf("Statistical anomalies detected: %s (z-score: %.2f, baseline: %.0f, observed: %.0f)",
+		reason := fmt.Sprintf("Statistical anomalies detected: %s (z-score: %.2f, baseline: %.0f, observed: %.0f)",
 			significantAnomalies[0].Description,
 			significantAnomalies[0].Score,
 			significantAnomalies[0].BaselineValue,
 			significantAnomalies[0].ObservedValue,
 		)
+		if significantAnomalies[0].LowConfidence {
+			reason += " - " + significantAnomalies[0].Caveat
+		}
+		return true, reason
 	}
 
-	if len(anomalies) > 0 {
+	// A moderate deviation from a small baseline is mostly noise.
+	if len(anomalies) > 0 && !anomalies[0].LowConfidence {
 		return true, fmt.Sprintf("Moderate statistical deviation: %s (z-score: %.2f)",
 			anomalies[0].Description,
 			anomalies[0].Score,
@@ -71,12 +105,24 @@ func (s *StatisticalAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *met
 
 func (s *StatisticalAnomalyStrategy) SetBaseline(pairs []*git.CommitPair) {
 	s.baselinePairs = pairs
+	if s.historical != nil {
+		return
+	}
 	if len(pairs) > 0 {
 		s.baseline = analysis.CalculateBaseline(pairs)
 		s.baselineUpdated = true
 	}
 }
 
+// Caveat returns the gate's caveat for the baseline commits are scored
+// against, or "" when there is none or it is large enough to trust.
+func (s *StatisticalAnomalyStrategy) Caveat() string {
+	if s.baseline == nil {
+		return ""
+	}
+	return s.gate.Caveat(s.baseline)
+}
+
 type TimingAnomalyStrategy struct {
 	enabled bool
 }
@@ -0,0 +1,229 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+const (
+	// boilerplateMinFiles is the fewest new files sharing a header that is
+	// worth reporting.
+	boilerplateMinFiles = 3
+	// boilerplateMinHeaderLines is the shortest header compared; files
+	// starting with one or two lines say little about a template.
+	boilerplateMinHeaderLines = 3
+	// boilerplateSimilarity is the Dice coefficient of normalized header
+	// lines at which two headers count as near-identical. A shared license
+	// banner followed by a few file-specific lines still matches.
+	boilerplateSimilarity = 0.7
+	// boilerplateMaxFiles caps how many new files are compared pairwise.
+	boilerplateMaxFiles = 200
+)
+
+// BoilerplateHeaderStrategy compares the first lines of every file a commit
+// creates. Scaffolding tools and AI assistants tend to open each new file
+// with the same license banner, doc comment or skeleton, so a commit where
+// most new files share a near-identical header was likely stamped out from
+// a template. FileExtensionPatternStrategy looks at file sizes; this looks
+// at their content.
+type BoilerplateHeaderStrategy struct {
+	headerLines int
+	minShare    float64
+}
+
+// NewBoilerplateHeaderStrategy creates a strategy that compares the first
+// headerLines non-blank lines of each new file (0 = 10) and flags commits
+// where at least minShare (0-1, 0 = 0.6) of the new files share a header.
+func NewBoilerplateHeaderStrategy(headerLines int, minShare float64) *BoilerplateHeaderStrategy {
+	if headerLines <= 0 {
+		headerLines = 10
+	}
+	if minShare <= 0 {
+		minShare = 0.6
+	}
+	return &BoilerplateHeaderStrategy{
+		headerLines: headerLines,
+		minShare:    minShare,
+	}
+}
+
+func (s *BoilerplateHeaderStrategy) Name() string        { return "boilerplate_header_analysis" }
+func (s *BoilerplateHeaderStrategy) Category() string    { return "structural" }
+func (s *BoilerplateHeaderStrategy) Confidence() float64 { return 0.5 }
+func (s *BoilerplateHeaderStrategy) Description() string {
+	return "Detects commits whose new files mostly open with the same boilerplate header"
+}
+
+func (s *BoilerplateHeaderStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.DiffContent == "" {
+		return false, ""
+	}
+
+	headers := newFileHeaders(pair.DiffContent, s.headerLines)
+	if len(headers) < boilerplateMinFiles {
+		return false, ""
+	}
+	group := largestHeaderGroup(headers)
+	share := float64(len(group)) / float64(len(headers))
+	if len(group) >= boilerplateMinFiles && share >= s.minShare {
+		template := headers[group[0]]
+		return true, fmt.Sprintf(
+			"%d of %d new files share a near-identical %d-line header starting %q (e.g. %s) - may be scaffolded from a template",
+			len(group), len(headers), template.n, quoteHeaderLine(template.first), headerFileList(headers, group),
+		)
+	}
+
+	return false, ""
+}
+
+func (s *BoilerplateHeaderStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	if pair == nil || pair.DiffContent == "" {
+		return newTrace(s, pair)
+	}
+	headers := newFileHeaders(pair.DiffContent, s.headerLines)
+	inputs := []TraceInput{{Name: "new_files", Value: float64(len(headers)), Threshold: boilerplateMinFiles - 1, Operator: ">"}}
+	if len(headers) >= boilerplateMinFiles {
+		group := largestHeaderGroup(headers)
+		inputs = append(inputs,
+			TraceInput{Name: "shared_header_files", Value: float64(len(group)), Threshold: boilerplateMinFiles - 1, Operator: ">"},
+			TraceInput{Name: "shared_header_share", Value: float64(len(group)) / float64(len(headers)), Threshold: s.minShare},
+		)
+	}
+	return newTrace(s, pair, inputs...)
+}
+
+// fileHeader is the opening of one file added by a commit.
+type fileHeader struct {
+	path  string
+	first string         // first header line as written
+	lines map[string]int // normalized header lines
+	n     int
+}
+
+// newFileHeaders returns the first maxLines non-blank lines of each file a
+// unified diff creates (a "--- /dev/null" side), skipping files with fewer
+// than boilerplateMinHeaderLines of them. At most boilerplateMaxFiles files
+// are read.
+func newFileHeaders(diff string, maxLines int) []*fileHeader {
+	var headers []*fileHeader
+	var current *fileHeader
+	created := false
+
+	flush := func() {
+		if current != nil && current.n >= boilerplateMinHeaderLines {
+			headers = append(headers, current)
+		}
+		current = nil
+	}
+
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			flush()
+			created = strings.TrimSpace(strings.TrimPrefix(line, "--- ")) == "/dev/null"
+		case strings.HasPrefix(line, "+++ "):
+			flush()
+			if created && len(headers) < boilerplateMaxFiles {
+				current = &fileHeader{
+					path:  strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(line, "+++ ")), "b/"),
+					lines: make(map[string]int),
+				}
+			}
+			created = false
+		case current != nil && strings.HasPrefix(line, "+") && current.n < maxLines:
+			norm := normalizeHeaderLine(line[1:])
+			if norm == "" {
+				continue
+			}
+			if current.n == 0 {
+				current.first = strings.TrimSpace(line[1:])
+			}
+			current.lines[norm]++
+			current.n++
+		}
+	}
+	flush()
+	return headers
+}
+
+// normalizeHeaderLine trims and collapses whitespace and replaces digit runs
+// with "0", so headers that differ only in indentation, years or numbering
+// still match.
+func normalizeHeaderLine(line string) string {
+	var b strings.Builder
+	inDigits := false
+	for _, field := range strings.Fields(line) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		for _, r := range field {
+			if unicode.IsDigit(r) {
+				if !inDigits {
+					b.WriteByte('0')
+				}
+				inDigits = true
+				continue
+			}
+			inDigits = false
+			b.WriteRune(r)
+		}
+		inDigits = false
+	}
+	return b.String()
+}
+
+// headerSimilarity is the Dice coefficient of two headers' normalized lines.
+func headerSimilarity(a, b *fileHeader) float64 {
+	shared := 0
+	for line, n := range a.lines {
+		shared += min(n, b.lines[line])
+	}
+	return 2 * float64(shared) / float64(a.n+b.n)
+}
+
+// largestHeaderGroup returns the indexes of the biggest set of headers
+// near-identical to one of them, that one first.
+func largestHeaderGroup(headers []*fileHeader) []int {
+	var best []int
+	for i, h := range headers {
+		group := []int{i}
+		for j, other := range headers {
+			if j != i && headerSimilarity(h, other) >= boilerplateSimilarity {
+				group = append(group, j)
+			}
+		}
+		if len(group) > len(best) {
+			best = group
+		}
+	}
+	return best
+}
+
+// quoteHeaderLine shortens a header line for reasons.
+func quoteHeaderLine(line string) string {
+	r := []rune(line)
+	if len(r) > 60 {
+		return string(r[:57]) + "..."
+	}
+	return line
+}
+
+// headerFileList names up to three files of group.
+func headerFileList(headers []*fileHeader, group []int) string {
+	names := make([]string, 0, 3)
+	for _, i := range group {
+		if len(names) == 3 {
+			break
+		}
+		names = append(names, headers[i].path)
+	}
+	list := strings.Join(names, ", ")
+	if len(group) > len(names) {
+		list += ", ..."
+	}
+	return list
+}
@@ -0,0 +1,110 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+const licenseHeader = `// Copyright %d Example Corp.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+`
+
+// newFilesDiff builds a diff creating one file per body, each as new-file
+// patches do ("--- /dev/null").
+func newFilesDiff(bodies ...string) string {
+	var b strings.Builder
+	for i, body := range bodies {
+		lines := strings.Split(strings.TrimRight(body, "\n"), "\n")
+		fmt.Fprintf(&b, "diff --git a/pkg/file%d.go b/pkg/file%d.go\nnew file mode 100644\n--- /dev/null\n+++ b/pkg/file%d.go\n@@ -0,0 +1,%d @@\n", i, i, i, len(lines))
+		for _, line := range lines {
+			b.WriteString("+" + line + "\n")
+		}
+	}
+	return b.String()
+}
+
+var distinctNames = []string{"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf", "hotel", "india", "juliet"}
+
+// distinctFile returns a file body sharing little with the others.
+func distinctFile(i int) string {
+	name := distinctNames[i%len(distinctNames)]
+	return fmt.Sprintf("package %s\n\nimport \"%s/store\"\n\nfunc %sHandler() {\n\t%sCounter++\n\treturn\n}\n", name, name, name, name)
+}
+
+func TestBoilerplateHeaderStrategy(t *testing.T) {
+	templated := func(n int) []string {
+		bodies := make([]string, n)
+		for i := range bodies {
+			// Years and the code after the header vary per file.
+			bodies[i] = fmt.Sprintf(licenseHeader, 2020+i) + "\n" + distinctFile(i)
+		}
+		return bodies
+	}
+
+	tests := []struct {
+		name       string
+		diff       string
+		wantDetect bool
+		wantReason string
+	}{
+		{name: "empty diff", diff: "", wantDetect: false},
+		{name: "two templated files", diff: newFilesDiff(templated(2)...), wantDetect: false},
+		{name: "distinct files", diff: newFilesDiff(distinctFile(1), distinctFile(2), distinctFile(3), distinctFile(4)), wantDetect: false},
+		{
+			name:       "minority share a header",
+			diff:       newFilesDiff(append(templated(3), distinctFile(4), distinctFile(5), distinctFile(6))...),
+			wantDetect: false,
+		},
+		{
+			name:       "templated files",
+			diff:       newFilesDiff(append(templated(4), distinctFile(9))...),
+			wantDetect: true,
+			wantReason: `4 of 5 new files share a near-identical 10-line header starting "// Copyright 2020 Example Corp."`,
+		},
+		{
+			name: "modified files are not new",
+			diff: strings.ReplaceAll(newFilesDiff(templated(4)...), "--- /dev/null", "--- a/pkg/old.go"),
+		},
+	}
+
+	s := NewBoilerplateHeaderStrategy(0, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := &git.CommitPair{Stats: &git.DiffStats{}, DiffContent: tt.diff}
+			detected, reason := s.Detect(pair, nil)
+			if detected != tt.wantDetect {
+				t.Fatalf("Detect() = %v (%s), want %v", detected, reason, tt.wantDetect)
+			}
+			if !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("reason %q should contain %q", reason, tt.wantReason)
+			}
+			if trace := s.Explain(pair); trace.Fired != detected {
+				t.Errorf("Explain().Fired = %v, want %v", trace.Fired, detected)
+			}
+		})
+	}
+}
+
+func TestNormalizeHeaderLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"  // Copyright 2024   Example  ", "// Copyright 0 Example"},
+		{"\tversion: 1.2.3", "version: 0.0.0"},
+		{"   ", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeHeaderLine(tt.line); got != tt.want {
+			t.Errorf("normalizeHeaderLine(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+package patterns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// PairHistoryStrategy is implemented by strategies that follow files across
+// the analyzed commit pairs, such as code that is added and soon deleted
+// again. GitDetector runs each once per analysis, after the per-commit
+// strategies, and reports every hit as a detection of its own.
+type PairHistoryStrategy interface {
+	Name() string
+	Category() string
+	Confidence() float64
+	Description() string
+	DetectPairHistory(pairs []*git.CommitPair) []HistoryHit
+}
+
+// HistoryHit is one finding of a PairHistoryStrategy: the commit it is
+// attributed to and the evidence.
+type HistoryHit struct {
+	Commit string
+	Reason string
+}
+
+// Defaults for ChurnOptions fields left at zero.
+const (
+	DefaultChurnMinAdditions   = 100
+	DefaultChurnMinRevertRatio = 0.6
+	DefaultChurnMaxCommits     = 10
+	DefaultChurnWindow         = 7 * 24 * time.Hour
+)
+
+// churnMaxReasonFiles is how many churned files a hit's reason lists.
+const churnMaxReasonFiles = 3
+
+// ChurnOptions configures CodeChurnStrategy. Zero fields use the defaults.
+type ChurnOptions struct {
+	// MinAdditions is the fewest lines a commit must add to one file for the
+	// file to be followed.
+	MinAdditions int64
+	// MinRevertRatio is the share of those lines (0-1) later commits must
+	// delete from the same file.
+	MinRevertRatio float64
+	// MaxCommits and Window bound how soon after the addition the deletions
+	// must come: within MaxCommits commits and Window time.
+	MaxCommits int
+	Window     time.Duration
+}
+
+// CodeChurnStrategy flags commits that add a lot of code to a file which the
+// following commits then largely delete or rewrite. Generated code is often
+// committed wholesale and thrown away soon after; hand-written code tends to
+// be built up and kept.
+type CodeChurnStrategy struct {
+	opts ChurnOptions
+}
+
+// NewCodeChurnStrategy creates a churn strategy; zero options use the
+// DefaultChurn* values.
+func NewCodeChurnStrategy(opts ChurnOptions) *CodeChurnStrategy {
+	if opts.MinAdditions <= 0 {
+		opts.MinAdditions = DefaultChurnMinAdditions
+	}
+	if opts.MinRevertRatio <= 0 {
+		opts.MinRevertRatio = DefaultChurnMinRevertRatio
+	}
+	if opts.MaxCommits <= 0 {
+		opts.MaxCommits = DefaultChurnMaxCommits
+	}
+	if opts.Window <= 0 {
+		opts.Window = DefaultChurnWindow
+	}
+	return &CodeChurnStrategy{opts: opts}
+}
+
+func (s *CodeChurnStrategy) Name() string        { return "code_churn_analysis" }
+func (s *CodeChurnStrategy) Category() string    { return "behavioral" }
+func (s *CodeChurnStrategy) Confidence() float64 { return 0.5 }
+func (s *CodeChurnStrategy) Description() string {
+	return "Detects large additions to a file that are largely deleted again within a few commits"
+}
+
+// FileChurn is a file whose added lines were largely deleted soon after.
+type FileChurn struct {
+	Path string
+	// Added is the lines AddCommit added to the file, and Deleted the lines
+	// deleted from it by the commits up to and including RevertCommit.
+	Added   int64
+	Deleted int64
+	// AddCommit added the lines; RevertCommit is the commit whose deletions
+	// reached the revert ratio, Commits commits and Elapsed time later.
+	AddCommit    string
+	RevertCommit string
+	Commits      int
+	Elapsed      time.Duration
+}
+
+// Churn finds the files in pairs whose large additions were largely deleted
+// within the strategy's window. Pairs may be in any order; they are followed
+// oldest first. Merge commits are skipped.
+func (s *CodeChurnStrategy) Churn(pairs []*git.CommitPair) []FileChurn {
+	seq := make([]*git.CommitPair, 0, len(pairs))
+	for _, p := range pairs {
+		if p != nil && p.Current != nil && p.Stats != nil && len(p.Current.Parents) <= 1 {
+			seq = append(seq, p)
+		}
+	}
+	// Sources list pairs newest first, so reversing before the stable sort
+	// keeps commits with equal timestamps in commit order.
+	for i, j := 0, len(seq)-1; i < j; i, j = i+1, j-1 {
+		seq[i], seq[j] = seq[j], seq[i]
+	}
+	sort.SliceStable(seq, func(i, j int) bool {
+		return seq[i].Current.Timestamp.Before(seq[j].Current.Timestamp)
+	})
+
+	var churned []FileChurn
+	for i, add := range seq {
+		for _, f := range add.Stats.Files {
+			if f.Additions < s.opts.MinAdditions {
+				continue
+			}
+			need := int64(float64(f.Additions) * s.opts.MinRevertRatio)
+			var deleted int64
+			for j := i + 1; j < len(seq) && j-i <= s.opts.MaxCommits; j++ {
+				elapsed := seq[j].Current.Timestamp.Sub(add.Current.Timestamp)
+				if elapsed > s.opts.Window {
+					break
+				}
+				deleted += fileDeletions(seq[j].Stats, f.Path)
+				if deleted >= need {
+					churned = append(churned, FileChurn{
+						Path:         f.Path,
+						Added:        f.Additions,
+						Deleted:      deleted,
+						AddCommit:    add.Current.Hash,
+						RevertCommit: seq[j].Current.Hash,
+						Commits:      j - i,
+						Elapsed:      elapsed,
+					})
+					break
+				}
+			}
+		}
+	}
+	return churned
+}
+
+func fileDeletions(stats *git.DiffStats, path string) int64 {
+	for _, f := range stats.Files {
+		if f.Path == path {
+			return f.Deletions
+		}
+	}
+	return 0
+}
+
+// DetectPairHistory reports one hit per commit with churned files, listing
+// the files that lost the most lines along with the commits that deleted
+// them.
+func (s *CodeChurnStrategy) DetectPairHistory(pairs []*git.CommitPair) []HistoryHit {
+	churned := s.Churn(pairs)
+	if len(churned) == 0 {
+		return nil
+	}
+
+	byCommit := make(map[string][]FileChurn)
+	var order []string
+	for _, c := range churned {
+		if _, ok := byCommit[c.AddCommit]; !ok {
+			order = append(order, c.AddCommit)
+		}
+		byCommit[c.AddCommit] = append(byCommit[c.AddCommit], c)
+	}
+
+	hits := make([]HistoryHit, 0, len(order))
+	for _, commit := range order {
+		files := byCommit[commit]
+		sort.SliceStable(files, func(i, j int) bool { return files[i].Deleted > files[j].Deleted })
+		shown := files[:min(len(files), churnMaxReasonFiles)]
+		parts := make([]string, len(shown))
+		for i, f := range shown {
+			parts[i] = fmt.Sprintf("%s (+%d, then -%d by %s, %d commits and %s later)",
+				f.Path, f.Added, f.Deleted, shortHash(f.RevertCommit), f.Commits, f.Elapsed.Round(time.Minute))
+		}
+		reason := "Added code was largely deleted soon after: " + strings.Join(parts, ", ")
+		if more := len(files) - len(shown); more > 0 {
+			reason += fmt.Sprintf(" and %d more", more)
+		}
+		hits = append(hits, HistoryHit{Commit: commit, Reason: reason})
+	}
+	return hits
+}
@@ -0,0 +1,123 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// churnPair builds a commit pair at hours after a fixed start touching files.
+func churnPair(hash string, hours float64, files ...git.FileStat) *git.CommitPair {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC).Add(time.Duration(hours * float64(time.Hour)))
+	stats := &git.DiffStats{Files: files, FilesChanged: len(files)}
+	for _, f := range files {
+		stats.Additions += f.Additions
+		stats.Deletions += f.Deletions
+	}
+	return &git.CommitPair{
+		Current: &git.Commit{Hash: hash, Timestamp: at, Parents: []string{hash + "-parent"}},
+		Stats:   stats,
+	}
+}
+
+// newestFirst reverses pairs listed oldest first, as sources list them.
+func newestFirst(pairs ...*git.CommitPair) []*git.CommitPair {
+	out := make([]*git.CommitPair, len(pairs))
+	for i, p := range pairs {
+		out[len(pairs)-1-i] = p
+	}
+	return out
+}
+
+func TestCodeChurnStrategy_AddThenRevert(t *testing.T) {
+	s := NewCodeChurnStrategy(ChurnOptions{})
+	pairs := newestFirst(
+		churnPair("add", 0,
+			git.FileStat{Path: "api.go", Additions: 400},
+			git.FileStat{Path: "util.go", Additions: 150},
+			git.FileStat{Path: "kept.go", Additions: 300}),
+		churnPair("tweak", 2, git.FileStat{Path: "api.go", Additions: 5, Deletions: 100}),
+		churnPair("revert", 5,
+			git.FileStat{Path: "api.go", Deletions: 200},
+			git.FileStat{Path: "util.go", Deletions: 120}),
+	)
+
+	churned := s.Churn(pairs)
+	if len(churned) != 2 {
+		t.Fatalf("Churn() = %+v, want api.go and util.go", churned)
+	}
+	api := churned[0]
+	if api.Path != "api.go" || api.AddCommit != "add" || api.RevertCommit != "revert" ||
+		api.Deleted != 300 || api.Commits != 2 || api.Elapsed != 5*time.Hour {
+		t.Errorf("api.go churn = %+v, want 300 of 400 lines deleted by revert two commits later", api)
+	}
+
+	hits := s.DetectPairHistory(pairs)
+	if len(hits) != 1 || hits[0].Commit != "add" {
+		t.Fatalf("DetectPairHistory() = %+v, want one hit on the adding commit", hits)
+	}
+	for _, want := range []string{"api.go (+400, then -300 by revert", "util.go (+150, then -120"} {
+		if !strings.Contains(hits[0].Reason, want) {
+			t.Errorf("reason %q should mention %q", hits[0].Reason, want)
+		}
+	}
+	if strings.Contains(hits[0].Reason, "kept.go") {
+		t.Errorf("reason %q should not mention the kept file", hits[0].Reason)
+	}
+}
+
+func TestCodeChurnStrategy_NotFlagged(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  ChurnOptions
+		pairs []*git.CommitPair
+	}{
+		{
+			name: "small addition",
+			pairs: newestFirst(
+				churnPair("add", 0, git.FileStat{Path: "a.go", Additions: 50}),
+				churnPair("del", 1, git.FileStat{Path: "a.go", Deletions: 50}),
+			),
+		},
+		{
+			name: "mostly kept",
+			pairs: newestFirst(
+				churnPair("add", 0, git.FileStat{Path: "a.go", Additions: 400}),
+				churnPair("del", 1, git.FileStat{Path: "a.go", Deletions: 100}),
+			),
+		},
+		{
+			name: "deleted after the time window",
+			opts: ChurnOptions{Window: 24 * time.Hour},
+			pairs: newestFirst(
+				churnPair("add", 0, git.FileStat{Path: "a.go", Additions: 400}),
+				churnPair("del", 48, git.FileStat{Path: "a.go", Deletions: 400}),
+			),
+		},
+		{
+			name: "deleted after the commit window",
+			opts: ChurnOptions{MaxCommits: 1},
+			pairs: newestFirst(
+				churnPair("add", 0, git.FileStat{Path: "a.go", Additions: 400}),
+				churnPair("other", 1, git.FileStat{Path: "b.go", Additions: 10}),
+				churnPair("del", 2, git.FileStat{Path: "a.go", Deletions: 400}),
+			),
+		},
+		{
+			name: "another file deleted",
+			pairs: newestFirst(
+				churnPair("add", 0, git.FileStat{Path: "a.go", Additions: 400}),
+				churnPair("del", 1, git.FileStat{Path: "b.go", Deletions: 400}),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if hits := NewCodeChurnStrategy(tt.opts).DetectPairHistory(tt.pairs); len(hits) != 0 {
+				t.Errorf("DetectPairHistory() = %+v, want no hits", hits)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// DefaultAIAssistants lists the assistant identities AICoauthorStrategy looks
+// for in attribution trailers when none are configured. Names alone are not
+// enough: a human co-author can be called Claude or Devin, so each entry is
+// an address the tool commits with, an address domain ("@aider.chat"), or a
+// GitHub login matched against its users.noreply.github.com address.
+var DefaultAIAssistants = []string{
+	"Copilot",
+	"copilot-swe-agent[bot]",
+	"noreply@anthropic.com",
+	"claude[bot]",
+	"chatgpt-codex-connector[bot]",
+	"gemini-code-assist[bot]",
+	"google-labs-jules[bot]",
+	"cursoragent@cursor.com",
+	"cursor[bot]",
+	"@aider.chat",
+	"devin-ai-integration[bot]",
+	"amazon-q-developer[bot]",
+}
+
+// githubNoreplyDomain is the domain of GitHub's per-login commit addresses,
+// "<id>+<login>@users.noreply.github.com" or "<login>@users.noreply.github.com".
+const githubNoreplyDomain = "@users.noreply.github.com"
+
+// aiAttributionTrailers are the trailer keys (lowercase) that credit
+// another author or tool for a commit.
+var aiAttributionTrailers = map[string]bool{
+	"co-authored-by": true,
+	"assisted-by":    true,
+	"ai-assisted-by": true,
+	"generated-by":   true,
+	"generated-with": true,
+	"made-with":      true,
+}
+
+// AICoauthorStrategy flags commits whose attribution trailers
+// (Co-authored-by, Assisted-by, ...) name an AI assistant. Tools add these
+// themselves, so a match is a direct signal rather than a heuristic.
+type AICoauthorStrategy struct {
+	identities []string
+}
+
+// NewAICoauthorStrategy creates a strategy matching the given assistant
+// identities (see DefaultAIAssistants) case-insensitively against the address
+// in a trailer. An empty list uses DefaultAIAssistants.
+func NewAICoauthorStrategy(assistants []string) *AICoauthorStrategy {
+	if len(assistants) == 0 {
+		assistants = DefaultAIAssistants
+	}
+	identities := make([]string, 0, len(assistants))
+	for _, id := range assistants {
+		if id = strings.TrimSpace(id); id != "" {
+			identities = append(identities, strings.ToLower(id))
+		}
+	}
+	return &AICoauthorStrategy{identities: identities}
+}
+
+// match returns the identity that the address in a trailer value such as
+// "Copilot <175728472+Copilot@users.noreply.github.com>" belongs to. Values
+// without an address never match.
+func (s *AICoauthorStrategy) match(value string) string {
+	start := strings.LastIndex(value, "<")
+	end := strings.LastIndex(value, ">")
+	if start < 0 || end <= start+1 {
+		return ""
+	}
+	email := strings.ToLower(strings.TrimSpace(value[start+1 : end]))
+
+	login := ""
+	if local, ok := strings.CutSuffix(email, githubNoreplyDomain); ok {
+		if _, after, found := strings.Cut(local, "+"); found {
+			local = after
+		}
+		login = local
+	}
+
+	for _, id := range s.identities {
+		switch {
+		case strings.HasPrefix(id, "@"):
+			if strings.HasSuffix(email, id) {
+				return id
+			}
+		case strings.Contains(id, "@"):
+			if email == id {
+				return id
+			}
+		default:
+			if login == id {
+				return id
+			}
+		}
+	}
+	return ""
+}
+
+func (s *AICoauthorStrategy) Name() string        { return "ai_coauthor_analysis" }
+func (s *AICoauthorStrategy) Category() string    { return "behavioral" }
+func (s *AICoauthorStrategy) Confidence() float64 { return 0.95 }
+func (s *AICoauthorStrategy) Description() string {
+	return "Detects Co-authored-by and similar trailers that credit an AI assistant's commit identity"
+}
+
+func (s *AICoauthorStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.Current == nil {
+		return false, ""
+	}
+
+	trailers := pair.Current.Trailers
+	if trailers == nil {
+		trailers = git.ParseTrailers(pair.Current.Message)
+	}
+
+	for _, t := range trailers {
+		if !aiAttributionTrailers[strings.ToLower(t.Key)] {
+			continue
+		}
+		if match := s.match(t.Value); match != "" {
+			return true, fmt.Sprintf("AI assistant credited in commit trailer (%s): %q", match, t.String())
+		}
+	}
+	return false, ""
+}
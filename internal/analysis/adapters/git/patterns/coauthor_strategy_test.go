@@ -0,0 +1,71 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+func TestAICoauthorStrategy(t *testing.T) {
+	tests := []struct {
+		name       string
+		message    string
+		assistants []string
+		wantLine   string
+	}{
+		{
+			name:     "copilot co-author",
+			message:  "Add cache\n\nCo-authored-by: Copilot <175728472+Copilot@users.noreply.github.com>",
+			wantLine: "Co-authored-by: Copilot <175728472+Copilot@users.noreply.github.com>",
+		},
+		{
+			name:     "assistant address",
+			message:  "Refactor\n\nCo-Authored-By: Claude <noreply@anthropic.com>",
+			wantLine: "Co-Authored-By: Claude <noreply@anthropic.com>",
+		},
+		{
+			name:     "assistant domain",
+			message:  "Refactor\n\nassisted-by: aider (gpt-4o) <noreply@aider.chat>",
+			wantLine: "assisted-by: aider (gpt-4o) <noreply@aider.chat>",
+		},
+		{
+			name:     "bot login",
+			message:  "Fix\n\nCo-authored-by: devin-ai-integration[bot] <158243242+devin-ai-integration[bot]@users.noreply.github.com>",
+			wantLine: "devin-ai-integration[bot]",
+		},
+		{name: "human co-author", message: "Pair session\n\nCo-authored-by: Jane Doe <jane@example.com>"},
+		{name: "human named like an assistant", message: "Pair session\n\nCo-authored-by: Claude Dupont <claude@example.com>"},
+		{name: "human login named like an assistant", message: "Fix\n\nCo-authored-by: Devin <1234+devin@users.noreply.github.com>"},
+		{name: "name without address", message: "Refactor\n\nAssisted-by: Cursor"},
+		{name: "assistant mentioned in body", message: "Remove Copilot config\n\nThe plugin is no longer used."},
+		{name: "other trailer key", message: "Bump\n\nReviewed-by: Claude <noreply@anthropic.com>"},
+		{
+			name:       "custom list",
+			message:    "Fix\n\nGenerated-by: InternalBot v2 <bot@tools.internal.example>",
+			assistants: []string{"@tools.internal.example"},
+			wantLine:   "Generated-by: InternalBot v2 <bot@tools.internal.example>",
+		},
+		{
+			name:       "custom list replaces defaults",
+			message:    "Fix\n\nCo-authored-by: Copilot <175728472+Copilot@users.noreply.github.com>",
+			assistants: []string{"@tools.internal.example"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewAICoauthorStrategy(tt.assistants)
+			// Trailers are left nil so the strategy parses them from the message.
+			commit := &git.Commit{Hash: "abc", Message: tt.message}
+
+			detected, reason := s.Detect(&git.CommitPair{Current: commit, Stats: &git.DiffStats{}}, nil)
+			if detected != (tt.wantLine != "") {
+				t.Fatalf("Detect() = %v (%q), want %v", detected, reason, tt.wantLine != "")
+			}
+			if detected && !strings.Contains(reason, tt.wantLine) {
+				t.Errorf("reason %q should quote the trailer %q", reason, tt.wantLine)
+			}
+		})
+	}
+}
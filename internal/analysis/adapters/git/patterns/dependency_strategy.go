@@ -0,0 +1,240 @@
+package patterns
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// DefaultDependencyManifests lists the manifest filenames inspected by
+// DependencyAdditionStrategy when none are configured.
+var DefaultDependencyManifests = []string{
+	"go.mod",
+	"package.json",
+	"requirements.txt",
+	"Cargo.toml",
+	"Gemfile",
+	"composer.json",
+	"pyproject.toml",
+}
+
+var (
+	jsonDependencyLine   = regexp.MustCompile(`^"(@?[A-Za-z0-9._\-/]+)"\s*:\s*"[~^<>=*]*[\dx*]|^"(@?[A-Za-z0-9._\-/]+)"\s*:\s*"(latest|\*)"`)
+	tomlDependencyLine   = regexp.MustCompile(`^([A-Za-z0-9_\-]+)\s*=\s*(["{]|\[)`)
+	gemDependencyLine    = regexp.MustCompile(`^gem\s+['"]([^'"]+)['"]`)
+	requirementNameChars = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._\-\[\]]*`)
+)
+
+// jsonManifestKeys are package.json/composer.json fields that look like
+// dependencies but are not.
+var jsonManifestKeys = map[string]bool{
+	"version": true, "name": true, "description": true, "main": true, "license": true,
+	"node": true, "npm": true, "php": true, "type": true, "module": true, "types": true,
+}
+
+// tomlManifestKeys are Cargo.toml/pyproject.toml fields that are not dependencies.
+var tomlManifestKeys = map[string]bool{
+	"name": true, "version": true, "edition": true, "python": true, "authors": true,
+	"description": true, "license": true, "readme": true, "keywords": true,
+	"dependencies": true, "requires-python": true, "build-backend": true, "requires": true,
+}
+
+type DependencyAdditionStrategy struct {
+	manifests       []string
+	minDependencies int
+}
+
+// NewDependencyAdditionStrategy creates a strategy that flags commits adding at
+// least minDeps dependencies to the given manifest files. An empty manifest list
+// uses DefaultDependencyManifests; minDeps <= 0 defaults to 10.
+func NewDependencyAdditionStrategy(manifests []string, minDeps int) *DependencyAdditionStrategy {
+	if len(manifests) == 0 {
+		manifests = DefaultDependencyManifests
+	}
+	if minDeps <= 0 {
+		minDeps = 10
+	}
+	return &DependencyAdditionStrategy{
+		manifests:       manifests,
+		minDependencies: minDeps,
+	}
+}
+
+func (s *DependencyAdditionStrategy) Name() string        { return "dependency_addition_analysis" }
+func (s *DependencyAdditionStrategy) Category() string    { return "structural" }
+func (s *DependencyAdditionStrategy) Confidence() float64 { return 0.6 }
+func (s *DependencyAdditionStrategy) Description() string {
+	return "Detects mass dependency additions to manifests, a project-scaffolding signal"
+}
+
+func (s *DependencyAdditionStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair.DiffContent == "" {
+		return false, ""
+	}
+
+	deps, manifestLines, files := s.addedDependencies(pair.DiffContent)
+	if len(deps) == 0 {
+		return false, ""
+	}
+
+	codeAdditions := int64(0)
+	if pair.Stats != nil {
+		codeAdditions = pair.Stats.Additions - manifestLines
+	}
+
+	// A large dump of code alongside the dependencies lowers the bar: that is
+	// the typical shape of a scaffolded project landing in one commit.
+	threshold := s.minDependencies
+	withCodeDump := codeAdditions >= 500
+	if withCodeDump {
+		threshold = (s.minDependencies + 1) / 2
+	}
+
+	if len(deps) < threshold {
+		return false, ""
+	}
+
+	reason = fmt.Sprintf("Mass dependency addition: %d dependencies added to %s (%s)",
+		len(deps), strings.Join(files, ", "), summarizeNames(deps, 8))
+	if withCodeDump {
+		reason += fmt.Sprintf(" alongside %d added lines of code - possible scaffolded project", codeAdditions)
+	}
+	return true, reason
+}
+
+// addedDependencies returns the dependency names added across all configured
+// manifests in a unified diff, the number of added manifest lines, and the
+// manifest paths that contributed.
+func (s *DependencyAdditionStrategy) addedDependencies(diff string) (deps []string, manifestLines int64, files []string) {
+	seen := make(map[string]bool)
+	seenFiles := make(map[string]bool)
+
+	currentFile := ""
+	manifest := ""
+	inGoRequire := false
+
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "+++ ") {
+			currentFile = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+			manifest = s.matchManifest(currentFile)
+			inGoRequire = false
+			continue
+		}
+		if manifest == "" || strings.HasPrefix(line, "---") {
+			continue
+		}
+
+		// Track go.mod require blocks on context and added lines alike.
+		body := line
+		if len(body) > 0 && (body[0] == '+' || body[0] == ' ' || body[0] == '-') {
+			body = body[1:]
+		}
+		trimmed := strings.TrimSpace(body)
+		if manifest == "go.mod" {
+			if strings.HasPrefix(trimmed, "require (") {
+				inGoRequire = true
+			} else if trimmed == ")" {
+				inGoRequire = false
+			}
+		}
+
+		if !strings.HasPrefix(line, "+") {
+			continue
+		}
+		manifestLines++
+
+		name := parseDependencyLine(manifest, trimmed, inGoRequire)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		deps = append(deps, name)
+		if !seenFiles[currentFile] {
+			seenFiles[currentFile] = true
+			files = append(files, currentFile)
+		}
+	}
+
+	return deps, manifestLines, files
+}
+
+// matchManifest returns the manifest kind for a path, or "" if it is not a
+// configured manifest. Configured entries may be base names or glob patterns.
+func (s *DependencyAdditionStrategy) matchManifest(filePath string) string {
+	base := path.Base(filePath)
+	for _, m := range s.manifests {
+		if m == base || m == filePath {
+			return manifestKind(base)
+		}
+		if ok, _ := path.Match(m, base); ok {
+			return manifestKind(base)
+		}
+	}
+	return ""
+}
+
+func manifestKind(base string) string {
+	switch {
+	case base == "go.mod":
+		return "go.mod"
+	case strings.HasSuffix(base, ".json"):
+		return "json"
+	case strings.HasSuffix(base, ".toml"):
+		return "toml"
+	case base == "Gemfile":
+		return "gemfile"
+	default:
+		return "requirements"
+	}
+}
+
+// parseDependencyLine extracts a dependency name from an added manifest line.
+func parseDependencyLine(kind, line string, inGoRequire bool) string {
+	if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//") {
+		return ""
+	}
+
+	switch kind {
+	case "go.mod":
+		fields := strings.Fields(strings.TrimPrefix(line, "require "))
+		if (inGoRequire || strings.HasPrefix(line, "require ")) && len(fields) >= 2 &&
+			strings.Contains(fields[0], "/") && strings.HasPrefix(fields[1], "v") {
+			return fields[0]
+		}
+	case "json":
+		if m := jsonDependencyLine.FindStringSubmatch(line); m != nil {
+			name := m[1]
+			if name == "" {
+				name = m[2]
+			}
+			if !jsonManifestKeys[name] {
+				return name
+			}
+		}
+	case "toml":
+		if m := tomlDependencyLine.FindStringSubmatch(line); m != nil && !tomlManifestKeys[m[1]] {
+			return m[1]
+		}
+	case "gemfile":
+		if m := gemDependencyLine.FindStringSubmatch(line); m != nil {
+			return m[1]
+		}
+	default:
+		if strings.HasPrefix(line, "-") {
+			return ""
+		}
+		return requirementNameChars.FindString(line)
+	}
+	return ""
+}
+
+func summarizeNames(names []string, limit int) string {
+	if len(names) <= limit {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s, +%d more", strings.Join(names[:limit], ", "), len(names)-limit)
+}
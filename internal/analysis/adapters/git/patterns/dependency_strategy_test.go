@@ -0,0 +1,106 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+func manifestDiff(file string, lines []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n@@ -1,0 +1,%d @@\n", file, file, file, file, len(lines))
+	for _, l := range lines {
+		b.WriteString("+" + l + "\n")
+	}
+	return b.String()
+}
+
+func TestDependencyAdditionStrategy(t *testing.T) {
+	goModLines := []string{"module example.com/app", "", "go 1.22", "", "require ("}
+	for i := 0; i < 12; i++ {
+		goModLines = append(goModLines, fmt.Sprintf("\tgithub.com/acme/lib%d v1.%d.0", i, i))
+	}
+	goModLines = append(goModLines, ")")
+
+	packageJSON := []string{`{`, `  "name": "app",`, `  "version": "1.0.0",`, `  "dependencies": {`,
+		`    "react": "^18.2.0",`, `    "lodash": "4.17.21",`, `    "@types/node": "~20.1.0",`,
+		`    "axios": "latest",`, `    "zod": "*",`, `    "dayjs": "^1.11.0"`, `  }`, `}`}
+
+	tests := []struct {
+		name       string
+		diff       string
+		additions  int64
+		manifests  []string
+		wantDetect bool
+		wantInText string
+	}{
+		{
+			name:       "go.mod with many requires",
+			diff:       manifestDiff("go.mod", goModLines),
+			additions:  int64(len(goModLines)),
+			wantDetect: true,
+			wantInText: "12 dependencies added to go.mod",
+		},
+		{
+			name:       "package.json below threshold without code dump",
+			diff:       manifestDiff("package.json", packageJSON),
+			additions:  int64(len(packageJSON)),
+			wantDetect: false,
+		},
+		{
+			name:       "package.json with large code dump",
+			diff:       manifestDiff("package.json", packageJSON) + manifestDiff("src/app.ts", []string{"export {}"}),
+			additions:  2000,
+			wantDetect: true,
+			wantInText: "possible scaffolded project",
+		},
+		{
+			name:       "requirements.txt ignores comments and options",
+			diff:       manifestDiff("requirements.txt", []string{"# deps", "-r base.txt", "flask==3.0", "requests>=2"}),
+			additions:  4,
+			wantDetect: false,
+		},
+		{
+			name:       "manifest not configured",
+			diff:       manifestDiff("go.mod", goModLines),
+			additions:  int64(len(goModLines)),
+			manifests:  []string{"package.json"},
+			wantDetect: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewDependencyAdditionStrategy(tt.manifests, 10)
+			pair := &git.CommitPair{
+				Stats:       &git.DiffStats{Additions: tt.additions},
+				DiffContent: tt.diff,
+			}
+
+			detected, reason := s.Detect(pair, nil)
+			if detected != tt.wantDetect {
+				t.Fatalf("Detect() = %v (%q), want %v", detected, reason, tt.wantDetect)
+			}
+			if tt.wantInText != "" && !strings.Contains(reason, tt.wantInText) {
+				t.Errorf("reason %q does not contain %q", reason, tt.wantInText)
+			}
+		})
+	}
+}
+
+func TestDependencyAdditionStrategy_ParsesNames(t *testing.T) {
+	s := NewDependencyAdditionStrategy(nil, 10)
+	diff := manifestDiff("requirements.txt", []string{"flask==3.0", "requests>=2", "# comment", "numpy"}) +
+		manifestDiff("Gemfile", []string{`source "https://rubygems.org"`, `gem 'rails', '~> 7.1'`})
+
+	deps, _, files := s.addedDependencies(diff)
+	want := []string{"flask", "requests", "numpy", "rails"}
+	if strings.Join(deps, ",") != strings.Join(want, ",") {
+		t.Errorf("addedDependencies() = %v, want %v", deps, want)
+	}
+	if len(files) != 2 {
+		t.Errorf("files = %v, want 2 manifests", files)
+	}
+}
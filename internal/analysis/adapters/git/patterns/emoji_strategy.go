@@ -6,9 +6,20 @@ import (
 	"unicode"
 
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	webpatterns "github.com/TryCadence/Cadence/internal/analysis/adapters/web/patterns"
 	"github.com/TryCadence/Cadence/internal/metrics"
 )
 
+const (
+	// commitEmojiMinCount is how many emoji make a commit message emoji
+	// heavy on their own. One leading emoji ("✨ feat: ...", gitmoji) is a
+	// common human convention and never fires.
+	commitEmojiMinCount = 3
+	// commitEmojiMaxDensity is the emoji per word above which two emoji
+	// are already too many.
+	commitEmojiMaxDensity = 0.25
+)
+
 type EmojiPatternStrategy struct {
 	enabled bool
 }
@@ -24,87 +35,34 @@ func (s *EmojiPatternStrategy) Description() string {
 	return "Detects excessive emoji usage in commit messages"
 }
 
-func isEmoji(r rune) bool {
-	return r >= 0x1F300 && r <= 0x1F9FF ||
-		r >= 0x2600 && r <= 0x27BF ||
-		r >= 0x1F600 && r <= 0x1F64F ||
-		r >= 0x1F900 && r <= 0x1F9FF ||
-		r >= 0x2300 && r <= 0x23FF ||
-		r >= 0x2000 && r <= 0x206F
-}
-
-func countEmojis(text string) int {
-	count := 0
-	for _, r := range text {
-		if isEmoji(r) {
-			count++
-		}
-	}
-	return count
-}
-
-func isEmojiHeavy(text string) bool {
-	if text == "" {
-		return false
-	}
-
-	emojiCount := countEmojis(text)
-	if emojiCount == 0 {
-		return false
-	}
-
-	emojiRatio := float64(emojiCount) / float64(len([]rune(text)))
-	return emojiRatio > 0.2
-}
-
-func hasEmojiOnlyCommit(text string) bool {
-	trimmed := strings.TrimSpace(text)
-	if trimmed == "" {
-		return false
-	}
-
-	emojiCount := countEmojis(trimmed)
-	if emojiCount == 0 {
-		return false
-	}
-
-	nonEmojiChars := 0
-	for _, r := range trimmed {
-		if !isEmoji(r) && !unicode.IsSpace(r) {
-			nonEmojiChars++
-		}
-	}
-
-	return emojiCount > nonEmojiChars*2
-}
-
 func (s *EmojiPatternStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
 	if !s.enabled || pair == nil || pair.Current == nil {
 		return false, ""
 	}
 
 	msg := pair.Current.Message
-
-	emojiCount := countEmojis(msg)
-	msgLength := len([]rune(msg))
-
-	if emojiCount >= 3 {
-		return true, fmt.Sprintf(
-			"Excessive emoji usage in commit message (%d emojis detected)",
-			emojiCount,
-		)
-	}
-
-	if isEmojiHeavy(msg) {
-		return true, fmt.Sprintf(
-			"Commit message is predominantly emoji (%.1f%% emoji content)",
-			float64(emojiCount)/float64(msgLength)*100,
-		)
+	scan := webpatterns.ScanSymbols(msg)
+	if scan.Emoji == 0 {
+		return false, ""
 	}
-
-	if hasEmojiOnlyCommit(msg) {
-		return true, "Commit message is primarily emoji with minimal text content"
+	density := float64(scan.Emoji) / float64(scan.Words)
+	examples := strings.Join(scan.EmojiRuns, ", ")
+
+	switch {
+	case !hasWordCharacters(msg):
+		return true, fmt.Sprintf("Commit message is only emoji (%s)", examples)
+	case scan.Emoji >= commitEmojiMinCount:
+		return true, fmt.Sprintf("Excessive emoji in commit message (%d emoji in %d words: %s)",
+			scan.Emoji, scan.Words, examples)
+	case scan.Emoji > 1 && density > commitEmojiMaxDensity:
+		return true, fmt.Sprintf("Commit message is emoji heavy (%.0f%% of words: %s)", density*100, examples)
 	}
-
 	return false, ""
 }
+
+// hasWordCharacters reports whether text contains any letter or digit.
+func hasWordCharacters(text string) bool {
+	return strings.IndexFunc(text, func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsDigit(r)
+	}) >= 0
+}
@@ -0,0 +1,180 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"unicode"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// BaselineStrategy is implemented by strategies that compare each commit
+// against statistics gathered from the whole set of analyzed pairs.
+type BaselineStrategy interface {
+	SetBaseline(pairs []*git.CommitPair)
+}
+
+const (
+	// entropyMinTokens is the smallest added-token sample worth scoring;
+	// shorter diffs have too little text for a stable distribution.
+	entropyMinTokens = 40
+	// entropyMinBaselineSamples is how many scored pairs are needed before
+	// the repository baseline is trusted over the absolute floor.
+	entropyMinBaselineSamples = 5
+	// entropyWindow is the token window used to normalize entropy.
+	entropyWindow = 200
+	// entropyMinStdDev keeps a very uniform repository from turning small,
+	// natural variations into large z-scores.
+	entropyMinStdDev = 0.03
+)
+
+// CodeEntropyStrategy flags commits whose added code has unusually low
+// lexical entropy, i.e. it repeats a small vocabulary the way templated or
+// generated code does. Entropy is measured over fixed-size token windows so
+// small and large diffs are comparable.
+type CodeEntropyStrategy struct {
+	minNormalized float64
+	zThreshold    float64
+
+	baselineMean   float64
+	baselineStdDev float64
+	baselineCount  int
+}
+
+// NewCodeEntropyStrategy creates a strategy that flags added code whose
+// normalized entropy is zThreshold standard deviations below the repository
+// mean, or below minNormalized when there is no usable baseline.
+func NewCodeEntropyStrategy(minNormalized, zThreshold float64) *CodeEntropyStrategy {
+	if minNormalized <= 0 {
+		minNormalized = 0.6
+	}
+	if zThreshold <= 0 {
+		zThreshold = 2.0
+	}
+	return &CodeEntropyStrategy{
+		minNormalized: minNormalized,
+		zThreshold:    zThreshold,
+	}
+}
+
+func (s *CodeEntropyStrategy) Name() string        { return "code_entropy_analysis" }
+func (s *CodeEntropyStrategy) Category() string    { return "statistical" }
+func (s *CodeEntropyStrategy) Confidence() float64 { return 0.55 }
+func (s *CodeEntropyStrategy) Description() string {
+	return "Detects added code with unusually low lexical entropy relative to the repository"
+}
+
+func (s *CodeEntropyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.DiffContent == "" {
+		return false, ""
+	}
+
+	entropy, normalized, tokens := addedCodeEntropy(pair.DiffContent)
+	if tokens < entropyMinTokens {
+		return false, ""
+	}
+
+	if s.baselineCount >= entropyMinBaselineSamples {
+		z := (normalized - s.baselineMean) / math.Max(s.baselineStdDev, entropyMinStdDev)
+		if z <= -s.zThreshold {
+			return true, fmt.Sprintf(
+				"Low lexical entropy in added code: %.2f bits (normalized %.2f vs repository mean %.2f, z-score %.2f over %d tokens)",
+				entropy, normalized, s.baselineMean, z, tokens,
+			)
+		}
+		return false, ""
+	}
+
+	if normalized < s.minNormalized {
+		return true, fmt.Sprintf(
+			"Low lexical entropy in added code: %.2f bits (normalized %.2f, below %.2f over %d tokens)",
+			entropy, normalized, s.minNormalized, tokens,
+		)
+	}
+
+	return false, ""
+}
+
+// SetBaseline records the mean and standard deviation of normalized entropy
+// across all pairs with enough added tokens to score.
+func (s *CodeEntropyStrategy) SetBaseline(pairs []*git.CommitPair) {
+	values := make([]float64, 0, len(pairs))
+	for _, pair := range pairs {
+		if pair == nil || pair.DiffContent == "" {
+			continue
+		}
+		if _, normalized, tokens := addedCodeEntropy(pair.DiffContent); tokens >= entropyMinTokens {
+			values = append(values, normalized)
+		}
+	}
+
+	s.baselineCount = len(values)
+	s.baselineMean = 0
+	s.baselineStdDev = 0
+	if len(values) == 0 {
+		return
+	}
+
+	sum := 0.0
+	for _, v := range values {
+		sum += v
+	}
+	s.baselineMean = sum / float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - s.baselineMean) * (v - s.baselineMean)
+	}
+	s.baselineStdDev = math.Sqrt(variance / float64(len(values)))
+}
+
+// addedCodeEntropy returns the Shannon entropy (bits) of the token
+// distribution in the added lines of a unified diff, a size-independent
+// normalized entropy, and the token count. The normalized value is the mean
+// entropy of consecutive entropyWindow-token windows divided by its maximum,
+// so a 50-line and a 5,000-line diff are measured on the same scale. Both
+// values are 0 when there are fewer than two tokens.
+func addedCodeEntropy(diff string) (entropy, normalized float64, tokens int) {
+	words := addedTokens(diff)
+
+	tokens = len(words)
+	if tokens < 2 {
+		return 0, 0, tokens
+	}
+
+	entropy = shannonEntropy(words)
+
+	if tokens <= entropyWindow {
+		return entropy, entropy / math.Log2(float64(tokens)), tokens
+	}
+
+	sum := 0.0
+	windows := 0
+	for start := 0; start+entropyWindow <= tokens; start += entropyWindow {
+		sum += shannonEntropy(words[start : start+entropyWindow])
+		windows++
+	}
+	normalized = sum / float64(windows) / math.Log2(entropyWindow)
+
+	return entropy, normalized, tokens
+}
+
+func shannonEntropy(words []string) float64 {
+	counts := make(map[string]int, len(words))
+	for _, w := range words {
+		counts[w]++
+	}
+
+	total := float64(len(words))
+	h := 0.0
+	for _, c := range counts {
+		p := float64(c) / total
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+func isTokenSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+}
@@ -0,0 +1,102 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// templatedDiff repeats the same handler body n times, the low-entropy shape
+// of generated boilerplate.
+func templatedDiff(n int) string {
+	var b strings.Builder
+	b.WriteString("+++ b/handlers.go\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "+func Handle%d(ctx context.Context, input *Input) (*Output, error) {\n", i)
+		b.WriteString("+\tif err := validateInput(input); err != nil {\n")
+		b.WriteString("+\t\treturn nil, fmt.Errorf(\"failed to validate input: %w\", err)\n")
+		b.WriteString("+\t}\n+\treturn process(ctx, input)\n+}\n")
+	}
+	return b.String()
+}
+
+// variedDiff produces added code with a broad vocabulary.
+func variedDiff(seed int) string {
+	words := []string{
+		"parse", "header", "buffer", "offset", "retry", "socket", "cursor", "token", "limit", "window",
+		"merge", "field", "index", "scope", "queue", "frame", "delta", "group", "match", "split",
+		"flush", "close", "ready", "stale", "epoch", "shard", "route", "proxy", "cache", "trace",
+		"batch", "chunk", "reader", "writer", "lease", "clock", "drift", "quota", "bloom", "hash",
+	}
+	var b strings.Builder
+	b.WriteString("+++ b/engine.go\n")
+	for i := 0; i < 30; i++ {
+		a := words[(i*7+seed)%len(words)]
+		c := words[(i*13+seed*3+5)%len(words)]
+		d := words[(i*17+seed*5+11)%len(words)]
+		fmt.Fprintf(&b, "+\t%s%d := %s.%s(%s, %d)\n", a, i, c, d, words[(i+seed)%len(words)], i*seed+3)
+	}
+	return b.String()
+}
+
+func TestCodeEntropyStrategy(t *testing.T) {
+	tests := []struct {
+		name       string
+		diff       string
+		wantDetect bool
+	}{
+		{name: "empty diff", diff: "", wantDetect: false},
+		{name: "too few tokens", diff: "+++ b/a.go\n+x := 1\n", wantDetect: false},
+		{name: "templated code", diff: templatedDiff(8), wantDetect: true},
+		{name: "varied code", diff: variedDiff(1), wantDetect: false},
+	}
+
+	s := NewCodeEntropyStrategy(0, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := &git.CommitPair{Stats: &git.DiffStats{}, DiffContent: tt.diff}
+			detected, reason := s.Detect(pair, nil)
+			if detected != tt.wantDetect {
+				t.Fatalf("Detect() = %v (%s), want %v", detected, reason, tt.wantDetect)
+			}
+			if detected && !strings.Contains(reason, "bits") {
+				t.Errorf("reason should include the computed entropy: %q", reason)
+			}
+		})
+	}
+}
+
+func TestCodeEntropyStrategy_Baseline(t *testing.T) {
+	pairs := make([]*git.CommitPair, 0)
+	for i := 1; i <= 6; i++ {
+		pairs = append(pairs, &git.CommitPair{Stats: &git.DiffStats{}, DiffContent: variedDiff(i)})
+	}
+	outlier := &git.CommitPair{Stats: &git.DiffStats{}, DiffContent: templatedDiff(8)}
+	pairs = append(pairs, outlier)
+
+	s := NewCodeEntropyStrategy(0, 0)
+	s.SetBaseline(pairs)
+	if s.baselineCount != len(pairs) {
+		t.Fatalf("baselineCount = %d, want %d", s.baselineCount, len(pairs))
+	}
+
+	if detected, reason := s.Detect(outlier, nil); !detected || !strings.Contains(reason, "repository mean") {
+		t.Errorf("outlier Detect() = %v (%s), want baseline-relative detection", detected, reason)
+	}
+	if detected, reason := s.Detect(pairs[0], nil); detected {
+		t.Errorf("typical commit flagged: %s", reason)
+	}
+}
+
+func TestAddedCodeEntropy_IgnoresRemovedAndHeaderLines(t *testing.T) {
+	diff := "--- a/x.go\n+++ b/x.go\n-removed removed removed\n+alpha beta\n context line\n"
+	entropy, normalized, tokens := addedCodeEntropy(diff)
+	if tokens != 2 {
+		t.Fatalf("tokens = %d, want 2", tokens)
+	}
+	if entropy != 1 || normalized != 1 {
+		t.Errorf("entropy = %v, normalized = %v, want 1 and 1", entropy, normalized)
+	}
+}
@@ -0,0 +1,114 @@
+package patterns
+
+import (
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// TraceInput is one numeric value a strategy evaluated. When Operator is set
+// the strategy fires if Value Operator Threshold holds (">" or "<"); inputs
+// without an operator are informational.
+type TraceInput struct {
+	Name      string  `json:"name"`
+	Value     float64 `json:"value"`
+	Threshold float64 `json:"threshold,omitempty"`
+	Operator  string  `json:"operator,omitempty"`
+}
+
+// StrategyTrace records how a strategy judged a single commit pair.
+type StrategyTrace struct {
+	Strategy string       `json:"strategy"`
+	Fired    bool         `json:"fired"`
+	Reason   string       `json:"reason,omitempty"`
+	Inputs   []TraceInput `json:"inputs,omitempty"`
+}
+
+// ExplainableStrategy is implemented by strategies that can report the inputs
+// and thresholds behind a decision. Explain must agree with Detect.
+type ExplainableStrategy interface {
+	Explain(pair *git.CommitPair) StrategyTrace
+}
+
+// ExplainStrategy returns a trace for any strategy, using Explain when the
+// strategy implements it and the bare Detect result otherwise.
+func ExplainStrategy(s DetectionStrategy, pair *git.CommitPair, repoStats *metrics.RepositoryStats) StrategyTrace {
+	if e, ok := s.(ExplainableStrategy); ok {
+		return e.Explain(pair)
+	}
+	fired, reason := s.Detect(pair, repoStats)
+	return StrategyTrace{Strategy: s.Name(), Fired: fired, Reason: reason}
+}
+
+// newTrace runs Detect and wraps the result, so Explain implementations only
+// have to list their inputs.
+func newTrace(s DetectionStrategy, pair *git.CommitPair, inputs ...TraceInput) StrategyTrace {
+	fired, reason := s.Detect(pair, nil)
+	return StrategyTrace{Strategy: s.Name(), Fired: fired, Reason: reason, Inputs: inputs}
+}
+
+func (s *VelocityStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	inputs := []TraceInput{
+		{Name: "additions", Value: float64(pair.Stats.Additions)},
+		{Name: "deletions", Value: float64(pair.Stats.Deletions)},
+		{Name: "time_delta_seconds", Value: pair.TimeDelta.Seconds()},
+	}
+	if pair.TimeDelta > 0 {
+		if s.maxAdditionsPerMin > 0 {
+			v, _ := metrics.CalculateVelocityPerMinute(pair.Stats.Additions, pair.TimeDelta)
+			inputs = append(inputs, TraceInput{Name: "additions_per_min", Value: v, Threshold: s.maxAdditionsPerMin, Operator: ">"})
+		}
+		if s.maxDeletionsPerMin > 0 {
+			v, _ := metrics.CalculateVelocityPerMinute(pair.Stats.Deletions, pair.TimeDelta)
+			inputs = append(inputs, TraceInput{Name: "deletions_per_min", Value: v, Threshold: s.maxDeletionsPerMin, Operator: ">"})
+		}
+	}
+	return newTrace(s, pair, inputs...)
+}
+
+func (s *SizeStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	inputs := make([]TraceInput, 0, 2)
+	if s.suspiciousAdditions > 0 {
+		inputs = append(inputs, TraceInput{Name: "additions", Value: float64(pair.Stats.Additions), Threshold: float64(s.suspiciousAdditions), Operator: ">"})
+	}
+	if s.suspiciousDeletions > 0 {
+		inputs = append(inputs, TraceInput{Name: "deletions", Value: float64(pair.Stats.Deletions), Threshold: float64(s.suspiciousDeletions), Operator: ">"})
+	}
+	return newTrace(s, pair, inputs...)
+}
+
+func (s *TimingStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	return newTrace(s, pair, TraceInput{
+		Name: "time_delta_seconds", Value: pair.TimeDelta.Seconds(), Threshold: float64(s.minTimeDeltaSeconds), Operator: "<",
+	})
+}
+
+func (s *DispersionStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	return newTrace(s, pair, TraceInput{
+		Name: "files_changed", Value: float64(pair.Stats.FilesChanged), Threshold: float64(s.maxFilesThreshold), Operator: ">",
+	})
+}
+
+func (s *RatioStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	total := pair.Stats.Additions + pair.Stats.Deletions
+	inputs := []TraceInput{
+		{Name: "additions", Value: float64(pair.Stats.Additions)},
+		{Name: "deletions", Value: float64(pair.Stats.Deletions)},
+		{Name: "total_changes", Value: float64(total), Threshold: float64(s.minCommitSize), Operator: ">"},
+	}
+	if total > 0 {
+		if s.maxAdditionRatio > 0 {
+			inputs = append(inputs, TraceInput{Name: "addition_ratio", Value: float64(pair.Stats.Additions) / float64(total), Threshold: s.maxAdditionRatio, Operator: ">"})
+		}
+		if s.minDeletionRatio > 0 {
+			inputs = append(inputs, TraceInput{Name: "deletion_ratio", Value: float64(pair.Stats.Deletions) / float64(total), Threshold: s.minDeletionRatio, Operator: ">"})
+		}
+	}
+	return newTrace(s, pair, inputs...)
+}
+
+func (s *BurstPatternStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	return newTrace(s, pair,
+		TraceInput{Name: "time_delta_seconds", Value: pair.TimeDelta.Seconds(), Threshold: 300, Operator: "<"},
+		TraceInput{Name: "additions", Value: float64(pair.Stats.Additions), Threshold: 50, Operator: ">"},
+	)
+}
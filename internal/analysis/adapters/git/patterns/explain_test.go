@@ -0,0 +1,67 @@
+package patterns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+func TestExplain_AgreesWithDetect(t *testing.T) {
+	strategies := []DetectionStrategy{
+		NewVelocityStrategy(100, 100),
+		NewSizeStrategy(500, 500),
+		NewTimingStrategy(60),
+		NewDispersionStrategy(10),
+		NewRatioStrategy(0.9, 0.9, 50),
+		NewBurstPatternStrategy(10),
+	}
+	pairs := []*git.CommitPair{
+		{Current: &git.Commit{}, TimeDelta: 10 * time.Second, Stats: &git.DiffStats{Additions: 800, Deletions: 2, FilesChanged: 30}},
+		{Current: &git.Commit{}, TimeDelta: 2 * time.Hour, Stats: &git.DiffStats{Additions: 20, Deletions: 15, FilesChanged: 2}},
+	}
+
+	for _, s := range strategies {
+		if _, ok := s.(ExplainableStrategy); !ok {
+			t.Errorf("%s does not implement ExplainableStrategy", s.Name())
+			continue
+		}
+		for i, pair := range pairs {
+			detected, reason := s.Detect(pair, nil)
+			trace := ExplainStrategy(s, pair, nil)
+			if trace.Strategy != s.Name() || trace.Fired != detected || trace.Reason != reason {
+				t.Errorf("%s pair %d: trace = %+v, Detect = (%v, %q)", s.Name(), i, trace, detected, reason)
+			}
+			if len(trace.Inputs) == 0 {
+				t.Errorf("%s pair %d: trace has no inputs", s.Name(), i)
+			}
+		}
+	}
+}
+
+func TestExplain_SizeInputs(t *testing.T) {
+	pair := &git.CommitPair{Current: &git.Commit{}, Stats: &git.DiffStats{Additions: 800, Deletions: 5}}
+	trace := NewSizeStrategy(500, 0).Explain(pair)
+
+	if !trace.Fired {
+		t.Fatal("Explain() should fire for 800 additions over a 500 threshold")
+	}
+	if len(trace.Inputs) != 1 {
+		t.Fatalf("Inputs = %+v, want only additions (deletions check disabled)", trace.Inputs)
+	}
+	in := trace.Inputs[0]
+	if in.Name != "additions" || in.Value != 800 || in.Threshold != 500 || in.Operator != ">" {
+		t.Errorf("input = %+v, want additions 800 > 500", in)
+	}
+}
+
+func TestExplainStrategy_FallsBackToDetect(t *testing.T) {
+	s := NewCommitMessageStrategy()
+	pair := &git.CommitPair{Current: &git.Commit{Message: "fix typo"}, Stats: &git.DiffStats{Additions: 1}}
+
+	trace := ExplainStrategy(s, pair, nil)
+	detected, reason := s.Detect(pair, nil)
+	if trace.Strategy != "commit_message_analysis" || trace.Fired != detected || trace.Reason != reason || trace.Inputs != nil {
+		t.Errorf("ExplainStrategy() = %+v, want bare Detect result", trace)
+	}
+}
@@ -0,0 +1,168 @@
+package patterns
+
+import (
+	"path"
+	"strings"
+)
+
+// LanguageProfile describes what idiomatic code looks like in one language so
+// content strategies do not judge a Go diff by Java's rules.
+type LanguageProfile struct {
+	// Name is shown in detection reasons, e.g. "Go".
+	Name string
+	// ErrorMarkers are lowercase substrings that mark an error-handling line.
+	ErrorMarkers []string
+	// LinesPerErrorMarker is the expected density: about one error-handling
+	// line per this many added lines. Zero disables the missing-handling check.
+	LinesPerErrorMarker int
+	// MaxErrorMarkerRatio is the share of added lines above which error
+	// handling is considered excessive. Zero disables the check.
+	MaxErrorMarkerRatio float64
+	// CamelCaseIdentifiers marks languages where camelCase is the idiomatic
+	// style, so a high camelCase ratio is not treated as suspicious.
+	CamelCaseIdentifiers bool
+}
+
+// GenericLanguageProfile is used when no profile matches the files in a diff.
+var GenericLanguageProfile = LanguageProfile{
+	Name: "generic",
+	ErrorMarkers: []string{
+		"try", "catch", "except", "throw", "error", "exception", "handle",
+		"if err != nil", "rescue",
+	},
+	LinesPerErrorMarker: 30,
+	MaxErrorMarkerRatio: 0.2,
+}
+
+// LanguageProfiles maps lowercase file extensions (including the dot) to the
+// profile applied when that language dominates a diff. Callers may register
+// additional extensions with RegisterLanguageProfile or pass their own map to
+// the *WithProfiles strategy constructors.
+var LanguageProfiles = map[string]LanguageProfile{
+	".go": {
+		Name:                 "Go",
+		ErrorMarkers:         []string{"if err != nil", "errors.", "fmt.errorf(", "panic(", "recover()"},
+		LinesPerErrorMarker:  15,
+		MaxErrorMarkerRatio:  0.45,
+		CamelCaseIdentifiers: true,
+	},
+	".py": {
+		Name:                "Python",
+		ErrorMarkers:        []string{"try:", "except", "raise ", "finally:"},
+		LinesPerErrorMarker: 40,
+		MaxErrorMarkerRatio: 0.2,
+	},
+	".js": {
+		Name:                 "JavaScript",
+		ErrorMarkers:         []string{"try {", "catch", ".catch(", "throw ", "reject(", "finally"},
+		LinesPerErrorMarker:  40,
+		MaxErrorMarkerRatio:  0.2,
+		CamelCaseIdentifiers: true,
+	},
+	".ts": {
+		Name:                 "TypeScript",
+		ErrorMarkers:         []string{"try {", "catch", ".catch(", "throw ", "reject(", "finally"},
+		LinesPerErrorMarker:  40,
+		MaxErrorMarkerRatio:  0.2,
+		CamelCaseIdentifiers: true,
+	},
+	".java": {
+		Name:                 "Java",
+		ErrorMarkers:         []string{"try {", "catch", "throw ", "throws ", "finally"},
+		LinesPerErrorMarker:  30,
+		MaxErrorMarkerRatio:  0.25,
+		CamelCaseIdentifiers: true,
+	},
+	".rb": {
+		Name:                "Ruby",
+		ErrorMarkers:        []string{"begin", "rescue", "raise ", "ensure"},
+		LinesPerErrorMarker: 50,
+		MaxErrorMarkerRatio: 0.2,
+	},
+	".rs": {
+		Name:                "Rust",
+		ErrorMarkers:        []string{"?;", "?)", "result<", "err(", ".map_err(", ".unwrap", ".expect(", "panic!("},
+		LinesPerErrorMarker: 20,
+		MaxErrorMarkerRatio: 0.4,
+	},
+}
+
+func init() {
+	LanguageProfiles[".jsx"] = LanguageProfiles[".js"]
+	LanguageProfiles[".mjs"] = LanguageProfiles[".js"]
+	LanguageProfiles[".tsx"] = LanguageProfiles[".ts"]
+}
+
+// RegisterLanguageProfile adds or replaces the profile for a file extension
+// in LanguageProfiles. The extension may be given with or without the dot.
+func RegisterLanguageProfile(ext string, profile LanguageProfile) {
+	LanguageProfiles[normalizeExtension(ext)] = profile
+}
+
+// MergeLanguageProfiles returns a copy of LanguageProfiles with overrides
+// applied on top, keyed by normalized extension.
+func MergeLanguageProfiles(overrides map[string]LanguageProfile) map[string]LanguageProfile {
+	merged := make(map[string]LanguageProfile, len(LanguageProfiles)+len(overrides))
+	for ext, p := range LanguageProfiles {
+		merged[ext] = p
+	}
+	for ext, p := range overrides {
+		merged[normalizeExtension(ext)] = p
+	}
+	return merged
+}
+
+func normalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// dominantLanguageProfile picks the profile of the extension with the most
+// added lines in a unified diff. Files without a known profile are ignored;
+// GenericLanguageProfile is returned when nothing matches.
+func dominantLanguageProfile(diff string, profiles map[string]LanguageProfile) LanguageProfile {
+	counts := make(map[string]int)
+	ext := ""
+	for _, line := range strings.Split(diff, "\n") {
+		if strings.HasPrefix(line, "+++ ") {
+			file := strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+			ext = strings.ToLower(path.Ext(file))
+			continue
+		}
+		if ext != "" && strings.HasPrefix(line, "+") {
+			if _, ok := profiles[ext]; ok {
+				counts[ext]++
+			}
+		}
+	}
+
+	best, bestCount := "", 0
+	for e, c := range counts {
+		// Break ties by extension so the choice is deterministic.
+		if c > bestCount || (c == bestCount && e < best) {
+			best, bestCount = e, c
+		}
+	}
+	if best == "" {
+		return GenericLanguageProfile
+	}
+	return profiles[best]
+}
+
+// countErrorHandlingLines returns how many lines contain at least one of the
+// profile's error markers. lines must already be lowercased.
+func (p LanguageProfile) countErrorHandlingLines(lines []string) int {
+	count := 0
+	for _, line := range lines {
+		for _, marker := range p.ErrorMarkers {
+			if strings.Contains(line, marker) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
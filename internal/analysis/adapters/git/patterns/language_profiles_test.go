@@ -0,0 +1,122 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// fileDiff builds a diff adding the given lines to path.
+func fileDiff(path string, lines []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n", path, path, path, path)
+	for _, l := range lines {
+		b.WriteString("+" + l + "\n")
+	}
+	return b.String()
+}
+
+func goLines(n int) []string {
+	lines := make([]string, 0, n)
+	for len(lines) < n {
+		i := len(lines)
+		lines = append(lines,
+			fmt.Sprintf("v%d, err := load%d(ctx)", i, i),
+			"if err != nil {",
+			fmt.Sprintf("\treturn fmt.Errorf(\"load %d: %%w\", err)", i),
+			"}",
+			fmt.Sprintf("total += v%d", i),
+			fmt.Sprintf("log.Printf(\"loaded %%d\", v%d)", i),
+		)
+	}
+	return lines[:n]
+}
+
+func plainLines(n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("    total_%d = compute(%d) + offset", i, i)
+	}
+	return lines
+}
+
+func TestDominantLanguageProfile(t *testing.T) {
+	diff := fileDiff("main.go", goLines(5)) + fileDiff("tool.py", plainLines(12))
+	if got := dominantLanguageProfile(diff, LanguageProfiles); got.Name != "Python" {
+		t.Errorf("dominantLanguageProfile() = %s, want Python", got.Name)
+	}
+
+	if got := dominantLanguageProfile(fileDiff("notes.txt", plainLines(5)), LanguageProfiles); got.Name != GenericLanguageProfile.Name {
+		t.Errorf("unknown extension profile = %s, want generic", got.Name)
+	}
+}
+
+func TestErrorHandlingPatternStrategy_LanguageProfiles(t *testing.T) {
+	tests := []struct {
+		name       string
+		diff       string
+		wantDetect bool
+		wantReason string
+	}{
+		{name: "idiomatic go", diff: fileDiff("service.go", goLines(120)), wantDetect: false},
+		{name: "python without handling", diff: fileDiff("service.py", plainLines(150)), wantDetect: true, wantReason: "Python profile"},
+		{name: "go without handling", diff: fileDiff("service.go", plainLines(150)), wantDetect: true, wantReason: "Go profile"},
+	}
+
+	s := NewErrorHandlingPatternStrategy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added := int64(strings.Count(tt.diff, "\n+") - 1)
+			pair := &git.CommitPair{Stats: &git.DiffStats{Additions: added}, DiffContent: tt.diff}
+			detected, reason := s.Detect(pair, nil)
+			if detected != tt.wantDetect {
+				t.Fatalf("Detect() = %v (%s), want %v", detected, reason, tt.wantDetect)
+			}
+			if tt.wantReason != "" && !strings.Contains(reason, tt.wantReason) {
+				t.Errorf("reason %q should mention %q", reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestErrorHandlingPatternStrategy_CustomProfile(t *testing.T) {
+	profiles := MergeLanguageProfiles(map[string]LanguageProfile{
+		"kt": {Name: "Kotlin", ErrorMarkers: []string{"runcatching"}, LinesPerErrorMarker: 10},
+	})
+	if _, ok := profiles[".kt"]; !ok {
+		t.Fatal("MergeLanguageProfiles() should normalize the extension to .kt")
+	}
+	if _, ok := LanguageProfiles[".kt"]; ok {
+		t.Fatal("MergeLanguageProfiles() must not modify LanguageProfiles")
+	}
+
+	s := NewErrorHandlingPatternStrategyWithProfiles(profiles)
+	diff := fileDiff("App.kt", plainLines(150))
+	pair := &git.CommitPair{Stats: &git.DiffStats{Additions: 150}, DiffContent: diff}
+	detected, reason := s.Detect(pair, nil)
+	if !detected || !strings.Contains(reason, "Kotlin profile") {
+		t.Errorf("Detect() = %v (%s), want detection with the Kotlin profile", detected, reason)
+	}
+}
+
+func TestNamingPatternStrategy_CamelCaseIdiomaticLanguages(t *testing.T) {
+	lines := make([]string, 30)
+	for i := range lines {
+		lines[i] = "userCount userName orderTotal dataSize"
+	}
+
+	s := NewNamingPatternStrategy()
+	pair := &git.CommitPair{Stats: &git.DiffStats{Additions: 30}}
+
+	pair.DiffContent = fileDiff("handler.py", lines)
+	if detected, reason := s.Detect(pair, nil); !detected || !strings.Contains(reason, "Python profile") {
+		t.Errorf("python Detect() = %v (%s), want detection", detected, reason)
+	}
+
+	pair.DiffContent = fileDiff("handler.js", lines)
+	if detected, reason := s.Detect(pair, nil); detected {
+		t.Errorf("camelCase is idiomatic in JavaScript, got detection: %s", reason)
+	}
+}
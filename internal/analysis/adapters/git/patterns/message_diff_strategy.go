@@ -0,0 +1,193 @@
+package patterns
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+// mismatchMaxTrivialWords is the longest message, in words, that can still
+// describe nothing more than a trivial change.
+const mismatchMaxTrivialWords = 8
+
+// trivialChangeWords mark a message as describing a cosmetic change.
+var trivialChangeWords = map[string]bool{
+	"typo":        true,
+	"typos":       true,
+	"spelling":    true,
+	"wording":     true,
+	"whitespace":  true,
+	"formatting":  true,
+	"indentation": true,
+	"punctuation": true,
+	"nit":         true,
+	"nits":        true,
+	"tweak":       true,
+	"tweaks":      true,
+	"minor":       true,
+}
+
+// fileExtensions are the extensions a word in a message needs to be taken
+// for a file name.
+var fileExtensions = map[string]bool{
+	"go": true, "mod": true, "sum": true, "py": true, "rb": true, "js": true, "jsx": true,
+	"ts": true, "tsx": true, "vue": true, "java": true, "kt": true, "rs": true, "c": true,
+	"h": true, "cc": true, "cpp": true, "hpp": true, "cs": true, "php": true, "swift": true,
+	"scala": true, "sh": true, "sql": true, "proto": true, "md": true, "txt": true,
+	"json": true, "yaml": true, "yml": true, "toml": true, "xml": true, "html": true,
+	"css": true, "scss": true, "ini": true, "cfg": true, "lock": true, "gradle": true,
+}
+
+// frameworkNames are product names that look like file names.
+var frameworkNames = map[string]bool{
+	"node.js": true, "vue.js": true, "next.js": true, "nuxt.js": true,
+	"react.js": true, "express.js": true, "three.js": true, "d3.js": true,
+}
+
+var (
+	messageURL  = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://\S+`)
+	fileMention = regexp.MustCompile(`(?:[\w.-]+/)*[\w-]+(?:\.[\w-]+)*\.([A-Za-z][A-Za-z0-9]*)\b`)
+)
+
+// MessageDiffMismatchStrategy compares what a commit message says against
+// what the diff does. It flags a message describing a trivial change ("fix
+// typo") on a commit that changes many lines, and a message naming files the
+// commit does not touch. CommitMessageStrategy judges the message alone;
+// this strategy needs both.
+type MessageDiffMismatchStrategy struct {
+	maxTrivialLines int64
+}
+
+// NewMessageDiffMismatchStrategy creates a strategy that flags trivial-change
+// messages on commits changing more than maxTrivialLines lines (added plus
+// deleted).
+func NewMessageDiffMismatchStrategy(maxTrivialLines int64) *MessageDiffMismatchStrategy {
+	if maxTrivialLines <= 0 {
+		maxTrivialLines = 200
+	}
+	return &MessageDiffMismatchStrategy{maxTrivialLines: maxTrivialLines}
+}
+
+func (s *MessageDiffMismatchStrategy) Name() string        { return "message_diff_mismatch_analysis" }
+func (s *MessageDiffMismatchStrategy) Category() string    { return "behavioral" }
+func (s *MessageDiffMismatchStrategy) Confidence() float64 { return 0.5 }
+func (s *MessageDiffMismatchStrategy) Description() string {
+	return "Detects commit messages that describe a trivial change or name files the diff does not match"
+}
+
+func (s *MessageDiffMismatchStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.Current == nil || pair.Stats == nil {
+		return false, ""
+	}
+	message := messageWithoutTrailers(pair.Current)
+	changed := pair.Stats.Additions + pair.Stats.Deletions
+
+	if word, ok := trivialMessageWord(message); ok && changed > s.maxTrivialLines {
+		return true, fmt.Sprintf(
+			"Commit message %q describes a trivial change (%q) but the diff changes %d lines across %d files, %.1fx the %d-line limit for such a message",
+			subjectLine(message), word, changed, pair.Stats.FilesChanged,
+			float64(changed)/float64(s.maxTrivialLines), s.maxTrivialLines,
+		)
+	}
+
+	if missing, mentioned := missingFileMentions(message, pair.Stats); len(missing) > 0 {
+		return true, fmt.Sprintf(
+			"Commit message names %d of %d files that are not in the changeset (%s); the commit changes %d other files",
+			len(missing), mentioned, strings.Join(missing, ", "), pair.Stats.FilesChanged,
+		)
+	}
+
+	return false, ""
+}
+
+func (s *MessageDiffMismatchStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	if pair == nil || pair.Current == nil || pair.Stats == nil {
+		return newTrace(s, pair)
+	}
+	message := messageWithoutTrailers(pair.Current)
+	trivial := 0.0
+	if _, ok := trivialMessageWord(message); ok {
+		trivial = 1
+	}
+	missing, mentioned := missingFileMentions(message, pair.Stats)
+	return newTrace(s, pair,
+		TraceInput{Name: "trivial_message", Value: trivial},
+		TraceInput{Name: "changed_lines", Value: float64(pair.Stats.Additions + pair.Stats.Deletions), Threshold: float64(s.maxTrivialLines), Operator: ">"},
+		TraceInput{Name: "mentioned_files", Value: float64(mentioned)},
+		TraceInput{Name: "missing_files", Value: float64(len(missing)), Threshold: 0, Operator: ">"},
+	)
+}
+
+// messageWithoutTrailers returns the commit message minus its closing
+// trailer paragraph.
+func messageWithoutTrailers(c *git.Commit) string {
+	message := strings.TrimSpace(c.Message)
+	if len(c.Trailers) > 0 {
+		if sep := strings.LastIndex(message, "\n\n"); sep >= 0 {
+			message = strings.TrimSpace(message[:sep])
+		}
+	}
+	return message
+}
+
+func subjectLine(message string) string {
+	subject, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// trivialMessageWord returns the word marking a short message as describing
+// a trivial change.
+func trivialMessageWord(message string) (string, bool) {
+	words := strings.FieldsFunc(strings.ToLower(message), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '\'')
+	})
+	if len(words) > mismatchMaxTrivialWords {
+		return "", false
+	}
+	for _, w := range words {
+		if trivialChangeWords[w] {
+			return w, true
+		}
+	}
+	return "", false
+}
+
+// missingFileMentions returns the distinct file names the message mentions
+// that no changed file matches, and how many it mentions in all. It reports
+// nothing when stats.Files leaves some changed files out (excluded,
+// generated, binary or renamed without edits), since a mentioned file may
+// be among them.
+func missingFileMentions(message string, stats *git.DiffStats) (missing []string, mentioned int) {
+	if len(stats.Files) == 0 || stats.FilesChangedTotal > len(stats.Files) {
+		return nil, 0
+	}
+
+	seen := make(map[string]bool)
+	for _, m := range fileMention.FindAllStringSubmatch(messageURL.ReplaceAllString(message, " "), -1) {
+		name := m[0]
+		if !fileExtensions[strings.ToLower(m[1])] || frameworkNames[strings.ToLower(name)] || seen[name] {
+			continue
+		}
+		seen[name] = true
+		mentioned++
+		if !changesetHas(stats.Files, name) {
+			missing = append(missing, name)
+		}
+	}
+	return missing, mentioned
+}
+
+// changesetHas reports whether a changed file is the one a message names:
+// the same path, a path ending in it, or the same base name.
+func changesetHas(files []git.FileStat, name string) bool {
+	for _, f := range files {
+		if f.Path == name || strings.HasSuffix(f.Path, "/"+name) || strings.EqualFold(path.Base(f.Path), path.Base(name)) {
+			return true
+		}
+	}
+	return false
+}
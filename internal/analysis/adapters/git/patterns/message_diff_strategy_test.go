@@ -0,0 +1,106 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// mismatchPair builds a commit pair with the given message and changed
+// files (path -> additions).
+func mismatchPair(message string, files map[string]int64) *git.CommitPair {
+	stats := &git.DiffStats{FilesChanged: len(files), FilesChangedTotal: len(files)}
+	for path, additions := range files {
+		stats.Files = append(stats.Files, git.FileStat{Path: path, Additions: additions})
+		stats.Additions += additions
+	}
+	return &git.CommitPair{
+		Current: &git.Commit{Hash: "abc", Message: message, Trailers: git.ParseTrailers(message)},
+		Stats:   stats,
+	}
+}
+
+func TestMessageDiffMismatchStrategy_TrivialMessage(t *testing.T) {
+	s := NewMessageDiffMismatchStrategy(0)
+
+	tests := []struct {
+		name    string
+		message string
+		files   map[string]int64
+		want    bool
+	}{
+		{"typo on a huge diff", "fix typo", map[string]int64{"auth.go": 300, "db.go": 112}, true},
+		{"typo on a small diff", "Fix typo", map[string]int64{"auth.go": 2}, false},
+		{"trailers do not count as words", "Fix typo\n\nSigned-off-by: A <a@example.com>\nReviewed-by: B <b@example.com>", map[string]int64{"auth.go": 450}, true},
+		{"long message explains the change", "Fix typo in the login form and rewrite session handling to use the new token store", map[string]int64{"auth.go": 450}, false},
+		{"non-trivial message", "Rewrite session handling", map[string]int64{"auth.go": 450}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := s.Detect(mismatchPair(tt.message, tt.files), nil)
+			if got != tt.want {
+				t.Fatalf("Detect() = %v (%q), want %v", got, reason, tt.want)
+			}
+		})
+	}
+
+	_, reason := s.Detect(mismatchPair("fix typo", map[string]int64{"auth.go": 300, "db.go": 112}), nil)
+	for _, want := range []string{"412 lines", "2 files", "2.1x", "200-line"} {
+		if !strings.Contains(reason, want) {
+			t.Errorf("reason %q should mention %q", reason, want)
+		}
+	}
+}
+
+func TestMessageDiffMismatchStrategy_FileMentions(t *testing.T) {
+	s := NewMessageDiffMismatchStrategy(0)
+	files := map[string]int64{"internal/auth/session.go": 20, "README.md": 3}
+
+	tests := []struct {
+		name    string
+		message string
+		want    bool
+	}{
+		{"mentioned by base name", "Handle expired tokens in session.go", false},
+		{"mentioned by path", "Update internal/auth/session.go and README.md", false},
+		{"file not in changeset", "Handle expired tokens in token_store.go", true},
+		{"framework names are not files", "Support Node.js 22 in the session handler", false},
+		{"versions are not files", "Bump version to 1.2.3", false},
+		{"urls are ignored", "See https://example.com/docs/auth.html for the session rules", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, reason := s.Detect(mismatchPair(tt.message, files), nil)
+			if got != tt.want {
+				t.Fatalf("Detect() = %v (%q), want %v", got, reason, tt.want)
+			}
+		})
+	}
+
+	_, reason := s.Detect(mismatchPair("Fix session.go and token_store.go", files), nil)
+	if !strings.Contains(reason, "1 of 2 files") || !strings.Contains(reason, "token_store.go") {
+		t.Errorf("reason %q should count and name the missing file", reason)
+	}
+
+	hidden := mismatchPair("Handle expired tokens in token_store.go", files)
+	hidden.Stats.FilesChangedTotal++
+	if got, reason := s.Detect(hidden, nil); got {
+		t.Errorf("files left out of the stats may be the mentioned ones, got %q", reason)
+	}
+}
+
+func TestMessageDiffMismatchStrategy_Explain(t *testing.T) {
+	s := NewMessageDiffMismatchStrategy(100)
+	pair := mismatchPair("fix typo", map[string]int64{"auth.go": 150})
+
+	trace := s.Explain(pair)
+	if !trace.Fired || trace.Strategy != s.Name() {
+		t.Fatalf("trace = %+v, want a fired trace", trace)
+	}
+	if len(trace.Inputs) < 2 || trace.Inputs[1].Name != "changed_lines" || trace.Inputs[1].Value != 150 || trace.Inputs[1].Threshold != 100 {
+		t.Errorf("inputs = %+v", trace.Inputs)
+	}
+}
@@ -0,0 +1,283 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+const (
+	// messageStyleRareShare is the largest share of an author's other
+	// messages a trait value may have for a message with it to deviate.
+	messageStyleRareShare = 0.1
+	// messageStyleMinLengthRatio and messageStyleMinLengthZ are how much
+	// longer than the author's other subject lines a subject must be, as a
+	// ratio of their mean and in standard deviations, to deviate in length.
+	messageStyleMinLengthRatio = 2.0
+	messageStyleMinLengthZ     = 3.0
+	// messageStyleMinDeviations is how many style traits a message must
+	// deviate in to be flagged; one odd trait is ordinary variation.
+	messageStyleMinDeviations = 3
+	// messageStyleQuoteLen caps the subject line quoted in reasons.
+	messageStyleQuoteLen = 80
+)
+
+// imperativeVerbs are common first words of imperative subject lines
+// ("add", "fix"), the form git's own conventions ask for.
+var imperativeVerbs = map[string]bool{
+	"add": true, "allow": true, "avoid": true, "bump": true, "change": true, "clean": true,
+	"convert": true, "create": true, "delete": true, "disable": true, "document": true,
+	"drop": true, "enable": true, "ensure": true, "extract": true, "fix": true,
+	"handle": true, "implement": true, "improve": true, "introduce": true, "make": true,
+	"merge": true, "move": true, "refactor": true, "remove": true, "rename": true,
+	"replace": true, "restore": true, "revert": true, "set": true, "simplify": true,
+	"skip": true, "support": true, "switch": true, "test": true, "tweak": true,
+	"update": true, "upgrade": true, "use": true,
+}
+
+// messageStyle is the style of one commit message.
+type messageStyle struct {
+	subjectLen  int
+	capitalized bool
+	period      bool
+	body        bool
+	mood        string // imperative, past, gerund or other
+}
+
+func styleOf(message string) messageStyle {
+	subject := subjectLine(message)
+	style := messageStyle{
+		subjectLen: len([]rune(subject)),
+		period:     strings.HasSuffix(subject, "."),
+		body:       strings.Contains(message, "\n"),
+		mood:       "other",
+	}
+	if r := []rune(subject); len(r) > 0 {
+		style.capitalized = unicode.IsUpper(r[0])
+	}
+	if fields := strings.Fields(subject); len(fields) > 0 {
+		word := strings.ToLower(strings.TrimRight(fields[0], ":,"))
+		switch {
+		case imperativeVerbs[word]:
+			style.mood = "imperative"
+		case strings.HasSuffix(word, "ed") && imperativeVerbs[strings.TrimSuffix(word, "d")],
+			strings.HasSuffix(word, "ed") && imperativeVerbs[strings.TrimSuffix(word, "ed")]:
+			style.mood = "past"
+		case strings.HasSuffix(word, "ing"):
+			style.mood = "gerund"
+		}
+	}
+	return style
+}
+
+// authorStyle summarizes the messages of one author.
+type authorStyle struct {
+	n             int
+	lenSum, lenSq float64
+	capitalized   int
+	period        int
+	body          int
+	moods         map[string]int
+}
+
+func (a *authorStyle) add(s messageStyle) {
+	a.n++
+	a.lenSum += float64(s.subjectLen)
+	a.lenSq += float64(s.subjectLen) * float64(s.subjectLen)
+	a.capitalized += boolCount(s.capitalized)
+	a.period += boolCount(s.period)
+	a.body += boolCount(s.body)
+	a.moods[s.mood]++
+}
+
+// without returns the summary of the author's messages other than s, so a
+// message is judged against the rest of the author's history.
+func (a *authorStyle) without(s messageStyle) *authorStyle {
+	rest := &authorStyle{
+		n:           a.n - 1,
+		lenSum:      a.lenSum - float64(s.subjectLen),
+		lenSq:       a.lenSq - float64(s.subjectLen)*float64(s.subjectLen),
+		capitalized: a.capitalized - boolCount(s.capitalized),
+		period:      a.period - boolCount(s.period),
+		body:        a.body - boolCount(s.body),
+		moods:       make(map[string]int, len(a.moods)),
+	}
+	for mood, n := range a.moods {
+		rest.moods[mood] = n
+	}
+	rest.moods[s.mood]--
+	return rest
+}
+
+func (a *authorStyle) share(count int) float64 {
+	return float64(count) / float64(a.n)
+}
+
+func (a *authorStyle) meanLen() float64 { return a.lenSum / float64(a.n) }
+
+func (a *authorStyle) stdLen() float64 {
+	mean := a.meanLen()
+	return math.Sqrt(math.Max(a.lenSq/float64(a.n)-mean*mean, 0))
+}
+
+// usualMood returns the author's most common mood, breaking ties by name.
+func (a *authorStyle) usualMood() string {
+	best, bestCount := "", -1
+	for mood, n := range a.moods {
+		if n > bestCount || (n == bestCount && mood < best) {
+			best, bestCount = mood, n
+		}
+	}
+	return best
+}
+
+// String describes the author's usual style, e.g. "avg 18 chars, lowercase,
+// no trailing period, subject only, mostly imperative".
+func (a *authorStyle) String() string {
+	parts := []string{fmt.Sprintf("avg %.0f chars", a.meanLen())}
+	parts = append(parts, pick(a.share(a.capitalized) >= 0.5, "capitalized", "lowercase"))
+	parts = append(parts, pick(a.share(a.period) >= 0.5, "trailing period", "no trailing period"))
+	parts = append(parts, pick(a.share(a.body) >= 0.5, "with body", "subject only"))
+	parts = append(parts, "mostly "+a.usualMood())
+	return strings.Join(parts, ", ")
+}
+
+func pick(b bool, yes, no string) string {
+	if b {
+		return yes
+	}
+	return no
+}
+
+func boolCount(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// MessageStyleConsistencyStrategy models each author's commit message style
+// (subject length, capitalization, trailing punctuation, message body and
+// the mood of the first word) and flags messages that break with it in
+// several ways at once. An author who always writes terse lowercase
+// subjects and suddenly commits a long, capitalized, punctuated message
+// with a body is often not the one who wrote it.
+type MessageStyleConsistencyStrategy struct {
+	minHistory int
+
+	// flagged maps commit hashes to their reason, computed from the baseline.
+	flagged map[string]string
+}
+
+// NewMessageStyleConsistencyStrategy creates a strategy that models authors
+// with at least minHistory commits (0 = 8).
+func NewMessageStyleConsistencyStrategy(minHistory int) *MessageStyleConsistencyStrategy {
+	if minHistory <= 0 {
+		minHistory = 8
+	}
+	return &MessageStyleConsistencyStrategy{minHistory: minHistory}
+}
+
+func (s *MessageStyleConsistencyStrategy) Name() string        { return "message_style_consistency_analysis" }
+func (s *MessageStyleConsistencyStrategy) Category() string    { return "behavioral" }
+func (s *MessageStyleConsistencyStrategy) Confidence() float64 { return 0.45 }
+func (s *MessageStyleConsistencyStrategy) Description() string {
+	return "Detects commit messages whose style departs sharply from the author's usual messages"
+}
+
+func (s *MessageStyleConsistencyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.Current == nil {
+		return false, ""
+	}
+	reason, ok := s.flagged[pair.Current.Hash]
+	return ok, reason
+}
+
+// styledCommit reports whether c's message was written by its author:
+// merge and revert messages are generated by git.
+func styledCommit(c *git.Commit) bool {
+	return len(c.Parents) <= 1 && !strings.HasPrefix(c.Message, `Revert "`) && strings.TrimSpace(c.Message) != ""
+}
+
+// SetBaseline learns each author's message style from every commit in pairs
+// and decides which commits to flag.
+func (s *MessageStyleConsistencyStrategy) SetBaseline(pairs []*git.CommitPair) {
+	s.flagged = make(map[string]string)
+
+	type styled struct {
+		commit *git.Commit
+		style  messageStyle
+	}
+	byAuthor := make(map[string][]styled)
+	for _, c := range baselineCommits(pairs) {
+		if !styledCommit(c) {
+			continue
+		}
+		author := strings.ToLower(c.Email)
+		byAuthor[author] = append(byAuthor[author], styled{c, styleOf(messageWithoutTrailers(c))})
+	}
+
+	for author, history := range byAuthor {
+		if len(history) < s.minHistory {
+			continue
+		}
+		all := &authorStyle{moods: make(map[string]int)}
+		for _, h := range history {
+			all.add(h.style)
+		}
+		for _, h := range history {
+			rest := all.without(h.style)
+			if deviations := styleDeviations(h.style, rest); len(deviations) >= messageStyleMinDeviations {
+				s.flagged[h.commit.Hash] = fmt.Sprintf(
+					"Message style departs from %s's usual style (%d other commits: %s): %s in %q",
+					author, rest.n, rest, strings.Join(deviations, ", "), quoteSubject(h.commit.Message),
+				)
+			}
+		}
+	}
+}
+
+// styleDeviations lists the traits in which style departs from base.
+func styleDeviations(style messageStyle, base *authorStyle) []string {
+	var out []string
+	mean, std := base.meanLen(), base.stdLen()
+	// Authors with very regular subjects would otherwise deviate by a few
+	// characters.
+	std = math.Max(std, math.Max(5, mean*0.25))
+	if float64(style.subjectLen) >= mean*messageStyleMinLengthRatio && (float64(style.subjectLen)-mean)/std >= messageStyleMinLengthZ {
+		out = append(out, fmt.Sprintf("%d-char subject", style.subjectLen))
+	}
+	rare := func(count int, has bool) bool {
+		if !has {
+			count = base.n - count
+		}
+		return base.share(count) <= messageStyleRareShare
+	}
+	if rare(base.capitalized, style.capitalized) {
+		out = append(out, pick(style.capitalized, "capitalized", "lowercase"))
+	}
+	if rare(base.period, style.period) {
+		out = append(out, pick(style.period, "trailing period", "no trailing period"))
+	}
+	if rare(base.body, style.body) {
+		out = append(out, pick(style.body, "with body", "subject only"))
+	}
+	if usual := base.usualMood(); style.mood != usual && base.share(base.moods[style.mood]) <= messageStyleRareShare &&
+		base.share(base.moods[usual]) >= 0.6 {
+		out = append(out, style.mood+" mood")
+	}
+	return out
+}
+
+// quoteSubject returns the message's subject line, shortened for reasons.
+func quoteSubject(message string) string {
+	subject := []rune(subjectLine(message))
+	if len(subject) > messageStyleQuoteLen {
+		return string(subject[:messageStyleQuoteLen-3]) + "..."
+	}
+	return string(subject)
+}
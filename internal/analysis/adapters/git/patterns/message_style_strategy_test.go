@@ -0,0 +1,128 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// messagePairs chains commits with the given authors and messages, oldest
+// first, into pairs the way GetCommitPairs does. Commit i gets hash "m<i>".
+func messagePairs(history [][2]string) []*git.CommitPair {
+	start := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	commits := make([]*git.Commit, len(history))
+	for i, h := range history {
+		commits[i] = &git.Commit{
+			Hash:      fmt.Sprintf("m%d", i),
+			Email:     h[0],
+			Message:   h[1],
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+		}
+	}
+	pairs := make([]*git.CommitPair, 0, len(commits))
+	for i := len(commits) - 1; i > 0; i-- {
+		pairs = append(pairs, &git.CommitPair{Previous: commits[i-1], Current: commits[i], Stats: &git.DiffStats{}})
+	}
+	return pairs
+}
+
+// terseHistory gives bob n short lowercase imperative subjects.
+func terseHistory(n int) [][2]string {
+	subjects := []string{"fix typo", "add retry", "bump deps", "fix login bug", "update readme", "tweak config"}
+	history := make([][2]string, n)
+	for i := range history {
+		history[i] = [2]string{"bob@example.com", subjects[i%len(subjects)]}
+	}
+	return history
+}
+
+func messageStyleFlagged(s *MessageStyleConsistencyStrategy, pairs []*git.CommitPair) map[string]string {
+	flagged := make(map[string]string)
+	for _, pair := range pairs {
+		if ok, reason := s.Detect(pair, nil); ok {
+			flagged[pair.Current.Hash] = reason
+		}
+	}
+	return flagged
+}
+
+func TestMessageStyleConsistencyStrategy_SuddenVerboseMessage(t *testing.T) {
+	history := append(terseHistory(10), [2]string{"Bob@Example.com",
+		"Implemented comprehensive error handling for the authentication module.\n\nThis change ensures robust behavior."})
+	history = append(history, [2]string{"bob@example.com", "fix test"})
+	pairs := messagePairs(history)
+
+	s := NewMessageStyleConsistencyStrategy(0)
+	s.SetBaseline(pairs)
+	flagged := messageStyleFlagged(s, pairs)
+
+	reason, ok := flagged["m10"]
+	if len(flagged) != 1 || !ok {
+		t.Fatalf("flagged = %v, want only the verbose commit m10", flagged)
+	}
+	for _, want := range []string{
+		"bob@example.com's usual style (11 other commits: avg 10 chars, lowercase, no trailing period, subject only, mostly imperative)",
+		"capitalized", "trailing period", "with body", "past mood",
+		`"Implemented comprehensive error handling for the authentication module."`,
+	} {
+		if !strings.Contains(reason, want) {
+			t.Errorf("reason %q should contain %q", reason, want)
+		}
+	}
+}
+
+func TestMessageStyleConsistencyStrategy_NotFlagged(t *testing.T) {
+	tests := []struct {
+		name    string
+		history [][2]string
+	}{
+		{
+			name:    "too few commits to model",
+			history: append(terseHistory(5), [2]string{"bob@example.com", "Implemented a much longer message.\n\nWith a body."}),
+		},
+		{
+			name:    "one odd trait",
+			history: append(terseHistory(10), [2]string{"bob@example.com", "Fix typo"}),
+		},
+		{
+			name:    "revert message",
+			history: append(terseHistory(10), [2]string{"bob@example.com", `Revert "fix typo"` + "\n\nThis reverts commit abc."}),
+		},
+		{
+			name: "other author's style",
+			history: append(terseHistory(10),
+				[2]string{"carol@example.com", "Implemented comprehensive error handling.\n\nWith a body."}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs := messagePairs(tt.history)
+			s := NewMessageStyleConsistencyStrategy(0)
+			s.SetBaseline(pairs)
+			if flagged := messageStyleFlagged(s, pairs); len(flagged) != 0 {
+				t.Errorf("flagged = %v, want none", flagged)
+			}
+		})
+	}
+}
+
+func TestStyleOf(t *testing.T) {
+	tests := []struct {
+		message string
+		want    messageStyle
+	}{
+		{"fix typo", messageStyle{subjectLen: 8, mood: "imperative"}},
+		{"Added tests.", messageStyle{subjectLen: 12, capitalized: true, period: true, mood: "past"}},
+		{"Updated docs\n\nMore detail", messageStyle{subjectLen: 12, capitalized: true, body: true, mood: "past"}},
+		{"adding cache", messageStyle{subjectLen: 12, mood: "gerund"}},
+		{"feat: new parser", messageStyle{subjectLen: 16, mood: "other"}},
+	}
+	for _, tt := range tests {
+		if got := styleOf(tt.message); got != tt.want {
+			t.Errorf("styleOf(%q) = %+v, want %+v", tt.message, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+const (
+	// DefaultGitNGramCoverage is the share of added tokens inside repeated
+	// 3- and 4-token phrases above which a commit is flagged. Code repeats
+	// itself far more than prose, so the bar is high.
+	DefaultGitNGramCoverage = 0.75
+	// ngramMinTokens is the smallest added-token sample worth scoring.
+	ngramMinTokens = 80
+)
+
+// NGramRepetitionStrategy flags commits whose added lines are dominated by
+// repeated 3- and 4-token phrases, as copy-pasted blocks and generated
+// boilerplate are.
+type NGramRepetitionStrategy struct {
+	maxCoverage float64
+}
+
+// NewNGramRepetitionStrategy creates a strategy that flags added code whose
+// repeated n-gram coverage exceeds maxCoverage (0 uses
+// DefaultGitNGramCoverage).
+func NewNGramRepetitionStrategy(maxCoverage float64) *NGramRepetitionStrategy {
+	if maxCoverage <= 0 || maxCoverage > 1 {
+		maxCoverage = DefaultGitNGramCoverage
+	}
+	return &NGramRepetitionStrategy{maxCoverage: maxCoverage}
+}
+
+func (s *NGramRepetitionStrategy) Name() string        { return "ngram_repetition_analysis" }
+func (s *NGramRepetitionStrategy) Category() string    { return "pattern" }
+func (s *NGramRepetitionStrategy) Confidence() float64 { return 0.45 }
+func (s *NGramRepetitionStrategy) Description() string {
+	return "Detects added code dominated by repeated 3- and 4-token phrases"
+}
+
+func (s *NGramRepetitionStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.DiffContent == "" {
+		return false, ""
+	}
+
+	tokens := addedTokens(pair.DiffContent)
+	if len(tokens) < ngramMinTokens {
+		return false, ""
+	}
+
+	rep := analysis.RepeatedNGrams(tokens, 3, 4, 3)
+	if rep.Coverage <= s.maxCoverage {
+		return false, ""
+	}
+
+	phrases := make([]string, len(rep.Top))
+	for i, p := range rep.Top {
+		phrases[i] = fmt.Sprintf("%q ×%d", p.Phrase, p.Count)
+	}
+	return true, fmt.Sprintf(
+		"Repeated phrases cover %.0f%% of %d added tokens (threshold %.0f%%): %s",
+		rep.Coverage*100, rep.Tokens, s.maxCoverage*100, strings.Join(phrases, ", "),
+	)
+}
+
+// addedTokens splits the added lines of a unified diff into identifier and
+// number tokens.
+func addedTokens(diff string) []string {
+	words := make([]string, 0)
+	for _, line := range strings.Split(diff, "\n") {
+		if !strings.HasPrefix(line, "+") || strings.HasPrefix(line, "+++") {
+			continue
+		}
+		words = append(words, strings.FieldsFunc(line[1:], isTokenSeparator)...)
+	}
+	return words
+}
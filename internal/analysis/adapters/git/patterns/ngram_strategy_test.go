@@ -0,0 +1,45 @@
+package patterns
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+func TestNGramRepetitionStrategy(t *testing.T) {
+	tests := []struct {
+		name       string
+		diff       string
+		coverage   float64
+		wantDetect bool
+	}{
+		{name: "empty diff", diff: "", wantDetect: false},
+		{name: "too few tokens", diff: "+++ b/a.go\n+x := 1\n", wantDetect: false},
+		{name: "templated code", diff: templatedDiff(8), wantDetect: true},
+		{name: "varied code", diff: variedDiff(1), wantDetect: false},
+		{name: "templated code under a lenient threshold", diff: templatedDiff(8), coverage: 0.99, wantDetect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewNGramRepetitionStrategy(tt.coverage)
+			pair := &git.CommitPair{Stats: &git.DiffStats{}, DiffContent: tt.diff}
+			detected, reason := s.Detect(pair, nil)
+			if detected != tt.wantDetect {
+				t.Fatalf("Detect() = %v (%q), want %v", detected, reason, tt.wantDetect)
+			}
+			if detected && !strings.Contains(reason, "×") {
+				t.Errorf("reason %q does not list the repeated phrases", reason)
+			}
+		})
+	}
+}
+
+func TestAddedTokens(t *testing.T) {
+	diff := "+++ b/a.go\n--- a/a.go\n-old := 1\n+new_value := compute(x, 42)\n context\n"
+	got := strings.Join(addedTokens(diff), " ")
+	if got != "new_value compute x 42" {
+		t.Errorf("addedTokens() = %q", got)
+	}
+}
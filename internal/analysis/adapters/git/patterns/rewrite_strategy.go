@@ -0,0 +1,154 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+const (
+	// rewriteMaxLines caps how many removed and added lines are compared so a
+	// pathological diff stays linear in the capped size.
+	rewriteMaxLines = 2000
+	// rewriteMinTokens is the smallest removed and added token sample worth
+	// comparing.
+	rewriteMinTokens = 100
+)
+
+// RewriteSimilarityStrategy compares the words on removed and added lines of
+// a commit that both deletes and adds a lot of code. A refactor keeps most of
+// its vocabulary (renames, moves, reordering); a wholesale regeneration
+// replaces it with new tokens, so its overlap is low.
+type RewriteSimilarityStrategy struct {
+	minChangedLines int64
+	maxOverlap      float64
+}
+
+// NewRewriteSimilarityStrategy creates a strategy that considers commits with
+// at least minChangedLines additions and deletions, and flags those whose
+// word overlap between removed and added code is below maxOverlap (0-1).
+func NewRewriteSimilarityStrategy(minChangedLines int64, maxOverlap float64) *RewriteSimilarityStrategy {
+	if minChangedLines <= 0 {
+		minChangedLines = 50
+	}
+	if maxOverlap <= 0 {
+		maxOverlap = 0.3
+	}
+	return &RewriteSimilarityStrategy{
+		minChangedLines: minChangedLines,
+		maxOverlap:      maxOverlap,
+	}
+}
+
+func (s *RewriteSimilarityStrategy) Name() string        { return "rewrite_similarity_analysis" }
+func (s *RewriteSimilarityStrategy) Category() string    { return "structural" }
+func (s *RewriteSimilarityStrategy) Confidence() float64 { return 0.55 }
+func (s *RewriteSimilarityStrategy) Description() string {
+	return "Detects large rewrites whose added code shares little vocabulary with the code it replaces"
+}
+
+func (s *RewriteSimilarityStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.Stats == nil || pair.DiffContent == "" {
+		return false, ""
+	}
+	if pair.Stats.Additions < s.minChangedLines || pair.Stats.Deletions < s.minChangedLines {
+		return false, ""
+	}
+
+	sim := diffWordSimilarity(pair.DiffContent)
+	if sim.removedTokens < rewriteMinTokens || sim.addedTokens < rewriteMinTokens {
+		return false, ""
+	}
+
+	if sim.overlap < s.maxOverlap {
+		return true, fmt.Sprintf(
+			"Low word overlap between removed and added code: %.0f%% (%.0f%% of added tokens are new, %d removed / %d added tokens) - may indicate wholesale regeneration rather than a refactor",
+			sim.overlap*100, sim.novelShare*100, sim.removedTokens, sim.addedTokens,
+		)
+	}
+
+	return false, ""
+}
+
+func (s *RewriteSimilarityStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	if pair == nil || pair.Stats == nil {
+		return newTrace(s, pair)
+	}
+	inputs := []TraceInput{
+		{Name: "additions", Value: float64(pair.Stats.Additions), Threshold: float64(s.minChangedLines), Operator: ">"},
+		{Name: "deletions", Value: float64(pair.Stats.Deletions), Threshold: float64(s.minChangedLines), Operator: ">"},
+	}
+	if pair.DiffContent != "" {
+		sim := diffWordSimilarity(pair.DiffContent)
+		inputs = append(inputs,
+			TraceInput{Name: "removed_tokens", Value: float64(sim.removedTokens), Threshold: rewriteMinTokens, Operator: ">"},
+			TraceInput{Name: "added_tokens", Value: float64(sim.addedTokens), Threshold: rewriteMinTokens, Operator: ">"},
+			TraceInput{Name: "word_overlap", Value: sim.overlap, Threshold: s.maxOverlap, Operator: "<"},
+		)
+	}
+	return newTrace(s, pair, inputs...)
+}
+
+type wordSimilarity struct {
+	removedTokens int
+	addedTokens   int
+	// overlap is the Dice coefficient of the removed and added token
+	// multisets: 1 when added code reuses every removed token, 0 when it
+	// shares none. Word order does not matter, so moved code still overlaps.
+	overlap float64
+	// novelShare is the fraction of added tokens that never appear on a
+	// removed line.
+	novelShare float64
+}
+
+// diffWordSimilarity compares word tokens on the removed and added lines of a
+// unified diff, reading at most rewriteMaxLines of each.
+func diffWordSimilarity(diff string) wordSimilarity {
+	removed := make(map[string]int)
+	added := make(map[string]int)
+	var sim wordSimilarity
+	removedLines, addedLines := 0, 0
+
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++"):
+			continue
+		case strings.HasPrefix(line, "-") && removedLines < rewriteMaxLines:
+			removedLines++
+			for _, w := range strings.FieldsFunc(line[1:], isTokenSeparator) {
+				removed[w]++
+				sim.removedTokens++
+			}
+		case strings.HasPrefix(line, "+") && addedLines < rewriteMaxLines:
+			addedLines++
+			for _, w := range strings.FieldsFunc(line[1:], isTokenSeparator) {
+				added[w]++
+				sim.addedTokens++
+			}
+		}
+	}
+
+	if sim.removedTokens == 0 || sim.addedTokens == 0 {
+		return sim
+	}
+
+	common, novel := 0, 0
+	for w, n := range added {
+		r := removed[w]
+		if r == 0 {
+			novel += n
+			continue
+		}
+		if r < n {
+			common += r
+		} else {
+			common += n
+		}
+	}
+
+	sim.overlap = 2 * float64(common) / float64(sim.removedTokens+sim.addedTokens)
+	sim.novelShare = float64(novel) / float64(sim.addedTokens)
+	return sim
+}
@@ -0,0 +1,90 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// rewriteDiff builds a diff removing and adding n lines each. The removed
+// lines use the "old" vocabulary; added lines reuse it (refactor) or draw on
+// an unrelated one (regeneration).
+func rewriteDiff(n int, refactor bool) string {
+	var b strings.Builder
+	b.WriteString("--- a/service.go\n+++ b/service.go\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "-\tuserCount%d := store.LoadUsers(ctx, tenant%d, limit)\n", i, i)
+	}
+	for i := 0; i < n; i++ {
+		if refactor {
+			// Same words, reordered and renamed slightly.
+			fmt.Fprintf(&b, "+\tuserCount%d := store.LoadUsers(ctx, limit, tenant%d)\n", n-1-i, n-1-i)
+		} else {
+			fmt.Fprintf(&b, "+\tresponsePayload%d = processingPipeline.ExecuteStage(requestEnvelope%d)\n", i, i)
+		}
+	}
+	return b.String()
+}
+
+func TestRewriteSimilarityStrategy(t *testing.T) {
+	tests := []struct {
+		name       string
+		additions  int64
+		deletions  int64
+		diff       string
+		wantDetect bool
+	}{
+		{name: "empty diff", additions: 100, deletions: 100, diff: "", wantDetect: false},
+		{name: "small change", additions: 10, deletions: 10, diff: rewriteDiff(10, false), wantDetect: false},
+		{name: "additions only", additions: 100, deletions: 0, diff: rewriteDiff(60, false), wantDetect: false},
+		{name: "refactor", additions: 60, deletions: 60, diff: rewriteDiff(60, true), wantDetect: false},
+		{name: "regeneration", additions: 60, deletions: 60, diff: rewriteDiff(60, false), wantDetect: true},
+	}
+
+	s := NewRewriteSimilarityStrategy(0, 0)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pair := &git.CommitPair{
+				Stats:       &git.DiffStats{Additions: tt.additions, Deletions: tt.deletions},
+				DiffContent: tt.diff,
+			}
+			detected, reason := s.Detect(pair, nil)
+			if detected != tt.wantDetect {
+				t.Fatalf("Detect() = %v (%s), want %v", detected, reason, tt.wantDetect)
+			}
+			if detected && !strings.Contains(reason, "% (") {
+				t.Errorf("reason should report the overlap percentage: %q", reason)
+			}
+		})
+	}
+}
+
+func TestDiffWordSimilarity(t *testing.T) {
+	refactor := diffWordSimilarity(rewriteDiff(60, true))
+	if refactor.overlap < 0.9 || refactor.novelShare > 0.1 {
+		t.Errorf("refactor overlap = %.2f, novel = %.2f; want high overlap", refactor.overlap, refactor.novelShare)
+	}
+
+	regen := diffWordSimilarity(rewriteDiff(60, false))
+	if regen.overlap > 0.3 || regen.novelShare < 0.7 {
+		t.Errorf("regeneration overlap = %.2f, novel = %.2f; want low overlap", regen.overlap, regen.novelShare)
+	}
+
+	// Header lines are not content.
+	if sim := diffWordSimilarity("--- a/x.go\n+++ b/x.go\n"); sim.removedTokens != 0 || sim.addedTokens != 0 {
+		t.Errorf("header-only diff counted %d/%d tokens", sim.removedTokens, sim.addedTokens)
+	}
+}
+
+func TestDiffWordSimilarity_CapsLines(t *testing.T) {
+	huge := rewriteDiff(rewriteMaxLines+500, false)
+	sim := diffWordSimilarity(huge)
+
+	capped := diffWordSimilarity(rewriteDiff(rewriteMaxLines, false))
+	if sim.removedTokens != capped.removedTokens || sim.addedTokens != capped.addedTokens {
+		t.Errorf("token counts %d/%d, want them capped at %d/%d",
+			sim.removedTokens, sim.addedTokens, capped.removedTokens, capped.addedTokens)
+	}
+}
@@ -0,0 +1,175 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+const (
+	// roundStatsMinRatio is how many times the chance rate of round values
+	// a statistic must reach before its round commits are flagged.
+	roundStatsMinRatio = 4.0
+	// roundStatsMaxPValue is the largest probability of seeing at least as
+	// many round values by chance that still counts as an anomaly.
+	roundStatsMaxPValue = 0.001
+)
+
+// roundStat is a commit statistic checked for round values. A value is
+// round when it is a multiple of unit; among values of at least unit,
+// about one in unit is round by chance.
+type roundStat struct {
+	name  string
+	unit  int64
+	value func(*git.DiffStats) int64
+}
+
+var roundStats = []roundStat{
+	{"additions", 100, func(s *git.DiffStats) int64 { return s.Additions }},
+	{"deletions", 100, func(s *git.DiffStats) int64 { return s.Deletions }},
+	{"files", 10, func(s *git.DiffStats) int64 { return int64(s.FilesChanged) }},
+}
+
+// RoundStatisticsStrategy looks for commit statistics that are exact round
+// numbers (500 additions, 1000 deletions, 10 files) far more often across
+// the repository than chance predicts. Hand-written changes land on round
+// sizes about as often as on any other; generated or scripted changes
+// that emit a fixed number of lines or files do so repeatedly. It is a
+// repository-level check: single round commits are never flagged, only
+// the commits behind a statistically unlikely excess of them.
+type RoundStatisticsStrategy struct {
+	minCommits int
+
+	// flagged maps commit hashes to their reason, computed from the baseline.
+	flagged map[string]string
+}
+
+// NewRoundStatisticsStrategy creates a strategy that needs at least
+// minCommits round values in one statistic (0 = 3) before flagging.
+func NewRoundStatisticsStrategy(minCommits int) *RoundStatisticsStrategy {
+	if minCommits <= 0 {
+		minCommits = 3
+	}
+	return &RoundStatisticsStrategy{minCommits: minCommits}
+}
+
+func (s *RoundStatisticsStrategy) Name() string        { return "round_statistics_analysis" }
+func (s *RoundStatisticsStrategy) Category() string    { return "statistical" }
+func (s *RoundStatisticsStrategy) Confidence() float64 { return 0.4 }
+func (s *RoundStatisticsStrategy) Description() string {
+	return "Detects commits whose additions, deletions or file counts are round numbers far more often than chance"
+}
+
+func (s *RoundStatisticsStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.Current == nil {
+		return false, ""
+	}
+	reason, ok := s.flagged[pair.Current.Hash]
+	return ok, reason
+}
+
+func (s *RoundStatisticsStrategy) Explain(pair *git.CommitPair) StrategyTrace {
+	if pair == nil || pair.Stats == nil {
+		return newTrace(s, pair)
+	}
+	inputs := make([]TraceInput, 0, len(roundStats))
+	for _, stat := range roundStats {
+		inputs = append(inputs, TraceInput{Name: stat.name, Value: float64(stat.value(pair.Stats))})
+	}
+	return newTrace(s, pair, inputs...)
+}
+
+// isRound reports whether v is a positive multiple of the stat's unit.
+func (r roundStat) isRound(v int64) bool {
+	return v >= r.unit && v%r.unit == 0
+}
+
+// SetBaseline counts the round values of each statistic over every commit
+// in pairs and flags the commits contributing to statistics with an
+// unlikely excess of them.
+func (s *RoundStatisticsStrategy) SetBaseline(pairs []*git.CommitPair) {
+	s.flagged = make(map[string]string)
+
+	type commitStats struct {
+		hash  string
+		stats *git.DiffStats
+	}
+	seen := make(map[string]bool)
+	var commits []commitStats
+	for _, pair := range pairs {
+		if pair == nil || pair.Current == nil || pair.Stats == nil || len(pair.Current.Parents) > 1 || seen[pair.Current.Hash] {
+			continue
+		}
+		seen[pair.Current.Hash] = true
+		commits = append(commits, commitStats{pair.Current.Hash, pair.Stats})
+	}
+
+	var summaries []string
+	anomalous := make([]roundStat, 0, len(roundStats))
+	for _, stat := range roundStats {
+		eligible, round := 0, 0
+		for _, c := range commits {
+			if v := stat.value(c.stats); v >= stat.unit {
+				eligible++
+				if stat.isRound(v) {
+					round++
+				}
+			}
+		}
+		p := 1 / float64(stat.unit)
+		expected := float64(eligible) * p
+		if round < s.minCommits || float64(round) < roundStatsMinRatio*expected || binomialTail(eligible, round, p) > roundStatsMaxPValue {
+			continue
+		}
+		anomalous = append(anomalous, stat)
+		summaries = append(summaries, fmt.Sprintf(
+			"%d of %d commits with %d+ %s are exact multiples of %d (%.1f expected by chance)",
+			round, eligible, stat.unit, stat.name, stat.unit, expected,
+		))
+	}
+	if len(anomalous) == 0 {
+		return
+	}
+
+	summary := strings.Join(summaries, "; ")
+	for _, c := range commits {
+		var values []string
+		for _, stat := range anomalous {
+			if v := stat.value(c.stats); stat.isRound(v) {
+				values = append(values, fmt.Sprintf("%d %s", v, stat.name))
+			}
+		}
+		if len(values) > 0 {
+			s.flagged[c.hash] = fmt.Sprintf("Round commit statistics (%s): %s - may be generated or scripted",
+				strings.Join(values, ", "), summary)
+		}
+	}
+}
+
+// binomialTail returns the probability of at least k successes in n trials
+// with success probability p.
+func binomialTail(n, k int, p float64) float64 {
+	if k <= 0 {
+		return 1
+	}
+	if k > n {
+		return 0
+	}
+	lnFactorial := func(x int) float64 {
+		v, _ := math.Lgamma(float64(x) + 1)
+		return v
+	}
+	lnP, lnQ := math.Log(p), math.Log1p(-p)
+	sum := 0.0
+	for i := k; i <= n; i++ {
+		term := math.Exp(lnFactorial(n) - lnFactorial(i) - lnFactorial(n-i) + float64(i)*lnP + float64(n-i)*lnQ)
+		sum += term
+		if term < sum*1e-12 {
+			break
+		}
+	}
+	return math.Min(sum, 1)
+}
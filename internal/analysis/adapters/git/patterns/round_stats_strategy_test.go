@@ -0,0 +1,141 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// statsPairs returns one pair per {additions, deletions, files} entry, with
+// commit i hashed "r<i>".
+func statsPairs(stats [][3]int64) []*git.CommitPair {
+	pairs := make([]*git.CommitPair, len(stats))
+	for i, st := range stats {
+		pairs[i] = &git.CommitPair{
+			Current: &git.Commit{Hash: fmt.Sprintf("r%d", i)},
+			Stats:   &git.DiffStats{Additions: st[0], Deletions: st[1], FilesChanged: int(st[2])},
+		}
+	}
+	return pairs
+}
+
+// organicStats gives n commits of irregular sizes, none of them round.
+func organicStats(n int) [][3]int64 {
+	stats := make([][3]int64, n)
+	for i := range stats {
+		stats[i] = [3]int64{int64(137 + 31*i), int64(23 + 7*i%90), int64(1 + i%7)}
+		if stats[i][0]%100 == 0 {
+			stats[i][0]++
+		}
+	}
+	return stats
+}
+
+func roundFlagged(s *RoundStatisticsStrategy, pairs []*git.CommitPair) map[string]string {
+	flagged := make(map[string]string)
+	for _, pair := range pairs {
+		if ok, reason := s.Detect(pair, nil); ok {
+			flagged[pair.Current.Hash] = reason
+		}
+	}
+	return flagged
+}
+
+func TestRoundStatisticsStrategy_FlagsRoundCommits(t *testing.T) {
+	stats := append(organicStats(40),
+		[3]int64{500, 12, 3},
+		[3]int64{1000, 40, 5},
+		[3]int64{500, 3, 2},
+		[3]int64{300, 0, 1},
+	)
+	pairs := statsPairs(stats)
+
+	s := NewRoundStatisticsStrategy(0)
+	s.SetBaseline(pairs)
+	flagged := roundFlagged(s, pairs)
+
+	if len(flagged) != 4 {
+		t.Fatalf("flagged %d commits, want the 4 round ones: %v", len(flagged), flagged)
+	}
+	reason := flagged["r41"]
+	for _, want := range []string{"(1000 additions)", "4 of 44 commits with 100+ additions are exact multiples of 100 (0.4 expected by chance)"} {
+		if !strings.Contains(reason, want) {
+			t.Errorf("reason %q should contain %q", reason, want)
+		}
+	}
+	if strings.Contains(reason, "deletions") {
+		t.Errorf("reason %q names deletions, which are not anomalous", reason)
+	}
+	if trace := s.Explain(pairs[41]); !trace.Fired || len(trace.Inputs) != 3 || trace.Inputs[0].Value != 1000 {
+		t.Errorf("Explain() = %+v, want a fired trace with the commit's statistics", trace)
+	}
+}
+
+func TestRoundStatisticsStrategy_FileCounts(t *testing.T) {
+	stats := organicStats(30)
+	for i := 0; i < 6; i++ {
+		stats = append(stats, [3]int64{int64(211 + i), 5, 10}, [3]int64{int64(263 + i), 9, int64(11 + i)})
+	}
+	pairs := statsPairs(stats)
+
+	s := NewRoundStatisticsStrategy(0)
+	s.SetBaseline(pairs)
+	flagged := roundFlagged(s, pairs)
+
+	if len(flagged) != 6 {
+		t.Fatalf("flagged %d commits, want the 6 with exactly 10 files: %v", len(flagged), flagged)
+	}
+	if reason := flagged["r30"]; !strings.Contains(reason, "(10 files)") || !strings.Contains(reason, "6 of 12 commits with 10+ files") {
+		t.Errorf("reason = %q", reason)
+	}
+}
+
+func TestRoundStatisticsStrategy_NotFlagged(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats [][3]int64
+	}{
+		{"organic history", organicStats(50)},
+		{"a single round commit", append(organicStats(50), [3]int64{500, 0, 2})},
+		{"round values at chance rate", func() [][3]int64 {
+			stats := make([][3]int64, 300)
+			for i := range stats {
+				stats[i] = [3]int64{int64(100 + 7*i), 1, 1} // multiples of 100 once every 100 commits
+			}
+			return stats
+		}()},
+		{"too few commits", [][3]int64{{500, 0, 1}, {1000, 0, 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs := statsPairs(tt.stats)
+			s := NewRoundStatisticsStrategy(0)
+			s.SetBaseline(pairs)
+			if flagged := roundFlagged(s, pairs); len(flagged) != 0 {
+				t.Errorf("flagged = %v, want none", flagged)
+			}
+		})
+	}
+}
+
+func TestBinomialTail(t *testing.T) {
+	tests := []struct {
+		n, k int
+		p    float64
+		want float64
+	}{
+		{10, 0, 0.5, 1},
+		{10, 11, 0.5, 0},
+		{10, 10, 0.5, math.Pow(0.5, 10)},
+		{3, 2, 0.5, 0.5},
+		{44, 4, 0.01, 0.000987},
+	}
+	for _, tt := range tests {
+		if got := binomialTail(tt.n, tt.k, tt.p); math.Abs(got-tt.want) > 1e-5 {
+			t.Errorf("binomialTail(%d, %d, %v) = %v, want %v", tt.n, tt.k, tt.p, got, tt.want)
+		}
+	}
+}
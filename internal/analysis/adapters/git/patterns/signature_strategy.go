@@ -0,0 +1,219 @@
+package patterns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+const (
+	// signatureMinSignedHistory is how many signed commits an author needs
+	// before a run of unsigned ones counts as a break in habit.
+	signatureMinSignedHistory = 3
+	// signatureMinSignedShare is the share of an author's earlier commits
+	// that must be signed for the habit to be established.
+	signatureMinSignedShare = 0.8
+)
+
+// platformSigningKeys are keys that hosting platforms use to sign commits
+// made through their web UI on behalf of many authors. Sharing them across
+// authors is expected. Matched against the trailing 16 hex digits (key ID).
+var platformSigningKeys = map[string]bool{
+	"4AEE18F83AFDEB23": true, // GitHub web-flow
+	"B5690EEEBB952194": true, // GitHub web-flow (2024 rotation)
+}
+
+// SignatureStrategy uses commit signatures as a behavioral signal. It flags
+// an author who habitually signs commits and then produces a burst of
+// unsigned ones, and commits signed by a key that signs for many different
+// authors, as an automation key would. Repositories without any signed
+// commits never fire.
+type SignatureStrategy struct {
+	minUnsignedBurst int
+	minKeyAuthors    int
+
+	// flagged maps commit hashes to their reason, computed from the baseline.
+	flagged map[string]string
+}
+
+// NewSignatureStrategy creates a strategy that flags runs of at least
+// minUnsignedBurst unsigned commits by an author who normally signs, and
+// commits signed by a key shared by at least minKeyAuthors authors.
+func NewSignatureStrategy(minUnsignedBurst, minKeyAuthors int) *SignatureStrategy {
+	if minUnsignedBurst <= 0 {
+		minUnsignedBurst = 3
+	}
+	if minKeyAuthors <= 0 {
+		minKeyAuthors = 3
+	}
+	return &SignatureStrategy{
+		minUnsignedBurst: minUnsignedBurst,
+		minKeyAuthors:    minKeyAuthors,
+	}
+}
+
+func (s *SignatureStrategy) Name() string        { return "signature_analysis" }
+func (s *SignatureStrategy) Category() string    { return "behavioral" }
+func (s *SignatureStrategy) Confidence() float64 { return 0.5 }
+func (s *SignatureStrategy) Description() string {
+	return "Detects signed-to-unsigned commit transitions and signing keys shared across many authors"
+}
+
+func (s *SignatureStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.Current == nil {
+		return false, ""
+	}
+	reason, ok := s.flagged[pair.Current.Hash]
+	return ok, reason
+}
+
+type signatureTally struct {
+	signed, unsigned int
+}
+
+func (t signatureTally) ratio() string {
+	return fmt.Sprintf("%d:%d", t.signed, t.unsigned)
+}
+
+// SetBaseline walks every commit in pairs in chronological order and decides
+// which ones to flag.
+func (s *SignatureStrategy) SetBaseline(pairs []*git.CommitPair) {
+	s.flagged = make(map[string]string)
+
+	commits := baselineCommits(pairs)
+	byAuthor := make(map[string][]*git.Commit)
+	tallies := make(map[string]signatureTally)
+	keyAuthors := make(map[string]map[string]int)
+	anySigned := false
+
+	for _, c := range commits {
+		author := strings.ToLower(c.Email)
+		byAuthor[author] = append(byAuthor[author], c)
+
+		t := tallies[author]
+		if c.Signed {
+			anySigned = true
+			t.signed++
+			if c.SignerID != "" {
+				if keyAuthors[c.SignerID] == nil {
+					keyAuthors[c.SignerID] = make(map[string]int)
+				}
+				keyAuthors[c.SignerID][author]++
+			}
+		} else {
+			t.unsigned++
+		}
+		tallies[author] = t
+	}
+	if !anySigned {
+		return
+	}
+
+	for author, history := range byAuthor {
+		s.flagUnsignedBursts(author, history, tallies[author])
+	}
+	s.flagSharedKeys(commits, keyAuthors, tallies)
+}
+
+// flagUnsignedBursts flags runs of unsigned commits that follow an
+// established habit of signing.
+func (s *SignatureStrategy) flagUnsignedBursts(author string, history []*git.Commit, tally signatureTally) {
+	signedBefore, seenBefore := 0, 0
+	var run []*git.Commit
+
+	closeRun := func() {
+		established := signedBefore >= signatureMinSignedHistory &&
+			float64(signedBefore) >= signatureMinSignedShare*float64(seenBefore)
+		if len(run) >= s.minUnsignedBurst && established {
+			for _, c := range run {
+				s.flagged[c.Hash] = fmt.Sprintf(
+					"Burst of %d unsigned commits by %s after %d of %d earlier commits were signed (author signed/unsigned ratio %s)",
+					len(run), author, signedBefore, seenBefore, tally.ratio(),
+				)
+			}
+		}
+		seenBefore += len(run)
+		run = nil
+	}
+
+	for _, c := range history {
+		if c.Signed {
+			closeRun()
+			signedBefore++
+			seenBefore++
+			continue
+		}
+		run = append(run, c)
+	}
+	closeRun()
+}
+
+// flagSharedKeys flags commits signed by a key that signs for many authors,
+// unless the commit's author is the key's main user.
+func (s *SignatureStrategy) flagSharedKeys(commits []*git.Commit, keyAuthors map[string]map[string]int, tallies map[string]signatureTally) {
+	for _, c := range commits {
+		if !c.Signed || c.SignerID == "" || isPlatformKey(c.SignerID) {
+			continue
+		}
+		authors := keyAuthors[c.SignerID]
+		if len(authors) < s.minKeyAuthors {
+			continue
+		}
+		author := strings.ToLower(c.Email)
+		owner := primaryAuthor(authors)
+		if author == owner {
+			continue
+		}
+		if _, ok := s.flagged[c.Hash]; ok {
+			continue
+		}
+		s.flagged[c.Hash] = fmt.Sprintf(
+			"Signed by key %s, which signs commits for %d different authors (mostly %s), not the author's own key (author signed/unsigned ratio %s)",
+			c.SignerID, len(authors), owner, tallies[author].ratio(),
+		)
+	}
+}
+
+// baselineCommits returns the distinct commits of pairs, oldest first.
+func baselineCommits(pairs []*git.CommitPair) []*git.Commit {
+	seen := make(map[string]bool)
+	commits := make([]*git.Commit, 0, len(pairs)+1)
+	for _, pair := range pairs {
+		if pair == nil {
+			continue
+		}
+		for _, c := range []*git.Commit{pair.Current, pair.Previous} {
+			if c == nil || seen[c.Hash] {
+				continue
+			}
+			seen[c.Hash] = true
+			commits = append(commits, c)
+		}
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Timestamp.Before(commits[j].Timestamp)
+	})
+	return commits
+}
+
+// primaryAuthor returns the author with the most commits for a key, breaking
+// ties by name so the result is stable.
+func primaryAuthor(authors map[string]int) string {
+	best, bestCount := "", 0
+	for author, count := range authors {
+		if count > bestCount || (count == bestCount && author < best) {
+			best, bestCount = author, count
+		}
+	}
+	return best
+}
+
+func isPlatformKey(signer string) bool {
+	if len(signer) < 16 {
+		return false
+	}
+	return platformSigningKeys[signer[len(signer)-16:]]
+}
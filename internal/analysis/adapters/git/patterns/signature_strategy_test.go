@@ -0,0 +1,118 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// signedCommit describes one commit of a synthetic history, oldest first.
+type signedCommit struct {
+	email  string
+	signer string // empty = unsigned
+}
+
+// signaturePairs chains the commits into pairs the way GetCommitPairs does,
+// newest first. Commit i gets hash "c<i>".
+func signaturePairs(history []signedCommit) []*git.CommitPair {
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	commits := make([]*git.Commit, len(history))
+	for i, h := range history {
+		commits[i] = &git.Commit{
+			Hash:      fmt.Sprintf("c%d", i),
+			Email:     h.email,
+			Timestamp: base.Add(time.Duration(i) * time.Hour),
+			Signed:    h.signer != "",
+			SignerID:  h.signer,
+		}
+		if h.signer != "" {
+			commits[i].SignatureType = git.SignatureGPG
+		}
+	}
+
+	pairs := make([]*git.CommitPair, 0, len(commits))
+	for i := len(commits) - 1; i > 0; i-- {
+		pairs = append(pairs, &git.CommitPair{Previous: commits[i-1], Current: commits[i], Stats: &git.DiffStats{}})
+	}
+	return pairs
+}
+
+func flaggedHashes(s *SignatureStrategy, pairs []*git.CommitPair) map[string]string {
+	flagged := make(map[string]string)
+	for _, pair := range pairs {
+		if ok, reason := s.Detect(pair, nil); ok {
+			flagged[pair.Current.Hash] = reason
+		}
+	}
+	return flagged
+}
+
+func TestSignatureStrategy_UnsignedBurstAfterSigning(t *testing.T) {
+	alice := "alice@example.com"
+	history := []signedCommit{
+		{alice, "AAAA"}, {alice, "AAAA"}, {alice, "AAAA"}, {alice, "AAAA"},
+		{alice, ""}, {alice, ""}, {alice, ""}, // c4-c6: burst
+		{alice, "AAAA"},
+		{alice, ""}, // c8: a single lapse is fine
+	}
+	pairs := signaturePairs(history)
+
+	s := NewSignatureStrategy(0, 0)
+	s.SetBaseline(pairs)
+	flagged := flaggedHashes(s, pairs)
+
+	for _, hash := range []string{"c4", "c5", "c6"} {
+		reason, ok := flagged[hash]
+		if !ok {
+			t.Errorf("%s should be flagged as part of the unsigned burst", hash)
+			continue
+		}
+		if !strings.Contains(reason, "signed/unsigned ratio 5:4") {
+			t.Errorf("reason %q should state the signed/unsigned ratio 5:4", reason)
+		}
+	}
+	if len(flagged) != 3 {
+		t.Errorf("flagged = %v, want only c4-c6", flagged)
+	}
+}
+
+func TestSignatureStrategy_SharedSigningKey(t *testing.T) {
+	history := []signedCommit{
+		{"bot@example.com", "BOTKEY"}, {"bot@example.com", "BOTKEY"},
+		{"alice@example.com", "ALICEKEY"},
+		{"alice@example.com", "BOTKEY"}, // c3
+		{"bob@example.com", "BOTKEY"},   // c4
+		{"bob@example.com", "5DE3E0509C47EA3CF04A42D34AEE18F83AFDEB23"},
+		{"carol@example.com", "5DE3E0509C47EA3CF04A42D34AEE18F83AFDEB23"},
+		{"dave@example.com", "5DE3E0509C47EA3CF04A42D34AEE18F83AFDEB23"},
+	}
+	pairs := signaturePairs(history)
+
+	s := NewSignatureStrategy(0, 0)
+	s.SetBaseline(pairs)
+	flagged := flaggedHashes(s, pairs)
+
+	if len(flagged) != 2 || flagged["c3"] == "" || flagged["c4"] == "" {
+		t.Fatalf("flagged = %v, want c3 and c4 (GitHub web-flow key is exempt)", flagged)
+	}
+	if reason := flagged["c4"]; !strings.Contains(reason, "BOTKEY") || !strings.Contains(reason, "ratio 2:0") {
+		t.Errorf("reason %q should name the key and the signed/unsigned ratio", reason)
+	}
+}
+
+func TestSignatureStrategy_NoSignaturesNeverFires(t *testing.T) {
+	history := make([]signedCommit, 20)
+	for i := range history {
+		history[i] = signedCommit{email: fmt.Sprintf("dev%d@example.com", i%3)}
+	}
+	pairs := signaturePairs(history)
+
+	s := NewSignatureStrategy(0, 0)
+	s.SetBaseline(pairs)
+	if flagged := flaggedHashes(s, pairs); len(flagged) != 0 {
+		t.Errorf("flagged = %v, want none for an unsigned repository", flagged)
+	}
+}
@@ -3,11 +3,17 @@ package patterns
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	webpatterns "github.com/TryCadence/Cadence/internal/analysis/adapters/web/patterns"
 	"github.com/TryCadence/Cadence/internal/metrics"
 )
 
+// commitDecorativeMinCount is how many decorative Unicode symbols (arrows,
+// box drawing, "bold" math letters) flag a commit message.
+const commitDecorativeMinCount = 3
+
 type SpecialCharacterPatternStrategy struct {
 	enabled bool
 }
@@ -23,16 +29,36 @@ func (s *SpecialCharacterPatternStrategy) Description() string {
 	return "Detects unusual special character patterns in commits"
 }
 
-func countCharacter(text string, char rune) int {
+// countLooseCharacter counts char in text except where it joins two word
+// characters ("co-authored-by", "max_commits") or marks a list item at the
+// start of a line, so ordinary trailers, identifiers and bullet lists in a
+// commit body are not mistaken for decoration.
+func countLooseCharacter(text string, char rune) int {
 	count := 0
-	for _, r := range text {
-		if r == char {
+	for _, line := range splitByLines(text) {
+		runes := []rune(line)
+		trimmed := strings.TrimLeft(line, " \t")
+		indent := len(runes) - len([]rune(trimmed))
+		for i, r := range runes {
+			if r != char {
+				continue
+			}
+			if i == indent && i+1 < len(runes) && runes[i+1] == ' ' {
+				continue
+			}
+			if i > 0 && i+1 < len(runes) && isWordRune(runes[i-1]) && isWordRune(runes[i+1]) {
+				continue
+			}
 			count++
 		}
 	}
 	return count
 }
 
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
 func detectConsecutiveCharacters(text string, char rune, threshold int) bool {
 	consecutive := 0
 	for _, r := range text {
@@ -73,7 +99,7 @@ func countSpecialCharactersInLine(line string) map[rune]int {
 	suspiciousChars := []rune{'-', '*', '_', '=', '+', '#', '!', '?', '.', ','}
 
 	for _, char := range suspiciousChars {
-		if count := countCharacter(line, char); count > 0 {
+		if count := countLooseCharacter(line, char); count > 0 {
 			specialChars[char] = count
 		}
 	}
@@ -89,7 +115,12 @@ func (s *SpecialCharacterPatternStrategy) Detect(pair *git.CommitPair, repoStats
 	msg := pair.Current.Message
 	lines := splitByLines(msg)
 
-	hyphenCount := countCharacter(msg, '-')
+	if scan := webpatterns.ScanSymbols(msg); scan.Decorative >= commitDecorativeMinCount {
+		return true, fmt.Sprintf("Decorative Unicode symbols in commit message (%d: %s)",
+			scan.Decorative, strings.Join(scan.Symbols, " "))
+	}
+
+	hyphenCount := countLooseCharacter(msg, '-')
 	if hyphenCount >= 5 {
 		return true, fmt.Sprintf(
 			"Excessive hyphens in commit message (%d occurrences)",
@@ -105,7 +136,7 @@ func (s *SpecialCharacterPatternStrategy) Detect(pair *git.CommitPair, repoStats
 		)
 	}
 
-	asteriskCount := countCharacter(msg, '*')
+	asteriskCount := countLooseCharacter(msg, '*')
 	if asteriskCount >= 4 {
 		return true, fmt.Sprintf(
 			"Excessive asterisks in commit message (%d occurrences)",
@@ -121,7 +152,7 @@ func (s *SpecialCharacterPatternStrategy) Detect(pair *git.CommitPair, repoStats
 		)
 	}
 
-	underscoreCount := countCharacter(msg, '_')
+	underscoreCount := countLooseCharacter(msg, '_')
 	if underscoreCount >= 4 {
 		return true, fmt.Sprintf(
 			"Excessive underscores in commit message (%d occurrences)",
@@ -2,6 +2,8 @@ package patterns
 
 import (
 	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
 )
 
 func TestCoreStrategies(t *testing.T) {
@@ -49,18 +51,34 @@ func TestEmojiDetectionStrategy(t *testing.T) {
 			shouldDetect: false,
 		},
 		{
+			// A single leading or inline emoji is the gitmoji convention.
 			name:         "mixed content",
 			text:         "Hello world with emoji 😊 and text",
+			shouldDetect: false,
+		},
+		{
+			name:         "gitmoji",
+			text:         "✨ feat: add support for custom key bindings\n\nBindings are read from the config file.",
+			shouldDetect: false,
+		},
+		{
+			name:         "emoji only",
+			text:         "🚀",
+			shouldDetect: true,
+		},
+		{
+			name:         "many emoji",
+			text:         "Refactor the parser 🔧, speed up lexing 🚀 and fix the tests ✅ across the whole package",
 			shouldDetect: true,
 		},
 	}
 
+	s := NewEmojiPatternStrategy()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Emoji detection would be implemented in the strategy
-			// This test validates the structure
-			if tt.text == "" {
-				t.Error("text should not be empty")
+			detected, reason := s.Detect(&git.CommitPair{Current: &git.Commit{Message: tt.text}, Stats: &git.DiffStats{}}, nil)
+			if detected != tt.shouldDetect {
+				t.Errorf("Detect() = %v (%q), want %v", detected, reason, tt.shouldDetect)
 			}
 		})
 	}
@@ -87,22 +105,30 @@ func TestSpecialCharacterStrategy(t *testing.T) {
 			text:                "",
 			hasManySpecialChars: false,
 		},
+		{
+			name:                "decorative unicode",
+			text:                "→ Refactor parser ▶ add tests ✔ ship ▸ done",
+			hasManySpecialChars: true,
+		},
+		{
+			name: "bullets, identifiers and trailers",
+			text: "Raise max_commits_per_hour default\n\n" +
+				"- read max_commits from the config\n" +
+				"- keep the per-author rate_limit\n" +
+				"- update the read-only docs\n" +
+				"* drop the old fall-back path\n\n" +
+				"Signed-off-by: Jane Doe <jane@example.com>\n" +
+				"Co-authored-by: John Roe <john@example.com>",
+			hasManySpecialChars: false,
+		},
 	}
 
+	s := NewSpecialCharacterPatternStrategy()
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Verify text is processed
-			if tt.text != "" {
-				specialCharCount := 0
-				for _, ch := range tt.text {
-					if !((ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9') || ch == ' ') {
-						specialCharCount++
-					}
-				}
-
-				if tt.hasManySpecialChars && specialCharCount == 0 {
-					t.Error("expected special characters but found none")
-				}
+			detected, reason := s.Detect(&git.CommitPair{Current: &git.Commit{Message: tt.text}, Stats: &git.DiffStats{}}, nil)
+			if detected != tt.hasManySpecialChars {
+				t.Errorf("Detect() = %v (%q), want %v", detected, reason, tt.hasManySpecialChars)
 			}
 		})
 	}
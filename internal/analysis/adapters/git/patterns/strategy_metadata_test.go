@@ -27,14 +27,24 @@ func TestAllGitStrategies_HaveMetadata(t *testing.T) {
 		NewRatioStrategy(th.MaxAdditionRatio, th.MinDeletionRatio, th.MinCommitSizeRatio),
 		NewPrecisionStrategy(0.85),
 		NewCommitMessageStrategy(),
+		NewMessageDiffMismatchStrategy(0),
 		NewNamingPatternStrategy(),
 		NewStructuralConsistencyStrategy(),
 		NewBurstPatternStrategy(10),
 		NewErrorHandlingPatternStrategy(),
 		NewTemplatePatternStrategy(),
 		NewFileExtensionPatternStrategy(),
+		NewBoilerplateHeaderStrategy(0, 0),
+		NewDependencyAdditionStrategy(nil, 10),
 		NewStatisticalAnomalyStrategy(),
+		NewRoundStatisticsStrategy(0),
+		NewCodeEntropyStrategy(0, 0),
+		NewNGramRepetitionStrategy(0),
+		NewRewriteSimilarityStrategy(0, 0),
 		NewTimingAnomalyStrategy(),
+		NewSignatureStrategy(0, 0),
+		NewTimezoneAnomalyStrategy(0, 0),
+		NewAICoauthorStrategy(nil),
 		NewEmojiPatternStrategy(),
 		NewSpecialCharacterPatternStrategy(),
 	}
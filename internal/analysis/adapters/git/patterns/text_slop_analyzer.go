@@ -3,13 +3,29 @@ package patterns
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/TryCadence/Cadence/internal/analysis"
 	webpatterns "github.com/TryCadence/Cadence/internal/analysis/adapters/web/patterns"
 )
 
+// DefaultMinWordCount is the fewest words AnalyzeContent analyzes when
+// TextSlopAnalyzer.MinWordCount is zero. Shorter text gives the pattern
+// strategies too little to go on.
+const DefaultMinWordCount = 50
+
 type TextSlopAnalyzer struct {
 	enabled  bool
 	registry *webpatterns.WebPatternRegistry
+	// MinWordCount is the fewest words worth analyzing; shorter content
+	// fails with an *analysis.InsufficientContentError. Zero uses
+	// DefaultMinWordCount.
+	MinWordCount int
+	// Language is the content's ISO 639-1 code (see
+	// webpatterns.DetectLanguage). The content is segmented for it, and
+	// strategies that do not support it are skipped and listed in the
+	// result's NotApplicable. Empty runs every strategy on the text as is.
+	Language string
 }
 
 func NewTextSlopAnalyzer() *TextSlopAnalyzer {
@@ -20,13 +36,14 @@ func NewTextSlopAnalyzer() *TextSlopAnalyzer {
 }
 
 func (a *TextSlopAnalyzer) AnalyzeContent(content string) (*TextSlopResult, error) {
-	if content == "" {
-		return nil, fmt.Errorf("empty content")
+	minWords := a.MinWordCount
+	if minWords <= 0 {
+		minWords = DefaultMinWordCount
 	}
-
+	content = webpatterns.Segment(content, a.Language)
 	wordCount := len(strings.Fields(content))
-	if wordCount < 50 {
-		return nil, fmt.Errorf("content too short for reliable analysis (minimum 50 words, got %d)", wordCount)
+	if wordCount < minWords {
+		return nil, &analysis.InsufficientContentError{WordCount: wordCount, MinWordCount: minWords}
 	}
 
 	result := &TextSlopResult{
@@ -34,6 +51,7 @@ func (a *TextSlopAnalyzer) AnalyzeContent(content string) (*TextSlopResult, erro
 		PassedPatterns: make([]Pattern, 0),
 		SuspicionRate:  0,
 		WordCount:      wordCount,
+		NotApplicable:  a.registry.DisableUnsupported(a.Language),
 	}
 
 	// Build confidence lookup from registered strategies
@@ -57,6 +75,7 @@ func (a *TextSlopAnalyzer) AnalyzeContent(content string) (*TextSlopResult, erro
 			Confidence:  confidence,
 			Description: dr.Description,
 			Examples:    dr.Examples,
+			Duration:    dr.Duration,
 		}
 
 		if dr.Detected {
@@ -94,6 +113,8 @@ type Pattern struct {
 	Confidence  float64
 	Description string
 	Examples    []string
+	// Duration is how long the strategy took on the content.
+	Duration time.Duration
 }
 
 type TextSlopResult struct {
@@ -102,6 +123,9 @@ type TextSlopResult struct {
 	SuspicionRate  float64
 	Summary        string
 	WordCount      int
+	// NotApplicable names the strategies skipped because they do not
+	// support the content's language.
+	NotApplicable []string
 }
 
 func (r *TextSlopResult) GetConfidenceScore() int {
@@ -1,7 +1,12 @@
 package patterns
 
 import (
+	"errors"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
 )
 
 func TestTextSlopAnalyzer_AnalyzeContent(t *testing.T) {
@@ -63,6 +68,77 @@ Let me know if you run into the same issue!`,
 	}
 }
 
+func TestTextSlopAnalyzer_InsufficientContent(t *testing.T) {
+	tests := []struct {
+		name         string
+		minWordCount int
+		content      string
+		wantMin      int
+	}{
+		{"empty", 0, "", DefaultMinWordCount},
+		{"below default", 0, strings.Repeat("word ", 49), DefaultMinWordCount},
+		{"below custom", 120, strings.Repeat("word ", 100), 120},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analyzer := NewTextSlopAnalyzer()
+			analyzer.MinWordCount = tt.minWordCount
+			_, err := analyzer.AnalyzeContent(tt.content)
+
+			var insufficient *analysis.InsufficientContentError
+			if !errors.As(err, &insufficient) {
+				t.Fatalf("AnalyzeContent() error = %v, want *analysis.InsufficientContentError", err)
+			}
+			if want := len(strings.Fields(tt.content)); insufficient.WordCount != want || insufficient.MinWordCount != tt.wantMin {
+				t.Errorf("error = %+v, want WordCount %d, MinWordCount %d", insufficient, want, tt.wantMin)
+			}
+		})
+	}
+
+	t.Run("lower custom minimum", func(t *testing.T) {
+		analyzer := NewTextSlopAnalyzer()
+		analyzer.MinWordCount = 10
+		if _, err := analyzer.AnalyzeContent(strings.Repeat("word ", 20)); err != nil {
+			t.Errorf("AnalyzeContent() error = %v, want 20 words to meet a minimum of 10", err)
+		}
+	})
+}
+
+func TestTextSlopAnalyzer_Language(t *testing.T) {
+	// Japanese has no spaces between words: segmented, each character
+	// counts toward the minimum word count.
+	analyzer := NewTextSlopAnalyzer()
+	analyzer.Language = "ja"
+	result, err := analyzer.AnalyzeContent(strings.Repeat("私たちは新しいサービスを開発しています。", 5))
+	if err != nil {
+		t.Fatalf("AnalyzeContent() unexpected error = %v", err)
+	}
+	if result.WordCount != 95 {
+		t.Errorf("WordCount = %d, want 95 characters", result.WordCount)
+	}
+
+	reported := make(map[string]bool)
+	for _, p := range append(result.Patterns, result.PassedPatterns...) {
+		reported[p.Type] = true
+	}
+	for _, name := range []string{"ai_vocabulary", "lexical_diversity"} {
+		if !slices.Contains(result.NotApplicable, name) {
+			t.Errorf("NotApplicable = %v, want it to list %s", result.NotApplicable, name)
+		}
+		if reported[name] {
+			t.Errorf("%s ran on Japanese content", name)
+		}
+	}
+
+	english, err := NewTextSlopAnalyzer().AnalyzeContent(strings.Repeat("The quick brown fox jumps over the lazy dog. ", 10))
+	if err != nil {
+		t.Fatalf("AnalyzeContent() unexpected error = %v", err)
+	}
+	if len(english.NotApplicable) != 0 {
+		t.Errorf("NotApplicable = %v for content of unknown language, want none", english.NotApplicable)
+	}
+}
+
 func TestTextSlopAnalyzer_DetectOverusedPhrases(t *testing.T) {
 	analyzer := NewTextSlopAnalyzer()
 	content := "In today's world, it is important to note that furthermore, in conclusion, we must leverage our innovative platform to provide transformative solutions. Our revolutionary system provides unprecedented value and synergy across all stakeholder touchpoints and business objectives through innovative methodologies and advanced technical capabilities in today's dynamic business environment now."
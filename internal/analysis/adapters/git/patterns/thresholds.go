@@ -1,6 +1,9 @@
 package patterns
 
-import "fmt"
+import (
+	"fmt"
+	"math"
+)
 
 type Thresholds struct {
 	SuspiciousAdditions int64
@@ -77,3 +80,31 @@ func (t *Thresholds) IsZero() bool {
 		t.MaxAdditionRatio == 0 &&
 		t.MinDeletionRatio == 0
 }
+
+// Scaled returns a copy of t loosened by factor, or tightened by a factor
+// below 1: every size, rate and ratio a commit must exceed is multiplied by
+// it and the minimum time between commits divided by it, so each commit's
+// measurements sit proportionally closer to or further from the limits.
+// Ratios stay at most 1, and thresholds that are off (zero) stay off.
+func (t *Thresholds) Scaled(factor float64) *Thresholds {
+	scaled := *t
+	scaled.SuspiciousAdditions = scaleCount(t.SuspiciousAdditions, factor)
+	scaled.SuspiciousDeletions = scaleCount(t.SuspiciousDeletions, factor)
+	scaled.MaxAdditionsPerMin = t.MaxAdditionsPerMin * factor
+	scaled.MaxDeletionsPerMin = t.MaxDeletionsPerMin * factor
+	scaled.MinTimeDeltaSeconds = scaleCount(t.MinTimeDeltaSeconds, 1/factor)
+	scaled.MaxFilesPerCommit = int(scaleCount(int64(t.MaxFilesPerCommit), factor))
+	scaled.MaxAdditionRatio = math.Min(t.MaxAdditionRatio*factor, 1)
+	scaled.MinDeletionRatio = math.Min(t.MinDeletionRatio*factor, 1)
+	scaled.MinCommitSizeRatio = scaleCount(t.MinCommitSizeRatio, factor)
+	return &scaled
+}
+
+// scaleCount multiplies a whole-number threshold by factor, rounding to the
+// nearest and keeping a threshold that was on at 1 or more.
+func scaleCount(n int64, factor float64) int64 {
+	if n <= 0 {
+		return n
+	}
+	return max(int64(math.Round(float64(n)*factor)), 1)
+}
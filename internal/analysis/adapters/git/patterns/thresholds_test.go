@@ -1,6 +1,7 @@
 package patterns
 
 import (
+	"math"
 	"testing"
 )
 
@@ -210,3 +211,36 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestThresholds_Scaled(t *testing.T) {
+	base := &Thresholds{
+		SuspiciousAdditions: 500,
+		MaxAdditionsPerMin:  100,
+		MinTimeDeltaSeconds: 60,
+		MaxFilesPerCommit:   3,
+		MaxAdditionRatio:    0.95,
+		MinCommitSizeRatio:  1,
+	}
+
+	looser := base.Scaled(1.1)
+	if looser.SuspiciousAdditions != 550 || math.Abs(looser.MaxAdditionsPerMin-110) > 1e-9 || looser.MaxFilesPerCommit != 3 {
+		t.Errorf("looser limits = %+v, want 550 additions, 110 per minute and 3 files", looser)
+	}
+	if looser.MinTimeDeltaSeconds != 55 {
+		t.Errorf("MinTimeDeltaSeconds = %d, want 55 (a looser minimum is shorter)", looser.MinTimeDeltaSeconds)
+	}
+	if looser.MaxAdditionRatio != 1 {
+		t.Errorf("MaxAdditionRatio = %v, want it capped at 1", looser.MaxAdditionRatio)
+	}
+	if looser.SuspiciousDeletions != 0 || looser.MinDeletionRatio != 0 {
+		t.Errorf("thresholds that were off should stay off: %+v", looser)
+	}
+
+	stricter := base.Scaled(0.5)
+	if stricter.SuspiciousAdditions != 250 || stricter.MinTimeDeltaSeconds != 120 || stricter.MinCommitSizeRatio != 1 {
+		t.Errorf("stricter limits = %+v, want 250 additions, 120s and a commit size of at least 1", stricter)
+	}
+	if base.SuspiciousAdditions != 500 {
+		t.Error("Scaled() should not modify the receiver")
+	}
+}
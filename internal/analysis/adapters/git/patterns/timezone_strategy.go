@@ -0,0 +1,187 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/metrics"
+)
+
+const (
+	// timezoneActiveShare is the share of an author's commits their typical
+	// active window must cover.
+	timezoneActiveShare = 0.8
+	// timezoneMaxWindowHours is the widest active window still treated as a
+	// norm; authors who commit around the clock have no unusual hours.
+	timezoneMaxWindowHours = 16
+	// timezoneBurstGap is the longest gap between two commits of one burst.
+	timezoneBurstGap = 2 * time.Hour
+)
+
+// TimezoneAnomalyStrategy builds each author's distribution of commit
+// hour-of-day, in the UTC offset recorded with each commit, and flags bursts
+// of commits at hours outside that author's typical active window. History
+// generated in bulk is often committed at hours, or in a timezone, the
+// author never works in.
+type TimezoneAnomalyStrategy struct {
+	minBurst   int
+	minHistory int
+
+	// flagged maps commit hashes to their reason, computed from the baseline.
+	flagged map[string]string
+}
+
+// NewTimezoneAnomalyStrategy creates a strategy that flags runs of at least
+// minBurst commits at unusual hours by authors with at least minHistory
+// commits to learn a norm from.
+func NewTimezoneAnomalyStrategy(minBurst, minHistory int) *TimezoneAnomalyStrategy {
+	if minBurst <= 0 {
+		minBurst = 3
+	}
+	if minHistory <= 0 {
+		minHistory = 10
+	}
+	return &TimezoneAnomalyStrategy{
+		minBurst:   minBurst,
+		minHistory: minHistory,
+	}
+}
+
+func (s *TimezoneAnomalyStrategy) Name() string        { return "timezone_anomaly_analysis" }
+func (s *TimezoneAnomalyStrategy) Category() string    { return "behavioral" }
+func (s *TimezoneAnomalyStrategy) Confidence() float64 { return 0.45 }
+func (s *TimezoneAnomalyStrategy) Description() string {
+	return "Detects bursts of commits at hours outside the author's usual active window or timezone"
+}
+
+func (s *TimezoneAnomalyStrategy) Detect(pair *git.CommitPair, repoStats *metrics.RepositoryStats) (isSuspicious bool, reason string) {
+	if pair == nil || pair.Current == nil {
+		return false, ""
+	}
+	reason, ok := s.flagged[pair.Current.Hash]
+	return ok, reason
+}
+
+// activeWindow is a span of hours [start, start+hours) wrapping at midnight.
+type activeWindow struct {
+	start, hours int
+}
+
+func (w activeWindow) contains(hour int) bool {
+	return (hour-w.start+24)%24 < w.hours
+}
+
+func (w activeWindow) String() string {
+	return fmt.Sprintf("%02d:00-%02d:00", w.start, (w.start+w.hours)%24)
+}
+
+// SetBaseline learns each author's active window from every commit in pairs
+// and decides which commits to flag.
+func (s *TimezoneAnomalyStrategy) SetBaseline(pairs []*git.CommitPair) {
+	s.flagged = make(map[string]string)
+
+	byAuthor := make(map[string][]*git.Commit)
+	for _, c := range baselineCommits(pairs) {
+		author := strings.ToLower(c.Email)
+		byAuthor[author] = append(byAuthor[author], c)
+	}
+
+	for author, history := range byAuthor {
+		if len(history) < s.minHistory {
+			continue
+		}
+		s.flagBursts(author, history)
+	}
+}
+
+// flagBursts flags runs of the author's commits that fall outside their
+// active window and follow each other within timezoneBurstGap.
+func (s *TimezoneAnomalyStrategy) flagBursts(author string, history []*git.Commit) {
+	var hist [24]int
+	offsets := make(map[int]int)
+	for _, c := range history {
+		hist[localHour(c)]++
+		offsets[c.TZOffset]++
+	}
+	window := narrowestWindow(hist, len(history))
+	if window.hours > timezoneMaxWindowHours {
+		return
+	}
+	usualOffset := mostCommonOffset(offsets)
+
+	var run []*git.Commit
+	closeRun := func() {
+		if len(run) >= s.minBurst {
+			for _, c := range run {
+				reason := fmt.Sprintf(
+					"Burst of %d commits by %s at unusual hours: this one at %02d:00 (%s), outside the author's typical active window %s (%s)",
+					len(run), author, localHour(c), formatOffset(c.TZOffset), window, formatOffset(usualOffset),
+				)
+				if c.TZOffset != usualOffset {
+					reason += " - committed in a different timezone than usual"
+				}
+				s.flagged[c.Hash] = reason
+			}
+		}
+		run = nil
+	}
+
+	for _, c := range history {
+		if window.contains(localHour(c)) {
+			closeRun()
+			continue
+		}
+		if len(run) > 0 && c.Timestamp.Sub(run[len(run)-1].Timestamp) > timezoneBurstGap {
+			closeRun()
+		}
+		run = append(run, c)
+	}
+	closeRun()
+}
+
+// localHour is the hour of day of the commit in the offset it was made in.
+func localHour(c *git.Commit) int {
+	return c.Timestamp.In(time.FixedZone("", c.TZOffset)).Hour()
+}
+
+// narrowestWindow returns the shortest span of hours holding at least
+// timezoneActiveShare of total commits, preferring the earliest start.
+func narrowestWindow(hist [24]int, total int) activeWindow {
+	need := timezoneActiveShare * float64(total)
+	best := activeWindow{start: 0, hours: 24}
+	for start := 0; start < 24; start++ {
+		sum := 0
+		for hours := 1; hours < best.hours; hours++ {
+			sum += hist[(start+hours-1)%24]
+			if float64(sum) >= need {
+				best = activeWindow{start: start, hours: hours}
+				break
+			}
+		}
+	}
+	return best
+}
+
+// mostCommonOffset returns the offset used most often, breaking ties
+// toward the smaller offset so the result is stable.
+func mostCommonOffset(offsets map[int]int) int {
+	best, bestCount := 0, 0
+	for offset, count := range offsets {
+		if count > bestCount || (count == bestCount && offset < best) {
+			best, bestCount = offset, count
+		}
+	}
+	return best
+}
+
+// formatOffset renders an offset in seconds as UTC+hh:mm.
+func formatOffset(offset int) string {
+	sign := '+'
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+	return fmt.Sprintf("UTC%c%02d:%02d", sign, offset/3600, offset%3600/60)
+}
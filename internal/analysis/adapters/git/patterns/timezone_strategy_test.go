@@ -0,0 +1,180 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// zonedCommit is one commit of a synthetic history, oldest first, at the
+// given local time in the given UTC offset (hours).
+type zonedCommit struct {
+	email  string
+	when   time.Time
+	offset int
+}
+
+// timezonePairs chains the commits into pairs the way GetCommitPairs does,
+// newest first. Commit i gets hash "c<i>".
+func timezonePairs(history []zonedCommit) []*git.CommitPair {
+	commits := make([]*git.Commit, len(history))
+	for i, h := range history {
+		zone := time.FixedZone("", h.offset*3600)
+		local := time.Date(h.when.Year(), h.when.Month(), h.when.Day(), h.when.Hour(), h.when.Minute(), 0, 0, zone)
+		commits[i] = &git.Commit{
+			Hash:      fmt.Sprintf("c%d", i),
+			Email:     h.email,
+			Timestamp: local,
+			TZOffset:  h.offset * 3600,
+		}
+	}
+
+	pairs := make([]*git.CommitPair, 0, len(commits))
+	for i := len(commits) - 1; i > 0; i-- {
+		pairs = append(pairs, &git.CommitPair{Previous: commits[i-1], Current: commits[i], Stats: &git.DiffStats{}})
+	}
+	return pairs
+}
+
+// workdayHistory gives alice two commits a day at 10:00 and 15:00 (UTC+2)
+// for days days, starting on 1 March 2024.
+func workdayHistory(days int) []zonedCommit {
+	var history []zonedCommit
+	for d := 0; d < days; d++ {
+		day := time.Date(2024, time.March, 1+d, 0, 0, 0, 0, time.UTC)
+		history = append(history,
+			zonedCommit{"alice@example.com", day.Add(10 * time.Hour), 2},
+			zonedCommit{"alice@example.com", day.Add(15 * time.Hour), 2},
+		)
+	}
+	return history
+}
+
+func timezoneFlagged(s *TimezoneAnomalyStrategy, pairs []*git.CommitPair) map[string]string {
+	flagged := make(map[string]string)
+	for _, pair := range pairs {
+		if ok, reason := s.Detect(pair, nil); ok {
+			flagged[pair.Current.Hash] = reason
+		}
+	}
+	return flagged
+}
+
+func TestTimezoneAnomalyStrategy_NightBurst(t *testing.T) {
+	history := workdayHistory(10)
+	night := time.Date(2024, time.March, 12, 3, 0, 0, 0, time.UTC)
+	for i := 0; i < 4; i++ {
+		history = append(history, zonedCommit{"alice@example.com", night.Add(time.Duration(i) * 20 * time.Minute), 2})
+	}
+	pairs := timezonePairs(history)
+
+	s := NewTimezoneAnomalyStrategy(0, 0)
+	s.SetBaseline(pairs)
+	flagged := timezoneFlagged(s, pairs)
+
+	if len(flagged) != 4 {
+		t.Fatalf("flagged = %v, want the four night commits c20-c23", flagged)
+	}
+	reason := flagged["c20"]
+	for _, want := range []string{"Burst of 4 commits", "at 03:00 (UTC+02:00)", "typical active window 10:00-16:00 (UTC+02:00)"} {
+		if !strings.Contains(reason, want) {
+			t.Errorf("reason %q should contain %q", reason, want)
+		}
+	}
+	if strings.Contains(reason, "different timezone") {
+		t.Errorf("reason %q should not mention a timezone change", reason)
+	}
+}
+
+func TestTimezoneAnomalyStrategy_ForeignTimezone(t *testing.T) {
+	history := workdayHistory(10)
+	// 03:00-04:00 in UTC+9 is 20:00-21:00 the previous day in UTC+2.
+	burst := time.Date(2024, time.March, 12, 3, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		history = append(history, zonedCommit{"alice@example.com", burst.Add(time.Duration(i) * 30 * time.Minute), 9})
+	}
+	pairs := timezonePairs(history)
+
+	s := NewTimezoneAnomalyStrategy(0, 0)
+	s.SetBaseline(pairs)
+	flagged := timezoneFlagged(s, pairs)
+
+	reason, ok := flagged["c21"]
+	if !ok || len(flagged) != 3 {
+		t.Fatalf("flagged = %v, want the three commits made in UTC+9", flagged)
+	}
+	if !strings.Contains(reason, "(UTC+09:00)") || !strings.Contains(reason, "different timezone than usual") {
+		t.Errorf("reason %q should name the foreign offset and the timezone change", reason)
+	}
+}
+
+func TestTimezoneAnomalyStrategy_NoFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		history func() []zonedCommit
+	}{
+		{
+			name:    "regular hours",
+			history: func() []zonedCommit { return workdayHistory(12) },
+		},
+		{
+			name: "one late commit is not a burst",
+			history: func() []zonedCommit {
+				h := workdayHistory(10)
+				late := time.Date(2024, time.March, 12, 23, 0, 0, 0, time.UTC)
+				return append(h, zonedCommit{"alice@example.com", late, 2}, zonedCommit{"alice@example.com", late.Add(26 * time.Hour), 2})
+			},
+		},
+		{
+			name: "too little history",
+			history: func() []zonedCommit {
+				h := workdayHistory(3)
+				night := time.Date(2024, time.March, 5, 3, 0, 0, 0, time.UTC)
+				for i := 0; i < 3; i++ {
+					h = append(h, zonedCommit{"alice@example.com", night.Add(time.Duration(i) * 10 * time.Minute), 2})
+				}
+				return h
+			},
+		},
+		{
+			name: "around the clock author has no norm",
+			history: func() []zonedCommit {
+				var h []zonedCommit
+				start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+				for i := 0; i < 48; i++ {
+					h = append(h, zonedCommit{"bot@example.com", start.Add(time.Duration(i) * time.Hour), 0})
+				}
+				return h
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pairs := timezonePairs(tt.history())
+			s := NewTimezoneAnomalyStrategy(0, 0)
+			s.SetBaseline(pairs)
+			if flagged := timezoneFlagged(s, pairs); len(flagged) != 0 {
+				t.Errorf("flagged = %v, want none", flagged)
+			}
+		})
+	}
+}
+
+func TestNarrowestWindow(t *testing.T) {
+	var hist [24]int
+	// Night owl: most commits at 22:00-01:00, wrapping midnight.
+	hist[22], hist[23], hist[0], hist[12] = 3, 3, 3, 1
+	w := narrowestWindow(hist, 10)
+	if w.start != 22 || w.hours != 3 || w.String() != "22:00-01:00" {
+		t.Errorf("narrowestWindow() = %+v (%s), want 22:00-01:00", w, w)
+	}
+	if !w.contains(23) || !w.contains(0) || w.contains(12) {
+		t.Errorf("window %s contains the wrong hours", w)
+	}
+	if got := formatOffset(-(5*3600 + 30*60)); got != "UTC-05:30" {
+		t.Errorf("formatOffset() = %q, want UTC-05:30", got)
+	}
+}
@@ -0,0 +1,234 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// HistoryStrategy is implemented by strategies that judge the analyzed
+// history as a whole, such as the shape of its commit graph, instead of one
+// commit pair at a time. GitDetector runs each once per analysis over every
+// analyzed commit and reports a hit as a detection of its own, attributed to
+// the commit DetectHistory names as the most representative.
+type HistoryStrategy interface {
+	Name() string
+	Category() string
+	Confidence() float64
+	Description() string
+	DetectHistory(commits []*git.Commit) (detected bool, commit, reason string)
+}
+
+// topologyMaxGapVariation is the largest coefficient of variation (standard
+// deviation over mean) of the gaps between commits in a run for the run to
+// count as uniformly spaced. Human commit gaps routinely vary by more than
+// their mean.
+const topologyMaxGapVariation = 0.25
+
+// CommitTopologyStrategy flags histories with no branches or merges at all
+// and a long run of commits at near-identical intervals, the shape left by
+// bulk imports and scripted or AI-generated history. Hand-written history
+// tends to branch and merge, and its commit spacing is irregular.
+type CommitTopologyStrategy struct {
+	minCommits    int
+	minUniformRun int
+}
+
+// NewCommitTopologyStrategy creates a strategy that judges histories of at
+// least minCommits commits and flags a linear one containing a uniformly
+// spaced run of at least minUniformRun commits.
+func NewCommitTopologyStrategy(minCommits, minUniformRun int) *CommitTopologyStrategy {
+	if minCommits <= 0 {
+		minCommits = 20
+	}
+	if minUniformRun <= 0 {
+		minUniformRun = 10
+	}
+	return &CommitTopologyStrategy{minCommits: minCommits, minUniformRun: minUniformRun}
+}
+
+func (s *CommitTopologyStrategy) Name() string        { return "commit_topology_analysis" }
+func (s *CommitTopologyStrategy) Category() string    { return "structural" }
+func (s *CommitTopologyStrategy) Confidence() float64 { return 0.45 }
+func (s *CommitTopologyStrategy) Description() string {
+	return "Detects perfectly linear commit histories with uniformly spaced commits"
+}
+
+// GraphTopology summarizes the commit graph of an analyzed history.
+type GraphTopology struct {
+	Commits int
+	// Merges are commits with more than one parent.
+	Merges int
+	// BranchPoints are commits with more than one child among the analyzed
+	// commits.
+	BranchPoints int
+	// BranchFactor is the mean number of children of the commits that have
+	// any; 1 for a linear history.
+	BranchFactor float64
+	// LongestLinearRun is the longest chain of single-parent, single-child
+	// commits.
+	LongestLinearRun int
+	// UniformRun is the longest stretch of a linear run whose commit gaps
+	// vary by at most topologyMaxGapVariation.
+	UniformRun UniformRun
+}
+
+// MergeFrequency is the share of commits that are merges.
+func (t GraphTopology) MergeFrequency() float64 {
+	if t.Commits == 0 {
+		return 0
+	}
+	return float64(t.Merges) / float64(t.Commits)
+}
+
+// UniformRun is a run of consecutive commits at near-identical intervals.
+type UniformRun struct {
+	Length    int           // commits in the run
+	Newest    string        // hash of the run's newest commit
+	Oldest    string        // hash of the run's oldest commit
+	MeanGap   time.Duration // mean time between the run's commits
+	Variation float64       // coefficient of variation of the gaps
+}
+
+// Topology computes the commit graph shape of commits from their Parents.
+// Parents outside commits, such as those beyond a capped history, are
+// ignored.
+func Topology(commits []*git.Commit) GraphTopology {
+	topo := GraphTopology{Commits: len(commits)}
+	if len(commits) == 0 {
+		return topo
+	}
+
+	byHash := make(map[string]*git.Commit, len(commits))
+	for _, c := range commits {
+		byHash[c.Hash] = c
+	}
+
+	children := make(map[string]int, len(commits))
+	for _, c := range commits {
+		if len(c.Parents) > 1 {
+			topo.Merges++
+		}
+		for _, p := range c.Parents {
+			if _, ok := byHash[p]; ok {
+				children[p]++
+			}
+		}
+	}
+
+	parents, edges := 0, 0
+	for _, n := range children {
+		parents++
+		edges += n
+		if n > 1 {
+			topo.BranchPoints++
+		}
+	}
+	if parents > 0 {
+		topo.BranchFactor = float64(edges) / float64(parents)
+	}
+
+	// A linear edge joins a single-parent commit to a parent it is the only
+	// child of, so every commit has at most one linear edge in and one out
+	// and the linear runs are simple chains.
+	linearParent := func(c *git.Commit) *git.Commit {
+		if len(c.Parents) != 1 || children[c.Parents[0]] != 1 {
+			return nil
+		}
+		return byHash[c.Parents[0]]
+	}
+	hasLinearChild := make(map[string]bool, len(commits))
+	for _, c := range commits {
+		if p := linearParent(c); p != nil {
+			hasLinearChild[p.Hash] = true
+		}
+	}
+
+	for _, head := range commits {
+		if hasLinearChild[head.Hash] {
+			continue
+		}
+		run := []*git.Commit{head}
+		for p := linearParent(head); p != nil; p = linearParent(p) {
+			run = append(run, p)
+		}
+		topo.LongestLinearRun = max(topo.LongestLinearRun, len(run))
+		if u := longestUniformRun(run); u.Length > topo.UniformRun.Length {
+			topo.UniformRun = u
+		}
+	}
+	return topo
+}
+
+// longestUniformRun finds the longest stretch of run, newest commit first,
+// whose gaps vary by at most topologyMaxGapVariation.
+func longestUniformRun(run []*git.Commit) UniformRun {
+	var best UniformRun
+	if len(run) < 2 {
+		return best
+	}
+
+	gaps := make([]float64, len(run)-1)
+	for i := range gaps {
+		gaps[i] = math.Abs(run[i].Timestamp.Sub(run[i+1].Timestamp).Seconds())
+	}
+
+	for start := range gaps {
+		var sum, sumSq float64
+		for end := start; end < len(gaps); end++ {
+			sum += gaps[end]
+			sumSq += gaps[end] * gaps[end]
+			n := float64(end - start + 1)
+			mean := sum / n
+			variation := 0.0
+			if mean > 0 {
+				variation = math.Sqrt(math.Max(sumSq/n-mean*mean, 0)) / mean
+			}
+			if variation > topologyMaxGapVariation {
+				break
+			}
+			if length := end - start + 2; length > best.Length {
+				best = UniformRun{
+					Length:    length,
+					Newest:    run[start].Hash,
+					Oldest:    run[end+1].Hash,
+					MeanGap:   time.Duration(mean * float64(time.Second)),
+					Variation: variation,
+				}
+			}
+		}
+		if len(gaps)-start+1 <= best.Length {
+			break
+		}
+	}
+	return best
+}
+
+// DetectHistory flags a linear history with a long uniform run, attributed to
+// the run's newest commit.
+func (s *CommitTopologyStrategy) DetectHistory(commits []*git.Commit) (detected bool, commit, reason string) {
+	if len(commits) < s.minCommits {
+		return false, "", ""
+	}
+
+	topo := Topology(commits)
+	if topo.Merges > 0 || topo.BranchPoints > 0 || topo.UniformRun.Length < s.minUniformRun {
+		return false, "", ""
+	}
+
+	run := topo.UniformRun
+	return true, run.Newest, fmt.Sprintf(
+		"Linear history: no merges or branch points in %d commits; longest uniform run is %d commits (%s..%s) about %s apart (gap variation %.0f%%)",
+		topo.Commits, run.Length, shortHash(run.Oldest), shortHash(run.Newest),
+		run.MeanGap.Round(time.Second), run.Variation*100,
+	)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
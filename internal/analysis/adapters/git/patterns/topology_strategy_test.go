@@ -0,0 +1,110 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// linearHistory builds n commits, newest first, each the only parent of the
+// one before it, spaced by gap(i) between commit i and its parent.
+func linearHistory(n int, gap func(i int) time.Duration) []*git.Commit {
+	commits := make([]*git.Commit, n)
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range commits {
+		commits[i] = &git.Commit{Hash: fmt.Sprintf("c%02d", i), Timestamp: at}
+		at = at.Add(-gap(i))
+	}
+	for i := 0; i < n-1; i++ {
+		commits[i].Parents = []string{commits[i+1].Hash}
+	}
+	return commits
+}
+
+// irregularGaps cycles through human-looking commit gaps.
+func irregularGaps(i int) time.Duration {
+	return []time.Duration{7 * time.Minute, 3 * time.Hour, 25 * time.Minute, 26 * time.Hour, 90 * time.Second}[i%5]
+}
+
+func TestCommitTopologyStrategy_LinearUniformHistory(t *testing.T) {
+	s := NewCommitTopologyStrategy(0, 0)
+
+	// 12 commits five minutes apart in the middle of irregular history.
+	commits := linearHistory(30, func(i int) time.Duration {
+		if i >= 10 && i < 21 {
+			return 5*time.Minute + time.Duration(i%2)*10*time.Second
+		}
+		return irregularGaps(i)
+	})
+
+	detected, commit, reason := s.DetectHistory(commits)
+	if !detected {
+		t.Fatal("linear history with a uniform run should be flagged")
+	}
+	if commit != "c10" {
+		t.Errorf("commit = %q, want the run's newest commit c10", commit)
+	}
+	for _, want := range []string{"30 commits", "12 commits", "c21..c10", "5m5s apart"} {
+		if !strings.Contains(reason, want) {
+			t.Errorf("reason %q should mention %q", reason, want)
+		}
+	}
+}
+
+func TestCommitTopologyStrategy_NotFlagged(t *testing.T) {
+	s := NewCommitTopologyStrategy(0, 0)
+	uniform := func(int) time.Duration { return 10 * time.Minute }
+
+	merged := linearHistory(30, uniform)
+	merged[5].Parents = append(merged[5].Parents, "feature-tip")
+
+	branched := linearHistory(30, uniform)
+	branched = append(branched, &git.Commit{Hash: "side", Parents: []string{branched[20].Hash}})
+
+	tests := []struct {
+		name    string
+		commits []*git.Commit
+	}{
+		{"irregular spacing", linearHistory(30, irregularGaps)},
+		{"too few commits", linearHistory(15, uniform)},
+		{"merge commit", merged},
+		{"branch point", branched},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if detected, _, reason := s.DetectHistory(tt.commits); detected {
+				t.Errorf("DetectHistory() fired: %s", reason)
+			}
+		})
+	}
+}
+
+func TestTopology(t *testing.T) {
+	// main: m3 <- m2 <- m1 <- m0, with f1 <- f0 branched off m0 and merged
+	// back by m3.
+	commits := []*git.Commit{
+		{Hash: "m3", Parents: []string{"m2", "f1"}},
+		{Hash: "f1", Parents: []string{"f0"}},
+		{Hash: "m2", Parents: []string{"m1"}},
+		{Hash: "f0", Parents: []string{"m0"}},
+		{Hash: "m1", Parents: []string{"m0"}},
+		{Hash: "m0"},
+	}
+
+	topo := Topology(commits)
+	if topo.Merges != 1 || topo.BranchPoints != 1 {
+		t.Errorf("merges = %d, branch points = %d, want 1 and 1", topo.Merges, topo.BranchPoints)
+	}
+	if topo.BranchFactor != 6.0/5.0 {
+		t.Errorf("BranchFactor = %v, want 6 edges over 5 parents", topo.BranchFactor)
+	}
+	if topo.LongestLinearRun != 2 {
+		t.Errorf("LongestLinearRun = %d, want 2 (m2 <- m1 or f1 <- f0)", topo.LongestLinearRun)
+	}
+	if got := topo.MergeFrequency(); got != 1.0/6.0 {
+		t.Errorf("MergeFrequency() = %v, want 1/6", got)
+	}
+}
@@ -1,9 +1,14 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"io"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	cerrors "github.com/TryCadence/Cadence/internal/errors"
 	"github.com/TryCadence/Cadence/internal/logging"
@@ -15,6 +20,25 @@ import (
 
 type RepositoryOptions struct {
 	ExcludeFiles []string
+	// GeneratedFiles adds file patterns to DefaultGeneratedFiles. Files
+	// matching them, or starting with a "Code generated ... DO NOT EDIT"
+	// header, are left out of the stats and diff content detectors see and
+	// counted in the Generated* stats instead. Excluded files are dropped
+	// before this filter applies.
+	GeneratedFiles []string
+	// CountGeneratedFiles turns the generated-file filter off, counting
+	// generated files like any other change.
+	CountGeneratedFiles bool
+	// IgnoreAuthors drops commits whose author name or email matches any of
+	// these glob patterns (e.g. "dependabot*", "*@renovateapp.com").
+	IgnoreAuthors []string
+	// MaxDiffBytes caps the diff content held in memory across all commit
+	// pairs. Once it is reached, later pairs keep their stats but get no
+	// DiffContent. Zero means no limit.
+	MaxDiffBytes int64
+	// DiffWorkers is how many commit pairs have their diffs computed at
+	// once. Zero uses GOMAXPROCS; 1 computes them serially.
+	DiffWorkers int
 }
 
 type Repository interface {
@@ -24,6 +48,9 @@ type Repository interface {
 
 type CommitPairProvider interface {
 	GetCommitPairs(commits []*Commit) ([]*CommitPair, error)
+	// GetCommitPairsContext is GetCommitPairs that stops between commit
+	// pairs once ctx is done, returning ctx's error.
+	GetCommitPairsContext(ctx context.Context, commits []*Commit) ([]*CommitPair, error)
 }
 
 type DiffProvider interface {
@@ -31,10 +58,15 @@ type DiffProvider interface {
 }
 
 type gitRepository struct {
-	repo         *git.Repository
-	path         string
-	excludeFiles []string
-	logger       *logging.Logger
+	repo           *git.Repository
+	path           string
+	excludeFiles   []string
+	generatedFiles []string
+	countGenerated bool
+	ignoreAuthors  []string
+	maxDiffBytes   int64
+	diffWorkers    int
+	logger         *logging.Logger
 }
 
 func OpenRepository(path string, opts *RepositoryOptions) (Repository, error) {
@@ -52,10 +84,15 @@ func OpenRepository(path string, opts *RepositoryOptions) (Repository, error) {
 	}
 
 	return &gitRepository{
-		repo:         r,
-		path:         path,
-		excludeFiles: opts.ExcludeFiles,
-		logger:       logging.Default(),
+		repo:           r,
+		path:           path,
+		excludeFiles:   opts.ExcludeFiles,
+		generatedFiles: append(append([]string{}, DefaultGeneratedFiles...), opts.GeneratedFiles...),
+		countGenerated: opts.CountGeneratedFiles,
+		ignoreAuthors:  opts.IgnoreAuthors,
+		maxDiffBytes:   opts.MaxDiffBytes,
+		diffWorkers:    opts.DiffWorkers,
+		logger:         logging.Default(),
 	}, nil
 }
 
@@ -64,82 +101,231 @@ func (r *gitRepository) GetCommits(opts *CommitOptions) ([]*Commit, error) {
 		opts = &CommitOptions{}
 	}
 
-	var ref *plumbing.Reference
-	var err error
+	start, err := r.walkStart(opts)
+	if err != nil {
+		return nil, err
+	}
 
-	if opts.Branch != "" {
-		ref, err = r.repo.Reference(plumbing.ReferenceName("refs/heads/"+opts.Branch), true)
-		if err != nil {
-			// If the specified branch doesn't exist, fall back to HEAD
-			r.logger.Warn("branch not found, using default branch", "branch", opts.Branch)
-			ref, err = r.repo.Head()
-			if err != nil {
-				return nil, cerrors.GitError("failed to get HEAD").Wrap(err)
-			}
-		}
-	} else {
-		ref, err = r.repo.Head()
-		if err != nil {
-			return nil, cerrors.GitError("failed to get HEAD").Wrap(err)
+	var from plumbing.Hash
+	if opts.FromHash != "" {
+		if from, err = r.resolveRange(opts.FromHash, start); err != nil {
+			return nil, err
 		}
 	}
 
-	commitIter, err := r.repo.Log(&git.LogOptions{
-		From: ref.Hash(),
-	})
+	mergeStrategy, err := ParseMergeStrategy(string(opts.MergeStrategy))
 	if err != nil {
-		return nil, cerrors.GitError("failed to create commit iterator").Wrap(err)
+		return nil, err
 	}
-	defer commitIter.Close()
 
 	commits := make([]*Commit, 0)
 	count := 0
+	ignored := 0
+	since := strings.ToLower(strings.TrimSpace(opts.SinceHash))
+	reachedSince := false
+	reachedFrom := false
+
+	visit := func(c *object.Commit) error {
+		if !from.IsZero() && c.Hash == from {
+			reachedFrom = true
+			return io.EOF
+		}
+		if since != "" && strings.HasPrefix(c.Hash.String(), since) {
+			reachedSince = true
+			return io.EOF
+		}
 
-	err = commitIter.ForEach(func(c *object.Commit) error {
 		if opts.MaxDepth > 0 && count >= opts.MaxDepth {
 			return io.EOF
 		}
 
+		if r.shouldIgnoreAuthor(c.Author.Name, c.Author.Email) {
+			ignored++
+			return nil
+		}
+
 		parents := make([]string, len(c.ParentHashes))
 		for i, p := range c.ParentHashes {
 			parents[i] = p.String()
 		}
 
+		sigType, signer := parseSignature(c.PGPSignature)
+		_, offset := c.Author.When.Zone()
+
 		commits = append(commits, &Commit{
-			Hash:      c.Hash.String(),
-			Author:    c.Author.Name,
-			Email:     c.Author.Email,
-			Timestamp: c.Author.When,
-			Message:   c.Message,
-			Parents:   parents,
+			Hash:          c.Hash.String(),
+			Author:        c.Author.Name,
+			Email:         c.Author.Email,
+			Timestamp:     c.Author.When,
+			TZOffset:      offset,
+			Message:       c.Message,
+			Parents:       parents,
+			Signed:        sigType != "",
+			SignatureType: sigType,
+			SignerID:      signer,
+			Trailers:      ParseTrailers(c.Message),
+			pairMerge:     len(parents) > 1 && mergeStrategy != MergeSkip,
 		})
 
 		count++
 		return nil
-	})
+	}
+
+	if mergeStrategy == MergeFirstParent {
+		err = r.walkFirstParent(start, visit)
+	} else {
+		var commitIter object.CommitIter
+		commitIter, err = r.repo.Log(&git.LogOptions{
+			From: start,
+		})
+		if err != nil {
+			return nil, cerrors.GitError("failed to create commit iterator").Wrap(err)
+		}
+		defer commitIter.Close()
+		err = commitIter.ForEach(visit)
+	}
 
 	if err != nil && err != io.EOF {
 		return nil, cerrors.GitError("error iterating commits").Wrap(err)
 	}
 
+	// The walk only stops at the range start when it meets it; one it never
+	// meets, such as a start only on a merged branch with --first-parent,
+	// would otherwise return the whole history.
+	if !from.IsZero() && !reachedFrom && (opts.MaxDepth == 0 || count < opts.MaxDepth) {
+		return nil, cerrors.GitError("range start is not in the walked history of the range end").
+			WithDetails(opts.FromHash)
+	}
+
+	if ignored > 0 {
+		r.logger.Info("skipped commits from ignored authors", "ignored", ignored, "kept", len(commits))
+	}
+
+	if since != "" && !reachedSince && (opts.MaxDepth == 0 || count < opts.MaxDepth) {
+		r.logger.Warn("since commit not found in history, returning all commits", "since", opts.SinceHash)
+	}
+
 	return commits, nil
 }
 
+// walkFirstParent calls visit on start and each first parent after it,
+// like git log --first-parent, until visit returns an error or the history
+// ends. A missing parent (shallow clone) ends the history.
+func (r *gitRepository) walkFirstParent(start plumbing.Hash, visit func(*object.Commit) error) error {
+	for hash := start; ; {
+		c, err := r.repo.CommitObject(hash)
+		if errors.Is(err, plumbing.ErrObjectNotFound) && hash != start {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := visit(c); err != nil {
+			return err
+		}
+		if len(c.ParentHashes) == 0 {
+			return nil
+		}
+		hash = c.ParentHashes[0]
+	}
+}
+
+// walkStart returns the commit GetCommits walks back from: ToHash when set,
+// otherwise the head of Branch, falling back to HEAD.
+func (r *gitRepository) walkStart(opts *CommitOptions) (plumbing.Hash, error) {
+	if opts.ToHash != "" {
+		return r.ResolveRevision(opts.ToHash)
+	}
+
+	if opts.Branch != "" {
+		ref, err := r.repo.Reference(plumbing.ReferenceName("refs/heads/"+opts.Branch), true)
+		if err == nil {
+			return ref.Hash(), nil
+		}
+		// If the specified branch doesn't exist, fall back to HEAD
+		r.logger.Warn("branch not found, using default branch", "branch", opts.Branch)
+	}
+
+	ref, err := r.repo.Head()
+	if err != nil {
+		return plumbing.ZeroHash, cerrors.GitError("failed to get HEAD").Wrap(err)
+	}
+	return ref.Hash(), nil
+}
+
+// resolveRange resolves the start of a commit range and checks that it is
+// an ancestor of end, so the walk from end is guaranteed to stop at it.
+func (r *gitRepository) resolveRange(fromRev string, end plumbing.Hash) (plumbing.Hash, error) {
+	from, err := r.ResolveRevision(fromRev)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if from == end {
+		return from, nil
+	}
+
+	fromCommit, err := r.repo.CommitObject(from)
+	if err != nil {
+		return plumbing.ZeroHash, cerrors.GitError("revision is not a commit").WithDetails(fromRev)
+	}
+	endCommit, err := r.repo.CommitObject(end)
+	if err != nil {
+		return plumbing.ZeroHash, cerrors.GitError("failed to read commit").WithDetails(end.String()).Wrap(err)
+	}
+	ancestor, err := fromCommit.IsAncestor(endCommit)
+	if err != nil {
+		return plumbing.ZeroHash, cerrors.GitError("failed to check commit ancestry").Wrap(err)
+	}
+	if !ancestor {
+		return plumbing.ZeroHash, cerrors.GitError("range start is not an ancestor of the range end").
+			WithDetails(fromRev + ".." + end.String()[:12])
+	}
+	return from, nil
+}
+
+// ResolveRevision resolves a hash, abbreviated hash, branch, tag or other
+// git revision (HEAD~2, main^) to a full commit hash.
+func (r *gitRepository) ResolveRevision(rev string) (plumbing.Hash, error) {
+	rev = strings.TrimSpace(rev)
+	if rev == "" {
+		return plumbing.ZeroHash, cerrors.ValidationError("revision cannot be empty")
+	}
+	hash, err := r.repo.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return plumbing.ZeroHash, cerrors.GitError("revision not found in repository").WithDetails(rev).Wrap(err)
+	}
+	if _, err := r.repo.CommitObject(*hash); err != nil {
+		return plumbing.ZeroHash, cerrors.GitError("revision is not a commit").WithDetails(rev).Wrap(err)
+	}
+	return *hash, nil
+}
+
+// pairCandidate is an adjacent commit pair that passed the merge and
+// timestamp checks and still needs its diff computed.
+type pairCandidate struct {
+	previous, current *Commit
+	base              string
+	timeDelta         time.Duration
+}
+
 func (r *gitRepository) GetCommitPairs(commits []*Commit) ([]*CommitPair, error) {
+	return r.GetCommitPairsContext(context.Background(), commits)
+}
+
+func (r *gitRepository) GetCommitPairsContext(ctx context.Context, commits []*Commit) ([]*CommitPair, error) {
 	if len(commits) < 2 {
 		return []*CommitPair{}, nil
 	}
 
-	pairs := make([]*CommitPair, 0)
 	skippedMerge := 0
 	skippedTimeDelta := 0
-	skippedDiffErr := 0
+	candidates := make([]pairCandidate, 0, len(commits)-1)
 
 	for i := 0; i < len(commits)-1; i++ {
 		current := commits[i]
 		previous := commits[i+1]
 
-		if len(current.Parents) > 1 {
+		if current.IsMerge() && !current.pairMerge {
 			skippedMerge++
 			continue
 		}
@@ -150,68 +336,230 @@ func (r *gitRepository) GetCommitPairs(commits []*Commit) ([]*CommitPair, error)
 			continue
 		}
 
-		stats, err := r.getDiffStats(previous.Hash, current.Hash)
-		if err != nil {
+		// Diff against the real parent so changes from commits dropped by
+		// IgnoreAuthors are not attributed to the next kept commit. Merges
+		// are diffed against their first parent, the mainline.
+		base := previous.Hash
+		if len(current.Parents) > 0 {
+			base = current.Parents[0]
+		}
+
+		candidates = append(candidates, pairCandidate{
+			previous:  previous,
+			current:   current,
+			base:      base,
+			timeDelta: timeDelta,
+		})
+	}
+
+	workers := r.diffWorkerRepos(len(candidates))
+
+	stats := make([]*DiffStats, len(candidates))
+	statErrs := make([]error, len(candidates))
+	runParallel(ctx, workers, 0, len(candidates), func(w *gitRepository, i int) {
+		stats[i], statErrs[i] = w.getDiffStats(candidates[i].base, candidates[i].current.Hash)
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	pairs := make([]*CommitPair, 0, len(candidates))
+	bases := make([]string, 0, len(candidates))
+	skippedDiffErr := 0
+	for i, c := range candidates {
+		if statErrs[i] != nil {
 			skippedDiffErr++
 			r.logger.Warn("skipping commit pair: failed to get diff stats",
-				"current_hash", current.Hash,
-				"previous_hash", previous.Hash,
-				"error", err,
+				"current_hash", c.current.Hash,
+				"previous_hash", c.previous.Hash,
+				"error", statErrs[i],
 			)
 			continue
 		}
-
-		// Get the actual diff content for AI analysis
-		diffContent, err := r.GetCommitDiff(previous.Hash, current.Hash)
-		if err != nil {
-			// If we can't get diff content, continue without it but log the issue
-			r.logger.Warn("failed to get diff content, proceeding without it",
-				"current_hash", current.Hash,
-				"previous_hash", previous.Hash,
-				"error", err,
-			)
-			diffContent = ""
-		}
-
 		pairs = append(pairs, &CommitPair{
-			Previous:    previous,
-			Current:     current,
-			TimeDelta:   timeDelta,
-			Stats:       stats,
-			DiffContent: diffContent,
+			Previous:  c.previous,
+			Current:   c.current,
+			TimeDelta: c.timeDelta,
+			Stats:     stats[i],
 		})
+		bases = append(bases, c.base)
+	}
+
+	skippedDiffBudget := r.fillDiffContent(ctx, workers, pairs, bases)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
 
-	if skippedDiffErr > 0 || skippedMerge > 0 || skippedTimeDelta > 0 {
+	if skippedDiffErr > 0 || skippedMerge > 0 || skippedTimeDelta > 0 || skippedDiffBudget > 0 {
 		r.logger.Info("commit pair generation complete",
 			"total_commits", len(commits),
 			"pairs_generated", len(pairs),
 			"skipped_merge", skippedMerge,
 			"skipped_time_delta", skippedTimeDelta,
 			"skipped_diff_error", skippedDiffErr,
+			"skipped_diff_content", skippedDiffBudget,
 		)
 	}
 
 	return pairs, nil
 }
 
+// fillDiffContent sets the diff content of pairs and returns how many were
+// skipped by the MaxDiffBytes budget. With a budget, diffs are computed one
+// batch of len(workers) at a time and accepted in commit order, so the same
+// pairs are skipped as in a serial run and at most one batch is held past
+// the budget.
+func (r *gitRepository) fillDiffContent(ctx context.Context, workers []*gitRepository, pairs []*CommitPair, bases []string) int {
+	batch := len(pairs)
+	if r.maxDiffBytes > 0 {
+		batch = len(workers)
+	}
+
+	diffErrs := make([]error, len(pairs))
+	var diffBytes int64
+	skipped := 0
+	spent := func() bool {
+		return r.maxDiffBytes > 0 && diffBytes >= r.maxDiffBytes
+	}
+
+	for start := 0; start < len(pairs); start += batch {
+		end := min(start+batch, len(pairs))
+		if ctx.Err() != nil {
+			return skipped
+		}
+		if !spent() {
+			runParallel(ctx, workers, start, end, func(w *gitRepository, i int) {
+				pairs[i].DiffContent, diffErrs[i] = w.GetCommitDiff(bases[i], pairs[i].Current.Hash)
+			})
+		}
+
+		for i := start; i < end; i++ {
+			pair := pairs[i]
+			if spent() {
+				if skipped == 0 {
+					r.logger.Warn("diff content limit reached, keeping only stats for remaining commits",
+						"max_diff_bytes", r.maxDiffBytes,
+						"first_skipped_hash", pair.Current.Hash,
+					)
+				}
+				skipped++
+				pair.DiffContent = ""
+				pair.DiffSkipped = true
+				continue
+			}
+			if diffErrs[i] != nil {
+				// If we can't get diff content, continue without it but log the issue
+				r.logger.Warn("failed to get diff content, proceeding without it",
+					"current_hash", pair.Current.Hash,
+					"previous_hash", pair.Previous.Hash,
+					"error", diffErrs[i],
+				)
+				pair.DiffContent = ""
+			}
+			diffBytes += int64(len(pair.DiffContent))
+		}
+	}
+	return skipped
+}
+
+// diffWorkerRepos returns one repository handle per diff worker, at most n.
+// The first is r itself; the rest reopen the repository so workers never
+// share go-git's object cache or pack file readers. If reopening fails,
+// fewer workers are used.
+func (r *gitRepository) diffWorkerRepos(n int) []*gitRepository {
+	count := r.diffWorkers
+	if count <= 0 {
+		count = runtime.GOMAXPROCS(0)
+	}
+	count = min(count, n)
+
+	workers := []*gitRepository{r}
+	for len(workers) < count {
+		repo, err := git.PlainOpen(r.path)
+		if err != nil {
+			r.logger.Warn("failed to open repository for diff worker, using fewer workers",
+				"workers", len(workers),
+				"error", err,
+			)
+			break
+		}
+		w := *r
+		w.repo = repo
+		workers = append(workers, &w)
+	}
+	return workers
+}
+
+// runParallel calls fn for every index in [start, end), spread across
+// workers. Each worker handle is used by one goroutine at a time. Indexes
+// not yet started when ctx is done are skipped.
+func runParallel(ctx context.Context, workers []*gitRepository, start, end int, fn func(w *gitRepository, i int)) {
+	if len(workers) == 1 || end-start <= 1 {
+		for i := start; i < end && ctx.Err() == nil; i++ {
+			fn(workers[0], i)
+		}
+		return
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for _, w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if ctx.Err() == nil {
+					fn(w, i)
+				}
+			}
+		}()
+	}
+	for i := start; i < end; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
 func (r *gitRepository) shouldExcludeFile(filePath string) bool {
 	if len(r.excludeFiles) == 0 {
 		return false
 	}
 
-	for _, pattern := range r.excludeFiles {
-		matched, err := filepath.Match(pattern, filepath.Base(filePath))
-		if err == nil && matched {
+	return matchesAny(r.excludeFiles, filepath.Base(filePath)) || matchesAny(r.excludeFiles, filePath)
+}
+
+// shouldIgnoreAuthor reports whether a commit author is in the ignore list.
+// Matching is case-insensitive, and a pattern equal to the name or email
+// matches even when it contains glob metacharacters such as "dependabot[bot]".
+func (r *gitRepository) shouldIgnoreAuthor(name, email string) bool {
+	if len(r.ignoreAuthors) == 0 {
+		return false
+	}
+
+	name = strings.ToLower(name)
+	email = strings.ToLower(email)
+	for _, pattern := range r.ignoreAuthors {
+		pattern = strings.ToLower(pattern)
+		if pattern == name || pattern == email {
 			return true
 		}
+		if matchesAny([]string{pattern}, name) || matchesAny([]string{pattern}, email) {
+			return true
+		}
+	}
 
-		matched, err = filepath.Match(pattern, filePath)
+	return false
+}
+
+// matchesAny reports whether value matches any filepath.Match pattern.
+func matchesAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		matched, err := filepath.Match(pattern, value)
 		if err == nil && matched {
 			return true
 		}
 	}
-
 	return false
 }
 
@@ -268,9 +616,11 @@ func (r *gitRepository) getDiffStats(fromHash, toHash string) (*DiffStats, error
 				filesChangedTotal[to.Path()] = true
 			}
 
+			chunks := filePatch.Chunks()
 			isExcluded := r.shouldExcludeFile(filePath)
+			isGenerated := !isExcluded && r.isGeneratedFile(filePath, chunks, to == nil)
 
-			if !isExcluded {
+			if !isExcluded && !isGenerated {
 				if from != nil {
 					filesChanged[from.Path()] = true
 				}
@@ -279,7 +629,7 @@ func (r *gitRepository) getDiffStats(fromHash, toHash string) (*DiffStats, error
 				}
 			}
 
-			chunks := filePatch.Chunks()
+			file := FileStat{Path: filePath}
 			for _, chunk := range chunks {
 				lines := strings.Split(chunk.Content(), "\n")
 				for _, line := range lines {
@@ -289,17 +639,26 @@ func (r *gitRepository) getDiffStats(fromHash, toHash string) (*DiffStats, error
 					switch chunk.Type() {
 					case diff.Add:
 						stats.TotalAdditions++
-						if !isExcluded {
-							stats.Additions++
-						}
+						file.Additions++
 					case diff.Delete:
 						stats.TotalDeletions++
-						if !isExcluded {
-							stats.Deletions++
-						}
+						file.Deletions++
 					}
 				}
 			}
+
+			switch {
+			case isGenerated:
+				stats.GeneratedFiles++
+				stats.GeneratedAdditions += file.Additions
+				stats.GeneratedDeletions += file.Deletions
+			case !isExcluded:
+				stats.Additions += file.Additions
+				stats.Deletions += file.Deletions
+				if file.Additions > 0 || file.Deletions > 0 {
+					stats.Files = append(stats.Files, file)
+				}
+			}
 		}
 	}
 
@@ -360,7 +719,7 @@ func (r *gitRepository) GetCommitDiff(fromHash, toHash string) (string, error) {
 			filePath = from.Path()
 		}
 
-		if r.shouldExcludeFile(filePath) {
+		if r.shouldExcludeFile(filePath) || r.isGeneratedFile(filePath, filePatches[0].Chunks(), to == nil) {
 			continue
 		}
 
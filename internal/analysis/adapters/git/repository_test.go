@@ -1,11 +1,18 @@
 package git
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 // createTestRepo creates a test git repository with commits
@@ -229,6 +236,37 @@ func TestGitRepository_GetCommits(t *testing.T) {
 		}
 	})
 
+	t.Run("since hash is exclusive", func(t *testing.T) {
+		all, err := repo.GetCommits(nil)
+		if err != nil {
+			t.Fatalf("GetCommits() unexpected error = %v", err)
+		}
+
+		commits, err := repo.GetCommits(&CommitOptions{SinceHash: all[1].Hash})
+		if err != nil {
+			t.Fatalf("GetCommits() unexpected error = %v", err)
+		}
+		if len(commits) != 1 || commits[0].Hash != all[0].Hash {
+			t.Errorf("GetCommits(since=%s) = %d commits, want only the newest", all[1].Hash, len(commits))
+		}
+
+		commits, err = repo.GetCommits(&CommitOptions{SinceHash: all[2].Hash[:10]})
+		if err != nil {
+			t.Fatalf("GetCommits() unexpected error = %v", err)
+		}
+		if len(commits) != 2 {
+			t.Errorf("abbreviated since hash returned %d commits, want 2", len(commits))
+		}
+
+		commits, err = repo.GetCommits(&CommitOptions{SinceHash: "0000000000000000000000000000000000000000"})
+		if err != nil {
+			t.Fatalf("GetCommits() unexpected error = %v", err)
+		}
+		if len(commits) != 3 {
+			t.Errorf("unknown since hash returned %d commits, want all 3", len(commits))
+		}
+	})
+
 	t.Run("nil options works", func(t *testing.T) {
 		commits, err := repo.GetCommits(nil)
 		if err != nil {
@@ -305,6 +343,284 @@ func TestGitRepository_GetCommitPairs(t *testing.T) {
 	})
 }
 
+func TestGitRepository_MaxDiffBytes(t *testing.T) {
+	repoPath := createTestRepo(t)
+	gitRepo, err := OpenRepository(repoPath, &RepositoryOptions{MaxDiffBytes: 1})
+	if err != nil {
+		t.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer gitRepo.Close()
+
+	commits, err := gitRepo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() unexpected error = %v", err)
+	}
+	pairs, err := gitRepo.(CommitPairProvider).GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+	}
+	if len(pairs) != 2 {
+		t.Fatalf("len(pairs) = %d, want 2", len(pairs))
+	}
+
+	if pairs[0].DiffSkipped || pairs[0].DiffContent == "" {
+		t.Errorf("first pair should carry its diff (skipped=%v)", pairs[0].DiffSkipped)
+	}
+	if !pairs[1].DiffSkipped || pairs[1].DiffContent != "" {
+		t.Errorf("second pair should be skipped once the budget is spent (skipped=%v, %d bytes)", pairs[1].DiffSkipped, len(pairs[1].DiffContent))
+	}
+	if pairs[1].Stats == nil || pairs[1].Stats.Additions != 2 {
+		t.Errorf("skipped pair should keep its stats, got %+v", pairs[1].Stats)
+	}
+}
+
+// createHistoryRepo creates a repository with n commits an hour apart, each
+// rewriting one of a few files so the diffs differ in size.
+func createHistoryRepo(tb testing.TB, n int) string {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		tb.Fatalf("PlainInit() failed: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		tb.Fatalf("Worktree() failed: %v", err)
+	}
+
+	when := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < n; i++ {
+		name := fmt.Sprintf("file%d.go", i%4)
+		content := strings.Repeat(fmt.Sprintf("line %d of commit %d\n", i, i), i%7+1)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			tb.Fatalf("WriteFile() failed: %v", err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			tb.Fatalf("Add() failed: %v", err)
+		}
+		sig := &object.Signature{Name: "dev", Email: "dev@example.com", When: when.Add(time.Duration(i) * time.Hour)}
+		if _, err := wt.Commit(fmt.Sprintf("commit %d", i), &gogit.CommitOptions{Author: sig}); err != nil {
+			tb.Fatalf("Commit() failed: %v", err)
+		}
+	}
+	return dir
+}
+
+func commitPairsWith(tb testing.TB, path string, opts *RepositoryOptions) []*CommitPair {
+	tb.Helper()
+
+	repo, err := OpenRepository(path, opts)
+	if err != nil {
+		tb.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer repo.Close()
+
+	commits, err := repo.GetCommits(nil)
+	if err != nil {
+		tb.Fatalf("GetCommits() unexpected error = %v", err)
+	}
+	pairs, err := repo.(CommitPairProvider).GetCommitPairs(commits)
+	if err != nil {
+		tb.Fatalf("GetCommitPairs() unexpected error = %v", err)
+	}
+	return pairs
+}
+
+// createFilesRepo creates a repository with one commit per entry of
+// commits, an hour apart, each writing the given files (path to content).
+func createFilesRepo(tb testing.TB, commits ...map[string]string) string {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		tb.Fatalf("PlainInit() failed: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		tb.Fatalf("Worktree() failed: %v", err)
+	}
+
+	when := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	for i, files := range commits {
+		for name, content := range files {
+			path := filepath.Join(dir, name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+				tb.Fatalf("MkdirAll() failed: %v", err)
+			}
+			if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+				tb.Fatalf("WriteFile() failed: %v", err)
+			}
+			if _, err := wt.Add(name); err != nil {
+				tb.Fatalf("Add() failed: %v", err)
+			}
+		}
+		sig := &object.Signature{Name: "dev", Email: "dev@example.com", When: when.Add(time.Duration(i) * time.Hour)}
+		if _, err := wt.Commit(fmt.Sprintf("commit %d", i), &gogit.CommitOptions{Author: sig}); err != nil {
+			tb.Fatalf("Commit() failed: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestGitRepository_FileStats(t *testing.T) {
+	dir := createFilesRepo(t, map[string]string{"main.go": "a\nb\n"}, map[string]string{
+		"main.go":           "a\nc\nd\ne\n",
+		"util.go":           "x\n",
+		"package-lock.json": strings.Repeat("{}\n", 10),
+	})
+
+	pairs := commitPairsWith(t, dir, &RepositoryOptions{ExcludeFiles: []string{"package-lock.json"}})
+	if len(pairs) != 1 {
+		t.Fatalf("len(pairs) = %d, want 1", len(pairs))
+	}
+	stats := pairs[0].Stats
+
+	want := []FileStat{
+		{Path: "main.go", Additions: 3, Deletions: 1},
+		{Path: "util.go", Additions: 1},
+	}
+	if !reflect.DeepEqual(stats.Files, want) {
+		t.Errorf("Files = %+v, want %+v (excluded files left out)", stats.Files, want)
+	}
+	if stats.Additions != 4 || stats.Deletions != 1 {
+		t.Errorf("Additions/Deletions = %d/%d, want 4/1", stats.Additions, stats.Deletions)
+	}
+	if stats.TotalAdditions != 14 {
+		t.Errorf("TotalAdditions = %d, want 14", stats.TotalAdditions)
+	}
+
+	if top := stats.TopFiles(1); len(top) != 1 || top[0].Path != "main.go" {
+		t.Errorf("TopFiles(1) = %+v, want main.go", top)
+	}
+	if top := stats.TopFiles(5); len(top) != 2 {
+		t.Errorf("TopFiles(5) = %+v, want both files", top)
+	}
+	if top := (*DiffStats)(nil).TopFiles(3); top != nil {
+		t.Errorf("nil TopFiles() = %+v, want nil", top)
+	}
+}
+
+func TestGitRepository_ParallelPairsMatchSerial(t *testing.T) {
+	repoPath := createHistoryRepo(t, 40)
+
+	for _, maxDiffBytes := range []int64{0, 2000} {
+		t.Run(fmt.Sprintf("max_diff_bytes=%d", maxDiffBytes), func(t *testing.T) {
+			serial := commitPairsWith(t, repoPath, &RepositoryOptions{DiffWorkers: 1, MaxDiffBytes: maxDiffBytes})
+			parallel := commitPairsWith(t, repoPath, &RepositoryOptions{DiffWorkers: 8, MaxDiffBytes: maxDiffBytes})
+
+			if len(serial) != 39 {
+				t.Fatalf("len(serial) = %d, want 39", len(serial))
+			}
+			if !reflect.DeepEqual(serial, parallel) {
+				for i := range serial {
+					if i >= len(parallel) || !reflect.DeepEqual(serial[i], parallel[i]) {
+						t.Fatalf("pair %d differs between serial and parallel runs", i)
+					}
+				}
+				t.Fatalf("parallel run returned %d pairs, serial %d", len(parallel), len(serial))
+			}
+			if maxDiffBytes > 0 && !serial[len(serial)-1].DiffSkipped {
+				t.Error("expected the diff budget to skip the oldest pairs")
+			}
+		})
+	}
+}
+
+func BenchmarkGitRepository_GetCommitPairs(b *testing.B) {
+	repoPath := createHistoryRepo(b, 100)
+
+	for _, workers := range []int{1, 0} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				commitPairsWith(b, repoPath, &RepositoryOptions{DiffWorkers: workers})
+			}
+		})
+	}
+}
+
+func TestGitRepository_CommitRange(t *testing.T) {
+	path := createHistoryRepo(t, 6)
+
+	raw, err := gogit.PlainOpen(path)
+	if err != nil {
+		t.Fatalf("PlainOpen() failed: %v", err)
+	}
+	head, err := raw.Head()
+	if err != nil {
+		t.Fatalf("Head() failed: %v", err)
+	}
+	headCommit, err := raw.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject() failed: %v", err)
+	}
+	// Tag commit 1 (the second commit) to use as a symbolic range start.
+	first := headCommit
+	for i := 0; i < 4; i++ {
+		if first, err = first.Parent(0); err != nil {
+			t.Fatalf("Parent() failed: %v", err)
+		}
+	}
+	if _, err := raw.CreateTag("v0.1", first.Hash, nil); err != nil {
+		t.Fatalf("CreateTag() failed: %v", err)
+	}
+
+	repo, err := OpenRepository(path, nil)
+	if err != nil {
+		t.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer repo.Close()
+
+	messages := func(commits []*Commit) string {
+		out := make([]string, len(commits))
+		for i, c := range commits {
+			out[i] = strings.TrimSpace(c.Message)
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name string
+		opts *CommitOptions
+		want string
+	}{
+		{name: "tag to HEAD", opts: &CommitOptions{FromHash: "v0.1"}, want: "commit 5,commit 4,commit 3,commit 2"},
+		{name: "tag to relative ref", opts: &CommitOptions{FromHash: "v0.1", ToHash: "HEAD~2"}, want: "commit 3,commit 2"},
+		{name: "abbreviated hash end", opts: &CommitOptions{ToHash: first.Hash.String()[:10]}, want: "commit 1,commit 0"},
+		{name: "empty range", opts: &CommitOptions{FromHash: "HEAD", ToHash: "HEAD"}, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commits, err := repo.GetCommits(tt.opts)
+			if err != nil {
+				t.Fatalf("GetCommits() unexpected error = %v", err)
+			}
+			if got := messages(commits); got != tt.want {
+				t.Errorf("GetCommits() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	errorTests := []struct {
+		name string
+		opts *CommitOptions
+		want string
+	}{
+		{name: "unknown from", opts: &CommitOptions{FromHash: "no-such-branch"}, want: "revision not found in repository: no-such-branch"},
+		{name: "unknown to", opts: &CommitOptions{ToHash: "v9.9"}, want: "revision not found in repository: v9.9"},
+		{name: "from after to", opts: &CommitOptions{FromHash: "HEAD", ToHash: "v0.1"}, want: "not an ancestor"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := repo.GetCommits(tt.opts)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("GetCommits() error = %v, want it to contain %q", err, tt.want)
+			}
+		})
+	}
+}
+
 func TestGitRepository_ShouldExcludeFile(t *testing.T) {
 	repoPath := createTestRepo(t)
 
@@ -399,3 +715,268 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestGitRepository_IgnoreAuthors(t *testing.T) {
+	repoPath := createTestRepo(t)
+
+	commitAs := func(name, email, file, content, message string) {
+		t.Helper()
+		time.Sleep(1 * time.Second)
+		if err := os.WriteFile(filepath.Join(repoPath, file), []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write %s: %v", file, err)
+		}
+		for _, args := range [][]string{
+			{"add", file},
+			{"-c", "user.name=" + name, "-c", "user.email=" + email, "commit", "-m", message},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = repoPath
+			if err := cmd.Run(); err != nil {
+				t.Fatalf("git %v failed: %v", args, err)
+			}
+		}
+	}
+
+	commitAs("dependabot[bot]", "49699333+dependabot[bot]@users.noreply.github.com", "deps.txt", "a\nb\nc\nd\n", "Bump deps")
+	commitAs("Test User", "test@example.com", "file3.txt", "x\n", "Add file3")
+
+	gitRepo, err := OpenRepository(repoPath, &RepositoryOptions{IgnoreAuthors: []string{"dependabot[bot]"}})
+	if err != nil {
+		t.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer gitRepo.Close()
+
+	commits, err := gitRepo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() unexpected error = %v", err)
+	}
+	if len(commits) != 4 {
+		t.Fatalf("len(commits) = %d, want 4 (bot commit skipped)", len(commits))
+	}
+	for _, c := range commits {
+		if c.Author == "dependabot[bot]" {
+			t.Errorf("GetCommits() returned ignored author commit %s", c.Hash)
+		}
+	}
+
+	pairs, err := gitRepo.(CommitPairProvider).GetCommitPairs(commits)
+	if err != nil {
+		t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+	}
+	if len(pairs) == 0 || pairs[0].Current.Message != "Add file3\n" {
+		t.Fatalf("unexpected first pair: %+v", pairs)
+	}
+	if pairs[0].Stats.Additions != 1 {
+		t.Errorf("pair after skipped commit has %d additions, want 1 (bot changes must not be attributed)", pairs[0].Stats.Additions)
+	}
+}
+
+func TestGitRepository_ShouldIgnoreAuthor(t *testing.T) {
+	repo := &gitRepository{ignoreAuthors: []string{"dependabot[bot]", "renovate*", "*@bots.example.com"}}
+
+	tests := []struct {
+		name   string
+		author string
+		email  string
+		want   bool
+	}{
+		{"literal name with brackets", "dependabot[bot]", "x@github.com", true},
+		{"glob on name", "Renovate Bot", "bot@renovateapp.com", true},
+		{"glob on email", "Release Helper", "release@bots.example.com", true},
+		{"human author", "Jane Doe", "jane@example.com", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repo.shouldIgnoreAuthor(tt.author, tt.email); got != tt.want {
+				t.Errorf("shouldIgnoreAuthor(%q, %q) = %v, want %v", tt.author, tt.email, got, tt.want)
+			}
+		})
+	}
+}
+
+// createMergeRepo creates main: "commit 0", "commit 1", "commit 2" and a
+// "feature" commit branched off commit 1, merged into main by "merge". The
+// feature commit adds feature.go; the merge adds merge.go.
+func createMergeRepo(tb testing.TB) string {
+	tb.Helper()
+
+	dir := tb.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		tb.Fatalf("PlainInit() failed: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		tb.Fatalf("Worktree() failed: %v", err)
+	}
+
+	when := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	commit := func(i int, message, name, content string, parents ...plumbing.Hash) plumbing.Hash {
+		tb.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			tb.Fatalf("WriteFile() failed: %v", err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			tb.Fatalf("Add() failed: %v", err)
+		}
+		sig := &object.Signature{Name: "dev", Email: "dev@example.com", When: when.Add(time.Duration(i) * time.Hour)}
+		hash, err := wt.Commit(message, &gogit.CommitOptions{Author: sig, Parents: parents})
+		if err != nil {
+			tb.Fatalf("Commit() failed: %v", err)
+		}
+		return hash
+	}
+
+	commit(0, "commit 0", "main.go", "a\n")
+	c1 := commit(1, "commit 1", "main.go", "a\nb\n")
+	feature := commit(2, "feature", "feature.go", "f\ng\nh\n")
+	if err := wt.Reset(&gogit.ResetOptions{Commit: c1, Mode: gogit.HardReset}); err != nil {
+		tb.Fatalf("Reset() failed: %v", err)
+	}
+	c2 := commit(3, "commit 2", "main.go", "a\nb\nc\n")
+	if err := os.WriteFile(filepath.Join(dir, "feature.go"), []byte("f\ng\nh\n"), 0o600); err != nil {
+		tb.Fatalf("WriteFile() failed: %v", err)
+	}
+	if _, err := wt.Add("feature.go"); err != nil {
+		tb.Fatalf("Add() failed: %v", err)
+	}
+	commit(4, "merge", "merge.go", "m\n", c2, feature)
+	return dir
+}
+
+func TestGitRepository_MergeStrategy(t *testing.T) {
+	repo, err := OpenRepository(createMergeRepo(t), nil)
+	if err != nil {
+		t.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer repo.Close()
+
+	tests := []struct {
+		strategy    MergeStrategy
+		wantCommits int
+		wantMerge   bool
+	}{
+		{strategy: "", wantCommits: 5},
+		{strategy: MergeSkip, wantCommits: 5},
+		{strategy: MergeFirstParent, wantCommits: 4, wantMerge: true},
+		{strategy: MergeBoth, wantCommits: 5, wantMerge: true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			commits, err := repo.GetCommits(&CommitOptions{MergeStrategy: tt.strategy})
+			if err != nil {
+				t.Fatalf("GetCommits() unexpected error = %v", err)
+			}
+			if len(commits) != tt.wantCommits {
+				t.Fatalf("len(commits) = %d, want %d", len(commits), tt.wantCommits)
+			}
+			if !commits[0].IsMerge() {
+				t.Fatalf("commits[0] = %q, want the merge", commits[0].Message)
+			}
+
+			pairs, err := repo.(CommitPairProvider).GetCommitPairs(commits)
+			if err != nil {
+				t.Fatalf("GetCommitPairs() unexpected error = %v", err)
+			}
+			var merge *CommitPair
+			for _, p := range pairs {
+				if p.Current.IsMerge() {
+					merge = p
+				}
+			}
+			if (merge != nil) != tt.wantMerge {
+				t.Fatalf("merge paired = %v, want %v", merge != nil, tt.wantMerge)
+			}
+			if merge == nil {
+				return
+			}
+			// Against the first parent the merge brings in the feature
+			// branch's file as well as its own.
+			if merge.Stats.FilesChanged != 2 || merge.Stats.Additions != 4 {
+				t.Errorf("merge Stats = %+v, want feature.go and merge.go against the first parent", merge.Stats)
+			}
+		})
+	}
+
+	t.Run("first-parent leaves out side branches", func(t *testing.T) {
+		commits, err := repo.GetCommits(&CommitOptions{MergeStrategy: MergeFirstParent})
+		if err != nil {
+			t.Fatalf("GetCommits() unexpected error = %v", err)
+		}
+		for _, c := range commits {
+			if strings.TrimSpace(c.Message) == "feature" {
+				t.Error("first-parent walk should not reach the feature commit")
+			}
+		}
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		_, err := repo.GetCommits(&CommitOptions{MergeStrategy: "octopus"})
+		if err == nil || !strings.Contains(err.Error(), "unknown merge strategy") {
+			t.Errorf("GetCommits() error = %v, want an unknown merge strategy error", err)
+		}
+	})
+}
+
+func TestGitRepository_CommitRangeFirstParent(t *testing.T) {
+	repo, err := OpenRepository(createMergeRepo(t), nil)
+	if err != nil {
+		t.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer repo.Close()
+
+	// The feature commit is an ancestor of HEAD but not on its first-parent
+	// line, so a first-parent walk never meets it.
+	_, err = repo.GetCommits(&CommitOptions{FromHash: "HEAD^2", MergeStrategy: MergeFirstParent})
+	if err == nil || !strings.Contains(err.Error(), "range start is not in the walked history") {
+		t.Fatalf("GetCommits() error = %v, want the range start rejected", err)
+	}
+
+	commits, err := repo.GetCommits(&CommitOptions{FromHash: "HEAD~2", MergeStrategy: MergeFirstParent})
+	if err != nil {
+		t.Fatalf("GetCommits() unexpected error = %v", err)
+	}
+	if len(commits) != 2 {
+		t.Errorf("len(commits) = %d, want 2", len(commits))
+	}
+}
+
+func TestGitRepository_TZOffset(t *testing.T) {
+	dir := t.TempDir()
+	raw, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit() failed: %v", err)
+	}
+	wt, err := raw.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if _, err := wt.Add("main.go"); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	when := time.Date(2024, time.March, 1, 23, 30, 0, 0, time.FixedZone("", -(3*3600+30*60)))
+	sig := &object.Signature{Name: "dev", Email: "dev@example.com", When: when}
+	if _, err := wt.Commit("initial", &gogit.CommitOptions{Author: sig}); err != nil {
+		t.Fatalf("Commit() failed: %v", err)
+	}
+
+	repo, err := OpenRepository(dir, nil)
+	if err != nil {
+		t.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer repo.Close()
+	commits, err := repo.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() unexpected error = %v", err)
+	}
+	if len(commits) != 1 || commits[0].TZOffset != -(3*3600+30*60) {
+		t.Fatalf("commits = %+v, want one commit with offset -03:30", commits)
+	}
+	if !commits[0].Timestamp.Equal(when) {
+		t.Errorf("Timestamp = %v, want %v", commits[0].Timestamp, when)
+	}
+}
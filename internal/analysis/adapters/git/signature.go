@@ -0,0 +1,96 @@
+package git
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// Signature types recorded in Commit.SignatureType.
+const (
+	SignatureGPG  = "gpg"
+	SignatureSSH  = "ssh"
+	SignatureX509 = "x509"
+)
+
+const sshSignatureMagic = "SSHSIG"
+
+// parseSignature identifies the kind of an armored commit signature and the
+// key that made it: the OpenPGP issuer fingerprint (or key ID) for GPG, the
+// SHA256 public key fingerprint for SSH. The signature is not verified, so the
+// signer is only the key the commit claims. An unreadable signature still
+// counts as signed, with an empty signer.
+func parseSignature(armored string) (kind, signer string) {
+	armored = strings.TrimSpace(armored)
+	switch {
+	case armored == "":
+		return "", ""
+	case strings.HasPrefix(armored, "-----BEGIN SSH SIGNATURE-----"):
+		return SignatureSSH, sshSigner(armored)
+	case strings.HasPrefix(armored, "-----BEGIN SIGNED MESSAGE-----"):
+		return SignatureX509, ""
+	default:
+		return SignatureGPG, pgpSigner(armored)
+	}
+}
+
+func pgpSigner(armored string) string {
+	block, err := armor.Decode(strings.NewReader(armored))
+	if err != nil {
+		return ""
+	}
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return ""
+	}
+	sig, ok := p.(*packet.Signature)
+	if !ok {
+		return ""
+	}
+	if len(sig.IssuerFingerprint) > 0 {
+		return strings.ToUpper(hex.EncodeToString(sig.IssuerFingerprint))
+	}
+	if sig.IssuerKeyId != nil {
+		return fmt.Sprintf("%016X", *sig.IssuerKeyId)
+	}
+	return ""
+}
+
+// sshSigner returns the fingerprint of the public key embedded in an SSH
+// signature (PROTOCOL.sshsig), formatted like ssh-keygen -l.
+func sshSigner(armored string) string {
+	lines := strings.Split(armored, "\n")
+	var body strings.Builder
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "-----") {
+			continue
+		}
+		body.WriteString(line)
+	}
+	blob, err := base64.StdEncoding.DecodeString(body.String())
+	if err != nil || !bytes.HasPrefix(blob, []byte(sshSignatureMagic)) {
+		return ""
+	}
+
+	// magic, uint32 version, then the public key as an SSH string.
+	rest := blob[len(sshSignatureMagic):]
+	if len(rest) < 8 {
+		return ""
+	}
+	rest = rest[4:]
+	n := binary.BigEndian.Uint32(rest)
+	rest = rest[4:]
+	if uint64(n) > uint64(len(rest)) {
+		return ""
+	}
+	sum := sha256.Sum256(rest[:n])
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
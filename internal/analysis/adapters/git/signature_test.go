@@ -0,0 +1,112 @@
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+func TestGitRepository_GetCommitsCapturesSignatures(t *testing.T) {
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit() unexpected error = %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() unexpected error = %v", err)
+	}
+
+	key, err := openpgp.NewEntity("Test User", "", "test@example.com", nil)
+	if err != nil {
+		t.Fatalf("NewEntity() unexpected error = %v", err)
+	}
+
+	when := time.Now().Add(-time.Hour)
+	for i, signKey := range []*openpgp.Entity{nil, key} {
+		name := fmt.Sprintf("file%d.txt", i)
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("content\n"), 0o600); err != nil {
+			t.Fatalf("WriteFile() unexpected error = %v", err)
+		}
+		if _, err := wt.Add(name); err != nil {
+			t.Fatalf("Add() unexpected error = %v", err)
+		}
+		sig := &object.Signature{Name: "Test User", Email: "test@example.com", When: when.Add(time.Duration(i) * time.Minute)}
+		if _, err := wt.Commit("commit "+name, &gogit.CommitOptions{Author: sig, SignKey: signKey}); err != nil {
+			t.Fatalf("Commit() unexpected error = %v", err)
+		}
+	}
+
+	r, err := OpenRepository(dir, nil)
+	if err != nil {
+		t.Fatalf("OpenRepository() unexpected error = %v", err)
+	}
+	defer r.Close()
+
+	commits, err := r.GetCommits(nil)
+	if err != nil {
+		t.Fatalf("GetCommits() unexpected error = %v", err)
+	}
+	if len(commits) != 2 {
+		t.Fatalf("GetCommits() returned %d commits, want 2", len(commits))
+	}
+
+	signed, unsigned := commits[0], commits[1]
+	if !signed.Signed || signed.SignatureType != SignatureGPG {
+		t.Errorf("signed commit = %+v, want a gpg signature", signed)
+	}
+	if want := fmt.Sprintf("%X", key.PrimaryKey.Fingerprint); signed.SignerID != want {
+		t.Errorf("SignerID = %q, want %q", signed.SignerID, want)
+	}
+	if unsigned.Signed || unsigned.SignatureType != "" || unsigned.SignerID != "" {
+		t.Errorf("unsigned commit = %+v, want no signature", unsigned)
+	}
+}
+
+func TestParseSignature_SSH(t *testing.T) {
+	pubKey := []byte("\x00\x00\x00\x0bssh-ed25519\x00\x00\x00\x20" + string(make([]byte, 32)))
+
+	blob := []byte(sshSignatureMagic)
+	blob = binary.BigEndian.AppendUint32(blob, 1)
+	blob = binary.BigEndian.AppendUint32(blob, uint32(len(pubKey)))
+	blob = append(blob, pubKey...)
+	blob = append(blob, "\x00\x00\x00\x03git"...)
+
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	armored := "-----BEGIN SSH SIGNATURE-----\n" + encoded[:20] + "\n" + encoded[20:] + "\n-----END SSH SIGNATURE-----\n"
+
+	kind, signer := parseSignature(armored)
+	if kind != SignatureSSH {
+		t.Errorf("kind = %q, want %q", kind, SignatureSSH)
+	}
+	sum := sha256.Sum256(pubKey)
+	if want := "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]); signer != want {
+		t.Errorf("signer = %q, want %q", signer, want)
+	}
+}
+
+func TestParseSignature_Other(t *testing.T) {
+	tests := []struct {
+		armored  string
+		wantKind string
+	}{
+		{"", ""},
+		{"-----BEGIN SIGNED MESSAGE-----\nMIIB\n-----END SIGNED MESSAGE-----", SignatureX509},
+		{"-----BEGIN PGP SIGNATURE-----\nnot base64\n-----END PGP SIGNATURE-----", SignatureGPG},
+	}
+	for _, tt := range tests {
+		kind, signer := parseSignature(tt.armored)
+		if kind != tt.wantKind || signer != "" {
+			t.Errorf("parseSignature(%q) = (%q, %q), want (%q, \"\")", tt.armored, kind, signer, tt.wantKind)
+		}
+	}
+}
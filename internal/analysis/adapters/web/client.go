@@ -0,0 +1,167 @@
+package web
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRedirects is how many redirects a Fetcher follows by default,
+// matching net/http.
+const DefaultMaxRedirects = 10
+
+// TooManyRedirectsError is returned when a page redirects more often than
+// the fetcher's limit allows.
+type TooManyRedirectsError struct {
+	URL   string
+	Limit int
+}
+
+func (e *TooManyRedirectsError) Error() string {
+	if e.Limit == 0 {
+		return fmt.Sprintf("%s redirected, and redirects are disabled", e.URL)
+	}
+	return fmt.Sprintf("%s redirected more than %d times", e.URL, e.Limit)
+}
+
+// WithProxy sends requests through the proxy at proxyURL. A nil URL leaves
+// the proxy to WithEnvProxy.
+func WithProxy(proxyURL *url.URL) FetcherOption {
+	return func(f *Fetcher) {
+		f.proxy = proxyURL
+	}
+}
+
+// WithEnvProxy sets whether requests go through the proxy HTTP_PROXY,
+// HTTPS_PROXY and NO_PROXY select when no WithProxy URL is set. By default
+// they do, except on a guarded fetcher: the guard cannot check addresses a
+// proxy dials, so there an environment proxy is only used on purpose.
+func WithEnvProxy(use bool) FetcherOption {
+	return func(f *Fetcher) {
+		f.envProxy = &use
+	}
+}
+
+// WithTLSConfig sets the TLS configuration of HTTPS requests, e.g. to trust
+// a corporate CA (see TLSConfigFromCAFile).
+func WithTLSConfig(c *tls.Config) FetcherOption {
+	return func(f *Fetcher) {
+		f.tlsConfig = c
+	}
+}
+
+// WithMaxRedirects sets how many redirects a fetch follows before failing
+// with TooManyRedirectsError. Zero keeps DefaultMaxRedirects; a negative
+// value fails on the first redirect.
+func WithMaxRedirects(n int) FetcherOption {
+	return func(f *Fetcher) {
+		if n != 0 {
+			f.maxRedirects = max(n, 0)
+		}
+	}
+}
+
+// TLSConfigFromCAFile returns a TLS configuration trusting the PEM
+// certificates in path in addition to the system roots.
+func TLSConfigFromCAFile(path string) (*tls.Config, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no PEM certificates found in %s", path)
+	}
+	return &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}, nil
+}
+
+// newTransport builds the fetcher's transport. A guarded fetcher checks
+// every address it dials except its proxies': a request sent through a
+// proxy is resolved and dialed by the proxy, so the guard relies on
+// checking each URL, including redirect targets, before it is requested.
+func (f *Fetcher) newTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	envProxy := f.guard == nil || f.guard.AllowPrivate
+	if f.envProxy != nil {
+		envProxy = *f.envProxy
+	}
+	var proxy func(*http.Request) (*url.URL, error)
+	switch {
+	case f.proxy != nil:
+		proxy = http.ProxyURL(f.proxy)
+	case envProxy:
+		proxy = http.ProxyFromEnvironment
+	}
+	if f.tlsConfig != nil {
+		transport.TLSClientConfig = f.tlsConfig.Clone()
+	}
+	transport.Proxy = proxy
+	if f.guard == nil {
+		return transport
+	}
+
+	proxies := &proxyAddrs{}
+	if proxy != nil {
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			u, err := proxy(req)
+			if u != nil {
+				proxies.add(u)
+			}
+			return u, err
+		}
+	}
+	direct := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	guarded := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second, Control: f.guard.Control}
+	transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+		if proxies.has(addr) {
+			return direct.DialContext(ctx, network, addr)
+		}
+		return guarded.DialContext(ctx, network, addr)
+	}
+	return transport
+}
+
+// proxyAddrs records the host:port of every proxy the transport has chosen,
+// so its dialer can tell a proxy connection from a direct one.
+type proxyAddrs struct {
+	addrs sync.Map
+}
+
+func (p *proxyAddrs) add(u *url.URL) {
+	port := u.Port()
+	if port == "" {
+		switch u.Scheme {
+		case "https":
+			port = "443"
+		case "socks5", "socks5h":
+			port = "1080"
+		default:
+			port = "80"
+		}
+	}
+	p.addrs.Store(net.JoinHostPort(u.Hostname(), port), true)
+}
+
+func (p *proxyAddrs) has(addr string) bool {
+	_, ok := p.addrs.Load(addr)
+	return ok
+}
+
+// checkRedirect enforces the redirect limit and re-checks the target of
+// every hop with the guard.
+func (f *Fetcher) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > f.maxRedirects {
+		return &TooManyRedirectsError{URL: via[0].URL.String(), Limit: f.maxRedirects}
+	}
+	return f.guard.CheckURL(req.Context(), req.URL.String())
+}
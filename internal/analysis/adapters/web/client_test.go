@@ -0,0 +1,175 @@
+package web
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/netguard"
+)
+
+const redirectPage = "<html><head><title>Final</title></head><body><p>Reached the end of the chain.</p></body></html>"
+
+// redirectChain serves /hop/N, which redirects to /hop/N-1 until /hop/0
+// serves a page.
+func redirectChain(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var n int
+		if _, err := fmt.Sscanf(r.URL.Path, "/hop/%d", &n); err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		if n > 0 {
+			http.Redirect(w, r, fmt.Sprintf("/hop/%d", n-1), http.StatusFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(redirectPage))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchMaxRedirects(t *testing.T) {
+	server := redirectChain(t)
+
+	fetcher := NewFetcher(5*time.Second, WithMaxRedirects(3))
+	content, err := fetcher.Fetch(server.URL + "/hop/3")
+	if err != nil {
+		t.Fatalf("Fetch() through 3 redirects error = %v", err)
+	}
+	if content.Title != "Final" {
+		t.Errorf("Title = %q, want the page at the end of the chain", content.Title)
+	}
+
+	var tooMany *TooManyRedirectsError
+	if _, err := fetcher.Fetch(server.URL + "/hop/4"); !errors.As(err, &tooMany) || tooMany.Limit != 3 {
+		t.Fatalf("Fetch() through 4 redirects error = %v, want TooManyRedirectsError with limit 3", err)
+	}
+
+	none := NewFetcher(5*time.Second, WithMaxRedirects(-1))
+	if _, err := none.Fetch(server.URL + "/hop/1"); !errors.As(err, &tooMany) || tooMany.Limit != 0 {
+		t.Errorf("Fetch() with redirects disabled error = %v, want TooManyRedirectsError", err)
+	}
+}
+
+// staticResolver resolves the names in its map and nothing else.
+type staticResolver map[string]string
+
+func (r staticResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	if ip, ok := r[host]; ok {
+		return []netip.Addr{netip.MustParseAddr(ip)}, nil
+	}
+	return nil, fmt.Errorf("no such host %s", host)
+}
+
+func TestFetchProxyGuardChecksEveryHop(t *testing.T) {
+	// The proxy, itself on loopback, serves a redirect chain across hosts.
+	// Through a proxy the guard cannot check the dialed address, so it must
+	// stop the chain at the hop whose host resolves internally.
+	var requested []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.Host)
+		switch r.Host {
+		case "start.example":
+			http.Redirect(w, r, "http://hop.example/", http.StatusFound)
+		case "hop.example":
+			http.Redirect(w, r, "http://metadata.example/", http.StatusFound)
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte(redirectPage))
+		}
+	}))
+	defer proxy.Close()
+	proxyURL, _ := url.Parse(proxy.URL)
+
+	guard := &netguard.Guard{Resolver: staticResolver{
+		"start.example":    "93.184.216.34",
+		"hop.example":      "93.184.216.35",
+		"metadata.example": "169.254.169.254",
+	}}
+	fetcher := NewFetcher(5*time.Second, WithGuard(guard), WithProxy(proxyURL))
+
+	_, err := fetcher.Fetch("http://start.example/")
+	if !errors.Is(err, netguard.ErrBlockedDestination) {
+		t.Fatalf("Fetch() error = %v, want ErrBlockedDestination", err)
+	}
+	if strings.Join(requested, ",") != "start.example,hop.example" {
+		t.Errorf("proxy received %v, want the two public hops only", requested)
+	}
+
+}
+
+func TestNewTransportEnvProxy(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "http://proxy.example:3128")
+	guard := &netguard.Guard{}
+	proxyURL, _ := url.Parse("http://explicit.example:3128")
+
+	tests := []struct {
+		name      string
+		opts      []FetcherOption
+		wantProxy bool
+	}{
+		{"default", nil, true},
+		{"guarded default", []FetcherOption{WithGuard(guard)}, false},
+		{"guard allowing private hosts", []FetcherOption{WithGuard(&netguard.Guard{AllowPrivate: true})}, true},
+		{"env proxy off", []FetcherOption{WithEnvProxy(false)}, false},
+		{"guarded env proxy", []FetcherOption{WithGuard(guard), WithEnvProxy(true)}, true},
+		{"explicit proxy", []FetcherOption{WithProxy(proxyURL)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := NewFetcher(time.Second, tt.opts...).client.Transport.(*http.Transport)
+			if got := transport.Proxy != nil; got != tt.wantProxy {
+				t.Errorf("transport has a proxy = %v, want %v", got, tt.wantProxy)
+			}
+		})
+	}
+}
+
+func TestTLSConfigFromCAFile(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(redirectPage))
+	}))
+	defer server.Close()
+
+	if _, err := NewFetcher(5 * time.Second).Fetch(server.URL); err == nil {
+		t.Fatal("Fetch() should reject the test server's untrusted certificate")
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tlsConfig, err := TLSConfigFromCAFile(caFile)
+	if err != nil {
+		t.Fatalf("TLSConfigFromCAFile() error = %v", err)
+	}
+	if tlsConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("MinVersion = %x, want TLS 1.2", tlsConfig.MinVersion)
+	}
+	if _, err := NewFetcher(5*time.Second, WithTLSConfig(tlsConfig)).Fetch(server.URL); err != nil {
+		t.Errorf("Fetch() trusting the CA file error = %v", err)
+	}
+
+	if err := os.WriteFile(caFile, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := TLSConfigFromCAFile(caFile); err == nil {
+		t.Error("TLSConfigFromCAFile() should reject a file without certificates")
+	}
+}
@@ -1,15 +1,22 @@
 package web
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"mime"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web/patterns"
 	cerrors "github.com/TryCadence/Cadence/internal/errors"
+	"github.com/TryCadence/Cadence/internal/netguard"
 )
 
 type PageContent struct {
@@ -25,25 +32,151 @@ type PageContent struct {
 	WordCount   int
 	MetaTags    map[string]string
 	Headings    []string
+	// BoldListLeads are the labels of list items that open with a bold
+	// label and a colon ("<li><strong>Label</strong>: ..."), which the
+	// extracted text no longer shows as bold.
+	BoldListLeads []string
+	// Language is the ISO 639-1 code of the main content's language, or
+	// empty when it could not be determined (see patterns.DetectLanguage).
+	// WordCount is counted for it.
+	Language string
 }
 
+// DefaultMaxBytes is the largest response body a Fetcher reads by default.
+const DefaultMaxBytes int64 = 5 << 20
+
+// DefaultUserAgent identifies Cadence to the sites it fetches.
+const DefaultUserAgent = "Cadence/1.0 (+https://github.com/TryCadence/Cadence)"
+
+// DefaultContentTypes are the media types a Fetcher parses by default.
+var DefaultContentTypes = []string{"text/html", "application/xhtml+xml"}
+
 type Fetcher struct {
-	client     *http.Client
-	timeout    time.Duration
-	maxRetries int
+	client       *http.Client
+	timeout      time.Duration
+	maxRetries   int
+	maxBytes     int64
+	contentTypes []string
+	userAgent    string
+	robots       *RobotsCache
+	renderJS     bool
+	guard        *netguard.Guard
+	proxy        *url.URL
+	envProxy     *bool
+	tlsConfig    *tls.Config
+	maxRedirects int
+}
+
+// FetcherOption configures a Fetcher.
+type FetcherOption func(*Fetcher)
+
+// WithMaxBytes sets the largest response body the fetcher will read.
+// Zero or a negative value removes the limit.
+func WithMaxBytes(n int64) FetcherOption {
+	return func(f *Fetcher) {
+		f.maxBytes = n
+	}
+}
+
+// WithContentTypes replaces the accepted response media types. Calling it
+// with no types accepts any content type.
+func WithContentTypes(types ...string) FetcherOption {
+	return func(f *Fetcher) {
+		f.contentTypes = types
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) FetcherOption {
+	return func(f *Fetcher) {
+		if ua != "" {
+			f.userAgent = ua
+		}
+	}
+}
+
+// WithRespectRobots makes the fetcher honor robots.txt, caching each host's
+// rules for DefaultRobotsTTL. Disallowed pages fail with RobotsDisallowedError.
+func WithRespectRobots(respect bool) FetcherOption {
+	return func(f *Fetcher) {
+		if !respect {
+			f.robots = nil
+		} else if f.robots == nil {
+			f.robots = NewRobotsCache(DefaultRobotsTTL)
+		}
+	}
+}
+
+// WithRobotsCache honors robots.txt using a cache that may be shared between
+// fetchers, so short-lived fetchers do not refetch it for every page.
+func WithRobotsCache(cache *RobotsCache) FetcherOption {
+	return func(f *Fetcher) {
+		f.robots = cache
+	}
 }
 
-func NewFetcher(timeout time.Duration) *Fetcher {
+// WithGuard rejects pages, redirect targets and dialed addresses that are
+// internal to the server's network (see netguard). Connections to a proxy
+// are not checked; the guard checks the URL of every hop instead.
+func WithGuard(g *netguard.Guard) FetcherOption {
+	return func(f *Fetcher) {
+		f.guard = g
+	}
+}
+
+func NewFetcher(timeout time.Duration, opts ...FetcherOption) *Fetcher {
 	if timeout == 0 {
 		timeout = 10 * time.Second
 	}
-	return &Fetcher{
+	f := &Fetcher{
 		client: &http.Client{
 			Timeout: timeout,
 		},
-		timeout:    timeout,
-		maxRetries: 3,
+		timeout:      timeout,
+		maxRetries:   3,
+		maxBytes:     DefaultMaxBytes,
+		contentTypes: DefaultContentTypes,
+		userAgent:    DefaultUserAgent,
+		maxRedirects: DefaultMaxRedirects,
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	f.client.Transport = f.newTransport()
+	f.client.CheckRedirect = f.checkRedirect
+	return f
+}
+
+// UnsupportedContentTypeError is returned when a response is not one of the
+// fetcher's accepted media types.
+type UnsupportedContentTypeError struct {
+	URL         string
+	ContentType string
+}
+
+func (e *UnsupportedContentTypeError) Error() string {
+	return fmt.Sprintf("%s returned unsupported content type %q (expected HTML)", e.URL, e.ContentType)
+}
+
+// BodyTooLargeError is returned when a response body exceeds the fetcher's
+// byte limit.
+type BodyTooLargeError struct {
+	URL   string
+	Limit int64
+}
+
+func (e *BodyTooLargeError) Error() string {
+	return fmt.Sprintf("response body from %s exceeds the %d byte limit", e.URL, e.Limit)
+}
+
+// isPermanentFetchError reports errors that retrying the same URL cannot fix.
+func isPermanentFetchError(err error) bool {
+	var ct *UnsupportedContentTypeError
+	var tl *BodyTooLargeError
+	var rd *RobotsDisallowedError
+	var tr *TooManyRedirectsError
+	return errors.As(err, &ct) || errors.As(err, &tl) || errors.As(err, &rd) || errors.As(err, &tr) ||
+		errors.Is(err, ErrRenderingUnavailable) || errors.Is(err, netguard.ErrBlockedDestination)
 }
 
 // isRetryableStatus returns true for HTTP status codes that indicate a
@@ -65,6 +198,23 @@ func (f *Fetcher) Fetch(url string) (*PageContent, error) {
 		url = "https://" + url
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), f.timeout)
+	err := f.guard.CheckURL(ctx, url)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	if f.robots != nil {
+		allowed, err := f.robots.allowed(f.client, url, f.userAgent)
+		if err != nil {
+			return nil, cerrors.ValidationError("invalid URL").WithDetails(url).Wrap(err)
+		}
+		if !allowed {
+			return nil, &RobotsDisallowedError{URL: url, UserAgent: f.userAgent}
+		}
+	}
+
 	var lastErr error
 	for attempt := 0; attempt <= f.maxRetries; attempt++ {
 		if attempt > 0 {
@@ -79,6 +229,10 @@ func (f *Fetcher) Fetch(url string) (*PageContent, error) {
 		}
 		lastErr = err
 
+		if isPermanentFetchError(err) {
+			return nil, err
+		}
+
 		// Only retry on retryable errors (network or retryable status codes)
 		if cerr, ok := err.(*cerrors.CadenceError); ok {
 			if strings.Contains(cerr.Details, "returned") {
@@ -106,8 +260,24 @@ func isRetryableStatusFromDetails(details string) bool {
 }
 
 func (f *Fetcher) doFetch(url string) (*PageContent, error) {
-	resp, err := f.client.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, cerrors.ValidationError("invalid URL").WithDetails(url).Wrap(err)
+	}
+	req.Header.Set("User-Agent", f.userAgent)
+
+	resp, err := f.client.Do(req)
 	if err != nil {
+		// Surface guard rejections (redirects or dials to internal addresses)
+		// as the validation error itself rather than a network failure.
+		var cerr *cerrors.CadenceError
+		if errors.Is(err, netguard.ErrBlockedDestination) && errors.As(err, &cerr) {
+			return nil, cerr
+		}
+		var tr *TooManyRedirectsError
+		if errors.As(err, &tr) {
+			return nil, tr
+		}
 		return nil, cerrors.IOError("failed to fetch URL").WithDetails(url).Wrap(err)
 	}
 	defer func() {
@@ -118,9 +288,19 @@ func (f *Fetcher) doFetch(url string) (*PageContent, error) {
 		return nil, cerrors.IOError("unexpected status code").WithDetails(fmt.Sprintf("%s returned %d", url, resp.StatusCode))
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	if err := f.checkContentType(url, resp.Header.Get("Content-Type")); err != nil {
+		return nil, err
+	}
+
+	body, err := f.readBody(url, resp)
 	if err != nil {
-		return nil, cerrors.IOError("failed to read response body").Wrap(err)
+		return nil, err
+	}
+
+	if f.renderJS {
+		if body, err = f.render(url); err != nil {
+			return nil, cerrors.IOError("failed to render page").WithDetails(url).Wrap(err)
+		}
 	}
 
 	doc, err := goquery.NewDocumentFromReader(strings.NewReader(string(body)))
@@ -138,6 +318,7 @@ func (f *Fetcher) doFetch(url string) (*PageContent, error) {
 	}
 
 	content.Title = strings.TrimSpace(doc.Find("title").First().Text())
+	declaredLang, _ := doc.Find("html").First().Attr("lang")
 
 	metaDesc, _ := doc.Find("meta[name='description']").Attr("content")
 	content.Description = metaDesc
@@ -163,15 +344,82 @@ func (f *Fetcher) doFetch(url string) (*PageContent, error) {
 
 	doc.Find("script, style, nav, header, footer, aside, .ad, .advertisement, .sidebar, .menu, .navigation, #comments, .comment").Remove()
 
+	content.BoldListLeads = boldListLeads(doc)
+
 	allText := doc.Find("body").Text()
 	content.Body = strings.TrimSpace(allText)
 	content.AllText = extractStructuredText(doc)
 	content.MainContent = extractMainContent(doc)
-	content.WordCount = len(strings.Fields(content.MainContent))
+	content.Language = patterns.DetectLanguage(content.MainContent, declaredLang)
+	content.WordCount = patterns.CountWords(content.MainContent, content.Language)
 
 	return content, nil
 }
 
+// checkContentType accepts an empty header, since some servers omit it for
+// HTML, and otherwise requires one of the configured media types.
+func (f *Fetcher) checkContentType(url, header string) error {
+	if len(f.contentTypes) == 0 || header == "" {
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(header)
+	if err == nil {
+		for _, allowed := range f.contentTypes {
+			if strings.EqualFold(mediaType, allowed) {
+				return nil
+			}
+		}
+	}
+	return &UnsupportedContentTypeError{URL: url, ContentType: header}
+}
+
+// readBody reads at most maxBytes of the response, failing instead of
+// truncating when the body is larger.
+func (f *Fetcher) readBody(url string, resp *http.Response) ([]byte, error) {
+	var reader io.Reader = resp.Body
+	if f.maxBytes > 0 {
+		if resp.ContentLength > f.maxBytes {
+			return nil, &BodyTooLargeError{URL: url, Limit: f.maxBytes}
+		}
+		reader = io.LimitReader(resp.Body, f.maxBytes+1)
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, cerrors.IOError("failed to read response body").Wrap(err)
+	}
+	if f.maxBytes > 0 && int64(len(body)) > f.maxBytes {
+		return nil, &BodyTooLargeError{URL: url, Limit: f.maxBytes}
+	}
+	return body, nil
+}
+
+// boldListLeads returns the labels of the list items whose first content is
+// a <strong> or <b> label followed by a colon, inside or right after it.
+func boldListLeads(doc *goquery.Document) []string {
+	var leads []string
+	doc.Find("li").Each(func(_ int, s *goquery.Selection) {
+		contents := s.Contents().FilterFunction(func(_ int, c *goquery.Selection) bool {
+			return goquery.NodeName(c) != "#text" || strings.TrimSpace(c.Text()) != ""
+		})
+		if name := goquery.NodeName(contents.First()); name != "strong" && name != "b" {
+			return
+		}
+		label := strings.TrimSpace(contents.First().Text())
+		if label == "" || len(label) > 60 {
+			return
+		}
+		after := ""
+		if next := contents.Eq(1); goquery.NodeName(next) == "#text" {
+			after = strings.TrimSpace(next.Text())
+		}
+		if strings.HasSuffix(label, ":") || strings.HasPrefix(after, ":") {
+			leads = append(leads, strings.TrimSuffix(label, ":"))
+		}
+	})
+	return leads
+}
+
 func extractStructuredText(doc *goquery.Document) string {
 	var texts []string
 
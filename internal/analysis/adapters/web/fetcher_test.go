@@ -1,11 +1,14 @@
 package web
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/TryCadence/Cadence/internal/netguard"
 )
 
 func TestNewFetcher(t *testing.T) {
@@ -163,6 +166,38 @@ func TestFetchValidHTML(t *testing.T) {
 	}
 }
 
+func TestFetchDetectsLanguage(t *testing.T) {
+	pages := map[string]string{
+		"/de": `<html lang="en"><body><p>Die Mannschaft hat sich auf das Spiel gefreut, und es ist nicht vorbei.</p></body></html>`,
+		"/ja": `<html lang="ja"><body><p>私たちは新しいサービスを開発しています。</p></body></html>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(pages[r.URL.Path]))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		path      string
+		language  string
+		wordCount int
+	}{
+		{"/de", "de", 13},
+		{"/ja", "ja", 19},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			content, err := NewFetcher(5 * time.Second).Fetch(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if content.Language != tt.language || content.WordCount != tt.wordCount {
+				t.Errorf("Language = %q, WordCount = %d, want %q and %d", content.Language, content.WordCount, tt.language, tt.wordCount)
+			}
+		})
+	}
+}
+
 func TestPageContentGetMainContent(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -424,3 +459,108 @@ func TestPageContentHeaders(t *testing.T) {
 		t.Error("expected headers to contain values")
 	}
 }
+
+func TestFetchBodyLimit(t *testing.T) {
+	page := "<html><body>" + strings.Repeat("a", 2048) + "</body></html>"
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/html")
+		// Omit Content-Length so the limit is enforced while reading.
+		w.(http.Flusher).Flush()
+		w.Write([]byte(page))
+	}))
+	defer server.Close()
+
+	_, err := NewFetcher(5*time.Second, WithMaxBytes(1024)).Fetch(server.URL)
+	var tooLarge *BodyTooLargeError
+	if !errors.As(err, &tooLarge) {
+		t.Fatalf("Fetch() error = %v, want BodyTooLargeError", err)
+	}
+	if tooLarge.Limit != 1024 {
+		t.Errorf("Limit = %d, want 1024", tooLarge.Limit)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, oversized bodies should not be retried", requests)
+	}
+
+	if _, err := NewFetcher(5*time.Second, WithMaxBytes(int64(len(page)))).Fetch(server.URL); err != nil {
+		t.Errorf("body exactly at the limit should be accepted, got %v", err)
+	}
+	if _, err := NewFetcher(5*time.Second, WithMaxBytes(0)).Fetch(server.URL); err != nil {
+		t.Errorf("WithMaxBytes(0) should remove the limit, got %v", err)
+	}
+}
+
+func TestFetchContentType(t *testing.T) {
+	contentType := "application/json"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		w.Write([]byte(`<html><body>Test</body></html>`))
+	}))
+	defer server.Close()
+
+	_, err := NewFetcher(5 * time.Second).Fetch(server.URL)
+	var unsupported *UnsupportedContentTypeError
+	if !errors.As(err, &unsupported) {
+		t.Fatalf("Fetch() error = %v, want UnsupportedContentTypeError", err)
+	}
+	if unsupported.ContentType != "application/json" {
+		t.Errorf("ContentType = %q, want application/json", unsupported.ContentType)
+	}
+
+	if _, err := NewFetcher(5*time.Second, WithContentTypes()).Fetch(server.URL); err != nil {
+		t.Errorf("WithContentTypes() should accept any type, got %v", err)
+	}
+
+	contentType = "application/XHTML+xml; charset=utf-8"
+	if _, err := NewFetcher(5 * time.Second).Fetch(server.URL); err != nil {
+		t.Errorf("XHTML should be accepted by default, got %v", err)
+	}
+}
+
+func TestFetchGuardBlocksInternalAddresses(t *testing.T) {
+	hits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><body>internal</body></html>"))
+	}))
+	defer server.Close()
+
+	fetcher := NewFetcher(5*time.Second, WithGuard(&netguard.Guard{}))
+	_, err := fetcher.Fetch(server.URL)
+	if !errors.Is(err, netguard.ErrBlockedDestination) {
+		t.Fatalf("Fetch() error = %v, want ErrBlockedDestination", err)
+	}
+	if hits != 0 {
+		t.Errorf("server was contacted %d times, want 0", hits)
+	}
+
+	allowed := NewFetcher(5*time.Second, WithGuard(&netguard.Guard{AllowPrivate: true}))
+	if _, err := allowed.Fetch(server.URL); err != nil {
+		t.Errorf("Fetch() with AllowPrivate error = %v", err)
+	}
+}
+
+func TestFetchGuardChecksRedirects(t *testing.T) {
+	fetcher := NewFetcher(5*time.Second, WithGuard(&netguard.Guard{}))
+
+	req, _ := http.NewRequest(http.MethodGet, "http://169.254.169.254/latest/meta-data/", nil)
+	if err := fetcher.client.CheckRedirect(req, []*http.Request{{}}); !errors.Is(err, netguard.ErrBlockedDestination) {
+		t.Errorf("redirect to metadata endpoint error = %v, want ErrBlockedDestination", err)
+	}
+
+	req, _ = http.NewRequest(http.MethodGet, "https://93.184.216.34/", nil)
+	if err := fetcher.client.CheckRedirect(req, []*http.Request{{}}); err != nil {
+		t.Errorf("redirect to public address error = %v, want nil", err)
+	}
+	via := make([]*http.Request, DefaultMaxRedirects+1)
+	for i := range via {
+		via[i] = req
+	}
+	var tooMany *TooManyRedirectsError
+	if err := fetcher.client.CheckRedirect(req, via); !errors.As(err, &tooMany) {
+		t.Errorf("error after too many redirects = %v, want TooManyRedirectsError", err)
+	}
+}
@@ -0,0 +1,161 @@
+package web
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	cerrors "github.com/TryCadence/Cadence/internal/errors"
+)
+
+// DefaultTrackingParams are query parameters that never change page content
+// and are removed when tracking-param stripping is enabled. Entries ending in
+// "*" match any parameter with that prefix.
+var DefaultTrackingParams = []string{
+	"utm_*",
+	"fbclid",
+	"gclid",
+	"dclid",
+	"msclkid",
+	"mc_cid",
+	"mc_eid",
+	"_ga",
+	"_hsenc",
+	"_hsmi",
+	"ref_src",
+}
+
+// URLNormalizer canonicalizes URLs so that equivalent submissions of the same
+// page (scheme/host case, default ports, trailing slashes, fragments, query
+// order and optionally tracking parameters) share cache and dedup keys.
+type URLNormalizer struct {
+	// StripTrackingParams removes parameters matching TrackingParams.
+	StripTrackingParams bool
+	// TrackingParams overrides DefaultTrackingParams when non-empty.
+	TrackingParams []string
+}
+
+// NewURLNormalizer creates a normalizer using DefaultTrackingParams.
+func NewURLNormalizer(stripTrackingParams bool) *URLNormalizer {
+	return &URLNormalizer{StripTrackingParams: stripTrackingParams}
+}
+
+// Normalize returns the canonical form of rawURL. URLs without a scheme are
+// treated as https, matching Fetcher.Fetch.
+func (n *URLNormalizer) Normalize(rawURL string) (string, error) {
+	u, err := n.parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// Key returns a scheme-insensitive identifier for rawURL suitable for cache
+// and dedup keys, so http://x/ and https://x map to the same entry. Unparseable
+// input falls back to the trimmed raw string.
+func (n *URLNormalizer) Key(rawURL string) string {
+	u, err := n.parse(rawURL)
+	if err != nil {
+		return strings.TrimSpace(rawURL)
+	}
+	u.Scheme = ""
+	return strings.TrimPrefix(u.String(), "//")
+}
+
+func (n *URLNormalizer) parse(rawURL string) (*url.URL, error) {
+	raw := strings.TrimSpace(rawURL)
+	if raw == "" {
+		return nil, cerrors.ValidationError("URL is required")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, cerrors.ValidationError("invalid URL").WithDetails(rawURL).Wrap(err)
+	}
+	if u.Host == "" {
+		return nil, cerrors.ValidationError("URL has no host").WithDetails(rawURL)
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = normalizeHost(u.Scheme, u.Host)
+	u.Fragment = ""
+	u.RawFragment = ""
+	u.User = nil
+
+	if u.Path == "" {
+		u.Path = "/"
+	} else if len(u.Path) > 1 {
+		u.Path = strings.TrimRight(u.Path, "/")
+		if u.Path == "" {
+			u.Path = "/"
+		}
+	}
+	u.RawPath = ""
+
+	u.RawQuery = n.normalizeQuery(u.Query())
+	return u, nil
+}
+
+// normalizeHost lowercases the host and drops the port when it is the scheme default.
+func normalizeHost(scheme, host string) string {
+	host = strings.ToLower(host)
+
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil {
+		return strings.TrimSuffix(host, ".")
+	}
+	hostname = strings.TrimSuffix(hostname, ".")
+
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		if strings.Contains(hostname, ":") {
+			return "[" + hostname + "]"
+		}
+		return hostname
+	}
+	return net.JoinHostPort(hostname, port)
+}
+
+// normalizeQuery sorts parameters (url.Values.Encode orders by key) and
+// removes tracking parameters when enabled.
+func (n *URLNormalizer) normalizeQuery(values url.Values) string {
+	if len(values) == 0 {
+		return ""
+	}
+
+	if n.StripTrackingParams {
+		for key := range values {
+			if n.isTrackingParam(key) {
+				values.Del(key)
+			}
+		}
+	}
+
+	for _, v := range values {
+		sort.Strings(v)
+	}
+	return values.Encode()
+}
+
+func (n *URLNormalizer) isTrackingParam(key string) bool {
+	params := n.TrackingParams
+	if len(params) == 0 {
+		params = DefaultTrackingParams
+	}
+
+	key = strings.ToLower(key)
+	for _, p := range params {
+		p = strings.ToLower(p)
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			if strings.HasPrefix(key, prefix) {
+				return true
+			}
+		} else if key == p {
+			return true
+		}
+	}
+	return false
+}
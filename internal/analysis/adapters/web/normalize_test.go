@@ -0,0 +1,61 @@
+package web
+
+import "testing"
+
+func TestURLNormalizer_Normalize(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+		strip bool
+	}{
+		{name: "lowercases scheme and host", input: "HTTPS://Example.COM/Path", want: "https://example.com/Path", strip: true},
+		{name: "adds root slash", input: "https://example.com", want: "https://example.com/", strip: true},
+		{name: "strips trailing slash", input: "https://example.com/docs/", want: "https://example.com/docs", strip: true},
+		{name: "drops default https port", input: "https://example.com:443/", want: "https://example.com/", strip: true},
+		{name: "drops default http port", input: "http://example.com:80/a", want: "http://example.com/a", strip: true},
+		{name: "keeps non-default port", input: "https://example.com:8443/", want: "https://example.com:8443/", strip: true},
+		{name: "drops fragment", input: "https://example.com/#top", want: "https://example.com/", strip: true},
+		{name: "adds missing scheme", input: "example.com", want: "https://example.com/", strip: true},
+		{name: "sorts query params", input: "https://example.com/?b=2&a=1", want: "https://example.com/?a=1&b=2", strip: true},
+		{name: "strips tracking params", input: "https://example.com/?utm_source=x&id=7&fbclid=abc", want: "https://example.com/?id=7", strip: true},
+		{name: "keeps tracking params when disabled", input: "https://example.com/?utm=1", want: "https://example.com/?utm=1", strip: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewURLNormalizer(tt.strip).Normalize(tt.input)
+			if err != nil {
+				t.Fatalf("Normalize(%q) unexpected error = %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLNormalizer_Key(t *testing.T) {
+	n := NewURLNormalizer(true)
+	n.TrackingParams = []string{"utm"}
+
+	want := n.Key("https://x")
+	for _, input := range []string{"http://x/", "https://x", "https://x/?utm=1", "HTTPS://X:443"} {
+		if got := n.Key(input); got != want {
+			t.Errorf("Key(%q) = %q, want %q", input, got, want)
+		}
+	}
+
+	if n.Key("https://x/?page=2") == want {
+		t.Error("meaningful query params should produce a different key")
+	}
+}
+
+func TestURLNormalizer_Invalid(t *testing.T) {
+	n := NewURLNormalizer(true)
+	for _, input := range []string{"", "   ", "https://"} {
+		if _, err := n.Normalize(input); err == nil {
+			t.Errorf("Normalize(%q) expected error", input)
+		}
+	}
+}
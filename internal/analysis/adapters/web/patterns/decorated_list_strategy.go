@@ -0,0 +1,128 @@
+package patterns
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// decoratedLeadPattern matches bold or plain "label:" leads such as
+// "**Key point:**", "**Key point**:" or "Key point:" at the start of an item.
+var decoratedLeadPattern = regexp.MustCompile(`^(\*\*|__)?[A-Z][^:*_\n]{0,40}?(\*\*|__)?:(\*\*|__)?\s`)
+
+var listMarkerPattern = regexp.MustCompile(`^([-*•+]|\d+[.)])\s+`)
+
+type DecoratedListStrategy struct{}
+
+func NewDecoratedListStrategy() *DecoratedListStrategy {
+	return &DecoratedListStrategy{}
+}
+
+func (s *DecoratedListStrategy) Name() string        { return "decorated_list_items" }
+func (s *DecoratedListStrategy) Category() string    { return "structural" }
+func (s *DecoratedListStrategy) Confidence() float64 { return 0.6 }
+func (s *DecoratedListStrategy) Description() string {
+	return "Detects chat-assistant style emoji bullets with bold-lead labels"
+}
+
+// leadingEmoji reports whether a line opens with a decorative emoji. General
+// punctuation (bullets, dashes) is excluded so plain "•" lists are not counted.
+func leadingEmoji(line string) bool {
+	for _, r := range line {
+		if r >= 0x2000 && r <= 0x206F {
+			return false
+		}
+		return isEmoji(r)
+	}
+	return false
+}
+
+// stripLeadingEmoji removes leading emoji, variation selectors and spacing.
+func stripLeadingEmoji(line string) string {
+	return strings.TrimLeftFunc(line, func(r rune) bool {
+		return (isEmoji(r) && (r < 0x2000 || r > 0x206F)) || r == 0xFE0F || r == 0x200D || unicode.IsSpace(r)
+	})
+}
+
+func (s *DecoratedListStrategy) Detect(content string, wordCount int) *DetectionResult {
+	lines := strings.Split(content, "\n")
+
+	totalLines := 0
+	emojiLeads := 0
+	labelLeads := 0
+	decorated := 0
+	var examples []string
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		totalLines++
+
+		item := listMarkerPattern.ReplaceAllString(trimmed, "")
+		item = strings.TrimLeft(item, "# ")
+
+		hasEmoji := leadingEmoji(item)
+		if hasEmoji {
+			emojiLeads++
+			item = stripLeadingEmoji(item)
+		}
+
+		hasLabel := decoratedLeadPattern.MatchString(item)
+		if hasLabel {
+			labelLeads++
+		}
+
+		if hasEmoji && hasLabel {
+			decorated++
+			if len(examples) < 5 {
+				examples = append(examples, truncateExample(trimmed, 60))
+			}
+		}
+	}
+
+	if totalLines < 4 {
+		return nil
+	}
+
+	decoratedRatio := float64(decorated) / float64(totalLines)
+	emojiRatio := float64(emojiLeads) / float64(totalLines)
+
+	if decorated >= 3 && decoratedRatio >= 0.3 {
+		severity := 0.5 + decoratedRatio
+		if severity > 1.0 {
+			severity = 1.0
+		}
+		return &DetectionResult{
+			Detected: true,
+			Type:     s.Name(),
+			Severity: severity,
+			Description: fmt.Sprintf("Emoji + bold-lead list items dominate the structure (%d of %d items, %.0f%%)",
+				decorated, totalLines, decoratedRatio*100),
+			Examples: examples,
+		}
+	}
+
+	if emojiLeads >= 4 && emojiRatio >= 0.5 {
+		return &DetectionResult{
+			Detected: true,
+			Type:     s.Name(),
+			Severity: 0.5,
+			Description: fmt.Sprintf("Most sections and items open with decorative emoji (%d of %d, %d with label leads)",
+				emojiLeads, totalLines, labelLeads),
+			Examples: []string{fmt.Sprintf("%d emoji-led lines found", emojiLeads)},
+		}
+	}
+
+	return nil
+}
+
+func truncateExample(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen]) + "..."
+}
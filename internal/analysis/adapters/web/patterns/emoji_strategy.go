@@ -2,6 +2,18 @@ package patterns
 
 import (
 	"fmt"
+	"strings"
+)
+
+const (
+	// emojiMinCount is how many emoji a page needs before density matters.
+	emojiMinCount = 5
+	// emojiMaxDensity is the emoji per word above which a page is emoji
+	// heavy: one in fifty words.
+	emojiMaxDensity = 0.02
+	// emojiMinRun is the emoji-in-a-row count that marks a decorative run
+	// ("🚀✨🔥").
+	emojiMinRun = 3
 )
 
 type EmojiStrategy struct{}
@@ -15,59 +27,36 @@ func (s *EmojiStrategy) Category() string    { return "pattern" }
 func (s *EmojiStrategy) Confidence() float64 { return 0.4 }
 func (s *EmojiStrategy) Description() string { return "Detects excessive emoji usage in content" }
 
-func isEmoji(r rune) bool {
-	return r >= 0x1F300 && r <= 0x1F9FF ||
-		r >= 0x2600 && r <= 0x27BF ||
-		r >= 0x1F600 && r <= 0x1F64F ||
-		r >= 0x1F900 && r <= 0x1F9FF ||
-		r >= 0x2300 && r <= 0x23FF ||
-		r >= 0x2000 && r <= 0x206F
-}
-
-func countEmojis(text string) int {
-	count := 0
-	for _, r := range text {
-		if isEmoji(r) {
-			count++
-		}
-	}
-	return count
-}
-
 func (s *EmojiStrategy) Detect(content string, wordCount int) *DetectionResult {
-	emojiCount := countEmojis(content)
-	textLength := len([]rune(content))
-
-	if textLength == 0 || emojiCount == 0 {
+	scan := ScanSymbols(content)
+	if scan.Emoji == 0 || scan.Words == 0 {
 		return &DetectionResult{Detected: false}
 	}
+	density := float64(scan.Emoji) / float64(scan.Words)
 
-	emojiRatio := float64(emojiCount) / float64(textLength)
-
-	if emojiCount >= 5 && emojiRatio > 0.05 {
-		severity := emojiRatio * 100
-		if severity > 1 {
-			severity = 1
-		}
+	dense := scan.Emoji >= emojiMinCount && density > emojiMaxDensity
+	runs := scan.LongestRun >= emojiMinRun
+	if !dense && !runs {
+		return &DetectionResult{Detected: false}
+	}
 
-		return &DetectionResult{
-			Detected:    true,
-			Type:        "emoji_overuse",
-			Severity:    severity,
-			Description: fmt.Sprintf("Excessive emoji usage detected (%d emojis, %.1f%% of content)", emojiCount, emojiRatio*100),
-			Examples:    []string{fmt.Sprintf("Found %d emoji characters", emojiCount)},
-		}
+	// Severity grows with density, reaching 1 at one emoji in every seven
+	// words; runs of emoji alone start it at 0.5.
+	severity := min(0.3+5*density, 1.0)
+	if runs {
+		severity = max(severity, 0.5)
 	}
 
-	if emojiRatio > 0.15 {
-		return &DetectionResult{
-			Detected:    true,
-			Type:        "emoji_overuse",
-			Severity:    0.8,
-			Description: fmt.Sprintf("High emoji density detected (%.1f%% of content)", emojiRatio*100),
-			Examples:    []string{"Text is predominantly emoji characters"},
-		}
+	description := fmt.Sprintf("Excessive emoji usage detected (%d emoji, %.1f per 100 words)", scan.Emoji, density*100)
+	if runs && !dense {
+		description = fmt.Sprintf("Decorative emoji runs detected (up to %d in a row)", scan.LongestRun)
 	}
 
-	return &DetectionResult{Detected: false}
+	return &DetectionResult{
+		Detected:    true,
+		Type:        s.Name(),
+		Severity:    severity,
+		Description: description,
+		Examples:    []string{"Emoji found: " + strings.Join(scan.EmojiRuns, ", ")},
+	}
 }
@@ -18,6 +18,8 @@ func (s *GenericLanguageStrategy) Description() string {
 	return "Detects excessive use of generic business language"
 }
 
+func (s *GenericLanguageStrategy) SupportsLanguage(lang string) bool { return englishOnly(lang) }
+
 func (s *GenericLanguageStrategy) Detect(content string, wordCount int) *DetectionResult {
 	genericTerms := []string{
 		"the user", "the customer", "the client",
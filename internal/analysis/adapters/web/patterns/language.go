@@ -0,0 +1,263 @@
+package patterns
+
+import (
+	"strings"
+	"unicode"
+)
+
+// LanguageEnglish is the language the word-list strategies are written for.
+const LanguageEnglish = "en"
+
+// LanguageSupport is implemented by strategies that only work for some
+// languages, such as those matching English word lists. The registry skips
+// them for content in other languages (see DisableUnsupported), so they are
+// reported as not applicable instead of as a misleading pass.
+type LanguageSupport interface {
+	SupportsLanguage(lang string) bool
+}
+
+// englishOnly reports whether strategies built on English word lists or
+// English syllable rules can judge content in lang. Undetected languages
+// are assumed to be English, as before language detection existed.
+func englishOnly(lang string) bool {
+	return lang == "" || lang == LanguageEnglish
+}
+
+// wordStatistics reports whether statistics calibrated on whitespace-
+// delimited words, such as vocabulary diversity or sentence lengths in
+// words, hold for lang. Segment splits Chinese and Japanese into single
+// characters, which repeat far more often than words and make longer
+// sentences; Thai is not segmented at all.
+func wordStatistics(lang string) bool {
+	return !unsegmented(lang) && lang != "th"
+}
+
+// unsegmented reports whether lang is written without spaces between words
+// in a script Segment splits into characters.
+func unsegmented(lang string) bool {
+	return lang == "ja" || lang == "zh"
+}
+
+// cjkPunctuation maps full-width sentence and clause punctuation to the
+// ASCII marks the strategies split on.
+var cjkPunctuation = map[rune]string{
+	'。': ". ", '．': ". ", '！': "! ", '？': "? ",
+	'、': ", ", '，': ", ", '；': "; ", '：': ": ",
+}
+
+// Segment prepares text in lang for the strategies' whitespace and
+// punctuation based tokenizers. Chinese and Japanese text gets a space
+// before every ideograph and kana, so each counts as a word, and its
+// full-width punctuation becomes ASCII attached to the character before it,
+// as in English; other languages are returned as is.
+func Segment(text, lang string) string {
+	if !unsegmented(lang) {
+		return text
+	}
+
+	var sb strings.Builder
+	sb.Grow(len(text) * 2)
+	afterCJK := false
+	for _, r := range text {
+		if p, ok := cjkPunctuation[r]; ok {
+			sb.WriteString(p)
+			afterCJK = false
+			continue
+		}
+		if isCJK(r) {
+			sb.WriteByte(' ')
+			sb.WriteRune(r)
+			afterCJK = true
+			continue
+		}
+		if afterCJK && !unicode.IsSpace(r) {
+			sb.WriteByte(' ')
+		}
+		sb.WriteRune(r)
+		afterCJK = false
+	}
+	return sb.String()
+}
+
+// CountWords counts the words of text in lang, counting each Chinese or
+// Japanese character as a word.
+func CountWords(text, lang string) int {
+	return len(strings.Fields(Segment(text, lang)))
+}
+
+// isCJK reports whether r is a Han ideograph or kana, counting the
+// prolonged sound mark that lengthens kana.
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) || r == 'ー'
+}
+
+// languageDetectionRunes bounds how much text DetectLanguage reads.
+const languageDetectionRunes = 20000
+
+// minScriptShare is the share of letters a non-Latin script needs for
+// DetectLanguage to attribute the text to it.
+const minScriptShare = 0.3
+
+// minStopwordShare is the share of words that must be stopwords of the best
+// matching Latin-script language for DetectLanguage to pick it. Running text
+// in any of them is roughly a third stopwords.
+const minStopwordShare = 0.1
+
+// scriptLanguages maps non-Latin scripts to the language DetectLanguage
+// reports for them when the page declares no language in that script.
+var scriptLanguages = []struct {
+	script *unicode.RangeTable
+	lang   string
+}{
+	{unicode.Hangul, "ko"},
+	{unicode.Cyrillic, "ru"},
+	{unicode.Arabic, "ar"},
+	{unicode.Hebrew, "he"},
+	{unicode.Greek, "el"},
+	{unicode.Thai, "th"},
+	{unicode.Devanagari, "hi"},
+}
+
+// declaredScripts maps the languages commonly declared in <html lang> to
+// their script, so a declared language is trusted when the text is in it.
+var declaredScripts = map[string]*unicode.RangeTable{
+	"ja": unicode.Han, "zh": unicode.Han, "ko": unicode.Hangul,
+	"ru": unicode.Cyrillic, "uk": unicode.Cyrillic, "bg": unicode.Cyrillic,
+	"sr": unicode.Cyrillic, "mk": unicode.Cyrillic, "be": unicode.Cyrillic, "kk": unicode.Cyrillic,
+	"ar": unicode.Arabic, "fa": unicode.Arabic, "ur": unicode.Arabic,
+	"he": unicode.Hebrew, "yi": unicode.Hebrew,
+	"el": unicode.Greek, "th": unicode.Thai,
+	"hi": unicode.Devanagari, "mr": unicode.Devanagari, "ne": unicode.Devanagari,
+}
+
+// stopwords are frequent function words of the Latin-script languages
+// DetectLanguage tells apart.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "is", "in", "that", "it", "for", "with", "are", "this", "on", "you", "be", "as", "was", "not", "have", "from"},
+	"de": {"der", "die", "und", "das", "ist", "nicht", "ein", "eine", "zu", "den", "mit", "sich", "auf", "für", "von", "auch", "dem", "des", "wir", "sie"},
+	"fr": {"le", "la", "les", "et", "est", "des", "une", "un", "du", "que", "pas", "pour", "dans", "qui", "sur", "avec", "au", "sont", "nous", "vous"},
+	"es": {"el", "la", "los", "las", "y", "es", "que", "de", "en", "por", "una", "un", "con", "para", "del", "se", "no", "lo", "como", "más"},
+	"it": {"il", "la", "che", "e", "di", "è", "non", "per", "una", "un", "del", "della", "sono", "con", "gli", "le", "ma", "anche", "come", "più"},
+	"pt": {"o", "a", "os", "as", "e", "é", "que", "de", "não", "um", "uma", "para", "com", "do", "da", "em", "por", "mais", "se", "são"},
+	"nl": {"de", "het", "een", "en", "is", "van", "niet", "dat", "op", "te", "zijn", "met", "voor", "ook", "die", "er", "maar", "wij", "aan", "als"},
+}
+
+// stopwordLanguages lists the keys of stopwords in the order ties are
+// broken.
+var stopwordLanguages = []string{"en", "de", "fr", "es", "it", "pt", "nl"}
+
+// DetectLanguage guesses the language of text and returns its ISO 639-1
+// code, or "" when it cannot tell. Non-Latin scripts are recognized by their
+// letters (kana marks Japanese, other Han text Chinese), Latin-script text
+// by its share of English, German, French, Spanish, Italian, Portuguese or
+// Dutch stopwords. declared, the page's <html lang> if any, decides between
+// languages sharing a script, is trusted for Latin-script languages outside
+// those seven and is the answer when the text is inconclusive.
+func DetectLanguage(text, declared string) string {
+	declared = primaryLanguage(declared)
+
+	scripts := make(map[*unicode.RangeTable]int)
+	var kana, letters int
+	n := 0
+	for _, r := range text {
+		if n++; n > languageDetectionRunes {
+			break
+		}
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters++
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			kana++
+			scripts[unicode.Han]++
+		case unicode.Is(unicode.Han, r):
+			scripts[unicode.Han]++
+		case unicode.Is(unicode.Latin, r):
+			scripts[unicode.Latin]++
+		default:
+			for _, sl := range scriptLanguages {
+				if unicode.Is(sl.script, r) {
+					scripts[sl.script]++
+					break
+				}
+			}
+		}
+	}
+	if letters == 0 {
+		return declared
+	}
+
+	share := func(script *unicode.RangeTable) float64 {
+		return float64(scripts[script]) / float64(letters)
+	}
+	if share(unicode.Han) >= minScriptShare {
+		if kana > 0 || declared == "ja" {
+			return "ja"
+		}
+		return "zh"
+	}
+	for _, sl := range scriptLanguages {
+		if share(sl.script) >= minScriptShare {
+			if declaredScripts[declared] == sl.script {
+				return declared
+			}
+			return sl.lang
+		}
+	}
+
+	// A declared Latin-script language the stopwords cannot recognize, such
+	// as Swedish, is more likely right than the closest of the seven.
+	if _, known := stopwords[declared]; declared != "" && !known && declaredScripts[declared] == nil {
+		return declared
+	}
+	if lang := detectByStopwords(text); lang != "" {
+		return lang
+	}
+	return declared
+}
+
+// detectByStopwords returns the Latin-script language whose stopwords make
+// up the largest share of text's words, if that share reaches
+// minStopwordShare.
+func detectByStopwords(text string) string {
+	if len(text) > languageDetectionRunes {
+		text = text[:languageDetectionRunes]
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	counts := make(map[string]int, len(words))
+	for _, w := range words {
+		counts[w]++
+	}
+
+	best, bestHits := "", 0
+	for _, lang := range stopwordLanguages {
+		hits := 0
+		for _, w := range stopwords[lang] {
+			hits += counts[w]
+		}
+		if hits > bestHits {
+			best, bestHits = lang, hits
+		}
+	}
+	if float64(bestHits)/float64(len(words)) < minStopwordShare {
+		return ""
+	}
+	return best
+}
+
+// primaryLanguage returns the lowercased primary subtag of a BCP 47 tag,
+// e.g. "pt" for "pt-BR".
+func primaryLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
@@ -0,0 +1,94 @@
+package patterns
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		declared string
+		want     string
+	}{
+		{"english", "The team has been working on this for years, and it is not finished yet.", "", "en"},
+		{"german", "Die Mannschaft hat sich auf das Spiel gefreut, und es ist nicht vorbei.", "", "de"},
+		{"french", "Nous sommes heureux de vous présenter les résultats de notre étude dans le cadre du projet.", "", "fr"},
+		{"spanish", "Los resultados del estudio son muy buenos para el equipo y para la empresa.", "", "es"},
+		{"wrong declaration", "Die Mannschaft hat sich auf das Spiel gefreut, und es ist nicht vorbei.", "en", "de"},
+		{"japanese", "私たちは新しいサービスを開発しています。", "", "ja"},
+		{"chinese", "我们正在开发一项新的服务。", "", "zh"},
+		{"korean", "우리는 새로운 서비스를 개발하고 있습니다.", "", "ko"},
+		{"cyrillic", "Мы разрабатываем новый сервис.", "", "ru"},
+		{"declared cyrillic language", "Ми розробляємо новий сервіс.", "uk-UA", "uk"},
+		{"declared unrecognized latin language", "Vi utvecklar en ny tjänst för våra kunder.", "sv", "sv"},
+		{"inconclusive falls back to the declaration", "Lorem ipsum dolor sit amet.", "pt-BR", "pt"},
+		{"inconclusive", "Lorem ipsum dolor sit amet.", "", ""},
+		{"no letters", "1234 5678", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.text, tt.declared); got != tt.want {
+				t.Errorf("DetectLanguage(%q, %q) = %q, want %q", tt.text, tt.declared, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSegment(t *testing.T) {
+	got := strings.Join(strings.Fields(Segment("新しいAPIです。速い！", "ja")), " ")
+	if want := "新 し い API で す. 速 い!"; got != want {
+		t.Errorf("Segment() = %q, want %q", got, want)
+	}
+	if got := CountWords("新しいAPIです。", "ja"); got != 6 {
+		t.Errorf("CountWords() = %d, want 6 (five characters and one Latin word)", got)
+	}
+
+	text := "Ein ganz normaler Satz."
+	if got := Segment(text, "de"); got != text {
+		t.Errorf("Segment() changed space-delimited text: %q", got)
+	}
+}
+
+func TestWebPatternRegistry_DisableUnsupported(t *testing.T) {
+	englishOnly := []string{
+		"overused_phrases", "generic_language", "boilerplate_text", "missing_nuance",
+		"excessive_transitions", "flesch_readability", "ai_vocabulary", "marketing_tone", "link_text_quality",
+	}
+	wordStats := []string{
+		"perfect_grammar", "repetitive_patterns", "uniform_sentence_length", "lexical_diversity", "ngram_repetition",
+	}
+	names := func(names []string) map[string]bool {
+		set := make(map[string]bool, len(names))
+		for _, n := range names {
+			set[n] = true
+		}
+		return set
+	}
+
+	tests := []struct {
+		lang string
+		want map[string]bool
+	}{
+		{"", map[string]bool{}},
+		{"en", map[string]bool{}},
+		{"de", names(englishOnly)},
+		{"ja", names(append(append([]string{}, englishOnly...), wordStats...))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			registry := NewWebPatternRegistry()
+			before := len(registry.GetStrategies())
+
+			got := names(registry.DisableUnsupported(tt.lang))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DisableUnsupported(%q) = %v, want %v", tt.lang, got, tt.want)
+			}
+			if left := len(registry.GetStrategies()); left != before-len(tt.want) {
+				t.Errorf("%d strategies left, want %d", left, before-len(tt.want))
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package patterns
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	// lexicalWindow is the window size, in tokens, of the moving-average
+	// type-token ratio (MATTR). A fixed window keeps the ratio comparable
+	// across pages of different lengths, which the plain TTR is not.
+	lexicalWindow = 50
+	// lexicalMinTokens is the shortest content worth scoring: four windows.
+	lexicalMinTokens = 4 * lexicalWindow
+	// lexicalLowMATTR is the moving-average ratio below which vocabulary is
+	// unusually repetitive. Ordinary English prose scores about 0.7-0.8.
+	lexicalLowMATTR = 0.6
+	// lexicalMaxSpread is the standard deviation of per-window ratios below
+	// which diversity is suspiciously constant from passage to passage.
+	lexicalMaxSpread = 0.02
+)
+
+// LexicalDiversityStrategy measures vocabulary diversity with the type-token
+// ratio and its moving average over fixed windows. It flags content that
+// reuses an unusually small vocabulary, and content whose diversity stays
+// nearly identical in every passage.
+type LexicalDiversityStrategy struct{}
+
+func NewLexicalDiversityStrategy() *LexicalDiversityStrategy {
+	return &LexicalDiversityStrategy{}
+}
+
+func (s *LexicalDiversityStrategy) Name() string        { return "lexical_diversity" }
+func (s *LexicalDiversityStrategy) Category() string    { return "statistical" }
+func (s *LexicalDiversityStrategy) Confidence() float64 { return 0.4 }
+func (s *LexicalDiversityStrategy) Description() string {
+	return "Detects unusually low or unnaturally constant lexical diversity (type-token ratio)"
+}
+
+func (s *LexicalDiversityStrategy) SupportsLanguage(lang string) bool { return wordStatistics(lang) }
+
+func (s *LexicalDiversityStrategy) Detect(content string, wordCount int) *DetectionResult {
+	tokens := lexicalTokens(content)
+	if len(tokens) < lexicalMinTokens {
+		return nil
+	}
+
+	ttr := typeTokenRatio(tokens)
+	mattr := movingTypeTokenRatio(tokens, lexicalWindow)
+
+	var chunks []float64
+	for start := 0; start+lexicalWindow <= len(tokens); start += lexicalWindow {
+		chunks = append(chunks, typeTokenRatio(tokens[start:start+lexicalWindow]))
+	}
+	spread := stddev(chunks)
+
+	low := mattr < lexicalLowMATTR
+	constant := spread < lexicalMaxSpread
+	if !low && !constant {
+		return nil
+	}
+
+	examples := []string{
+		fmt.Sprintf("Type-token ratio: %.3f over %d tokens", ttr, len(tokens)),
+		fmt.Sprintf("Moving-average TTR (%d-token window): %.3f", lexicalWindow, mattr),
+		fmt.Sprintf("Window TTR spread: %.3f across %d windows", spread, len(chunks)),
+	}
+
+	var severity float64
+	var description string
+	switch {
+	case low && constant:
+		severity = 0.8
+		description = fmt.Sprintf("Low, constant lexical diversity (MATTR %.3f, TTR %.3f, spread %.3f)", mattr, ttr, spread)
+	case low:
+		severity = 0.4 + (lexicalLowMATTR-mattr)/lexicalLowMATTR
+		if severity > 1.0 {
+			severity = 1.0
+		}
+		description = fmt.Sprintf("Unusually repetitive vocabulary (MATTR %.3f, TTR %.3f)", mattr, ttr)
+	default:
+		severity = 0.4
+		description = fmt.Sprintf("Lexical diversity barely varies between passages (MATTR %.3f, TTR %.3f, spread %.3f)", mattr, ttr, spread)
+	}
+
+	return &DetectionResult{
+		Detected:    true,
+		Type:        s.Name(),
+		Severity:    severity,
+		Description: description,
+		Examples:    examples,
+	}
+}
+
+// lexicalTokens lowercases content and splits it into words with the
+// surrounding punctuation removed, so "The" and "the." are one type.
+func lexicalTokens(content string) []string {
+	content = strings.ReplaceAll(strings.ToLower(content), "’", "'")
+	words := strings.FieldsFunc(content, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-'
+	})
+	tokens := words[:0]
+	for _, w := range words {
+		if w = strings.Trim(w, "'-"); w != "" {
+			tokens = append(tokens, w)
+		}
+	}
+	return tokens
+}
+
+func typeTokenRatio(tokens []string) float64 {
+	if len(tokens) == 0 {
+		return 0
+	}
+	types := make(map[string]struct{}, len(tokens))
+	for _, t := range tokens {
+		types[t] = struct{}{}
+	}
+	return float64(len(types)) / float64(len(tokens))
+}
+
+// movingTypeTokenRatio returns the mean type-token ratio of every window of
+// size tokens, sliding one token at a time.
+func movingTypeTokenRatio(tokens []string, size int) float64 {
+	if len(tokens) < size {
+		return typeTokenRatio(tokens)
+	}
+
+	counts := make(map[string]int, size)
+	for _, t := range tokens[:size] {
+		counts[t]++
+	}
+	total := float64(len(counts))
+	windows := 1
+
+	for i := size; i < len(tokens); i++ {
+		out := tokens[i-size]
+		if counts[out]--; counts[out] == 0 {
+			delete(counts, out)
+		}
+		counts[tokens[i]]++
+		total += float64(len(counts))
+		windows++
+	}
+	return total / float64(windows) / float64(size)
+}
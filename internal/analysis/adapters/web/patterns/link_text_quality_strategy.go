@@ -19,6 +19,8 @@ func (s *LinkTextQualityStrategy) Description() string {
 	return "Detects generic or non-descriptive link text"
 }
 
+func (s *LinkTextQualityStrategy) SupportsLanguage(lang string) bool { return englishOnly(lang) }
+
 func (s *LinkTextQualityStrategy) Detect(content string, wordCount int) *DetectionResult {
 	linkRegex := regexp.MustCompile(`<a[^>]*href[^>]*>([^<]+)</a>`)
 	matches := linkRegex.FindAllStringSubmatch(strings.ToLower(content), -1)
@@ -0,0 +1,137 @@
+package patterns
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// markdownMinHits is how many artifacts a page needs before it is
+	// flagged; a single bold sentence or "Here's a summary:" is common in
+	// hand-written copy too.
+	markdownMinHits = 3
+	// markdownSaturation is the artifact count at which severity reaches 1.0.
+	markdownSaturation = 10
+)
+
+// MarkdownArtifact is one kind of chat-assistant markdown left behind in
+// published content, matched line by line.
+type MarkdownArtifact struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// DefaultMarkdownArtifacts are the artifacts NewMarkdownArtifactStrategy
+// looks for when given none. Append to a copy to extend the list.
+var DefaultMarkdownArtifacts = []MarkdownArtifact{
+	{
+		// "**This approach keeps the API small and predictable.**"
+		Name:    "bold sentence",
+		Pattern: regexp.MustCompile(`^(\*\*|__)[A-Z][^*_\n]{15,}[.!?](\*\*|__)$`),
+	},
+	{
+		// "Here's a summary:", "Here is a quick breakdown of the options:"
+		Name:    "summary lead-in",
+		Pattern: regexp.MustCompile(`(?i)^(here['’]s|here is|below is) (a|an|the) (quick |brief |short |high-level )?(summary|overview|breakdown|rundown|recap|comparison|list)\b[^:\n]{0,40}:`),
+	},
+	{
+		// "1. **Understand the problem**:", "**Key point:** ...". The list
+		// marker is optional since extracted <li> text has none.
+		Name:    "bold list lead",
+		Pattern: regexp.MustCompile(`^((\d+[.)]|[-*•+])\s+)?(\*\*|__)[^*_\n]{1,60}?(:(\*\*|__)|(\*\*|__):)`),
+	},
+	{
+		// "## Key Takeaways" rendered as literal text.
+		Name:    "markdown heading",
+		Pattern: regexp.MustCompile(`^#{1,6} \S`),
+	},
+}
+
+// MarkdownArtifactStrategy flags content that still carries the markdown of
+// a chat assistant's answer: whole sentences in bold, "Here's a summary:"
+// lead-ins and list items opening with a bold label. Unlike
+// ExcessiveStructureStrategy it looks at how items are written, not how
+// many lists and headings there are.
+type MarkdownArtifactStrategy struct {
+	artifacts []MarkdownArtifact
+	listLeads []string
+}
+
+// NewMarkdownArtifactStrategy creates a strategy matching artifacts, or
+// DefaultMarkdownArtifacts when none are given.
+func NewMarkdownArtifactStrategy(artifacts ...MarkdownArtifact) *MarkdownArtifactStrategy {
+	if len(artifacts) == 0 {
+		artifacts = DefaultMarkdownArtifacts
+	}
+	return &MarkdownArtifactStrategy{artifacts: artifacts}
+}
+
+// WithListLeads returns a copy of s that also counts leads, the labels of
+// the page's HTML list items that open in bold (web.PageContent's
+// BoldListLeads), as bold list leads. Extracted text loses the bold, so
+// these cannot be matched line by line.
+func (s *MarkdownArtifactStrategy) WithListLeads(leads []string) *MarkdownArtifactStrategy {
+	return &MarkdownArtifactStrategy{artifacts: s.artifacts, listLeads: leads}
+}
+
+func (s *MarkdownArtifactStrategy) Name() string        { return "markdown_artifacts" }
+func (s *MarkdownArtifactStrategy) Category() string    { return "structural" }
+func (s *MarkdownArtifactStrategy) Confidence() float64 { return 0.5 }
+func (s *MarkdownArtifactStrategy) Description() string {
+	return "Detects leftover chat-assistant markdown such as bold sentences, summary lead-ins and bold list leads"
+}
+
+func (s *MarkdownArtifactStrategy) Detect(content string, wordCount int) *DetectionResult {
+	hits := 0
+	kinds := make(map[string]int)
+	var examples []string
+
+	for _, lead := range s.listLeads {
+		hits++
+		kinds["bold list lead"]++
+		if len(examples) < 5 {
+			examples = append(examples, fmt.Sprintf("bold list lead: %q", truncateExample("<strong>"+lead+"</strong>:", 60)))
+		}
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		for _, artifact := range s.artifacts {
+			match := artifact.Pattern.FindString(trimmed)
+			if match == "" {
+				continue
+			}
+			hits++
+			kinds[artifact.Name]++
+			if len(examples) < 5 {
+				examples = append(examples, fmt.Sprintf("%s: %q", artifact.Name, truncateExample(match, 60)))
+			}
+			// One artifact per line; a bold list lead is not also a bold sentence.
+			break
+		}
+	}
+
+	if hits < markdownMinHits {
+		return nil
+	}
+
+	severity := float64(hits) / markdownSaturation
+	if severity < 0.4 {
+		severity = 0.4
+	}
+	if severity > 1.0 {
+		severity = 1.0
+	}
+
+	return &DetectionResult{
+		Detected:    true,
+		Type:        s.Name(),
+		Severity:    severity,
+		Description: fmt.Sprintf("Chat-assistant markdown artifacts found (%d across %d kinds)", hits, len(kinds)),
+		Examples:    examples,
+	}
+}
@@ -0,0 +1,180 @@
+package patterns
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// marketingMinWords keeps short snippets such as a single tagline from
+	// producing a meaningless density.
+	marketingMinWords = 80
+	// marketingMinHits is the smallest number of matches worth reporting.
+	marketingMinHits = 4
+	// marketingThreshold is the density (matches per 100 words) at which
+	// content reads as promotional copy.
+	marketingThreshold = 2.0
+	// marketingSaturation is the density at which severity reaches 1.0.
+	marketingSaturation = 6.0
+)
+
+var marketingSuperlatives = map[string]bool{
+	"best": true, "best-in-class": true, "leading": true, "industry-leading": true,
+	"world-class": true, "revolutionary": true, "groundbreaking": true, "game-changing": true,
+	"seamless": true, "seamlessly": true, "effortless": true, "effortlessly": true,
+	"unparalleled": true, "unmatched": true, "unrivaled": true, "unbeatable": true,
+	"ultimate": true, "premier": true, "cutting-edge": true, "next-generation": true,
+	"state-of-the-art": true, "innovative": true, "powerful": true, "exceptional": true,
+	"incredible": true, "amazing": true, "transformative": true, "supercharge": true,
+}
+
+var marketingCallsToAction = []string{
+	"sign up", "get started", "start your free trial", "free trial", "try it free",
+	"book a demo", "request a demo", "schedule a demo", "contact us today",
+	"buy now", "shop now", "order now", "subscribe today", "join thousands",
+	"don't miss out", "limited time", "act now",
+}
+
+// marketingCTAWords holds marketingCallsToAction split into words, longest
+// first, so "start your free trial" wins over the "free trial" inside it.
+var marketingCTAWords = func() [][]string {
+	phrases := make([][]string, len(marketingCallsToAction))
+	for i, p := range marketingCallsToAction {
+		phrases[i] = strings.Fields(p)
+	}
+	sort.SliceStable(phrases, func(i, j int) bool { return len(phrases[i]) > len(phrases[j]) })
+	return phrases
+}()
+
+// MarketingToneStrategy flags promotional copy: a high density of
+// superlatives and calls-to-action per 100 words.
+type MarketingToneStrategy struct{}
+
+func NewMarketingToneStrategy() *MarketingToneStrategy {
+	return &MarketingToneStrategy{}
+}
+
+func (s *MarketingToneStrategy) Name() string        { return "marketing_tone" }
+func (s *MarketingToneStrategy) Category() string    { return "linguistic" }
+func (s *MarketingToneStrategy) Confidence() float64 { return 0.5 }
+func (s *MarketingToneStrategy) Description() string {
+	return "Detects dense promotional superlatives and calls-to-action"
+}
+
+func (s *MarketingToneStrategy) SupportsLanguage(lang string) bool { return englishOnly(lang) }
+
+func (s *MarketingToneStrategy) Detect(content string, wordCount int) *DetectionResult {
+	words := marketingWords(content)
+	if wordCount <= 0 {
+		wordCount = len(words)
+	}
+	if wordCount < marketingMinWords {
+		return nil
+	}
+
+	counts := make(map[string]int)
+	superlatives := 0
+	for _, w := range words {
+		if marketingSuperlatives[w] {
+			counts[w]++
+			superlatives++
+		}
+	}
+
+	// Match phrases on the word stream so punctuation and line breaks
+	// between words do not hide them. Each word belongs to at most one
+	// phrase, the longest that matches where it starts.
+	ctas := 0
+	for i := 0; i < len(words); {
+		phrase := matchCallToAction(words[i:])
+		if phrase == nil {
+			i++
+			continue
+		}
+		counts[strings.Join(phrase, " ")]++
+		ctas++
+		i += len(phrase)
+	}
+
+	hits := superlatives + ctas
+	density := float64(hits) / float64(wordCount) * 100
+	if hits < marketingMinHits || density < marketingThreshold {
+		return nil
+	}
+
+	severity := density / marketingSaturation
+	if severity > 1.0 {
+		severity = 1.0
+	}
+
+	return &DetectionResult{
+		Detected: true,
+		Type:     s.Name(),
+		Severity: severity,
+		Description: fmt.Sprintf("Promotional tone: %.1f marketing phrases per 100 words (%d superlatives, %d calls-to-action in %d words)",
+			density, superlatives, ctas, wordCount),
+		Examples: topMarketingPhrases(counts, 5),
+	}
+}
+
+// matchCallToAction returns the longest call-to-action words starts with, or
+// nil.
+func matchCallToAction(words []string) []string {
+	for _, phrase := range marketingCTAWords {
+		if len(phrase) > len(words) {
+			continue
+		}
+		matched := true
+		for i, w := range phrase {
+			if words[i] != w {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return phrase
+		}
+	}
+	return nil
+}
+
+// marketingWords lowercases content and splits it into words, keeping
+// hyphens and apostrophes so "world-class" and "don't" stay whole.
+func marketingWords(content string) []string {
+	content = strings.ReplaceAll(strings.ToLower(content), "’", "'")
+	words := strings.FieldsFunc(content, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '\''
+	})
+	kept := words[:0]
+	for _, w := range words {
+		if w = strings.Trim(w, "-'"); w != "" {
+			kept = append(kept, w)
+		}
+	}
+	return kept
+}
+
+// topMarketingPhrases returns up to limit matched phrases, most frequent first.
+func topMarketingPhrases(counts map[string]int, limit int) []string {
+	phrases := make([]string, 0, len(counts))
+	for p := range counts {
+		phrases = append(phrases, p)
+	}
+	sort.Slice(phrases, func(i, j int) bool {
+		if counts[phrases[i]] != counts[phrases[j]] {
+			return counts[phrases[i]] > counts[phrases[j]]
+		}
+		return phrases[i] < phrases[j]
+	})
+	if len(phrases) > limit {
+		phrases = phrases[:limit]
+	}
+
+	examples := make([]string, len(phrases))
+	for i, p := range phrases {
+		examples[i] = fmt.Sprintf("%q ×%d", p, counts[p])
+	}
+	return examples
+}
@@ -0,0 +1,75 @@
+package patterns
+
+import (
+	"fmt"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+const (
+	// DefaultWebNGramCoverage is the share of words inside repeated 3- and
+	// 4-word phrases above which content is flagged. Hand-written pages
+	// rarely pass 15%.
+	DefaultWebNGramCoverage = 0.3
+	// ngramMinWords is the shortest content worth scoring.
+	ngramMinWords = 100
+	// ngramSaturation is how far past the threshold coverage must go for
+	// severity to reach 1.0.
+	ngramSaturation = 0.3
+)
+
+// NGramRepetitionStrategy flags content in which a large share of the words
+// belong to 3- and 4-word phrases that occur more than once, a cheap marker
+// of templated or generated copy.
+type NGramRepetitionStrategy struct {
+	maxCoverage float64
+}
+
+// NewNGramRepetitionStrategy creates a strategy that flags content whose
+// repeated n-gram coverage exceeds maxCoverage (0 uses
+// DefaultWebNGramCoverage).
+func NewNGramRepetitionStrategy(maxCoverage float64) *NGramRepetitionStrategy {
+	if maxCoverage <= 0 || maxCoverage > 1 {
+		maxCoverage = DefaultWebNGramCoverage
+	}
+	return &NGramRepetitionStrategy{maxCoverage: maxCoverage}
+}
+
+func (s *NGramRepetitionStrategy) Name() string        { return "ngram_repetition" }
+func (s *NGramRepetitionStrategy) Category() string    { return "pattern" }
+func (s *NGramRepetitionStrategy) Confidence() float64 { return 0.5 }
+func (s *NGramRepetitionStrategy) Description() string {
+	return "Detects content dominated by repeated 3- and 4-word phrases"
+}
+
+func (s *NGramRepetitionStrategy) SupportsLanguage(lang string) bool { return wordStatistics(lang) }
+
+func (s *NGramRepetitionStrategy) Detect(content string, wordCount int) *DetectionResult {
+	tokens := lexicalTokens(content)
+	if len(tokens) < ngramMinWords {
+		return nil
+	}
+
+	rep := analysis.RepeatedNGrams(tokens, 3, 4, 5)
+	if rep.Coverage <= s.maxCoverage {
+		return nil
+	}
+
+	severity := 0.4 + 0.6*(rep.Coverage-s.maxCoverage)/ngramSaturation
+	if severity > 1.0 {
+		severity = 1.0
+	}
+
+	examples := make([]string, len(rep.Top))
+	for i, p := range rep.Top {
+		examples[i] = fmt.Sprintf("%q ×%d", p.Phrase, p.Count)
+	}
+
+	return &DetectionResult{
+		Detected:    true,
+		Type:        s.Name(),
+		Severity:    severity,
+		Description: fmt.Sprintf("Repeated 3-4 word phrases cover %.0f%% of %d words (threshold %.0f%%)", rep.Coverage*100, rep.Tokens, s.maxCoverage*100),
+		Examples:    examples,
+	}
+}
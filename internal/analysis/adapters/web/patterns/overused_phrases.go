@@ -5,10 +5,21 @@ import (
 	"strings"
 )
 
-type OverusedPhrasesStrategy struct{}
+type OverusedPhrasesStrategy struct {
+	phrases []string
+}
 
 func NewOverusedPhrasesStrategy() *OverusedPhrasesStrategy {
-	return &OverusedPhrasesStrategy{}
+	return &OverusedPhrasesStrategy{phrases: DefaultOverusedPhrases}
+}
+
+// NewOverusedPhrasesStrategyWithPhrases creates the strategy with its own
+// lowercased phrase list; an empty list uses DefaultOverusedPhrases.
+func NewOverusedPhrasesStrategyWithPhrases(phrases []string) *OverusedPhrasesStrategy {
+	if len(phrases) == 0 {
+		return NewOverusedPhrasesStrategy()
+	}
+	return &OverusedPhrasesStrategy{phrases: phrases}
 }
 
 func (s *OverusedPhrasesStrategy) Name() string        { return "overused_phrases" }
@@ -18,50 +29,15 @@ func (s *OverusedPhrasesStrategy) Description() string {
 	return "Detects common AI-generated filler phrases"
 }
 
-func (s *OverusedPhrasesStrategy) Detect(content string, wordCount int) *DetectionResult {
-	phrases := []string{
-		"it is important to note that",
-		"it's worth noting that",
-		"in conclusion",
-		"furthermore",
-		"in today's world",
-		"in today's digital age",
-		"as you know",
-		"the power of",
-		"next level",
-		"best practices",
-		"moving forward",
-		"at the end of the day",
-		"synergy",
-		"leveraging",
-		"paradigm shift",
-		"transformative",
-		"revolutionary",
-		"innovative approach",
-		"seamlessly integrated",
-		"cutting-edge",
-		"state-of-the-art",
-		"game-changing",
-		"it's no secret that",
-		"the fact of the matter is",
-		"at the forefront",
-		"driving innovation",
-		"unlock the potential",
-		"harness the power",
-		"in an ever-changing",
-		"ever-evolving",
-		"comprehensive solution",
-		"holistic approach",
-		"robust solution",
-		"scalable solution",
-	}
+func (s *OverusedPhrasesStrategy) SupportsLanguage(lang string) bool { return englishOnly(lang) }
 
+func (s *OverusedPhrasesStrategy) Detect(content string, wordCount int) *DetectionResult {
 	lowerContent := strings.ToLower(content)
 	count := 0
 	foundExamples := make([]string, 0)
 
-	for _, phrase := range phrases {
-		occurrences := strings.Count(lowerContent, strings.ToLower(phrase))
+	for _, phrase := range s.phrases {
+		occurrences := strings.Count(lowerContent, phrase)
 		if occurrences > 0 {
 			count += occurrences
 			if len(foundExamples) < 5 {
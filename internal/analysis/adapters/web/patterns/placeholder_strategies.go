@@ -65,6 +65,8 @@ func (s *PerfectGrammarStrategy) Description() string {
 	return "Detects suspiciously consistent sentence lengths"
 }
 
+func (s *PerfectGrammarStrategy) SupportsLanguage(lang string) bool { return wordStatistics(lang) }
+
 func (s *PerfectGrammarStrategy) Detect(content string, wordCount int) *DetectionResult {
 	sentences := regexp.MustCompile(`[.!?]+`).Split(content, -1)
 	if len(sentences) < 5 {
@@ -96,10 +98,21 @@ func (s *PerfectGrammarStrategy) Detect(content string, wordCount int) *Detectio
 	return nil
 }
 
-type BoilerplateTextStrategy struct{}
+type BoilerplateTextStrategy struct {
+	phrases []string
+}
 
 func NewBoilerplateTextStrategy() *BoilerplateTextStrategy {
-	return &BoilerplateTextStrategy{}
+	return &BoilerplateTextStrategy{phrases: DefaultBoilerplatePhrases}
+}
+
+// NewBoilerplateTextStrategyWithPhrases creates the strategy with its own
+// lowercased phrase list; an empty list uses DefaultBoilerplatePhrases.
+func NewBoilerplateTextStrategyWithPhrases(phrases []string) *BoilerplateTextStrategy {
+	if len(phrases) == 0 {
+		return NewBoilerplateTextStrategy()
+	}
+	return &BoilerplateTextStrategy{phrases: phrases}
 }
 
 func (s *BoilerplateTextStrategy) Name() string        { return "boilerplate_text" }
@@ -109,19 +122,14 @@ func (s *BoilerplateTextStrategy) Description() string {
 	return "Detects common boilerplate and filler phrases"
 }
 
-func (s *BoilerplateTextStrategy) Detect(content string, wordCount int) *DetectionResult {
-	boilerplateIndicators := []string{
-		"welcome to", "this article", "this post", "in this guide",
-		"let's dive", "let's explore", "let's take a look",
-		"it's important to understand", "by the end of this",
-		"in this section", "as we've seen", "as discussed",
-	}
+func (s *BoilerplateTextStrategy) SupportsLanguage(lang string) bool { return englishOnly(lang) }
 
+func (s *BoilerplateTextStrategy) Detect(content string, wordCount int) *DetectionResult {
 	lowerContent := strings.ToLower(content)
 	count := 0
 	found := make([]string, 0)
 
-	for _, indicator := range boilerplateIndicators {
+	for _, indicator := range s.phrases {
 		if strings.Contains(lowerContent, indicator) {
 			count++
 			if len(found) < 3 {
@@ -160,6 +168,8 @@ func (s *RepetitivePatternsStrategy) Description() string {
 	return "Detects repetitive sentence structures and patterns"
 }
 
+func (s *RepetitivePatternsStrategy) SupportsLanguage(lang string) bool { return wordStatistics(lang) }
+
 func (s *RepetitivePatternsStrategy) Detect(content string, wordCount int) *DetectionResult {
 	sentences := regexp.MustCompile(`[.!?]+\s+`).Split(content, -1)
 	if len(sentences) < 5 {
@@ -208,6 +218,8 @@ func (s *MissingNuanceStrategy) Description() string {
 	return "Detects excessive absolute terms lacking nuance"
 }
 
+func (s *MissingNuanceStrategy) SupportsLanguage(lang string) bool { return englishOnly(lang) }
+
 func (s *MissingNuanceStrategy) Detect(content string, wordCount int) *DetectionResult {
 	absoluteTerms := []string{
 		"always", "never", "all", "none", "every", "completely",
@@ -251,6 +263,8 @@ func (s *ExcessiveTransitionsStrategy) Description() string {
 	return "Detects overuse of transition words and connectors"
 }
 
+func (s *ExcessiveTransitionsStrategy) SupportsLanguage(lang string) bool { return englishOnly(lang) }
+
 func (s *ExcessiveTransitionsStrategy) Detect(content string, wordCount int) *DetectionResult {
 	transitions := []string{
 		"however", "moreover", "furthermore", "additionally",
@@ -302,6 +316,10 @@ func (s *UniformSentenceLengthStrategy) Description() string {
 	return "Detects unnaturally uniform sentence lengths"
 }
 
+func (s *UniformSentenceLengthStrategy) SupportsLanguage(lang string) bool {
+	return wordStatistics(lang)
+}
+
 func (s *UniformSentenceLengthStrategy) Detect(content string, wordCount int) *DetectionResult {
 	sentences := regexp.MustCompile(`[.!?]+`).Split(content, -1)
 	if len(sentences) < 5 {
@@ -346,10 +364,21 @@ func (s *UniformSentenceLengthStrategy) Detect(content string, wordCount int) *D
 	return nil
 }
 
-type AIVocabularyStrategy struct{}
+type AIVocabularyStrategy struct {
+	terms []string
+}
 
 func NewAIVocabularyStrategy() *AIVocabularyStrategy {
-	return &AIVocabularyStrategy{}
+	return &AIVocabularyStrategy{terms: DefaultAIVocabulary}
+}
+
+// NewAIVocabularyStrategyWithTerms creates the strategy with its own
+// lowercased term list; an empty list uses DefaultAIVocabulary.
+func NewAIVocabularyStrategyWithTerms(terms []string) *AIVocabularyStrategy {
+	if len(terms) == 0 {
+		return NewAIVocabularyStrategy()
+	}
+	return &AIVocabularyStrategy{terms: terms}
 }
 
 func (s *AIVocabularyStrategy) Name() string        { return "ai_vocabulary" }
@@ -359,20 +388,14 @@ func (s *AIVocabularyStrategy) Description() string {
 	return "Detects AI-characteristic vocabulary and word choices"
 }
 
-func (s *AIVocabularyStrategy) Detect(content string, wordCount int) *DetectionResult {
-	aiVocab := []string{
-		"delve into", "delve deeper", "navigating", "landscape",
-		"realm", "tapestry", "intricacies", "nuances",
-		"unveil", "unravel", "embark", "journey",
-		"paramount", "crucial", "pivotal", "vital",
-		"plethora", "myriad", "multifaceted",
-	}
+func (s *AIVocabularyStrategy) SupportsLanguage(lang string) bool { return englishOnly(lang) }
 
+func (s *AIVocabularyStrategy) Detect(content string, wordCount int) *DetectionResult {
 	lowerContent := strings.ToLower(content)
 	count := 0
 	found := make([]string, 0)
 
-	for _, term := range aiVocab {
+	for _, term := range s.terms {
 		if strings.Contains(lowerContent, term) {
 			count++
 			if len(found) < 5 {
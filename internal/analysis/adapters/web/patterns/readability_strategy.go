@@ -0,0 +1,198 @@
+package patterns
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// readabilityMinSentences keeps short pages, where one odd sentence
+	// swings the score, from being scored at all.
+	readabilityMinSentences = 10
+	// readabilityWindow is how many sentences each windowed score covers.
+	readabilityWindow = 5
+	// readabilityMinWindows is how many windows the spread needs to mean
+	// anything.
+	readabilityMinWindows = 3
+	// readabilityMaxSpread is the standard deviation of windowed Reading Ease
+	// scores below which the readability is considered uniform. Human prose
+	// usually drifts by 15 points or more between passages.
+	readabilityMaxSpread = 6.0
+	// readabilityBandLow and readabilityBandHigh bound the Reading Ease band
+	// that generated prose gravitates toward: polished, "college level" text
+	// with long words but no very long sentences. The grade bounds keep out
+	// plain text that only lands in the band through a few long sentences.
+	readabilityBandLow   = 30.0
+	readabilityBandHigh  = 50.0
+	readabilityGradeLow  = 11.0
+	readabilityGradeHigh = 16.0
+)
+
+var readabilitySentenceSplit = regexp.MustCompile(`[.!?]+(\s+|$)`)
+
+// ReadabilityStrategy scores content with the Flesch Reading Ease and
+// Flesch-Kincaid grade level. It flags readability that barely changes from
+// passage to passage, and content sitting in the narrow band typical of
+// generated prose; either alone is a weak signal, both together a stronger
+// one.
+type ReadabilityStrategy struct{}
+
+func NewReadabilityStrategy() *ReadabilityStrategy {
+	return &ReadabilityStrategy{}
+}
+
+func (s *ReadabilityStrategy) Name() string        { return "flesch_readability" }
+func (s *ReadabilityStrategy) Category() string    { return "statistical" }
+func (s *ReadabilityStrategy) Confidence() float64 { return 0.4 }
+func (s *ReadabilityStrategy) Description() string {
+	return "Detects uniform Flesch readability or scores in the band typical of generated prose"
+}
+
+func (s *ReadabilityStrategy) SupportsLanguage(lang string) bool { return englishOnly(lang) }
+
+func (s *ReadabilityStrategy) Detect(content string, wordCount int) *DetectionResult {
+	sentences := readabilitySentences(content)
+	if len(sentences) < readabilityMinSentences {
+		return nil
+	}
+
+	overall := scoreReadability(sentences)
+	if overall.words == 0 {
+		return nil
+	}
+	ease, grade := overall.ease(), overall.grade()
+
+	var windows []float64
+	for start := 0; start+readabilityWindow <= len(sentences); start += readabilityWindow {
+		if w := scoreReadability(sentences[start : start+readabilityWindow]); w.words > 0 {
+			windows = append(windows, w.ease())
+		}
+	}
+	spread := stddev(windows)
+
+	uniform := len(windows) >= readabilityMinWindows && spread < readabilityMaxSpread
+	inBand := ease >= readabilityBandLow && ease <= readabilityBandHigh &&
+		grade >= readabilityGradeLow && grade <= readabilityGradeHigh
+
+	if !uniform && !inBand {
+		return nil
+	}
+
+	examples := []string{
+		fmt.Sprintf("Flesch Reading Ease: %.1f", ease),
+		fmt.Sprintf("Flesch-Kincaid grade: %.1f", grade),
+	}
+	if len(windows) >= readabilityMinWindows {
+		examples = append(examples, fmt.Sprintf("Reading Ease spread: %.1f across %d passages of %d sentences", spread, len(windows), readabilityWindow))
+	}
+
+	var severity float64
+	var description string
+	switch {
+	case uniform && inBand:
+		severity = 0.8
+		description = fmt.Sprintf("Uniform readability in the generated-prose band (Reading Ease %.1f, grade %.1f, spread %.1f)", ease, grade, spread)
+	case uniform:
+		severity = 0.5 + 0.3*(1-spread/readabilityMaxSpread)
+		description = fmt.Sprintf("Readability barely varies between passages (Reading Ease spread %.1f across %d passages)", spread, len(windows))
+	default:
+		severity = 0.3
+		description = fmt.Sprintf("Readability sits in the band typical of generated prose (Reading Ease %.1f, grade %.1f)", ease, grade)
+	}
+
+	return &DetectionResult{
+		Detected:    true,
+		Type:        s.Name(),
+		Severity:    severity,
+		Description: description,
+		Examples:    examples,
+	}
+}
+
+type readabilityCounts struct {
+	sentences, words, syllables int
+}
+
+// ease returns the Flesch Reading Ease score (higher is easier).
+func (c readabilityCounts) ease() float64 {
+	return 206.835 - 1.015*float64(c.words)/float64(c.sentences) - 84.6*float64(c.syllables)/float64(c.words)
+}
+
+// grade returns the Flesch-Kincaid grade level.
+func (c readabilityCounts) grade() float64 {
+	return 0.39*float64(c.words)/float64(c.sentences) + 11.8*float64(c.syllables)/float64(c.words) - 15.59
+}
+
+func scoreReadability(sentences []string) readabilityCounts {
+	c := readabilityCounts{sentences: len(sentences)}
+	for _, sentence := range sentences {
+		for _, w := range readabilityWords(sentence) {
+			c.words++
+			c.syllables += countSyllables(w)
+		}
+	}
+	return c
+}
+
+// readabilitySentences splits content into sentences of at least three
+// words, dropping headings, labels and other fragments.
+func readabilitySentences(content string) []string {
+	var sentences []string
+	for _, line := range strings.Split(content, "\n") {
+		for _, part := range readabilitySentenceSplit.Split(line, -1) {
+			if len(readabilityWords(part)) >= 3 {
+				sentences = append(sentences, part)
+			}
+		}
+	}
+	return sentences
+}
+
+func readabilityWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+}
+
+// countSyllables estimates the syllables in an English word by counting
+// vowel groups, discounting a silent trailing "e". Every word has at least
+// one.
+func countSyllables(word string) int {
+	word = strings.Trim(word, "'")
+	count := 0
+	prevVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+	if strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") && count > 1 {
+		count--
+	}
+	if count == 0 {
+		count = 1
+	}
+	return count
+}
+
+func stddev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	mean := 0.0
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+
+	variance := 0.0
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	return math.Sqrt(variance / float64(len(values)))
+}
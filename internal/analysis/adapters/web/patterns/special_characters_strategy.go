@@ -5,6 +5,15 @@ import (
 	"strings"
 )
 
+const (
+	// decorativeMinCount is how many decorative symbols a page needs before
+	// their density matters.
+	decorativeMinCount = 5
+	// decorativeMaxDensity is the decorative symbols per word above which a
+	// page is flagged: one in fifty words.
+	decorativeMaxDensity = 0.02
+)
+
 type SpecialCharactersStrategy struct{}
 
 func NewSpecialCharactersStrategy() *SpecialCharactersStrategy {
@@ -34,6 +43,21 @@ func (s *SpecialCharactersStrategy) Detect(content string, wordCount int) *Detec
 		return &DetectionResult{Detected: false}
 	}
 
+	// Decorative Unicode (arrows, box drawing, "bold" math letters) is
+	// checked first: it is the clearest sign of formatting-for-show.
+	if scan := ScanSymbols(content); scan.Decorative >= decorativeMinCount && scan.Words > 0 {
+		density := float64(scan.Decorative) / float64(scan.Words)
+		if density > decorativeMaxDensity {
+			return &DetectionResult{
+				Detected:    true,
+				Type:        "special_characters",
+				Severity:    min(0.4+5*density, 1.0),
+				Description: fmt.Sprintf("Decorative Unicode symbols detected (%d symbols, %.1f per 100 words)", scan.Decorative, density*100),
+				Examples:    []string{"Symbols found: " + strings.Join(scan.Symbols, " ")},
+			}
+		}
+	}
+
 	hyphenCount := countCharacter(content, '-')
 	asteriskCount := countCharacter(content, '*')
 	underscoreCount := countCharacter(content, '_')
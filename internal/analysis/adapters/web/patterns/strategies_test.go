@@ -1,6 +1,8 @@
 package patterns
 
 import (
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -365,3 +367,419 @@ func TestHardcodedValuesStrategy(t *testing.T) {
 		})
 	}
 }
+
+func TestDecoratedListStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		detected bool
+	}{
+		{
+			name: "emoji bold-lead items",
+			content: "🚀 Getting Started\n" +
+				"✅ **Fast setup:** Install in seconds\n" +
+				"📌 **Key point:** Everything is configurable\n" +
+				"🔒 **Security:** Secrets never leave your machine\n" +
+				"💡 **Pro tip:** Use the CLI for automation",
+			detected: true,
+		},
+		{
+			name: "emoji-led sections without labels",
+			content: "🚀 Getting started\n" +
+				"📦 Installation steps\n" +
+				"🔧 Configuration options\n" +
+				"📚 Further reading",
+			detected: true,
+		},
+		{
+			name: "plain bullets",
+			content: "- Install the package\n" +
+				"- Configure the server\n" +
+				"- Run the tests\n" +
+				"- Deploy to production",
+			detected: false,
+		},
+		{
+			name:     "too few lines",
+			content:  "✅ **Done:** shipped\n📌 **Note:** later",
+			detected: false,
+		},
+	}
+
+	s := NewDecoratedListStrategy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Detect(tt.content, len(strings.Fields(tt.content)))
+			got := result != nil && result.Detected
+			if got != tt.detected {
+				t.Errorf("Detect() detected = %v, want %v", got, tt.detected)
+			}
+		})
+	}
+}
+
+func TestMarkdownArtifactStrategy(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		detected bool
+	}{
+		{
+			name: "pasted assistant answer",
+			content: "Here's a summary of the key steps:\n" +
+				"1. **Understand the problem**: read the ticket twice\n" +
+				"2. **Plan the approach**: sketch the data flow\n" +
+				"**Key point:** keep each step small\n" +
+				"**Following these steps will make every release predictable.**",
+			detected: true,
+		},
+		{
+			name: "hand-written copy",
+			content: "We started this project in 2019 to scratch our own itch.\n" +
+				"Here is what we learned: small releases beat big ones.\n" +
+				"**Note:** the API is still changing.\n" +
+				"- Install the package\n- Run the tests",
+			detected: false,
+		},
+	}
+
+	s := NewMarkdownArtifactStrategy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Detect(tt.content, len(strings.Fields(tt.content)))
+			got := result != nil && result.Detected
+			if got != tt.detected {
+				t.Fatalf("Detect() detected = %v, want %v", got, tt.detected)
+			}
+			if got && len(result.Examples) == 0 {
+				t.Error("Detect() should report the matched artifacts as examples")
+			}
+		})
+	}
+
+	t.Run("html list leads", func(t *testing.T) {
+		content := "Speed: pages load in under a second\nSafety: every change is reviewed\nScale: grows with your team"
+		if result := s.Detect(content, 18); result != nil && result.Detected {
+			t.Fatalf("Detect() = %+v, want nothing from plain extracted text", result)
+		}
+		result := s.WithListLeads([]string{"Speed", "Safety", "Scale"}).Detect(content, 18)
+		if result == nil || !result.Detected || result.Examples[0] != `bold list lead: "<strong>Speed</strong>:"` {
+			t.Fatalf("Detect() = %+v, want the page's bold list leads", result)
+		}
+	})
+
+	t.Run("custom artifacts", func(t *testing.T) {
+		artifacts := append([]MarkdownArtifact{
+			{Name: "sign-off", Pattern: regexp.MustCompile(`(?i)^i hope this helps`)},
+		}, DefaultMarkdownArtifacts...)
+		content := "I hope this helps!\n**Key point:** ship it\n## Next Steps"
+		result := NewMarkdownArtifactStrategy(artifacts...).Detect(content, 9)
+		if result == nil || !strings.HasPrefix(result.Examples[0], "sign-off: ") {
+			t.Fatalf("Detect() = %+v, want the custom artifact reported first", result)
+		}
+	})
+}
+
+func TestMarketingToneStrategy(t *testing.T) {
+	filler := strings.Repeat("The team reviewed the quarterly numbers and wrote a short summary for the board. ", 24)
+	promo := "Our revolutionary, seamless platform is the best way to work. " +
+		"Get started with the industry-leading, world-class toolkit today. " +
+		"Start your free trial now and see the best results. Sign up - don't miss out! "
+
+	tests := []struct {
+		name     string
+		content  string
+		detected bool
+	}{
+		{name: "promotional copy", content: promo + filler, detected: true},
+		{name: "plain prose", content: filler, detected: false},
+		{name: "too short", content: promo, detected: false},
+		{name: "a single superlative", content: "This is the best option we found. " + filler, detected: false},
+	}
+
+	s := NewMarketingToneStrategy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Detect(tt.content, len(strings.Fields(tt.content)))
+			got := result != nil && result.Detected
+			if got != tt.detected {
+				t.Errorf("Detect() detected = %v, want %v", got, tt.detected)
+			}
+		})
+	}
+
+	result := s.Detect(promo+filler, len(strings.Fields(promo+filler)))
+	if result == nil {
+		t.Fatal("Detect() returned nil for promotional copy")
+	}
+	if len(result.Examples) == 0 || result.Examples[0] != `"best" ×2` {
+		t.Errorf("Examples = %v, want most frequent phrase first", result.Examples)
+	}
+	// promo has 6 superlatives and 4 calls-to-action; "free trial" inside
+	// "start your free trial" is not a fifth.
+	if !strings.Contains(result.Description, "6 superlatives, 4 calls-to-action") {
+		t.Errorf("Description = %q, want overlapping phrases counted once", result.Description)
+	}
+	for _, ex := range result.Examples {
+		if strings.HasPrefix(ex, `"free trial"`) {
+			t.Errorf("Examples = %v, want only the longest overlapping phrase", result.Examples)
+		}
+	}
+
+	denser := s.Detect(promo+promo+filler, len(strings.Fields(promo+promo+filler)))
+	if denser == nil || denser.Severity <= result.Severity {
+		t.Errorf("severity should grow with density: %v vs %v", denser, result.Severity)
+	}
+}
+
+func TestWebPatternRegistry_Disable(t *testing.T) {
+	registry := NewWebPatternRegistry()
+	before := len(registry.GetStrategies())
+
+	registry.Disable(map[string]bool{"overused_phrases": true, "emoji_overuse": true})
+
+	if got := len(registry.GetStrategies()); got != before-2 {
+		t.Errorf("strategies after Disable = %d, want %d", got, before-2)
+	}
+	for _, s := range registry.GetStrategies() {
+		if s.Name() == "overused_phrases" || s.Name() == "emoji_overuse" {
+			t.Errorf("disabled strategy %s still registered", s.Name())
+		}
+	}
+	for _, r := range registry.DetectAllWithPassed(strings.Repeat("word ", 100), 100) {
+		if r.Type == "overused_phrases" {
+			t.Error("disabled strategy still produced a result")
+		}
+	}
+}
+
+func TestCountSyllables(t *testing.T) {
+	tests := map[string]int{
+		"cat":           1,
+		"make":          1,
+		"table":         2,
+		"readability":   5,
+		"comprehensive": 4,
+		"rhythm":        1,
+		"don't":         1,
+	}
+	for word, want := range tests {
+		if got := countSyllables(word); got != want {
+			t.Errorf("countSyllables(%q) = %d, want %d", word, got, want)
+		}
+	}
+}
+
+func TestReadabilityStrategy(t *testing.T) {
+	generated := strings.Repeat("Our comprehensive platform delivers innovative solutions for modern organizations. "+
+		"Additionally, it facilitates seamless collaboration across distributed teams. ", 8)
+	human := "I went to the shop. It was shut. So I walked home in the rain and got soaked. " +
+		"The dog was happy to see me. We sat by the fire for a bit. " +
+		"Apparently the municipal authorities had decided, without any consultation whatsoever, that independent retailers operating within the historic district would henceforth require additional licensing documentation. " +
+		"Nobody told us. Not one letter came. My neighbour, who has run the bakery on the corner for thirty years, found out from a newspaper. " +
+		"She laughed. Then she cried a little. We had tea. " +
+		"Bureaucratic communication failures of this magnitude inevitably generate considerable frustration among affected communities, particularly elderly proprietors. " +
+		"Still, it was a good day. The sun came out at six."
+
+	s := NewReadabilityStrategy()
+
+	t.Run("generated prose", func(t *testing.T) {
+		result := s.Detect(generated, len(strings.Fields(generated)))
+		if result == nil || !result.Detected {
+			t.Fatal("Detect() did not flag uniform, dense prose")
+		}
+		joined := strings.Join(result.Examples, "; ")
+		if !strings.Contains(joined, "Flesch Reading Ease:") || !strings.Contains(joined, "Flesch-Kincaid grade:") {
+			t.Errorf("Examples = %v, want the computed scores", result.Examples)
+		}
+	})
+
+	t.Run("varied human prose", func(t *testing.T) {
+		if result := s.Detect(human, len(strings.Fields(human))); result != nil && result.Detected {
+			t.Errorf("Detect() flagged varied prose: %s (%v)", result.Description, result.Examples)
+		}
+	})
+
+	t.Run("too few sentences", func(t *testing.T) {
+		short := "Our comprehensive platform delivers innovative solutions. It facilitates seamless collaboration."
+		if result := s.Detect(short, len(strings.Fields(short))); result != nil {
+			t.Errorf("Detect() = %+v, want nil for tiny input", result)
+		}
+	})
+}
+
+func TestLexicalTokens(t *testing.T) {
+	got := lexicalTokens(`The cat saw "the." dog—THE end! Don't stop; well-known.`)
+	want := []string{"the", "cat", "saw", "the", "dog", "the", "end", "don't", "stop", "well-known"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("lexicalTokens() = %v, want %v", got, want)
+	}
+}
+
+func TestLexicalDiversityStrategy(t *testing.T) {
+	repetitive := strings.Repeat("The solution helps the team and the solution helps the business grow. ", 20)
+	human := "I went down to the harbour early, before the fishing boats came back, because my uncle had promised to show me how he mended nets. " +
+		"He was late. Of course he was late; he always is. While I waited, a woman selling coffee from a van told me about the storm of 1987, " +
+		"when half the pier washed away and nobody could reach the lighthouse for three days. Her father had been out there, alone, keeping the lamp lit with paraffin. " +
+		"When my uncle finally arrived he smelled of diesel and oranges. We sat on upturned crates. His fingers moved quickly, looping twine through the torn mesh, " +
+		"and he muttered the names of knots as if they were old friends: bowline, clove hitch, sheet bend. I kept losing count. Gulls screamed overhead. " +
+		"A boy on a bicycle skidded past and nearly went into the water. By noon my hands were raw and the net looked worse than before, " +
+		"but my uncle said it was a decent first try, which from him is high praise indeed. Afterwards we bought chips, ate them on the sea wall, " +
+		"and watched a container ship crawl along the horizon toward Rotterdam. Tomorrow, he says, we will paint the hull, if the weather holds and his knee behaves."
+
+	s := NewLexicalDiversityStrategy()
+
+	t.Run("repetitive vocabulary", func(t *testing.T) {
+		result := s.Detect(repetitive, len(strings.Fields(repetitive)))
+		if result == nil || !result.Detected {
+			t.Fatal("Detect() did not flag repetitive vocabulary")
+		}
+		if !strings.Contains(result.Description, "TTR") {
+			t.Errorf("Description = %q, want the computed ratio", result.Description)
+		}
+	})
+
+	t.Run("varied human prose", func(t *testing.T) {
+		if result := s.Detect(human, len(strings.Fields(human))); result != nil && result.Detected {
+			t.Errorf("Detect() flagged varied prose: %s (%v)", result.Description, result.Examples)
+		}
+	})
+
+	t.Run("short content", func(t *testing.T) {
+		short := strings.Repeat("the same words again ", 10)
+		if result := s.Detect(short, len(strings.Fields(short))); result != nil {
+			t.Errorf("Detect() = %+v, want nil for short content", result)
+		}
+	})
+}
+
+func TestNGramRepetitionStrategy(t *testing.T) {
+	templated := strings.Repeat("Our platform helps your team move faster. Our platform helps your business grow. "+
+		"With our platform you can scale with confidence. ", 6)
+	human := "I went down to the harbour early, before the fishing boats came back, because my uncle had promised to show me how he mended nets. " +
+		"He was late. Of course he was late; he always is. While I waited, a woman selling coffee from a van told me about the storm of 1987, " +
+		"when half the pier washed away and nobody could reach the lighthouse for three days. Her father had been out there, alone, keeping the lamp lit with paraffin. " +
+		"When my uncle finally arrived he smelled of diesel and oranges. We sat on upturned crates and he muttered the names of knots as if they were old friends."
+
+	s := NewNGramRepetitionStrategy(0)
+
+	result := s.Detect(templated, len(strings.Fields(templated)))
+	if result == nil || !result.Detected {
+		t.Fatal("Detect() did not flag templated copy")
+	}
+	if len(result.Examples) == 0 || !strings.Contains(result.Examples[0], "our platform") {
+		t.Errorf("Examples = %v, want the top repeated phrases", result.Examples)
+	}
+
+	if result := s.Detect(human, len(strings.Fields(human))); result != nil {
+		t.Errorf("Detect() flagged hand-written prose: %s", result.Description)
+	}
+
+	short := "Our platform helps. Our platform helps."
+	if result := s.Detect(short, 6); result != nil {
+		t.Errorf("Detect() = %+v, want nil for short content", result)
+	}
+}
+
+func TestWebPatternRegistry_Replace(t *testing.T) {
+	registry := NewWebPatternRegistry()
+	before := len(registry.GetStrategies())
+
+	if !registry.Replace(NewNGramRepetitionStrategy(0.9)) {
+		t.Fatal("Replace() did not find ngram_repetition")
+	}
+	if got := len(registry.GetStrategies()); got != before {
+		t.Errorf("Replace() changed the strategy count: %d, want %d", got, before)
+	}
+	for _, s := range registry.GetStrategies() {
+		if ngram, ok := s.(*NGramRepetitionStrategy); ok && ngram.maxCoverage != 0.9 {
+			t.Errorf("maxCoverage = %v, want 0.9", ngram.maxCoverage)
+		}
+	}
+	if registry.Replace(NewCustomPatternStrategy("not_registered", nil, 1)) {
+		t.Error("Replace() of an unregistered strategy reported success")
+	}
+}
+
+func TestScanSymbols(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		emoji      int
+		decorative int
+		longestRun int
+		runs       []string
+	}{
+		{"plain text", "Nothing to see here", 0, 0, 0, nil},
+		{"punctuation is not emoji", "Fast — really “fast” • simple…", 0, 0, 0, nil},
+		{"single emoji", "Ship it 🚀 today", 1, 0, 1, []string{"🚀"}},
+		{"zwj family counts once", "Our team 👨‍👩‍👧 grows", 1, 0, 1, []string{"👨‍👩‍👧"}},
+		{"skin tone counts once", "Thanks 👍🏽", 1, 0, 1, []string{"👍🏽"}},
+		{"variation selector", "Done ✔️ now", 1, 0, 1, []string{"✔️"}},
+		{"run with spaces", "Launch 🚀 ✨  🔥 now, then 🎉", 4, 0, 3, []string{"🚀 ✨ 🔥", "🎉"}},
+		{"decorative symbols", "→ Step one ▶ step two ① 𝐁𝐨𝐥𝐝", 0, 7, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scan := ScanSymbols(tt.text)
+			if scan.Emoji != tt.emoji || scan.Decorative != tt.decorative || scan.LongestRun != tt.longestRun {
+				t.Errorf("ScanSymbols() = emoji %d, decorative %d, longest run %d; want %d, %d, %d",
+					scan.Emoji, scan.Decorative, scan.LongestRun, tt.emoji, tt.decorative, tt.longestRun)
+			}
+			if strings.Join(scan.EmojiRuns, "|") != strings.Join(tt.runs, "|") {
+				t.Errorf("EmojiRuns = %q, want %q", scan.EmojiRuns, tt.runs)
+			}
+		})
+	}
+}
+
+func TestEmojiStrategy(t *testing.T) {
+	prose := strings.Repeat("We build reliable tools for teams who ship software every day. ", 20)
+	tests := []struct {
+		name     string
+		content  string
+		detected bool
+	}{
+		{"no emoji", prose, false},
+		{"a few emoji in long prose", prose + "Thanks 🙏 and enjoy 🎉", false},
+		{"emoji heavy", "🚀 Fast. ✨ Simple. 🔥 Powerful. 💡 Smart. 🎯 Focused. Try it today!", true},
+		{"decorative run", prose + "Get started now 🚀✨🔥", true},
+	}
+
+	s := NewEmojiStrategy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := s.Detect(tt.content, len(strings.Fields(tt.content)))
+			if result.Detected != tt.detected {
+				t.Fatalf("Detect() detected = %v, want %v (%s)", result.Detected, tt.detected, result.Description)
+			}
+			if result.Detected && (len(result.Examples) == 0 || !strings.HasPrefix(result.Examples[0], "Emoji found: ")) {
+				t.Errorf("Examples = %q, want the emoji found", result.Examples)
+			}
+		})
+	}
+
+	sparse := s.Detect(strings.Repeat("word ", 200)+"🚀 ✨ 🔥 💡 🎯", 205)
+	dense := s.Detect("🚀 Fast. ✨ Simple. 🔥 Powerful. 💡 Smart. 🎯 Focused.", 10)
+	if !(dense.Severity > sparse.Severity) {
+		t.Errorf("Severity dense = %v, sparse = %v, want severity to grow with density", dense.Severity, sparse.Severity)
+	}
+}
+
+func TestSpecialCharactersStrategy_Decorative(t *testing.T) {
+	s := NewSpecialCharactersStrategy()
+
+	decorated := "→ 𝐅𝐚𝐬𝐭 setup ▶ zero config ① install ② run ③ profit"
+	result := s.Detect(decorated, len(strings.Fields(decorated)))
+	if !result.Detected {
+		t.Fatal("Detect() missed decorative Unicode symbols")
+	}
+	if len(result.Examples) == 0 || !strings.Contains(result.Examples[0], "→") {
+		t.Errorf("Examples = %q, want the symbols found", result.Examples)
+	}
+
+	plain := strings.Repeat("Install the package and run it. ", 20) + "Then open Settings → Profile."
+	if result := s.Detect(plain, len(strings.Fields(plain))); result.Detected {
+		t.Errorf("Detect() flagged one arrow in long prose: %s", result.Description)
+	}
+}
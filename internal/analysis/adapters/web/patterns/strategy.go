@@ -1,5 +1,7 @@
 package patterns
 
+import "time"
+
 // WebPatternStrategy is the interface for all web-based detection strategies.
 // Each strategy must provide metadata (Category, Confidence, Description)
 // in addition to its detection logic.
@@ -17,6 +19,9 @@ type DetectionResult struct {
 	Severity    float64
 	Description string
 	Examples    []string
+	// Duration is how long the strategy's Detect took; DetectAllWithPassed
+	// sets it.
+	Duration time.Duration
 }
 
 type WebPatternRegistry struct {
@@ -46,8 +51,14 @@ func (r *WebPatternRegistry) RegisterDefaults() {
 	r.Register(NewMissingNuanceStrategy())
 	r.Register(NewExcessiveTransitionsStrategy())
 	r.Register(NewUniformSentenceLengthStrategy())
+	r.Register(NewReadabilityStrategy())
+	r.Register(NewLexicalDiversityStrategy())
+	r.Register(NewNGramRepetitionStrategy(0))
 	r.Register(NewAIVocabularyStrategy())
+	r.Register(NewMarketingToneStrategy())
 	r.Register(NewEmojiStrategy())
+	r.Register(NewDecoratedListStrategy())
+	r.Register(NewMarkdownArtifactStrategy())
 	r.Register(NewSpecialCharactersStrategy())
 	r.Register(NewMissingAltTextStrategy())
 	r.Register(NewSemanticHTMLStrategy())
@@ -59,6 +70,45 @@ func (r *WebPatternRegistry) RegisterDefaults() {
 	r.Register(NewGenericStylingStrategy())
 }
 
+// Disable removes the strategies whose names are in disabled.
+func (r *WebPatternRegistry) Disable(disabled map[string]bool) {
+	kept := make([]WebPatternStrategy, 0, len(r.strategies))
+	for _, s := range r.strategies {
+		if !disabled[s.Name()] {
+			kept = append(kept, s)
+		}
+	}
+	r.strategies = kept
+}
+
+// DisableUnsupported removes the strategies that implement LanguageSupport
+// and do not support lang, returning their names in registration order.
+func (r *WebPatternRegistry) DisableUnsupported(lang string) []string {
+	kept := make([]WebPatternStrategy, 0, len(r.strategies))
+	var removed []string
+	for _, s := range r.strategies {
+		if ls, ok := s.(LanguageSupport); ok && !ls.SupportsLanguage(lang) {
+			removed = append(removed, s.Name())
+			continue
+		}
+		kept = append(kept, s)
+	}
+	r.strategies = kept
+	return removed
+}
+
+// Replace swaps the registered strategy with the same name for strategy,
+// e.g. to apply a configured threshold. It reports whether one was found.
+func (r *WebPatternRegistry) Replace(strategy WebPatternStrategy) bool {
+	for i, s := range r.strategies {
+		if s.Name() == strategy.Name() {
+			r.strategies[i] = strategy
+			return true
+		}
+	}
+	return false
+}
+
 func (r *WebPatternRegistry) DetectAll(content string, wordCount int) []*DetectionResult {
 	results := make([]*DetectionResult, 0)
 
@@ -75,7 +125,9 @@ func (r *WebPatternRegistry) DetectAllWithPassed(content string, wordCount int)
 	results := make([]*DetectionResult, 0)
 
 	for _, strategy := range r.strategies {
+		start := time.Now()
 		result := strategy.Detect(content, wordCount)
+		elapsed := time.Since(start)
 		if result == nil {
 			result = &DetectionResult{
 				Detected:    false,
@@ -92,6 +144,7 @@ func (r *WebPatternRegistry) DetectAllWithPassed(content string, wordCount int)
 				result.Description = "No issues detected"
 			}
 		}
+		result.Duration = elapsed
 		results = append(results, result)
 	}
 
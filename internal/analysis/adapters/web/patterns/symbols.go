@@ -0,0 +1,116 @@
+package patterns
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxSymbolExamples caps the emoji runs and the symbols a SymbolScan keeps.
+const maxSymbolExamples = 5
+
+// SymbolScan summarizes the emoji and decorative Unicode symbols in a text.
+type SymbolScan struct {
+	// Emoji counts emoji, with a ZWJ sequence, keycap or skin-tone variant
+	// counted once.
+	Emoji int
+	// Decorative counts arrows, box drawing, geometric shapes, enclosed
+	// alphanumerics and "bold"/"italic" mathematical letters.
+	Decorative int
+	// Words is the number of whitespace-separated words, the base of Density.
+	Words int
+	// LongestRun is the most emoji in a row, spaces between them allowed.
+	LongestRun int
+	// EmojiRuns and Symbols list distinct runs of adjacent emoji and distinct
+	// decorative symbols in order of first appearance, at most
+	// maxSymbolExamples of each.
+	EmojiRuns []string
+	Symbols   []string
+}
+
+// Density returns emoji and decorative symbols per word.
+func (s SymbolScan) Density() float64 {
+	if s.Words == 0 {
+		return 0
+	}
+	return float64(s.Emoji+s.Decorative) / float64(s.Words)
+}
+
+// ScanSymbols counts the emoji and decorative symbols in text and collects
+// examples of them.
+func ScanSymbols(text string) SymbolScan {
+	scan := SymbolScan{Words: len(strings.Fields(text))}
+	seen := make(map[string]bool)
+	example := func(list *[]string, s string) {
+		if s != "" && !seen[s] && len(*list) < maxSymbolExamples {
+			seen[s] = true
+			*list = append(*list, s)
+		}
+	}
+
+	var run strings.Builder
+	runLen := 0
+	joined := false
+	flush := func() {
+		if runLen > 0 {
+			example(&scan.EmojiRuns, strings.Join(strings.Fields(run.String()), " "))
+			scan.LongestRun = max(scan.LongestRun, runLen)
+		}
+		run.Reset()
+		runLen = 0
+		joined = false
+	}
+
+	for _, r := range text {
+		switch {
+		case isEmojiModifier(r):
+			if runLen > 0 {
+				run.WriteRune(r)
+				joined = joined || r == 0x200D
+			}
+		case isEmoji(r):
+			if !joined {
+				scan.Emoji++
+				runLen++
+			}
+			joined = false
+			run.WriteRune(r)
+		case unicode.IsSpace(r) && runLen > 0:
+			run.WriteRune(' ')
+		default:
+			flush()
+			if isDecorativeSymbol(r) {
+				scan.Decorative++
+				example(&scan.Symbols, string(r))
+			}
+		}
+	}
+	flush()
+	return scan
+}
+
+// isEmoji reports whether r is a pictographic emoji or dingbat. General
+// punctuation such as dashes, quotes and bullets is not emoji.
+func isEmoji(r rune) bool {
+	return r >= 0x1F000 && r <= 0x1FAFF || // pictographs, emoticons, transport, flags
+		r >= 0x2600 && r <= 0x27BF || // miscellaneous symbols and dingbats
+		r >= 0x2300 && r <= 0x23FF || // miscellaneous technical (⌚, ⏰)
+		r >= 0x2B00 && r <= 0x2BFF // arrows and stars (⭐, ⬆)
+}
+
+// isEmojiModifier reports whether r only modifies or joins the emoji before
+// it: zero-width joiner, variation selectors, skin tones, keycaps and tags.
+func isEmojiModifier(r rune) bool {
+	return r == 0x200D || r == 0x20E3 ||
+		r >= 0xFE00 && r <= 0xFE0F ||
+		r >= 0x1F3FB && r <= 0x1F3FF ||
+		r >= 0xE0020 && r <= 0xE007F
+}
+
+// isDecorativeSymbol reports whether r is a symbol used to decorate text
+// rather than write it.
+func isDecorativeSymbol(r rune) bool {
+	return r >= 0x2190 && r <= 0x21FF || // arrows
+		r >= 0x2460 && r <= 0x24FF || // enclosed alphanumerics (①, Ⓐ)
+		r >= 0x2500 && r <= 0x25FF || // box drawing, blocks, geometric shapes
+		r >= 0x1D400 && r <= 0x1D7FF // mathematical "bold" and "italic" letters
+}
@@ -0,0 +1,151 @@
+package patterns
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultOverusedPhrases are the filler phrases overused_phrases counts when
+// no vocabulary file replaces them.
+var DefaultOverusedPhrases = []string{
+	"it is important to note that",
+	"it's worth noting that",
+	"in conclusion",
+	"furthermore",
+	"in today's world",
+	"in today's digital age",
+	"as you know",
+	"the power of",
+	"next level",
+	"best practices",
+	"moving forward",
+	"at the end of the day",
+	"synergy",
+	"leveraging",
+	"paradigm shift",
+	"transformative",
+	"revolutionary",
+	"innovative approach",
+	"seamlessly integrated",
+	"cutting-edge",
+	"state-of-the-art",
+	"game-changing",
+	"it's no secret that",
+	"the fact of the matter is",
+	"at the forefront",
+	"driving innovation",
+	"unlock the potential",
+	"harness the power",
+	"in an ever-changing",
+	"ever-evolving",
+	"comprehensive solution",
+	"holistic approach",
+	"robust solution",
+	"scalable solution",
+}
+
+// DefaultAIVocabulary are the AI-characteristic terms ai_vocabulary looks for
+// when no vocabulary file replaces them.
+var DefaultAIVocabulary = []string{
+	"delve into", "delve deeper", "navigating", "landscape",
+	"realm", "tapestry", "intricacies", "nuances",
+	"unveil", "unravel", "embark", "journey",
+	"paramount", "crucial", "pivotal", "vital",
+	"plethora", "myriad", "multifaceted",
+}
+
+// DefaultBoilerplatePhrases are the boilerplate openers and signposts
+// boilerplate_text looks for when no vocabulary file replaces them.
+var DefaultBoilerplatePhrases = []string{
+	"welcome to", "this article", "this post", "in this guide",
+	"let's dive", "let's explore", "let's take a look",
+	"it's important to understand", "by the end of this",
+	"in this section", "as we've seen", "as discussed",
+}
+
+// Vocabulary holds the phrase lists of the overused_phrases, ai_vocabulary
+// and boilerplate_text strategies. An empty list keeps that strategy's
+// built-in default.
+type Vocabulary struct {
+	OverusedPhrases []string `yaml:"overused_phrases"`
+	AIVocabulary    []string `yaml:"ai_vocabulary"`
+	Boilerplate     []string `yaml:"boilerplate"`
+}
+
+// ReadVocabulary reads a vocabulary file: a YAML mapping of any of
+// overused_phrases, ai_vocabulary and boilerplate to lists of phrases. Each
+// list present replaces that category's defaults; absent ones keep them.
+// Phrases are matched case-insensitively and stored lowercased.
+func ReadVocabulary(path string) (*Vocabulary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vocabulary file: %w", err)
+	}
+
+	var raw map[string]*[]string
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	if err := dec.Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse vocabulary file %s: %w", path, err)
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("vocabulary file %s defines no categories", path)
+	}
+
+	var vocab Vocabulary
+	for key, terms := range raw {
+		var dst *[]string
+		switch key {
+		case "overused_phrases":
+			dst = &vocab.OverusedPhrases
+		case "ai_vocabulary":
+			dst = &vocab.AIVocabulary
+		case "boilerplate":
+			dst = &vocab.Boilerplate
+		default:
+			return nil, fmt.Errorf("vocabulary file %s: unknown category %q (want overused_phrases, ai_vocabulary or boilerplate)", path, key)
+		}
+		if terms == nil || len(*terms) == 0 {
+			return nil, fmt.Errorf("vocabulary file %s: %s is empty", path, key)
+		}
+		if *dst, err = normalizeTerms(*terms); err != nil {
+			return nil, fmt.Errorf("vocabulary file %s: %s: %w", path, key, err)
+		}
+	}
+	return &vocab, nil
+}
+
+// normalizeTerms lowercases and trims terms and drops duplicates, rejecting
+// blank entries.
+func normalizeTerms(terms []string) ([]string, error) {
+	seen := make(map[string]bool, len(terms))
+	out := make([]string, 0, len(terms))
+	for i, term := range terms {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if term == "" {
+			return nil, fmt.Errorf("entry %d is blank", i+1)
+		}
+		if !seen[term] {
+			seen[term] = true
+			out = append(out, term)
+		}
+	}
+	return out, nil
+}
+
+// UseVocabulary replaces the overused_phrases, ai_vocabulary and
+// boilerplate_text strategies with ones matching v's lists. A nil v keeps
+// the registered strategies.
+func (r *WebPatternRegistry) UseVocabulary(v *Vocabulary) {
+	if v == nil {
+		return
+	}
+	r.Replace(NewOverusedPhrasesStrategyWithPhrases(v.OverusedPhrases))
+	r.Replace(NewAIVocabularyStrategyWithTerms(v.AIVocabulary))
+	r.Replace(NewBoilerplateTextStrategyWithPhrases(v.Boilerplate))
+}
@@ -0,0 +1,99 @@
+package patterns
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeVocabulary(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "vocabulary.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("Failed to write vocabulary file: %v", err)
+	}
+	return path
+}
+
+func TestReadVocabulary(t *testing.T) {
+	vocab, err := ReadVocabulary(writeVocabulary(t, `
+ai_vocabulary:
+  - "  Testament To "
+  - seamless
+  - testament to
+boilerplate:
+  - in this write-up
+`))
+	if err != nil {
+		t.Fatalf("ReadVocabulary() error = %v", err)
+	}
+	want := &Vocabulary{
+		AIVocabulary: []string{"testament to", "seamless"},
+		Boilerplate:  []string{"in this write-up"},
+	}
+	if !reflect.DeepEqual(vocab, want) {
+		t.Errorf("ReadVocabulary() = %+v, want %+v", vocab, want)
+	}
+}
+
+func TestReadVocabulary_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"empty file", "", "defines no categories"},
+		{"not a mapping", "- delve\n", "failed to parse"},
+		{"unknown category", "marketing:\n  - synergy\n", `unknown category "marketing"`},
+		{"empty category", "ai_vocabulary: []\n", "ai_vocabulary is empty"},
+		{"null category", "boilerplate:\n", "boilerplate is empty"},
+		{"blank term", "overused_phrases:\n  - furthermore\n  - ' '\n", "entry 2 is blank"},
+		{"scalar category", "ai_vocabulary: delve\n", "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadVocabulary(writeVocabulary(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ReadVocabulary() error = %v, want it to mention %q", err, tt.want)
+			}
+		})
+	}
+
+	if _, err := ReadVocabulary(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("ReadVocabulary() of a missing file should fail")
+	}
+}
+
+func TestWebPatternRegistry_UseVocabulary(t *testing.T) {
+	content := "Our offering is a testament to craft, seamless and a testament to care. " +
+		"It is frictionless, a quiet testament to years of work."
+	words := len(strings.Fields(content))
+
+	registry := NewWebPatternRegistry()
+	registry.UseVocabulary(&Vocabulary{AIVocabulary: []string{"testament to", "seamless", "frictionless"}})
+
+	var aiVocab, overused WebPatternStrategy
+	for _, s := range registry.GetStrategies() {
+		switch s.Name() {
+		case "ai_vocabulary":
+			aiVocab = s
+		case "overused_phrases":
+			overused = s
+		}
+	}
+
+	result := aiVocab.Detect(content, words)
+	if result == nil || !result.Detected {
+		t.Fatal("ai_vocabulary should fire on the configured terms")
+	}
+	if NewAIVocabularyStrategy().Detect(content, words) != nil {
+		t.Error("the default ai_vocabulary list should not fire on this content")
+	}
+
+	// Categories the vocabulary leaves empty keep the built-in phrases.
+	if got := overused.(*OverusedPhrasesStrategy).phrases; !reflect.DeepEqual(got, DefaultOverusedPhrases) {
+		t.Errorf("overused_phrases list = %v, want the defaults", got)
+	}
+}
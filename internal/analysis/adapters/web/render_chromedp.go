@@ -0,0 +1,28 @@
+//go:build chromedp
+
+package web
+
+import (
+	"context"
+
+	"github.com/chromedp/chromedp"
+)
+
+// renderPage navigates a fresh headless browser to url and returns the
+// document's outer HTML once the body is ready.
+func renderPage(ctx context.Context, url, userAgent string) (string, error) {
+	opts := append(chromedp.DefaultExecAllocatorOptions[:], chromedp.UserAgent(userAgent))
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx, opts...)
+	defer cancelAlloc()
+
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	defer cancelBrowser()
+
+	var html string
+	err := chromedp.Run(browserCtx,
+		chromedp.Navigate(url),
+		chromedp.WaitReady("body", chromedp.ByQuery),
+		chromedp.OuterHTML("html", &html, chromedp.ByQuery),
+	)
+	return html, err
+}
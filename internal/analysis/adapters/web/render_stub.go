@@ -0,0 +1,9 @@
+//go:build !chromedp
+
+package web
+
+import "context"
+
+func renderPage(context.Context, string, string) (string, error) {
+	return "", ErrRenderingUnavailable
+}
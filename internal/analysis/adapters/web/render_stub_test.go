@@ -0,0 +1,33 @@
+//go:build !chromedp
+
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFetch_RenderJSUnavailable(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><body><div id="app"></div></body></html>`))
+	}))
+	defer server.Close()
+
+	_, err := NewFetcher(5*time.Second, WithRenderJS(true)).Fetch(server.URL)
+	if !errors.Is(err, ErrRenderingUnavailable) {
+		t.Fatalf("Fetch() error = %v, want ErrRenderingUnavailable", err)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, an unavailable renderer should not be retried", requests)
+	}
+
+	if _, err := NewFetcher(5*time.Second, WithRenderJS(false)).Fetch(server.URL); err != nil {
+		t.Errorf("Fetch() without rendering error = %v", err)
+	}
+}
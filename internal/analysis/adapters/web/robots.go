@@ -0,0 +1,250 @@
+package web
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRobotsTTL is how long a host's robots.txt is cached.
+const DefaultRobotsTTL = time.Hour
+
+// DefaultRobotsMaxHosts is how many hosts' robots.txt a RobotsCache keeps.
+const DefaultRobotsMaxHosts = 1000
+
+// maxRobotsBytes caps how much of a robots.txt is parsed (RFC 9309 requires
+// crawlers to handle at least 500 KiB).
+const maxRobotsBytes = 500 << 10
+
+// RobotsDisallowedError is returned when a site's robots.txt disallows the
+// requested path for the fetcher's user agent.
+type RobotsDisallowedError struct {
+	URL       string
+	UserAgent string
+}
+
+func (e *RobotsDisallowedError) Error() string {
+	return fmt.Sprintf("robots.txt disallows %s for user agent %q", e.URL, e.UserAgent)
+}
+
+// robotsRule is one Allow or Disallow line.
+type robotsRule struct {
+	pattern *regexp.Regexp
+	length  int
+	allow   bool
+}
+
+// newRobotsRule compiles a robots.txt path pattern, supporting "*" wildcards
+// and a trailing "$" end anchor.
+func newRobotsRule(pattern string, allow bool) robotsRule {
+	anchored := strings.HasSuffix(pattern, "$")
+	parts := strings.Split(strings.TrimSuffix(pattern, "$"), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return robotsRule{pattern: regexp.MustCompile(expr), length: len(pattern), allow: allow}
+}
+
+// robotsRules are the rules of the group that applies to one user agent.
+type robotsRules []robotsRule
+
+// allowed applies the longest matching rule; Allow wins ties and paths
+// without a matching rule are allowed.
+func (r robotsRules) allowed(path string) bool {
+	best := -1
+	allow := true
+	for _, rule := range r {
+		if !rule.pattern.MatchString(path) {
+			continue
+		}
+		if rule.length > best || (rule.length == best && rule.allow) {
+			best = rule.length
+			allow = rule.allow
+		}
+	}
+	return allow
+}
+
+// parseRobots returns the rules of every group naming userAgent's product
+// token (e.g. "cadence" for "Cadence/1.0"), falling back to the "*" group.
+func parseRobots(r io.Reader, userAgent string) robotsRules {
+	token := robotsProductToken(userAgent)
+
+	var specific, wildcard robotsRules
+	var agents []string
+	inRules := false
+	foundSpecific := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent":
+			if inRules {
+				agents = nil
+				inRules = false
+			}
+			agents = append(agents, strings.ToLower(value))
+		case "allow", "disallow":
+			inRules = true
+			if value == "" {
+				// "Disallow:" with no path allows everything.
+				continue
+			}
+			rule := newRobotsRule(value, key == "allow")
+			for _, agent := range agents {
+				switch {
+				case agent == "*":
+					wildcard = append(wildcard, rule)
+				case agent != "" && agent == token:
+					specific = append(specific, rule)
+					foundSpecific = true
+				}
+			}
+		}
+	}
+
+	if foundSpecific {
+		return specific
+	}
+	return wildcard
+}
+
+func robotsProductToken(userAgent string) string {
+	fields := strings.FieldsFunc(strings.ToLower(userAgent), func(r rune) bool {
+		return r == '/' || r == ' '
+	})
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+type robotsEntry struct {
+	rules     robotsRules
+	fetchedAt time.Time
+	usedAt    time.Time
+}
+
+// RobotsCache stores parsed robots.txt rules per scheme and host for a TTL,
+// keeping at most DefaultRobotsMaxHosts hosts. It is safe for concurrent use
+// and can be shared between fetchers.
+type RobotsCache struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	maxHosts int
+	entries  map[string]robotsEntry
+}
+
+// NewRobotsCache creates a cache; a zero ttl uses DefaultRobotsTTL.
+func NewRobotsCache(ttl time.Duration) *RobotsCache {
+	if ttl <= 0 {
+		ttl = DefaultRobotsTTL
+	}
+	return &RobotsCache{ttl: ttl, maxHosts: DefaultRobotsMaxHosts, entries: make(map[string]robotsEntry)}
+}
+
+// Len returns the number of hosts cached.
+func (c *RobotsCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// store caches entry under key. A full cache first drops expired hosts and
+// then, if still full, the least recently used one. Callers hold c.mu.
+func (c *RobotsCache) store(key string, entry robotsEntry) {
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.maxHosts {
+		oldestKey, oldest := "", time.Time{}
+		for k, e := range c.entries {
+			if time.Since(e.fetchedAt) > c.ttl {
+				delete(c.entries, k)
+				continue
+			}
+			if oldestKey == "" || e.usedAt.Before(oldest) {
+				oldestKey, oldest = k, e.usedAt
+			}
+		}
+		if len(c.entries) >= c.maxHosts {
+			delete(c.entries, oldestKey)
+		}
+	}
+	c.entries[key] = entry
+}
+
+// allowed reports whether userAgent may fetch target, loading the host's
+// robots.txt with client on a cache miss. A missing or unreachable robots.txt
+// allows everything.
+func (c *RobotsCache) allowed(client *http.Client, target, userAgent string) (bool, error) {
+	u, err := url.Parse(target)
+	if err != nil {
+		return false, err
+	}
+	key := u.Scheme + "://" + u.Host
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if ok {
+		entry.usedAt = time.Now()
+		c.entries[key] = entry
+	}
+	c.mu.Unlock()
+
+	if !ok || time.Since(entry.fetchedAt) > c.ttl {
+		now := time.Now()
+		entry = robotsEntry{rules: fetchRobots(client, key+"/robots.txt", userAgent), fetchedAt: now, usedAt: now}
+		c.mu.Lock()
+		c.store(key, entry)
+		c.mu.Unlock()
+	}
+
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	return entry.rules.allowed(path), nil
+}
+
+func fetchRobots(client *http.Client, robotsURL, userAgent string) robotsRules {
+	req, err := http.NewRequest(http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil
+	}
+	return parseRobots(io.LimitReader(resp.Body, maxRobotsBytes), userAgent)
+}
@@ -0,0 +1,162 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testRobots = `# comment
+User-agent: *
+Disallow: /private/
+Allow: /private/public-page
+Disallow: /*.pdf$
+
+User-agent: OtherBot
+Disallow: /
+
+User-agent: Cadence
+User-agent: SomeCrawler
+Disallow: /no-cadence
+`
+
+func TestParseRobots(t *testing.T) {
+	tests := []struct {
+		userAgent string
+		path      string
+		want      bool
+	}{
+		{"Mozilla/5.0", "/", true},
+		{"Mozilla/5.0", "/private/secret", false},
+		{"Mozilla/5.0", "/private/public-page", true},
+		{"Mozilla/5.0", "/docs/report.pdf", false},
+		{"Mozilla/5.0", "/docs/report.pdf?x=1", true},
+		{"OtherBot/2.0", "/anything", false},
+		{"Cadence/1.0", "/private/secret", true},
+		{"Cadence/1.0", "/no-cadence/page", false},
+		{"cadence", "/no-cadence", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.userAgent+" "+tt.path, func(t *testing.T) {
+			rules := parseRobots(strings.NewReader(testRobots), tt.userAgent)
+			if got := rules.allowed(tt.path); got != tt.want {
+				t.Errorf("allowed(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRobots_EmptyDisallowAllowsAll(t *testing.T) {
+	rules := parseRobots(strings.NewReader("User-agent: *\nDisallow:\n"), DefaultUserAgent)
+	if !rules.allowed("/anything") {
+		t.Error("empty Disallow should allow everything")
+	}
+}
+
+func TestFetcher_UserAgentAndRobots(t *testing.T) {
+	robotsHits := 0
+	var gotUA string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/robots.txt" {
+			robotsHits++
+			w.Write([]byte("User-agent: *\nDisallow: /blocked\n"))
+			return
+		}
+		gotUA = r.Header.Get("User-Agent")
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><body>Content</body></html>`))
+	}))
+	defer server.Close()
+
+	if _, err := NewFetcher(5 * time.Second).Fetch(server.URL + "/blocked"); err != nil {
+		t.Fatalf("robots.txt should be ignored by default, got %v", err)
+	}
+	if gotUA != DefaultUserAgent {
+		t.Errorf("User-Agent = %q, want %q", gotUA, DefaultUserAgent)
+	}
+	if robotsHits != 0 {
+		t.Errorf("robots.txt fetched %d times without WithRespectRobots", robotsHits)
+	}
+
+	cache := NewRobotsCache(time.Minute)
+	fetcher := NewFetcher(5*time.Second, WithUserAgent("TestBot/2.0"), WithRobotsCache(cache))
+
+	_, err := fetcher.Fetch(server.URL + "/blocked")
+	var disallowed *RobotsDisallowedError
+	if !errors.As(err, &disallowed) {
+		t.Fatalf("Fetch() error = %v, want RobotsDisallowedError", err)
+	}
+	if _, err := fetcher.Fetch(server.URL + "/allowed"); err != nil {
+		t.Fatalf("Fetch() allowed path error = %v", err)
+	}
+	if gotUA != "TestBot/2.0" {
+		t.Errorf("User-Agent = %q, want TestBot/2.0", gotUA)
+	}
+
+	// A second fetcher sharing the cache must not refetch robots.txt.
+	if _, err := NewFetcher(5*time.Second, WithRobotsCache(cache)).Fetch(server.URL + "/other"); err != nil {
+		t.Fatalf("Fetch() error = %v", err)
+	}
+	if robotsHits != 1 {
+		t.Errorf("robots.txt fetched %d times, want 1 (cached per host)", robotsHits)
+	}
+}
+
+func TestRobotsCache_TTL(t *testing.T) {
+	robotsHits := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		robotsHits++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cache := NewRobotsCache(time.Nanosecond)
+	for i := 0; i < 2; i++ {
+		allowed, err := cache.allowed(server.Client(), server.URL+"/page", DefaultUserAgent)
+		if err != nil || !allowed {
+			t.Fatalf("allowed() = %v, %v; a missing robots.txt should allow everything", allowed, err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if robotsHits != 2 {
+		t.Errorf("robots.txt fetched %d times, want 2 after the TTL expired", robotsHits)
+	}
+}
+
+func TestRobotsCache_MaxHosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	cache := NewRobotsCache(time.Hour)
+	cache.maxHosts = 2
+	// Distinct hosts that all resolve to the test server.
+	port := server.URL[strings.LastIndex(server.URL, ":"):]
+	hosts := []string{"http://127.0.0.1" + port, "http://localhost" + port, "http://[::1]" + port}
+
+	for _, host := range hosts[:2] {
+		if _, err := cache.allowed(server.Client(), host+"/page", DefaultUserAgent); err != nil {
+			t.Fatalf("allowed() error = %v", err)
+		}
+	}
+	// Using the first host makes the second the least recently used.
+	_, _ = cache.allowed(server.Client(), hosts[0]+"/page", DefaultUserAgent)
+	_, _ = cache.allowed(server.Client(), hosts[2]+"/page", DefaultUserAgent)
+
+	if got := cache.Len(); got != 2 {
+		t.Errorf("Len() = %d, want the cap of 2", got)
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if _, ok := cache.entries[hosts[0]]; !ok {
+		t.Error("the recently used host should stay cached")
+	}
+	if _, ok := cache.entries[hosts[1]]; ok {
+		t.Error("the least recently used host should be evicted")
+	}
+}
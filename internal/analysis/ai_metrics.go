@@ -0,0 +1,122 @@
+package analysis
+
+import (
+	"sync"
+)
+
+// TokenPrice is what a model costs per 1000 tokens, in US dollars.
+type TokenPrice struct {
+	PromptPer1K     float64 `json:"promptPer1k"`
+	CompletionPer1K float64 `json:"completionPer1k"`
+}
+
+// cost estimates what promptTokens and completionTokens cost at p.
+func (p TokenPrice) cost(promptTokens, completionTokens int64) float64 {
+	return (float64(promptTokens)*p.PromptPer1K + float64(completionTokens)*p.CompletionPer1K) / 1000
+}
+
+// AITokenSnapshot is a point-in-time view of AI token usage.
+type AITokenSnapshot struct {
+	Calls            int64 `json:"calls"`
+	PromptTokens     int64 `json:"promptTokens"`
+	CompletionTokens int64 `json:"completionTokens"`
+	// EstimatedCostUSD only covers models with a configured price.
+	EstimatedCostUSD float64 `json:"estimatedCostUsd"`
+	// ByModel is keyed by "provider/model".
+	ByModel map[string]*AIModelUsage `json:"byModel"`
+}
+
+// AIModelUsage is the token usage of one provider's model.
+type AIModelUsage struct {
+	Provider         string  `json:"provider"`
+	Model            string  `json:"model"`
+	Calls            int64   `json:"calls"`
+	PromptTokens     int64   `json:"promptTokens"`
+	CompletionTokens int64   `json:"completionTokens"`
+	EstimatedCostUSD float64 `json:"estimatedCostUsd"`
+	Priced           bool    `json:"priced"` // whether a price was configured for the model
+}
+
+// AITokenMetrics accumulates the tokens AI calls consume per provider and
+// model and estimates their cost from a per-model price list. It is safe
+// for concurrent use and satisfies ai.UsageRecorder.
+type AITokenMetrics struct {
+	mu      sync.Mutex
+	usage   map[string]*AIModelUsage // "provider/model" -> usage
+	pricing map[string]TokenPrice
+}
+
+// NewAITokenMetrics creates a collector pricing calls with pricing, keyed
+// by model name or by "provider/model" to price one provider's model
+// differently.
+func NewAITokenMetrics(pricing map[string]TokenPrice) *AITokenMetrics {
+	m := &AITokenMetrics{usage: make(map[string]*AIModelUsage)}
+	m.SetPricing(pricing)
+	return m
+}
+
+// SetPricing replaces the price list. Costs are estimated when a snapshot
+// is taken, so the new prices apply to tokens already recorded.
+func (m *AITokenMetrics) SetPricing(pricing map[string]TokenPrice) {
+	prices := make(map[string]TokenPrice, len(pricing))
+	for model, price := range pricing {
+		prices[model] = price
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pricing = prices
+}
+
+// RecordAITokens records one AI call and the tokens it used.
+func (m *AITokenMetrics) RecordAITokens(provider, model string, promptTokens, completionTokens int) {
+	key := provider + "/" + model
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	u, ok := m.usage[key]
+	if !ok {
+		u = &AIModelUsage{Provider: provider, Model: model}
+		m.usage[key] = u
+	}
+	u.Calls++
+	u.PromptTokens += int64(promptTokens)
+	u.CompletionTokens += int64(completionTokens)
+}
+
+// price looks up a model's price, preferring a "provider/model" entry.
+func (m *AITokenMetrics) price(key, model string) (TokenPrice, bool) {
+	if price, ok := m.pricing[key]; ok {
+		return price, true
+	}
+	price, ok := m.pricing[model]
+	return price, ok
+}
+
+// Snapshot returns the usage recorded so far with its estimated cost.
+func (m *AITokenMetrics) Snapshot() *AITokenSnapshot {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snap := &AITokenSnapshot{ByModel: make(map[string]*AIModelUsage, len(m.usage))}
+	for key, u := range m.usage {
+		usage := *u
+		if price, ok := m.price(key, u.Model); ok {
+			usage.Priced = true
+			usage.EstimatedCostUSD = price.cost(u.PromptTokens, u.CompletionTokens)
+		}
+		snap.ByModel[key] = &usage
+		snap.Calls += usage.Calls
+		snap.PromptTokens += usage.PromptTokens
+		snap.CompletionTokens += usage.CompletionTokens
+		snap.EstimatedCostUSD += usage.EstimatedCostUSD
+	}
+	return snap
+}
+
+// Reset clears the recorded usage but keeps the price list.
+func (m *AITokenMetrics) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.usage = make(map[string]*AIModelUsage)
+}
@@ -0,0 +1,49 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAITokenMetrics(t *testing.T) {
+	m := NewAITokenMetrics(map[string]TokenPrice{
+		"gpt-4o-mini":       {PromptPer1K: 0.15, CompletionPer1K: 0.6},
+		"ollama/llama3.2":   {},
+		"azure/gpt-4o-mini": {PromptPer1K: 0.3, CompletionPer1K: 1.2},
+	})
+
+	m.RecordAITokens("openai", "gpt-4o-mini", 1000, 500)
+	m.RecordAITokens("openai", "gpt-4o-mini", 3000, 500)
+	m.RecordAITokens("azure", "gpt-4o-mini", 1000, 1000)
+	m.RecordAITokens("anthropic", "claude-sonnet-4-20250514", 200, 100)
+
+	snap := m.Snapshot()
+	if snap.Calls != 4 || snap.PromptTokens != 5200 || snap.CompletionTokens != 2100 {
+		t.Errorf("totals = %d calls, %d prompt, %d completion; want 4, 5200, 2100", snap.Calls, snap.PromptTokens, snap.CompletionTokens)
+	}
+
+	openai := snap.ByModel["openai/gpt-4o-mini"]
+	if openai == nil || openai.Calls != 2 || !openai.Priced || math.Abs(openai.EstimatedCostUSD-1.2) > 1e-9 {
+		t.Errorf("openai usage = %+v, want 2 calls costing $1.20", openai)
+	}
+	if azure := snap.ByModel["azure/gpt-4o-mini"]; azure == nil || math.Abs(azure.EstimatedCostUSD-1.5) > 1e-9 {
+		t.Errorf("azure usage = %+v, want its provider-specific price of $1.50", azure)
+	}
+	if claude := snap.ByModel["anthropic/claude-sonnet-4-20250514"]; claude == nil || claude.Priced || claude.EstimatedCostUSD != 0 {
+		t.Errorf("unpriced usage = %+v, want it counted but not costed", claude)
+	}
+	if math.Abs(snap.EstimatedCostUSD-2.7) > 1e-9 {
+		t.Errorf("EstimatedCostUSD = %v, want 2.7", snap.EstimatedCostUSD)
+	}
+
+	// Prices apply retroactively; Reset keeps them.
+	m.SetPricing(map[string]TokenPrice{"claude-sonnet-4-20250514": {PromptPer1K: 3, CompletionPer1K: 15}})
+	if cost := m.Snapshot().ByModel["anthropic/claude-sonnet-4-20250514"].EstimatedCostUSD; math.Abs(cost-2.1) > 1e-9 {
+		t.Errorf("repriced cost = %v, want 2.1", cost)
+	}
+	m.Reset()
+	m.RecordAITokens("anthropic", "claude-sonnet-4-20250514", 1000, 0)
+	if snap := m.Snapshot(); snap.Calls != 1 || math.Abs(snap.EstimatedCostUSD-3) > 1e-9 {
+		t.Errorf("after Reset = %+v, want one call priced at $3", snap)
+	}
+}
@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"fmt"
 	"math"
 	"sort"
 
@@ -29,6 +30,10 @@ type StatisticalAnomaly struct {
 	BaselineValue float64
 	ObservedValue float64
 	IsSignificant bool
+	// LowConfidence marks an anomaly scored against a baseline of fewer
+	// commits than its SampleSizeGate considers reliable; Caveat says why.
+	LowConfidence bool
+	Caveat        string
 }
 
 type CommitStatistics struct {
@@ -39,15 +44,17 @@ type CommitStatistics struct {
 }
 
 type RepositoryBaseline struct {
-	AvgAdditions     float64
-	StdDevAdditions  float64
-	AvgDeletions     float64
-	StdDevDeletions  float64
-	AvgFilesChanged  float64
-	AvgAdditionRatio float64
-	MedianCommitSize int64
-	Q1CommitSize     int64
-	Q3CommitSize     int64
+	AvgAdditions     float64 `json:"avg_additions"`
+	StdDevAdditions  float64 `json:"stddev_additions"`
+	AvgDeletions     float64 `json:"avg_deletions"`
+	StdDevDeletions  float64 `json:"stddev_deletions"`
+	AvgFilesChanged  float64 `json:"avg_files_changed"`
+	AvgAdditionRatio float64 `json:"avg_addition_ratio"`
+	MedianCommitSize int64   `json:"median_commit_size"`
+	Q1CommitSize     int64   `json:"q1_commit_size"`
+	Q3CommitSize     int64   `json:"q3_commit_size"`
+	// SampleSize is the number of non-empty commits the baseline was computed from.
+	SampleSize int `json:"sample_size"`
 }
 
 func CalculateBaseline(pairs []*git.CommitPair) *RepositoryBaseline {
@@ -88,6 +95,7 @@ func CalculateBaseline(pairs []*git.CommitPair) *RepositoryBaseline {
 	if n == 0 {
 		return baseline
 	}
+	baseline.SampleSize = len(additions)
 
 	// Use trimmed mean/stddev (exclude top/bottom 10%) to resist outlier pollution.
 	// This prevents a few massive AI-generated commits from skewing the baseline
@@ -154,7 +162,83 @@ func trimmedMeanStdDev(values []float64, trimFraction float64) (mean, stddev flo
 	return mean, stddev
 }
 
+const (
+	// DefaultMinBaselineCommits is the fewest baseline commits statistical
+	// anomalies are computed from at all.
+	DefaultMinBaselineCommits = 5
+	// DefaultReliableBaselineCommits is the fewest baseline commits whose
+	// anomalies are not marked LowConfidence.
+	DefaultReliableBaselineCommits = 30
+)
+
+// SampleSizeGate keeps statistics computed from too little history from
+// reading as confident findings. Zero fields use the defaults above.
+type SampleSizeGate struct {
+	// MinCommits is the fewest baseline commits anomalies are computed from;
+	// smaller baselines yield none.
+	MinCommits int
+	// ReliableCommits is the fewest baseline commits whose anomalies are
+	// trusted; anomalies from smaller baselines are marked LowConfidence.
+	ReliableCommits int
+}
+
+func (g SampleSizeGate) withDefaults() SampleSizeGate {
+	if g.MinCommits <= 0 {
+		g.MinCommits = DefaultMinBaselineCommits
+	}
+	if g.ReliableCommits <= 0 {
+		g.ReliableCommits = DefaultReliableBaselineCommits
+	}
+	if g.ReliableCommits < g.MinCommits {
+		g.ReliableCommits = g.MinCommits
+	}
+	return g
+}
+
+// Allows reports whether baseline has enough commits to compute anomalies from.
+func (g SampleSizeGate) Allows(baseline *RepositoryBaseline) bool {
+	return baseline != nil && baseline.SampleSize >= g.withDefaults().MinCommits
+}
+
+// Caveat describes why anomalies against baseline are unreliable, or is
+// empty when the baseline has enough commits to trust them.
+func (g SampleSizeGate) Caveat(baseline *RepositoryBaseline) string {
+	g = g.withDefaults()
+	if baseline == nil || baseline.SampleSize >= g.ReliableCommits {
+		return ""
+	}
+	if baseline.SampleSize < g.MinCommits {
+		return fmt.Sprintf("baseline has only %d commits (statistical anomalies need at least %d); no anomalies computed",
+			baseline.SampleSize, g.MinCommits)
+	}
+	return fmt.Sprintf("low confidence: baseline has only %d commits (at least %d needed for reliable z-scores)",
+		baseline.SampleSize, g.ReliableCommits)
+}
+
+// DetectStatisticalAnomalies compares pair against baseline using the
+// default SampleSizeGate.
 func DetectStatisticalAnomalies(pair *git.CommitPair, baseline *RepositoryBaseline) []*StatisticalAnomaly {
+	return SampleSizeGate{}.DetectStatisticalAnomalies(pair, baseline)
+}
+
+// DetectStatisticalAnomalies compares pair against baseline. It returns no
+// anomalies when the baseline is below g.MinCommits, and marks them
+// LowConfidence when it is below g.ReliableCommits.
+func (g SampleSizeGate) DetectStatisticalAnomalies(pair *git.CommitPair, baseline *RepositoryBaseline) []*StatisticalAnomaly {
+	if !g.Allows(baseline) {
+		return make([]*StatisticalAnomaly, 0)
+	}
+	anomalies := detectStatisticalAnomalies(pair, baseline)
+	if caveat := g.Caveat(baseline); caveat != "" {
+		for _, a := range anomalies {
+			a.LowConfidence = true
+			a.Caveat = caveat
+		}
+	}
+	return anomalies
+}
+
+func detectStatisticalAnomalies(pair *git.CommitPair, baseline *RepositoryBaseline) []*StatisticalAnomaly {
 	anomalies := make([]*StatisticalAnomaly, 0)
 
 	if baseline.StdDevAdditions == 0 && baseline.StdDevDeletions == 0 {
@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+func TestSampleSizeGate_DetectStatisticalAnomalies(t *testing.T) {
+	pair := &git.CommitPair{
+		Current: &git.Commit{Hash: "big"},
+		Stats:   &git.DiffStats{Additions: 500, FilesChanged: 1},
+	}
+	baseline := func(n int) *RepositoryBaseline {
+		return &RepositoryBaseline{AvgAdditions: 100, StdDevAdditions: 10, AvgFilesChanged: 1, SampleSize: n}
+	}
+
+	tests := []struct {
+		name          string
+		gate          SampleSizeGate
+		sampleSize    int
+		wantAnomalies bool
+		wantLow       bool
+	}{
+		{"below the default minimum", SampleSizeGate{}, 4, false, false},
+		{"small default baseline", SampleSizeGate{}, 8, true, true},
+		{"reliable default baseline", SampleSizeGate{}, 30, true, false},
+		{"below a configured minimum", SampleSizeGate{MinCommits: 10}, 8, false, false},
+		{"configured reliable size", SampleSizeGate{ReliableCommits: 8}, 8, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			anomalies := tt.gate.DetectStatisticalAnomalies(pair, baseline(tt.sampleSize))
+			if got := len(anomalies) > 0; got != tt.wantAnomalies {
+				t.Fatalf("got %d anomalies, want any = %v", len(anomalies), tt.wantAnomalies)
+			}
+			for _, a := range anomalies {
+				if a.LowConfidence != tt.wantLow || (a.Caveat != "") != tt.wantLow {
+					t.Errorf("%s: LowConfidence = %v, Caveat = %q, want low confidence %v", a.Type, a.LowConfidence, a.Caveat, tt.wantLow)
+				}
+			}
+		})
+	}
+}
+
+func TestSampleSizeGate_Caveat(t *testing.T) {
+	gate := SampleSizeGate{MinCommits: 5, ReliableCommits: 20}
+	if got := gate.Caveat(&RepositoryBaseline{SampleSize: 20}); got != "" {
+		t.Errorf("Caveat() = %q for a reliable baseline, want none", got)
+	}
+	if got := gate.Caveat(&RepositoryBaseline{SampleSize: 8}); !strings.Contains(got, "only 8 commits") || !strings.Contains(got, "at least 20") {
+		t.Errorf("Caveat() = %q, want the sample size and the reliable size", got)
+	}
+	if got := gate.Caveat(&RepositoryBaseline{SampleSize: 2}); !strings.Contains(got, "no anomalies computed") {
+		t.Errorf("Caveat() = %q, want a note that no anomalies were computed", got)
+	}
+}
@@ -0,0 +1,106 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Assessments are the verdicts reports and job results carry. Reports
+// always store these canonical strings, so baselines, diffs and stored
+// states compare equal whatever wording is configured; AssessmentLabels
+// rewords them on output.
+const (
+	// Bands of a report's overall score (0-100).
+	AssessmentHighSuspicion     = "Suspicious Activity Detected"
+	AssessmentModerateSuspicion = "Moderate Suspicion"
+	AssessmentLowSuspicion      = "Low Suspicion"
+
+	// Classes of a webhook job's suspicion rate.
+	AssessmentLikelyAI    = "Likely AI-Generated"
+	AssessmentSuspicious  = "Suspicious Activity"
+	AssessmentLikelyHuman = "Likely Human-Written"
+
+	// AssessmentInsufficientContent is the assessment of a report whose
+	// source was too short to analyze.
+	AssessmentInsufficientContent = "Insufficient content for reliable analysis"
+)
+
+// AssessmentKeys maps the config keys of the assessment_labels section to
+// the assessments they reword.
+var AssessmentKeys = map[string]string{
+	"high_suspicion":       AssessmentHighSuspicion,
+	"moderate_suspicion":   AssessmentModerateSuspicion,
+	"low_suspicion":        AssessmentLowSuspicion,
+	"likely_ai":            AssessmentLikelyAI,
+	"suspicious":           AssessmentSuspicious,
+	"likely_human":         AssessmentLikelyHuman,
+	"insufficient_content": AssessmentInsufficientContent,
+}
+
+// assessmentFor names the suspicion band an overall score (0-100) falls in.
+func assessmentFor(score float64) string {
+	if score >= 70 {
+		return AssessmentHighSuspicion
+	} else if score >= 40 {
+		return AssessmentModerateSuspicion
+	}
+	return AssessmentLowSuspicion
+}
+
+// AssessmentLabels maps canonical assessments to the wording shown in
+// reports, job results and streams, e.g. to translate them. Assessments
+// without an entry, and every assessment of a nil map, keep their
+// canonical wording.
+type AssessmentLabels map[string]string
+
+// ParseAssessmentLabels builds labels from assessment_labels config keys
+// (see AssessmentKeys), rejecting unknown keys. Empty values are ignored.
+func ParseAssessmentLabels(byKey map[string]string) (AssessmentLabels, error) {
+	var labels AssessmentLabels
+	for key, label := range byKey {
+		assessment, ok := AssessmentKeys[strings.ToLower(key)]
+		if !ok {
+			known := make([]string, 0, len(AssessmentKeys))
+			for k := range AssessmentKeys {
+				known = append(known, k)
+			}
+			sort.Strings(known)
+			return nil, fmt.Errorf("unknown assessment label %q (want one of %s)", key, strings.Join(known, ", "))
+		}
+		if label = strings.TrimSpace(label); label != "" {
+			if labels == nil {
+				labels = make(AssessmentLabels)
+			}
+			labels[assessment] = label
+		}
+	}
+	return labels, nil
+}
+
+// Label returns the configured wording of assessment.
+func (l AssessmentLabels) Label(assessment string) string {
+	if label, ok := l[assessment]; ok {
+		return label
+	}
+	return assessment
+}
+
+// Apply returns report with its assessment, and those of its sources,
+// reworded. The report itself, which may be shared with a cache, is not
+// modified; without labels it is returned unchanged.
+func (l AssessmentLabels) Apply(report *AnalysisReport) *AnalysisReport {
+	if report == nil || len(l) == 0 {
+		return report
+	}
+	labeled := *report
+	labeled.Assessment = l.Label(report.Assessment)
+	if len(report.Sources) > 0 {
+		labeled.Sources = make([]SourceSummary, len(report.Sources))
+		for i, src := range report.Sources {
+			src.Assessment = l.Label(src.Assessment)
+			labeled.Sources[i] = src
+		}
+	}
+	return &labeled
+}
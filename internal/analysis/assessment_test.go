@@ -0,0 +1,66 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAssessmentLabels(t *testing.T) {
+	labels, err := ParseAssessmentLabels(map[string]string{
+		"Likely_AI":      "Probablemente generado por IA",
+		"low_suspicion":  " Baja sospecha ",
+		"high_suspicion": "",
+	})
+	if err != nil {
+		t.Fatalf("ParseAssessmentLabels() unexpected error = %v", err)
+	}
+	want := AssessmentLabels{
+		AssessmentLikelyAI:     "Probablemente generado por IA",
+		AssessmentLowSuspicion: "Baja sospecha",
+	}
+	if len(labels) != len(want) || labels[AssessmentLikelyAI] != want[AssessmentLikelyAI] || labels[AssessmentLowSuspicion] != want[AssessmentLowSuspicion] {
+		t.Errorf("labels = %v, want %v", labels, want)
+	}
+
+	if labels, err := ParseAssessmentLabels(nil); err != nil || labels != nil {
+		t.Errorf("ParseAssessmentLabels(nil) = %v, %v, want no labels", labels, err)
+	}
+	if _, err := ParseAssessmentLabels(map[string]string{"very_suspicious": "x"}); err == nil || !strings.Contains(err.Error(), "likely_ai") {
+		t.Errorf("error = %v, want an unknown key error listing the valid keys", err)
+	}
+}
+
+func TestAssessmentLabels_Apply(t *testing.T) {
+	report := &AnalysisReport{
+		Assessment: AssessmentHighSuspicion,
+		Sources: []SourceSummary{
+			{SourceType: SourceTypeGit, Assessment: AssessmentLowSuspicion},
+			{SourceType: SourceTypeWeb, Assessment: AssessmentModerateSuspicion},
+		},
+	}
+
+	if got := AssessmentLabels(nil).Apply(report); got != report {
+		t.Error("nil labels should return the report unchanged")
+	}
+
+	labels := AssessmentLabels{AssessmentHighSuspicion: "Hoch", AssessmentLowSuspicion: "Niedrig"}
+	got := labels.Apply(report)
+	if got.Assessment != "Hoch" || got.Sources[0].Assessment != "Niedrig" || got.Sources[1].Assessment != AssessmentModerateSuspicion {
+		t.Errorf("labeled = %q, sources %+v", got.Assessment, got.Sources)
+	}
+	if report.Assessment != AssessmentHighSuspicion || report.Sources[0].Assessment != AssessmentLowSuspicion {
+		t.Error("Apply modified the original report")
+	}
+}
+
+func TestAssessmentKeys_CoverAssessments(t *testing.T) {
+	for _, assessment := range []string{assessmentFor(90), assessmentFor(50), assessmentFor(0), AssessmentInsufficientContent} {
+		found := false
+		for _, a := range AssessmentKeys {
+			found = found || a == assessment
+		}
+		if !found {
+			t.Errorf("assessment %q has no assessment_labels key", assessment)
+		}
+	}
+}
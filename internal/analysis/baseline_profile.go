@@ -0,0 +1,119 @@
+package analysis
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// BaselineProfileVersion is the format version written by SaveBaselineProfile.
+const BaselineProfileVersion = 1
+
+// DefaultBaselineDriftFactor is how many times larger or smaller a baseline
+// metric must become between two profiles to count as drift.
+const DefaultBaselineDriftFactor = 2.0
+
+// BaselineProfile is a RepositoryBaseline saved to disk so a later analysis
+// can score against it and report how the repository has changed since.
+type BaselineProfile struct {
+	Version   int                 `json:"version"`
+	Source    string              `json:"source,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+	Baseline  *RepositoryBaseline `json:"baseline"`
+}
+
+// NewBaselineProfile wraps baseline for saving, stamped with the current time.
+func NewBaselineProfile(source string, baseline *RepositoryBaseline) *BaselineProfile {
+	return &BaselineProfile{
+		Version:   BaselineProfileVersion,
+		Source:    source,
+		CreatedAt: time.Now().UTC(),
+		Baseline:  baseline,
+	}
+}
+
+// MarshalBaselineProfile encodes a profile as indented JSON.
+func MarshalBaselineProfile(profile *BaselineProfile) ([]byte, error) {
+	return json.MarshalIndent(profile, "", "  ")
+}
+
+// UnmarshalBaselineProfile decodes a profile written by MarshalBaselineProfile.
+func UnmarshalBaselineProfile(data []byte) (*BaselineProfile, error) {
+	var profile BaselineProfile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("invalid baseline profile: %w", err)
+	}
+	if profile.Version > BaselineProfileVersion {
+		return nil, fmt.Errorf("baseline profile version %d is newer than supported version %d", profile.Version, BaselineProfileVersion)
+	}
+	if profile.Baseline == nil {
+		return nil, fmt.Errorf("baseline profile has no baseline")
+	}
+	return &profile, nil
+}
+
+// SaveBaselineProfile writes profile to path, replacing any earlier profile.
+func SaveBaselineProfile(path string, profile *BaselineProfile) error {
+	data, err := MarshalBaselineProfile(profile)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// LoadBaselineProfile reads a profile saved by SaveBaselineProfile.
+func LoadBaselineProfile(path string) (*BaselineProfile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return UnmarshalBaselineProfile(data)
+}
+
+// BaselineDrift is a baseline metric that moved by at least the drift
+// factor between a historical and a current baseline.
+type BaselineDrift struct {
+	Metric     string  `json:"metric"`
+	Historical float64 `json:"historical"`
+	Current    float64 `json:"current"`
+	Ratio      float64 `json:"ratio"` // Current / Historical
+}
+
+func (d BaselineDrift) String() string {
+	return fmt.Sprintf("%s changed %.1fx (%.1f -> %.1f)", d.Metric, d.Ratio, d.Historical, d.Current)
+}
+
+// CompareBaselines reports the commit-size metrics of current that grew or
+// shrank by at least factor relative to historical, sorted by metric name.
+// Metrics that were zero historically are skipped. A factor <= 1 uses
+// DefaultBaselineDriftFactor.
+func CompareBaselines(historical, current *RepositoryBaseline, factor float64) []BaselineDrift {
+	if historical == nil || current == nil || historical.SampleSize == 0 || current.SampleSize == 0 {
+		return nil
+	}
+	if factor <= 1 {
+		factor = DefaultBaselineDriftFactor
+	}
+
+	metrics := map[string][2]float64{
+		"avg_additions":      {historical.AvgAdditions, current.AvgAdditions},
+		"avg_deletions":      {historical.AvgDeletions, current.AvgDeletions},
+		"avg_files_changed":  {historical.AvgFilesChanged, current.AvgFilesChanged},
+		"median_commit_size": {float64(historical.MedianCommitSize), float64(current.MedianCommitSize)},
+	}
+
+	drifts := make([]BaselineDrift, 0)
+	for name, v := range metrics {
+		if v[0] <= 0 {
+			continue
+		}
+		ratio := v[1] / v[0]
+		if ratio >= factor || ratio <= 1/factor {
+			drifts = append(drifts, BaselineDrift{Metric: name, Historical: v[0], Current: v[1], Ratio: ratio})
+		}
+	}
+	sort.Slice(drifts, func(i, j int) bool { return drifts[i].Metric < drifts[j].Metric })
+	return drifts
+}
@@ -0,0 +1,99 @@
+package analysis
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+func baselinePairs(sizes ...int64) []*git.CommitPair {
+	pairs := make([]*git.CommitPair, 0, len(sizes))
+	for i, size := range sizes {
+		pairs = append(pairs, &git.CommitPair{
+			Current:   &git.Commit{Hash: string(rune('a' + i))},
+			TimeDelta: time.Hour,
+			Stats:     &git.DiffStats{Additions: size, Deletions: size / 10, FilesChanged: 2},
+		})
+	}
+	return pairs
+}
+
+func TestBaselineProfile_SaveAndLoad(t *testing.T) {
+	baseline := CalculateBaseline(baselinePairs(10, 20, 30, 40, 50))
+	if baseline.SampleSize != 5 {
+		t.Fatalf("SampleSize = %d, want 5", baseline.SampleSize)
+	}
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := SaveBaselineProfile(path, NewBaselineProfile("repo", baseline)); err != nil {
+		t.Fatalf("SaveBaselineProfile() unexpected error = %v", err)
+	}
+
+	profile, err := LoadBaselineProfile(path)
+	if err != nil {
+		t.Fatalf("LoadBaselineProfile() unexpected error = %v", err)
+	}
+	if profile.Version != BaselineProfileVersion || profile.Source != "repo" {
+		t.Errorf("profile = %+v, want version %d from repo", profile, BaselineProfileVersion)
+	}
+	if *profile.Baseline != *baseline {
+		t.Errorf("loaded baseline = %+v, want %+v", profile.Baseline, baseline)
+	}
+}
+
+func TestUnmarshalBaselineProfile_Rejects(t *testing.T) {
+	tests := map[string]string{
+		"invalid json":   `{`,
+		"no baseline":    `{"version":1}`,
+		"newer version":  `{"version":99,"baseline":{}}`,
+		"wrong baseline": `{"version":1,"baseline":"x"}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := UnmarshalBaselineProfile([]byte(data)); err == nil {
+				t.Error("UnmarshalBaselineProfile() want error, got nil")
+			}
+		})
+	}
+}
+
+func TestLoadBaselineProfile_Missing(t *testing.T) {
+	_, err := LoadBaselineProfile(filepath.Join(t.TempDir(), "none.json"))
+	if !os.IsNotExist(err) {
+		t.Errorf("LoadBaselineProfile() error = %v, want a not-exist error", err)
+	}
+}
+
+func TestCompareBaselines(t *testing.T) {
+	historical := CalculateBaseline(baselinePairs(100, 100, 110, 90, 100))
+	tripled := CalculateBaseline(baselinePairs(300, 300, 330, 270, 300))
+
+	drifts := CompareBaselines(historical, tripled, 0)
+	got := make(map[string]BaselineDrift, len(drifts))
+	for _, d := range drifts {
+		got[d.Metric] = d
+	}
+	for _, metric := range []string{"avg_additions", "avg_deletions", "median_commit_size"} {
+		d, ok := got[metric]
+		if !ok {
+			t.Errorf("CompareBaselines() missing drift for %s, got %v", metric, drifts)
+			continue
+		}
+		if d.Ratio < 2.9 || d.Ratio > 3.1 {
+			t.Errorf("%s ratio = %.2f, want ~3", metric, d.Ratio)
+		}
+	}
+	if _, ok := got["avg_files_changed"]; ok {
+		t.Error("avg_files_changed did not change and should not drift")
+	}
+
+	if drifts := CompareBaselines(historical, historical, 0); len(drifts) != 0 {
+		t.Errorf("CompareBaselines(same) = %v, want none", drifts)
+	}
+	if drifts := CompareBaselines(&RepositoryBaseline{}, tripled, 0); drifts != nil {
+		t.Errorf("CompareBaselines(empty historical) = %v, want nil", drifts)
+	}
+}
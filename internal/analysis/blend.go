@@ -0,0 +1,55 @@
+package analysis
+
+// DefaultAIBlendWeight is the share of the blended score taken from AI
+// validation when ai.blend_weight is not set.
+const DefaultAIBlendWeight = 0.5
+
+// ScoreBreakdown shows how a report's OverallScore was reached. All scores
+// are 0-100.
+type ScoreBreakdown struct {
+	// Heuristic is the score computed from the detections alone.
+	Heuristic float64
+	// AI is the mean AI-validation probability of AI generation over the
+	// reviewed detections.
+	AI float64
+	// AIWeight is the share of Blended taken from AI (0-1). It stays 0 when
+	// AI validation did not run or returned no verdicts.
+	AIWeight float64
+	// AIReviewed counts the detections AI validation returned a verdict for.
+	AIReviewed int
+	// Blended is (1-AIWeight)*Heuristic + AIWeight*AI and is the report's
+	// OverallScore.
+	Blended float64
+}
+
+// BlendScores merges a heuristic and an AI score (both 0-100) with weight
+// as the AI share, clamped to [0, 1].
+func BlendScores(heuristic, ai, weight float64) float64 {
+	weight = min(max(weight, 0), 1)
+	if weight == 0 {
+		return heuristic
+	}
+	return (1-weight)*heuristic + weight*ai
+}
+
+// ApplyAIScore blends the AI-validation probabilities that the reviewed
+// content is AI-generated (0-1, one per reviewed detection) into the report
+// with weight as the AI share, updating OverallScore and Assessment. Without
+// confidences the report keeps its heuristic score.
+func (r *AnalysisReport) ApplyAIScore(confidences []float64, weight float64) {
+	if len(confidences) == 0 {
+		return
+	}
+
+	var sum float64
+	for _, c := range confidences {
+		sum += min(max(c, 0), 1)
+	}
+
+	r.Scores.AI = sum / float64(len(confidences)) * 100
+	r.Scores.AIReviewed = len(confidences)
+	r.Scores.AIWeight = min(max(weight, 0), 1)
+	r.Scores.Blended = BlendScores(r.Scores.Heuristic, r.Scores.AI, r.Scores.AIWeight)
+	r.OverallScore = r.Scores.Blended
+	r.Assessment = assessmentFor(r.OverallScore)
+}
@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBlendScores(t *testing.T) {
+	tests := []struct {
+		name      string
+		heuristic float64
+		ai        float64
+		weight    float64
+		want      float64
+	}{
+		{"even split", 40, 80, 0.5, 60},
+		{"weighted toward ai", 40, 80, 0.75, 70},
+		{"zero weight keeps heuristic", 42.5, 90, 0, 42.5},
+		{"full weight uses ai", 40, 90, 1, 90},
+		{"weight clamped above one", 40, 90, 3, 90},
+		{"weight clamped below zero", 40, 90, -1, 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BlendScores(tt.heuristic, tt.ai, tt.weight); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("BlendScores() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateReportStats_ScoresWithoutAI(t *testing.T) {
+	report := &AnalysisReport{
+		Detections: []Detection{{Strategy: "size_analysis", Detected: true, Severity: "high", Confidence: 0.6}},
+		Metrics:    map[string]interface{}{MetricWeightedScore: 37.25},
+	}
+	calculateReportStats(report)
+
+	if report.Scores.Heuristic != 37.25 || report.Scores.Blended != report.OverallScore || report.OverallScore != 37.25 {
+		t.Errorf("Scores = %+v, OverallScore = %v, want blended equal to the heuristic 37.25", report.Scores, report.OverallScore)
+	}
+	if report.Scores.AIWeight != 0 || report.Scores.AIReviewed != 0 {
+		t.Errorf("Scores = %+v, want no AI share", report.Scores)
+	}
+
+	report.ApplyAIScore(nil, 0.5)
+	if report.OverallScore != 37.25 || report.Scores.Blended != 37.25 {
+		t.Errorf("ApplyAIScore(nil) changed the score to %v", report.OverallScore)
+	}
+}
+
+func TestAnalysisReport_ApplyAIScore(t *testing.T) {
+	report := &AnalysisReport{Metrics: map[string]interface{}{MetricWeightedScore: 30.0}}
+	calculateReportStats(report)
+	if report.Assessment != "Low Suspicion" {
+		t.Fatalf("Assessment = %q, want Low Suspicion before blending", report.Assessment)
+	}
+
+	report.ApplyAIScore([]float64{0.9, 0.7, 1.4}, 0.5)
+
+	// 1.4 is clamped to 1: (0.9 + 0.7 + 1) / 3.
+	if math.Abs(report.Scores.AI-86.6666666667) > 1e-6 || report.Scores.AIReviewed != 3 {
+		t.Errorf("Scores = %+v, want AI 86.7 over 3 verdicts", report.Scores)
+	}
+	if math.Abs(report.OverallScore-58.3333333333) > 1e-6 || report.Scores.Blended != report.OverallScore {
+		t.Errorf("OverallScore = %v, Blended = %v, want 58.3", report.OverallScore, report.Scores.Blended)
+	}
+	if report.Scores.Heuristic != 30 {
+		t.Errorf("Heuristic = %v, want the unblended 30", report.Scores.Heuristic)
+	}
+	if report.Assessment != "Moderate Suspicion" {
+		t.Errorf("Assessment = %q, want it recomputed from the blended score", report.Assessment)
+	}
+}
@@ -1,8 +1,10 @@
 package analysis
 
 import (
+	"container/list"
 	"crypto/sha256"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 )
@@ -42,30 +44,43 @@ type AnalysisCache interface {
 
 // CacheStats provides visibility into cache performance.
 type CacheStats struct {
-	Hits      int64   `json:"hits"`
-	Misses    int64   `json:"misses"`
-	Evictions int64   `json:"evictions"`
-	Size      int     `json:"size"`
-	MaxSize   int     `json:"maxSize"`
-	HitRate   float64 `json:"hitRate"`
+	Hits       int64   `json:"hits"`
+	Misses     int64   `json:"misses"`
+	Evictions  int64   `json:"evictions"`
+	Size       int     `json:"size"`
+	MaxSize    int     `json:"maxSize"`
+	HitRate    float64 `json:"hitRate"`
+	TTLSeconds float64 `json:"ttlSeconds"`
 }
 
+// DefaultCacheTTL is how long an InMemoryCache keeps entries stored without
+// an explicit TTL, unless WithDefaultTTL says otherwise.
+const DefaultCacheTTL = 15 * time.Minute
+
 // InMemoryCache is a thread-safe in-memory implementation of AnalysisCache
-// with TTL-based expiration and an optional max-size eviction policy (LRU-ish).
+// with TTL-based expiration and an optional max-size least-recently-used
+// eviction policy.
 type InMemoryCache struct {
-	mu        sync.RWMutex
-	entries   map[string]*CacheEntry
-	maxSize   int
-	hits      int64
-	misses    int64
-	evictions int64
+	mu         sync.RWMutex
+	entries    map[string]*list.Element
+	recent     *list.List // Most recently used first; values are *cacheItem
+	maxSize    int
+	defaultTTL time.Duration
+	hits       int64
+	misses     int64
+	evictions  int64
+}
+
+type cacheItem struct {
+	key   string
+	entry *CacheEntry
 }
 
 // CacheOption configures an InMemoryCache.
 type CacheOption func(*InMemoryCache)
 
 // WithMaxSize sets the maximum number of entries the cache will hold.
-// When the limit is exceeded the oldest entry is evicted.
+// When the limit is exceeded the least recently used entry is evicted.
 // A value of 0 means unlimited.
 func WithMaxSize(n int) CacheOption {
 	return func(c *InMemoryCache) {
@@ -73,10 +88,21 @@ func WithMaxSize(n int) CacheOption {
 	}
 }
 
+// WithDefaultTTL sets the TTL of entries stored with a zero or negative TTL.
+func WithDefaultTTL(ttl time.Duration) CacheOption {
+	return func(c *InMemoryCache) {
+		if ttl > 0 {
+			c.defaultTTL = ttl
+		}
+	}
+}
+
 // NewInMemoryCache creates a new in-memory cache.
 func NewInMemoryCache(opts ...CacheOption) *InMemoryCache {
 	c := &InMemoryCache{
-		entries: make(map[string]*CacheEntry),
+		entries:    make(map[string]*list.Element),
+		recent:     list.New(),
+		defaultTTL: DefaultCacheTTL,
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -84,43 +110,58 @@ func NewInMemoryCache(opts ...CacheOption) *InMemoryCache {
 	return c
 }
 
-// Get retrieves a cached report. Expired entries are treated as misses and removed.
+// Get retrieves a cached report and marks it most recently used. Expired
+// entries are treated as misses and removed.
 func (c *InMemoryCache) Get(key string) (*AnalysisReport, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	entry, ok := c.entries[key]
+	elem, ok := c.entries[key]
 	if !ok {
 		c.misses++
 		return nil, false
 	}
 
-	if entry.IsExpired() {
-		delete(c.entries, key)
+	item := elem.Value.(*cacheItem)
+	if item.entry.IsExpired() {
+		c.removeLocked(elem)
 		c.misses++
 		c.evictions++
 		return nil, false
 	}
 
 	c.hits++
-	return entry.Report, true
+	c.recent.MoveToFront(elem)
+	return item.entry.Report, true
 }
 
-// Set stores a report with the given TTL.
+// Set stores a report with the given TTL, or the cache's default TTL when
+// ttl is zero or negative.
 func (c *InMemoryCache) Set(key string, report *AnalysisReport, ttl time.Duration) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if ttl <= 0 {
+		ttl = c.defaultTTL
+	}
 	now := time.Now()
-	c.entries[key] = &CacheEntry{
+	entry := &CacheEntry{
 		Report:    report,
 		CreatedAt: now,
 		ExpiresAt: now.Add(ttl),
 	}
 
-	// Enforce max size — evict oldest entry if necessary
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*cacheItem).entry = entry
+		c.recent.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.recent.PushFront(&cacheItem{key: key, entry: entry})
+
+	// Enforce max size — evict the least recently used entry if necessary
 	if c.maxSize > 0 && len(c.entries) > c.maxSize {
-		c.evictOldestLocked()
+		c.removeLocked(c.recent.Back())
+		c.evictions++
 	}
 }
 
@@ -128,14 +169,17 @@ func (c *InMemoryCache) Set(key string, report *AnalysisReport, ttl time.Duratio
 func (c *InMemoryCache) Delete(key string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	delete(c.entries, key)
+	if elem, ok := c.entries[key]; ok {
+		c.removeLocked(elem)
+	}
 }
 
 // Clear removes all entries and resets counters.
 func (c *InMemoryCache) Clear() {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.entries = make(map[string]*CacheEntry)
+	c.entries = make(map[string]*list.Element)
+	c.recent.Init()
 	c.hits = 0
 	c.misses = 0
 	c.evictions = 0
@@ -160,12 +204,13 @@ func (c *InMemoryCache) Stats() CacheStats {
 	}
 
 	return CacheStats{
-		Hits:      c.hits,
-		Misses:    c.misses,
-		Evictions: c.evictions,
-		Size:      len(c.entries),
-		MaxSize:   c.maxSize,
-		HitRate:   hitRate,
+		Hits:       c.hits,
+		Misses:     c.misses,
+		Evictions:  c.evictions,
+		Size:       len(c.entries),
+		MaxSize:    c.maxSize,
+		HitRate:    hitRate,
+		TTLSeconds: c.defaultTTL.Seconds(),
 	}
 }
 
@@ -175,9 +220,9 @@ func (c *InMemoryCache) Prune() int {
 	defer c.mu.Unlock()
 
 	pruned := 0
-	for key, entry := range c.entries {
-		if entry.IsExpired() {
-			delete(c.entries, key)
+	for _, elem := range c.entries {
+		if elem.Value.(*cacheItem).entry.IsExpired() {
+			c.removeLocked(elem)
 			pruned++
 			c.evictions++
 		}
@@ -185,23 +230,10 @@ func (c *InMemoryCache) Prune() int {
 	return pruned
 }
 
-// evictOldestLocked removes the entry with the earliest creation time.
-// Caller must hold the write lock.
-func (c *InMemoryCache) evictOldestLocked() {
-	var oldestKey string
-	var oldestTime time.Time
-
-	for key, entry := range c.entries {
-		if oldestKey == "" || entry.CreatedAt.Before(oldestTime) {
-			oldestKey = key
-			oldestTime = entry.CreatedAt
-		}
-	}
-
-	if oldestKey != "" {
-		delete(c.entries, oldestKey)
-		c.evictions++
-	}
+// removeLocked drops elem from the cache. Caller must hold the write lock.
+func (c *InMemoryCache) removeLocked(elem *list.Element) {
+	c.recent.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheItem).key)
 }
 
 // CacheKey generates a deterministic cache key for a given source type and identifier.
@@ -210,6 +242,15 @@ func CacheKey(sourceType, identifier string) string {
 	return fmt.Sprintf("%s:%x", sourceType, h[:8])
 }
 
+// ReportCacheKey identifies a cached report by what was analyzed and how:
+// the source type and ID, the branch (empty when the source has none) and a
+// hash of the configuration the report was computed under. Any difference
+// in these yields a different key.
+func ReportCacheKey(sourceType, sourceID, branch, configHash string) string {
+	h := sha256.Sum256([]byte(strings.Join([]string{sourceType, sourceID, branch, configHash}, "\x00")))
+	return fmt.Sprintf("%s:%x", sourceType, h[:16])
+}
+
 // NullCache is a no-op implementation of AnalysisCache for use when caching is disabled.
 type NullCache struct{}
 
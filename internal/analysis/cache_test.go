@@ -96,6 +96,53 @@ func TestInMemoryCache_MaxSize(t *testing.T) {
 	}
 }
 
+func TestInMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewInMemoryCache(WithMaxSize(2))
+
+	c.Set("first", &AnalysisReport{ID: "first"}, 10*time.Minute)
+	c.Set("second", &AnalysisReport{ID: "second"}, 10*time.Minute)
+	if _, ok := c.Get("first"); !ok {
+		t.Fatal("expected 'first' to be cached")
+	}
+	c.Set("third", &AnalysisReport{ID: "third"}, 10*time.Minute)
+
+	if _, ok := c.Get("second"); ok {
+		t.Fatal("expected least recently used entry 'second' to be evicted")
+	}
+	if _, ok := c.Get("first"); !ok {
+		t.Fatal("expected recently read 'first' to survive eviction")
+	}
+
+	// Overwriting an entry refreshes it without growing the cache.
+	c.Set("third", &AnalysisReport{ID: "third-v2"}, 10*time.Minute)
+	if c.Size() != 2 {
+		t.Fatalf("got size %d after overwrite, want 2", c.Size())
+	}
+	if r, _ := c.Get("third"); r == nil || r.ID != "third-v2" {
+		t.Fatalf("Get(third) = %+v, want the overwritten report", r)
+	}
+	if evictions := c.Stats().Evictions; evictions != 1 {
+		t.Fatalf("got %d evictions, want 1", evictions)
+	}
+}
+
+func TestInMemoryCache_DefaultTTL(t *testing.T) {
+	c := NewInMemoryCache(WithDefaultTTL(time.Millisecond))
+	c.Set("default", &AnalysisReport{}, 0)
+	c.Set("explicit", &AnalysisReport{}, 10*time.Minute)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("default"); ok {
+		t.Fatal("expected entry stored without a TTL to expire after the default TTL")
+	}
+	if _, ok := c.Get("explicit"); !ok {
+		t.Fatal("expected entry with an explicit TTL to survive")
+	}
+	if got := NewInMemoryCache().Stats().TTLSeconds; got != DefaultCacheTTL.Seconds() {
+		t.Fatalf("got default ttlSeconds %v, want %v", got, DefaultCacheTTL.Seconds())
+	}
+}
+
 func TestInMemoryCache_Stats(t *testing.T) {
 	c := NewInMemoryCache(WithMaxSize(10))
 
@@ -152,6 +199,24 @@ func TestCacheKey(t *testing.T) {
 	}
 }
 
+func TestReportCacheKey(t *testing.T) {
+	base := ReportCacheKey("git", "https://github.com/example/repo", "main", "cfg1")
+	if base != ReportCacheKey("git", "https://github.com/example/repo", "main", "cfg1") {
+		t.Fatal("same inputs should produce same key")
+	}
+	for name, other := range map[string]string{
+		"source type": ReportCacheKey("web", "https://github.com/example/repo", "main", "cfg1"),
+		"source id":   ReportCacheKey("git", "https://github.com/example/other", "main", "cfg1"),
+		"branch":      ReportCacheKey("git", "https://github.com/example/repo", "dev", "cfg1"),
+		"config":      ReportCacheKey("git", "https://github.com/example/repo", "main", "cfg2"),
+		"boundaries":  ReportCacheKey("git", "https://github.com/example/repomain", "", "cfg1"),
+	} {
+		if other == base {
+			t.Errorf("changing the %s should change the key", name)
+		}
+	}
+}
+
 func TestNullCache(t *testing.T) {
 	c := NullCache{}
 
@@ -2,6 +2,9 @@ package detectors
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/TryCadence/Cadence/internal/analysis"
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
@@ -14,6 +17,100 @@ import (
 type GitDetector struct {
 	Thresholds     *patterns.Thresholds
 	StrategyConfig *config.StrategyConfig
+	// DependencyManifests overrides the manifest filenames inspected for mass
+	// dependency additions. Empty uses patterns.DefaultDependencyManifests.
+	DependencyManifests []string
+	// AIAssistants overrides the assistant names matched in commit trailers.
+	// Empty uses patterns.DefaultAIAssistants.
+	AIAssistants []string
+	// NGramMaxCoverage is the repeated 3-/4-token phrase coverage of added
+	// code above which ngram_repetition_analysis fires. Zero uses
+	// patterns.DefaultGitNGramCoverage.
+	NGramMaxCoverage float64
+	// Churn sets how much of a large addition code_churn_analysis expects to
+	// be deleted, and how soon. Zero fields use the patterns.DefaultChurn*
+	// values.
+	Churn patterns.ChurnOptions
+	// LanguageProfiles adds to or replaces entries of patterns.LanguageProfiles
+	// for the error-handling and naming strategies, keyed by file extension.
+	LanguageProfiles map[string]patterns.LanguageProfile
+	// DisabledStrategies names strategies to skip for this detector only, on
+	// top of any disabled through StrategyConfig.
+	DisabledStrategies map[string]bool
+	// Registry supplies per-strategy confidence used to weight scores.
+	// Strategies missing from it fall back to their own Confidence().
+	Registry *analysis.StrategyRegistry
+	// MinReportConfidence hides hits from strategies whose confidence is
+	// below it: they still count toward the score but are left out of the
+	// commit's Strategies and Examples, and a commit with only such hits is
+	// left out of the detections (see analysis.HideDetections). Zero shows
+	// every hit.
+	MinReportConfidence float64
+	// IncludePassed adds a Detected=false record for every strategy that ran
+	// but fired on no commit, so reports show which strategies cleared the
+	// repository as well as which flagged it. Records of strategies below
+	// MinReportConfidence are hidden like their hits.
+	IncludePassed bool
+	// Explain records a StrategyTrace for every strategy on every commit in
+	// Traces, for tuning thresholds.
+	Explain bool
+	// Traces holds the per-commit traces from the last Detect when Explain is set.
+	Traces []CommitTrace
+	// Suppressions lists strategies reported as false positives on specific
+	// commits; those hits are dropped and counted in "suppressed_count".
+	Suppressions *analysis.SuppressionList
+	// HistoricalBaseline, when set, is the baseline from an earlier analysis
+	// that statistical anomalies are scored against. Metrics that drifted
+	// from it are listed in "baseline_drift".
+	HistoricalBaseline *analysis.RepositoryBaseline
+	// SampleSizeGate sets how many baseline commits statistical anomalies
+	// need before they are computed and before they are trusted. When the
+	// baseline falls short its caveat is set in analysis.MetricBaselineCaveat
+	// and on the detections a statistical anomaly contributed to.
+	SampleSizeGate analysis.SampleSizeGate
+	// Baseline holds the repository baseline computed by the last Detect.
+	Baseline *analysis.RepositoryBaseline
+	// Metrics, when set, receives one RecordStrategyExecution per strategy
+	// per Detect: the strategy's total time across all commits, and whether
+	// it fired on any of them.
+	Metrics analysis.AnalysisMetrics
+
+	itemProgress func(analysis.ItemProgress)
+}
+
+// SetItemProgress sets a callback invoked after each commit pair Detect
+// analyzes; nil disables it. It implements analysis.ItemProgressDetector.
+func (g *GitDetector) SetItemProgress(fn func(analysis.ItemProgress)) {
+	g.itemProgress = fn
+}
+
+// reportCommit calls the item progress callback, if any, for pair.
+func (g *GitDetector) reportCommit(index, total int, pair *git.CommitPair, flagged int, detection *analysis.Detection, skipped string) {
+	if g.itemProgress == nil {
+		return
+	}
+	item := analysis.ItemProgress{
+		Kind:            "commit",
+		ID:              pair.Current.Hash,
+		Current:         index + 1,
+		Total:           total,
+		Skipped:         skipped,
+		SuspiciousSoFar: flagged,
+	}
+	if detection != nil {
+		item.Suspicious = true
+		item.Score = detection.Score
+		item.Severity = detection.Severity
+	}
+	g.itemProgress(item)
+}
+
+// CommitTrace is the explain output for one commit pair.
+type CommitTrace struct {
+	Hash       string                   `json:"hash"`
+	Message    string                   `json:"message"`
+	Skipped    string                   `json:"skipped,omitempty"`
+	Strategies []patterns.StrategyTrace `json:"strategies,omitempty"`
 }
 
 func NewGitDetector(thresholds *patterns.Thresholds) *GitDetector {
@@ -31,7 +128,7 @@ func NewGitDetector(thresholds *patterns.Thresholds) *GitDetector {
 			EnablePrecisionAnalysis: true,
 		}
 	}
-	return &GitDetector{Thresholds: thresholds}
+	return &GitDetector{Thresholds: thresholds, Registry: analysis.DefaultGitRegistry()}
 }
 
 // NewGitDetectorWithConfig creates a GitDetector with strategy enable/disable support.
@@ -58,52 +155,135 @@ func (g *GitDetector) Detect(ctx context.Context, data *analysis.SourceData) ([]
 
 	repoStats := &metrics.RepositoryStats{}
 
+	// Incremental sources supply older pairs purely as baseline context.
+	baselinePairs := pairs
+	if extra, ok := data.Metadata["baseline_pairs"].([]*git.CommitPair); ok && len(extra) > 0 {
+		baselinePairs = make([]*git.CommitPair, 0, len(pairs)+len(extra))
+		baselinePairs = append(baselinePairs, pairs...)
+		baselinePairs = append(baselinePairs, extra...)
+	}
+
+	anomalyStrategy, baselineCaveat := "", ""
 	for _, strategy := range strategies {
-		if statStrategy, ok := strategy.(*patterns.StatisticalAnomalyStrategy); ok {
-			statStrategy.SetBaseline(pairs)
-			break
+		if baselined, ok := strategy.(patterns.BaselineStrategy); ok {
+			baselined.SetBaseline(baselinePairs)
+		}
+		if anomaly, ok := strategy.(*patterns.StatisticalAnomalyStrategy); ok {
+			if caveat := anomaly.Caveat(); caveat != "" {
+				data.Metadata[analysis.MetricBaselineCaveat] = caveat
+				anomalyStrategy, baselineCaveat = anomaly.Name(), caveat
+			}
 		}
 	}
+	g.Baseline = analysis.CalculateBaseline(baselinePairs)
+	if g.HistoricalBaseline != nil {
+		data.Metadata["baseline_drift"] = analysis.CompareBaselines(g.HistoricalBaseline, g.Baseline, 0)
+	}
+
+	confidences := make([]float64, len(strategies))
+	totalConfidence := 0.0
+	for i, strategy := range strategies {
+		confidences[i] = g.strategyConfidence(strategy)
+		totalConfidence += confidences[i]
+	}
 
 	detections := make([]analysis.Detection, 0)
+	scoredCommits := 0
+	strategyHits := 0
+	weightedSum := 0.0
+	suppressed := 0
+	hidden := 0
+	elapsed := make([]time.Duration, len(strategies))
+	firedAny := make([]bool, len(strategies))
+	reported := make([]bool, len(strategies)) // fired after suppressions
+
+	g.Traces = nil
+
+	// A source whose merge strategy pairs merges against their first parent
+	// records it in "merge_strategy"; those merge pairs are scored like any
+	// other commit. Merges are skipped otherwise.
+	mergeStrategy, _ := data.Metadata["merge_strategy"].(string)
+	scoreMerges := mergeStrategy != "" && git.MergeStrategy(mergeStrategy) != git.MergeSkip
+
+	for index, pair := range pairs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 
-	for _, pair := range pairs {
 		if pair.Stats.Additions == 0 && pair.Stats.Deletions == 0 {
+			g.traceSkipped(pair, "no changes")
+			g.reportCommit(index, len(pairs), pair, len(detections)+hidden, nil, "no changes")
 			continue
 		}
 
-		if len(pair.Current.Parents) > 1 {
+		if len(pair.Current.Parents) > 1 && !scoreMerges {
+			g.traceSkipped(pair, "merge commit")
+			g.reportCommit(index, len(pairs), pair, len(detections)+hidden, nil, "merge commit")
 			continue
 		}
+		scoredCommits++
 
 		type strategyHit struct {
+			strategy   string
 			reason     string
 			category   string
 			confidence float64
+			hidden     bool // below MinReportConfidence
 		}
 
 		hits := make([]strategyHit, 0)
 
-		for _, strategy := range strategies {
-			detected, reason := strategy.Detect(pair, repoStats)
+		var trace *CommitTrace
+		if g.Explain {
+			g.Traces = append(g.Traces, CommitTrace{Hash: pair.Current.Hash, Message: pair.Current.Message})
+			trace = &g.Traces[len(g.Traces)-1]
+		}
+
+		for i, strategy := range strategies {
+			var detected bool
+			var reason string
+			start := time.Now()
+			if trace != nil {
+				st := patterns.ExplainStrategy(strategy, pair, repoStats)
+				trace.Strategies = append(trace.Strategies, st)
+				detected, reason = st.Fired, st.Reason
+			} else {
+				detected, reason = strategy.Detect(pair, repoStats)
+			}
+			elapsed[i] += time.Since(start)
+			firedAny[i] = firedAny[i] || detected
+			if detected && g.Suppressions.Suppressed(strategy.Name(), data.ID, pair.Current.Hash) {
+				suppressed++
+				detected = false
+			}
 			if detected {
+				reported[i] = true
 				hits = append(hits, strategyHit{
+					strategy:   strategy.Name(),
 					reason:     reason,
 					category:   strategy.Category(),
-					confidence: strategy.Confidence(),
+					confidence: confidences[i],
+					hidden:     confidences[i] < g.MinReportConfidence,
 				})
 			}
 		}
 
 		if len(hits) > 0 {
-			score := float64(len(hits)) / float64(len(strategies))
+			strategyHits += len(hits)
 
-			// Weight score by average confidence of triggered strategies
-			totalConfidence := 0.0
+			// Score is the confidence-weighted share of strategies that fired,
+			// so a low-confidence hit moves it less than a high-confidence one.
+			hitConfidence := 0.0
+			missProbability := 1.0
 			for _, h := range hits {
-				totalConfidence += h.confidence
+				hitConfidence += h.confidence
+				missProbability *= 1 - h.confidence
 			}
-			avgConfidence := totalConfidence / float64(len(hits))
+			score := hitConfidence / totalConfidence
+
+			// Confidence that at least one of the fired strategies is right.
+			combinedConfidence := 1 - missProbability
+			weightedSum += combinedConfidence
 
 			severity := "low"
 			if score >= 0.7 {
@@ -114,8 +294,16 @@ func (g *GitDetector) Detect(ctx context.Context, data *analysis.SourceData) ([]
 
 			examples := make([]string, 0, len(hits)+1)
 			examples = append(examples, pair.Current.Hash)
+			fired := make([]string, 0, len(hits))
 			for _, h := range hits {
+				if h.hidden {
+					continue
+				}
 				examples = append(examples, h.reason)
+				fired = append(fired, h.strategy)
+			}
+			if files := topFilesReason(pair.Stats); files != "" {
+				examples = append(examples, files)
 			}
 
 			// Use the most common category from triggered strategies
@@ -137,20 +325,216 @@ func (g *GitDetector) Detect(ctx context.Context, data *analysis.SourceData) ([]
 				Detected:    true,
 				Severity:    severity,
 				Score:       score,
-				Confidence:  avgConfidence,
+				Confidence:  combinedConfidence,
 				Category:    topCategory,
 				Description: pair.Current.Message,
 				Examples:    examples,
+				Strategies:  fired,
+				Files:       changedFiles(pair.Stats),
+			}
+			for _, name := range fired {
+				if name == anomalyStrategy {
+					detection.LowConfidence = true
+					detection.Caveat = baselineCaveat
+				}
+			}
+			if len(fired) == 0 {
+				// Hidden detections only feed the report's stats, which count
+				// the strategies they name.
+				for _, h := range hits {
+					detection.Strategies = append(detection.Strategies, h.strategy)
+				}
+				analysis.HideDetections(data.Metadata, detection)
+				hidden++
+			} else {
+				detections = append(detections, detection)
+			}
+			g.reportCommit(index, len(pairs), pair, len(detections)+hidden, &detection, "")
+		} else {
+			g.reportCommit(index, len(pairs), pair, len(detections)+hidden, nil, "")
+		}
+	}
+
+	if g.Metrics != nil {
+		for i, strategy := range strategies {
+			g.Metrics.RecordStrategyExecution(strategy.Name(), firedAny[i], elapsed[i])
+		}
+	}
+
+	// History detections are not commits, so they stay out of the count.
+	suspiciousCommits := len(detections) + hidden
+
+	var passed []analysis.Detection
+	if g.IncludePassed && scoredCommits > 0 {
+		for i, strategy := range strategies {
+			if !reported[i] {
+				passed = g.appendPassed(data, passed, passedDetection(strategy, confidences[i]))
 			}
-			detections = append(detections, detection)
 		}
 	}
 
-	data.Metadata["suspicious_count"] = len(detections)
+	// reportHistory turns the hits of a whole-history strategy into
+	// detections attributed to the commits they name.
+	reportHistory := func(strategy interface {
+		Name() string
+		Category() string
+		Confidence() float64
+		Description() string
+	}, hits []patterns.HistoryHit) {
+		confidence := g.strategyConfidence(strategy)
+		reportedAny := false
+		for _, hit := range hits {
+			if g.Suppressions.Suppressed(strategy.Name(), data.ID, hit.Commit) {
+				suppressed++
+				continue
+			}
+			reportedAny = true
+			detection := analysis.Detection{
+				Strategy:    strategy.Name(),
+				Detected:    true,
+				Severity:    "medium",
+				Score:       confidence,
+				Confidence:  confidence,
+				Category:    strategy.Category(),
+				Description: strategy.Description(),
+				Examples:    []string{hit.Commit, hit.Reason},
+				Strategies:  []string{strategy.Name()},
+			}
+			if confidence < g.MinReportConfidence {
+				analysis.HideDetections(data.Metadata, detection)
+				hidden++
+			} else {
+				detections = append(detections, detection)
+			}
+		}
+		if !reportedAny && g.IncludePassed {
+			passed = g.appendPassed(data, passed, passedDetection(strategy, confidence))
+		}
+	}
+
+	if commits, ok := data.Metadata["commits"].([]*git.Commit); ok {
+		for _, strategy := range g.buildHistoryStrategies() {
+			start := time.Now()
+			detected, commit, reason := strategy.DetectHistory(commits)
+			if g.Metrics != nil {
+				g.Metrics.RecordStrategyExecution(strategy.Name(), detected, time.Since(start))
+			}
+			var hits []patterns.HistoryHit
+			if detected {
+				hits = []patterns.HistoryHit{{Commit: commit, Reason: reason}}
+			}
+			reportHistory(strategy, hits)
+		}
+	}
+
+	for _, strategy := range g.buildPairHistoryStrategies() {
+		start := time.Now()
+		hits := strategy.DetectPairHistory(pairs)
+		if g.Metrics != nil {
+			g.Metrics.RecordStrategyExecution(strategy.Name(), len(hits) > 0, time.Since(start))
+		}
+		reportHistory(strategy, hits)
+	}
+
+	detections = append(detections, passed...)
+
+	data.Metadata["scored_commit_count"] = scoredCommits
+	data.Metadata["strategy_hit_count"] = strategyHits
+	if scoredCommits > 0 {
+		data.Metadata[analysis.MetricWeightedScore] = 100 * weightedSum / float64(scoredCommits)
+	}
+	data.Metadata["suspicious_count"] = suspiciousCommits
+	data.Metadata["suppressed_count"] = suppressed
 
 	return detections, nil
 }
 
+// passedDetection is the record IncludePassed adds for a strategy that ran
+// without firing.
+func passedDetection(s interface {
+	Name() string
+	Category() string
+	Description() string
+}, confidence float64) analysis.Detection {
+	return analysis.Detection{
+		Strategy:    s.Name(),
+		Detected:    false,
+		Severity:    "none",
+		Score:       0,
+		Confidence:  confidence,
+		Category:    s.Category(),
+		Description: s.Description(),
+		Strategies:  []string{s.Name()},
+	}
+}
+
+// appendPassed appends a passed record to passed, or hides it when its
+// confidence is below MinReportConfidence.
+func (g *GitDetector) appendPassed(data *analysis.SourceData, passed []analysis.Detection, detection analysis.Detection) []analysis.Detection {
+	if detection.Confidence < g.MinReportConfidence {
+		analysis.HideDetections(data.Metadata, detection)
+		return passed
+	}
+	return append(passed, detection)
+}
+
+// maxReasonFiles is how many files the "Top files" reason of a flagged
+// commit lists.
+const maxReasonFiles = 3
+
+// topFilesReason names the files that contributed most to a flagged commit,
+// so reviewers can go straight to them. It is empty when the diff has no
+// per-file breakdown.
+func topFilesReason(stats *git.DiffStats) string {
+	top := stats.TopFiles(maxReasonFiles)
+	if len(top) == 0 {
+		return ""
+	}
+	parts := make([]string, len(top))
+	for i, f := range top {
+		parts[i] = fmt.Sprintf("%s (+%d/-%d)", f.Path, f.Additions, f.Deletions)
+	}
+	reason := "Top files: " + strings.Join(parts, ", ")
+	if more := len(stats.Files) - len(top); more > 0 {
+		reason += fmt.Sprintf(" and %d more", more)
+	}
+	return reason
+}
+
+// changedFiles lists the paths of the files with the most changed lines,
+// up to analysis.MaxDetectionFiles.
+func changedFiles(stats *git.DiffStats) []string {
+	top := stats.TopFiles(analysis.MaxDetectionFiles)
+	if len(top) == 0 {
+		return nil
+	}
+	paths := make([]string, len(top))
+	for i, f := range top {
+		paths[i] = f.Path
+	}
+	return paths
+}
+
+func (g *GitDetector) traceSkipped(pair *git.CommitPair, why string) {
+	if g.Explain {
+		g.Traces = append(g.Traces, CommitTrace{Hash: pair.Current.Hash, Message: pair.Current.Message, Skipped: why})
+	}
+}
+
+// strategyConfidence returns the registry confidence for a strategy, falling
+// back to the strategy's own value when it is not registered.
+func (g *GitDetector) strategyConfidence(s interface {
+	Name() string
+	Confidence() float64
+}) float64 {
+	if g.Registry != nil {
+		if info, ok := g.Registry.Get(s.Name()); ok && info.Confidence > 0 {
+			return info.Confidence
+		}
+	}
+	return s.Confidence()
+}
+
 func (g *GitDetector) buildStrategies() ([]patterns.DetectionStrategy, error) {
 	if err := g.Thresholds.Validate(); err != nil {
 		return nil, cerrors.ValidationError("invalid thresholds").Wrap(err)
@@ -182,23 +566,41 @@ func (g *GitDetector) buildStrategies() ([]patterns.DetectionStrategy, error) {
 		strategies = append(strategies, patterns.NewPrecisionStrategy(0.85))
 	}
 
+	var profiles map[string]patterns.LanguageProfile
+	if len(g.LanguageProfiles) > 0 {
+		profiles = patterns.MergeLanguageProfiles(g.LanguageProfiles)
+	}
+
 	strategies = append(strategies,
 		patterns.NewCommitMessageStrategy(),
-		patterns.NewNamingPatternStrategy(),
+		patterns.NewMessageDiffMismatchStrategy(0),
+		patterns.NewNamingPatternStrategyWithProfiles(profiles),
 		patterns.NewStructuralConsistencyStrategy(),
 		patterns.NewBurstPatternStrategy(10),
-		patterns.NewErrorHandlingPatternStrategy(),
+		patterns.NewErrorHandlingPatternStrategyWithProfiles(profiles),
 		patterns.NewTemplatePatternStrategy(),
 		patterns.NewFileExtensionPatternStrategy(),
-		patterns.NewStatisticalAnomalyStrategy(),
+		patterns.NewBoilerplateHeaderStrategy(0, 0),
+		patterns.NewDependencyAdditionStrategy(g.DependencyManifests, 10),
+		patterns.NewStatisticalAnomalyStrategyWithGate(g.HistoricalBaseline, g.SampleSizeGate),
+		patterns.NewRoundStatisticsStrategy(0),
+		patterns.NewCodeEntropyStrategy(0, 0),
+		patterns.NewNGramRepetitionStrategy(g.NGramMaxCoverage),
+		patterns.NewRewriteSimilarityStrategy(0, 0),
 		patterns.NewTimingAnomalyStrategy(),
+		patterns.NewSignatureStrategy(0, 0),
+		patterns.NewTimezoneAnomalyStrategy(0, 0),
+		patterns.NewMessageStyleConsistencyStrategy(0),
+		patterns.NewAICoauthorStrategy(g.AIAssistants),
+		patterns.NewEmojiPatternStrategy(),
+		patterns.NewSpecialCharacterPatternStrategy(),
 	)
 
-	// Filter out strategies disabled via config
-	if g.StrategyConfig != nil {
+	// Filter out strategies disabled via config or for this detector
+	if g.StrategyConfig != nil || len(g.DisabledStrategies) > 0 {
 		filtered := make([]patterns.DetectionStrategy, 0, len(strategies))
 		for _, s := range strategies {
-			if g.StrategyConfig.IsEnabled(s.Name()) {
+			if g.strategyEnabled(s.Name()) {
 				filtered = append(filtered, s)
 			}
 		}
@@ -207,3 +609,46 @@ func (g *GitDetector) buildStrategies() ([]patterns.DetectionStrategy, error) {
 
 	return strategies, nil
 }
+
+// buildHistoryStrategies returns the enabled strategies that judge the
+// analyzed history as a whole. They run after the per-commit strategies and
+// do not count toward per-commit scores.
+func (g *GitDetector) buildHistoryStrategies() []patterns.HistoryStrategy {
+	all := []patterns.HistoryStrategy{
+		patterns.NewCommitTopologyStrategy(0, 0),
+	}
+
+	strategies := make([]patterns.HistoryStrategy, 0, len(all))
+	for _, s := range all {
+		if g.strategyEnabled(s.Name()) {
+			strategies = append(strategies, s)
+		}
+	}
+	return strategies
+}
+
+// buildPairHistoryStrategies returns the enabled strategies that follow
+// files across the analyzed commit pairs. Like the history strategies they
+// run after the per-commit strategies.
+func (g *GitDetector) buildPairHistoryStrategies() []patterns.PairHistoryStrategy {
+	all := []patterns.PairHistoryStrategy{
+		patterns.NewCodeChurnStrategy(g.Churn),
+	}
+
+	strategies := make([]patterns.PairHistoryStrategy, 0, len(all))
+	for _, s := range all {
+		if g.strategyEnabled(s.Name()) {
+			strategies = append(strategies, s)
+		}
+	}
+	return strategies
+}
+
+// strategyEnabled reports whether the named strategy is disabled neither
+// through StrategyConfig nor for this detector.
+func (g *GitDetector) strategyEnabled(name string) bool {
+	if g.DisabledStrategies[name] {
+		return false
+	}
+	return g.StrategyConfig == nil || g.StrategyConfig.IsEnabled(name)
+}
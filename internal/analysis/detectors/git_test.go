@@ -0,0 +1,858 @@
+package detectors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+	"github.com/TryCadence/Cadence/internal/config"
+)
+
+// onlyStrategies returns a detector running just the named strategies.
+func onlyStrategies(t *testing.T, thresholds *patterns.Thresholds, keep ...string) *GitDetector {
+	t.Helper()
+
+	d := NewGitDetector(thresholds)
+	all, err := d.buildStrategies()
+	if err != nil {
+		t.Fatalf("buildStrategies() unexpected error = %v", err)
+	}
+
+	kept := make(map[string]bool, len(keep))
+	for _, name := range keep {
+		kept[name] = true
+	}
+	d.StrategyConfig = &config.StrategyConfig{DisabledStrategies: map[string]bool{}}
+	for _, s := range all {
+		if !kept[s.Name()] {
+			d.StrategyConfig.DisabledStrategies[s.Name()] = true
+		}
+	}
+	for _, s := range d.buildPairHistoryStrategies() {
+		if !kept[s.Name()] {
+			d.StrategyConfig.DisabledStrategies[s.Name()] = true
+		}
+	}
+	return d
+}
+
+func testPair(hash string, additions int64, delta time.Duration) *git.CommitPair {
+	return &git.CommitPair{
+		Previous:  &git.Commit{Hash: hash + "-prev"},
+		Current:   &git.Commit{Hash: hash, Message: "update", Parents: []string{hash + "-prev"}},
+		TimeDelta: delta,
+		Stats:     &git.DiffStats{Additions: additions, FilesChanged: 1},
+	}
+}
+
+func TestGitDetector_ConfidenceWeightedScore(t *testing.T) {
+	thresholds := &patterns.Thresholds{SuspiciousAdditions: 100, MinTimeDeltaSeconds: 60}
+	d := onlyStrategies(t, thresholds, "size_analysis", "timing_analysis")
+
+	d.Registry = analysis.NewStrategyRegistry()
+	d.Registry.Register(analysis.StrategyInfo{Name: "size_analysis", Confidence: 0.9})
+	d.Registry.Register(analysis.StrategyInfo{Name: "timing_analysis", Confidence: 0.3})
+
+	data := &analysis.SourceData{
+		Type: "git",
+		RawContent: []*git.CommitPair{
+			testPair("high", 500, time.Hour),     // size only
+			testPair("low", 10, 5*time.Second),   // timing only
+			testPair("both", 500, 5*time.Second), // size and timing
+			testPair("clean", 10, time.Hour),     // nothing
+		},
+		Metadata: map[string]interface{}{},
+	}
+
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 3 {
+		t.Fatalf("Detect() returned %d detections, want 3", len(detections))
+	}
+
+	byHash := make(map[string]analysis.Detection)
+	for _, det := range detections {
+		byHash[det.Examples[0]] = det
+	}
+
+	tests := []struct {
+		hash           string
+		wantScore      float64
+		wantConfidence float64
+		wantSeverity   string
+	}{
+		{hash: "high", wantScore: 0.75, wantConfidence: 0.9, wantSeverity: "high"},
+		{hash: "low", wantScore: 0.25, wantConfidence: 0.3, wantSeverity: "low"},
+		{hash: "both", wantScore: 1, wantConfidence: 1 - 0.1*0.7, wantSeverity: "high"},
+	}
+	for _, tt := range tests {
+		det, ok := byHash[tt.hash]
+		if !ok {
+			t.Errorf("no detection for %s", tt.hash)
+			continue
+		}
+		if math.Abs(det.Score-tt.wantScore) > 1e-9 {
+			t.Errorf("%s: Score = %v, want %v", tt.hash, det.Score, tt.wantScore)
+		}
+		if math.Abs(det.Confidence-tt.wantConfidence) > 1e-9 {
+			t.Errorf("%s: Confidence = %v, want %v", tt.hash, det.Confidence, tt.wantConfidence)
+		}
+		if det.Severity != tt.wantSeverity {
+			t.Errorf("%s: Severity = %s, want %s", tt.hash, det.Severity, tt.wantSeverity)
+		}
+	}
+
+	if both := byHash["both"]; len(both.Strategies) != 2 || len(both.Strategies) != len(both.Examples)-1 {
+		t.Errorf("both: Strategies = %v, want the two fired strategies aligned with reasons %v", both.Strategies, both.Examples[1:])
+	} else if both.Strategies[0] != "size_analysis" || both.Strategies[1] != "timing_analysis" {
+		t.Errorf("both: Strategies = %v, want [size_analysis timing_analysis]", both.Strategies)
+	}
+
+	// (0.9 + 0.3 + 0.93 + 0) / 4 commits
+	wantWeighted := 100 * (0.9 + 0.3 + 0.93) / 4
+	if got, _ := data.Metadata[analysis.MetricWeightedScore].(float64); math.Abs(got-wantWeighted) > 1e-9 {
+		t.Errorf("weighted_score = %v, want %v", got, wantWeighted)
+	}
+	if got := data.Metadata["strategy_hit_count"]; got != 4 {
+		t.Errorf("strategy_hit_count = %v, want 4", got)
+	}
+	if got := data.Metadata["suspicious_count"]; got != 3 {
+		t.Errorf("suspicious_count = %v, want 3", got)
+	}
+	if got := data.Metadata["scored_commit_count"]; got != 4 {
+		t.Errorf("scored_commit_count = %v, want 4", got)
+	}
+}
+
+func TestGitDetector_LowConfidenceMovesScoreLess(t *testing.T) {
+	thresholds := &patterns.Thresholds{SuspiciousAdditions: 100}
+	pairs := []*git.CommitPair{testPair("a", 500, time.Hour), testPair("b", 10, time.Hour)}
+
+	score := func(confidence float64) float64 {
+		d := onlyStrategies(t, thresholds, "size_analysis")
+		d.Registry = analysis.NewStrategyRegistry()
+		d.Registry.Register(analysis.StrategyInfo{Name: "size_analysis", Confidence: confidence})
+		data := &analysis.SourceData{Type: "git", RawContent: pairs, Metadata: map[string]interface{}{}}
+		if _, err := d.Detect(context.Background(), data); err != nil {
+			t.Fatalf("Detect() unexpected error = %v", err)
+		}
+		return data.Metadata[analysis.MetricWeightedScore].(float64)
+	}
+
+	low, high := score(0.4), score(0.8)
+	if low >= high {
+		t.Errorf("weighted score with confidence 0.4 (%v) should be below 0.8 (%v)", low, high)
+	}
+}
+
+func TestGitDetector_TopFilesReason(t *testing.T) {
+	d := onlyStrategies(t, &patterns.Thresholds{SuspiciousAdditions: 100}, "size_analysis")
+
+	pair := testPair("big", 500, time.Hour)
+	pair.Stats.Files = []git.FileStat{
+		{Path: "README.md", Additions: 5},
+		{Path: "gen/api.go", Additions: 300, Deletions: 20},
+		{Path: "internal/server.go", Additions: 150},
+		{Path: "internal/client.go", Additions: 40, Deletions: 5},
+		{Path: "go.sum", Additions: 5},
+	}
+	data := &analysis.SourceData{Type: "git", RawContent: []*git.CommitPair{pair}, Metadata: map[string]interface{}{}}
+
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 1 {
+		t.Fatalf("Detect() returned %d detections, want 1", len(detections))
+	}
+
+	examples := detections[0].Examples
+	want := "Top files: gen/api.go (+300/-20), internal/server.go (+150/-0), internal/client.go (+40/-5) and 2 more"
+	if got := examples[len(examples)-1]; got != want {
+		t.Errorf("last reason = %q, want %q", got, want)
+	}
+	if len(examples) != len(detections[0].Strategies)+2 {
+		t.Errorf("Examples = %v, want hash, one reason per strategy, then the top files", examples)
+	}
+	wantFiles := []string{"gen/api.go", "internal/server.go", "internal/client.go", "README.md", "go.sum"}
+	if got := detections[0].Files; !reflect.DeepEqual(got, wantFiles) {
+		t.Errorf("Files = %v, want %v", got, wantFiles)
+	}
+}
+
+func TestGitDetector_UsesDefaultRegistryConfidence(t *testing.T) {
+	d := NewGitDetector(nil)
+	strategies, err := d.buildStrategies()
+	if err != nil {
+		t.Fatalf("buildStrategies() unexpected error = %v", err)
+	}
+	registry := analysis.DefaultGitRegistry()
+	for _, s := range strategies {
+		info, ok := registry.Get(s.Name())
+		if !ok {
+			continue
+		}
+		if got := d.strategyConfidence(s); got != info.Confidence {
+			t.Errorf("%s: strategyConfidence() = %v, want registry value %v", s.Name(), got, info.Confidence)
+		}
+	}
+}
+
+func TestGitDetector_BuildsSymbolStrategies(t *testing.T) {
+	d := NewGitDetector(nil)
+	strategies, err := d.buildStrategies()
+	if err != nil {
+		t.Fatalf("buildStrategies() unexpected error = %v", err)
+	}
+	built := make(map[string]bool, len(strategies))
+	for _, s := range strategies {
+		built[s.Name()] = true
+	}
+	for _, name := range []string{"emoji_pattern_analysis", "special_character_pattern_analysis"} {
+		if _, ok := analysis.DefaultGitRegistry().Get(name); !ok {
+			t.Errorf("%s is not in the default registry", name)
+		}
+		if !built[name] {
+			t.Errorf("%s is registered but never built", name)
+		}
+	}
+}
+
+type pairSource struct {
+	pairs []*git.CommitPair
+}
+
+func (s *pairSource) Type() string                       { return "git" }
+func (s *pairSource) Validate(ctx context.Context) error { return nil }
+func (s *pairSource) Fetch(ctx context.Context) (*analysis.SourceData, error) {
+	return &analysis.SourceData{ID: "test", Type: "git", RawContent: s.pairs, Metadata: map[string]interface{}{}}, nil
+}
+
+func TestGitDetector_OverallScoreIsWeighted(t *testing.T) {
+	d := onlyStrategies(t, &patterns.Thresholds{SuspiciousAdditions: 100}, "size_analysis")
+	d.Registry = analysis.NewStrategyRegistry()
+	d.Registry.Register(analysis.StrategyInfo{Name: "size_analysis", Confidence: 0.8})
+
+	source := &pairSource{pairs: []*git.CommitPair{testPair("a", 500, time.Hour), testPair("b", 10, time.Hour)}}
+	report, err := analysis.NewDefaultDetectionRunner().Run(context.Background(), source, d)
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+
+	if math.Abs(report.OverallScore-40) > 1e-9 {
+		t.Errorf("OverallScore = %v, want 40 (one of two commits at confidence 0.8)", report.OverallScore)
+	}
+	if report.Assessment != "Moderate Suspicion" {
+		t.Errorf("Assessment = %q, want Moderate Suspicion", report.Assessment)
+	}
+	if got := report.Metrics["strategy_hit_count"]; got != 1 {
+		t.Errorf("strategy_hit_count = %v, want 1", got)
+	}
+}
+
+func TestGitDetector_MinReportConfidence(t *testing.T) {
+	thresholds := &patterns.Thresholds{SuspiciousAdditions: 100, MinTimeDeltaSeconds: 60}
+	d := onlyStrategies(t, thresholds, "size_analysis", "timing_analysis")
+	d.Registry = analysis.NewStrategyRegistry()
+	d.Registry.Register(analysis.StrategyInfo{Name: "size_analysis", Confidence: 0.9})
+	d.Registry.Register(analysis.StrategyInfo{Name: "timing_analysis", Confidence: 0.3})
+	d.MinReportConfidence = 0.5
+
+	source := &pairSource{pairs: []*git.CommitPair{
+		testPair("low", 10, 5*time.Second),   // timing only: hidden
+		testPair("both", 500, 5*time.Second), // timing left out of the reasons
+		testPair("clean", 10, time.Hour),
+	}}
+	report, err := analysis.NewDefaultDetectionRunner().Run(context.Background(), source, d)
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+
+	if len(report.Detections) != 1 || report.Detections[0].Examples[0] != "both" {
+		t.Fatalf("Detections = %+v, want only the commit with a confident hit", report.Detections)
+	}
+	if both := report.Detections[0]; len(both.Strategies) != 1 || both.Strategies[0] != "size_analysis" || len(both.Examples) != 2 {
+		t.Errorf("both: Strategies = %v, Examples = %v, want only size_analysis", both.Strategies, both.Examples)
+	}
+	if both := report.Detections[0]; math.Abs(both.Confidence-(1-0.1*0.7)) > 1e-9 {
+		t.Errorf("both: Confidence = %v, want the hidden hit still combined in", both.Confidence)
+	}
+
+	if report.DetectionCount != 2 || report.TotalDetections != 2 {
+		t.Errorf("DetectionCount = %d, TotalDetections = %d, want the hidden commit counted", report.DetectionCount, report.TotalDetections)
+	}
+	if wantScore := 100 * (0.3 + 0.93) / 3; math.Abs(report.OverallScore-wantScore) > 1e-9 {
+		t.Errorf("OverallScore = %v, want %v", report.OverallScore, wantScore)
+	}
+	if got := report.Metrics[analysis.MetricHiddenDetectionCount]; got != 1 {
+		t.Errorf("%s = %v, want 1", analysis.MetricHiddenDetectionCount, got)
+	}
+	if _, ok := report.Metrics[analysis.MetricHiddenDetections]; ok {
+		t.Errorf("hidden detections should not be left in the report metrics")
+	}
+}
+
+func TestGitDetector_IncludePassed(t *testing.T) {
+	thresholds := &patterns.Thresholds{SuspiciousAdditions: 100, MinTimeDeltaSeconds: 60}
+	source := &pairSource{pairs: []*git.CommitPair{
+		testPair("big", 500, time.Hour), // size only
+		testPair("clean", 10, time.Hour),
+	}}
+
+	d := onlyStrategies(t, thresholds, "size_analysis", "timing_analysis")
+	report, err := analysis.NewDefaultDetectionRunner().Run(context.Background(), source, d)
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+	if len(report.Detections) != 1 {
+		t.Fatalf("Detections = %+v, want only the flagged commit by default", report.Detections)
+	}
+	if sm := report.SourceMetrics; sm.StrategiesUsed != 1 || sm.StrategiesHit != 1 {
+		t.Errorf("StrategiesUsed = %d, StrategiesHit = %d, want 1 and 1", sm.StrategiesUsed, sm.StrategiesHit)
+	}
+
+	d = onlyStrategies(t, thresholds, "size_analysis", "timing_analysis")
+	d.IncludePassed = true
+	report, err = analysis.NewDefaultDetectionRunner().Run(context.Background(), source, d)
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+	if len(report.Detections) != 2 {
+		t.Fatalf("Detections = %+v, want the flagged commit and one passed strategy", report.Detections)
+	}
+	passed := report.Detections[1]
+	if passed.Detected || passed.Strategy != "timing_analysis" || passed.Severity != "none" || passed.Category == "" {
+		t.Errorf("passed record = %+v, want an undetected timing_analysis record", passed)
+	}
+	if report.DetectionCount != 1 || report.PassedDetections != 1 {
+		t.Errorf("DetectionCount = %d, PassedDetections = %d, want 1 and 1", report.DetectionCount, report.PassedDetections)
+	}
+	if sm := report.SourceMetrics; sm.StrategiesUsed != 2 || sm.StrategiesHit != 1 {
+		t.Errorf("StrategiesUsed = %d, StrategiesHit = %d, want 2 and 1", sm.StrategiesUsed, sm.StrategiesHit)
+	}
+	if sm := report.SourceMetrics; sm.ItemsFlagged != 1 {
+		t.Errorf("ItemsFlagged = %d, want passed strategies left out", sm.ItemsFlagged)
+	}
+
+	// Below the confidence floor a passed record is hidden like a hit.
+	d = onlyStrategies(t, thresholds, "size_analysis", "timing_analysis")
+	d.IncludePassed = true
+	d.Registry = analysis.NewStrategyRegistry()
+	d.Registry.Register(analysis.StrategyInfo{Name: "size_analysis", Confidence: 0.9})
+	d.Registry.Register(analysis.StrategyInfo{Name: "timing_analysis", Confidence: 0.3})
+	d.MinReportConfidence = 0.5
+	report, err = analysis.NewDefaultDetectionRunner().Run(context.Background(), source, d)
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+	if len(report.Detections) != 1 || !report.Detections[0].Detected {
+		t.Errorf("Detections = %+v, want the low-confidence passed record hidden", report.Detections)
+	}
+	if report.PassedDetections != 1 || report.Metrics[analysis.MetricHiddenDetectionCount] != 1 {
+		t.Errorf("PassedDetections = %d, hidden = %v, want the hidden record still counted", report.PassedDetections, report.Metrics[analysis.MetricHiddenDetectionCount])
+	}
+}
+
+func TestGitDetector_Explain(t *testing.T) {
+	d := onlyStrategies(t, &patterns.Thresholds{SuspiciousAdditions: 100, MinTimeDeltaSeconds: 60}, "size_analysis", "timing_analysis")
+	d.Explain = true
+
+	merge := testPair("merge", 10, time.Hour)
+	merge.Current.Parents = []string{"p1", "p2"}
+	data := &analysis.SourceData{
+		Type:       "git",
+		RawContent: []*git.CommitPair{testPair("big", 500, time.Hour), merge},
+		Metadata:   map[string]interface{}{},
+	}
+
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 1 {
+		t.Fatalf("Detect() returned %d detections, want 1", len(detections))
+	}
+
+	if len(d.Traces) != 2 {
+		t.Fatalf("Traces = %d, want 2", len(d.Traces))
+	}
+	big := d.Traces[0]
+	if big.Hash != "big" || len(big.Strategies) != 2 {
+		t.Fatalf("first trace = %+v, want both strategies for commit big", big)
+	}
+	fired := map[string]bool{}
+	for _, st := range big.Strategies {
+		fired[st.Strategy] = st.Fired
+	}
+	if !fired["size_analysis"] || fired["timing_analysis"] {
+		t.Errorf("fired = %v, want only size_analysis", fired)
+	}
+	if d.Traces[1].Skipped != "merge commit" {
+		t.Errorf("merge trace Skipped = %q, want merge commit", d.Traces[1].Skipped)
+	}
+
+	d.Explain = false
+	if _, err := d.Detect(context.Background(), data); err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if d.Traces != nil {
+		t.Errorf("Traces should be empty when Explain is off, got %d", len(d.Traces))
+	}
+}
+
+func TestGitDetector_MergePairs(t *testing.T) {
+	newData := func(strategy string) *analysis.SourceData {
+		merge := testPair("merge", 500, time.Hour)
+		merge.Current.Parents = []string{"p1", "p2"}
+		data := &analysis.SourceData{
+			Type:       "git",
+			RawContent: []*git.CommitPair{merge, testPair("small", 10, time.Hour)},
+			Metadata:   map[string]interface{}{},
+		}
+		if strategy != "" {
+			data.Metadata["merge_strategy"] = strategy
+		}
+		return data
+	}
+
+	for _, strategy := range []string{"", string(git.MergeSkip)} {
+		d := onlyStrategies(t, &patterns.Thresholds{SuspiciousAdditions: 100}, "size_analysis")
+		detections, err := d.Detect(context.Background(), newData(strategy))
+		if err != nil {
+			t.Fatalf("Detect() unexpected error = %v", err)
+		}
+		if len(detections) != 0 {
+			t.Errorf("merge strategy %q: %d detections, want the merge skipped", strategy, len(detections))
+		}
+	}
+
+	for _, strategy := range []git.MergeStrategy{git.MergeFirstParent, git.MergeBoth} {
+		d := onlyStrategies(t, &patterns.Thresholds{SuspiciousAdditions: 100}, "size_analysis")
+		data := newData(string(strategy))
+		detections, err := d.Detect(context.Background(), data)
+		if err != nil {
+			t.Fatalf("Detect() unexpected error = %v", err)
+		}
+		if len(detections) != 1 || detections[0].Examples[0] != "merge" {
+			t.Errorf("merge strategy %q: detections = %+v, want the merge pair flagged", strategy, detections)
+		}
+		if got := data.Metadata["scored_commit_count"]; got != 2 {
+			t.Errorf("merge strategy %q: scored_commit_count = %v, want 2", strategy, got)
+		}
+	}
+}
+
+func TestGitDetector_ItemProgress(t *testing.T) {
+	d := onlyStrategies(t, &patterns.Thresholds{SuspiciousAdditions: 100}, "size_analysis")
+
+	merge := testPair("merge", 10, time.Hour)
+	merge.Current.Parents = []string{"p1", "p2"}
+	data := &analysis.SourceData{
+		Type:       "git",
+		RawContent: []*git.CommitPair{testPair("big", 500, time.Hour), merge, testPair("small", 10, time.Hour)},
+		Metadata:   map[string]interface{}{},
+	}
+
+	var items []analysis.ItemProgress
+	d.SetItemProgress(func(item analysis.ItemProgress) { items = append(items, item) })
+	if _, err := d.Detect(context.Background(), data); err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+
+	want := []analysis.ItemProgress{
+		{Kind: "commit", ID: "big", Current: 1, Total: 3, Suspicious: true, SuspiciousSoFar: 1},
+		{Kind: "commit", ID: "merge", Current: 2, Total: 3, Skipped: "merge commit", SuspiciousSoFar: 1},
+		{Kind: "commit", ID: "small", Current: 3, Total: 3, SuspiciousSoFar: 1},
+	}
+	if len(items) != len(want) {
+		t.Fatalf("reported %d commits, want %d", len(items), len(want))
+	}
+	for i, got := range items {
+		got.Score, got.Severity = 0, ""
+		if got != want[i] {
+			t.Errorf("item %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+	if items[0].Score <= 0 || items[0].Severity == "" {
+		t.Errorf("flagged commit reported without score or severity: %+v", items[0])
+	}
+
+	d.SetItemProgress(nil)
+	if _, err := d.Detect(context.Background(), data); err != nil {
+		t.Fatalf("Detect() without callback unexpected error = %v", err)
+	}
+}
+
+func TestGitDetector_BaselinePairsAreNotAnalyzed(t *testing.T) {
+	d := onlyStrategies(t, &patterns.Thresholds{SuspiciousAdditions: 100}, "size_analysis")
+	data := &analysis.SourceData{
+		Type:       "git",
+		RawContent: []*git.CommitPair{testPair("new", 10, time.Hour)},
+		Metadata: map[string]interface{}{
+			"baseline_pairs": []*git.CommitPair{testPair("old", 500, time.Hour)},
+		},
+	}
+
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 0 {
+		t.Errorf("Detect() flagged %d commits, want 0 (baseline pairs are context only)", len(detections))
+	}
+	if got := data.Metadata["scored_commit_count"]; got != 1 {
+		t.Errorf("scored_commit_count = %v, want 1", got)
+	}
+}
+
+func TestGitDetector_DisabledStrategies(t *testing.T) {
+	thresholds := &patterns.Thresholds{SuspiciousAdditions: 100, MinTimeDeltaSeconds: 60}
+	d := onlyStrategies(t, thresholds, "size_analysis", "timing_analysis")
+	d.DisabledStrategies = map[string]bool{"size_analysis": true}
+
+	strategies, err := d.buildStrategies()
+	if err != nil {
+		t.Fatalf("buildStrategies() unexpected error = %v", err)
+	}
+	if len(strategies) != 1 || strategies[0].Name() != "timing_analysis" {
+		names := make([]string, 0, len(strategies))
+		for _, s := range strategies {
+			names = append(names, s.Name())
+		}
+		t.Fatalf("strategies = %v, want [timing_analysis]", names)
+	}
+
+	data := &analysis.SourceData{
+		Type:       "git",
+		RawContent: []*git.CommitPair{testPair("big", 500, time.Hour)},
+		Metadata:   map[string]interface{}{},
+	}
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 0 {
+		t.Errorf("Detect() returned %d detections, want 0 with size_analysis disabled", len(detections))
+	}
+}
+
+func TestGitDetector_StopsWhenContextDone(t *testing.T) {
+	d := NewGitDetector(&patterns.Thresholds{SuspiciousAdditions: 100, MinTimeDeltaSeconds: 60})
+	data := &analysis.SourceData{
+		Type:       "git",
+		RawContent: []*git.CommitPair{testPair("big", 500, time.Hour)},
+		Metadata:   map[string]interface{}{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := d.Detect(ctx, data); !errors.Is(err, context.Canceled) {
+		t.Errorf("Detect() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestGitDetector_HistoricalBaseline(t *testing.T) {
+	thresholds := &patterns.Thresholds{SuspiciousAdditions: 100000, MinTimeDeltaSeconds: 1}
+	pairs := []*git.CommitPair{
+		testPair("a", 300, time.Hour),
+		testPair("b", 300, time.Hour),
+		testPair("c", 300, time.Hour),
+		testPair("d", 300, time.Hour),
+	}
+	newData := func() *analysis.SourceData {
+		return &analysis.SourceData{Type: "git", RawContent: pairs, Metadata: map[string]interface{}{}}
+	}
+
+	d := onlyStrategies(t, thresholds, "StatisticalAnomaly")
+	detections, err := d.Detect(context.Background(), newData())
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 0 {
+		t.Fatalf("uniform commits scored against themselves: %d detections, want 0", len(detections))
+	}
+	if d.Baseline == nil || d.Baseline.SampleSize != 4 {
+		t.Fatalf("Baseline = %+v, want one computed from 4 commits", d.Baseline)
+	}
+
+	d = onlyStrategies(t, thresholds, "StatisticalAnomaly")
+	d.HistoricalBaseline = &analysis.RepositoryBaseline{
+		AvgAdditions: 100, StdDevAdditions: 10, AvgFilesChanged: 1,
+		MedianCommitSize: 100, Q1CommitSize: 90, Q3CommitSize: 110, SampleSize: 50,
+	}
+	data := newData()
+	detections, err = d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != len(pairs) {
+		t.Errorf("tripled commits scored against the historical baseline: %d detections, want %d", len(detections), len(pairs))
+	}
+	drifts, _ := data.Metadata["baseline_drift"].([]analysis.BaselineDrift)
+	if len(drifts) == 0 || drifts[0].Metric != "avg_additions" || drifts[0].Ratio < 2.5 {
+		t.Errorf("baseline_drift = %+v, want avg_additions roughly tripled", drifts)
+	}
+}
+
+func TestGitDetector_SampleSizeGate(t *testing.T) {
+	thresholds := &patterns.Thresholds{SuspiciousAdditions: 100000, MinTimeDeltaSeconds: 1}
+	var pairs []*git.CommitPair
+	for i, size := range []int64{100, 110, 90, 105, 95, 100, 102, 98, 101, 5000} {
+		pairs = append(pairs, testPair(fmt.Sprintf("c%d", i), size, time.Hour))
+	}
+	newData := func() *analysis.SourceData {
+		return &analysis.SourceData{Type: "git", RawContent: pairs, Metadata: map[string]interface{}{}}
+	}
+
+	d := onlyStrategies(t, thresholds, "StatisticalAnomaly")
+	data := newData()
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 1 || !strings.Contains(detections[0].Examples[1], "low confidence: baseline has only 10 commits") {
+		t.Fatalf("detections = %+v, want the outlier flagged with a low-confidence caveat", detections)
+	}
+	if caveat, _ := data.Metadata[analysis.MetricBaselineCaveat].(string); !strings.Contains(caveat, "only 10 commits") {
+		t.Errorf("%s = %q, want the small baseline caveat", analysis.MetricBaselineCaveat, caveat)
+	}
+	if !detections[0].LowConfidence || !strings.Contains(detections[0].Caveat, "only 10 commits") {
+		t.Errorf("LowConfidence = %v, Caveat = %q, want the detection marked low confidence", detections[0].LowConfidence, detections[0].Caveat)
+	}
+
+	d = onlyStrategies(t, thresholds, "StatisticalAnomaly")
+	d.SampleSizeGate = analysis.SampleSizeGate{MinCommits: 20}
+	data = newData()
+	if detections, err = d.Detect(context.Background(), data); err != nil || len(detections) != 0 {
+		t.Errorf("Detect() = %d detections, %v; want none below the minimum baseline", len(detections), err)
+	}
+	if _, ok := data.Metadata[analysis.MetricBaselineCaveat]; !ok {
+		t.Errorf("%s missing below the minimum baseline", analysis.MetricBaselineCaveat)
+	}
+
+	d = onlyStrategies(t, thresholds, "StatisticalAnomaly")
+	d.SampleSizeGate = analysis.SampleSizeGate{ReliableCommits: 10}
+	data = newData()
+	detections, err = d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	// Trusted, the small baseline also yields moderate deviations.
+	last := len(detections) - 1
+	if len(detections) < 2 || detections[last].Examples[0] != "c9" || strings.Contains(detections[last].Examples[1], "low confidence") || detections[last].LowConfidence {
+		t.Errorf("detections = %+v, want moderate deviations and the outlier flagged without a caveat", detections)
+	}
+	if _, ok := data.Metadata[analysis.MetricBaselineCaveat]; ok {
+		t.Errorf("%s set for a reliable baseline", analysis.MetricBaselineCaveat)
+	}
+}
+
+func TestGitDetector_Suppressions(t *testing.T) {
+	thresholds := &patterns.Thresholds{SuspiciousAdditions: 100, MinTimeDeltaSeconds: 60}
+	d := onlyStrategies(t, thresholds, "size_analysis", "timing_analysis")
+	d.Suppressions = analysis.NewSuppressionList()
+	d.Suppressions.Add("size_analysis", "", "reported")
+	d.Suppressions.Add("size_analysis", "", "both")
+
+	data := &analysis.SourceData{
+		Type: "git",
+		RawContent: []*git.CommitPair{
+			testPair("reported", 500, time.Hour),  // size only, suppressed
+			testPair("both", 500, 5*time.Second),  // size suppressed, timing kept
+			testPair("untouched", 500, time.Hour), // size only
+		},
+		Metadata: map[string]interface{}{},
+	}
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+
+	fired := make(map[string][]string)
+	for _, det := range detections {
+		fired[det.Examples[0]] = det.Strategies
+	}
+	if _, ok := fired["reported"]; ok {
+		t.Error("commit whose only hit was suppressed should not be flagged")
+	}
+	if got := fired["both"]; len(got) != 1 || got[0] != "timing_analysis" {
+		t.Errorf("both strategies = %v, want [timing_analysis]", got)
+	}
+	if got := fired["untouched"]; len(got) != 1 || got[0] != "size_analysis" {
+		t.Errorf("untouched strategies = %v, want [size_analysis]", got)
+	}
+	if got := data.Metadata["suppressed_count"]; got != 2 {
+		t.Errorf("suppressed_count = %v, want 2", got)
+	}
+}
+
+// strategyRecorder is a metrics collector that keeps every strategy
+// execution it is given.
+type strategyRecorder struct {
+	analysis.NullMetrics
+	mu         sync.Mutex
+	executions map[string]int
+	detected   map[string]bool
+}
+
+func (r *strategyRecorder) RecordStrategyExecution(strategy string, detected bool, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.executions == nil {
+		r.executions = make(map[string]int)
+		r.detected = make(map[string]bool)
+	}
+	r.executions[strategy]++
+	r.detected[strategy] = r.detected[strategy] || detected
+}
+
+func TestGitDetector_RecordsStrategyExecutions(t *testing.T) {
+	d := NewGitDetector(&patterns.Thresholds{SuspiciousAdditions: 100, MinTimeDeltaSeconds: 60})
+	strategies, err := d.buildStrategies()
+	if err != nil {
+		t.Fatalf("buildStrategies() unexpected error = %v", err)
+	}
+	recorder := &strategyRecorder{}
+	d.Metrics = recorder
+
+	data := &analysis.SourceData{
+		Type: "git",
+		RawContent: []*git.CommitPair{
+			testPair("big", 500, time.Hour),
+			testPair("small", 10, time.Hour),
+			testPair("fast", 10, 5*time.Second),
+		},
+		Metadata: map[string]interface{}{},
+	}
+	if _, err := d.Detect(context.Background(), data); err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+
+	pairHistory := d.buildPairHistoryStrategies()
+	if want := len(strategies) + len(pairHistory); len(recorder.executions) != want {
+		t.Errorf("recorded %d strategies, want all %d", len(recorder.executions), want)
+	}
+	for _, s := range strategies {
+		if n := recorder.executions[s.Name()]; n != 1 {
+			t.Errorf("%s recorded %d executions, want exactly 1 per analysis", s.Name(), n)
+		}
+	}
+	if !recorder.detected["size_analysis"] {
+		t.Error("size_analysis fired on a commit but was recorded as not detected")
+	}
+}
+
+func TestGitDetector_HistoryStrategies(t *testing.T) {
+	// 25 commits exactly ten minutes apart, each the only parent of the next.
+	commits := make([]*git.Commit, 25)
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := range commits {
+		commits[i] = &git.Commit{Hash: fmt.Sprintf("c%02d", i), Timestamp: at.Add(-time.Duration(i) * 10 * time.Minute)}
+		if i > 0 {
+			commits[i-1].Parents = []string{commits[i].Hash}
+		}
+	}
+	newData := func() *analysis.SourceData {
+		return &analysis.SourceData{
+			ID:         "repo",
+			Type:       "git",
+			RawContent: []*git.CommitPair{testPair("big", 5000, time.Hour)},
+			Metadata:   map[string]interface{}{"commits": commits},
+		}
+	}
+
+	d := NewGitDetector(&patterns.Thresholds{SuspiciousAdditions: 100})
+	data := newData()
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+
+	var topology *analysis.Detection
+	for i := range detections {
+		if detections[i].Strategy == "commit_topology_analysis" {
+			topology = &detections[i]
+		}
+	}
+	if topology == nil {
+		t.Fatalf("expected a commit_topology_analysis detection, got %+v", detections)
+	}
+	if len(topology.Examples) != 2 || topology.Examples[0] != "c00" || !strings.Contains(topology.Examples[1], "25 commits") {
+		t.Errorf("Examples = %q, want the run's newest commit and the evidence", topology.Examples)
+	}
+	if got := data.Metadata["suspicious_count"]; got != 1 {
+		t.Errorf("suspicious_count = %v, want only the flagged commit", got)
+	}
+
+	d.DisabledStrategies = map[string]bool{"commit_topology_analysis": true}
+	detections, err = d.Detect(context.Background(), newData())
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	for _, det := range detections {
+		if det.Strategy == "commit_topology_analysis" {
+			t.Error("disabled history strategy should not run")
+		}
+	}
+}
+
+func TestGitDetector_PairHistoryStrategies(t *testing.T) {
+	at := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	churnPair := func(hash string, hours int, file git.FileStat) *git.CommitPair {
+		p := testPair(hash, file.Additions, time.Hour)
+		p.Current.Timestamp = at.Add(time.Duration(hours) * time.Hour)
+		p.Stats.Deletions = file.Deletions
+		p.Stats.Files = []git.FileStat{file}
+		return p
+	}
+	newData := func() *analysis.SourceData {
+		return &analysis.SourceData{
+			ID:   "repo",
+			Type: "git",
+			RawContent: []*git.CommitPair{
+				churnPair("revert", 2, git.FileStat{Path: "gen.go", Deletions: 280}),
+				churnPair("add", 0, git.FileStat{Path: "gen.go", Additions: 300}),
+			},
+			Metadata: map[string]interface{}{},
+		}
+	}
+
+	d := onlyStrategies(t, &patterns.Thresholds{SuspiciousAdditions: 1000}, "code_churn_analysis")
+	detections, err := d.Detect(context.Background(), newData())
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 1 || detections[0].Strategy != "code_churn_analysis" {
+		t.Fatalf("detections = %+v, want one code_churn_analysis detection", detections)
+	}
+	churn := detections[0]
+	if len(churn.Examples) != 2 || churn.Examples[0] != "add" || !strings.Contains(churn.Examples[1], "gen.go (+300, then -280 by revert") {
+		t.Errorf("Examples = %q, want the adding commit and the churned file", churn.Examples)
+	}
+
+	d.Suppressions = analysis.NewSuppressionList()
+	d.Suppressions.Add("code_churn_analysis", "repo", "add")
+	d.IncludePassed = true
+	data := newData()
+	detections, err = d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 1 || detections[0].Detected {
+		t.Errorf("detections = %+v, want only a passed record once the hit is suppressed", detections)
+	}
+	if got := data.Metadata["suppressed_count"]; got != 1 {
+		t.Errorf("suppressed_count = %v, want 1", got)
+	}
+}
@@ -2,18 +2,48 @@ package detectors
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/TryCadence/Cadence/internal/analysis"
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
+	webpatterns "github.com/TryCadence/Cadence/internal/analysis/adapters/web/patterns"
 )
 
 type WebDetector struct {
+	// DisabledStrategies names web pattern strategies to skip.
+	DisabledStrategies map[string]bool
+	// Suppressions lists patterns reported as false positives for a page.
+	// Matching patterns are reported as passed and left out of the
+	// suspicion rate.
+	Suppressions *analysis.SuppressionList
+	// SourceKey identifies the page in Suppressions; empty uses the source ID.
+	SourceKey string
+	// NGramMaxCoverage is the repeated 3-/4-word phrase coverage above which
+	// ngram_repetition fires. Zero uses webpatterns.DefaultWebNGramCoverage.
+	NGramMaxCoverage float64
+	// Vocabulary, when set, replaces the phrase lists of overused_phrases,
+	// ai_vocabulary and boilerplate_text (see webpatterns.ReadVocabulary).
+	Vocabulary *webpatterns.Vocabulary
+	// Metrics, when set, receives a RecordStrategyExecution for every
+	// strategy run on the page, before suppressions apply.
+	Metrics analysis.AnalysisMetrics
+	// MinWordCount is the fewest words a page needs to be analyzed. Shorter
+	// pages get no detections and an analysis.MetricInsufficientContent
+	// entry. Zero uses patterns.DefaultMinWordCount.
+	MinWordCount int
+	// Registry supplies the per-strategy confidence MinReportConfidence is
+	// checked against. Patterns missing from it use their own confidence.
+	Registry *analysis.StrategyRegistry
+	// MinReportConfidence hides detections, fired or passed, from patterns
+	// whose confidence is below it. They still count toward the report's
+	// totals and score (see analysis.HideDetections). Zero shows them all.
+	MinReportConfidence float64
 }
 
 func NewWebDetector() *WebDetector {
-	return &WebDetector{}
+	return &WebDetector{Registry: analysis.DefaultWebRegistry()}
 }
 
 func (w *WebDetector) Detect(ctx context.Context, data *analysis.SourceData) ([]analysis.Detection, error) {
@@ -26,12 +56,43 @@ func (w *WebDetector) Detect(ctx context.Context, data *analysis.SourceData) ([]
 		return nil, fmt.Errorf("invalid RawContent type for web source")
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	slopAnalyzer := patterns.NewTextSlopAnalyzer()
+	slopAnalyzer.MinWordCount = w.MinWordCount
+	slopAnalyzer.Language = page.Language
+	if w.NGramMaxCoverage > 0 {
+		slopAnalyzer.GetRegistry().Replace(webpatterns.NewNGramRepetitionStrategy(w.NGramMaxCoverage))
+	}
+	if len(page.BoldListLeads) > 0 {
+		slopAnalyzer.GetRegistry().Replace(webpatterns.NewMarkdownArtifactStrategy().WithListLeads(page.BoldListLeads))
+	}
+	slopAnalyzer.GetRegistry().UseVocabulary(w.Vocabulary)
+	if len(w.DisabledStrategies) > 0 {
+		slopAnalyzer.GetRegistry().Disable(w.DisabledStrategies)
+	}
 	slopResult, err := slopAnalyzer.AnalyzeContent(page.AllText)
-	if err != nil {
-		data.Metadata["analysis_error"] = err.Error()
+	var insufficient *analysis.InsufficientContentError
+	if errors.As(err, &insufficient) {
+		data.Metadata[analysis.MetricInsufficientContent] = insufficient
 		return []analysis.Detection{}, nil
 	}
+	if err != nil {
+		return nil, fmt.Errorf("web content analysis failed: %w", err)
+	}
+
+	if w.Metrics != nil {
+		for _, p := range slopResult.Patterns {
+			w.Metrics.RecordStrategyExecution(p.Type, true, p.Duration)
+		}
+		for _, p := range slopResult.PassedPatterns {
+			w.Metrics.RecordStrategyExecution(p.Type, false, p.Duration)
+		}
+	}
+
+	suppressed := w.suppress(slopResult, data.ID)
 
 	detections := make([]analysis.Detection, 0)
 
@@ -53,7 +114,7 @@ func (w *WebDetector) Detect(ctx context.Context, data *analysis.SourceData) ([]
 			Description: pattern.Description,
 			Examples:    pattern.Examples,
 		}
-		detections = append(detections, detection)
+		detections = w.report(data, detections, detection)
 	}
 
 	for _, pattern := range slopResult.PassedPatterns {
@@ -67,11 +128,69 @@ func (w *WebDetector) Detect(ctx context.Context, data *analysis.SourceData) ([]
 			Description: pattern.Description,
 			Examples:    pattern.Examples,
 		}
-		detections = append(detections, detection)
+		detections = w.report(data, detections, detection)
 	}
 
 	data.Metadata["slop_suspicion_rate"] = slopResult.SuspicionRate
 	data.Metadata["slop_word_count"] = slopResult.WordCount
+	data.Metadata["suppressed_count"] = suppressed
+	if len(slopResult.NotApplicable) > 0 {
+		data.Metadata["not_applicable_strategies"] = slopResult.NotApplicable
+	}
 
 	return detections, nil
 }
+
+// report appends detection to detections, or hides it when its strategy's
+// confidence is below MinReportConfidence.
+func (w *WebDetector) report(data *analysis.SourceData, detections []analysis.Detection, detection analysis.Detection) []analysis.Detection {
+	confidence := detection.Confidence
+	if w.Registry != nil {
+		if info, ok := w.Registry.Get(detection.Strategy); ok && info.Confidence > 0 {
+			confidence = info.Confidence
+		}
+	}
+	if confidence < w.MinReportConfidence {
+		analysis.HideDetections(data.Metadata, detection)
+		return detections
+	}
+	return append(detections, detection)
+}
+
+// suppress moves patterns listed in w.Suppressions to the passed patterns and
+// recomputes the suspicion rate from the rest. It returns how many moved.
+func (w *WebDetector) suppress(result *patterns.TextSlopResult, sourceID string) int {
+	if w.Suppressions.Len() == 0 {
+		return 0
+	}
+	key := w.SourceKey
+	if key == "" {
+		key = sourceID
+	}
+
+	kept := result.Patterns[:0]
+	suppressed := 0
+	for _, p := range result.Patterns {
+		if w.Suppressions.Suppressed(p.Type, key, "") {
+			p.Description += " (suppressed: reported as a false positive)"
+			result.PassedPatterns = append(result.PassedPatterns, p)
+			suppressed++
+			continue
+		}
+		kept = append(kept, p)
+	}
+	if suppressed == 0 {
+		return 0
+	}
+	result.Patterns = kept
+
+	result.SuspicionRate = 0
+	if len(kept) > 0 {
+		total := 0.0
+		for _, p := range kept {
+			total += p.Severity
+		}
+		result.SuspicionRate = min(total/float64(len(kept)), 1.0)
+	}
+	return suppressed
+}
@@ -0,0 +1,169 @@
+package detectors
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
+)
+
+func TestWebDetector_RecordsStrategyExecutions(t *testing.T) {
+	strategies := patterns.NewTextSlopAnalyzer().GetRegistry().GetStrategies()
+	recorder := &strategyRecorder{}
+	d := NewWebDetector()
+	d.Metrics = recorder
+
+	text := strings.Repeat("Furthermore, it is important to note that our team delivers robust solutions. ", 20)
+	data := &analysis.SourceData{
+		ID:         "https://example.com",
+		Type:       "web",
+		RawContent: &web.PageContent{AllText: text},
+		Metadata:   map[string]interface{}{},
+	}
+	if _, err := d.Detect(context.Background(), data); err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+
+	if len(recorder.executions) != len(strategies) {
+		t.Errorf("recorded %d strategies, want all %d", len(recorder.executions), len(strategies))
+	}
+	for _, s := range strategies {
+		if n := recorder.executions[s.Name()]; n != 1 {
+			t.Errorf("%s recorded %d executions, want exactly 1 per analysis", s.Name(), n)
+		}
+	}
+}
+
+func TestWebDetector_InsufficientContent(t *testing.T) {
+	d := NewWebDetector()
+	d.MinWordCount = 30
+	data := &analysis.SourceData{
+		ID:         "https://example.com",
+		Type:       "web",
+		RawContent: &web.PageContent{AllText: strings.Repeat("short page ", 10)},
+		Metadata:   map[string]interface{}{},
+	}
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	if len(detections) != 0 {
+		t.Errorf("Detect() = %d detections, want none for a short page", len(detections))
+	}
+	got, ok := data.Metadata[analysis.MetricInsufficientContent].(*analysis.InsufficientContentError)
+	if !ok || got.WordCount != 20 || got.MinWordCount != 30 {
+		t.Errorf("metadata[%q] = %#v, want 20 of 30 words", analysis.MetricInsufficientContent, data.Metadata[analysis.MetricInsufficientContent])
+	}
+}
+
+func TestWebDetector_MinReportConfidence(t *testing.T) {
+	text := strings.Repeat("Furthermore, it is important to note that our team delivers robust solutions. ", 20)
+	run := func(minConfidence float64) *analysis.AnalysisReport {
+		d := NewWebDetector()
+		d.MinReportConfidence = minConfidence
+		source := &pageSource{page: &web.PageContent{AllText: text}}
+		report, err := analysis.NewDefaultDetectionRunner().Run(context.Background(), source, d)
+		if err != nil {
+			t.Fatalf("Run() unexpected error = %v", err)
+		}
+		return report
+	}
+
+	all, filtered := run(0), run(0.5)
+	registry := analysis.DefaultWebRegistry()
+	for _, det := range filtered.Detections {
+		if info, ok := registry.Get(det.Strategy); ok && info.Confidence < 0.5 {
+			t.Errorf("%s (confidence %v) should be hidden", det.Strategy, info.Confidence)
+		}
+	}
+	hidden := len(all.Detections) - len(filtered.Detections)
+	if hidden == 0 {
+		t.Fatal("expected some low-confidence web strategies to be hidden")
+	}
+	if got := filtered.Metrics[analysis.MetricHiddenDetectionCount]; got != hidden {
+		t.Errorf("%s = %v, want %d", analysis.MetricHiddenDetectionCount, got, hidden)
+	}
+	if filtered.TotalDetections != all.TotalDetections || filtered.DetectionCount != all.DetectionCount || math.Abs(filtered.OverallScore-all.OverallScore) > 1e-9 {
+		t.Errorf("hiding detections changed the totals: %d/%d %.2f, want %d/%d %.2f",
+			filtered.DetectionCount, filtered.TotalDetections, filtered.OverallScore,
+			all.DetectionCount, all.TotalDetections, all.OverallScore)
+	}
+}
+
+func TestWebDetector_NonEnglishContent(t *testing.T) {
+	// German marketing copy. The English word-list strategies must not
+	// report it as passing them.
+	text := strings.Repeat("Unser Team liefert robuste Lösungen für die digitale Zukunft, und wir sind stolz darauf. ", 10)
+	d := NewWebDetector()
+	data := &analysis.SourceData{
+		ID:         "https://example.de",
+		Type:       "web",
+		RawContent: &web.PageContent{AllText: text, Language: "de"},
+		Metadata:   map[string]interface{}{},
+	}
+	detections, err := d.Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+
+	notApplicable, _ := data.Metadata["not_applicable_strategies"].([]string)
+	if !slices.Contains(notApplicable, "ai_vocabulary") || !slices.Contains(notApplicable, "excessive_transitions") {
+		t.Errorf("not_applicable_strategies = %v, want the English word-list strategies", notApplicable)
+	}
+	for _, det := range detections {
+		if slices.Contains(notApplicable, det.Strategy) {
+			t.Errorf("%s is not applicable to German but was reported (detected=%v)", det.Strategy, det.Detected)
+		}
+	}
+}
+
+func TestWebDetector_BoldListLeads(t *testing.T) {
+	intro := "<p>We build tools that help small teams ship software without the usual overhead and ceremony.</p>"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><head><title>Why us</title></head><body><main>` + strings.Repeat(intro, 6) + `<ul>
+			<li><strong>Speed</strong>: pages load in under a second on every device we support</li>
+			<li><strong>Safety</strong>: every change is reviewed by two people before it ships</li>
+			<li><b>Scale:</b> grows with your team from the first user to the thousandth</li>
+			<li>Support from real engineers whenever something goes wrong</li>
+		</ul></main></body></html>`))
+	}))
+	defer server.Close()
+
+	page, err := web.NewFetcher(5 * time.Second).Fetch(server.URL)
+	if err != nil {
+		t.Fatalf("Fetch() unexpected error = %v", err)
+	}
+	data := &analysis.SourceData{ID: server.URL, Type: "web", RawContent: page, Metadata: map[string]interface{}{}}
+	detections, err := NewWebDetector().Detect(context.Background(), data)
+	if err != nil {
+		t.Fatalf("Detect() unexpected error = %v", err)
+	}
+	for _, d := range detections {
+		if d.Strategy == "markdown_artifacts" {
+			if !d.Detected || len(d.Examples) != 3 {
+				t.Errorf("markdown_artifacts = %+v, want the three bold list leads", d)
+			}
+			return
+		}
+	}
+	t.Error("markdown_artifacts did not run")
+}
+
+type pageSource struct {
+	page *web.PageContent
+}
+
+func (s *pageSource) Type() string                       { return "web" }
+func (s *pageSource) Validate(ctx context.Context) error { return nil }
+func (s *pageSource) Fetch(ctx context.Context) (*analysis.SourceData, error) {
+	return &analysis.SourceData{ID: "https://example.com", Type: "web", RawContent: s.page, Metadata: map[string]interface{}{}}, nil
+}
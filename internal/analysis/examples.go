@@ -0,0 +1,94 @@
+package analysis
+
+import "regexp"
+
+// RedactedText replaces secrets and email addresses in redacted examples.
+const RedactedText = "[REDACTED]"
+
+// secretPattern masks matches of re with replacement, which may keep a
+// leading group such as the name in "api_key=...".
+type secretPattern struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// secretPatterns are the credentials and personal data RedactSecrets masks,
+// most specific first so a key inside an assignment is masked whole.
+var secretPatterns = []secretPattern{
+	{regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----(?s:.*?)(?:-----END [A-Z ]*PRIVATE KEY-----|$)`), RedactedText},
+	{regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`), RedactedText},
+	{regexp.MustCompile(`(?i)\b(bearer\s+)[A-Za-z0-9._~+/=-]{16,}`), "${1}" + RedactedText},
+	{regexp.MustCompile(`(?i)\b((?:api[_-]?key|access[_-]?key|secret(?:[_-]?key)?|client[_-]?secret|(?:auth|access)[_-]?token|token|password|passwd|pwd)["']?\s*[:=]\s*["']?)[^\s"'` + "`" + `,;]{6,}`), "${1}" + RedactedText},
+	{regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), RedactedText},
+	{regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`), RedactedText},
+	{regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,}\b`), RedactedText},
+	{regexp.MustCompile(`\bglpat-[A-Za-z0-9_-]{20,}\b`), RedactedText},
+	{regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}\b`), RedactedText},
+	{regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}\b`), RedactedText},
+	{regexp.MustCompile(`\b[rs]k_(?:live|test)_[A-Za-z0-9]{16,}\b`), RedactedText},
+	{regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`), RedactedText},
+	{regexp.MustCompile(`\b[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}\b`), RedactedText},
+}
+
+// RedactSecrets masks API keys, tokens, private keys, credential
+// assignments and email addresses in s with RedactedText.
+func RedactSecrets(s string) string {
+	for _, p := range secretPatterns {
+		s = p.re.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// ExamplePolicy controls the detection examples that leave Cadence in
+// reports, job results and streams, which may quote code and page content.
+// The zero policy keeps every example as is.
+type ExamplePolicy struct {
+	// MaxExamples caps the examples kept per detection (0 = no limit). The
+	// commit hash that opens a git commit detection's examples is kept and
+	// not counted.
+	MaxExamples int
+	// Redact masks secrets and email addresses (see RedactSecrets).
+	Redact bool
+}
+
+// Examples returns examples limited and redacted by the policy, without
+// modifying the slice passed in.
+func (p ExamplePolicy) Examples(examples []string) []string {
+	if p.MaxExamples > 0 && len(examples) > p.MaxExamples {
+		examples = examples[:p.MaxExamples]
+	}
+	if !p.Redact || len(examples) == 0 {
+		return examples
+	}
+	redacted := make([]string, len(examples))
+	for i, example := range examples {
+		redacted[i] = RedactSecrets(example)
+	}
+	return redacted
+}
+
+// DetectionExamples returns d's examples passed through Examples. A git
+// commit detection keeps its hash in Examples[0]; only the reasons after it
+// are capped, so those that remain stay aligned with d.Strategies.
+func (p ExamplePolicy) DetectionExamples(d *Detection) []string {
+	if len(d.Strategies) == 0 || len(d.Examples) == 0 {
+		return p.Examples(d.Examples)
+	}
+	return append([]string{d.Examples[0]}, p.Examples(d.Examples[1:])...)
+}
+
+// Apply returns report with the examples of its detections passed through
+// Examples. The report itself, which may be shared with a cache, is not
+// modified; a zero policy returns it unchanged.
+func (p ExamplePolicy) Apply(report *AnalysisReport) *AnalysisReport {
+	if report == nil || p == (ExamplePolicy{}) {
+		return report
+	}
+	sanitized := *report
+	sanitized.Detections = make([]Detection, len(report.Detections))
+	for i, d := range report.Detections {
+		d.Examples = p.DetectionExamples(&d)
+		sanitized.Detections[i] = d
+	}
+	return &sanitized
+}
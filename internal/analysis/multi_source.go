@@ -0,0 +1,209 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SourceTypeMulti is the source type of a report that merges the reports of
+// several sources; see MergeReports.
+const SourceTypeMulti SourceType = "multi"
+
+// DefaultSourceWeight is the weight of a source in a multi-source score when
+// SourceWeights has none for its type. With every source at the default the
+// merged score is the plain mean of the source scores.
+const DefaultSourceWeight = 0.5
+
+// SourceWeights weights each source type's overall score in a multi-source
+// report. The merged score is
+//
+//	sum(weight[s] * score[s]) / sum(weight[s])
+//
+// over the sources s that were analyzed, so with git 0.6 and web 0.4 it is
+// 0.6*git + 0.4*web. Weights need not sum to 1.
+type SourceWeights map[SourceType]float64
+
+func (w SourceWeights) weight(t SourceType) float64 {
+	if weight, ok := w[t]; ok {
+		return weight
+	}
+	return DefaultSourceWeight
+}
+
+// SourceSummary is one source's part in a multi-source report.
+type SourceSummary struct {
+	SourceType      SourceType
+	SourceID        string
+	Weight          float64 // share of the merged overall score (0-1)
+	OverallScore    float64
+	Assessment      string
+	TotalDetections int
+	DetectionCount  int
+	SourceMetrics   SourceMetrics
+}
+
+// SourceAnalysis is one source of a multi-source analysis and the detectors
+// to run over it.
+type SourceAnalysis struct {
+	Source    AnalysisSource
+	Detectors []Detector
+}
+
+// RunMultiSource runs each analysis with runner, in order, and merges the
+// reports with MergeReports. It stops at the first analysis that fails.
+func RunMultiSource(ctx context.Context, runner DetectionRunner, weights SourceWeights, analyses ...SourceAnalysis) (*AnalysisReport, error) {
+	if len(analyses) == 0 {
+		return nil, fmt.Errorf("multi-source analysis needs at least one source")
+	}
+
+	reports := make([]*AnalysisReport, 0, len(analyses))
+	for _, a := range analyses {
+		report, err := runner.Run(ctx, a.Source, a.Detectors...)
+		if err != nil {
+			return nil, fmt.Errorf("%s analysis failed: %w", a.Source.Type(), err)
+		}
+		reports = append(reports, report)
+	}
+	return MergeReports(weights, reports...), nil
+}
+
+// MergeReports combines per-source reports into one MultiSource report.
+// Detections keep the order of reports, so they stay grouped by source, and
+// each is tagged with the source it came from in Detection.Origin. Counts
+// and source metrics are summed, metrics are kept under "<source type>."
+// keys, and OverallScore is the weighted combination documented on
+// SourceWeights. A source with insufficient content, or whose weight is not
+// positive, does not count toward the score; when no source does, the score
+// is the plain mean.
+func MergeReports(weights SourceWeights, reports ...*AnalysisReport) *AnalysisReport {
+	merged := &AnalysisReport{
+		ID:          uuid.New().String(),
+		SourceType:  SourceTypeMulti,
+		MultiSource: true,
+		Detections:  make([]Detection, 0),
+		Metrics:     make(map[string]interface{}),
+		SourceMetrics: SourceMetrics{
+			Extra: make(map[string]interface{}),
+		},
+	}
+
+	shares := sourceShares(weights, reports)
+	ids := make([]string, 0, len(reports))
+	var errs []string
+	var scoreSum float64
+	hidden := 0
+
+	for i, r := range reports {
+		ids = append(ids, r.SourceID)
+		merged.Sources = append(merged.Sources, SourceSummary{
+			SourceType:      r.SourceType,
+			SourceID:        r.SourceID,
+			Weight:          shares[i],
+			OverallScore:    r.OverallScore,
+			Assessment:      r.Assessment,
+			TotalDetections: r.TotalDetections,
+			DetectionCount:  r.DetectionCount,
+			SourceMetrics:   r.SourceMetrics,
+		})
+
+		for _, d := range r.Detections {
+			d.Origin = r.SourceType
+			merged.Detections = append(merged.Detections, d)
+		}
+
+		merged.OverallScore += shares[i] * r.OverallScore
+		merged.TotalDetections += r.TotalDetections
+		merged.DetectionCount += r.DetectionCount
+		merged.PassedDetections += r.PassedDetections
+		merged.HighSeverityCount += r.HighSeverityCount
+		merged.MediumSeverityCount += r.MediumSeverityCount
+		merged.LowSeverityCount += r.LowSeverityCount
+
+		sm := &merged.SourceMetrics
+		sm.ItemsAnalyzed += r.SourceMetrics.ItemsAnalyzed
+		sm.ItemsFlagged += r.SourceMetrics.ItemsFlagged
+		sm.UniqueAuthors += r.SourceMetrics.UniqueAuthors
+		sm.StrategiesUsed += r.SourceMetrics.StrategiesUsed
+		sm.StrategiesHit += r.SourceMetrics.StrategiesHit
+		scoreSum += r.SourceMetrics.AverageScore * float64(r.DetectionCount)
+		for key, value := range r.SourceMetrics.Extra {
+			sm.Extra[string(r.SourceType)+"."+key] = value
+		}
+
+		for key, value := range r.Metrics {
+			merged.Metrics[string(r.SourceType)+"."+key] = value
+		}
+		if n, ok := r.Metrics[MetricHiddenDetectionCount].(int); ok {
+			hidden += n
+		}
+
+		if merged.Timing.StartedAt.IsZero() || r.Timing.StartedAt.Before(merged.Timing.StartedAt) {
+			merged.Timing.StartedAt = r.Timing.StartedAt
+		}
+		if r.Timing.CompletedAt.After(merged.Timing.CompletedAt) {
+			merged.Timing.CompletedAt = r.Timing.CompletedAt
+		}
+		for _, p := range r.Timing.Phases {
+			p.Name = string(r.SourceType) + ":" + p.Name
+			merged.Timing.Phases = append(merged.Timing.Phases, p)
+		}
+
+		if r.Error != "" {
+			errs = append(errs, fmt.Sprintf("%s: %s", r.SourceType, r.Error))
+		}
+	}
+
+	merged.SourceID = strings.Join(ids, " + ")
+	merged.AnalyzedAt = merged.Timing.StartedAt
+	merged.Timing.Duration = merged.Timing.CompletedAt.Sub(merged.Timing.StartedAt)
+	merged.Duration = merged.Timing.Duration
+	merged.Error = strings.Join(errs, "; ")
+
+	if merged.TotalDetections > 0 {
+		merged.SuspicionRate = float64(merged.DetectionCount) / float64(merged.TotalDetections)
+	}
+	if sm := &merged.SourceMetrics; sm.ItemsAnalyzed > 0 {
+		sm.CoverageRate = min(float64(sm.ItemsFlagged)/float64(sm.ItemsAnalyzed), 1.0)
+	}
+	if merged.DetectionCount > 0 {
+		merged.SourceMetrics.AverageScore = scoreSum / float64(merged.DetectionCount)
+	}
+	if hidden > 0 {
+		merged.Metrics[MetricHiddenDetectionCount] = hidden
+	}
+
+	merged.OverallScore = min(merged.OverallScore, 100)
+	merged.Scores = ScoreBreakdown{Heuristic: merged.OverallScore, Blended: merged.OverallScore}
+	merged.Assessment = assessmentFor(merged.OverallScore)
+	return merged
+}
+
+// sourceShares normalizes each report's weight to its share of the merged
+// score.
+func sourceShares(weights SourceWeights, reports []*AnalysisReport) []float64 {
+	shares := make([]float64, len(reports))
+	var total float64
+	for i, r := range reports {
+		if r.InsufficientContent() != nil {
+			continue
+		}
+		if w := weights.weight(r.SourceType); w > 0 {
+			shares[i] = w
+			total += w
+		}
+	}
+
+	if total == 0 {
+		for i := range shares {
+			shares[i] = 1 / float64(len(reports))
+		}
+		return shares
+	}
+	for i := range shares {
+		shares[i] /= total
+	}
+	return shares
+}
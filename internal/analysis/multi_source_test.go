@@ -0,0 +1,153 @@
+package analysis
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMergeReports(t *testing.T) {
+	gitReport := &AnalysisReport{
+		SourceType:        SourceTypeGit,
+		SourceID:          "https://github.com/example/app",
+		OverallScore:      80,
+		TotalDetections:   3,
+		DetectionCount:    2,
+		PassedDetections:  1,
+		HighSeverityCount: 2,
+		Detections: []Detection{
+			{Strategy: "commit", Detected: true, Severity: "high", Score: 0.9},
+			{Strategy: "commit", Detected: true, Severity: "high", Score: 0.7},
+		},
+		SourceMetrics: SourceMetrics{ItemsAnalyzed: 10, ItemsFlagged: 2, UniqueAuthors: 3, AverageScore: 0.8, StrategiesUsed: 5, StrategiesHit: 2},
+		Metrics:       map[string]interface{}{"commit_count": 10, MetricHiddenDetectionCount: 1},
+	}
+	webReport := &AnalysisReport{
+		SourceType:       SourceTypeWeb,
+		SourceID:         "https://example.com",
+		OverallScore:     20,
+		TotalDetections:  4,
+		DetectionCount:   1,
+		PassedDetections: 3,
+		LowSeverityCount: 1,
+		Detections: []Detection{
+			{Strategy: "overused_phrases", Detected: true, Severity: "low", Score: 0.2},
+		},
+		SourceMetrics: SourceMetrics{ItemsAnalyzed: 300, ItemsFlagged: 1, AverageScore: 0.2, StrategiesUsed: 4, StrategiesHit: 1},
+		Metrics:       map[string]interface{}{"word_count": 300},
+		Error:         "partial fetch",
+	}
+
+	merged := MergeReports(SourceWeights{SourceTypeGit: 0.75, SourceTypeWeb: 0.25}, gitReport, webReport)
+
+	if !merged.MultiSource || merged.SourceType != SourceTypeMulti {
+		t.Fatalf("merged report should be multi-source, got %q (MultiSource=%v)", merged.SourceType, merged.MultiSource)
+	}
+	if math.Abs(merged.OverallScore-65) > 1e-9 || merged.Scores.Blended != merged.OverallScore {
+		t.Errorf("OverallScore = %v, want 0.75*80 + 0.25*20 = 65", merged.OverallScore)
+	}
+	if merged.SourceID != "https://github.com/example/app + https://example.com" {
+		t.Errorf("SourceID = %q", merged.SourceID)
+	}
+	if merged.TotalDetections != 7 || merged.DetectionCount != 3 || merged.PassedDetections != 4 ||
+		merged.HighSeverityCount != 2 || merged.LowSeverityCount != 1 {
+		t.Errorf("counts = %d/%d/%d high %d low %d", merged.TotalDetections, merged.DetectionCount,
+			merged.PassedDetections, merged.HighSeverityCount, merged.LowSeverityCount)
+	}
+
+	if len(merged.Detections) != 3 {
+		t.Fatalf("len(Detections) = %d, want 3", len(merged.Detections))
+	}
+	for i, want := range []SourceType{SourceTypeGit, SourceTypeGit, SourceTypeWeb} {
+		if merged.Detections[i].Origin != want {
+			t.Errorf("Detections[%d].Origin = %q, want %q", i, merged.Detections[i].Origin, want)
+		}
+	}
+	if gitReport.Detections[0].Origin != "" {
+		t.Error("merging should not tag the source report's detections")
+	}
+
+	if len(merged.Sources) != 2 || merged.Sources[0].Weight != 0.75 || merged.Sources[1].OverallScore != 20 {
+		t.Errorf("Sources = %+v", merged.Sources)
+	}
+
+	sm := merged.SourceMetrics
+	if sm.ItemsAnalyzed != 310 || sm.ItemsFlagged != 3 || sm.UniqueAuthors != 3 || sm.StrategiesUsed != 9 || sm.StrategiesHit != 3 {
+		t.Errorf("SourceMetrics = %+v", sm)
+	}
+	if math.Abs(sm.AverageScore-0.6) > 1e-9 {
+		t.Errorf("AverageScore = %v, want the mean over all fired detections (0.6)", sm.AverageScore)
+	}
+	if merged.Metrics["git.commit_count"] != 10 || merged.Metrics["web.word_count"] != 300 || merged.Metrics[MetricHiddenDetectionCount] != 1 {
+		t.Errorf("Metrics = %v", merged.Metrics)
+	}
+	if !strings.Contains(merged.Error, "web: partial fetch") {
+		t.Errorf("Error = %q, want the web error named by source", merged.Error)
+	}
+}
+
+func TestMergeReports_Weights(t *testing.T) {
+	gitReport := &AnalysisReport{SourceType: SourceTypeGit, OverallScore: 80}
+	webReport := &AnalysisReport{SourceType: SourceTypeWeb, OverallScore: 20}
+
+	tests := []struct {
+		name    string
+		weights SourceWeights
+		web     *AnalysisReport
+		want    float64
+	}{
+		{"default weights are equal", nil, webReport, 50},
+		{"unnormalized weights", SourceWeights{SourceTypeGit: 3, SourceTypeWeb: 1}, webReport, 65},
+		{"zero weight leaves a source out", SourceWeights{SourceTypeGit: 1, SourceTypeWeb: 0}, webReport, 80},
+		{"insufficient content leaves a source out", nil, &AnalysisReport{
+			SourceType: SourceTypeWeb,
+			Metrics:    map[string]interface{}{MetricInsufficientContent: &InsufficientContentError{WordCount: 5, MinWordCount: 50}},
+		}, 80},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := MergeReports(tt.weights, gitReport, tt.web)
+			if math.Abs(merged.OverallScore-tt.want) > 1e-9 {
+				t.Errorf("OverallScore = %v, want %v", merged.OverallScore, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunMultiSource(t *testing.T) {
+	gitSource := &mockSource{sourceType: "git", data: &SourceData{
+		ID: "repo", Metadata: map[string]interface{}{MetricWeightedScore: 40.0},
+	}}
+	webSource := &mockSource{sourceType: "web", data: &SourceData{
+		ID: "site", Metadata: map[string]interface{}{},
+	}}
+	fired := &mockDetector{detections: []Detection{{Strategy: "s", Detected: true, Severity: "high", Confidence: 1}}}
+
+	report, err := RunMultiSource(context.Background(), NewDefaultDetectionRunner(), SourceWeights{SourceTypeGit: 1, SourceTypeWeb: 1},
+		SourceAnalysis{Source: gitSource, Detectors: []Detector{fired}},
+		SourceAnalysis{Source: webSource, Detectors: []Detector{fired}},
+	)
+	if err != nil {
+		t.Fatalf("RunMultiSource: %v", err)
+	}
+	if report.SourceID != "repo + site" || len(report.Detections) != 2 {
+		t.Fatalf("report = %q with %d detections", report.SourceID, len(report.Detections))
+	}
+	if report.Detections[0].Origin != SourceTypeGit || report.Detections[1].Origin != SourceTypeWeb {
+		t.Errorf("detections should be grouped by origin, got %q then %q", report.Detections[0].Origin, report.Detections[1].Origin)
+	}
+	// git: its weighted score of 40; web: one high detection at full confidence, 0.4
+	if want := (40 + 0.4) / 2; math.Abs(report.OverallScore-want) > 1e-9 {
+		t.Errorf("OverallScore = %v, want %v", report.OverallScore, want)
+	}
+
+	failing := &mockSource{sourceType: "web", fetchErr: errors.New("unreachable")}
+	if _, err := RunMultiSource(context.Background(), NewDefaultDetectionRunner(), nil,
+		SourceAnalysis{Source: gitSource, Detectors: []Detector{fired}},
+		SourceAnalysis{Source: failing},
+	); err == nil || !strings.Contains(err.Error(), "web analysis failed") {
+		t.Errorf("err = %v, want the failing source named", err)
+	}
+}
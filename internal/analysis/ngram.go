@@ -0,0 +1,104 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+)
+
+// NGramCount is one repeated phrase and how often it occurs.
+type NGramCount struct {
+	Phrase string
+	Count  int
+}
+
+// NGramRepetition summarizes repeated word n-grams in a token stream.
+type NGramRepetition struct {
+	Tokens int
+	// Covered is the number of tokens that fall inside at least one n-gram
+	// occurring more than once.
+	Covered int
+	// Coverage is Covered / Tokens.
+	Coverage float64
+	// Top lists the most frequent repeated n-grams, longest first on ties,
+	// leaving out phrases contained in a longer one already listed.
+	Top []NGramCount
+}
+
+// RepeatedNGrams counts the n-grams of minN to maxN tokens in tokens and
+// measures how much of the stream is covered by ones that repeat. It returns
+// a zero result when there are fewer than minN tokens. Templated and
+// generated text reuses whole phrases, so its coverage runs well above that
+// of hand-written text.
+func RepeatedNGrams(tokens []string, minN, maxN, top int) NGramRepetition {
+	result := NGramRepetition{Tokens: len(tokens)}
+	if minN <= 0 || maxN < minN || len(tokens) < minN {
+		return result
+	}
+
+	type gram struct {
+		phrase string
+		n      int
+	}
+	counts := make(map[gram]int)
+	positions := make([][]gram, 0, maxN-minN+1)
+	for n := minN; n <= maxN; n++ {
+		grams := make([]gram, 0, len(tokens))
+		for i := 0; i+n <= len(tokens); i++ {
+			g := gram{strings.Join(tokens[i:i+n], " "), n}
+			counts[g]++
+			grams = append(grams, g)
+		}
+		positions = append(positions, grams)
+	}
+
+	covered := make([]bool, len(tokens))
+	for _, grams := range positions {
+		for i, g := range grams {
+			if counts[g] > 1 {
+				for j := i; j < i+g.n; j++ {
+					covered[j] = true
+				}
+			}
+		}
+	}
+	for _, c := range covered {
+		if c {
+			result.Covered++
+		}
+	}
+	result.Coverage = float64(result.Covered) / float64(len(tokens))
+
+	repeated := make([]gram, 0)
+	for g, c := range counts {
+		if c > 1 {
+			repeated = append(repeated, g)
+		}
+	}
+	sort.Slice(repeated, func(i, j int) bool {
+		a, b := repeated[i], repeated[j]
+		if counts[a] != counts[b] {
+			return counts[a] > counts[b]
+		}
+		if a.n != b.n {
+			return a.n > b.n
+		}
+		return a.phrase < b.phrase
+	})
+
+	for _, g := range repeated {
+		if len(result.Top) >= top {
+			break
+		}
+		contained := false
+		for _, listed := range result.Top {
+			if strings.Contains(" "+listed.Phrase+" ", " "+g.phrase+" ") {
+				contained = true
+				break
+			}
+		}
+		if !contained {
+			result.Top = append(result.Top, NGramCount{Phrase: g.phrase, Count: counts[g]})
+		}
+	}
+	return result
+}
@@ -0,0 +1,44 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepeatedNGrams(t *testing.T) {
+	tokens := strings.Fields("we build great tools for teams and we build great tools for everyone today")
+	rep := RepeatedNGrams(tokens, 3, 4, 5)
+
+	if rep.Tokens != len(tokens) {
+		t.Errorf("Tokens = %d, want %d", rep.Tokens, len(tokens))
+	}
+	// "we build great tools for" appears twice: 5 + 5 of 14 tokens.
+	if rep.Covered != 10 {
+		t.Errorf("Covered = %d, want 10", rep.Covered)
+	}
+	if want := 10.0 / 14.0; rep.Coverage != want {
+		t.Errorf("Coverage = %v, want %v", rep.Coverage, want)
+	}
+
+	if len(rep.Top) == 0 || rep.Top[0].Count != 2 || len(strings.Fields(rep.Top[0].Phrase)) != 4 {
+		t.Fatalf("Top = %+v, want 4-word phrases first", rep.Top)
+	}
+	for i, a := range rep.Top {
+		for j, b := range rep.Top {
+			if i != j && strings.Contains(" "+a.Phrase+" ", " "+b.Phrase+" ") {
+				t.Errorf("Top lists %q, which is contained in %q", b.Phrase, a.Phrase)
+			}
+		}
+	}
+}
+
+func TestRepeatedNGrams_NoRepetition(t *testing.T) {
+	rep := RepeatedNGrams(strings.Fields("every word here is different from the rest"), 3, 4, 5)
+	if rep.Covered != 0 || rep.Coverage != 0 || len(rep.Top) != 0 {
+		t.Errorf("RepeatedNGrams() = %+v, want nothing covered", rep)
+	}
+
+	if rep := RepeatedNGrams([]string{"too", "short"}, 3, 4, 5); rep.Coverage != 0 || rep.Tokens != 2 {
+		t.Errorf("RepeatedNGrams(short) = %+v", rep)
+	}
+}
@@ -3,12 +3,17 @@ package analysis
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
+// AnalysisDurationBuckets are the upper bounds, in seconds, of the analysis
+// duration histogram. A final +Inf bucket is implied.
+var AnalysisDurationBuckets = []float64{0.1, 0.5, 1, 5, 30, 60}
+
 // AnalysisMetrics defines the interface for recording server-level observability data.
 type AnalysisMetrics interface {
 	// RecordAnalysis records a completed analysis with its type and duration.
@@ -29,6 +34,12 @@ type AnalysisMetrics interface {
 	// RecordCacheMiss records a cache miss.
 	RecordCacheMiss(sourceType string)
 
+	// RecordThrottled records a request rejected by the rate limiter.
+	RecordThrottled(route string)
+
+	// RecordFeedback records user feedback on a strategy's detection.
+	RecordFeedback(strategy string, falsePositive bool)
+
 	// Snapshot returns a point-in-time copy of all metrics.
 	Snapshot() *MetricsSnapshot
 
@@ -41,18 +52,44 @@ type AnalysisMetrics interface {
 
 // MetricsSnapshot is a serializable point-in-time view of all collected metrics.
 type MetricsSnapshot struct {
-	CollectedAt     time.Time                     `json:"collectedAt"`
-	UptimeSeconds   float64                       `json:"uptimeSeconds"`
-	TotalAnalyses   int64                         `json:"totalAnalyses"`
-	TotalErrors     int64                         `json:"totalErrors"`
-	TotalDetections int64                         `json:"totalDetections"`
-	TotalFlagged    int64                         `json:"totalFlagged"`
-	BySource        map[string]*SourceMetricsData `json:"bySource"`
-	ByStrategy      map[string]*StrategyMetrics   `json:"byStrategy"`
-	ErrorsByPhase   map[string]int64              `json:"errorsByPhase"`
-	CacheHits       int64                         `json:"cacheHits"`
-	CacheMisses     int64                         `json:"cacheMisses"`
-	AvgDurationMs   float64                       `json:"avgDurationMs"`
+	CollectedAt        time.Time                     `json:"collectedAt"`
+	UptimeSeconds      float64                       `json:"uptimeSeconds"`
+	TotalAnalyses      int64                         `json:"totalAnalyses"`
+	TotalErrors        int64                         `json:"totalErrors"`
+	TotalDetections    int64                         `json:"totalDetections"`
+	TotalFlagged       int64                         `json:"totalFlagged"`
+	BySource           map[string]*SourceMetricsData `json:"bySource"`
+	ByStrategy         map[string]*StrategyMetrics   `json:"byStrategy"`
+	ErrorsByPhase      map[string]int64              `json:"errorsByPhase"`
+	CacheHits          int64                         `json:"cacheHits"`
+	CacheMisses        int64                         `json:"cacheMisses"`
+	AvgDurationMs      float64                       `json:"avgDurationMs"`
+	Throttled          int64                         `json:"throttled"`
+	ThrottledByRoute   map[string]int64              `json:"throttledByRoute"`
+	FeedbackByStrategy map[string]*FeedbackMetrics   `json:"feedbackByStrategy"`
+	AITokens           *AITokenSnapshot              `json:"aiTokens,omitempty"`
+	Clones             *SlotSnapshot                 `json:"clones,omitempty"`
+}
+
+// SlotUsage reports the use of a fixed number of slots, such as the webhook
+// server's concurrent clone slots.
+type SlotUsage interface {
+	InUse() int
+	Waiting() int
+	Limit() int
+}
+
+// SlotSnapshot is the point-in-time use of a SlotUsage.
+type SlotSnapshot struct {
+	InUse   int `json:"inUse"`
+	Waiting int `json:"waiting"`
+	Limit   int `json:"limit"`
+}
+
+// FeedbackMetrics counts user feedback on one strategy's detections.
+type FeedbackMetrics struct {
+	FalsePositives int64 `json:"falsePositives"`
+	Confirmed      int64 `json:"confirmed"`
 }
 
 // SourceMetricsData holds per-source-type metrics.
@@ -65,6 +102,16 @@ type SourceMetricsData struct {
 	AvgDurationMs   float64 `json:"avgDurationMs"`
 	CacheHits       int64   `json:"cacheHits"`
 	CacheMisses     int64   `json:"cacheMisses"`
+
+	Duration *DurationHistogram `json:"duration"`
+}
+
+// DurationHistogram is a cumulative histogram of analysis durations.
+// Buckets[i] counts analyses that took at most AnalysisDurationBuckets[i].
+type DurationHistogram struct {
+	Buckets    []int64 `json:"buckets"`
+	SumSeconds float64 `json:"sumSeconds"`
+	Count      int64   `json:"count"`
 }
 
 // StrategyMetrics holds per-strategy execution metrics.
@@ -85,11 +132,22 @@ type InMemoryMetrics struct {
 	totalFlagged  atomic.Int64
 	cacheHits     atomic.Int64
 	cacheMisses   atomic.Int64
+	throttled     atomic.Int64
 
 	// Guarded by mu
 	sources    map[string]*sourceCounter
 	strategies map[string]*strategyCounter
 	errors     map[string]*atomic.Int64 // phase -> count
+	throttles  map[string]*atomic.Int64 // route -> count
+	feedback   map[string]*feedbackCounter
+
+	aiTokens *AITokenMetrics
+	clones   SlotUsage
+}
+
+type feedbackCounter struct {
+	falsePositives atomic.Int64
+	confirmed      atomic.Int64
 }
 
 type sourceCounter struct {
@@ -100,12 +158,33 @@ type sourceCounter struct {
 	totalDurMs  atomic.Int64
 	cacheHits   atomic.Int64
 	cacheMisses atomic.Int64
+
+	// durBuckets[i] counts durations in (bound[i-1], bound[i]]; the last
+	// entry is the +Inf overflow. Cumulated in Snapshot.
+	durBuckets []atomic.Int64
+	durSumNs   atomic.Int64
+}
+
+func (sc *sourceCounter) durationHistogram() *DurationHistogram {
+	h := &DurationHistogram{
+		Buckets:    make([]int64, len(AnalysisDurationBuckets)),
+		SumSeconds: time.Duration(sc.durSumNs.Load()).Seconds(),
+	}
+	for i := range sc.durBuckets {
+		h.Count += sc.durBuckets[i].Load()
+		if i < len(h.Buckets) {
+			h.Buckets[i] = h.Count
+		}
+	}
+	return h
 }
 
 type strategyCounter struct {
 	executions atomic.Int64
 	detections atomic.Int64
-	totalDurMs atomic.Int64
+	// totalDurNs is kept in nanoseconds: a strategy often runs in well under
+	// a millisecond, which whole-millisecond sums would round away.
+	totalDurNs atomic.Int64
 }
 
 // NewInMemoryMetrics creates a new in-memory metrics collector.
@@ -115,9 +194,26 @@ func NewInMemoryMetrics() *InMemoryMetrics {
 		sources:    make(map[string]*sourceCounter),
 		strategies: make(map[string]*strategyCounter),
 		errors:     make(map[string]*atomic.Int64),
+		throttles:  make(map[string]*atomic.Int64),
+		feedback:   make(map[string]*feedbackCounter),
+		aiTokens:   NewAITokenMetrics(nil),
 	}
 }
 
+// AITokens returns the collector for AI token usage, whose totals and
+// estimated cost are part of the snapshot and Prometheus output.
+func (m *InMemoryMetrics) AITokens() *AITokenMetrics {
+	return m.aiTokens
+}
+
+// TrackClones makes the snapshot and Prometheus output report the clone
+// slots u hands out.
+func (m *InMemoryMetrics) TrackClones(u SlotUsage) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.clones = u
+}
+
 func (m *InMemoryMetrics) getSource(sourceType string) *sourceCounter {
 	m.mu.RLock()
 	sc, ok := m.sources[sourceType]
@@ -132,7 +228,7 @@ func (m *InMemoryMetrics) getSource(sourceType string) *sourceCounter {
 	if sc, ok = m.sources[sourceType]; ok {
 		return sc
 	}
-	sc = &sourceCounter{}
+	sc = &sourceCounter{durBuckets: make([]atomic.Int64, len(AnalysisDurationBuckets)+1)}
 	m.sources[sourceType] = sc
 	return sc
 }
@@ -173,12 +269,62 @@ func (m *InMemoryMetrics) getPhaseError(phase string) *atomic.Int64 {
 	return counter
 }
 
+func (m *InMemoryMetrics) getThrottle(route string) *atomic.Int64 {
+	m.mu.RLock()
+	counter, ok := m.throttles[route]
+	m.mu.RUnlock()
+	if ok {
+		return counter
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if counter, ok = m.throttles[route]; ok {
+		return counter
+	}
+	counter = &atomic.Int64{}
+	m.throttles[route] = counter
+	return counter
+}
+
+func (m *InMemoryMetrics) getFeedback(strategy string) *feedbackCounter {
+	m.mu.RLock()
+	fc, ok := m.feedback[strategy]
+	m.mu.RUnlock()
+	if ok {
+		return fc
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if fc, ok = m.feedback[strategy]; ok {
+		return fc
+	}
+	fc = &feedbackCounter{}
+	m.feedback[strategy] = fc
+	return fc
+}
+
 // RecordAnalysis records a completed analysis.
 func (m *InMemoryMetrics) RecordAnalysis(sourceType string, duration time.Duration) {
 	m.totalAnalyses.Add(1)
 	sc := m.getSource(sourceType)
 	sc.analyses.Add(1)
 	sc.totalDurMs.Add(duration.Milliseconds())
+	sc.durSumNs.Add(duration.Nanoseconds())
+	sc.durBuckets[durationBucket(duration)].Add(1)
+}
+
+// durationBucket returns the index of the first bucket whose bound is at
+// least duration, or the +Inf bucket.
+func durationBucket(duration time.Duration) int {
+	seconds := duration.Seconds()
+	for i, bound := range AnalysisDurationBuckets {
+		if seconds <= bound {
+			return i
+		}
+	}
+	return len(AnalysisDurationBuckets)
 }
 
 // RecordDetections records detection counts.
@@ -202,7 +348,7 @@ func (m *InMemoryMetrics) RecordError(sourceType string, phase string) {
 func (m *InMemoryMetrics) RecordStrategyExecution(strategy string, detected bool, duration time.Duration) {
 	sc := m.getStrategy(strategy)
 	sc.executions.Add(1)
-	sc.totalDurMs.Add(duration.Milliseconds())
+	sc.totalDurNs.Add(duration.Nanoseconds())
 	if detected {
 		sc.detections.Add(1)
 	}
@@ -220,6 +366,22 @@ func (m *InMemoryMetrics) RecordCacheMiss(sourceType string) {
 	m.getSource(sourceType).cacheMisses.Add(1)
 }
 
+// RecordThrottled records a rate-limited request for the route.
+func (m *InMemoryMetrics) RecordThrottled(route string) {
+	m.throttled.Add(1)
+	m.getThrottle(route).Add(1)
+}
+
+// RecordFeedback records a false-positive report or a confirmation for the strategy.
+func (m *InMemoryMetrics) RecordFeedback(strategy string, falsePositive bool) {
+	fc := m.getFeedback(strategy)
+	if falsePositive {
+		fc.falsePositives.Add(1)
+	} else {
+		fc.confirmed.Add(1)
+	}
+}
+
 // Snapshot returns a point-in-time copy of all metrics.
 func (m *InMemoryMetrics) Snapshot() *MetricsSnapshot {
 	m.mu.RLock()
@@ -229,17 +391,20 @@ func (m *InMemoryMetrics) Snapshot() *MetricsSnapshot {
 	totalAnalyses := m.totalAnalyses.Load()
 
 	snap := &MetricsSnapshot{
-		CollectedAt:     now,
-		UptimeSeconds:   now.Sub(m.startTime).Seconds(),
-		TotalAnalyses:   totalAnalyses,
-		TotalErrors:     m.totalErrors.Load(),
-		TotalDetections: m.totalDetect.Load(),
-		TotalFlagged:    m.totalFlagged.Load(),
-		CacheHits:       m.cacheHits.Load(),
-		CacheMisses:     m.cacheMisses.Load(),
-		BySource:        make(map[string]*SourceMetricsData),
-		ByStrategy:      make(map[string]*StrategyMetrics),
-		ErrorsByPhase:   make(map[string]int64),
+		CollectedAt:        now,
+		UptimeSeconds:      now.Sub(m.startTime).Seconds(),
+		TotalAnalyses:      totalAnalyses,
+		TotalErrors:        m.totalErrors.Load(),
+		TotalDetections:    m.totalDetect.Load(),
+		TotalFlagged:       m.totalFlagged.Load(),
+		CacheHits:          m.cacheHits.Load(),
+		CacheMisses:        m.cacheMisses.Load(),
+		Throttled:          m.throttled.Load(),
+		BySource:           make(map[string]*SourceMetricsData),
+		ByStrategy:         make(map[string]*StrategyMetrics),
+		ErrorsByPhase:      make(map[string]int64),
+		ThrottledByRoute:   make(map[string]int64),
+		FeedbackByStrategy: make(map[string]*FeedbackMetrics),
 	}
 
 	// Per-source breakdown
@@ -261,6 +426,7 @@ func (m *InMemoryMetrics) Snapshot() *MetricsSnapshot {
 			AvgDurationMs:   avgMs,
 			CacheHits:       sc.cacheHits.Load(),
 			CacheMisses:     sc.cacheMisses.Load(),
+			Duration:        sc.durationHistogram(),
 		}
 	}
 
@@ -271,15 +437,15 @@ func (m *InMemoryMetrics) Snapshot() *MetricsSnapshot {
 	// Per-strategy breakdown
 	for name, sc := range m.strategies {
 		execs := sc.executions.Load()
-		durMs := sc.totalDurMs.Load()
+		dur := time.Duration(sc.totalDurNs.Load())
 		var avgMs float64
 		if execs > 0 {
-			avgMs = float64(durMs) / float64(execs)
+			avgMs = float64(dur) / float64(time.Millisecond) / float64(execs)
 		}
 		snap.ByStrategy[name] = &StrategyMetrics{
 			Executions:      execs,
 			Detections:      sc.detections.Load(),
-			TotalDurationMs: durMs,
+			TotalDurationMs: dur.Milliseconds(),
 			AvgDurationMs:   avgMs,
 		}
 	}
@@ -289,6 +455,22 @@ func (m *InMemoryMetrics) Snapshot() *MetricsSnapshot {
 		snap.ErrorsByPhase[phase] = counter.Load()
 	}
 
+	for route, counter := range m.throttles {
+		snap.ThrottledByRoute[route] = counter.Load()
+	}
+
+	for strategy, fc := range m.feedback {
+		snap.FeedbackByStrategy[strategy] = &FeedbackMetrics{
+			FalsePositives: fc.falsePositives.Load(),
+			Confirmed:      fc.confirmed.Load(),
+		}
+	}
+
+	snap.AITokens = m.aiTokens.Snapshot()
+	if m.clones != nil {
+		snap.Clones = &SlotSnapshot{InUse: m.clones.InUse(), Waiting: m.clones.Waiting(), Limit: m.clones.Limit()}
+	}
+
 	return snap
 }
 
@@ -317,6 +499,13 @@ func (m *InMemoryMetrics) PrometheusFormat() string {
 	writePromMetric(&b, "cadence_cache_misses_total", "counter", "Total cache misses",
 		fmt.Sprintf("%d", snap.CacheMisses))
 
+	// Rate limiting
+	writePromMetric(&b, "cadence_throttled_requests_total", "counter", "Total requests rejected by the rate limiter",
+		fmt.Sprintf("%d", snap.Throttled))
+	for _, route := range sortedKeys(snap.ThrottledByRoute) {
+		b.WriteString(fmt.Sprintf("cadence_throttled_requests{route=\"%s\"} %d\n", route, snap.ThrottledByRoute[route]))
+	}
+
 	// Per-source metrics
 	sourceNames := sortedKeys(snap.BySource)
 	for _, name := range sourceNames {
@@ -327,6 +516,22 @@ func (m *InMemoryMetrics) PrometheusFormat() string {
 		b.WriteString(fmt.Sprintf("cadence_source_avg_duration_ms{source=\"%s\"} %.2f\n", name, sd.AvgDurationMs))
 	}
 
+	// Analysis duration histogram per source
+	if len(sourceNames) > 0 {
+		b.WriteString("# HELP cadence_analysis_duration_seconds Analysis duration in seconds\n")
+		b.WriteString("# TYPE cadence_analysis_duration_seconds histogram\n")
+	}
+	for _, name := range sourceNames {
+		h := snap.BySource[name].Duration
+		for i, bound := range AnalysisDurationBuckets {
+			b.WriteString(fmt.Sprintf("cadence_analysis_duration_seconds_bucket{source=\"%s\",le=\"%s\"} %d\n",
+				name, strconv.FormatFloat(bound, 'g', -1, 64), h.Buckets[i]))
+		}
+		b.WriteString(fmt.Sprintf("cadence_analysis_duration_seconds_bucket{source=\"%s\",le=\"+Inf\"} %d\n", name, h.Count))
+		b.WriteString(fmt.Sprintf("cadence_analysis_duration_seconds_sum{source=\"%s\"} %g\n", name, h.SumSeconds))
+		b.WriteString(fmt.Sprintf("cadence_analysis_duration_seconds_count{source=\"%s\"} %d\n", name, h.Count))
+	}
+
 	// Per-strategy metrics (top strategies only to avoid huge output)
 	stratNames := sortedKeys(snap.ByStrategy)
 	for _, name := range stratNames {
@@ -336,6 +541,45 @@ func (m *InMemoryMetrics) PrometheusFormat() string {
 		b.WriteString(fmt.Sprintf("cadence_strategy_avg_duration_ms{strategy=\"%s\"} %.2f\n", name, sm.AvgDurationMs))
 	}
 
+	// Detection feedback per strategy
+	for _, name := range sortedKeys(snap.FeedbackByStrategy) {
+		fm := snap.FeedbackByStrategy[name]
+		b.WriteString(fmt.Sprintf("cadence_strategy_false_positives_total{strategy=\"%s\"} %d\n", name, fm.FalsePositives))
+		b.WriteString(fmt.Sprintf("cadence_strategy_confirmed_total{strategy=\"%s\"} %d\n", name, fm.Confirmed))
+	}
+
+	// AI token usage and estimated spend
+	if ai := snap.AITokens; ai != nil {
+		writePromMetric(&b, "cadence_ai_calls_total", "counter", "Total AI provider calls that reported token usage",
+			fmt.Sprintf("%d", ai.Calls))
+		writePromMetric(&b, "cadence_ai_prompt_tokens_total", "counter", "Total prompt tokens sent to AI providers",
+			fmt.Sprintf("%d", ai.PromptTokens))
+		writePromMetric(&b, "cadence_ai_completion_tokens_total", "counter", "Total completion tokens returned by AI providers",
+			fmt.Sprintf("%d", ai.CompletionTokens))
+		writePromMetric(&b, "cadence_ai_estimated_cost_usd", "counter", "Estimated AI spend in US dollars for priced models",
+			fmt.Sprintf("%.6f", ai.EstimatedCostUSD))
+		for _, key := range sortedKeys(ai.ByModel) {
+			u := ai.ByModel[key]
+			labels := fmt.Sprintf("provider=\"%s\",model=\"%s\"", u.Provider, u.Model)
+			b.WriteString(fmt.Sprintf("cadence_ai_model_calls_total{%s} %d\n", labels, u.Calls))
+			b.WriteString(fmt.Sprintf("cadence_ai_model_prompt_tokens_total{%s} %d\n", labels, u.PromptTokens))
+			b.WriteString(fmt.Sprintf("cadence_ai_model_completion_tokens_total{%s} %d\n", labels, u.CompletionTokens))
+			if u.Priced {
+				b.WriteString(fmt.Sprintf("cadence_ai_model_estimated_cost_usd{%s} %.6f\n", labels, u.EstimatedCostUSD))
+			}
+		}
+	}
+
+	// Clone slots
+	if c := snap.Clones; c != nil {
+		writePromMetric(&b, "cadence_clones_in_use", "gauge", "Repository clones holding a clone slot",
+			fmt.Sprintf("%d", c.InUse))
+		writePromMetric(&b, "cadence_clones_waiting", "gauge", "Repository clones waiting for a clone slot",
+			fmt.Sprintf("%d", c.Waiting))
+		writePromMetric(&b, "cadence_clone_slots", "gauge", "Concurrent repository clones allowed",
+			fmt.Sprintf("%d", c.Limit))
+	}
+
 	// Errors by phase
 	phaseNames := sortedKeys(snap.ErrorsByPhase)
 	for _, phase := range phaseNames {
@@ -357,9 +601,13 @@ func (m *InMemoryMetrics) Reset() {
 	m.totalFlagged.Store(0)
 	m.cacheHits.Store(0)
 	m.cacheMisses.Store(0)
+	m.throttled.Store(0)
 	m.sources = make(map[string]*sourceCounter)
 	m.strategies = make(map[string]*strategyCounter)
 	m.errors = make(map[string]*atomic.Int64)
+	m.throttles = make(map[string]*atomic.Int64)
+	m.feedback = make(map[string]*feedbackCounter)
+	m.aiTokens.Reset()
 }
 
 // NullMetrics is a no-op AnalysisMetrics implementation for when metrics are disabled.
@@ -371,6 +619,8 @@ func (NullMetrics) RecordError(string, string)                          {}
 func (NullMetrics) RecordStrategyExecution(string, bool, time.Duration) {}
 func (NullMetrics) RecordCacheHit(string)                               {}
 func (NullMetrics) RecordCacheMiss(string)                              {}
+func (NullMetrics) RecordThrottled(string)                              {}
+func (NullMetrics) RecordFeedback(string, bool)                         {}
 func (NullMetrics) Snapshot() *MetricsSnapshot                          { return &MetricsSnapshot{} }
 func (NullMetrics) PrometheusFormat() string                            { return "" }
 func (NullMetrics) Reset()                                              {}
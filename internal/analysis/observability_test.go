@@ -93,6 +93,18 @@ func TestInMemoryMetrics_RecordStrategyExecution(t *testing.T) {
 	}
 }
 
+func TestInMemoryMetrics_StrategyDurationKeepsSubMillisecond(t *testing.T) {
+	m := NewInMemoryMetrics()
+	for i := 0; i < 4; i++ {
+		m.RecordStrategyExecution("size_analysis", false, 250*time.Microsecond)
+	}
+
+	s := m.Snapshot().ByStrategy["size_analysis"]
+	if s.AvgDurationMs != 0.25 || s.TotalDurationMs != 1 {
+		t.Errorf("AvgDurationMs = %v, TotalDurationMs = %d, want 0.25 and 1", s.AvgDurationMs, s.TotalDurationMs)
+	}
+}
+
 func TestInMemoryMetrics_CacheHitsMisses(t *testing.T) {
 	m := NewInMemoryMetrics()
 
@@ -115,6 +127,155 @@ func TestInMemoryMetrics_CacheHitsMisses(t *testing.T) {
 	}
 }
 
+func TestInMemoryMetrics_RecordThrottled(t *testing.T) {
+	m := NewInMemoryMetrics()
+
+	m.RecordThrottled("/api/analyze/repository")
+	m.RecordThrottled("/api/analyze/repository")
+	m.RecordThrottled("/api/stream/website")
+
+	snap := m.Snapshot()
+	if snap.Throttled != 3 {
+		t.Fatalf("got %d throttled requests, want 3", snap.Throttled)
+	}
+	if snap.ThrottledByRoute["/api/analyze/repository"] != 2 {
+		t.Fatalf("got %d throttled repository requests, want 2", snap.ThrottledByRoute["/api/analyze/repository"])
+	}
+
+	prom := m.PrometheusFormat()
+	for _, want := range []string{
+		"cadence_throttled_requests_total 3",
+		`cadence_throttled_requests{route="/api/stream/website"} 1`,
+	} {
+		if !strings.Contains(prom, want) {
+			t.Errorf("PrometheusFormat() missing %q", want)
+		}
+	}
+}
+
+func TestInMemoryMetrics_RecordFeedback(t *testing.T) {
+	m := NewInMemoryMetrics()
+
+	m.RecordFeedback("size_analysis", true)
+	m.RecordFeedback("size_analysis", true)
+	m.RecordFeedback("size_analysis", false)
+
+	fm := m.Snapshot().FeedbackByStrategy["size_analysis"]
+	if fm == nil || fm.FalsePositives != 2 || fm.Confirmed != 1 {
+		t.Fatalf("got %+v, want 2 false positives and 1 confirmation", fm)
+	}
+
+	prom := m.PrometheusFormat()
+	if want := `cadence_strategy_false_positives_total{strategy="size_analysis"} 2`; !strings.Contains(prom, want) {
+		t.Errorf("PrometheusFormat() missing %q", want)
+	}
+}
+
+func TestInMemoryMetrics_AITokens(t *testing.T) {
+	m := NewInMemoryMetrics()
+	m.AITokens().SetPricing(map[string]TokenPrice{"gpt-4o-mini": {PromptPer1K: 0.15, CompletionPer1K: 0.6}})
+
+	m.AITokens().RecordAITokens("openai", "gpt-4o-mini", 2000, 1000)
+	m.AITokens().RecordAITokens("ollama", "llama3.2", 100, 50)
+
+	snap := m.Snapshot().AITokens
+	if snap == nil || snap.Calls != 2 || snap.PromptTokens != 2100 {
+		t.Fatalf("Snapshot().AITokens = %+v, want both calls", snap)
+	}
+
+	prom := m.PrometheusFormat()
+	for _, want := range []string{
+		"cadence_ai_prompt_tokens_total 2100",
+		"cadence_ai_estimated_cost_usd 0.900000",
+		`cadence_ai_model_completion_tokens_total{provider="ollama",model="llama3.2"} 50`,
+		`cadence_ai_model_estimated_cost_usd{provider="openai",model="gpt-4o-mini"} 0.900000`,
+	} {
+		if !strings.Contains(prom, want) {
+			t.Errorf("PrometheusFormat() missing %q", want)
+		}
+	}
+	if strings.Contains(prom, `cadence_ai_model_estimated_cost_usd{provider="ollama"`) {
+		t.Error("PrometheusFormat() should not report a cost for unpriced models")
+	}
+
+	m.Reset()
+	if snap := m.Snapshot().AITokens; snap.Calls != 0 {
+		t.Errorf("after Reset AITokens = %+v, want no calls", snap)
+	}
+}
+
+type fixedSlots struct{ inUse, waiting, limit int }
+
+func (f fixedSlots) InUse() int   { return f.inUse }
+func (f fixedSlots) Waiting() int { return f.waiting }
+func (f fixedSlots) Limit() int   { return f.limit }
+
+func TestInMemoryMetrics_TrackClones(t *testing.T) {
+	m := NewInMemoryMetrics()
+	if m.Snapshot().Clones != nil || strings.Contains(m.PrometheusFormat(), "cadence_clones_in_use") {
+		t.Error("clone slots should not be reported before TrackClones")
+	}
+
+	m.TrackClones(fixedSlots{inUse: 2, waiting: 3, limit: 2})
+	if want := (SlotSnapshot{InUse: 2, Waiting: 3, Limit: 2}); m.Snapshot().Clones == nil || *m.Snapshot().Clones != want {
+		t.Errorf("Snapshot().Clones = %+v, want %+v", m.Snapshot().Clones, want)
+	}
+	prom := m.PrometheusFormat()
+	for _, want := range []string{"cadence_clones_in_use 2", "cadence_clones_waiting 3", "cadence_clone_slots 2"} {
+		if !strings.Contains(prom, want) {
+			t.Errorf("PrometheusFormat() missing %q", want)
+		}
+	}
+}
+
+func TestInMemoryMetrics_DurationHistogram(t *testing.T) {
+	m := NewInMemoryMetrics()
+
+	for _, d := range []time.Duration{
+		50 * time.Millisecond,
+		100 * time.Millisecond, // on the bound: le="0.1"
+		300 * time.Millisecond,
+		2 * time.Second,
+		45 * time.Second,
+		2 * time.Minute,
+	} {
+		m.RecordAnalysis("git", d)
+	}
+	m.RecordAnalysis("web", 700*time.Millisecond)
+
+	h := m.Snapshot().BySource["git"].Duration
+	want := []int64{2, 3, 3, 4, 4, 5}
+	for i := range want {
+		if h.Buckets[i] != want[i] {
+			t.Errorf("bucket le=%g = %d, want %d", AnalysisDurationBuckets[i], h.Buckets[i], want[i])
+		}
+	}
+	if h.Count != 6 {
+		t.Errorf("count = %d, want 6", h.Count)
+	}
+	if h.SumSeconds != 167.45 {
+		t.Errorf("sum = %g, want 167.45", h.SumSeconds)
+	}
+
+	prom := m.PrometheusFormat()
+	for _, want := range []string{
+		"# TYPE cadence_analysis_duration_seconds histogram",
+		`cadence_analysis_duration_seconds_bucket{source="git",le="0.1"} 2`,
+		`cadence_analysis_duration_seconds_bucket{source="git",le="5"} 4`,
+		`cadence_analysis_duration_seconds_bucket{source="git",le="60"} 5`,
+		`cadence_analysis_duration_seconds_bucket{source="git",le="+Inf"} 6`,
+		`cadence_analysis_duration_seconds_sum{source="git"} 167.45`,
+		`cadence_analysis_duration_seconds_count{source="git"} 6`,
+		`cadence_analysis_duration_seconds_bucket{source="web",le="1"} 1`,
+		`cadence_analysis_duration_seconds_bucket{source="web",le="0.5"} 0`,
+		"cadence_analysis_avg_duration_ms",
+	} {
+		if !strings.Contains(prom, want) {
+			t.Errorf("PrometheusFormat() missing %q", want)
+		}
+	}
+}
+
 func TestInMemoryMetrics_Reset(t *testing.T) {
 	m := NewInMemoryMetrics()
 
@@ -200,6 +361,7 @@ func TestNullMetrics(t *testing.T) {
 	m.RecordStrategyExecution("test", true, time.Second)
 	m.RecordCacheHit("git")
 	m.RecordCacheMiss("git")
+	m.RecordThrottled("/api/analyze/website")
 	m.Reset()
 
 	snap := m.Snapshot()
@@ -3,13 +3,15 @@ package analysis
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/TryCadence/Cadence/internal/logging"
 )
 
 // StrategyPlugin defines the interface that custom detection strategy plugins must implement.
-// Plugins are loaded programmatically and registered with the PluginManager.
+// Plugins are registered programmatically with the PluginManager or loaded
+// from a manifest by LoadManifest.
 type StrategyPlugin interface {
 	// Info returns metadata about the strategy this plugin provides.
 	Info() StrategyInfo
@@ -33,7 +35,8 @@ func (pd *PluginDetector) Detect(ctx context.Context, data *SourceData) ([]Detec
 type PluginManager struct {
 	mu      sync.RWMutex
 	plugins map[string]StrategyPlugin
-	enabled map[string]bool // nil means "all enabled"
+	sources map[string]string // strategy name -> where it was loaded from
+	enabled map[string]bool   // nil means "all enabled"
 	logger  *logging.Logger
 }
 
@@ -41,6 +44,7 @@ type PluginManager struct {
 func NewPluginManager() *PluginManager {
 	return &PluginManager{
 		plugins: make(map[string]StrategyPlugin),
+		sources: make(map[string]string),
 		logger:  logging.Default().With("component", "plugin_manager"),
 	}
 }
@@ -52,13 +56,27 @@ func NewPluginManagerWithLogger(logger *logging.Logger) *PluginManager {
 	}
 	return &PluginManager{
 		plugins: make(map[string]StrategyPlugin),
+		sources: make(map[string]string),
 		logger:  logger.With("component", "plugin_manager"),
 	}
 }
 
+// PluginInfo describes a registered plugin: its strategy metadata and, for
+// plugins loaded from a manifest, the shared object or hook it came from.
+type PluginInfo struct {
+	StrategyInfo
+	Source string `json:"source,omitempty"`
+}
+
 // Register adds a plugin. If a plugin with the same strategy name already exists
 // it is replaced (allowing hot-reload patterns).
 func (pm *PluginManager) Register(p StrategyPlugin) error {
+	return pm.RegisterFrom(p, "")
+}
+
+// RegisterFrom adds a plugin like Register and records source, the path or
+// hook it was loaded from, for Plugins.
+func (pm *PluginManager) RegisterFrom(p StrategyPlugin, source string) error {
 	if p == nil {
 		return fmt.Errorf("cannot register nil plugin")
 	}
@@ -71,7 +89,12 @@ func (pm *PluginManager) Register(p StrategyPlugin) error {
 	defer pm.mu.Unlock()
 
 	pm.plugins[info.Name] = p
-	pm.logger.Info("plugin registered", "strategy", info.Name, "category", info.Category)
+	if source != "" {
+		pm.sources[info.Name] = source
+	} else {
+		delete(pm.sources, info.Name)
+	}
+	pm.logger.Info("plugin registered", "strategy", info.Name, "category", info.Category, "source", source)
 	return nil
 }
 
@@ -81,6 +104,7 @@ func (pm *PluginManager) Unregister(name string) bool {
 	defer pm.mu.Unlock()
 	if _, ok := pm.plugins[name]; ok {
 		delete(pm.plugins, name)
+		delete(pm.sources, name)
 		pm.logger.Info("plugin unregistered", "strategy", name)
 		return true
 	}
@@ -107,6 +131,20 @@ func (pm *PluginManager) List() []StrategyInfo {
 	return infos
 }
 
+// Plugins returns info about all registered plugins with their sources,
+// sorted by strategy name.
+func (pm *PluginManager) Plugins() []PluginInfo {
+	pm.mu.RLock()
+	defer pm.mu.RUnlock()
+
+	plugins := make([]PluginInfo, 0, len(pm.plugins))
+	for name, p := range pm.plugins {
+		plugins = append(plugins, PluginInfo{StrategyInfo: p.Info(), Source: pm.sources[name]})
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
 // Count returns the number of registered plugins.
 func (pm *PluginManager) Count() int {
 	pm.mu.RLock()
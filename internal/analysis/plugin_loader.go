@@ -0,0 +1,153 @@
+package analysis
+
+import (
+	"fmt"
+	"os"
+	"plugin"
+	"sort"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PluginSymbol is the function a Go plugin (.so built with
+// -buildmode=plugin) must export to be loaded from a manifest.
+const PluginSymbol = "NewStrategy"
+
+// PluginManifest lists the strategy plugins to load at server startup.
+// Each entry names either a shared object to open or a hook registered
+// with RegisterPluginHook by code compiled into the binary.
+//
+//	plugins:
+//	  - path: /opt/cadence/plugins/license_header.so
+//	  - hook: vendored_sql
+type PluginManifest struct {
+	Plugins []PluginManifestEntry `yaml:"plugins"`
+}
+
+// PluginManifestEntry is one plugin of a PluginManifest.
+type PluginManifestEntry struct {
+	Path string `yaml:"path"`
+	Hook string `yaml:"hook"`
+}
+
+// source describes where the entry's plugin comes from.
+func (e PluginManifestEntry) source() string {
+	if e.Path != "" {
+		return e.Path
+	}
+	return "hook:" + e.Hook
+}
+
+var (
+	pluginHooksMu sync.RWMutex
+	pluginHooks   = make(map[string]func() StrategyPlugin)
+)
+
+// RegisterPluginHook makes a compiled-in plugin loadable by name from a
+// manifest, typically from an init function, the way database/sql drivers
+// register themselves. Registering the same name again replaces the hook.
+func RegisterPluginHook(name string, newStrategy func() StrategyPlugin) {
+	pluginHooksMu.Lock()
+	defer pluginHooksMu.Unlock()
+	pluginHooks[name] = newStrategy
+}
+
+// PluginHooks returns the names of the registered hooks, sorted.
+func PluginHooks() []string {
+	pluginHooksMu.RLock()
+	defer pluginHooksMu.RUnlock()
+	names := make([]string, 0, len(pluginHooks))
+	for name := range pluginHooks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// openPlugin opens a shared object and returns its NewStrategy function.
+// Tests replace it to avoid building real plugins.
+var openPlugin = func(path string) (func() StrategyPlugin, error) {
+	p, err := plugin.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	sym, err := p.Lookup(PluginSymbol)
+	if err != nil {
+		return nil, err
+	}
+	newStrategy, ok := sym.(func() StrategyPlugin)
+	if !ok {
+		return nil, fmt.Errorf("%s has type %T, want func() analysis.StrategyPlugin", PluginSymbol, sym)
+	}
+	return newStrategy, nil
+}
+
+// ReadPluginManifest parses the YAML (or JSON) manifest at path.
+func ReadPluginManifest(path string) (*PluginManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin manifest: %w", err)
+	}
+	var manifest PluginManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin manifest %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// LoadManifest reads the manifest at path and registers every plugin it
+// lists, returning how many were loaded. A plugin that fails to load is
+// logged and skipped so one broken entry cannot keep the server from
+// starting; only an unreadable manifest is an error.
+func (pm *PluginManager) LoadManifest(path string) (int, error) {
+	manifest, err := ReadPluginManifest(path)
+	if err != nil {
+		return 0, err
+	}
+
+	loaded := 0
+	for i, entry := range manifest.Plugins {
+		if err := pm.loadEntry(entry); err != nil {
+			pm.logger.Warn("skipping plugin",
+				"manifest", path,
+				"entry", i,
+				"source", entry.source(),
+				"error", err.Error(),
+			)
+			continue
+		}
+		loaded++
+	}
+	pm.logger.Info("plugin manifest loaded", "manifest", path, "loaded", loaded, "skipped", len(manifest.Plugins)-loaded)
+	return loaded, nil
+}
+
+func (pm *PluginManager) loadEntry(entry PluginManifestEntry) (err error) {
+	var newStrategy func() StrategyPlugin
+	switch {
+	case entry.Path != "" && entry.Hook != "":
+		return fmt.Errorf("entry sets both path and hook")
+	case entry.Path != "":
+		if newStrategy, err = openPlugin(entry.Path); err != nil {
+			return err
+		}
+	case entry.Hook != "":
+		pluginHooksMu.RLock()
+		newStrategy = pluginHooks[entry.Hook]
+		pluginHooksMu.RUnlock()
+		if newStrategy == nil {
+			return fmt.Errorf("no plugin hook registered as %q", entry.Hook)
+		}
+	default:
+		return fmt.Errorf("entry sets neither path nor hook")
+	}
+
+	// Constructors run third-party code; treat a panic like any failure.
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s panicked: %v", PluginSymbol, r)
+		}
+	}()
+	return pm.RegisterFrom(newStrategy(), entry.source())
+}
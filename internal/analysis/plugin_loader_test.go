@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeManifest(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "plugins.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestPluginManager_LoadManifest(t *testing.T) {
+	realOpen := openPlugin
+	t.Cleanup(func() { openPlugin = realOpen })
+	openPlugin = func(path string) (func() StrategyPlugin, error) {
+		if filepath.Base(path) != "license.so" {
+			return nil, errors.New("plugin.Open: realpath failed")
+		}
+		return func() StrategyPlugin {
+			return &mockPlugin{info: StrategyInfo{Name: "license_header", Category: CategoryPattern}}
+		}, nil
+	}
+	RegisterPluginHook("test_vendored_sql", func() StrategyPlugin {
+		return &mockPlugin{info: StrategyInfo{Name: "vendored_sql", Category: CategoryPattern}}
+	})
+	RegisterPluginHook("test_panics", func() StrategyPlugin { panic("boom") })
+
+	path := writeManifest(t, `plugins:
+  - path: /opt/plugins/license.so
+  - hook: test_vendored_sql
+  - path: /opt/plugins/missing.so
+  - hook: test_unknown
+  - hook: test_panics
+  - path: /opt/plugins/license.so
+    hook: test_vendored_sql
+  - {}
+`)
+
+	pm := NewPluginManager()
+	loaded, err := pm.LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest() error = %v", err)
+	}
+	if loaded != 2 || pm.Count() != 2 {
+		t.Fatalf("LoadManifest() loaded %d (%d registered), want the two valid entries", loaded, pm.Count())
+	}
+
+	plugins := pm.Plugins()
+	if plugins[0].Name != "license_header" || plugins[0].Source != "/opt/plugins/license.so" {
+		t.Errorf("Plugins()[0] = %+v, want license_header from its .so path", plugins[0])
+	}
+	if plugins[1].Name != "vendored_sql" || plugins[1].Source != "hook:test_vendored_sql" {
+		t.Errorf("Plugins()[1] = %+v, want vendored_sql from its hook", plugins[1])
+	}
+}
+
+func TestPluginManager_LoadManifest_Errors(t *testing.T) {
+	pm := NewPluginManager()
+
+	if _, err := pm.LoadManifest(filepath.Join(t.TempDir(), "absent.yaml")); err == nil {
+		t.Error("LoadManifest() of a missing manifest should fail")
+	}
+	if _, err := pm.LoadManifest(writeManifest(t, "plugins: [")); err == nil {
+		t.Error("LoadManifest() of a malformed manifest should fail")
+	}
+
+	// A file that is not a shared object is skipped, not fatal.
+	notPlugin := writeManifest(t, "not a plugin")
+	loaded, err := pm.LoadManifest(writeManifest(t, `{"plugins": [{"path": "`+notPlugin+`"}]}`))
+	if err != nil || loaded != 0 || pm.Count() != 0 {
+		t.Errorf("LoadManifest() = %d, %v with %d registered, want the bad plugin skipped", loaded, err, pm.Count())
+	}
+}
+
+func TestPluginManager_RegisterClearsSource(t *testing.T) {
+	pm := NewPluginManager()
+	p := &mockPlugin{info: StrategyInfo{Name: "reloaded"}}
+	if err := pm.RegisterFrom(p, "/opt/plugins/reloaded.so"); err != nil {
+		t.Fatal(err)
+	}
+	if err := pm.Register(p); err != nil {
+		t.Fatal(err)
+	}
+	if got := pm.Plugins()[0].Source; got != "" {
+		t.Errorf("Source = %q, want it cleared by a programmatic re-registration", got)
+	}
+}
@@ -81,6 +81,20 @@ func (r *StrategyRegistry) AboveConfidence(threshold float64) []StrategyInfo {
 	return result
 }
 
+// Unknown returns the names that are not registered, in the order given.
+func (r *StrategyRegistry) Unknown(names []string) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var unknown []string
+	for _, name := range names {
+		if _, ok := r.strategies[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
 func (r *StrategyRegistry) Count() int {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -99,13 +113,26 @@ func DefaultGitRegistry() *StrategyRegistry {
 		{Name: "ratio_analysis", Category: CategoryStatistical, Confidence: 0.6, Description: "Detects skewed addition/deletion ratios indicating generated code", SourceTypes: []string{"git"}},
 		{Name: "precision_analysis", Category: CategoryStatistical, Confidence: 0.7, Description: "Detects suspiciously precise and balanced code changes", SourceTypes: []string{"git"}},
 		{Name: "commit_message_analysis", Category: CategoryBehavioral, Confidence: 0.8, Description: "Detects AI-typical commit message patterns and phrasing", SourceTypes: []string{"git"}},
+		{Name: "message_diff_mismatch_analysis", Category: CategoryBehavioral, Confidence: 0.5, Description: "Detects commit messages that describe a trivial change or name files the diff does not match", SourceTypes: []string{"git"}},
 		{Name: "naming_pattern_analysis", Category: CategoryPattern, Confidence: 0.7, Description: "Detects generic or AI-typical variable and function naming", SourceTypes: []string{"git"}},
 		{Name: "structural_consistency_analysis", Category: CategoryStatistical, Confidence: 0.6, Description: "Detects suspiciously balanced addition/deletion ratios", SourceTypes: []string{"git"}},
 		{Name: "burst_pattern_analysis", Category: CategoryBehavioral, Confidence: 0.7, Description: "Detects rapid-fire commit patterns suggesting batch processing", SourceTypes: []string{"git"}},
 		{Name: "error_handling_analysis", Category: CategoryPattern, Confidence: 0.6, Description: "Detects missing or excessive error handling typical of AI code", SourceTypes: []string{"git"}},
 		{Name: "template_pattern_analysis", Category: CategoryPattern, Confidence: 0.7, Description: "Detects template/boilerplate code patterns from AI generation", SourceTypes: []string{"git"}},
 		{Name: "file_extension_analysis", Category: CategoryStructural, Confidence: 0.5, Description: "Detects suspicious bulk file creation patterns", SourceTypes: []string{"git"}},
+		{Name: "boilerplate_header_analysis", Category: CategoryStructural, Confidence: 0.5, Description: "Detects commits whose new files mostly open with the same boilerplate header", SourceTypes: []string{"git"}},
+		{Name: "commit_topology_analysis", Category: CategoryStructural, Confidence: 0.45, Description: "Detects perfectly linear commit histories with uniformly spaced commits", SourceTypes: []string{"git"}},
+		{Name: "code_churn_analysis", Category: CategoryBehavioral, Confidence: 0.5, Description: "Detects large additions to a file that are largely deleted again within a few commits", SourceTypes: []string{"git"}},
+		{Name: "dependency_addition_analysis", Category: CategoryStructural, Confidence: 0.6, Description: "Detects mass dependency additions to manifests, a project-scaffolding signal", SourceTypes: []string{"git"}},
 		{Name: "StatisticalAnomaly", Category: CategoryStatistical, Confidence: 0.8, Description: "Detects statistical deviations from repository baseline", SourceTypes: []string{"git"}},
+		{Name: "ngram_repetition_analysis", Category: CategoryPattern, Confidence: 0.45, Description: "Detects added code dominated by repeated 3- and 4-token phrases", SourceTypes: []string{"git"}},
+		{Name: "round_statistics_analysis", Category: CategoryStatistical, Confidence: 0.4, Description: "Detects commits whose additions, deletions or file counts are round numbers far more often than chance", SourceTypes: []string{"git"}},
+		{Name: "code_entropy_analysis", Category: CategoryStatistical, Confidence: 0.55, Description: "Detects added code with unusually low lexical entropy relative to the repository", SourceTypes: []string{"git"}},
+		{Name: "rewrite_similarity_analysis", Category: CategoryStructural, Confidence: 0.55, Description: "Detects large rewrites whose added code shares little vocabulary with the code it replaces", SourceTypes: []string{"git"}},
+		{Name: "ai_coauthor_analysis", Category: CategoryBehavioral, Confidence: 0.95, Description: "Detects Co-authored-by and similar trailers that credit an AI assistant", SourceTypes: []string{"git"}},
+		{Name: "signature_analysis", Category: CategoryBehavioral, Confidence: 0.5, Description: "Detects signed-to-unsigned commit transitions and signing keys shared across many authors", SourceTypes: []string{"git"}},
+		{Name: "timezone_anomaly_analysis", Category: CategoryBehavioral, Confidence: 0.45, Description: "Detects bursts of commits at hours outside the author's usual active window or timezone", SourceTypes: []string{"git"}},
+		{Name: "message_style_consistency_analysis", Category: CategoryBehavioral, Confidence: 0.45, Description: "Detects commit messages whose style departs sharply from the author's usual messages", SourceTypes: []string{"git"}},
 		{Name: "TimingAnomaly", Category: CategoryBehavioral, Confidence: 0.7, Description: "Detects unusual timing patterns between commits", SourceTypes: []string{"git"}},
 		{Name: "emoji_pattern_analysis", Category: CategoryPattern, Confidence: 0.4, Description: "Detects excessive emoji usage in commit messages", SourceTypes: []string{"git"}},
 		{Name: "special_character_pattern_analysis", Category: CategoryPattern, Confidence: 0.4, Description: "Detects unusual special character patterns in commits", SourceTypes: []string{"git"}},
@@ -130,8 +157,14 @@ func DefaultWebRegistry() *StrategyRegistry {
 		{Name: "missing_nuance", Category: CategoryLinguistic, Confidence: 0.6, Description: "Detects excessive absolute terms lacking nuance", SourceTypes: []string{"web"}},
 		{Name: "excessive_transitions", Category: CategoryLinguistic, Confidence: 0.7, Description: "Detects overuse of transition words and connectors", SourceTypes: []string{"web"}},
 		{Name: "uniform_sentence_length", Category: CategoryStatistical, Confidence: 0.6, Description: "Detects unnaturally uniform sentence lengths", SourceTypes: []string{"web"}},
+		{Name: "flesch_readability", Category: CategoryStatistical, Confidence: 0.4, Description: "Detects uniform Flesch readability or scores in the band typical of generated prose", SourceTypes: []string{"web"}},
+		{Name: "lexical_diversity", Category: CategoryStatistical, Confidence: 0.4, Description: "Detects unusually low or unnaturally constant lexical diversity (type-token ratio)", SourceTypes: []string{"web"}},
+		{Name: "ngram_repetition", Category: CategoryPattern, Confidence: 0.5, Description: "Detects content dominated by repeated 3- and 4-word phrases", SourceTypes: []string{"web"}},
 		{Name: "ai_vocabulary", Category: CategoryLinguistic, Confidence: 0.8, Description: "Detects AI-characteristic vocabulary and word choices", SourceTypes: []string{"web"}},
+		{Name: "marketing_tone", Category: CategoryLinguistic, Confidence: 0.5, Description: "Detects dense promotional superlatives and calls-to-action", SourceTypes: []string{"web"}},
 		{Name: "emoji_overuse", Category: CategoryPattern, Confidence: 0.4, Description: "Detects excessive emoji usage in content", SourceTypes: []string{"web"}},
+		{Name: "decorated_list_items", Category: CategoryStructural, Confidence: 0.6, Description: "Detects chat-assistant style emoji bullets with bold-lead labels", SourceTypes: []string{"web"}},
+		{Name: "markdown_artifacts", Category: CategoryStructural, Confidence: 0.5, Description: "Detects leftover chat-assistant markdown such as bold sentences, summary lead-ins and bold list leads", SourceTypes: []string{"web"}},
 		{Name: "special_characters", Category: CategoryPattern, Confidence: 0.4, Description: "Detects excessive special character patterns", SourceTypes: []string{"web"}},
 		{Name: "missing_alt_text", Category: CategoryAccessibility, Confidence: 0.3, Description: "Detects images missing alt text attributes", SourceTypes: []string{"web"}},
 		{Name: "semantic_html_issues", Category: CategoryAccessibility, Confidence: 0.3, Description: "Detects overuse of div tags instead of semantic HTML", SourceTypes: []string{"web"}},
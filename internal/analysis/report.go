@@ -1,6 +1,9 @@
 package analysis
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type SourceType string
 
@@ -9,15 +12,79 @@ const (
 	SourceTypeWeb SourceType = "web"
 )
 
+// MetricWeightedScore is the metrics key a detector sets to supply its own
+// confidence-weighted overall score (0-100). When present the runner uses it
+// as AnalysisReport.OverallScore instead of the severity-based sum.
+const MetricWeightedScore = "weighted_score"
+
+// MetricInsufficientContent is the metrics key a detector sets to an
+// *InsufficientContentError when the source had too little content to
+// analyze. Such a report has no detections; see
+// AnalysisReport.InsufficientContent.
+const MetricInsufficientContent = "insufficient_content"
+
+// MetricHiddenDetections is the metrics key detectors add detections to,
+// with HideDetections, when they fall below the report's confidence floor.
+// The runner counts them in the report's totals, severity counts and score,
+// then leaves them out of AnalysisReport.Detections and records how many
+// there were under MetricHiddenDetectionCount.
+const MetricHiddenDetections = "hidden_detections"
+
+// MetricHiddenDetectionCount is the metrics key holding how many detections
+// were left out of the report for falling below its confidence floor.
+const MetricHiddenDetectionCount = "hidden_detection_count"
+
+// MetricBaselineCaveat is the metrics key a git detector sets to the
+// SampleSizeGate caveat when its repository baseline has too few commits
+// for its statistical anomalies to be trusted.
+const MetricBaselineCaveat = "baseline_caveat"
+
+// HideDetections adds detections to metadata's MetricHiddenDetections.
+func HideDetections(metadata map[string]interface{}, detections ...Detection) {
+	hidden, _ := metadata[MetricHiddenDetections].([]Detection)
+	metadata[MetricHiddenDetections] = append(hidden, detections...)
+}
+
+// InsufficientContentError reports content shorter than the minimum word
+// count analysis needs.
+type InsufficientContentError struct {
+	WordCount    int `json:"word_count"`
+	MinWordCount int `json:"min_word_count"`
+}
+
+func (e *InsufficientContentError) Error() string {
+	return fmt.Sprintf("content too short for reliable analysis (minimum %d words, got %d)", e.MinWordCount, e.WordCount)
+}
+
+// MaxDetectionFiles caps Detection.Files so a commit touching thousands of
+// files does not bloat every report format.
+const MaxDetectionFiles = 10
+
 type Detection struct {
 	Strategy    string
 	Detected    bool
 	Severity    string
 	Score       float64
-	Confidence  float64 // Strategy's base confidence weight (0.0-1.0)
+	Confidence  float64 // Confidence weight (0.0-1.0); git detections combine the fired strategies
 	Category    string
 	Description string
 	Examples    []string
+	// Strategies lists the strategies that fired for a git commit detection,
+	// in the same order as their reasons in Examples[1:]. A "Top files"
+	// example naming the files that contributed most may follow the reasons.
+	Strategies []string
+	// Files are the paths a git commit detection changed, largest change
+	// first and capped at MaxDetectionFiles, for reporters that locate
+	// results in the tree.
+	Files []string
+	// LowConfidence marks a git commit detection that a statistical anomaly
+	// against a baseline smaller than the SampleSizeGate trusts contributed
+	// to; Caveat says why.
+	LowConfidence bool
+	Caveat        string
+	// Origin is the source a detection came from in a MultiSource report,
+	// and empty otherwise.
+	Origin SourceType
 }
 
 // TimingInfo holds structured timing data for an analysis run.
@@ -61,6 +128,7 @@ type AnalysisReport struct {
 	SourceMetrics       SourceMetrics
 	Detections          []Detection
 	OverallScore        float64
+	Scores              ScoreBreakdown // how OverallScore was reached
 	Assessment          string
 	SuspicionRate       float64
 	TotalDetections     int
@@ -71,6 +139,17 @@ type AnalysisReport struct {
 	LowSeverityCount    int
 	Metrics             map[string]interface{}
 	Error               string
+	// MultiSource marks a report merged from several sources by
+	// MergeReports; Sources summarizes each of them.
+	MultiSource bool
+	Sources     []SourceSummary
+}
+
+// InsufficientContent returns why the source was too short to analyze, or
+// nil when it was analyzed.
+func (r *AnalysisReport) InsufficientContent() *InsufficientContentError {
+	ic, _ := r.Metrics[MetricInsufficientContent].(*InsufficientContentError)
+	return ic
 }
 
 func (r *AnalysisReport) GetDetectionsBySeverity(severity string) []Detection {
@@ -83,6 +162,26 @@ func (r *AnalysisReport) GetDetectionsBySeverity(severity string) []Detection {
 	return filtered
 }
 
+// summarizeReport computes the report's stats and source metrics over its
+// detections and any hidden below the confidence floor, then drops the hidden
+// ones from the report.
+func summarizeReport(report *AnalysisReport) {
+	hidden, _ := report.Metrics[MetricHiddenDetections].([]Detection)
+	delete(report.Metrics, MetricHiddenDetections)
+
+	visible := report.Detections
+	if len(hidden) > 0 {
+		report.Detections = append(visible[:len(visible):len(visible)], hidden...)
+	}
+	calculateReportStats(report)
+	calculateSourceMetrics(report)
+
+	if len(hidden) > 0 {
+		report.Detections = visible
+		report.Metrics[MetricHiddenDetectionCount] = len(hidden)
+	}
+}
+
 // calculateSourceMetrics populates cross-source summary metrics from detections and metadata.
 func calculateSourceMetrics(report *AnalysisReport) {
 	sm := &report.SourceMetrics
@@ -108,9 +207,19 @@ func calculateSourceMetrics(report *AnalysisReport) {
 	var scoredCount int
 
 	for _, d := range report.Detections {
-		strategySeen[d.Strategy] = true
+		// A git commit's detection names the strategies that fired on it;
+		// other detections are one strategy each.
+		names := d.Strategies
+		if len(names) == 0 {
+			names = []string{d.Strategy}
+		}
+		for _, name := range names {
+			strategySeen[name] = true
+			if d.Detected {
+				strategyHit[name] = true
+			}
+		}
 		if d.Detected {
-			strategyHit[d.Strategy] = true
 			totalScore += d.Score
 			scoredCount++
 		}
@@ -0,0 +1,196 @@
+package analysis
+
+import "sort"
+
+// DetectionKey identifies a finding across reports: the strategy that fired
+// and, for git findings, the commit it fired on.
+type DetectionKey struct {
+	Strategy string `json:"strategy"`
+	Commit   string `json:"commit,omitempty"`
+}
+
+// DetectionKeys returns the keys of a fired detection. A git commit
+// detection carries the commit hash in Examples[0] and yields one key per
+// strategy in Strategies; other detections yield a single key without a
+// commit. Passed detections have no keys.
+func DetectionKeys(d *Detection) []DetectionKey {
+	if !d.Detected {
+		return nil
+	}
+	if len(d.Strategies) == 0 || len(d.Examples) == 0 {
+		return []DetectionKey{{Strategy: d.Strategy}}
+	}
+	keys := make([]DetectionKey, len(d.Strategies))
+	for i, s := range d.Strategies {
+		keys[i] = DetectionKey{Strategy: s, Commit: d.Examples[0]}
+	}
+	return keys
+}
+
+// Commit change statuses in a ReportDiff.
+const (
+	CommitNewlyFlagged = "new"
+	CommitResolved     = "resolved"
+	CommitChanged      = "changed"
+)
+
+// CommitChange is how the findings on one commit differ between two reports.
+type CommitChange struct {
+	Commit string `json:"commit"`
+	// Status is CommitNewlyFlagged, CommitResolved or CommitChanged.
+	Status      string   `json:"status"`
+	ScoreBefore float64  `json:"score_before"`
+	ScoreAfter  float64  `json:"score_after"`
+	Added       []string `json:"added_strategies,omitempty"`
+	Removed     []string `json:"removed_strategies,omitempty"`
+}
+
+// ReportDiff is the delta from one analysis of a source to a later one.
+type ReportDiff struct {
+	SourceBefore     string  `json:"source_before"`
+	SourceAfter      string  `json:"source_after"`
+	ScoreBefore      float64 `json:"score_before"`
+	ScoreAfter       float64 `json:"score_after"`
+	ScoreDelta       float64 `json:"score_delta"`
+	AssessmentBefore string  `json:"assessment_before"`
+	AssessmentAfter  string  `json:"assessment_after"`
+	// NewlyFiring and StoppedFiring list the strategies that fired anywhere
+	// in only the later or only the earlier report.
+	NewlyFiring   []string `json:"newly_firing"`
+	StoppedFiring []string `json:"stopped_firing"`
+	// Added and Removed are the findings present in only the later or only
+	// the earlier report.
+	Added   []DetectionKey `json:"added"`
+	Removed []DetectionKey `json:"removed"`
+	// Commits lists the commits whose findings or score changed, by commit
+	// hash.
+	Commits []CommitChange `json:"commits"`
+}
+
+// Unchanged reports whether the two reports have the same findings and scores.
+func (d *ReportDiff) Unchanged() bool {
+	return d.ScoreDelta == 0 && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Commits) == 0
+}
+
+// DiffReports compares an earlier report with a later one. Findings are
+// matched by DetectionKey, so reports of the same source compare finding by
+// finding regardless of detection order.
+func DiffReports(before, after *AnalysisReport) *ReportDiff {
+	diff := &ReportDiff{
+		SourceBefore:     before.SourceID,
+		SourceAfter:      after.SourceID,
+		ScoreBefore:      before.OverallScore,
+		ScoreAfter:       after.OverallScore,
+		ScoreDelta:       after.OverallScore - before.OverallScore,
+		AssessmentBefore: before.Assessment,
+		AssessmentAfter:  after.Assessment,
+		NewlyFiring:      []string{},
+		StoppedFiring:    []string{},
+		Added:            []DetectionKey{},
+		Removed:          []DetectionKey{},
+		Commits:          []CommitChange{},
+	}
+
+	oldKeys, oldScores := reportKeys(before)
+	newKeys, newScores := reportKeys(after)
+
+	diff.Removed = keysMissingFrom(oldKeys, newKeys)
+	diff.Added = keysMissingFrom(newKeys, oldKeys)
+	diff.StoppedFiring = strategiesMissingFrom(oldKeys, newKeys)
+	diff.NewlyFiring = strategiesMissingFrom(newKeys, oldKeys)
+
+	changes := make(map[string]*CommitChange)
+	change := func(commit string) *CommitChange {
+		c, ok := changes[commit]
+		if !ok {
+			c = &CommitChange{Commit: commit, ScoreBefore: oldScores[commit], ScoreAfter: newScores[commit]}
+			changes[commit] = c
+		}
+		return c
+	}
+	for _, k := range diff.Added {
+		if k.Commit != "" {
+			c := change(k.Commit)
+			c.Added = append(c.Added, k.Strategy)
+		}
+	}
+	for _, k := range diff.Removed {
+		if k.Commit != "" {
+			c := change(k.Commit)
+			c.Removed = append(c.Removed, k.Strategy)
+		}
+	}
+	for commit, score := range newScores {
+		if old, ok := oldScores[commit]; ok && old != score {
+			change(commit)
+		}
+	}
+	for commit, c := range changes {
+		_, wasFlagged := oldScores[commit]
+		_, isFlagged := newScores[commit]
+		switch {
+		case !wasFlagged:
+			c.Status = CommitNewlyFlagged
+		case !isFlagged:
+			c.Status = CommitResolved
+		default:
+			c.Status = CommitChanged
+		}
+		diff.Commits = append(diff.Commits, *c)
+	}
+	sort.Slice(diff.Commits, func(i, j int) bool { return diff.Commits[i].Commit < diff.Commits[j].Commit })
+	return diff
+}
+
+// reportKeys returns the set of a report's finding keys and the score of
+// each flagged commit.
+func reportKeys(r *AnalysisReport) (map[DetectionKey]bool, map[string]float64) {
+	keys := make(map[DetectionKey]bool)
+	scores := make(map[string]float64)
+	for i := range r.Detections {
+		for _, k := range DetectionKeys(&r.Detections[i]) {
+			keys[k] = true
+			if k.Commit != "" {
+				scores[k.Commit] = max(scores[k.Commit], r.Detections[i].Score)
+			}
+		}
+	}
+	return keys, scores
+}
+
+// keysMissingFrom returns the keys of a that are not in b, sorted by commit
+// then strategy.
+func keysMissingFrom(a, b map[DetectionKey]bool) []DetectionKey {
+	missing := []DetectionKey{}
+	for k := range a {
+		if !b[k] {
+			missing = append(missing, k)
+		}
+	}
+	sort.Slice(missing, func(i, j int) bool {
+		if missing[i].Commit != missing[j].Commit {
+			return missing[i].Commit < missing[j].Commit
+		}
+		return missing[i].Strategy < missing[j].Strategy
+	})
+	return missing
+}
+
+// strategiesMissingFrom returns the strategies with a key in a and none in
+// b, sorted.
+func strategiesMissingFrom(a, b map[DetectionKey]bool) []string {
+	inB := make(map[string]bool)
+	for k := range b {
+		inB[k.Strategy] = true
+	}
+	seen := make(map[string]bool)
+	missing := []string{}
+	for k := range a {
+		if !inB[k.Strategy] && !seen[k.Strategy] {
+			seen[k.Strategy] = true
+			missing = append(missing, k.Strategy)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,75 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func commitDetection(hash string, score float64, strategies ...string) Detection {
+	return Detection{Strategy: "git-velocity-analysis", Detected: true, Score: score, Examples: []string{hash, "reason"}, Strategies: strategies}
+}
+
+func TestDetectionKeys(t *testing.T) {
+	d := commitDetection("abc", 0.5, "size_analysis", "velocity_analysis")
+	want := []DetectionKey{{Strategy: "size_analysis", Commit: "abc"}, {Strategy: "velocity_analysis", Commit: "abc"}}
+	if got := DetectionKeys(&d); !reflect.DeepEqual(got, want) {
+		t.Errorf("DetectionKeys(commit) = %v, want %v", got, want)
+	}
+
+	web := Detection{Strategy: "ai_vocabulary", Detected: true, Examples: []string{"delve"}}
+	if got := DetectionKeys(&web); !reflect.DeepEqual(got, []DetectionKey{{Strategy: "ai_vocabulary"}}) {
+		t.Errorf("DetectionKeys(web) = %v, want the strategy alone", got)
+	}
+
+	passed := Detection{Strategy: "size_analysis", Strategies: []string{"size_analysis"}}
+	if got := DetectionKeys(&passed); got != nil {
+		t.Errorf("DetectionKeys(passed) = %v, want none", got)
+	}
+}
+
+func TestDiffReports(t *testing.T) {
+	before := &AnalysisReport{
+		SourceID:     "repo",
+		OverallScore: 30,
+		Assessment:   "Low Suspicion",
+		Detections: []Detection{
+			commitDetection("aaa", 0.4, "size_analysis"),
+			commitDetection("bbb", 0.6, "size_analysis", "timing_anomaly"),
+			commitDetection("ccc", 0.3, "velocity_analysis"),
+		},
+	}
+	after := &AnalysisReport{
+		SourceID:     "repo",
+		OverallScore: 45,
+		Assessment:   "Moderate Suspicion",
+		Detections: []Detection{
+			commitDetection("bbb", 0.6, "size_analysis", "emoji_pattern_analysis"),
+			commitDetection("ddd", 0.5, "size_analysis"),
+			commitDetection("ccc", 0.5, "velocity_analysis"),
+			{Strategy: "size_analysis", Detected: false, Strategies: []string{"size_analysis"}},
+		},
+	}
+
+	d := DiffReports(before, after)
+
+	if d.ScoreDelta != 15 || d.AssessmentAfter != "Moderate Suspicion" {
+		t.Errorf("score delta = %v, assessment = %q", d.ScoreDelta, d.AssessmentAfter)
+	}
+	if !reflect.DeepEqual(d.NewlyFiring, []string{"emoji_pattern_analysis"}) || !reflect.DeepEqual(d.StoppedFiring, []string{"timing_anomaly"}) {
+		t.Errorf("newly firing = %v, stopped = %v", d.NewlyFiring, d.StoppedFiring)
+	}
+
+	want := []CommitChange{
+		{Commit: "aaa", Status: CommitResolved, ScoreBefore: 0.4, Removed: []string{"size_analysis"}},
+		{Commit: "bbb", Status: CommitChanged, ScoreBefore: 0.6, ScoreAfter: 0.6, Added: []string{"emoji_pattern_analysis"}, Removed: []string{"timing_anomaly"}},
+		{Commit: "ccc", Status: CommitChanged, ScoreBefore: 0.3, ScoreAfter: 0.5},
+		{Commit: "ddd", Status: CommitNewlyFlagged, ScoreAfter: 0.5, Added: []string{"size_analysis"}},
+	}
+	if !reflect.DeepEqual(d.Commits, want) {
+		t.Errorf("Commits =\n%+v\nwant\n%+v", d.Commits, want)
+	}
+
+	if same := DiffReports(before, before); !same.Unchanged() {
+		t.Errorf("a report diffed with itself should be unchanged: %+v", same)
+	}
+}
@@ -28,8 +28,9 @@ func NewDefaultDetectionRunnerWithLogger(logger *logging.Logger) *DefaultDetecti
 
 func (r *DefaultDetectionRunner) Run(ctx context.Context, source AnalysisSource, detectors ...Detector) (*AnalysisReport, error) {
 	startTime := time.Now()
+	log := r.logger.WithContext(ctx)
 
-	r.logger.LogAnalysis(source.Type(), "", "phase", "validating")
+	log.LogAnalysis(source.Type(), "", "phase", "validating")
 
 	phaseStart := time.Now()
 	if err := source.Validate(ctx); err != nil {
@@ -37,7 +38,7 @@ func (r *DefaultDetectionRunner) Run(ctx context.Context, source AnalysisSource,
 	}
 	validatePhase := PhaseTiming{Name: "validate", StartedAt: phaseStart, Duration: time.Since(phaseStart)}
 
-	r.logger.LogAnalysis(source.Type(), "", "phase", "fetching")
+	log.LogAnalysis(source.Type(), "", "phase", "fetching")
 
 	phaseStart = time.Now()
 	sourceData, err := source.Fetch(ctx)
@@ -55,10 +56,14 @@ func (r *DefaultDetectionRunner) Run(ctx context.Context, source AnalysisSource,
 		Metrics:    sourceData.Metadata,
 	}
 
-	r.logger.LogAnalysis(source.Type(), sourceData.ID, "phase", "detecting", "detector_count", len(detectors))
+	log.LogAnalysis(source.Type(), sourceData.ID, "phase", "detecting", "detector_count", len(detectors))
 
 	phaseStart = time.Now()
 	for _, detector := range detectors {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("detection canceled: %w", err)
+		}
+
 		detections, err := detector.Detect(ctx, sourceData)
 		if err != nil {
 			return nil, fmt.Errorf("detection failed: %w", err)
@@ -77,10 +82,9 @@ func (r *DefaultDetectionRunner) Run(ctx context.Context, source AnalysisSource,
 		Phases:      []PhaseTiming{validatePhase, fetchPhase, detectPhase},
 	}
 
-	calculateReportStats(report)
-	calculateSourceMetrics(report)
+	summarizeReport(report)
 
-	r.logger.LogAnalysis(source.Type(), sourceData.ID,
+	log.LogAnalysis(source.Type(), sourceData.ID,
 		"phase", "complete",
 		"duration_ms", report.Duration.Milliseconds(),
 		"total_detections", report.TotalDetections,
@@ -134,15 +138,17 @@ func calculateReportStats(report *AnalysisReport) {
 		report.SuspicionRate = float64(detectedCount) / float64(report.TotalDetections)
 	}
 
+	if weighted, ok := report.Metrics[MetricWeightedScore].(float64); ok {
+		report.OverallScore = weighted
+	}
+
 	if report.OverallScore > 100 {
 		report.OverallScore = 100
 	}
 
-	if report.OverallScore >= 70 {
-		report.Assessment = "Suspicious Activity Detected"
-	} else if report.OverallScore >= 40 {
-		report.Assessment = "Moderate Suspicion"
-	} else {
-		report.Assessment = "Low Suspicion"
+	report.Scores = ScoreBreakdown{Heuristic: report.OverallScore, Blended: report.OverallScore}
+	report.Assessment = assessmentFor(report.OverallScore)
+	if report.InsufficientContent() != nil {
+		report.Assessment = AssessmentInsufficientContent
 	}
 }
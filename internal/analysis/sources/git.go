@@ -7,17 +7,71 @@ import (
 
 	"github.com/TryCadence/Cadence/internal/analysis"
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/logging"
 )
 
 type GitRepositorySource struct {
 	Path   string
 	Branch string
+	// ExcludeFiles lists file patterns whose changes are left out of the
+	// commit stats and diff content entirely.
+	ExcludeFiles []string
+	// GeneratedFiles adds patterns to git.DefaultGeneratedFiles. Changes to
+	// generated files are kept out of the stats detectors see and totalled
+	// in the "generated_*" metadata instead. CountGeneratedFiles turns the
+	// filter off.
+	GeneratedFiles      []string
+	CountGeneratedFiles bool
+	// IgnoreAuthors drops commits by matching authors (name or email globs)
+	// before analysis, e.g. dependency and release bots.
+	IgnoreAuthors []string
+	// SinceHash limits analysis to commits newer than this one, e.g. the
+	// previous head of a pushed branch.
+	SinceHash string
+	// FromRef and ToRef restrict analysis to the commits reachable from
+	// ToRef but not from FromRef, e.g. the commits of a pull request. Both
+	// take any git revision (hash, branch, tag) and must exist; ToRef
+	// defaults to the branch head, and FromRef must be an ancestor of it.
+	FromRef string
+	ToRef   string
+	// BaselineCommits is how many older commits are loaded alongside an
+	// incremental (SinceHash or FromRef) run purely as baseline context.
+	// Zero uses DefaultBaselineCommits.
+	BaselineCommits int
+	// MaxCommits caps how many of the newest commits are analyzed, so very
+	// large histories are not walked in full. Zero or negative means no
+	// limit; NewGitRepositorySource sets DefaultMaxCommits.
+	MaxCommits int
+	// MaxDiffBytes caps the diff content held in memory across all commit
+	// pairs. Pairs past the cap keep their stats but carry no diff content.
+	// Zero means no limit.
+	MaxDiffBytes int64
+	// DiffWorkers is how many commit pairs have their diffs computed in
+	// parallel. Zero uses GOMAXPROCS; 1 disables parallelism.
+	DiffWorkers int
+	// MergeStrategy controls whether merge commits are analyzed against
+	// their first parent. Empty means git.MergeSkip.
+	MergeStrategy git.MergeStrategy
+	// Identity controls how author emails are grouped into the identities
+	// counted as "unique_authors". NewGitRepositorySource sets
+	// git.DefaultIdentityRules; the zero value only merges exact emails.
+	Identity git.IdentityRules
 }
 
+const (
+	// DefaultBaselineCommits is the history window used for baseline
+	// statistics when only commits since SinceHash are analyzed.
+	DefaultBaselineCommits = 100
+	// DefaultMaxCommits is the history depth NewGitRepositorySource applies.
+	DefaultMaxCommits = 1000
+)
+
 func NewGitRepositorySource(path, branch string) *GitRepositorySource {
 	return &GitRepositorySource{
-		Path:   path,
-		Branch: branch,
+		Path:       path,
+		Branch:     branch,
+		MaxCommits: DefaultMaxCommits,
+		Identity:   git.DefaultIdentityRules,
 	}
 }
 
@@ -43,40 +97,245 @@ func (g *GitRepositorySource) Validate(ctx context.Context) error {
 }
 
 func (g *GitRepositorySource) Fetch(ctx context.Context) (*analysis.SourceData, error) {
-	repo, err := git.OpenRepository(g.Path, nil)
+	repo, err := git.OpenRepository(g.Path, &git.RepositoryOptions{
+		ExcludeFiles:        g.ExcludeFiles,
+		GeneratedFiles:      g.GeneratedFiles,
+		CountGeneratedFiles: g.CountGeneratedFiles,
+		IgnoreAuthors:       g.IgnoreAuthors,
+		MaxDiffBytes:        g.MaxDiffBytes,
+		DiffWorkers:         g.DiffWorkers,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open repository: %w", err)
 	}
 	defer repo.Close()
 
-	opts := &git.CommitOptions{}
+	opts := &git.CommitOptions{SinceHash: g.SinceHash, FromHash: g.FromRef, ToHash: g.ToRef, MergeStrategy: g.MergeStrategy}
 	if g.Branch != "" {
 		opts.Branch = g.Branch
 	}
+	if g.MaxCommits > 0 {
+		// One extra commit tells a capped history from one that just fits,
+		// and gives the oldest analyzed commit a pair.
+		opts.MaxDepth = g.MaxCommits + 1
+	}
 
 	commits, err := repo.GetCommits(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get commits: %w", err)
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	truncated := g.MaxCommits > 0 && len(commits) > g.MaxCommits
+	if truncated {
+		logging.Default().With("component", "git_source").Warn("commit history capped",
+			"path", g.Path,
+			"max_commits", g.MaxCommits,
+		)
+	}
 
 	provider, ok := repo.(git.CommitPairProvider)
 	if !ok {
 		return nil, fmt.Errorf("repository does not support CommitPairProvider interface")
 	}
 
-	pairs, err := provider.GetCommitPairs(commits)
+	if g.SinceHash != "" || g.FromRef != "" {
+		if truncated {
+			commits = commits[:g.MaxCommits]
+		}
+		return g.fetchIncremental(ctx, repo, provider, commits, truncated)
+	}
+
+	pairs, err := provider.GetCommitPairsContext(ctx, commits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit pairs: %w", err)
+	}
+
+	analyzed := commits
+	if truncated {
+		analyzed = commits[:g.MaxCommits]
+	}
+	metadata := map[string]interface{}{
+		"branch":       g.Branch,
+		"commit_count": len(analyzed),
+		"commit_pairs": pairs,
+		"commits":      analyzed,
+	}
+	g.recordRange(metadata)
+	g.recordLimits(metadata, truncated, pairs)
+	recordGenerated(metadata, pairs)
+	g.recordMerges(metadata, analyzed, pairs)
+	g.recordAuthors(metadata, analyzed)
+
+	return &analysis.SourceData{
+		ID:         g.Path,
+		Type:       "git",
+		RawContent: pairs,
+		Metadata:   metadata,
+	}, nil
+}
+
+// recordRange notes the requested commit range in metadata.
+func (g *GitRepositorySource) recordRange(metadata map[string]interface{}) {
+	if g.FromRef != "" {
+		metadata["from"] = g.FromRef
+	}
+	if g.ToRef != "" {
+		metadata["to"] = g.ToRef
+	}
+}
+
+// recordLimits notes in metadata when MaxCommits cut the history short and
+// how many pairs were left without diff content by MaxDiffBytes.
+func (g *GitRepositorySource) recordLimits(metadata map[string]interface{}, truncated bool, pairs []*git.CommitPair) {
+	if truncated {
+		metadata["max_commits"] = g.MaxCommits
+		metadata["history_truncated"] = true
+	}
+
+	skipped := 0
+	for _, p := range pairs {
+		if p.DiffSkipped {
+			skipped++
+		}
+	}
+	if skipped > 0 {
+		metadata["max_diff_bytes"] = g.MaxDiffBytes
+		metadata["diff_content_skipped"] = skipped
+	}
+}
+
+// recordGenerated totals the generated-file changes the analyzed pairs left
+// out of their stats, so reports show how much machine-generated code the
+// history carried. Nothing is recorded when there was none.
+func recordGenerated(metadata map[string]interface{}, pairs []*git.CommitPair) {
+	var files int
+	var additions, deletions int64
+	for _, p := range pairs {
+		if p.Stats == nil {
+			continue
+		}
+		files += p.Stats.GeneratedFiles
+		additions += p.Stats.GeneratedAdditions
+		deletions += p.Stats.GeneratedDeletions
+	}
+	if files > 0 {
+		metadata["generated_files"] = files
+		metadata["generated_additions"] = additions
+		metadata["generated_deletions"] = deletions
+	}
+}
+
+// recordMerges notes how many of the analyzed commits were merges and how
+// many of those were paired and analyzed rather than skipped, with the
+// merge strategy in effect. Nothing is recorded when there were no merges.
+func (g *GitRepositorySource) recordMerges(metadata map[string]interface{}, commits []*git.Commit, pairs []*git.CommitPair) {
+	merges := 0
+	for _, c := range commits {
+		if c.IsMerge() {
+			merges++
+		}
+	}
+	if merges == 0 {
+		return
+	}
+
+	analyzed := 0
+	for _, p := range pairs {
+		if p.Current.IsMerge() {
+			analyzed++
+		}
+	}
+	strategy := g.MergeStrategy
+	if strategy == "" {
+		strategy = git.MergeSkip
+	}
+	metadata["merge_strategy"] = string(strategy)
+	metadata["merges_analyzed"] = analyzed
+	metadata["merges_skipped"] = merges - analyzed
+}
+
+// recordAuthors counts the distinct author identities behind commits, and
+// how many author emails were folded into another identity by the identity
+// rules when any were.
+func (g *GitRepositorySource) recordAuthors(metadata map[string]interface{}, commits []*git.Commit) {
+	if len(commits) == 0 {
+		return
+	}
+	emails := make(map[string]bool)
+	for _, c := range commits {
+		emails[c.Email] = true
+	}
+	identities := git.ClusterAuthors(commits, g.Identity)
+	metadata["unique_authors"] = identities.Count()
+	if merged := len(emails) - identities.Count(); merged > 0 {
+		metadata["author_emails_merged"] = merged
+	}
+}
+
+// fetchIncremental analyzes only the commits newer than SinceHash or
+// FromRef. Older
+// history is loaded so the oldest new commit still has a pair and so
+// baseline strategies see a representative window; those pairs are passed
+// as "baseline_pairs" and are not themselves analyzed.
+func (g *GitRepositorySource) fetchIncremental(ctx context.Context, repo git.Repository, provider git.CommitPairProvider, newCommits []*git.Commit, truncated bool) (*analysis.SourceData, error) {
+	window := g.BaselineCommits
+	if window <= 0 {
+		window = DefaultBaselineCommits
+	}
+
+	history, err := repo.GetCommits(&git.CommitOptions{
+		Branch:        g.Branch,
+		ToHash:        g.ToRef,
+		MaxDepth:      len(newCommits) + window + 1,
+		MergeStrategy: g.MergeStrategy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get baseline commits: %w", err)
+	}
+
+	allPairs, err := provider.GetCommitPairsContext(ctx, history)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get commit pairs: %w", err)
 	}
 
+	isNew := make(map[string]bool, len(newCommits))
+	for _, c := range newCommits {
+		isNew[c.Hash] = true
+	}
+
+	pairs := make([]*git.CommitPair, 0, len(newCommits))
+	baseline := make([]*git.CommitPair, 0, len(allPairs))
+	for _, p := range allPairs {
+		if isNew[p.Current.Hash] {
+			pairs = append(pairs, p)
+		} else {
+			baseline = append(baseline, p)
+		}
+	}
+
+	metadata := map[string]interface{}{
+		"branch":         g.Branch,
+		"commit_count":   len(newCommits),
+		"commit_pairs":   pairs,
+		"baseline_pairs": baseline,
+		"commits":        newCommits,
+	}
+	if g.SinceHash != "" {
+		metadata["since"] = g.SinceHash
+	}
+	g.recordRange(metadata)
+	g.recordLimits(metadata, truncated, allPairs)
+	recordGenerated(metadata, pairs)
+	g.recordMerges(metadata, newCommits, pairs)
+	g.recordAuthors(metadata, newCommits)
+
 	return &analysis.SourceData{
 		ID:         g.Path,
 		Type:       "git",
 		RawContent: pairs,
-		Metadata: map[string]interface{}{
-			"branch":       g.Branch,
-			"commit_count": len(commits),
-			"commit_pairs": pairs,
-		},
+		Metadata:   metadata,
 	}, nil
 }
@@ -12,6 +12,11 @@ import (
 
 type WebsiteSource struct {
 	URL string
+	// FetcherOptions configure the page fetcher, e.g. web.WithRenderJS.
+	FetcherOptions []web.FetcherOption
+	// Page, when set, is analyzed instead of fetching URL, for callers that
+	// already fetched it.
+	Page *web.PageContent
 }
 
 func NewWebsiteSource(url string) *WebsiteSource {
@@ -36,13 +41,16 @@ func (w *WebsiteSource) Validate(ctx context.Context) error {
 }
 
 func (w *WebsiteSource) Fetch(ctx context.Context) (*analysis.SourceData, error) {
-	fetcher := web.NewFetcher(30 * time.Second)
-	page, err := fetcher.Fetch(w.URL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch website: %w", err)
+	page := w.Page
+	if page == nil {
+		var err error
+		page, err = web.NewFetcher(30*time.Second, w.FetcherOptions...).Fetch(w.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch website: %w", err)
+		}
 	}
 
-	return &analysis.SourceData{
+	data := &analysis.SourceData{
 		ID:         w.URL,
 		Type:       "web",
 		RawContent: page,
@@ -54,5 +62,9 @@ func (w *WebsiteSource) Fetch(ctx context.Context) (*analysis.SourceData, error)
 			"heading_count":   len(page.Headings),
 			"headings":        page.Headings,
 		},
-	}, nil
+	}
+	if page.Language != "" {
+		data.Metadata["language"] = page.Language
+	}
+	return data, nil
 }
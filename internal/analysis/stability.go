@@ -0,0 +1,124 @@
+package analysis
+
+import (
+	"context"
+	"fmt"
+	"maps"
+)
+
+// DefaultStabilityJitter is how far CheckStability moves thresholds either
+// way when no jitter is given: ±10%.
+const DefaultStabilityJitter = 0.1
+
+// Stability metric keys CheckStability records in the report's metrics.
+const (
+	MetricStabilityRuns     = "stability_runs"
+	MetricStabilityScore    = "stability_score"
+	MetricStabilityVerdicts = "stability_verdicts"
+)
+
+// StabilityResult is the spread of verdicts over reruns of one analysis with
+// perturbed thresholds.
+type StabilityResult struct {
+	// Runs is the number of perturbed runs.
+	Runs int
+	// Jitter is the largest relative threshold change tried (0-1).
+	Jitter float64
+	// Verdict is the assessment of the unperturbed analysis.
+	Verdict string
+	// Verdicts counts the perturbed runs reaching each assessment.
+	Verdicts map[string]int
+	// Score is the share of perturbed runs agreeing with Verdict (0-1). A
+	// verdict close to a classification boundary scores low.
+	Score float64
+	// MinScore and MaxScore bound the runs' OverallScore.
+	MinScore, MaxScore float64
+}
+
+// StabilityFactors returns runs threshold factors evenly spaced over
+// [1-jitter, 1+jitter], from strictest to loosest.
+func StabilityFactors(runs int, jitter float64) []float64 {
+	if runs <= 1 {
+		return []float64{1}
+	}
+	factors := make([]float64, runs)
+	for i := range factors {
+		factors[i] = 1 - jitter + 2*jitter*float64(i)/float64(runs-1)
+	}
+	return factors
+}
+
+// CheckStability fetches source once and analyzes it with detector(1), the
+// unperturbed configuration, then once per StabilityFactors(runs, jitter)
+// with detector(factor), whose thresholds the factor should scale. The
+// heuristics are deterministic, so moving the thresholds is what probes how
+// close the commits sit to them. It returns the unperturbed report, with the
+// result also recorded in its metrics.
+func CheckStability(ctx context.Context, source AnalysisSource, runs int, jitter float64, detector func(factor float64) Detector) (*AnalysisReport, *StabilityResult, error) {
+	if err := source.Validate(ctx); err != nil {
+		return nil, nil, fmt.Errorf("source validation failed: %w", err)
+	}
+	data, err := source.Fetch(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch source data: %w", err)
+	}
+	replay := &replaySource{sourceType: source.Type(), data: data, metadata: maps.Clone(data.Metadata)}
+	runner := NewDefaultDetectionRunner()
+
+	report, err := runner.Run(ctx, replay, detector(1))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	factors := StabilityFactors(runs, jitter)
+	result := &StabilityResult{
+		Runs:     len(factors),
+		Jitter:   jitter,
+		Verdict:  report.Assessment,
+		Verdicts: make(map[string]int),
+	}
+	agree := 0
+	for i, factor := range factors {
+		rerun, err := runner.Run(ctx, replay, detector(factor))
+		if err != nil {
+			return nil, nil, fmt.Errorf("stability run %d: %w", i+1, err)
+		}
+		result.Verdicts[rerun.Assessment]++
+		if rerun.Assessment == result.Verdict {
+			agree++
+		}
+		if i == 0 || rerun.OverallScore < result.MinScore {
+			result.MinScore = rerun.OverallScore
+		}
+		if i == 0 || rerun.OverallScore > result.MaxScore {
+			result.MaxScore = rerun.OverallScore
+		}
+	}
+	result.Score = float64(agree) / float64(result.Runs)
+
+	if report.Metrics == nil {
+		report.Metrics = make(map[string]interface{})
+	}
+	report.Metrics[MetricStabilityRuns] = result.Runs
+	report.Metrics[MetricStabilityScore] = result.Score
+	report.Metrics[MetricStabilityVerdicts] = result.Verdicts
+	return report, result, nil
+}
+
+// replaySource serves data fetched once to every run, each with its own copy
+// of the fetched metadata since detectors write their results into it.
+type replaySource struct {
+	sourceType string
+	data       *SourceData
+	metadata   map[string]interface{}
+}
+
+func (s *replaySource) Type() string { return s.sourceType }
+
+func (s *replaySource) Validate(context.Context) error { return nil }
+
+func (s *replaySource) Fetch(context.Context) (*SourceData, error) {
+	data := *s.data
+	data.Metadata = maps.Clone(s.metadata)
+	return &data, nil
+}
@@ -0,0 +1,84 @@
+package analysis
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+)
+
+// scoreDetector flags one item and reports score as the weighted score.
+type scoreDetector struct {
+	score float64
+}
+
+func (d *scoreDetector) Detect(_ context.Context, data *SourceData) ([]Detection, error) {
+	runs, _ := data.Metadata["runs"].(int)
+	data.Metadata["runs"] = runs + 1
+	data.Metadata[MetricWeightedScore] = d.score
+	return []Detection{{Strategy: "s", Detected: true, Severity: "medium"}}, nil
+}
+
+func TestStabilityFactors(t *testing.T) {
+	want := []float64{0.9, 0.95, 1, 1.05, 1.1}
+	got := StabilityFactors(5, 0.1)
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Fatalf("StabilityFactors(5, 0.1) = %v, want %v", got, want)
+		}
+	}
+	if got := StabilityFactors(1, 0.1); !reflect.DeepEqual(got, []float64{1}) {
+		t.Errorf("StabilityFactors(1, 0.1) = %v, want only the unperturbed run", got)
+	}
+}
+
+func TestCheckStability(t *testing.T) {
+	fetches := 0
+	source := &countingSource{mockSource: mockSource{sourceType: "git", data: &SourceData{
+		ID: "repo", Type: "git", Metadata: map[string]interface{}{"commit_count": 10},
+	}}, fetches: &fetches}
+
+	// 40 sits on the "Moderate Suspicion" boundary: the strictest run drops
+	// below it.
+	report, result, err := CheckStability(context.Background(), source, 3, 0.1, func(factor float64) Detector {
+		return &scoreDetector{score: 40 / factor}
+	})
+	if err != nil {
+		t.Fatalf("CheckStability() unexpected error = %v", err)
+	}
+
+	if fetches != 1 {
+		t.Errorf("source fetched %d times, want once for all runs", fetches)
+	}
+	if report.OverallScore != 40 || result.Verdict != "Moderate Suspicion" {
+		t.Errorf("report score = %v, verdict = %q, want the unperturbed run", report.OverallScore, result.Verdict)
+	}
+	if got := report.Metrics["runs"]; got != 1 {
+		t.Errorf("runs = %v, want each run to start from the fetched metadata", got)
+	}
+
+	wantVerdicts := map[string]int{"Moderate Suspicion": 2, "Low Suspicion": 1}
+	if !reflect.DeepEqual(result.Verdicts, wantVerdicts) {
+		t.Errorf("Verdicts = %v, want %v", result.Verdicts, wantVerdicts)
+	}
+	if math.Abs(result.Score-2.0/3.0) > 1e-9 {
+		t.Errorf("Score = %v, want 2/3", result.Score)
+	}
+	if math.Abs(result.MinScore-40/1.1) > 1e-9 || math.Abs(result.MaxScore-40/0.9) > 1e-9 {
+		t.Errorf("score range = [%v, %v], want [%v, %v]", result.MinScore, result.MaxScore, 40/1.1, 40/0.9)
+	}
+
+	if report.Metrics[MetricStabilityRuns] != 3 || report.Metrics[MetricStabilityScore] != result.Score {
+		t.Errorf("stability metrics = %v", report.Metrics)
+	}
+}
+
+type countingSource struct {
+	mockSource
+	fetches *int
+}
+
+func (s *countingSource) Fetch(ctx context.Context) (*SourceData, error) {
+	*s.fetches++
+	return s.mockSource.Fetch(ctx)
+}
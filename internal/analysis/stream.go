@@ -16,16 +16,46 @@ const (
 	EventProgress  StreamEventType = "progress"
 	EventComplete  StreamEventType = "complete"
 	EventError     StreamEventType = "error"
+	// EventItem reports one item (e.g. a commit) analyzed by a detector that
+	// implements ItemProgressDetector.
+	EventItem StreamEventType = "item"
 )
 
 type StreamEvent struct {
 	Type      StreamEventType
 	Detection *Detection
 	Progress  *ProgressInfo
+	Item      *ItemProgress
 	Report    *AnalysisReport
 	Error     error
 }
 
+// ItemProgress describes one item a detector has finished analyzing, with a
+// running tally of how many items so far were flagged.
+type ItemProgress struct {
+	Kind    string // What the items are, e.g. "commit"
+	ID      string // Item identifier, e.g. the commit hash
+	Current int    // 1-based position of this item
+	Total   int
+	// Suspicious reports whether this item was flagged; Score and Severity
+	// are set when it was.
+	Suspicious bool
+	Score      float64
+	Severity   string
+	// Skipped names why the item was not scored (e.g. "merge commit").
+	Skipped string
+	// SuspiciousSoFar counts flagged items up to and including this one.
+	SuspiciousSoFar int
+}
+
+// ItemProgressDetector is implemented by detectors that can report progress
+// per analyzed item. StreamingRunner sets the callback before Detect and
+// forwards each report as a progress event and an EventItem.
+type ItemProgressDetector interface {
+	Detector
+	SetItemProgress(fn func(ItemProgress))
+}
+
 type ProgressInfo struct {
 	Phase       string
 	Current     int
@@ -53,6 +83,7 @@ func NewStreamingRunnerWithLogger(logger *logging.Logger) *StreamingRunner {
 
 func (r *StreamingRunner) RunStream(ctx context.Context, source AnalysisSource, detectors ...Detector) <-chan StreamEvent {
 	events := make(chan StreamEvent, 64)
+	log := r.logger.WithContext(ctx)
 
 	go func() {
 		defer close(events)
@@ -61,7 +92,7 @@ func (r *StreamingRunner) RunStream(ctx context.Context, source AnalysisSource,
 		// doesn't kill the entire server process during SSE streaming.
 		defer func() {
 			if rec := recover(); rec != nil {
-				r.logger.Error("panic in streaming runner", "panic", fmt.Sprintf("%v", rec))
+				log.Error("panic in streaming runner", "panic", fmt.Sprintf("%v", rec))
 				r.emit(ctx, events, StreamEvent{
 					Type:  EventError,
 					Error: fmt.Errorf("internal analysis error: %v", rec),
@@ -142,7 +173,26 @@ func (r *StreamingRunner) RunStream(ctx context.Context, source AnalysisSource,
 			default:
 			}
 
+			if itemized, ok := detector.(ItemProgressDetector); ok {
+				itemized.SetItemProgress(func(item ItemProgress) {
+					r.emit(ctx, events, StreamEvent{
+						Type: EventProgress,
+						Progress: &ProgressInfo{
+							Phase:       "detecting_" + item.Kind + "s",
+							Current:     item.Current,
+							Total:       item.Total,
+							Message:     fmt.Sprintf("Analyzing %s %d/%d", item.Kind, item.Current, item.Total),
+							ElapsedTime: time.Since(startTime),
+						},
+					})
+					r.emit(ctx, events, StreamEvent{Type: EventItem, Item: &item})
+				})
+			}
+
 			detections, err := detector.Detect(ctx, sourceData)
+			if itemized, ok := detector.(ItemProgressDetector); ok {
+				itemized.SetItemProgress(nil)
+			}
 			if err != nil {
 				r.emit(ctx, events, StreamEvent{
 					Type:  EventError,
@@ -181,10 +231,9 @@ func (r *StreamingRunner) RunStream(ctx context.Context, source AnalysisSource,
 			Duration:    report.Duration,
 			Phases:      phases,
 		}
-		calculateReportStats(report)
-		calculateSourceMetrics(report)
+		summarizeReport(report)
 
-		r.logger.LogAnalysis(source.Type(), sourceData.ID,
+		log.LogAnalysis(source.Type(), sourceData.ID,
 			"phase", "stream_complete",
 			"duration_ms", report.Duration.Milliseconds(),
 			"total_detections", report.TotalDetections,
@@ -204,6 +253,14 @@ func (r *StreamingRunner) emit(ctx context.Context, ch chan<- StreamEvent, event
 	select {
 	case ch <- event:
 	case <-ctx.Done():
+		// Still hand over a final error if there is room, so the consumer
+		// learns why the stream ended (e.g. a deadline).
+		if event.Type == EventError {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
 	}
 }
 
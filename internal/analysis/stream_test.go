@@ -106,6 +106,59 @@ func TestStreamingRunner_HappyPath(t *testing.T) {
 	}
 }
 
+// itemizedDetector reports one item per detection it returns.
+type itemizedDetector struct {
+	mockDetector
+	report func(ItemProgress)
+}
+
+func (m *itemizedDetector) SetItemProgress(fn func(ItemProgress)) { m.report = fn }
+
+func (m *itemizedDetector) Detect(ctx context.Context, data *SourceData) ([]Detection, error) {
+	for i, d := range m.detections {
+		if m.report != nil {
+			m.report(ItemProgress{Kind: "commit", ID: d.Strategy, Current: i + 1, Total: len(m.detections)})
+		}
+	}
+	return m.mockDetector.Detect(ctx, data)
+}
+
+func TestStreamingRunner_ItemProgress(t *testing.T) {
+	source := &mockSource{
+		sourceType: "git",
+		data:       &SourceData{ID: "repo", Type: "git", Metadata: map[string]interface{}{}},
+	}
+	detector := &itemizedDetector{mockDetector: mockDetector{detections: []Detection{
+		{Strategy: "a", Detected: true, Severity: "low"},
+		{Strategy: "b", Detected: true, Severity: "low"},
+	}}}
+
+	var items []ItemProgress
+	var itemPhases []string
+	for event := range NewStreamingRunner().RunStream(context.Background(), source, detector) {
+		switch event.Type {
+		case EventItem:
+			items = append(items, *event.Item)
+		case EventProgress:
+			if event.Progress.Phase == "detecting_commits" {
+				itemPhases = append(itemPhases, fmt.Sprintf("%d/%d", event.Progress.Current, event.Progress.Total))
+			}
+		case EventError:
+			t.Fatalf("unexpected error: %v", event.Error)
+		}
+	}
+
+	if len(items) != 2 || items[0].ID != "a" || items[1].Current != 2 {
+		t.Errorf("item events = %+v, want commits a and b", items)
+	}
+	if fmt.Sprint(itemPhases) != "[1/2 2/2]" {
+		t.Errorf("per-commit progress = %v, want [1/2 2/2]", itemPhases)
+	}
+	if detector.report != nil {
+		t.Error("runner left the item progress callback set after Detect")
+	}
+}
+
 func TestStreamingRunner_ValidationError(t *testing.T) {
 	source := &mockSource{
 		sourceType:  "test",
@@ -0,0 +1,51 @@
+package analysis
+
+type suppressionKey struct {
+	strategy string
+	target   string
+}
+
+// SuppressionList holds detections that users reported as false positives.
+// Entries with a commit hash silence one strategy on that commit; entries
+// without one silence a strategy for a whole source, such as a web page.
+// A nil list suppresses nothing.
+type SuppressionList struct {
+	commits map[suppressionKey]bool
+	sources map[suppressionKey]bool
+}
+
+func NewSuppressionList() *SuppressionList {
+	return &SuppressionList{
+		commits: make(map[suppressionKey]bool),
+		sources: make(map[suppressionKey]bool),
+	}
+}
+
+// Add suppresses strategy on commitHash, or on sourceID when no commit is given.
+func (l *SuppressionList) Add(strategy, sourceID, commitHash string) {
+	if commitHash != "" {
+		l.commits[suppressionKey{strategy, commitHash}] = true
+		return
+	}
+	l.sources[suppressionKey{strategy, sourceID}] = true
+}
+
+// Suppressed reports whether strategy was marked a false positive for the
+// commit or the source.
+func (l *SuppressionList) Suppressed(strategy, sourceID, commitHash string) bool {
+	if l == nil {
+		return false
+	}
+	if commitHash != "" && l.commits[suppressionKey{strategy, commitHash}] {
+		return true
+	}
+	return sourceID != "" && l.sources[suppressionKey{strategy, sourceID}]
+}
+
+// Len returns the number of suppressed entries.
+func (l *SuppressionList) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.commits) + len(l.sources)
+}
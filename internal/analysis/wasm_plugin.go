@@ -0,0 +1,264 @@
+package analysis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+const (
+	// DefaultWASMTimeout bounds one call into a WASM plugin when
+	// WASMOptions.Timeout is zero.
+	DefaultWASMTimeout = 5 * time.Second
+	// DefaultWASMMemoryLimitMB caps a WASM plugin's linear memory when
+	// WASMOptions.MemoryLimitMB is zero.
+	DefaultWASMMemoryLimitMB = 128
+)
+
+// WASMOptions sandboxes WASM plugins.
+type WASMOptions struct {
+	// Timeout bounds every call into the module; a call running over is
+	// aborted and fails (0 = DefaultWASMTimeout).
+	Timeout time.Duration
+	// MemoryLimitMB caps the module's linear memory; growing past it fails
+	// the call (0 = DefaultWASMMemoryLimitMB).
+	MemoryLimitMB int
+}
+
+func (o WASMOptions) withDefaults() WASMOptions {
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultWASMTimeout
+	}
+	if o.MemoryLimitMB <= 0 {
+		o.MemoryLimitMB = DefaultWASMMemoryLimitMB
+	}
+	return o
+}
+
+// wasmSourceData is the JSON a WASM plugin's detect function receives.
+type wasmSourceData struct {
+	ID       string                 `json:"id"`
+	Type     string                 `json:"type"`
+	Content  interface{}            `json:"content"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// wasmDetection is the JSON a WASM plugin's detect function returns a list of.
+type wasmDetection struct {
+	Strategy    string   `json:"strategy"`
+	Detected    bool     `json:"detected"`
+	Severity    string   `json:"severity"`
+	Score       float64  `json:"score"`
+	Confidence  float64  `json:"confidence"`
+	Category    string   `json:"category"`
+	Description string   `json:"description"`
+	Examples    []string `json:"examples"`
+}
+
+// WASMPlugin is a StrategyPlugin implemented by a WebAssembly module, run
+// in a sandbox with no filesystem or network access. Each Detect gets a
+// fresh module instance, so plugin state never leaks between analyses and
+// calls may run concurrently.
+//
+// The module must export its memory and:
+//
+//	alloc(size i32) i32       reserve size bytes for the host to write into
+//	info() i64                JSON StrategyInfo, packed as ptr<<32 | len
+//	detect(ptr, len i32) i64  JSON {id, type, content, metadata} in, JSON detections out
+//
+// WASI reactors (such as Go's GOOS=wasip1 -buildmode=c-shared) have their
+// _initialize function run first. examples/wasm-plugin is a minimal module.
+type WASMPlugin struct {
+	path     string
+	info     StrategyInfo
+	opts     WASMOptions
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+}
+
+// LoadWASMPlugin compiles the module at path and reads its strategy info.
+func LoadWASMPlugin(ctx context.Context, path string, opts WASMOptions) (*WASMPlugin, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WASM plugin: %w", err)
+	}
+
+	opts = opts.withDefaults()
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(uint32(opts.MemoryLimitMB)*16). // 64 KiB pages
+		WithCloseOnContextDone(true))
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to set up WASI: %w", err)
+	}
+
+	compiled, err := runtime.CompileModule(ctx, code)
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to compile WASM plugin: %w", err)
+	}
+	for _, name := range []string{"alloc", "info", "detect"} {
+		if _, ok := compiled.ExportedFunctions()[name]; !ok {
+			runtime.Close(ctx)
+			return nil, fmt.Errorf("WASM plugin does not export %s", name)
+		}
+	}
+
+	p := &WASMPlugin{path: path, opts: opts, runtime: runtime, compiled: compiled}
+	out, err := p.call(ctx, "info", nil)
+	if err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("failed to read WASM plugin info: %w", err)
+	}
+	if err := json.Unmarshal(out, &p.info); err != nil {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("WASM plugin info is not valid JSON: %w", err)
+	}
+	if p.info.Name == "" {
+		runtime.Close(ctx)
+		return nil, fmt.Errorf("WASM plugin info has no strategy name")
+	}
+	return p, nil
+}
+
+// Info returns the strategy metadata the module reported at load time.
+func (p *WASMPlugin) Info() StrategyInfo { return p.info }
+
+// Path returns the file the module was loaded from.
+func (p *WASMPlugin) Path() string { return p.path }
+
+// Close releases the compiled module.
+func (p *WASMPlugin) Close(ctx context.Context) error {
+	return p.runtime.Close(ctx)
+}
+
+// Detect serializes data to JSON, runs the module's detect function on it
+// and decodes the detections it returns.
+func (p *WASMPlugin) Detect(ctx context.Context, data *SourceData) ([]Detection, error) {
+	input, err := json.Marshal(wasmSourceData{
+		ID:       data.ID,
+		Type:     data.Type,
+		Content:  data.RawContent,
+		Metadata: data.Metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize source data: %w", err)
+	}
+
+	out, err := p.call(ctx, "detect", input)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []wasmDetection
+	if err := json.Unmarshal(out, &results); err != nil {
+		return nil, fmt.Errorf("WASM plugin returned invalid detections: %w", err)
+	}
+	detections := make([]Detection, len(results))
+	for i, r := range results {
+		detections[i] = Detection{
+			Strategy:    r.Strategy,
+			Detected:    r.Detected,
+			Severity:    r.Severity,
+			Score:       r.Score,
+			Confidence:  r.Confidence,
+			Category:    r.Category,
+			Description: r.Description,
+			Examples:    r.Examples,
+		}
+		if detections[i].Strategy == "" {
+			detections[i].Strategy = p.info.Name
+		}
+	}
+	return detections, nil
+}
+
+// call runs fn in a fresh instance of the module within the timeout. With
+// input, fn is called with the buffer it was copied into; either way its
+// packed result is read back.
+func (p *WASMPlugin) call(ctx context.Context, fn string, input []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.opts.Timeout)
+	defer cancel()
+
+	mod, err := p.runtime.InstantiateModule(ctx, p.compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"))
+	if err != nil {
+		return nil, p.callError(ctx, "instantiate", err)
+	}
+	defer mod.Close(context.WithoutCancel(ctx))
+
+	var params []uint64
+	if input != nil {
+		res, err := mod.ExportedFunction("alloc").Call(ctx, uint64(len(input)))
+		if err != nil {
+			return nil, p.callError(ctx, "alloc", err)
+		}
+		ptr := uint32(res[0])
+		if !mod.Memory().Write(ptr, input) {
+			return nil, fmt.Errorf("WASM plugin alloc returned an out of range buffer")
+		}
+		params = []uint64{uint64(ptr), uint64(len(input))}
+	}
+
+	res, err := mod.ExportedFunction(fn).Call(ctx, params...)
+	if err != nil {
+		return nil, p.callError(ctx, fn, err)
+	}
+	ptr, size := uint32(res[0]>>32), uint32(res[0])
+	out, ok := mod.Memory().Read(ptr, size)
+	if !ok {
+		return nil, fmt.Errorf("WASM plugin %s returned an out of range result", fn)
+	}
+	// The instance is discarded with its memory; copy the result out first.
+	return append([]byte(nil), out...), nil
+}
+
+// callError names a timeout instead of wazero's generic exit error.
+func (p *WASMPlugin) callError(ctx context.Context, fn string, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("WASM plugin %s timed out after %s", fn, p.opts.Timeout)
+	}
+	return fmt.Errorf("WASM plugin %s failed: %w", fn, err)
+}
+
+// LoadWASMDir registers every .wasm module in dir, returning how many were
+// loaded. Like LoadManifest, a module that fails to load is logged and
+// skipped; only an unreadable directory is an error.
+func (pm *PluginManager) LoadWASMDir(ctx context.Context, dir string, opts WASMOptions) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read WASM plugin directory: %w", err)
+	}
+
+	var paths []string
+	for _, e := range entries {
+		if !e.IsDir() && filepath.Ext(e.Name()) == ".wasm" {
+			paths = append(paths, filepath.Join(dir, e.Name()))
+		}
+	}
+	sort.Strings(paths)
+
+	loaded := 0
+	for _, path := range paths {
+		p, err := LoadWASMPlugin(ctx, path, opts)
+		if err == nil {
+			err = pm.RegisterFrom(p, path)
+		}
+		if err != nil {
+			pm.logger.Warn("skipping WASM plugin", "source", path, "error", err.Error())
+			continue
+		}
+		loaded++
+	}
+	pm.logger.Info("WASM plugins loaded", "dir", dir, "loaded", loaded, "skipped", len(paths)-loaded)
+	return loaded, nil
+}
@@ -0,0 +1,134 @@
+package analysis
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	exampleWASMOnce sync.Once
+	exampleWASMPath string
+	exampleWASMErr  error
+)
+
+// exampleWASM builds examples/wasm-plugin once per test run and returns the
+// module's path.
+func exampleWASM(t *testing.T) string {
+	t.Helper()
+	if testing.Short() {
+		t.Skip("building the example WASM module is slow")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available to build the example WASM module")
+	}
+
+	exampleWASMOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "cadence-wasm")
+		if err != nil {
+			exampleWASMErr = err
+			return
+		}
+		exampleWASMPath = filepath.Join(dir, "todo.wasm")
+		cmd := exec.Command(goTool, "build", "-buildmode=c-shared", "-o", exampleWASMPath, "./examples/wasm-plugin")
+		cmd.Dir = filepath.Join("..", "..")
+		cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			exampleWASMErr = err
+			exampleWASMPath = string(out)
+		}
+	})
+	if exampleWASMErr != nil {
+		t.Fatalf("building the example WASM module failed: %v\n%s", exampleWASMErr, exampleWASMPath)
+	}
+	return exampleWASMPath
+}
+
+func TestWASMPlugin_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	p, err := LoadWASMPlugin(ctx, exampleWASM(t), WASMOptions{})
+	if err != nil {
+		t.Fatalf("LoadWASMPlugin() error = %v", err)
+	}
+	defer p.Close(ctx)
+
+	if info := p.Info(); info.Name != "wasm_todo_markers" || info.Category != CategoryPattern || len(info.SourceTypes) != 2 {
+		t.Errorf("Info() = %+v, want the example module's strategy", info)
+	}
+
+	detections, err := p.Detect(ctx, &SourceData{
+		ID:         "https://example.com",
+		Type:       "web",
+		RawContent: map[string]string{"text": strings.Repeat("TODO: finish this. ", 5)},
+	})
+	if err != nil {
+		t.Fatalf("Detect() error = %v", err)
+	}
+	if len(detections) != 1 {
+		t.Fatalf("Detect() = %+v, want one detection", detections)
+	}
+	d := detections[0]
+	if d.Strategy != "wasm_todo_markers" || !d.Detected || d.Score != 0.5 || d.Description != "5 TODO markers in https://example.com" {
+		t.Errorf("Detect() = %+v, want 5 TODO markers scored 0.5", d)
+	}
+
+	clean, err := p.Detect(ctx, &SourceData{ID: "clean", Type: "web", RawContent: "nothing to do"})
+	if err != nil || len(clean) != 0 {
+		t.Errorf("Detect() on clean content = %+v, %v, want no detections", clean, err)
+	}
+}
+
+func TestWASMPlugin_Limits(t *testing.T) {
+	ctx := context.Background()
+	path := exampleWASM(t)
+
+	if _, err := LoadWASMPlugin(ctx, path, WASMOptions{MemoryLimitMB: 1}); err == nil {
+		t.Error("LoadWASMPlugin() should fail when the module needs more memory than the limit")
+	}
+
+	_, err := LoadWASMPlugin(ctx, path, WASMOptions{Timeout: time.Microsecond})
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("LoadWASMPlugin() error = %v, want the call to time out", err)
+	}
+}
+
+func TestPluginManager_LoadWASMDir(t *testing.T) {
+	ctx := context.Background()
+	module, err := os.ReadFile(exampleWASM(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	for name, data := range map[string][]byte{
+		"todo.wasm":   module,
+		"broken.wasm": []byte("not wasm"),
+		"notes.txt":   []byte("ignored"),
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pm := NewPluginManager()
+	loaded, err := pm.LoadWASMDir(ctx, dir, WASMOptions{})
+	if err != nil {
+		t.Fatalf("LoadWASMDir() error = %v", err)
+	}
+	if loaded != 1 || pm.Count() != 1 {
+		t.Fatalf("LoadWASMDir() loaded %d, want only todo.wasm", loaded)
+	}
+	if got := pm.Plugins()[0]; got.Name != "wasm_todo_markers" || got.Source != filepath.Join(dir, "todo.wasm") {
+		t.Errorf("Plugins()[0] = %+v, want the module with its path", got)
+	}
+
+	if _, err := pm.LoadWASMDir(ctx, filepath.Join(dir, "absent"), WASMOptions{}); err == nil {
+		t.Error("LoadWASMDir() of a missing directory should fail")
+	}
+}
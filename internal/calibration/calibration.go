@@ -0,0 +1,181 @@
+// Package calibration runs the detectors against bundled fixtures of known
+// provenance and reports how well the current thresholds separate AI-generated
+// from human-written samples.
+package calibration
+
+import (
+	"context"
+	"io"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+	webpatterns "github.com/TryCadence/Cadence/internal/analysis/adapters/web/patterns"
+	"github.com/TryCadence/Cadence/internal/analysis/detectors"
+	"github.com/TryCadence/Cadence/internal/config"
+	cerrors "github.com/TryCadence/Cadence/internal/errors"
+	"github.com/TryCadence/Cadence/internal/logging"
+)
+
+const (
+	// DefaultGitFlagRate is the fraction of commit pairs that must be flagged
+	// for a git fixture to be classified as AI-generated.
+	DefaultGitFlagRate = 0.5
+	// DefaultWebFlagRate is the fraction of web strategies that must fire for
+	// a web fixture to be classified as AI-generated.
+	DefaultWebFlagRate = 0.2
+)
+
+type Options struct {
+	Thresholds          *patterns.Thresholds
+	Strategies          *config.StrategyConfig
+	DependencyManifests []string
+	AIAssistants        []string
+	NGramRepetition     config.NGramRepetitionConfig
+	Churn               config.ChurnConfig
+	Baseline            config.BaselineConfig
+	WebVocabulary       *webpatterns.Vocabulary
+	GitFlagRate         float64
+	WebFlagRate         float64
+	Logger              *logging.Logger
+}
+
+// SampleResult is the outcome for a single fixture.
+type SampleResult struct {
+	Name      string  `json:"name"`
+	Kind      string  `json:"kind"`
+	Label     string  `json:"label"`
+	Predicted string  `json:"predicted"`
+	Score     float64 `json:"score"`
+	Flagged   int     `json:"flagged"`
+	Total     int     `json:"total"`
+	Correct   bool    `json:"correct"`
+}
+
+// Result aggregates a calibration run. AI is the positive class.
+type Result struct {
+	Samples        []SampleResult `json:"samples"`
+	TruePositives  int            `json:"true_positives"`
+	FalsePositives int            `json:"false_positives"`
+	TrueNegatives  int            `json:"true_negatives"`
+	FalseNegatives int            `json:"false_negatives"`
+}
+
+// Precision is the share of AI verdicts that were correct. A run with no AI
+// verdicts has precision 1 when there were also no AI fixtures to find.
+func (r *Result) Precision() float64 {
+	predicted := r.TruePositives + r.FalsePositives
+	if predicted == 0 {
+		if r.FalseNegatives == 0 {
+			return 1
+		}
+		return 0
+	}
+	return float64(r.TruePositives) / float64(predicted)
+}
+
+// Recall is the share of AI fixtures that were classified as AI.
+func (r *Result) Recall() float64 {
+	actual := r.TruePositives + r.FalseNegatives
+	if actual == 0 {
+		return 1
+	}
+	return float64(r.TruePositives) / float64(actual)
+}
+
+func (r *Result) Accuracy() float64 {
+	if len(r.Samples) == 0 {
+		return 0
+	}
+	return float64(r.TruePositives+r.TrueNegatives) / float64(len(r.Samples))
+}
+
+// Run analyzes every bundled fixture with the given options.
+func Run(ctx context.Context, opts Options) (*Result, error) {
+	fixtures, err := LoadFixtures()
+	if err != nil {
+		return nil, err
+	}
+	return RunFixtures(ctx, fixtures, opts)
+}
+
+// RunFixtures analyzes the given fixtures with the full detection pipeline
+// and classifies each one against its label.
+func RunFixtures(ctx context.Context, fixtures []Fixture, opts Options) (*Result, error) {
+	if opts.GitFlagRate <= 0 {
+		opts.GitFlagRate = DefaultGitFlagRate
+	}
+	if opts.WebFlagRate <= 0 {
+		opts.WebFlagRate = DefaultWebFlagRate
+	}
+	if opts.Logger == nil {
+		opts.Logger = logging.New(&logging.Config{Output: io.Discard})
+	}
+
+	runner := analysis.NewDefaultDetectionRunnerWithLogger(opts.Logger)
+	result := &Result{Samples: make([]SampleResult, 0, len(fixtures))}
+
+	for _, f := range fixtures {
+		var detector analysis.Detector
+		switch f.Kind {
+		case "git":
+			gitDetector := detectors.NewGitDetectorWithConfig(opts.Thresholds, opts.Strategies)
+			gitDetector.DependencyManifests = opts.DependencyManifests
+			gitDetector.AIAssistants = opts.AIAssistants
+			gitDetector.NGramMaxCoverage = opts.NGramRepetition.GitMaxCoverage
+			gitDetector.Churn = opts.Churn.Options()
+			gitDetector.SampleSizeGate = opts.Baseline.Gate()
+			detector = gitDetector
+		case "web":
+			webDetector := detectors.NewWebDetector()
+			webDetector.NGramMaxCoverage = opts.NGramRepetition.WebMaxCoverage
+			webDetector.Vocabulary = opts.WebVocabulary
+			detector = webDetector
+		default:
+			return nil, cerrors.ValidationError("unknown fixture kind").WithDetails(f.Kind)
+		}
+
+		report, err := runner.Run(ctx, f.Source(), detector)
+		if err != nil {
+			return nil, cerrors.AnalysisError("calibration fixture failed").WithDetails(f.Name).Wrap(err)
+		}
+
+		sample := SampleResult{
+			Name:    f.Name,
+			Kind:    f.Kind,
+			Label:   f.Label,
+			Flagged: report.DetectionCount,
+		}
+
+		rate := opts.WebFlagRate
+		if f.Kind == "git" {
+			rate = opts.GitFlagRate
+			sample.Total = len(f.pairs)
+		} else {
+			sample.Total = report.TotalDetections
+		}
+		if sample.Total > 0 {
+			sample.Score = float64(sample.Flagged) / float64(sample.Total)
+		}
+
+		sample.Predicted = LabelHuman
+		if sample.Total > 0 && sample.Score >= rate {
+			sample.Predicted = LabelAI
+		}
+		sample.Correct = sample.Predicted == sample.Label
+
+		switch {
+		case sample.Label == LabelAI && sample.Predicted == LabelAI:
+			result.TruePositives++
+		case sample.Label == LabelHuman && sample.Predicted == LabelAI:
+			result.FalsePositives++
+		case sample.Label == LabelHuman:
+			result.TrueNegatives++
+		default:
+			result.FalseNegatives++
+		}
+
+		result.Samples = append(result.Samples, sample)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,112 @@
+package calibration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+	"github.com/TryCadence/Cadence/internal/config"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	fixtures, err := LoadFixtures()
+	if err != nil {
+		t.Fatalf("LoadFixtures() unexpected error = %v", err)
+	}
+
+	counts := make(map[string]int)
+	for _, f := range fixtures {
+		counts[f.Kind+"/"+f.Label]++
+		if f.Kind == "git" && len(f.pairs) == 0 {
+			t.Errorf("git fixture %s has no commit pairs", f.Name)
+		}
+	}
+
+	for _, key := range []string{"git/ai", "git/human", "web/ai", "web/human"} {
+		if counts[key] == 0 {
+			t.Errorf("no %s fixtures bundled", key)
+		}
+	}
+}
+
+func TestRun_DefaultThresholds(t *testing.T) {
+	cfg, err := config.Load("")
+	if err != nil {
+		t.Fatalf("config.Load() unexpected error = %v", err)
+	}
+
+	result, err := Run(context.Background(), Options{Thresholds: &cfg.Thresholds})
+	if err != nil {
+		t.Fatalf("Run() unexpected error = %v", err)
+	}
+
+	for _, s := range result.Samples {
+		if !s.Correct {
+			t.Errorf("fixture %s (%s) labelled %s was classified %s (flagged %d/%d)",
+				s.Name, s.Kind, s.Label, s.Predicted, s.Flagged, s.Total)
+		}
+	}
+	if result.Precision() != 1 || result.Recall() != 1 {
+		t.Errorf("Precision() = %.2f, Recall() = %.2f, want 1.00 with default thresholds",
+			result.Precision(), result.Recall())
+	}
+}
+
+func TestRun_DetectsDegradedThresholds(t *testing.T) {
+	fixtures, err := LoadFixtures()
+	if err != nil {
+		t.Fatalf("LoadFixtures() unexpected error = %v", err)
+	}
+
+	gitFixtures := make([]Fixture, 0)
+	for _, f := range fixtures {
+		if f.Kind == "git" {
+			gitFixtures = append(gitFixtures, f)
+		}
+	}
+
+	// Only a size check that nothing in the fixtures reaches.
+	thresholds := &patterns.Thresholds{SuspiciousAdditions: 1_000_000}
+	disabled := &config.StrategyConfig{DisabledStrategies: map[string]bool{}}
+	for _, name := range []string{
+		"commit_message_analysis", "naming_pattern_analysis", "structural_consistency_analysis",
+		"burst_pattern_analysis", "error_handling_analysis", "template_pattern_analysis",
+		"file_extension_analysis", "dependency_addition_analysis", "StatisticalAnomaly", "round_statistics_analysis", "code_entropy_analysis", "ngram_repetition_analysis", "rewrite_similarity_analysis", "TimingAnomaly",
+		"emoji_pattern_analysis", "special_character_pattern_analysis",
+	} {
+		disabled.DisabledStrategies[name] = true
+	}
+
+	result, err := RunFixtures(context.Background(), gitFixtures, Options{Thresholds: thresholds, Strategies: disabled})
+	if err != nil {
+		t.Fatalf("RunFixtures() unexpected error = %v", err)
+	}
+	if result.Recall() != 0 {
+		t.Errorf("Recall() = %.2f, want 0 with all strategies effectively disabled", result.Recall())
+	}
+}
+
+func TestResult_Metrics(t *testing.T) {
+	r := &Result{
+		Samples:        make([]SampleResult, 10),
+		TruePositives:  3,
+		FalsePositives: 1,
+		TrueNegatives:  4,
+		FalseNegatives: 2,
+	}
+
+	if got := r.Precision(); got != 0.75 {
+		t.Errorf("Precision() = %v, want 0.75", got)
+	}
+	if got := r.Recall(); got != 0.6 {
+		t.Errorf("Recall() = %v, want 0.6", got)
+	}
+	if got := r.Accuracy(); got != 0.7 {
+		t.Errorf("Accuracy() = %v, want 0.7", got)
+	}
+
+	empty := &Result{}
+	if empty.Precision() != 1 || empty.Recall() != 1 || empty.Accuracy() != 0 {
+		t.Errorf("empty result metrics = %v/%v/%v, want 1/1/0", empty.Precision(), empty.Recall(), empty.Accuracy())
+	}
+}
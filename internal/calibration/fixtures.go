@@ -0,0 +1,236 @@
+package calibration
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
+	cerrors "github.com/TryCadence/Cadence/internal/errors"
+)
+
+//go:embed fixtures
+var fixtureFS embed.FS
+
+// Labels for fixtures with known provenance.
+const (
+	LabelAI    = "ai"
+	LabelHuman = "human"
+)
+
+// fixtureEpoch anchors fixture commit offsets to a fixed point in time so
+// timing-based strategies see the same history on every run.
+var fixtureEpoch = time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)
+
+// Fixture is a bundled sample with a known label.
+type Fixture struct {
+	Name        string
+	Kind        string // "git" or "web"
+	Label       string // LabelAI or LabelHuman
+	Description string
+
+	pairs []*git.CommitPair
+	text  string
+}
+
+type gitFixtureFile struct {
+	Label       string             `json:"label"`
+	Description string             `json:"description"`
+	Commits     []gitFixtureCommit `json:"commits"`
+}
+
+type gitFixtureCommit struct {
+	Hash          string `json:"hash"`
+	Author        string `json:"author"`
+	Email         string `json:"email"`
+	OffsetSeconds int64  `json:"offset_seconds"`
+	Message       string `json:"message"`
+	Additions     int64  `json:"additions"`
+	Deletions     int64  `json:"deletions"`
+	Files         int    `json:"files"`
+	Diff          string `json:"diff"`
+}
+
+// LoadFixtures returns all bundled fixtures sorted by kind and name. Git
+// fixtures are JSON commit histories; web fixtures are plain text files whose
+// name prefix (ai_ or human_) carries the label.
+func LoadFixtures() ([]Fixture, error) {
+	fixtures := make([]Fixture, 0)
+
+	gitFiles, err := fs.Glob(fixtureFS, "fixtures/git/*.json")
+	if err != nil {
+		return nil, cerrors.ConfigError("failed to list git fixtures").Wrap(err)
+	}
+	for _, name := range gitFiles {
+		f, err := loadGitFixture(name)
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, f)
+	}
+
+	webFiles, err := fs.Glob(fixtureFS, "fixtures/web/*.txt")
+	if err != nil {
+		return nil, cerrors.ConfigError("failed to list web fixtures").Wrap(err)
+	}
+	for _, name := range webFiles {
+		f, err := loadWebFixture(name)
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, f)
+	}
+
+	sort.SliceStable(fixtures, func(i, j int) bool {
+		if fixtures[i].Kind != fixtures[j].Kind {
+			return fixtures[i].Kind < fixtures[j].Kind
+		}
+		return fixtures[i].Name < fixtures[j].Name
+	})
+
+	return fixtures, nil
+}
+
+func loadGitFixture(name string) (Fixture, error) {
+	raw, err := fixtureFS.ReadFile(name)
+	if err != nil {
+		return Fixture{}, cerrors.ConfigError("failed to read fixture").WithDetails(name).Wrap(err)
+	}
+
+	var file gitFixtureFile
+	if err := json.Unmarshal(raw, &file); err != nil {
+		return Fixture{}, cerrors.ConfigError("invalid git fixture").WithDetails(name).Wrap(err)
+	}
+	if file.Label != LabelAI && file.Label != LabelHuman {
+		return Fixture{}, cerrors.ConfigError("git fixture has unknown label").WithDetails(name + ": " + file.Label)
+	}
+	if len(file.Commits) < 2 {
+		return Fixture{}, cerrors.ConfigError("git fixture needs at least two commits").WithDetails(name)
+	}
+
+	commits := make([]*git.Commit, len(file.Commits))
+	for i, c := range file.Commits {
+		commit := &git.Commit{
+			Hash:      c.Hash,
+			Author:    c.Author,
+			Email:     c.Email,
+			Timestamp: fixtureEpoch.Add(time.Duration(c.OffsetSeconds) * time.Second),
+			Message:   c.Message,
+		}
+		if i > 0 {
+			commit.Parents = []string{file.Commits[i-1].Hash}
+		}
+		commits[i] = commit
+	}
+
+	pairs := make([]*git.CommitPair, 0, len(commits)-1)
+	for i := 1; i < len(commits); i++ {
+		c := file.Commits[i]
+		pairs = append(pairs, &git.CommitPair{
+			Previous:  commits[i-1],
+			Current:   commits[i],
+			TimeDelta: commits[i].Timestamp.Sub(commits[i-1].Timestamp),
+			Stats: &git.DiffStats{
+				FilesChanged: c.Files,
+				Additions:    c.Additions,
+				Deletions:    c.Deletions,
+			},
+			DiffContent: c.Diff,
+		})
+	}
+
+	return Fixture{
+		Name:        fixtureName(name),
+		Kind:        "git",
+		Label:       file.Label,
+		Description: file.Description,
+		pairs:       pairs,
+	}, nil
+}
+
+func loadWebFixture(name string) (Fixture, error) {
+	raw, err := fixtureFS.ReadFile(name)
+	if err != nil {
+		return Fixture{}, cerrors.ConfigError("failed to read fixture").WithDetails(name).Wrap(err)
+	}
+
+	base := fixtureName(name)
+	label := ""
+	switch {
+	case strings.HasPrefix(base, LabelAI+"_"):
+		label = LabelAI
+	case strings.HasPrefix(base, LabelHuman+"_"):
+		label = LabelHuman
+	default:
+		return Fixture{}, cerrors.ConfigError("web fixture name must start with ai_ or human_").WithDetails(name)
+	}
+
+	return Fixture{
+		Name:  base,
+		Kind:  "web",
+		Label: label,
+		text:  string(raw),
+	}, nil
+}
+
+func fixtureName(name string) string {
+	base := path.Base(name)
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// Source returns an AnalysisSource that yields the fixture's prebuilt data
+// without touching the network or filesystem.
+func (f Fixture) Source() analysis.AnalysisSource {
+	return &fixtureSource{fixture: f}
+}
+
+type fixtureSource struct {
+	fixture Fixture
+}
+
+func (s *fixtureSource) Type() string {
+	return s.fixture.Kind
+}
+
+func (s *fixtureSource) Validate(ctx context.Context) error {
+	if s.fixture.Kind == "git" && len(s.fixture.pairs) == 0 {
+		return cerrors.ValidationError("fixture has no commit pairs").WithDetails(s.fixture.Name)
+	}
+	if s.fixture.Kind == "web" && strings.TrimSpace(s.fixture.text) == "" {
+		return cerrors.ValidationError("fixture has no content").WithDetails(s.fixture.Name)
+	}
+	return nil
+}
+
+func (s *fixtureSource) Fetch(ctx context.Context) (*analysis.SourceData, error) {
+	data := &analysis.SourceData{
+		ID:       s.fixture.Name,
+		Type:     s.fixture.Kind,
+		Metadata: map[string]interface{}{},
+	}
+
+	switch s.fixture.Kind {
+	case "git":
+		data.RawContent = s.fixture.pairs
+		data.Metadata["commit_count"] = len(s.fixture.pairs) + 1
+		data.Metadata["commit_pairs"] = s.fixture.pairs
+	case "web":
+		data.RawContent = &web.PageContent{
+			URL:       "fixture://" + s.fixture.Name,
+			AllText:   s.fixture.text,
+			WordCount: len(strings.Fields(s.fixture.text)),
+		}
+		data.Metadata["word_count"] = len(strings.Fields(s.fixture.text))
+	default:
+		return nil, cerrors.ValidationError("unknown fixture kind").WithDetails(s.fixture.Kind)
+	}
+
+	return data, nil
+}
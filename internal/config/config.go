@@ -1,10 +1,23 @@
 package config
 
 import (
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
+	webpatterns "github.com/TryCadence/Cadence/internal/analysis/adapters/web/patterns"
+	"github.com/TryCadence/Cadence/internal/logging"
 	"github.com/spf13/viper"
 )
 
@@ -14,27 +27,61 @@ const SampleConfigTemplate = `# Cadence Configuration - AI-Generated Code Detect
 # Analyzes git repositories to detect potential AI-generated code patterns
 
 thresholds:
+  # PRESET: strict, balanced (the defaults, shown below) or lenient.
+  # Thresholds uncommented in this section override the preset's.
+  # preset: balanced
+
   # SIZE-BASED DETECTION
-  suspicious_additions: 500
-  suspicious_deletions: 1000
-  
+  # suspicious_additions: 500
+  # suspicious_deletions: 1000
+
   # VELOCITY-BASED DETECTION
-  max_additions_per_min: 100
-  max_deletions_per_min: 500
-  
+  # max_additions_per_min: 100
+  # max_deletions_per_min: 500
+
   # TIMING-BASED DETECTION
-  min_time_delta_seconds: 60
-  
+  # min_time_delta_seconds: 60
+
   # FILE DISPERSION DETECTION
-  max_files_per_commit: 50
-  
+  # max_files_per_commit: 50
+
   # RATIO-BASED DETECTION
-  max_addition_ratio: 0.95
-  min_deletion_ratio: 0.95
-  min_commit_size_ratio: 100
-  
+  # max_addition_ratio: 0.95
+  # min_deletion_ratio: 0.95
+  # min_commit_size_ratio: 100
+
   # PRECISION ANALYSIS
-  enable_precision_analysis: true
+  # enable_precision_analysis: true
+
+# Suspicion-rate cutoffs for the report assessment (0-1, inclusive; medium <= high)
+classification:
+  high: 0.7     # at or above: "Likely AI-Generated"
+  medium: 0.4   # at or above: "Suspicious Activity"; below: "Likely Human-Written"
+
+# ASSESSMENT LABELS: reword the verdicts shown in reports, webhook job results and
+# streams, e.g. to translate them. Unset labels keep the defaults below.
+# assessment_labels:
+#   high_suspicion: "Suspicious Activity Detected"    # report overall score 70+
+#   moderate_suspicion: "Moderate Suspicion"          # report overall score 40+
+#   low_suspicion: "Low Suspicion"
+#   likely_ai: "Likely AI-Generated"                  # webhook jobs, by classification
+#   suspicious: "Suspicious Activity"
+#   likely_human: "Likely Human-Written"
+#   insufficient_content: "Insufficient content for reliable analysis"
+
+# ANALYSIS LIMITS: history depth, memory guards, diff parallelism and timeout
+analysis:
+  max_commits: 1000     # newest commits analyzed (0 = full history)
+  max_diff_bytes: 0     # diff content kept in memory across all commits; later
+                        # commits are analyzed on stats only (0 = no limit)
+  diff_workers: 0       # commit diffs computed in parallel (0 = one per CPU, 1 = serial)
+  merge_strategy: skip  # merge commits: skip, first-parent (analyze the mainline, merges
+                        # against their first parent) or both (all commits and merges)
+  timeout_seconds: 300  # per-job limit on fetching and detection in the webhook server,
+                        # on top of webhook.clone_timeout
+  min_report_confidence: 0.0  # leave detections from strategies less confident than this
+                              # (0-1) out of reports; they still count toward the score
+  include_passed: false       # also report git strategies that ran and fired on no commit
 
 # File patterns to exclude from analysis
 exclude_files:
@@ -62,6 +109,113 @@ exclude_files:
   - "*.eot"
   - "*.otf"
 
+# Machine-generated files (protobuf output, minified bundles, lockfiles and any
+# file starting with a "Code generated ... DO NOT EDIT" or "@generated" marker)
+# are left out of the commit sizes the detectors see and reported separately
+# as generated_additions/generated_files. Applied after exclude_files.
+generated_file_filter:
+  enabled: true
+  # patterns:               # extra file patterns treated as generated
+  #   - "*_gen.go"
+  #   - "internal/api/openapi/**"
+
+# Commit authors to skip entirely, matched against name or email (glob patterns).
+# Useful for bots whose commits always trip velocity and burst strategies.
+# ignore_authors:
+#   - "dependabot[bot]"
+#   - "renovate[bot]"
+#   - "release-please*"
+
+# How author emails are grouped into one identity for the unique author count
+# and per-author baselines (jane@work.com, jane+ci@work.com and
+# 123+jane@users.noreply.github.com are one person)
+author_identity:
+  lowercase: true        # compare emails case-insensitively
+  strip_plus_tag: true   # jane+ci@example.com is jane@example.com
+  map_noreply: true      # GitHub noreply addresses reduce to the login
+  match_names: true      # same local part and agreeing names, or same full name and domain
+
+# Dependency manifests checked for mass dependency additions (scaffolding signal).
+# Base names or glob patterns; leave unset to use the built-in list.
+# dependency_manifests:
+#   - go.mod
+#   - package.json
+#   - requirements.txt
+#   - Cargo.toml
+
+# AI assistant identities matched (case-insensitive) against the address in
+# Co-authored-by, Assisted-by and similar commit trailers: a full address, an
+# "@domain", or a GitHub login (matched against <id>+<login>@users.noreply.github.com).
+# Leave unset to use the built-in list.
+# ai_assistants:
+#   - Copilot
+#   - noreply@anthropic.com
+#   - cursoragent@cursor.com
+
+# Website analysis
+web:
+  min_word_count: 50   # pages with fewer words are reported as insufficient_content
+  # YAML file replacing the phrase lists of the vocabulary strategies. Any of
+  # overused_phrases, ai_vocabulary and boilerplate may be given as a list;
+  # the others keep their built-in lists. A malformed file is ignored with a
+  # warning.
+  # vocabulary_file: cadence-vocabulary.yaml
+  # Outbound HTTP for page fetches. proxy sets a proxy; otherwise
+  # HTTP_PROXY, HTTPS_PROXY and NO_PROXY are honored, except by the webhook
+  # server, whose private-address guard cannot check what a proxy dials.
+  # use_env_proxy overrides that default either way. ca_file adds a PEM CA
+  # bundle (e.g. a TLS-inspecting proxy's) to the system roots.
+  # max_redirects: 0 = 10, negative = follow none.
+  # proxy: http://proxy.example.com:3128
+  # use_env_proxy: true
+  # ca_file: /etc/ssl/corp-ca.pem
+  max_redirects: 10
+  # User-Agent sent with page fetches (default: "Cadence/1.0
+  # (+https://github.com/TryCadence/Cadence)"). respect_robots skips pages the site's robots.txt disallows for it.
+  # user_agent: "MyCrawler/1.0 (+https://example.com/bot)"
+  respect_robots: false
+
+# Combined report of a repository and a website (analyze --repo <url> --site <url>).
+# The overall score is (git_weight*git + web_weight*web) / (git_weight + web_weight).
+multi_source:
+  git_weight: 0.5
+  web_weight: 0.5
+
+# Share of words inside repeated 3- and 4-word phrases above which the
+# n-gram repetition strategies fire (0 = built-in default).
+# ngram_repetition:
+#   web_max_coverage: 0.3    # ngram_repetition, over page text
+#   git_max_coverage: 0.75   # ngram_repetition_analysis, over added diff lines
+
+# code_churn_analysis flags commits adding at least min_additions lines to a
+# file of which later commits delete min_revert_ratio within max_commits
+# commits and max_hours hours (0 = built-in default).
+# churn:
+#   min_additions: 100
+#   min_revert_ratio: 0.6
+#   max_commits: 10
+#   max_hours: 168
+
+# StatisticalAnomaly scores commits against the repository's own baseline,
+# which says little about a repository with only a handful of commits. No
+# anomalies are computed from fewer than min_commits commits, and anomalies
+# from fewer than reliable_commits are reported as low confidence with a
+# caveat (0 = built-in default).
+# baseline:
+#   min_commits: 5
+#   reliable_commits: 30
+
+# Per-language tuning for the error-handling and naming strategies, keyed by
+# file extension without the leading dot. Entries add to or replace the
+# built-in profiles (go, py, js, ts, java, rb, rs).
+# language_profiles:
+#   kt:
+#     name: Kotlin
+#     error_markers: ["try {", "catch", "throw ", "runcatching", "?: return"]
+#     lines_per_error_marker: 40     # expect ~1 error-handling line per 40 added
+#     max_error_marker_ratio: 0.25   # more than this share of lines is excessive
+#     camel_case_identifiers: true
+
 # WEBHOOK SERVER CONFIGURATION
 webhook:
   # Enable/disable webhook server
@@ -73,6 +227,10 @@ webhook:
   
   # Webhook secret for signature verification (set this!)
   secret: "your-webhook-secret-key-here"
+
+  # Bearer token for POST /api/feedback. The webhook secret is always accepted;
+  # set this to give feedback clients a token that cannot sign webhooks.
+  # api_token: ""
   
   # Number of concurrent workers for processing webhook events
   max_workers: 4
@@ -80,61 +238,496 @@ webhook:
   # Request timeouts in seconds
   read_timeout: 30
   write_timeout: 30
+  
+  # Record the SSE events of streaming analyses (served by GET /jobs/:id/events)
+  record_events: false
+  
+  # Seconds a finished stream's latest events are kept so a client reconnecting
+  # with Last-Event-ID and X-Job-ID resumes instead of starting over.
+  stream_resume_grace: 120
+  
+  # Gzip/deflate-compress responses for clients sending Accept-Encoding.
+  # SSE streams under /api/stream/* are never compressed.
+  compress_responses: true
+  
+  # Strip tracking query params (utm_*, fbclid, gclid, ...) when normalizing
+  # submitted URLs for caching and job dedup. Disable if your query params matter.
+  strip_tracking_params: true
+  
+  # Repository cloning. A branch named in the request is always cloned on its own.
+  clone_timeout: 120        # seconds
+  clone_depth: 0            # commits of history to fetch (0 = full history)
+  clone_single_branch: false
+  # Repositories cloned at once by queued jobs and streams together; further
+  # clones wait for a free slot (0 = max_workers)
+  max_concurrent_clones: 0
+  # Keep clones in this directory between analyses and fetch only what changed
+  # instead of re-cloning ("" = clone into a temporary directory every time).
+  # Past repo_cache_max_mb, the least recently used repositories are removed.
+  repo_cache_dir: ""
+  repo_cache_max_mb: 10240
+  
+  # Reuse a pending or running job when the same repository and branch, or the
+  # same website, is submitted again with the same options (e.g. two quick
+  # pushes or a double-clicked request). Requests with a callback_url always
+  # get their own job.
+  dedup_jobs: true
+  
+  # Deliveries of a job's callback_url result POST before giving up.
+  # Network errors and 5xx responses are retried with exponential backoff.
+  callback_max_attempts: 3
+  
+  # Where jobs and results are kept: "memory" (lost on restart) or "sqlite".
+  # With sqlite, unfinished jobs are re-queued when the server starts.
+  job_store: "memory"
+  job_store_path: "cadence-jobs.db"
+  
+  # Seconds running jobs get to finish after SIGINT/SIGTERM before they are
+  # interrupted. Jobs still waiting in the queue are marked cancelled.
+  shutdown_timeout: 30
+  
+  # Waiting jobs at which GET /health/ready answers 503 so load balancers stop
+  # routing new work here (0 = the queue's capacity of 100).
+  ready_max_queue_depth: 0
+  
+  # Manifest of strategy plugins to load at startup, listing .so files built
+  # with -buildmode=plugin (each exporting NewStrategy) or compiled-in hooks.
+  # Plugins that fail to load are logged and skipped. Listed at GET /api/plugins.
+  plugin_manifest: ""
+  
+  # Directory of WebAssembly strategy plugins (*.wasm) to load at startup.
+  # Each call runs in a fresh sandboxed instance with no filesystem or network
+  # access, aborted after wasm_plugin_timeout seconds or when its memory grows
+  # past wasm_plugin_memory_mb. See examples/wasm-plugin.
+  wasm_plugin_dir: ""
+  wasm_plugin_timeout: 5
+  wasm_plugin_memory_mb: 128
+  
+  # Allow submitted repository, website and callback URLs to point at loopback,
+  # private (RFC 1918) and link-local addresses. Keep this off on public servers;
+  # enable it for self-hosted Git servers on your own network.
+  allow_private_hosts: false
+  
+  # Hosts repositories may be cloned from by the analysis API and the push
+  # webhooks. "*.example.com" allows any subdomain of example.com (but not
+  # example.com itself). Other hosts are refused with 403; an empty list
+  # allows every host.
+  allowed_git_hosts: []     # e.g. ["github.com", "gitlab.com", "*.git.internal"]
+  
+  # Let /api/analyze/repository requests name a checkout on the server with
+  # "local_path" instead of a URL to clone. Off by default: anyone who can
+  # reach the API could otherwise have any repository on the server analyzed.
+  # When on, paths must lie under local_path_root, which is then required.
+  allow_local_paths: false
+  local_path_root: ""       # e.g. /srv/checkouts
+  
+  # Branches whose pushes are analyzed by /webhooks/github and /webhooks/gitlab.
+  # Glob patterns ("*" does not cross "/"); an empty include list allows every
+  # branch. Filtered pushes are answered with 200 and status "ignored".
+  branches:
+    include: []             # e.g. ["main", "release/*"]
+    exclude: []             # e.g. ["dependabot/*"]
+
+# RATE LIMITING for the public analysis API (/api/analyze/*, /api/stream/*)
+# Per client IP; webhook endpoints are never limited. 0 requests_per_minute disables it.
+ratelimit:
+  requests_per_minute: 30
+  burst: 10
+
+# RESULT CACHE for the webhook server. Repeated analyses of the same repository
+# URL and branch (or website URL) under the same configuration are served from
+# memory instead of being cloned and recomputed. Local paths are never cached.
+cache:
+  enabled: true
+  max_entries: 256          # least recently used reports are evicted beyond this
+  ttl_seconds: 900
+
+# LOGGING: level is debug, info, warn or error; debug adds the intermediate
+# steps of each webhook job. format json writes one JSON object per line with
+# time, level, msg and the structured attributes, for log shippers.
+logging:
+  level: info
+  format: text
+
+# REPORT OUTPUT: detection examples can quote code and page content. Cap how many
+# are kept per detection and mask secrets (API keys, tokens, private keys, credential
+# assignments) and email addresses in reports, webhook job results and streams.
+report:
+  max_examples: 0   # examples kept per detection (0 = all)
+  redact: false
 
 # AI ANALYSIS CONFIGURATION (Optional - requires API key)
 ai:
   # Enable/disable AI-powered code analysis
   enabled: false
   
-  # AI provider ("openai" or "anthropic")
+  # AI provider ("openai", "anthropic", "gemini", or "ollama")
   provider: "openai"
   
   # API key for the selected provider (or set via CADENCE_AI_KEY environment variable)
   api_key: ""
   
   # Model name (provider-specific; leave empty for provider default)
-  # OpenAI default: gpt-4o-mini | Anthropic default: claude-sonnet-4-20250514
+  # OpenAI default: gpt-4o-mini | Anthropic default: claude-sonnet-4-20250514 | Gemini default: gemini-1.5-flash | Ollama default: llama3.2
   model: ""
 
+  # Server URL for the ollama provider (no API key needed)
+  # base_url: "http://localhost:11434/api/chat"
+
+  # Retries for rate limits, timeouts and 5xx responses, with exponential backoff
+  max_retries: 3            # negative disables retries
+  retry_base_delay_ms: 500
+
+  # Share of the overall score taken from the AI verdicts (0-1); the rest is
+  # the heuristic score. 0 keeps the heuristic score, 1 uses only the AI's
+  blend_weight: 0.5
+
+  # The webhook server asks the AI whether its top high-severity suspicions
+  # are false positives and attaches the verdict to each one
+  triage_limit: 5           # suspicions triaged per analysis; negative disables
+
+  # USD per 1000 tokens, used to estimate AI spend in the webhook server's
+  # metrics. A model can be given as "provider/model" to price one
+  # provider's deployment separately. Unpriced models are counted, not costed.
+  # pricing:
+  #   - model: gpt-4o-mini
+  #     prompt_per_1k: 0.00015
+  #     completion_per_1k: 0.0006
+
 # STRATEGY CONFIGURATION (Optional - control which detection strategies are active)
 strategies:
   # Set any strategy to false to disable it. All strategies are enabled by default.
+  # Names are those listed by the strategy registry.
+  # velocity_analysis: true
+  # size_analysis: true
+  # timing_analysis: true
+  # merge_commit_filter: true
+  # file_dispersion_analysis: true
+  # ratio_analysis: true
+  # precision_analysis: true
   # commit_message_analysis: true
+  # message_diff_mismatch_analysis: true
   # naming_pattern_analysis: true
-  # structural_consistency: true
-  # burst_pattern: true
-  # error_handling_pattern: true
-  # template_pattern: true
-  # file_extension_pattern: true
-  # statistical_anomaly: true
-  # timing_anomaly: true
+  # structural_consistency_analysis: true
+  # burst_pattern_analysis: true
+  # error_handling_analysis: true
+  # template_pattern_analysis: true
+  # file_extension_analysis: true
+  # boilerplate_header_analysis: true
+  # commit_topology_analysis: true
+  # code_churn_analysis: true
+  # dependency_addition_analysis: true
+  # StatisticalAnomaly: true
+  # ngram_repetition_analysis: true
+  # round_statistics_analysis: true
+  # code_entropy_analysis: true
+  # rewrite_similarity_analysis: true
+  # ai_coauthor_analysis: true
+  # signature_analysis: true
+  # timezone_anomaly_analysis: true
+  # message_style_consistency_analysis: true
+  # TimingAnomaly: true
+  # emoji_pattern_analysis: true
+  # special_character_pattern_analysis: true
 `
 
 type Config struct {
 	Thresholds   patterns.Thresholds
 	ExcludeFiles []string
-	Webhook      WebhookConfig
-	AI           AIConfig
-	Strategies   StrategyConfig
+	// GeneratedFiles controls the filter that keeps machine-generated files out of commit sizes
+	GeneratedFiles GeneratedFilesConfig
+	// IgnoreAuthors lists author name/email globs whose commits are skipped (e.g. bots)
+	IgnoreAuthors []string
+	// AuthorIdentity controls how author emails are grouped into identities
+	AuthorIdentity git.IdentityRules
+	// Web holds website analysis settings
+	Web WebConfig
+	// DependencyManifests lists manifest filenames (or globs) checked for mass dependency additions
+	DependencyManifests []string
+	// AIAssistants lists assistant names matched in commit attribution trailers
+	AIAssistants []string
+	// LanguageProfiles adds or overrides per-extension language profiles
+	LanguageProfiles map[string]patterns.LanguageProfile
+	NGramRepetition  NGramRepetitionConfig
+	Churn            ChurnConfig
+	Baseline         BaselineConfig
+	// MultiSource weights the git and web scores of a combined report
+	MultiSource    MultiSourceConfig
+	Classification ClassificationConfig
+	// AssessmentLabels rewords assessments on output (nil keeps the defaults)
+	AssessmentLabels analysis.AssessmentLabels
+	Analysis         AnalysisConfig
+	RateLimit        RateLimitConfig
+	Cache            CacheConfig
+	Logging          LoggingConfig
+	Report           ReportConfig
+	Webhook          WebhookConfig
+	AI               AIConfig
+	Strategies       StrategyConfig
+
+	// ThresholdPreset names the preset applied to Thresholds (see
+	// ThresholdPresets), or is empty when none was selected
+	ThresholdPreset string
+	// explicitThresholds holds the thresholds.* keys set in the config
+	// file, which a preset does not override
+	explicitThresholds map[string]bool
+}
+
+// WebConfig holds settings for website analysis
+type WebConfig struct {
+	MinWordCount   int    // fewest words a page needs to be analyzed
+	VocabularyFile string // phrase lists for the vocabulary strategies
+	Proxy          string // outbound proxy URL ("" = the environment's, see UseEnvProxy)
+	// UseEnvProxy, without Proxy, sets whether HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+	// are honored. nil keeps the fetcher default: on, but off on the webhook
+	// server, whose private-address guard cannot check what a proxy dials
+	UseEnvProxy   *bool
+	CAFile        string // PEM CA bundle trusted in addition to the system roots
+	MaxRedirects  int    // redirects followed per page (0 = 10, negative = none)
+	UserAgent     string // User-Agent of page fetches ("" = web.DefaultUserAgent)
+	RespectRobots bool   // skip pages the site's robots.txt disallows
+
+	// Vocabulary is VocabularyFile's contents, or nil when it is unset or
+	// could not be read
+	Vocabulary *webpatterns.Vocabulary
+	// ProxyURL and TLSConfig are Proxy and CAFile parsed, or nil when unset
+	ProxyURL  *url.URL
+	TLSConfig *tls.Config
+	// RobotsCache is shared by every fetcher built from FetcherOptions when
+	// RespectRobots is set, so robots.txt is fetched once per host
+	RobotsCache *web.RobotsCache
+}
+
+// FetcherOptions returns the page fetcher options for the proxy, CA bundle,
+// redirect limit, user agent and robots.txt handling.
+func (c *WebConfig) FetcherOptions() []web.FetcherOption {
+	opts := []web.FetcherOption{
+		web.WithProxy(c.ProxyURL),
+		web.WithTLSConfig(c.TLSConfig),
+		web.WithMaxRedirects(c.MaxRedirects),
+		web.WithUserAgent(c.UserAgent),
+	}
+	if c.UseEnvProxy != nil {
+		opts = append(opts, web.WithEnvProxy(*c.UseEnvProxy))
+	}
+	if c.RespectRobots {
+		if c.RobotsCache != nil {
+			opts = append(opts, web.WithRobotsCache(c.RobotsCache))
+		} else {
+			opts = append(opts, web.WithRespectRobots(true))
+		}
+	}
+	return opts
+}
+
+// NGramRepetitionConfig holds the repeated-phrase coverage thresholds of the
+// n-gram repetition strategies (0 = built-in default)
+type NGramRepetitionConfig struct {
+	WebMaxCoverage float64
+	GitMaxCoverage float64
+}
+
+// ChurnConfig holds the thresholds of code_churn_analysis (0 = built-in
+// default)
+type ChurnConfig struct {
+	MinAdditions   int64   // lines a commit must add to one file
+	MinRevertRatio float64 // share of them later commits must delete (0-1)
+	MaxCommits     int     // within this many commits
+	MaxHours       float64 // and this many hours
+}
+
+// Options returns the churn thresholds as strategy options.
+func (c ChurnConfig) Options() patterns.ChurnOptions {
+	return patterns.ChurnOptions{
+		MinAdditions:   c.MinAdditions,
+		MinRevertRatio: c.MinRevertRatio,
+		MaxCommits:     c.MaxCommits,
+		Window:         time.Duration(c.MaxHours * float64(time.Hour)),
+	}
+}
+
+// BaselineConfig holds the baseline sample sizes StatisticalAnomaly needs
+// (0 = built-in default)
+type BaselineConfig struct {
+	MinCommits      int // fewest commits anomalies are computed from
+	ReliableCommits int // fewest commits whose anomalies are trusted
+}
+
+// Gate returns the sample sizes as an analysis.SampleSizeGate.
+func (c BaselineConfig) Gate() analysis.SampleSizeGate {
+	return analysis.SampleSizeGate{MinCommits: c.MinCommits, ReliableCommits: c.ReliableCommits}
+}
+
+// MultiSourceConfig holds the weight of each source's overall score in a
+// combined git and web report
+type MultiSourceConfig struct {
+	GitWeight float64
+	WebWeight float64
+}
+
+// Weights returns the weights in the form analysis.MergeReports takes.
+func (c MultiSourceConfig) Weights() analysis.SourceWeights {
+	return analysis.SourceWeights{
+		analysis.SourceTypeGit: c.GitWeight,
+		analysis.SourceTypeWeb: c.WebWeight,
+	}
+}
+
+// GeneratedFilesConfig holds the generated-file filter applied on top of
+// ExcludeFiles
+type GeneratedFilesConfig struct {
+	Enabled  bool
+	Patterns []string // added to the built-in generated file patterns
+}
+
+// ClassificationConfig holds the suspicion-rate cutoffs that map a report to
+// an assessment label
+type ClassificationConfig struct {
+	High   float64 // rate at or above which a source is "Likely AI-Generated"
+	Medium float64 // rate at or above which a source is "Suspicious Activity"
+}
+
+// AnalysisConfig bounds how much of a repository is loaded for analysis
+type AnalysisConfig struct {
+	MaxCommits   int   // newest commits analyzed (0 = no limit)
+	MaxDiffBytes int64 // diff content held in memory across all commits (0 = no limit)
+	DiffWorkers  int   // commit diffs computed in parallel (0 = GOMAXPROCS, 1 = serial)
+	// MergeStrategy controls whether merge commits are analyzed against
+	// their first parent
+	MergeStrategy git.MergeStrategy
+	// TimeoutSeconds bounds one server-side analysis, excluding the clone
+	TimeoutSeconds int
+	// MinReportConfidence hides detections from strategies whose registry
+	// confidence is below it; they still count in the report's totals and score
+	MinReportConfidence float64
+	// IncludePassed adds a passed record to git reports for every strategy
+	// that ran without firing
+	IncludePassed bool
+}
+
+// RateLimitConfig holds the per-IP token bucket for the public analysis API
+type RateLimitConfig struct {
+	RequestsPerMinute int // tokens regained per minute (0 = unlimited)
+	Burst             int // requests allowed at once
+}
+
+// CacheConfig holds the webhook server's analysis result cache
+type CacheConfig struct {
+	Enabled    bool
+	MaxEntries int
+	TTLSeconds int
+}
+
+// LoggingConfig holds the level and format of log output
+type LoggingConfig struct {
+	Level  string            // debug, info, warn or error
+	Format logging.LogFormat // text or json
+}
+
+// Logger returns a logger writing to stderr at the configured level and
+// format.
+func (c LoggingConfig) Logger() *logging.Logger {
+	return logging.New(&logging.Config{Level: c.Level, Format: c.Format})
+}
+
+// ReportConfig limits and redacts the detection examples in report output
+type ReportConfig struct {
+	MaxExamples int  // examples kept per detection (0 = all)
+	Redact      bool // mask secrets and email addresses in examples
+}
+
+// ExamplePolicy returns the policy applied to detection examples on output.
+func (c ReportConfig) ExamplePolicy() analysis.ExamplePolicy {
+	return analysis.ExamplePolicy{MaxExamples: c.MaxExamples, Redact: c.Redact}
 }
 
 // WebhookConfig holds webhook server configuration
 type WebhookConfig struct {
-	Enabled      bool
-	Host         string
-	Port         int
-	Secret       string
+	Enabled bool
+	Host    string
+	Port    int
+	Secret  string
+	// APIToken authorizes POST /api/feedback alongside the webhook secret.
+	APIToken     string
 	MaxWorkers   int
 	ReadTimeout  int
 	WriteTimeout int
+	RecordEvents bool
+	// StreamResumeGrace is how many seconds a finished stream stays resumable.
+	StreamResumeGrace int
+	// CompressResponses compresses responses, except SSE streams, for
+	// clients that accept it.
+	CompressResponses bool
+	// StripTrackingParams removes tracking query params from URL cache/dedup keys.
+	StripTrackingParams bool
+	// CloneTimeout is the repository clone timeout in seconds (0 = 120).
+	CloneTimeout int
+	// CloneDepth limits cloned history to this many commits (0 = full history).
+	CloneDepth int
+	// CloneSingleBranch fetches only the default branch when no branch is requested.
+	CloneSingleBranch bool
+	// MaxConcurrentClones bounds simultaneous clones (0 = MaxWorkers).
+	MaxConcurrentClones int
+	// RepoCacheDir keeps clones between analyses ("" = temporary clones).
+	RepoCacheDir string
+	// RepoCacheMaxMB bounds the repository cache on disk (0 = no limit).
+	RepoCacheMaxMB int
+	// CallbackMaxAttempts caps deliveries of a job result callback.
+	CallbackMaxAttempts int
+	// JobStore is "memory" or "sqlite"; JobStorePath is the SQLite database file.
+	JobStore     string
+	JobStorePath string
+	// AllowPrivateHosts disables the SSRF guard on submitted URLs.
+	AllowPrivateHosts bool
+	// ReadyMaxQueueDepth is the queue depth at which readiness fails (0 = capacity).
+	ReadyMaxQueueDepth int
+	// ShutdownTimeout is how many seconds running jobs get to finish on shutdown.
+	ShutdownTimeout int
+	// PluginManifest is the path of the strategy plugin manifest ("" = none).
+	PluginManifest string
+	// WASMPluginDir is the directory of .wasm strategy plugins ("" = none).
+	WASMPluginDir string
+	// WASMPluginTimeout is the per-call WASM plugin timeout in seconds.
+	WASMPluginTimeout int
+	// WASMPluginMemoryMB caps each WASM plugin instance's memory.
+	WASMPluginMemoryMB int
+	// Branches limits which pushed branches the push webhooks analyze.
+	Branches BranchFilterConfig
+	// AllowedGitHosts limits the hosts repositories are cloned from (empty = all).
+	AllowedGitHosts []string
+	// AllowLocalPaths accepts local_path in analysis requests, for checkouts
+	// under LocalPathRoot.
+	AllowLocalPaths bool
+	LocalPathRoot   string
+	// DedupJobs reuses a pending or running job for an identical submission.
+	DedupJobs bool
+}
+
+// BranchFilterConfig holds glob patterns matched against pushed branch
+// names. Empty Include allows every branch not matched by Exclude.
+type BranchFilterConfig struct {
+	Include []string
+	Exclude []string
 }
 
 // AIConfig holds AI analysis configuration
 type AIConfig struct {
-	Enabled  bool
-	Provider string
-	APIKey   string
-	Model    string
+	Enabled          bool
+	Provider         string
+	APIKey           string
+	Model            string
+	BaseURL          string
+	MaxRetries       int // retries for rate limits, timeouts and 5xx (0 = default, negative disables)
+	RetryBaseDelayMs int // first backoff delay in milliseconds (0 = default)
+	// BlendWeight is the share of the overall score taken from the AI
+	// verdicts (0-1); the rest is the heuristic score.
+	BlendWeight float64
+	// TriageLimit caps how many high-severity suspicions the webhook server
+	// triages per analysis (negative disables triage).
+	TriageLimit int
+	// Pricing maps a model name (or "provider/model") to its price per
+	// 1000 tokens, for estimating AI spend.
+	Pricing map[string]analysis.TokenPrice
 }
 
 // StrategyConfig controls which detection strategies are active.
@@ -155,20 +748,121 @@ func (sc *StrategyConfig) IsEnabled(name string) bool {
 	return !disabled
 }
 
+// Disabled returns the names of the disabled strategies, sorted.
+func (sc *StrategyConfig) Disabled() []string {
+	names := make([]string, 0, len(sc.DisabledStrategies))
+	for name, disabled := range sc.DisabledStrategies {
+		if disabled {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Fingerprint returns a stable hash of the settings that change analysis
+// results: thresholds, disabled strategies, file and author filters, pattern
+// lists, classification cutoffs and history limits. Server, cache and
+// credential settings are left out. Cached reports keyed by the fingerprint
+// are therefore never served once any of those settings change.
+func (c *Config) Fingerprint() string {
+	data, err := json.Marshal(struct {
+		Thresholds          patterns.Thresholds
+		DisabledStrategies  []string
+		ExcludeFiles        []string
+		GeneratedFiles      GeneratedFilesConfig
+		IgnoreAuthors       []string
+		AuthorIdentity      git.IdentityRules
+		WebMinWordCount     int
+		WebVocabulary       *webpatterns.Vocabulary
+		DependencyManifests []string
+		AIAssistants        []string
+		LanguageProfiles    map[string]patterns.LanguageProfile
+		NGramRepetition     NGramRepetitionConfig
+		Churn               ChurnConfig
+		Baseline            BaselineConfig
+		Classification      ClassificationConfig
+		MaxCommits          int
+		MaxDiffBytes        int64
+		MergeStrategy       git.MergeStrategy
+		MinReportConfidence float64
+		IncludePassed       bool
+		AIEnabled           bool
+		AIProvider          string
+		AIModel             string
+		AIBlendWeight       float64
+	}{
+		Thresholds:          c.Thresholds,
+		DisabledStrategies:  c.Strategies.Disabled(),
+		ExcludeFiles:        c.ExcludeFiles,
+		GeneratedFiles:      c.GeneratedFiles,
+		IgnoreAuthors:       c.IgnoreAuthors,
+		AuthorIdentity:      c.AuthorIdentity,
+		WebMinWordCount:     c.Web.MinWordCount,
+		WebVocabulary:       c.Web.Vocabulary,
+		DependencyManifests: c.DependencyManifests,
+		AIAssistants:        c.AIAssistants,
+		LanguageProfiles:    c.LanguageProfiles,
+		NGramRepetition:     c.NGramRepetition,
+		Churn:               c.Churn,
+		Baseline:            c.Baseline,
+		Classification:      c.Classification,
+		MaxCommits:          c.Analysis.MaxCommits,
+		MaxDiffBytes:        c.Analysis.MaxDiffBytes,
+		MergeStrategy:       c.Analysis.MergeStrategy,
+		MinReportConfidence: c.Analysis.MinReportConfidence,
+		IncludePassed:       c.Analysis.IncludePassed,
+		AIEnabled:           c.AI.Enabled,
+		AIProvider:          c.AI.Provider,
+		AIModel:             c.AI.Model,
+		AIBlendWeight:       c.AI.BlendWeight,
+	})
+	if err != nil {
+		// Every field is plain data; fall back to a unique value rather than
+		// sharing a fingerprint with a different configuration.
+		data = []byte(fmt.Sprintf("%p", c))
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:12])
+}
+
 func Load(configFile string) (*Config, error) {
 	v := viper.New()
 
-	// Set defaults
-	v.SetDefault("thresholds.suspicious_additions", 500)
-	v.SetDefault("thresholds.suspicious_deletions", 1000)
-	v.SetDefault("thresholds.max_additions_per_min", 100)
-	v.SetDefault("thresholds.max_deletions_per_min", 500)
-	v.SetDefault("thresholds.min_time_delta_seconds", 60)
-	v.SetDefault("thresholds.max_files_per_commit", 50)
-	v.SetDefault("thresholds.max_addition_ratio", 0.95)
-	v.SetDefault("thresholds.min_deletion_ratio", 0.95)
-	v.SetDefault("thresholds.min_commit_size_ratio", 100)
-	v.SetDefault("thresholds.enable_precision_analysis", true)
+	// Set defaults (the thresholds' are set once the explicit ones are known)
+	v.SetDefault("classification.high", 0.7)
+	v.SetDefault("classification.medium", 0.4)
+	v.SetDefault("generated_file_filter.enabled", true)
+	v.SetDefault("author_identity.lowercase", git.DefaultIdentityRules.Lowercase)
+	v.SetDefault("author_identity.strip_plus_tag", git.DefaultIdentityRules.StripPlusTag)
+	v.SetDefault("author_identity.map_noreply", git.DefaultIdentityRules.MapNoreply)
+	v.SetDefault("author_identity.match_names", git.DefaultIdentityRules.MatchNames)
+	v.SetDefault("web.min_word_count", patterns.DefaultMinWordCount)
+	v.SetDefault("multi_source.git_weight", analysis.DefaultSourceWeight)
+	v.SetDefault("multi_source.web_weight", analysis.DefaultSourceWeight)
+	v.SetDefault("ai.blend_weight", analysis.DefaultAIBlendWeight)
+	v.SetDefault("ai.triage_limit", 5)
+	v.SetDefault("analysis.max_commits", 1000)
+	v.SetDefault("analysis.merge_strategy", string(git.MergeSkip))
+	v.SetDefault("analysis.timeout_seconds", 300)
+	v.SetDefault("analysis.min_report_confidence", 0.0)
+	v.SetDefault("ratelimit.requests_per_minute", 30)
+	v.SetDefault("ratelimit.burst", 10)
+	v.SetDefault("cache.enabled", true)
+	v.SetDefault("cache.max_entries", 256)
+	v.SetDefault("cache.ttl_seconds", 900)
+	v.SetDefault("logging.level", "info")
+	v.SetDefault("logging.format", "text")
+	v.SetDefault("webhook.strip_tracking_params", true)
+	v.SetDefault("webhook.stream_resume_grace", 120)
+	v.SetDefault("webhook.compress_responses", true)
+	v.SetDefault("webhook.dedup_jobs", true)
+	v.SetDefault("webhook.repo_cache_max_mb", 10240)
+	v.SetDefault("webhook.callback_max_attempts", 3)
+	v.SetDefault("webhook.job_store", "memory")
+	v.SetDefault("webhook.job_store_path", "cadence-jobs.db")
+	v.SetDefault("webhook.wasm_plugin_timeout", int(analysis.DefaultWASMTimeout.Seconds()))
+	v.SetDefault("webhook.wasm_plugin_memory_mb", analysis.DefaultWASMMemoryLimitMB)
 
 	if configFile != "" {
 		v.SetConfigFile(configFile)
@@ -182,6 +876,25 @@ func Load(configFile string) (*Config, error) {
 
 	config := &Config{}
 
+	// IsSet is true for keys with a default too, so record the thresholds
+	// set in the file, environment or flags before registering defaults.
+	config.explicitThresholds = make(map[string]bool)
+	for _, k := range thresholdKeys {
+		if v.IsSet("thresholds." + k.key) {
+			config.explicitThresholds[k.key] = true
+		}
+	}
+	v.SetDefault("thresholds.suspicious_additions", 500)
+	v.SetDefault("thresholds.suspicious_deletions", 1000)
+	v.SetDefault("thresholds.max_additions_per_min", 100)
+	v.SetDefault("thresholds.max_deletions_per_min", 500)
+	v.SetDefault("thresholds.min_time_delta_seconds", 60)
+	v.SetDefault("thresholds.max_files_per_commit", 50)
+	v.SetDefault("thresholds.max_addition_ratio", 0.95)
+	v.SetDefault("thresholds.min_deletion_ratio", 0.95)
+	v.SetDefault("thresholds.min_commit_size_ratio", 100)
+	v.SetDefault("thresholds.enable_precision_analysis", true)
+
 	config.Thresholds.SuspiciousAdditions = v.GetInt64("thresholds.suspicious_additions")
 	config.Thresholds.SuspiciousDeletions = v.GetInt64("thresholds.suspicious_deletions")
 	config.Thresholds.MaxAdditionsPerMin = v.GetFloat64("thresholds.max_additions_per_min")
@@ -192,8 +905,126 @@ func Load(configFile string) (*Config, error) {
 	config.Thresholds.MinDeletionRatio = v.GetFloat64("thresholds.min_deletion_ratio")
 	config.Thresholds.MinCommitSizeRatio = v.GetInt64("thresholds.min_commit_size_ratio")
 	config.Thresholds.EnablePrecisionAnalysis = v.GetBool("thresholds.enable_precision_analysis")
+	if preset := v.GetString("thresholds.preset"); preset != "" {
+		if err := config.ApplyThresholdPreset(preset); err != nil {
+			return nil, fmt.Errorf("invalid thresholds.preset: %w", err)
+		}
+	}
 
 	config.ExcludeFiles = v.GetStringSlice("exclude_files")
+	config.GeneratedFiles.Enabled = v.GetBool("generated_file_filter.enabled")
+	config.GeneratedFiles.Patterns = v.GetStringSlice("generated_file_filter.patterns")
+	config.IgnoreAuthors = v.GetStringSlice("ignore_authors")
+	config.AuthorIdentity.Lowercase = v.GetBool("author_identity.lowercase")
+	config.AuthorIdentity.StripPlusTag = v.GetBool("author_identity.strip_plus_tag")
+	config.AuthorIdentity.MapNoreply = v.GetBool("author_identity.map_noreply")
+	config.AuthorIdentity.MatchNames = v.GetBool("author_identity.match_names")
+	config.DependencyManifests = v.GetStringSlice("dependency_manifests")
+	config.AIAssistants = v.GetStringSlice("ai_assistants")
+	config.LanguageProfiles = loadLanguageProfiles(v)
+	config.Web.MinWordCount = v.GetInt("web.min_word_count")
+	config.Web.VocabularyFile = v.GetString("web.vocabulary_file")
+	config.Web.Proxy = v.GetString("web.proxy")
+	if config.Web.Proxy != "" {
+		proxyURL, err := url.Parse(config.Web.Proxy)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid web.proxy %q: want a URL such as http://proxy.example.com:3128", config.Web.Proxy)
+		}
+		config.Web.ProxyURL = proxyURL
+	}
+	if v.IsSet("web.use_env_proxy") {
+		useEnvProxy := v.GetBool("web.use_env_proxy")
+		config.Web.UseEnvProxy = &useEnvProxy
+	}
+	config.Web.CAFile = v.GetString("web.ca_file")
+	if config.Web.CAFile != "" {
+		tlsConfig, err := web.TLSConfigFromCAFile(config.Web.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid web.ca_file: %w", err)
+		}
+		config.Web.TLSConfig = tlsConfig
+	}
+	config.Web.MaxRedirects = v.GetInt("web.max_redirects")
+	config.Web.UserAgent = v.GetString("web.user_agent")
+	config.Web.RespectRobots = v.GetBool("web.respect_robots")
+	if config.Web.RespectRobots {
+		config.Web.RobotsCache = web.NewRobotsCache(web.DefaultRobotsTTL)
+	}
+	if config.Web.VocabularyFile != "" {
+		vocab, err := webpatterns.ReadVocabulary(config.Web.VocabularyFile)
+		if err != nil {
+			logging.Default().Warn("ignoring web.vocabulary_file, using the built-in vocabulary", "error", err)
+		} else {
+			config.Web.Vocabulary = vocab
+		}
+	}
+	config.NGramRepetition.WebMaxCoverage = v.GetFloat64("ngram_repetition.web_max_coverage")
+	config.NGramRepetition.GitMaxCoverage = v.GetFloat64("ngram_repetition.git_max_coverage")
+	config.Churn.MinAdditions = v.GetInt64("churn.min_additions")
+	config.Churn.MinRevertRatio = v.GetFloat64("churn.min_revert_ratio")
+	config.Churn.MaxCommits = v.GetInt("churn.max_commits")
+	config.Churn.MaxHours = v.GetFloat64("churn.max_hours")
+	if config.Churn.MinRevertRatio < 0 || config.Churn.MinRevertRatio > 1 {
+		return nil, fmt.Errorf("churn.min_revert_ratio must be between 0 and 1, got %v", config.Churn.MinRevertRatio)
+	}
+	config.Baseline.MinCommits = v.GetInt("baseline.min_commits")
+	config.Baseline.ReliableCommits = v.GetInt("baseline.reliable_commits")
+	if b := config.Baseline; b.MinCommits < 0 || b.ReliableCommits < 0 {
+		return nil, fmt.Errorf("invalid baseline sample sizes (min_commits %d, reliable_commits %d): must not be negative", b.MinCommits, b.ReliableCommits)
+	}
+	if b := config.Baseline; b.MinCommits > 0 && b.ReliableCommits > 0 && b.ReliableCommits < b.MinCommits {
+		return nil, fmt.Errorf("invalid baseline.reliable_commits %d: must not be less than baseline.min_commits %d", b.ReliableCommits, b.MinCommits)
+	}
+	config.MultiSource.GitWeight = v.GetFloat64("multi_source.git_weight")
+	config.MultiSource.WebWeight = v.GetFloat64("multi_source.web_weight")
+	if w := config.MultiSource; w.GitWeight < 0 || w.WebWeight < 0 || w.GitWeight+w.WebWeight == 0 {
+		return nil, fmt.Errorf("invalid multi_source weights (git %v, web %v): must not be negative or both zero", w.GitWeight, w.WebWeight)
+	}
+
+	config.Classification.High = v.GetFloat64("classification.high")
+	config.Classification.Medium = v.GetFloat64("classification.medium")
+	if c := config.Classification; c.Medium > c.High {
+		return nil, fmt.Errorf("invalid classification.medium %v: must not be greater than classification.high %v", c.Medium, c.High)
+	}
+	labels, err := analysis.ParseAssessmentLabels(v.GetStringMapString("assessment_labels"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid assessment_labels: %w", err)
+	}
+	config.AssessmentLabels = labels
+
+	config.Analysis.MaxCommits = v.GetInt("analysis.max_commits")
+	config.Analysis.MaxDiffBytes = v.GetInt64("analysis.max_diff_bytes")
+	config.Analysis.DiffWorkers = v.GetInt("analysis.diff_workers")
+	mergeStrategy, err := git.ParseMergeStrategy(v.GetString("analysis.merge_strategy"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid analysis.merge_strategy: %w", err)
+	}
+	config.Analysis.MergeStrategy = mergeStrategy
+	config.Analysis.TimeoutSeconds = v.GetInt("analysis.timeout_seconds")
+	config.Analysis.MinReportConfidence = v.GetFloat64("analysis.min_report_confidence")
+	if c := config.Analysis.MinReportConfidence; c < 0 || c > 1 {
+		return nil, fmt.Errorf("invalid analysis.min_report_confidence %v: must be between 0 and 1", c)
+	}
+	config.Analysis.IncludePassed = v.GetBool("analysis.include_passed")
+
+	config.RateLimit.RequestsPerMinute = v.GetInt("ratelimit.requests_per_minute")
+	config.RateLimit.Burst = v.GetInt("ratelimit.burst")
+
+	config.Cache.Enabled = v.GetBool("cache.enabled")
+	config.Cache.MaxEntries = v.GetInt("cache.max_entries")
+	config.Cache.TTLSeconds = v.GetInt("cache.ttl_seconds")
+
+	config.Logging.Level = strings.ToLower(v.GetString("logging.level"))
+	config.Logging.Format = logging.LogFormat(strings.ToLower(v.GetString("logging.format")))
+	if err := (&logging.Config{Level: config.Logging.Level, Format: config.Logging.Format}).Validate(); err != nil {
+		return nil, fmt.Errorf("invalid logging config: %w", err)
+	}
+
+	config.Report.MaxExamples = v.GetInt("report.max_examples")
+	if config.Report.MaxExamples < 0 {
+		return nil, fmt.Errorf("invalid report.max_examples %d: must not be negative", config.Report.MaxExamples)
+	}
+	config.Report.Redact = v.GetBool("report.redact")
 
 	// Load webhook configuration
 	config.Webhook.Enabled = v.GetBool("webhook.enabled")
@@ -206,6 +1037,7 @@ func Load(configFile string) (*Config, error) {
 		config.Webhook.Port = 8000
 	}
 	config.Webhook.Secret = v.GetString("webhook.secret")
+	config.Webhook.APIToken = v.GetString("webhook.api_token")
 	config.Webhook.MaxWorkers = v.GetInt("webhook.max_workers")
 	if config.Webhook.MaxWorkers == 0 {
 		config.Webhook.MaxWorkers = 4
@@ -218,29 +1050,69 @@ func Load(configFile string) (*Config, error) {
 	if config.Webhook.WriteTimeout == 0 {
 		config.Webhook.WriteTimeout = 30
 	}
+	config.Webhook.RecordEvents = v.GetBool("webhook.record_events")
+	config.Webhook.StreamResumeGrace = v.GetInt("webhook.stream_resume_grace")
+	config.Webhook.CompressResponses = v.GetBool("webhook.compress_responses")
+	config.Webhook.StripTrackingParams = v.GetBool("webhook.strip_tracking_params")
+	config.Webhook.CloneTimeout = v.GetInt("webhook.clone_timeout")
+	config.Webhook.CloneDepth = v.GetInt("webhook.clone_depth")
+	config.Webhook.CloneSingleBranch = v.GetBool("webhook.clone_single_branch")
+	config.Webhook.RepoCacheDir = v.GetString("webhook.repo_cache_dir")
+	config.Webhook.RepoCacheMaxMB = v.GetInt("webhook.repo_cache_max_mb")
+	config.Webhook.MaxConcurrentClones = v.GetInt("webhook.max_concurrent_clones")
+	if config.Webhook.MaxConcurrentClones < 0 {
+		return nil, fmt.Errorf("invalid webhook.max_concurrent_clones %d: must not be negative", config.Webhook.MaxConcurrentClones)
+	}
+	config.Webhook.CallbackMaxAttempts = v.GetInt("webhook.callback_max_attempts")
+	config.Webhook.JobStore = v.GetString("webhook.job_store")
+	config.Webhook.JobStorePath = v.GetString("webhook.job_store_path")
+	config.Webhook.AllowPrivateHosts = v.GetBool("webhook.allow_private_hosts")
+	config.Webhook.ReadyMaxQueueDepth = v.GetInt("webhook.ready_max_queue_depth")
+	config.Webhook.ShutdownTimeout = v.GetInt("webhook.shutdown_timeout")
+	config.Webhook.PluginManifest = v.GetString("webhook.plugin_manifest")
+	config.Webhook.WASMPluginDir = v.GetString("webhook.wasm_plugin_dir")
+	config.Webhook.WASMPluginTimeout = v.GetInt("webhook.wasm_plugin_timeout")
+	config.Webhook.WASMPluginMemoryMB = v.GetInt("webhook.wasm_plugin_memory_mb")
+	config.Webhook.Branches.Include = v.GetStringSlice("webhook.branches.include")
+	config.Webhook.Branches.Exclude = v.GetStringSlice("webhook.branches.exclude")
+	config.Webhook.AllowedGitHosts = v.GetStringSlice("webhook.allowed_git_hosts")
+	config.Webhook.AllowLocalPaths = v.GetBool("webhook.allow_local_paths")
+	config.Webhook.LocalPathRoot = v.GetString("webhook.local_path_root")
+	if config.Webhook.AllowLocalPaths && config.Webhook.LocalPathRoot == "" {
+		return nil, fmt.Errorf("invalid webhook.allow_local_paths: webhook.local_path_root must be set")
+	}
+	config.Webhook.DedupJobs = v.GetBool("webhook.dedup_jobs")
+	if config.Webhook.ShutdownTimeout <= 0 {
+		config.Webhook.ShutdownTimeout = 30
+	}
 
 	// Load AI configuration
 	config.AI.Enabled = v.GetBool("ai.enabled")
 	config.AI.Provider = v.GetString("ai.provider")
 	config.AI.APIKey = v.GetString("ai.api_key")
 	config.AI.Model = v.GetString("ai.model")
+	config.AI.BaseURL = v.GetString("ai.base_url")
+	config.AI.MaxRetries = v.GetInt("ai.max_retries")
+	config.AI.RetryBaseDelayMs = v.GetInt("ai.retry_base_delay_ms")
+	config.AI.BlendWeight = v.GetFloat64("ai.blend_weight")
+	config.AI.TriageLimit = v.GetInt("ai.triage_limit")
+	pricing, err := loadAIPricing(v)
+	if err != nil {
+		return nil, err
+	}
+	config.AI.Pricing = pricing
 	// Model defaults are handled by the provider — leave empty to use provider default
 
 	// Load strategy configuration
 	config.Strategies.DisabledStrategies = make(map[string]bool)
-	strategyNames := []string{
-		"commit_message_analysis",
-		"naming_pattern_analysis",
-		"structural_consistency",
-		"burst_pattern",
-		"error_handling_pattern",
-		"template_pattern",
-		"file_extension_pattern",
-		"statistical_anomaly",
-		"timing_anomaly",
-	}
-	for _, name := range strategyNames {
-		key := "strategies." + name
+	for _, info := range analysis.DefaultGitRegistry().All() {
+		key := "strategies." + info.Name
+		if v.IsSet(key) && !v.GetBool(key) {
+			config.Strategies.DisabledStrategies[info.Name] = true
+		}
+	}
+	for legacy, name := range legacyStrategyKeys {
+		key := "strategies." + legacy
 		if v.IsSet(key) && !v.GetBool(key) {
 			config.Strategies.DisabledStrategies[name] = true
 		}
@@ -249,6 +1121,73 @@ func Load(configFile string) (*Config, error) {
 	return config, nil
 }
 
+// legacyStrategyKeys maps strategies.* keys from older sample configs to the
+// registered strategy names they meant.
+var legacyStrategyKeys = map[string]string{
+	"structural_consistency": "structural_consistency_analysis",
+	"burst_pattern":          "burst_pattern_analysis",
+	"error_handling_pattern": "error_handling_analysis",
+	"template_pattern":       "template_pattern_analysis",
+	"file_extension_pattern": "file_extension_analysis",
+	"statistical_anomaly":    "StatisticalAnomaly",
+	"timing_anomaly":         "TimingAnomaly",
+}
+
+// loadLanguageProfiles reads language_profiles entries. Extensions are keyed
+// without the dot because viper treats dots as key separators.
+func loadLanguageProfiles(v *viper.Viper) map[string]patterns.LanguageProfile {
+	raw := v.GetStringMap("language_profiles")
+	if len(raw) == 0 {
+		return nil
+	}
+
+	profiles := make(map[string]patterns.LanguageProfile, len(raw))
+	for ext := range raw {
+		key := "language_profiles." + ext
+		name := v.GetString(key + ".name")
+		if name == "" {
+			name = ext
+		}
+		markers := v.GetStringSlice(key + ".error_markers")
+		for i, m := range markers {
+			markers[i] = strings.ToLower(m)
+		}
+		profiles["."+ext] = patterns.LanguageProfile{
+			Name:                 name,
+			ErrorMarkers:         markers,
+			LinesPerErrorMarker:  v.GetInt(key + ".lines_per_error_marker"),
+			MaxErrorMarkerRatio:  v.GetFloat64(key + ".max_error_marker_ratio"),
+			CamelCaseIdentifiers: v.GetBool(key + ".camel_case_identifiers"),
+		}
+	}
+	return profiles
+}
+
+// loadAIPricing reads the ai.pricing list. It is a list rather than a map
+// because model names contain dots, which viper treats as key separators.
+func loadAIPricing(v *viper.Viper) (map[string]analysis.TokenPrice, error) {
+	var entries []struct {
+		Model           string  `mapstructure:"model"`
+		PromptPer1K     float64 `mapstructure:"prompt_per_1k"`
+		CompletionPer1K float64 `mapstructure:"completion_per_1k"`
+	}
+	if err := v.UnmarshalKey("ai.pricing", &entries); err != nil {
+		return nil, fmt.Errorf("invalid ai.pricing: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	pricing := make(map[string]analysis.TokenPrice, len(entries))
+	for _, e := range entries {
+		if e.Model == "" {
+			return nil, fmt.Errorf("invalid ai.pricing: every entry needs a model")
+		}
+		pricing[e.Model] = analysis.TokenPrice{PromptPer1K: e.PromptPer1K, CompletionPer1K: e.CompletionPer1K}
+	}
+	return pricing, nil
+}
+
 func GenerateSampleConfig(path string) error {
 	return os.WriteFile(path, []byte(SampleConfigTemplate), 0o600)
 }
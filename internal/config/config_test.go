@@ -3,7 +3,16 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+	webpatterns "github.com/TryCadence/Cadence/internal/analysis/adapters/web/patterns"
+	"github.com/TryCadence/Cadence/internal/logging"
 )
 
 func TestLoad(t *testing.T) {
@@ -22,6 +31,63 @@ func TestLoad(t *testing.T) {
 		if config.Thresholds.SuspiciousDeletions != 1000 {
 			t.Errorf("Empty config should have default SuspiciousDeletions=1000, got %d", config.Thresholds.SuspiciousDeletions)
 		}
+		if config.Classification.High != 0.7 || config.Classification.Medium != 0.4 {
+			t.Errorf("Classification = %+v, want High=0.7 Medium=0.4", config.Classification)
+		}
+		if config.Analysis.MaxCommits != 1000 || config.Analysis.MaxDiffBytes != 0 || config.Analysis.TimeoutSeconds != 300 {
+			t.Errorf("Analysis = %+v, want MaxCommits=1000, no diff limit and a 300s timeout", config.Analysis)
+		}
+		if config.Analysis.MergeStrategy != git.MergeSkip {
+			t.Errorf("Analysis.MergeStrategy = %q, want skip", config.Analysis.MergeStrategy)
+		}
+		if config.Analysis.MinReportConfidence != 0 {
+			t.Errorf("Analysis.MinReportConfidence = %v, want 0 so every detection is reported", config.Analysis.MinReportConfidence)
+		}
+		if config.Analysis.IncludePassed {
+			t.Error("Analysis.IncludePassed should default to false")
+		}
+		if config.AuthorIdentity != git.DefaultIdentityRules {
+			t.Errorf("AuthorIdentity = %+v, want the default rules", config.AuthorIdentity)
+		}
+		if config.Web.MinWordCount != patterns.DefaultMinWordCount {
+			t.Errorf("Web.MinWordCount = %d, want %d", config.Web.MinWordCount, patterns.DefaultMinWordCount)
+		}
+		if config.MultiSource.GitWeight != 0.5 || config.MultiSource.WebWeight != 0.5 {
+			t.Errorf("MultiSource = %+v, want equal weights of 0.5", config.MultiSource)
+		}
+		if config.Webhook.StreamResumeGrace != 120 {
+			t.Errorf("Webhook.StreamResumeGrace = %d, want 120", config.Webhook.StreamResumeGrace)
+		}
+		if !config.Webhook.CompressResponses {
+			t.Error("Webhook.CompressResponses should default to true")
+		}
+		if !config.Webhook.DedupJobs {
+			t.Error("Webhook.DedupJobs should default to true")
+		}
+		if config.Webhook.CallbackMaxAttempts != 3 {
+			t.Errorf("Webhook.CallbackMaxAttempts = %d, want 3", config.Webhook.CallbackMaxAttempts)
+		}
+		if !config.Cache.Enabled || config.Cache.MaxEntries != 256 || config.Cache.TTLSeconds != 900 {
+			t.Errorf("Cache = %+v, want enabled with 256 entries for 900s", config.Cache)
+		}
+		if !config.GeneratedFiles.Enabled || len(config.GeneratedFiles.Patterns) != 0 {
+			t.Errorf("GeneratedFiles = %+v, want enabled with no extra patterns", config.GeneratedFiles)
+		}
+		if config.AI.BlendWeight != 0.5 || config.AI.TriageLimit != 5 || config.AI.Pricing != nil {
+			t.Errorf("AI = %+v, want BlendWeight=0.5 TriageLimit=5 and no pricing", config.AI)
+		}
+		if config.Webhook.ShutdownTimeout != 30 {
+			t.Errorf("Webhook.ShutdownTimeout = %d, want 30", config.Webhook.ShutdownTimeout)
+		}
+		if config.Webhook.WASMPluginTimeout != 5 || config.Webhook.WASMPluginMemoryMB != 128 {
+			t.Errorf("Webhook WASM plugin limits = %ds, %dMB, want 5s and 128MB", config.Webhook.WASMPluginTimeout, config.Webhook.WASMPluginMemoryMB)
+		}
+		if config.RateLimit.RequestsPerMinute != 30 || config.RateLimit.Burst != 10 {
+			t.Errorf("RateLimit = %+v, want 30 per minute with burst 10", config.RateLimit)
+		}
+		if config.Webhook.JobStore != "memory" || config.Webhook.JobStorePath != "cadence-jobs.db" {
+			t.Errorf("Webhook job store = %q at %q, want memory at cadence-jobs.db", config.Webhook.JobStore, config.Webhook.JobStorePath)
+		}
 	})
 
 	t.Run("load from yaml file", func(t *testing.T) {
@@ -37,6 +103,69 @@ func TestLoad(t *testing.T) {
 exclude_files:
   - "*.log"
   - "*.tmp"
+ignore_authors:
+  - "dependabot[bot]"
+classification:
+  high: 0.8
+analysis:
+  max_commits: 250
+  max_diff_bytes: 1048576
+  diff_workers: 1
+  merge_strategy: first-parent
+  min_report_confidence: 0.4
+  include_passed: true
+web:
+  min_word_count: 120
+author_identity:
+  map_noreply: false
+  match_names: false
+ngram_repetition:
+  web_max_coverage: 0.4
+churn:
+  min_additions: 200
+  max_hours: 24
+baseline:
+  reliable_commits: 50
+multi_source:
+  git_weight: 0.75
+webhook:
+  ready_max_queue_depth: 25
+  max_concurrent_clones: 2
+  repo_cache_dir: /var/cache/cadence/repos
+  stream_resume_grace: 30
+  compress_responses: false
+  plugin_manifest: /etc/cadence/plugins.yaml
+  wasm_plugin_dir: /etc/cadence/wasm
+  wasm_plugin_timeout: 2
+  branches:
+    include: ["main", "release/*"]
+  allowed_git_hosts: ["github.com", "*.git.example.com"]
+  allow_local_paths: true
+  local_path_root: /srv/checkouts
+cache:
+  enabled: false
+  ttl_seconds: 60
+report:
+  max_examples: 3
+  redact: true
+assessment_labels:
+  likely_ai: "Probablemente generado por IA"
+generated_file_filter:
+  patterns: ["*_gen.go"]
+ai:
+  blend_weight: 0.25
+  triage_limit: -1
+  pricing:
+    - model: gpt-4.1-mini
+      prompt_per_1k: 0.0004
+      completion_per_1k: 0.0016
+    - model: ollama/llama3.2
+language_profiles:
+  kt:
+    name: Kotlin
+    error_markers: ["runCatching", "catch"]
+    lines_per_error_marker: 40
+    camel_case_identifiers: true
 `
 		if err := os.WriteFile(configFile, []byte(yamlContent), 0o600); err != nil {
 			t.Fatalf("Failed to write test config file: %v", err)
@@ -71,6 +200,231 @@ exclude_files:
 		if len(config.ExcludeFiles) >= 2 && config.ExcludeFiles[1] != "*.tmp" {
 			t.Errorf("ExcludeFiles[1] = %s, want *.tmp", config.ExcludeFiles[1])
 		}
+		if len(config.IgnoreAuthors) != 1 || config.IgnoreAuthors[0] != "dependabot[bot]" {
+			t.Errorf("IgnoreAuthors = %v, want [dependabot[bot]]", config.IgnoreAuthors)
+		}
+		if config.Classification.High != 0.8 || config.Classification.Medium != 0.4 {
+			t.Errorf("Classification = %+v, want High=0.8 and default Medium=0.4", config.Classification)
+		}
+		if config.Analysis.MaxCommits != 250 || config.Analysis.MaxDiffBytes != 1048576 || config.Analysis.DiffWorkers != 1 {
+			t.Errorf("Analysis = %+v, want MaxCommits=250 MaxDiffBytes=1048576 DiffWorkers=1", config.Analysis)
+		}
+		if config.Analysis.MergeStrategy != git.MergeFirstParent {
+			t.Errorf("Analysis.MergeStrategy = %q, want first-parent", config.Analysis.MergeStrategy)
+		}
+		if config.Analysis.MinReportConfidence != 0.4 {
+			t.Errorf("Analysis.MinReportConfidence = %v, want 0.4", config.Analysis.MinReportConfidence)
+		}
+		if !config.Analysis.IncludePassed {
+			t.Error("Analysis.IncludePassed should be true")
+		}
+		if want := (git.IdentityRules{Lowercase: true, StripPlusTag: true}); config.AuthorIdentity != want {
+			t.Errorf("AuthorIdentity = %+v, want %+v", config.AuthorIdentity, want)
+		}
+		if config.Web.MinWordCount != 120 {
+			t.Errorf("Web.MinWordCount = %d, want 120", config.Web.MinWordCount)
+		}
+		if config.NGramRepetition.WebMaxCoverage != 0.4 || config.NGramRepetition.GitMaxCoverage != 0 {
+			t.Errorf("NGramRepetition = %+v, want WebMaxCoverage=0.4 and the default git coverage", config.NGramRepetition)
+		}
+		if want := (ChurnConfig{MinAdditions: 200, MaxHours: 24}); config.Churn != want {
+			t.Errorf("Churn = %+v, want %+v", config.Churn, want)
+		}
+		if opts := config.Churn.Options(); opts.Window != 24*time.Hour || opts.MinAdditions != 200 {
+			t.Errorf("Churn.Options() = %+v, want a 24h window and 200 additions", opts)
+		}
+		if gate := config.Baseline.Gate(); gate.MinCommits != 0 || gate.ReliableCommits != 50 {
+			t.Errorf("Baseline.Gate() = %+v, want the default minimum and 50 reliable commits", gate)
+		}
+		if want := (MultiSourceConfig{GitWeight: 0.75, WebWeight: 0.5}); config.MultiSource != want {
+			t.Errorf("MultiSource = %+v, want %+v", config.MultiSource, want)
+		}
+		if config.Cache.Enabled || config.Cache.MaxEntries != 256 || config.Cache.TTLSeconds != 60 {
+			t.Errorf("Cache = %+v, want disabled, default size, 60s TTL", config.Cache)
+		}
+		if !config.GeneratedFiles.Enabled || len(config.GeneratedFiles.Patterns) != 1 || config.GeneratedFiles.Patterns[0] != "*_gen.go" {
+			t.Errorf("GeneratedFiles = %+v, want enabled with *_gen.go", config.GeneratedFiles)
+		}
+		if config.AI.BlendWeight != 0.25 || config.AI.TriageLimit != -1 {
+			t.Errorf("AI = %+v, want BlendWeight=0.25 TriageLimit=-1", config.AI)
+		}
+		wantPricing := map[string]analysis.TokenPrice{
+			"gpt-4.1-mini":    {PromptPer1K: 0.0004, CompletionPer1K: 0.0016},
+			"ollama/llama3.2": {},
+		}
+		if !reflect.DeepEqual(config.AI.Pricing, wantPricing) {
+			t.Errorf("AI.Pricing = %+v, want %+v", config.AI.Pricing, wantPricing)
+		}
+		if config.Webhook.ReadyMaxQueueDepth != 25 {
+			t.Errorf("Webhook.ReadyMaxQueueDepth = %d, want 25", config.Webhook.ReadyMaxQueueDepth)
+		}
+		if config.Webhook.MaxConcurrentClones != 2 {
+			t.Errorf("Webhook.MaxConcurrentClones = %d, want 2", config.Webhook.MaxConcurrentClones)
+		}
+		if config.Webhook.RepoCacheDir != "/var/cache/cadence/repos" || config.Webhook.RepoCacheMaxMB != 10240 {
+			t.Errorf("Webhook repo cache = %q, %dMB, want /var/cache/cadence/repos and the default 10240MB",
+				config.Webhook.RepoCacheDir, config.Webhook.RepoCacheMaxMB)
+		}
+		if config.Webhook.StreamResumeGrace != 30 {
+			t.Errorf("Webhook.StreamResumeGrace = %d, want 30", config.Webhook.StreamResumeGrace)
+		}
+		if config.Webhook.CompressResponses {
+			t.Error("Webhook.CompressResponses should be false")
+		}
+		if config.Webhook.PluginManifest != "/etc/cadence/plugins.yaml" {
+			t.Errorf("Webhook.PluginManifest = %q, want /etc/cadence/plugins.yaml", config.Webhook.PluginManifest)
+		}
+		if config.Webhook.WASMPluginDir != "/etc/cadence/wasm" || config.Webhook.WASMPluginTimeout != 2 || config.Webhook.WASMPluginMemoryMB != 128 {
+			t.Errorf("Webhook WASM plugins = %q, %ds, %dMB, want /etc/cadence/wasm, 2s and the default 128MB",
+				config.Webhook.WASMPluginDir, config.Webhook.WASMPluginTimeout, config.Webhook.WASMPluginMemoryMB)
+		}
+		if b := config.Webhook.Branches; len(b.Include) != 2 || b.Include[1] != "release/*" || len(b.Exclude) != 0 {
+			t.Errorf("Webhook.Branches = %+v, want include [main release/*] and no exclude", b)
+		}
+		if h := config.Webhook.AllowedGitHosts; len(h) != 2 || h[1] != "*.git.example.com" {
+			t.Errorf("Webhook.AllowedGitHosts = %v, want [github.com *.git.example.com]", h)
+		}
+		if !config.Webhook.AllowLocalPaths || config.Webhook.LocalPathRoot != "/srv/checkouts" {
+			t.Errorf("Webhook local paths = %v under %q, want allowed under /srv/checkouts", config.Webhook.AllowLocalPaths, config.Webhook.LocalPathRoot)
+		}
+		if got := config.AssessmentLabels.Label(analysis.AssessmentLikelyAI); got != "Probablemente generado por IA" || len(config.AssessmentLabels) != 1 {
+			t.Errorf("AssessmentLabels = %v, want likely_ai reworded", config.AssessmentLabels)
+		}
+		if p := config.Report.ExamplePolicy(); p.MaxExamples != 3 || !p.Redact {
+			t.Errorf("Report.ExamplePolicy() = %+v, want 3 examples, redacted", p)
+		}
+		kt, ok := config.LanguageProfiles[".kt"]
+		if !ok {
+			t.Fatalf("LanguageProfiles = %v, want a .kt profile", config.LanguageProfiles)
+		}
+		if kt.Name != "Kotlin" || kt.LinesPerErrorMarker != 40 || !kt.CamelCaseIdentifiers {
+			t.Errorf("LanguageProfiles[.kt] = %+v", kt)
+		}
+		if len(kt.ErrorMarkers) != 2 || kt.ErrorMarkers[0] != "runcatching" {
+			t.Errorf("ErrorMarkers = %v, want lowercased markers", kt.ErrorMarkers)
+		}
+	})
+
+	t.Run("disabled strategies", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		content := "strategies:\n" +
+			"  dependency_addition_analysis: false\n" +
+			"  rewrite_similarity_analysis: false\n" +
+			"  signature_analysis: false\n" +
+			"  ai_coauthor_analysis: false\n" +
+			"  ngram_repetition_analysis: false\n" +
+			"  StatisticalAnomaly: false\n" +
+			"  burst_pattern: false\n" +
+			"  size_analysis: true\n"
+		if err := os.WriteFile(configFile, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+		config, err := Load(configFile)
+		if err != nil {
+			t.Fatalf("Load() unexpected error = %v", err)
+		}
+		want := []string{
+			"StatisticalAnomaly",
+			"ai_coauthor_analysis",
+			"burst_pattern_analysis",
+			"dependency_addition_analysis",
+			"ngram_repetition_analysis",
+			"rewrite_similarity_analysis",
+			"signature_analysis",
+		}
+		if got := config.Strategies.Disabled(); strings.Join(got, ",") != strings.Join(want, ",") {
+			t.Errorf("Disabled() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("unknown merge strategy", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configFile, []byte("analysis:\n  merge_strategy: octopus\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+		if _, err := Load(configFile); err == nil || !strings.Contains(err.Error(), "merge_strategy") {
+			t.Errorf("Load() error = %v, want an invalid merge_strategy error", err)
+		}
+	})
+
+	t.Run("unknown assessment label", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configFile, []byte("assessment_labels:\n  maybe_ai: Vielleicht\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+		if _, err := Load(configFile); err == nil || !strings.Contains(err.Error(), "assessment_labels") {
+			t.Errorf("Load() error = %v, want an invalid assessment_labels error", err)
+		}
+	})
+
+	t.Run("negative max examples", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configFile, []byte("report:\n  max_examples: -1\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+		if _, err := Load(configFile); err == nil || !strings.Contains(err.Error(), "report.max_examples") {
+			t.Errorf("Load() error = %v, want an invalid report.max_examples error", err)
+		}
+	})
+
+	t.Run("invalid min report confidence", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configFile, []byte("analysis:\n  min_report_confidence: 1.5\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+		if _, err := Load(configFile); err == nil || !strings.Contains(err.Error(), "min_report_confidence") {
+			t.Errorf("Load() error = %v, want an invalid min_report_confidence error", err)
+		}
+	})
+
+	t.Run("medium classification above high", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configFile, []byte("classification:\n  high: 0.5\n  medium: 0.6\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+		if _, err := Load(configFile); err == nil || !strings.Contains(err.Error(), "classification.medium") {
+			t.Errorf("Load() error = %v, want an invalid classification.medium error", err)
+		}
+	})
+
+	t.Run("local paths without a root", func(t *testing.T) {
+		configFile := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configFile, []byte("webhook:\n  allow_local_paths: true\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write test config file: %v", err)
+		}
+		if _, err := Load(configFile); err == nil || !strings.Contains(err.Error(), "local_path_root") {
+			t.Errorf("Load() error = %v, want a missing webhook.local_path_root error", err)
+		}
+	})
+
+	t.Run("invalid baseline sample sizes", func(t *testing.T) {
+		for _, content := range []string{
+			"baseline:\n  min_commits: -1\n",
+			"baseline:\n  min_commits: 20\n  reliable_commits: 10\n",
+		} {
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configFile, []byte(content), 0o600); err != nil {
+				t.Fatalf("Failed to write test config file: %v", err)
+			}
+			if _, err := Load(configFile); err == nil || !strings.Contains(err.Error(), "baseline") {
+				t.Errorf("Load(%q) error = %v, want an invalid baseline error", content, err)
+			}
+		}
+	})
+
+	t.Run("invalid multi-source weights", func(t *testing.T) {
+		for _, content := range []string{
+			"multi_source:\n  git_weight: -1\n",
+			"multi_source:\n  git_weight: 0\n  web_weight: 0\n",
+		} {
+			configFile := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configFile, []byte(content), 0o600); err != nil {
+				t.Fatalf("Failed to write test config file: %v", err)
+			}
+			if _, err := Load(configFile); err == nil || !strings.Contains(err.Error(), "multi_source") {
+				t.Errorf("Load(%q) error = %v, want an invalid multi_source error", content, err)
+			}
+		}
 	})
 
 	t.Run("load from json file", func(t *testing.T) {
@@ -260,3 +614,225 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+func TestConfig_Fingerprint(t *testing.T) {
+	load := func() *Config {
+		t.Helper()
+		cfg, err := Load("")
+		if err != nil {
+			t.Fatalf("Load() unexpected error = %v", err)
+		}
+		return cfg
+	}
+	base := load().Fingerprint()
+	if base == "" || base != load().Fingerprint() {
+		t.Fatalf("Fingerprint() = %q, want a stable non-empty hash", base)
+	}
+
+	changes := map[string]func(*Config){
+		"threshold":          func(c *Config) { c.Thresholds.SuspiciousAdditions++ },
+		"float threshold":    func(c *Config) { c.Thresholds.MaxAdditionRatio = 0.5 },
+		"disabled strategy":  func(c *Config) { c.Strategies.DisabledStrategies["burst_pattern"] = true },
+		"exclude files":      func(c *Config) { c.ExcludeFiles = append(c.ExcludeFiles, "*.lock") },
+		"generated filter":   func(c *Config) { c.GeneratedFiles.Enabled = false },
+		"classification":     func(c *Config) { c.Classification.High = 0.9 },
+		"max commits":        func(c *Config) { c.Analysis.MaxCommits = 10 },
+		"ngram coverage":     func(c *Config) { c.NGramRepetition.GitMaxCoverage = 0.5 },
+		"churn window":       func(c *Config) { c.Churn.MaxCommits = 3 },
+		"baseline gate":      func(c *Config) { c.Baseline.MinCommits = 10 },
+		"language profiles":  func(c *Config) { c.LanguageProfiles = map[string]patterns.LanguageProfile{".kt": {Name: "Kotlin"}} },
+		"precision analysis": func(c *Config) { c.Thresholds.EnablePrecisionAnalysis = !c.Thresholds.EnablePrecisionAnalysis },
+		"ai blend weight":    func(c *Config) { c.AI.BlendWeight = 0.8 },
+		"merge strategy":     func(c *Config) { c.Analysis.MergeStrategy = git.MergeBoth },
+		"report confidence":  func(c *Config) { c.Analysis.MinReportConfidence = 0.5 },
+		"include passed":     func(c *Config) { c.Analysis.IncludePassed = true },
+		"author identity":    func(c *Config) { c.AuthorIdentity.MatchNames = false },
+		"web min word count": func(c *Config) { c.Web.MinWordCount = 200 },
+		"web vocabulary":     func(c *Config) { c.Web.Vocabulary = &webpatterns.Vocabulary{AIVocabulary: []string{"delve"}} },
+	}
+	for name, change := range changes {
+		cfg := load()
+		change(cfg)
+		if cfg.Fingerprint() == base {
+			t.Errorf("changing the %s left the fingerprint unchanged", name)
+		}
+	}
+
+	unchanged := map[string]func(*Config){
+		"explicitly enabled strategy": func(c *Config) { c.Strategies.DisabledStrategies["not_a_strategy"] = false },
+		"webhook port":                func(c *Config) { c.Webhook.Port = 9000 },
+		"cache ttl":                   func(c *Config) { c.Cache.TTLSeconds = 1 },
+		"ai api key":                  func(c *Config) { c.AI.APIKey = "sk-other" },
+	}
+	for name, change := range unchanged {
+		cfg := load()
+		change(cfg)
+		if cfg.Fingerprint() != base {
+			t.Errorf("changing the %s changed the fingerprint", name)
+		}
+	}
+}
+
+func TestLoad_VocabularyFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	t.Run("valid file", func(t *testing.T) {
+		vocab := write("vocabulary.yaml", "ai_vocabulary:\n  - Testament to\n")
+		config, err := Load(write("valid.yaml", "web:\n  vocabulary_file: "+vocab+"\n"))
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if config.Web.VocabularyFile != vocab {
+			t.Errorf("Web.VocabularyFile = %q, want %q", config.Web.VocabularyFile, vocab)
+		}
+		want := &webpatterns.Vocabulary{AIVocabulary: []string{"testament to"}}
+		if !reflect.DeepEqual(config.Web.Vocabulary, want) {
+			t.Errorf("Web.Vocabulary = %+v, want %+v", config.Web.Vocabulary, want)
+		}
+	})
+
+	t.Run("malformed file falls back to the defaults", func(t *testing.T) {
+		vocab := write("malformed.yaml", "marketing:\n  - synergy\n")
+		config, err := Load(write("malformed-config.yaml", "web:\n  vocabulary_file: "+vocab+"\n"))
+		if err != nil {
+			t.Fatalf("Load() error = %v, want the malformed file ignored", err)
+		}
+		if config.Web.Vocabulary != nil {
+			t.Errorf("Web.Vocabulary = %+v, want nil", config.Web.Vocabulary)
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		config, err := Load("")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if config.Web.VocabularyFile != "" || config.Web.Vocabulary != nil {
+			t.Errorf("Web = %+v, want no vocabulary", config.Web)
+		}
+	})
+}
+
+func TestLoad_WebFetcher(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+		return path
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		config, err := Load("")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if config.Web.ProxyURL != nil || config.Web.TLSConfig != nil || config.Web.MaxRedirects != 0 {
+			t.Errorf("Web = %+v, want no proxy, CA bundle or redirect limit", config.Web)
+		}
+	})
+
+	t.Run("proxy and redirects", func(t *testing.T) {
+		config, err := Load(write("proxy.yaml", "web:\n  proxy: http://proxy.internal:3128\n  max_redirects: 3\n"))
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if config.Web.ProxyURL == nil || config.Web.ProxyURL.Host != "proxy.internal:3128" {
+			t.Errorf("Web.ProxyURL = %v, want proxy.internal:3128", config.Web.ProxyURL)
+		}
+		if config.Web.MaxRedirects != 3 {
+			t.Errorf("Web.MaxRedirects = %d, want 3", config.Web.MaxRedirects)
+		}
+		if config.Web.UseEnvProxy != nil {
+			t.Errorf("Web.UseEnvProxy = %v, want unset to keep the fetcher default", *config.Web.UseEnvProxy)
+		}
+		if got := len(config.Web.FetcherOptions()); got != 4 {
+			t.Errorf("FetcherOptions() returned %d options, want 4", got)
+		}
+	})
+
+	t.Run("environment proxy override", func(t *testing.T) {
+		config, err := Load(write("env-proxy.yaml", "web:\n  use_env_proxy: false\n"))
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if config.Web.UseEnvProxy == nil || *config.Web.UseEnvProxy {
+			t.Errorf("Web.UseEnvProxy = %v, want an explicit false", config.Web.UseEnvProxy)
+		}
+		if got := len(config.Web.FetcherOptions()); got != 5 {
+			t.Errorf("FetcherOptions() returned %d options, want 5", got)
+		}
+	})
+
+	t.Run("user agent and robots", func(t *testing.T) {
+		config, err := Load(write("robots.yaml", "web:\n  user_agent: TestBot/1.0\n  respect_robots: true\n"))
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if config.Web.UserAgent != "TestBot/1.0" || !config.Web.RespectRobots || config.Web.RobotsCache == nil {
+			t.Errorf("Web = %+v, want the user agent, robots.txt respected and a shared robots cache", config.Web)
+		}
+		if got := len(config.Web.FetcherOptions()); got != 5 {
+			t.Errorf("FetcherOptions() returned %d options, want 5", got)
+		}
+	})
+
+	t.Run("invalid proxy", func(t *testing.T) {
+		if _, err := Load(write("bad-proxy.yaml", "web:\n  proxy: proxy.internal\n")); err == nil {
+			t.Error("Load() should reject a proxy without a scheme")
+		}
+	})
+
+	t.Run("missing CA file", func(t *testing.T) {
+		if _, err := Load(write("bad-ca.yaml", "web:\n  ca_file: "+filepath.Join(dir, "missing.pem")+"\n")); err == nil {
+			t.Error("Load() should reject a CA file that cannot be read")
+		}
+	})
+}
+
+func TestLoad_Logging(t *testing.T) {
+	config, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if config.Logging.Level != "info" || config.Logging.Format != logging.FormatText {
+		t.Errorf("Logging = %+v, want info and text by default", config.Logging)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging.yaml")
+	if err := os.WriteFile(path, []byte("logging:\n  level: DEBUG\n  format: json\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config, err = Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if config.Logging.Level != "debug" || config.Logging.Format != logging.FormatJSON {
+		t.Errorf("Logging = %+v, want debug and json", config.Logging)
+	}
+	if config.Logging.Logger() == nil {
+		t.Error("Logger() returned nil")
+	}
+
+	for name, content := range map[string]string{
+		"bad-level.yaml":  "logging:\n  level: verbose\n",
+		"bad-format.yaml": "logging:\n  format: logfmt\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load(%s) should reject the logging config", name)
+		}
+	}
+}
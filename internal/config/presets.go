@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+)
+
+// Threshold preset names, selected with thresholds.preset or analyze --preset.
+const (
+	PresetStrict   = "strict"
+	PresetBalanced = "balanced"
+	PresetLenient  = "lenient"
+)
+
+// ThresholdPresets are vetted threshold sets for the size, velocity, timing,
+// dispersion and ratio strategies. Every threshold of strict is at least as
+// sensitive as balanced's, and balanced's at least as sensitive as
+// lenient's, so a stricter preset flags every commit a looser one does.
+var ThresholdPresets = map[string]patterns.Thresholds{
+	// strict is for audits where a missed AI-generated commit costs more
+	// than reviewing a false positive: mid-sized commits, steady typing
+	// speeds and commits a couple of minutes apart are already suspicious.
+	PresetStrict: {
+		SuspiciousAdditions:     300,
+		SuspiciousDeletions:     600,
+		MaxAdditionsPerMin:      50,
+		MaxDeletionsPerMin:      250,
+		MinTimeDeltaSeconds:     120,
+		MaxFilesPerCommit:       30,
+		MaxAdditionRatio:        0.9,
+		MinDeletionRatio:        0.9,
+		MinCommitSizeRatio:      50,
+		EnablePrecisionAnalysis: true,
+	},
+	// balanced is the default configuration, tuned for typical application
+	// repositories.
+	PresetBalanced: {
+		SuspiciousAdditions:     500,
+		SuspiciousDeletions:     1000,
+		MaxAdditionsPerMin:      100,
+		MaxDeletionsPerMin:      500,
+		MinTimeDeltaSeconds:     60,
+		MaxFilesPerCommit:       50,
+		MaxAdditionRatio:        0.95,
+		MinDeletionRatio:        0.95,
+		MinCommitSizeRatio:      100,
+		EnablePrecisionAnalysis: true,
+	},
+	// lenient is for repositories where large, fast or bulk commits are
+	// routine (monorepos, vendored code, squash-merged history) and only
+	// the most extreme commits should be flagged.
+	PresetLenient: {
+		SuspiciousAdditions:     1000,
+		SuspiciousDeletions:     2000,
+		MaxAdditionsPerMin:      200,
+		MaxDeletionsPerMin:      1000,
+		MinTimeDeltaSeconds:     30,
+		MaxFilesPerCommit:       100,
+		MaxAdditionRatio:        0.98,
+		MinDeletionRatio:        0.98,
+		MinCommitSizeRatio:      200,
+		EnablePrecisionAnalysis: true,
+	},
+}
+
+// PresetNames returns the threshold preset names, sorted.
+func PresetNames() []string {
+	names := make([]string, 0, len(ThresholdPresets))
+	for name := range ThresholdPresets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// thresholdKeys maps each thresholds.* config key to the field it sets.
+var thresholdKeys = []struct {
+	key  string
+	copy func(dst *patterns.Thresholds, src patterns.Thresholds)
+}{
+	{"suspicious_additions", func(d *patterns.Thresholds, s patterns.Thresholds) { d.SuspiciousAdditions = s.SuspiciousAdditions }},
+	{"suspicious_deletions", func(d *patterns.Thresholds, s patterns.Thresholds) { d.SuspiciousDeletions = s.SuspiciousDeletions }},
+	{"max_additions_per_min", func(d *patterns.Thresholds, s patterns.Thresholds) { d.MaxAdditionsPerMin = s.MaxAdditionsPerMin }},
+	{"max_deletions_per_min", func(d *patterns.Thresholds, s patterns.Thresholds) { d.MaxDeletionsPerMin = s.MaxDeletionsPerMin }},
+	{"min_time_delta_seconds", func(d *patterns.Thresholds, s patterns.Thresholds) { d.MinTimeDeltaSeconds = s.MinTimeDeltaSeconds }},
+	{"max_files_per_commit", func(d *patterns.Thresholds, s patterns.Thresholds) { d.MaxFilesPerCommit = s.MaxFilesPerCommit }},
+	{"max_addition_ratio", func(d *patterns.Thresholds, s patterns.Thresholds) { d.MaxAdditionRatio = s.MaxAdditionRatio }},
+	{"min_deletion_ratio", func(d *patterns.Thresholds, s patterns.Thresholds) { d.MinDeletionRatio = s.MinDeletionRatio }},
+	{"min_commit_size_ratio", func(d *patterns.Thresholds, s patterns.Thresholds) { d.MinCommitSizeRatio = s.MinCommitSizeRatio }},
+	{"enable_precision_analysis", func(d *patterns.Thresholds, s patterns.Thresholds) {
+		d.EnablePrecisionAnalysis = s.EnablePrecisionAnalysis
+	}},
+}
+
+// ApplyThresholdPreset sets the thresholds to the named preset, except those
+// set explicitly in the config file, which keep their configured values.
+func (c *Config) ApplyThresholdPreset(name string) error {
+	preset, ok := ThresholdPresets[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown threshold preset %q (want one of %s)", name, strings.Join(PresetNames(), ", "))
+	}
+
+	for _, k := range thresholdKeys {
+		if !c.explicitThresholds[k.key] {
+			k.copy(&c.Thresholds, preset)
+		}
+	}
+	c.ThresholdPreset = strings.ToLower(name)
+	return nil
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+)
+
+// presetCorpus is a fixed set of commits spanning quiet to extreme, keyed
+// by a short description.
+var presetCorpus = map[string]*git.CommitPair{
+	"small, slow":            presetPair(40, 10, 2, time.Hour),
+	"medium, slow":           presetPair(400, 50, 5, 2*time.Hour),
+	"large, slow":            presetPair(800, 300, 10, 3*time.Hour),
+	"huge, slow":             presetPair(1500, 100, 20, 5*time.Hour),
+	"fast typist":            presetPair(200, 20, 3, 3*time.Minute),
+	"very fast":              presetPair(600, 20, 4, 3*time.Minute),
+	"quick follow-up":        presetPair(20, 20, 1, 90*time.Second),
+	"instant follow-up":      presetPair(20, 20, 1, 20*time.Second),
+	"wide change":            presetPair(200, 150, 40, 4*time.Hour),
+	"very wide change":       presetPair(200, 150, 120, 4*time.Hour),
+	"additions only, medium": presetPair(93, 7, 2, 2*time.Hour),
+	"additions only, large":  presetPair(495, 5, 3, 5*time.Hour),
+}
+
+func presetPair(additions, deletions int64, files int, delta time.Duration) *git.CommitPair {
+	return &git.CommitPair{
+		Current:   &git.Commit{Hash: "c"},
+		Previous:  &git.Commit{Hash: "p"},
+		TimeDelta: delta,
+		Stats:     &git.DiffStats{Additions: additions, Deletions: deletions, FilesChanged: files},
+	}
+}
+
+// flaggedBy returns the corpus commits that the strategies configured by t
+// flag.
+func flaggedBy(t patterns.Thresholds) map[string]bool {
+	strategies := []patterns.DetectionStrategy{
+		patterns.NewSizeStrategy(t.SuspiciousAdditions, t.SuspiciousDeletions),
+		patterns.NewVelocityStrategy(t.MaxAdditionsPerMin, t.MaxDeletionsPerMin),
+		patterns.NewTimingStrategy(t.MinTimeDeltaSeconds),
+		patterns.NewDispersionStrategy(t.MaxFilesPerCommit),
+		patterns.NewRatioStrategy(t.MaxAdditionRatio, t.MinDeletionRatio, t.MinCommitSizeRatio),
+	}
+
+	flagged := make(map[string]bool)
+	for name, pair := range presetCorpus {
+		for _, s := range strategies {
+			if hit, _ := s.Detect(pair, nil); hit {
+				flagged[name] = true
+				break
+			}
+		}
+	}
+	return flagged
+}
+
+func TestThresholdPresets_OrderedStrictness(t *testing.T) {
+	for _, name := range PresetNames() {
+		preset := ThresholdPresets[name]
+		if err := preset.Validate(); err != nil {
+			t.Errorf("preset %s is invalid: %v", name, err)
+		}
+	}
+
+	strict := flaggedBy(ThresholdPresets[PresetStrict])
+	balanced := flaggedBy(ThresholdPresets[PresetBalanced])
+	lenient := flaggedBy(ThresholdPresets[PresetLenient])
+
+	for _, tt := range []struct {
+		looser, stricter       string
+		looserSet, stricterSet map[string]bool
+	}{
+		{PresetBalanced, PresetStrict, balanced, strict},
+		{PresetLenient, PresetBalanced, lenient, balanced},
+	} {
+		for commit := range tt.looserSet {
+			if !tt.stricterSet[commit] {
+				t.Errorf("%s flags %q but %s does not", tt.looser, commit, tt.stricter)
+			}
+		}
+		if len(tt.stricterSet) <= len(tt.looserSet) {
+			t.Errorf("%s flags %d commits, want more than %s's %d", tt.stricter, len(tt.stricterSet), tt.looser, len(tt.looserSet))
+		}
+	}
+	if len(lenient) == 0 {
+		t.Error("lenient should still flag the most extreme commits")
+	}
+}
+
+func TestLoad_ThresholdPreset(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		return path
+	}
+
+	t.Run("preset with an explicit override", func(t *testing.T) {
+		config, err := Load(write(t, "thresholds:\n  preset: strict\n  suspicious_additions: 800\n"))
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+
+		want := ThresholdPresets[PresetStrict]
+		want.SuspiciousAdditions = 800
+		if config.Thresholds != want {
+			t.Errorf("Thresholds = %+v, want %+v", config.Thresholds, want)
+		}
+		if config.ThresholdPreset != PresetStrict {
+			t.Errorf("ThresholdPreset = %q, want strict", config.ThresholdPreset)
+		}
+
+		// A preset chosen later, as with analyze --preset, also leaves the
+		// file's thresholds alone.
+		if err := config.ApplyThresholdPreset(PresetLenient); err != nil {
+			t.Fatalf("ApplyThresholdPreset() error = %v", err)
+		}
+		want = ThresholdPresets[PresetLenient]
+		want.SuspiciousAdditions = 800
+		if config.Thresholds != want {
+			t.Errorf("Thresholds = %+v, want %+v", config.Thresholds, want)
+		}
+	})
+
+	t.Run("preset in the sample config", func(t *testing.T) {
+		sample := strings.Replace(SampleConfigTemplate, "# preset: balanced", "preset: strict", 1)
+		config, err := Load(write(t, sample))
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if config.Thresholds != ThresholdPresets[PresetStrict] {
+			t.Errorf("Thresholds = %+v, want the strict preset; the sample must not set thresholds", config.Thresholds)
+		}
+	})
+
+	t.Run("no preset keeps the defaults", func(t *testing.T) {
+		config, err := Load("")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if config.Thresholds != ThresholdPresets[PresetBalanced] || config.ThresholdPreset != "" {
+			t.Errorf("Thresholds = %+v (preset %q), want the balanced values", config.Thresholds, config.ThresholdPreset)
+		}
+	})
+
+	t.Run("unknown preset", func(t *testing.T) {
+		_, err := Load(write(t, "thresholds:\n  preset: paranoid\n"))
+		if err == nil || !strings.Contains(err.Error(), "thresholds.preset") || !strings.Contains(err.Error(), "balanced, lenient, strict") {
+			t.Errorf("Load() error = %v, want an unknown preset error listing the presets", err)
+		}
+	})
+}
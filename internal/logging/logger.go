@@ -2,10 +2,12 @@ package logging
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"strings"
+	"sync/atomic"
 )
 
 type LogFormat string
@@ -21,10 +23,30 @@ type Config struct {
 	Output io.Writer
 }
 
+// Validate reports an unknown level or format. Empty values are valid and
+// mean info and text.
+func (c *Config) Validate() error {
+	if _, ok := levels[strings.ToLower(c.Level)]; !ok && c.Level != "" {
+		return fmt.Errorf("unknown log level %q (use debug, info, warn or error)", c.Level)
+	}
+	switch c.Format {
+	case "", FormatText, FormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("unknown log format %q (use text or json)", c.Format)
+	}
+}
+
 type Logger struct {
 	slog *slog.Logger
+	// ctx is passed with every record, so a logger bound to a traced
+	// context stamps its trace ID on every line.
+	ctx context.Context
 }
 
+// defaultLogger is the logger Default returns once SetDefault is called.
+var defaultLogger atomic.Pointer[Logger]
+
 func New(cfg *Config) *Logger {
 	if cfg == nil {
 		cfg = &Config{}
@@ -47,34 +69,59 @@ func New(cfg *Config) *Logger {
 		handler = slog.NewTextHandler(cfg.Output, opts)
 	}
 
-	return &Logger{slog: slog.New(handler)}
+	return &Logger{slog: slog.New(traceHandler{handler})}
 }
 
+// Default returns the logger set with SetDefault, or an info-level text
+// logger writing to stderr.
 func Default() *Logger {
+	if l := defaultLogger.Load(); l != nil {
+		return l
+	}
 	return New(&Config{
 		Level:  "info",
 		Format: FormatText,
 	})
 }
 
+// SetDefault makes l the logger Default returns, so components that are not
+// handed a logger follow the configured level and format. A nil l restores
+// the built-in default.
+func SetDefault(l *Logger) {
+	defaultLogger.Store(l)
+}
+
 func (l *Logger) With(args ...any) *Logger {
-	return &Logger{slog: l.slog.With(args...)}
+	return &Logger{slog: l.slog.With(args...), ctx: l.ctx}
+}
+
+// WithContext returns a logger that logs every line with ctx, adding the
+// trace ID ctx carries (see ContextWithTraceID).
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	return &Logger{slog: l.slog, ctx: ctx}
+}
+
+func (l *Logger) context() context.Context {
+	if l.ctx == nil {
+		return context.Background()
+	}
+	return l.ctx
 }
 
 func (l *Logger) Debug(msg string, args ...any) {
-	l.slog.Debug(msg, args...)
+	l.slog.DebugContext(l.context(), msg, args...)
 }
 
 func (l *Logger) Info(msg string, args ...any) {
-	l.slog.Info(msg, args...)
+	l.slog.InfoContext(l.context(), msg, args...)
 }
 
 func (l *Logger) Warn(msg string, args ...any) {
-	l.slog.Warn(msg, args...)
+	l.slog.WarnContext(l.context(), msg, args...)
 }
 
 func (l *Logger) Error(msg string, args ...any) {
-	l.slog.Error(msg, args...)
+	l.slog.ErrorContext(l.context(), msg, args...)
 }
 
 func (l *Logger) InfoContext(ctx context.Context, msg string, args ...any) {
@@ -89,7 +136,16 @@ func (l *Logger) LogPhase(jobID string, phase string, args ...any) {
 	allArgs := make([]any, 0, len(args)+2)
 	allArgs = append(allArgs, "job_id", jobID)
 	allArgs = append(allArgs, args...)
-	l.slog.Info(phase, allArgs...)
+	l.slog.InfoContext(l.context(), phase, allArgs...)
+}
+
+// LogPhaseDebug is LogPhase at debug level, for the intermediate steps of a
+// job that are only worth seeing while troubleshooting.
+func (l *Logger) LogPhaseDebug(jobID string, phase string, args ...any) {
+	allArgs := make([]any, 0, len(args)+2)
+	allArgs = append(allArgs, "job_id", jobID)
+	allArgs = append(allArgs, args...)
+	l.slog.DebugContext(l.context(), phase, allArgs...)
 }
 
 func (l *Logger) LogPhaseError(jobID string, phase string, err error, args ...any) {
@@ -97,11 +153,11 @@ func (l *Logger) LogPhaseError(jobID string, phase string, err error, args ...an
 	allArgs = append(allArgs, "job_id", jobID)
 	allArgs = append(allArgs, "error", err)
 	allArgs = append(allArgs, args...)
-	l.slog.Error(phase, allArgs...)
+	l.slog.ErrorContext(l.context(), phase, allArgs...)
 }
 
 func (l *Logger) LogDetection(strategy string, severity string, score float64) {
-	l.slog.Info("detection",
+	l.slog.InfoContext(l.context(), "detection",
 		"strategy", strategy,
 		"severity", severity,
 		"score", score,
@@ -113,18 +169,20 @@ func (l *Logger) LogAnalysis(sourceType string, sourceID string, args ...any) {
 	allArgs = append(allArgs, "source_type", sourceType)
 	allArgs = append(allArgs, "source_id", sourceID)
 	allArgs = append(allArgs, args...)
-	l.slog.Info("analysis", allArgs...)
+	l.slog.InfoContext(l.context(), "analysis", allArgs...)
+}
+
+var levels = map[string]slog.Level{
+	"debug":   slog.LevelDebug,
+	"info":    slog.LevelInfo,
+	"warn":    slog.LevelWarn,
+	"warning": slog.LevelWarn,
+	"error":   slog.LevelError,
 }
 
 func parseLevel(level string) slog.Level {
-	switch strings.ToLower(level) {
-	case "debug":
-		return slog.LevelDebug
-	case "warn", "warning":
-		return slog.LevelWarn
-	case "error":
-		return slog.LevelError
-	default:
-		return slog.LevelInfo
+	if l, ok := levels[strings.ToLower(level)]; ok {
+		return l
 	}
+	return slog.LevelInfo
 }
@@ -2,6 +2,7 @@ package logging
 
 import (
 	"bytes"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -216,3 +217,67 @@ func TestParseLevel(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_Validate(t *testing.T) {
+	valid := []Config{{}, {Level: "debug", Format: FormatJSON}, {Level: "WARN", Format: FormatText}}
+	for _, c := range valid {
+		if err := c.Validate(); err != nil {
+			t.Errorf("Validate(%+v) unexpected error = %v", c, err)
+		}
+	}
+	invalid := []Config{{Level: "verbose"}, {Format: "logfmt"}}
+	for _, c := range invalid {
+		if err := c.Validate(); err == nil {
+			t.Errorf("Validate(%+v) should fail", c)
+		}
+	}
+}
+
+func TestSetDefault(t *testing.T) {
+	t.Cleanup(func() { SetDefault(nil) })
+
+	var buf bytes.Buffer
+	SetDefault(New(&Config{Level: "warn", Format: FormatJSON, Output: &buf}))
+	Default().Info("hidden")
+	Default().With("component", "queue").Warn("shown")
+
+	output := buf.String()
+	if strings.Contains(output, "hidden") {
+		t.Errorf("info message logged at warn level: %s", output)
+	}
+	if !strings.Contains(output, `"msg":"shown"`) || !strings.Contains(output, `"component":"queue"`) {
+		t.Errorf("expected the warning as JSON, got: %s", output)
+	}
+
+	SetDefault(nil)
+	if Default() == nil {
+		t.Fatal("Default() returned nil after SetDefault(nil)")
+	}
+}
+
+func TestLogger_LogPhaseLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&Config{Level: "info", Format: FormatJSON, Output: &buf})
+	logger.LogPhaseDebug("job-123", "populating results")
+	if buf.Len() > 0 {
+		t.Errorf("debug phase logged at info level: %s", buf.String())
+	}
+
+	logger = New(&Config{Level: "error", Format: FormatJSON, Output: &buf})
+	logger.LogPhase("job-123", "cloning")
+	logger.LogPhaseError("job-123", "clone failed", errors.New("timeout"))
+	output := buf.String()
+	if strings.Contains(output, "cloning") {
+		t.Errorf("info phase logged at error level: %s", output)
+	}
+	if !strings.Contains(output, `"msg":"clone failed"`) || !strings.Contains(output, `"error":"timeout"`) {
+		t.Errorf("expected the failed phase with its error, got: %s", output)
+	}
+
+	buf.Reset()
+	logger = New(&Config{Level: "debug", Format: FormatJSON, Output: &buf})
+	logger.LogPhaseDebug("job-123", "populating results", "total_commits", 3)
+	if !strings.Contains(buf.String(), `"level":"DEBUG"`) || !strings.Contains(buf.String(), `"total_commits":3`) {
+		t.Errorf("expected the debug phase, got: %s", buf.String())
+	}
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestTraceID(t *testing.T) {
+	if id := TraceID(context.Background()); id != "" {
+		t.Errorf("TraceID() = %q for an untraced context, want empty", id)
+	}
+	ctx := ContextWithTraceID(context.Background(), "trace-1")
+	if id := TraceID(ctx); id != "trace-1" {
+		t.Errorf("TraceID() = %q, want trace-1", id)
+	}
+}
+
+func TestLogger_WithContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&Config{
+		Level:  "debug",
+		Format: FormatJSON,
+		Output: &buf,
+	})
+
+	logger.Info("untraced")
+	if strings.Contains(buf.String(), TraceIDAttr) {
+		t.Errorf("expected no trace_id without a traced context, got: %s", buf.String())
+	}
+
+	buf.Reset()
+	traced := logger.WithContext(ContextWithTraceID(context.Background(), "trace-1"))
+	traced.With("component", "queue").LogPhaseDebug("job-1", "cloning")
+	traced.LogAnalysis("git", "repo", "phase", "detecting")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got: %s", buf.String())
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, `"trace_id":"trace-1"`) {
+			t.Errorf("expected trace_id in output, got: %s", line)
+		}
+	}
+	if !strings.Contains(lines[0], `"component":"queue"`) {
+		t.Errorf("expected With to keep the context and add attributes, got: %s", lines[0])
+	}
+}
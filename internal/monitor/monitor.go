@@ -0,0 +1,164 @@
+// Package monitor watches websites and re-analyzes a page only when the main
+// text it is judged on has changed since the last check.
+package monitor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
+	"github.com/TryCadence/Cadence/internal/analysis/sources"
+)
+
+// State is what is remembered about a monitored URL between checks.
+type State struct {
+	URL string `json:"url"`
+	// ContentHash identifies the main text last analyzed (see ContentHash).
+	ContentHash string `json:"content_hash"`
+	// Assessment and Score are the verdict of that analysis.
+	Assessment string  `json:"assessment"`
+	Score      float64 `json:"score"`
+	// CheckedAt is the last fetch; ChangedAt the last analysis.
+	CheckedAt time.Time `json:"checked_at"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// Store keeps the last State of each monitored URL. Both webhook job stores
+// implement it alongside their JobStore methods, so states live in the
+// configured job store.
+type Store interface {
+	// MonitorState returns the state saved for url, or nil if there is none.
+	MonitorState(url string) (*State, error)
+	// SaveMonitorState inserts or replaces the state of state.URL.
+	SaveMonitorState(state *State) error
+}
+
+// Fetcher retrieves a page; *web.Fetcher implements it.
+type Fetcher interface {
+	Fetch(url string) (*web.PageContent, error)
+}
+
+// Result is the outcome of checking one URL.
+type Result struct {
+	URL string
+	// Changed is set when the content differs from the stored hash, or the
+	// URL was never checked, and it was analyzed into Report.
+	Changed bool
+	Report  *analysis.AnalysisReport
+	// State is the URL's state after the check.
+	State *State
+	Err   error
+}
+
+// Monitor checks URLs against the states in its store.
+type Monitor struct {
+	store    Store
+	fetcher  Fetcher
+	detector analysis.Detector
+	runner   analysis.DetectionRunner
+}
+
+// New creates a monitor that fetches pages with fetcher, analyzes changed
+// ones with detector and remembers them in store.
+func New(store Store, fetcher Fetcher, detector analysis.Detector) *Monitor {
+	return &Monitor{
+		store:    store,
+		fetcher:  fetcher,
+		detector: detector,
+		runner:   analysis.NewDefaultDetectionRunner(),
+	}
+}
+
+// ContentHash returns the SHA-256 of the page's main text with whitespace
+// collapsed, so markup and layout changes that leave the text alone do not
+// count as changes. Pages without main content are hashed by all their text.
+func ContentHash(page *web.PageContent) string {
+	text := page.MainContent
+	if strings.TrimSpace(text) == "" {
+		text = page.AllText
+	}
+	sum := sha256.Sum256([]byte(strings.Join(strings.Fields(text), " ")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Check fetches url and, when its content hash differs from the stored one,
+// analyzes it and stores the new hash and verdict. Unchanged pages only have
+// their check time updated.
+func (m *Monitor) Check(ctx context.Context, url string) Result {
+	result := Result{URL: url}
+
+	page, err := m.fetcher.Fetch(url)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to fetch %s: %w", url, err)
+		return result
+	}
+	hash := ContentHash(page)
+
+	prev, err := m.store.MonitorState(url)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	now := time.Now()
+	if prev != nil && prev.ContentHash == hash {
+		state := *prev
+		state.CheckedAt = now
+		result.State = &state
+		result.Err = m.store.SaveMonitorState(&state)
+		return result
+	}
+
+	source := sources.NewWebsiteSource(url)
+	source.Page = page
+	report, err := m.runner.Run(ctx, source, m.detector)
+	if err != nil {
+		result.Err = fmt.Errorf("analysis of %s failed: %w", url, err)
+		return result
+	}
+
+	result.Changed = true
+	result.Report = report
+	result.State = &State{
+		URL:         url,
+		ContentHash: hash,
+		Assessment:  report.Assessment,
+		Score:       report.OverallScore,
+		CheckedAt:   now,
+		ChangedAt:   now,
+	}
+	result.Err = m.store.SaveMonitorState(result.State)
+	return result
+}
+
+// CheckAll checks urls one after another.
+func (m *Monitor) CheckAll(ctx context.Context, urls []string) []Result {
+	results := make([]Result, 0, len(urls))
+	for _, url := range urls {
+		if ctx.Err() != nil {
+			break
+		}
+		results = append(results, m.Check(ctx, url))
+	}
+	return results
+}
+
+// Run checks urls every interval, passing each round's results to report,
+// until ctx is done.
+func (m *Monitor) Run(ctx context.Context, urls []string, interval time.Duration, report func([]Result)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		report(m.CheckAll(ctx, urls))
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
+)
+
+type mapStore map[string]State
+
+func (s mapStore) MonitorState(url string) (*State, error) {
+	state, ok := s[url]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (s mapStore) SaveMonitorState(state *State) error {
+	s[state.URL] = *state
+	return nil
+}
+
+// pages serves the main text in the map, or an error for unknown URLs.
+type pages map[string]string
+
+func (p pages) Fetch(url string) (*web.PageContent, error) {
+	text, ok := p[url]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &web.PageContent{URL: url, MainContent: text, AllText: "nav " + text + " footer"}, nil
+}
+
+// countingDetector records how many pages it analyzed.
+type countingDetector struct{ calls int }
+
+func (d *countingDetector) Detect(ctx context.Context, data *analysis.SourceData) ([]analysis.Detection, error) {
+	d.calls++
+	return nil, nil
+}
+
+func TestMonitor_Check(t *testing.T) {
+	site := pages{"https://example.com/": "Our team ships   software.\n"}
+	store := mapStore{}
+	det := &countingDetector{}
+	m := New(store, site, det)
+	ctx := context.Background()
+
+	first := m.Check(ctx, "https://example.com/")
+	if first.Err != nil || !first.Changed || first.Report == nil || det.calls != 1 {
+		t.Fatalf("first Check() = %+v after %d analyses, want a changed result", first, det.calls)
+	}
+	saved := store["https://example.com/"]
+	if saved.ContentHash == "" || saved.ChangedAt.IsZero() || saved.Assessment != first.Report.Assessment {
+		t.Errorf("stored state = %+v, want the hash and verdict of the analysis", saved)
+	}
+
+	// Whitespace-only edits are not changes.
+	site["https://example.com/"] = "Our team ships software."
+	second := m.Check(ctx, "https://example.com/")
+	if second.Err != nil || second.Changed || second.Report != nil || det.calls != 1 {
+		t.Errorf("unchanged Check() = %+v after %d analyses, want no analysis", second, det.calls)
+	}
+	if got := store["https://example.com/"]; !got.ChangedAt.Equal(saved.ChangedAt) || got.CheckedAt.Before(saved.CheckedAt) {
+		t.Errorf("state after an unchanged check = %+v, want only CheckedAt moved", got)
+	}
+
+	site["https://example.com/"] = "Our team ships better software."
+	third := m.Check(ctx, "https://example.com/")
+	if third.Err != nil || !third.Changed || det.calls != 2 {
+		t.Errorf("Check() after an edit = %+v after %d analyses, want a changed result", third, det.calls)
+	}
+	if store["https://example.com/"].ContentHash == saved.ContentHash {
+		t.Error("the stored hash should follow the new content")
+	}
+
+	if failed := m.Check(ctx, "https://example.com/missing"); failed.Err == nil || failed.Changed {
+		t.Errorf("Check() of a failing URL = %+v, want an error", failed)
+	}
+}
+
+func TestMonitor_Run(t *testing.T) {
+	site := pages{"https://a.example/": "alpha", "https://b.example/": "bravo"}
+	m := New(mapStore{}, site, &countingDetector{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var rounds [][]Result
+	m.Run(ctx, []string{"https://a.example/", "https://b.example/"}, time.Millisecond, func(results []Result) {
+		rounds = append(rounds, results)
+		if len(rounds) == 1 {
+			site["https://b.example/"] = "bravo changed"
+		} else {
+			cancel()
+		}
+	})
+
+	if len(rounds) != 2 {
+		t.Fatalf("Run() reported %d rounds, want 2", len(rounds))
+	}
+	if !rounds[0][0].Changed || !rounds[0][1].Changed {
+		t.Error("the first round should analyze every URL")
+	}
+	if rounds[1][0].Changed || !rounds[1][1].Changed {
+		t.Errorf("second round changed = [%v %v], want only b", rounds[1][0].Changed, rounds[1][1].Changed)
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	a := ContentHash(&web.PageContent{MainContent: "Hello  world\n"})
+	if b := ContentHash(&web.PageContent{MainContent: "Hello world"}); a != b {
+		t.Error("whitespace should not change the hash")
+	}
+	if c := ContentHash(&web.PageContent{MainContent: "Hello there"}); a == c {
+		t.Error("different text should change the hash")
+	}
+	if d := ContentHash(&web.PageContent{AllText: "Hello world"}); a != d {
+		t.Error("pages without main content should be hashed by all their text")
+	}
+}
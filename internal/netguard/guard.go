@@ -0,0 +1,163 @@
+// Package netguard keeps user-supplied URLs from reaching internal networks
+// (loopback, RFC 1918 ranges, link-local addresses such as cloud metadata
+// endpoints) when the server clones or fetches on a caller's behalf.
+package netguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+
+	cerrors "github.com/TryCadence/Cadence/internal/errors"
+)
+
+// ErrBlockedDestination is wrapped by every error the guard returns, so
+// callers can tell a rejected destination from a network failure.
+var ErrBlockedDestination = errors.New("destination resolves to a private or internal address")
+
+// sharedAddressSpace is RFC 6598 carrier-grade NAT space, which some clouds
+// use for metadata services.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// Resolver looks up the addresses of a host name.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// Guard validates destinations before they are contacted. The zero value
+// blocks internal addresses using the system resolver; a nil *Guard allows
+// everything.
+type Guard struct {
+	// AllowPrivate disables all checks, e.g. for self-hosted Git servers on
+	// the local network.
+	AllowPrivate bool
+	// Resolver overrides net.DefaultResolver.
+	Resolver Resolver
+}
+
+// IsInternal reports whether addr is loopback, private, link-local,
+// unspecified, multicast or shared address space.
+func IsInternal(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() ||
+		addr.IsUnspecified() || sharedAddressSpace.Contains(addr) ||
+		(addr.Is4() && addr.As4()[0] == 0) // 0.0.0.0/8 "this network"
+}
+
+// CheckURL rejects http(s) URLs whose host is or resolves to an internal
+// address. A URL without a scheme is treated as https, as the web fetcher
+// does. Names that do not resolve are allowed; the connection fails on its own.
+func (g *Guard) CheckURL(ctx context.Context, rawURL string) error {
+	if g == nil || g.AllowPrivate {
+		return nil
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return cerrors.ValidationError("invalid URL").WithDetails(rawURL).Wrap(err)
+	}
+	return g.CheckHost(ctx, u.Hostname())
+}
+
+// CheckRepositoryURL is CheckURL for anything git can clone: URLs with any
+// transport and scp-style "user@host:path" addresses. Local paths and file://
+// URLs are rejected, since they read the server's own filesystem.
+func (g *Guard) CheckRepositoryURL(ctx context.Context, repoURL string) error {
+	if g == nil || g.AllowPrivate {
+		return nil
+	}
+
+	if strings.Contains(repoURL, "://") {
+		u, err := url.Parse(repoURL)
+		if err != nil {
+			return cerrors.ValidationError("invalid repository URL").WithDetails(repoURL).Wrap(err)
+		}
+		if u.Scheme == "file" {
+			return blocked(repoURL, "file:// repositories are not allowed")
+		}
+		return g.CheckHost(ctx, u.Hostname())
+	}
+
+	// scp-style "git@github.com:owner/repo.git"; anything else is a local path.
+	if at := strings.Index(repoURL, "@"); at >= 0 {
+		if host, _, ok := strings.Cut(repoURL[at+1:], ":"); ok && host != "" && !strings.Contains(host, "/") {
+			return g.CheckHost(ctx, host)
+		}
+	}
+	return blocked(repoURL, "local repository paths are not allowed")
+}
+
+// CheckHost rejects host names and IP literals that are or resolve to an
+// internal address.
+func (g *Guard) CheckHost(ctx context.Context, host string) error {
+	if g == nil || g.AllowPrivate {
+		return nil
+	}
+
+	host = strings.TrimSuffix(strings.Trim(host, "[]"), ".")
+	if host == "" {
+		return cerrors.ValidationError("URL has no host")
+	}
+	lower := strings.ToLower(host)
+	if lower == "localhost" || strings.HasSuffix(lower, ".localhost") {
+		return blocked(host, "localhost is not allowed")
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return checkAddr(host, addr)
+	}
+
+	var resolver Resolver = net.DefaultResolver
+	if g.Resolver != nil {
+		resolver = g.Resolver
+	}
+	addrs, err := resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return nil
+	}
+	for _, addr := range addrs {
+		if err := checkAddr(host, addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Control is a net.Dialer Control function that rejects connections to
+// internal addresses. It checks the address actually dialed, so it also
+// catches DNS answers that change after CheckURL ran.
+func (g *Guard) Control(network, address string, _ syscall.RawConn) error {
+	if g == nil || g.AllowPrivate {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return cerrors.ValidationError("unexpected dial address").WithDetails(address).Wrap(ErrBlockedDestination)
+	}
+	return checkAddr(host, addr)
+}
+
+func checkAddr(host string, addr netip.Addr) error {
+	if IsInternal(addr) {
+		return blocked(host, fmt.Sprintf("%s is an internal address", addr))
+	}
+	return nil
+}
+
+func blocked(target, reason string) error {
+	return cerrors.ValidationError("destination not allowed").
+		WithDetails(fmt.Sprintf("%s: %s", target, reason)).
+		Wrap(ErrBlockedDestination)
+}
@@ -0,0 +1,133 @@
+package netguard
+
+import (
+	"context"
+	"errors"
+	"net/netip"
+	"testing"
+
+	cerrors "github.com/TryCadence/Cadence/internal/errors"
+)
+
+type staticResolver map[string][]netip.Addr
+
+func (r staticResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	if addrs, ok := r[host]; ok {
+		return addrs, nil
+	}
+	return nil, errors.New("no such host")
+}
+
+func TestIsInternal(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.100.100.200", true},
+		{"0.0.0.0", true},
+		{"::1", true},
+		{"fd00::1", true},
+		{"fe80::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"8.8.8.8", false},
+		{"140.82.112.3", false},
+		{"2606:4700::1111", false},
+	}
+	for _, tt := range tests {
+		if got := IsInternal(netip.MustParseAddr(tt.addr)); got != tt.want {
+			t.Errorf("IsInternal(%s) = %v, want %v", tt.addr, got, tt.want)
+		}
+	}
+}
+
+func TestGuard_CheckURL(t *testing.T) {
+	g := &Guard{Resolver: staticResolver{
+		"github.com":        {netip.MustParseAddr("140.82.112.3")},
+		"metadata.internal": {netip.MustParseAddr("169.254.169.254")},
+		"mixed.example":     {netip.MustParseAddr("93.184.216.34"), netip.MustParseAddr("10.0.0.5")},
+	}}
+
+	tests := []struct {
+		url     string
+		blocked bool
+	}{
+		{"https://github.com/owner/repo", false},
+		{"github.com/owner/repo", false},
+		{"https://unresolvable.example/", false},
+		{"http://127.0.0.1:8080/admin", true},
+		{"http://[::1]/", true},
+		{"http://localhost/", true},
+		{"http://api.localhost/", true},
+		{"http://169.254.169.254/latest/meta-data/", true},
+		{"http://metadata.internal/", true},
+		{"http://mixed.example/", true},
+	}
+	for _, tt := range tests {
+		err := g.CheckURL(context.Background(), tt.url)
+		if (err != nil) != tt.blocked {
+			t.Errorf("CheckURL(%q) error = %v, blocked %v", tt.url, err, tt.blocked)
+			continue
+		}
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ErrBlockedDestination) {
+			t.Errorf("CheckURL(%q) error = %v, want ErrBlockedDestination", tt.url, err)
+		}
+		if !errors.Is(err, cerrors.ValidationError("")) {
+			t.Errorf("CheckURL(%q) error = %v, want a validation error", tt.url, err)
+		}
+	}
+}
+
+func TestGuard_CheckRepositoryURL(t *testing.T) {
+	g := &Guard{Resolver: staticResolver{"github.com": {netip.MustParseAddr("140.82.112.3")}}}
+
+	tests := []struct {
+		url     string
+		blocked bool
+	}{
+		{"https://github.com/owner/repo.git", false},
+		{"ssh://git@github.com/owner/repo.git", false},
+		{"git@github.com:owner/repo.git", false},
+		{"file:///etc", true},
+		{"/var/lib/repos/private", true},
+		{"./relative", true},
+		{"git@10.0.0.8:owner/repo.git", true},
+		{"git://192.168.0.10/repo.git", true},
+	}
+	for _, tt := range tests {
+		if err := g.CheckRepositoryURL(context.Background(), tt.url); (err != nil) != tt.blocked {
+			t.Errorf("CheckRepositoryURL(%q) error = %v, blocked %v", tt.url, err, tt.blocked)
+		}
+	}
+}
+
+func TestGuard_AllowPrivate(t *testing.T) {
+	for _, g := range []*Guard{nil, {AllowPrivate: true}} {
+		if err := g.CheckURL(context.Background(), "http://127.0.0.1/"); err != nil {
+			t.Errorf("CheckURL() with %+v error = %v, want nil", g, err)
+		}
+		if err := g.CheckRepositoryURL(context.Background(), "file:///srv/repo"); err != nil {
+			t.Errorf("CheckRepositoryURL() with %+v error = %v, want nil", g, err)
+		}
+		if err := g.Control("tcp", "10.0.0.1:443", nil); err != nil {
+			t.Errorf("Control() with %+v error = %v, want nil", g, err)
+		}
+	}
+}
+
+func TestGuard_Control(t *testing.T) {
+	g := &Guard{}
+	if err := g.Control("tcp4", "169.254.169.254:80", nil); !errors.Is(err, ErrBlockedDestination) {
+		t.Errorf("Control(metadata) error = %v, want ErrBlockedDestination", err)
+	}
+	if err := g.Control("tcp6", "[2606:4700::1111]:443", nil); err != nil {
+		t.Errorf("Control(public) error = %v, want nil", err)
+	}
+}
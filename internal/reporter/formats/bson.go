@@ -46,6 +46,14 @@ func (r *BSONReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 		Extra          map[string]interface{} `bson:"extra,omitempty"`
 	}
 
+	type bsonScores struct {
+		Heuristic  float64 `bson:"heuristic"`
+		AI         float64 `bson:"ai"`
+		AIWeight   float64 `bson:"aiWeight"`
+		AIReviewed int     `bson:"aiReviewed"`
+		Blended    float64 `bson:"blended"`
+	}
+
 	type bsonAnalysisReport struct {
 		ID                  string                 `bson:"id"`
 		SourceType          string                 `bson:"sourceType"`
@@ -54,6 +62,7 @@ func (r *BSONReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 		SourceMetrics       bsonSourceMetrics      `bson:"sourceMetrics"`
 		Detections          []bsonDetection        `bson:"detections"`
 		OverallScore        float64                `bson:"overallScore"`
+		Scores              bsonScores             `bson:"scores"`
 		Assessment          string                 `bson:"assessment"`
 		SuspicionRate       float64                `bson:"suspicionRate"`
 		TotalDetections     int                    `bson:"totalDetections"`
@@ -88,6 +97,14 @@ func (r *BSONReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 		}
 	}
 
+	scores := bsonScores{
+		Heuristic:  report.Scores.Heuristic,
+		AI:         report.Scores.AI,
+		AIWeight:   report.Scores.AIWeight,
+		AIReviewed: report.Scores.AIReviewed,
+		Blended:    report.Scores.Blended,
+	}
+
 	br := bsonAnalysisReport{
 		ID:         report.ID,
 		SourceType: string(report.SourceType),
@@ -111,6 +128,7 @@ func (r *BSONReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 		},
 		Detections:          detections,
 		OverallScore:        report.OverallScore,
+		Scores:              scores,
 		Assessment:          report.Assessment,
 		SuspicionRate:       report.SuspicionRate,
 		TotalDetections:     report.TotalDetections,
@@ -0,0 +1,103 @@
+package formats
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+// CSVHeader is the first row of every CSV export. Columns are only ever
+// appended so spreadsheets and warehouse loaders keyed on position keep working.
+var CSVHeader = []string{
+	"source_type", "source_id", "strategy", "category", "severity",
+	"score", "confidence", "detected", "description",
+}
+
+// CSVReporter writes one row per detection.
+type CSVReporter struct{}
+
+func (r *CSVReporter) FormatAnalysis(report *analysis.AnalysisReport) (string, error) {
+	return r.FormatAnalyses([]*analysis.AnalysisReport{report})
+}
+
+// FormatAnalyses writes the detections of several reports into one CSV
+// document with a single header row, e.g. to batch many repositories.
+func (r *CSVReporter) FormatAnalyses(reports []*analysis.AnalysisReport) (string, error) {
+	var sb strings.Builder
+	w := NewCSVWriter(&sb)
+	for _, report := range reports {
+		if err := w.Append(report); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// CSVWriter appends reports to a CSV stream. The header is written before the
+// first row, or on Flush if nothing was appended.
+type CSVWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func NewCSVWriter(w io.Writer) *CSVWriter {
+	return &CSVWriter{w: csv.NewWriter(w)}
+}
+
+// Append writes one row per detection in report. Nil reports are skipped.
+func (cw *CSVWriter) Append(report *analysis.AnalysisReport) error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	if report == nil {
+		return nil
+	}
+
+	sourceType := string(report.SourceType)
+	for _, d := range report.Detections {
+		row := []string{
+			sourceType,
+			report.SourceID,
+			d.Strategy,
+			d.Category,
+			d.Severity,
+			strconv.FormatFloat(d.Score, 'f', -1, 64),
+			strconv.FormatFloat(d.Confidence, 'f', -1, 64),
+			strconv.FormatBool(d.Detected),
+			singleLine(d.Description),
+		}
+		if err := cw.w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Flush writes any buffered rows to the underlying writer.
+func (cw *CSVWriter) Flush() error {
+	if err := cw.writeHeader(); err != nil {
+		return err
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *CSVWriter) writeHeader() error {
+	if cw.headerWritten {
+		return nil
+	}
+	cw.headerWritten = true
+	return cw.w.Write(CSVHeader)
+}
+
+// singleLine collapses line breaks and runs of whitespace into single spaces
+// so every detection stays on one CSV line.
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,112 @@
+package formats
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+func parseCSV(t *testing.T, output string) [][]string {
+	t.Helper()
+	rows, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, output)
+	}
+	return rows
+}
+
+func TestCSVReporter_FormatAnalysis(t *testing.T) {
+	report := &analysis.AnalysisReport{
+		SourceType: analysis.SourceTypeWeb,
+		SourceID:   "https://example.com",
+		Detections: []analysis.Detection{
+			{
+				Strategy:    "overused_phrases",
+				Detected:    true,
+				Severity:    "high",
+				Score:       0.85,
+				Confidence:  0.7,
+				Category:    "web-pattern",
+				Description: "Found \"delve\", \"tapestry\",\nand more\r\n  phrases",
+			},
+			{
+				Strategy:   "emoji_pattern_analysis",
+				Detected:   false,
+				Severity:   "none",
+				Category:   "web-pattern",
+				Confidence: 0.5,
+			},
+		},
+	}
+
+	output, err := (&CSVReporter{}).FormatAnalysis(report)
+	if err != nil {
+		t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+	}
+
+	rows := parseCSV(t, output)
+	if len(rows) != 3 {
+		t.Fatalf("rows = %d, want header + 2 detections", len(rows))
+	}
+	if strings.Join(rows[0], ",") != strings.Join(CSVHeader, ",") {
+		t.Errorf("header = %v, want %v", rows[0], CSVHeader)
+	}
+
+	want := []string{"web", "https://example.com", "overused_phrases", "web-pattern", "high", "0.85", "0.7", "true",
+		`Found "delve", "tapestry", and more phrases`}
+	if strings.Join(rows[1], "|") != strings.Join(want, "|") {
+		t.Errorf("row = %q, want %q", rows[1], want)
+	}
+	if rows[2][7] != "false" || rows[2][5] != "0" {
+		t.Errorf("passed detection row = %q", rows[2])
+	}
+	if strings.Count(output, "\n") != 3 {
+		t.Errorf("multi-line description should be collapsed, got:\n%s", output)
+	}
+}
+
+func TestCSVReporter_FormatAnalyses(t *testing.T) {
+	reports := []*analysis.AnalysisReport{
+		{
+			SourceType: analysis.SourceTypeGit,
+			SourceID:   "/repos/a",
+			Detections: []analysis.Detection{{Strategy: "velocity_analysis", Detected: true}},
+		},
+		nil,
+		{
+			SourceType: analysis.SourceTypeGit,
+			SourceID:   "/repos/b",
+			Detections: []analysis.Detection{
+				{Strategy: "size_analysis", Detected: true},
+				{Strategy: "timing_analysis"},
+			},
+		},
+	}
+
+	output, err := (&CSVReporter{}).FormatAnalyses(reports)
+	if err != nil {
+		t.Fatalf("FormatAnalyses() unexpected error = %v", err)
+	}
+
+	rows := parseCSV(t, output)
+	if len(rows) != 4 {
+		t.Fatalf("rows = %d, want one header + 3 detections", len(rows))
+	}
+	for i, wantSource := range []string{"/repos/a", "/repos/b", "/repos/b"} {
+		if rows[i+1][1] != wantSource {
+			t.Errorf("row %d source_id = %q, want %q", i+1, rows[i+1][1], wantSource)
+		}
+	}
+}
+
+func TestCSVWriter_EmptyStillWritesHeader(t *testing.T) {
+	var sb strings.Builder
+	if err := NewCSVWriter(&sb).Flush(); err != nil {
+		t.Fatalf("Flush() unexpected error = %v", err)
+	}
+	if got := strings.TrimSpace(sb.String()); got != strings.Join(CSVHeader, ",") {
+		t.Errorf("output = %q, want header only", got)
+	}
+}
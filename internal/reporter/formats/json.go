@@ -1,72 +1,170 @@
 package formats
 
 import (
+	"bytes"
 	"encoding/json"
+	"time"
 
 	"github.com/TryCadence/Cadence/internal/analysis"
 )
 
+// JSONSchemaVersion identifies the layout of JSONReport. Bump it on any
+// breaking change to field names or types.
+const JSONSchemaVersion = "1"
+
+// jsonTimeFormat is used for every timestamp in the report.
+const jsonTimeFormat = time.RFC3339Nano
+
 type JSONReporter struct{}
 
+type JSONDetection struct {
+	Strategy    string   `json:"strategy"`
+	Detected    bool     `json:"detected"`
+	Severity    string   `json:"severity"`
+	Score       float64  `json:"score"`
+	Confidence  float64  `json:"confidence"`
+	Category    string   `json:"category"`
+	Description string   `json:"description"`
+	Examples    []string `json:"examples,omitempty"`
+	Strategies  []string `json:"strategies,omitempty"` // strategies that fired on a git commit
+	Origin      string   `json:"origin,omitempty"`     // source type, in a multi-source report
+	// LowConfidence and Caveat flag a detection resting on a small baseline
+	LowConfidence bool   `json:"low_confidence,omitempty"`
+	Caveat        string `json:"caveat,omitempty"`
+}
+
+type JSONPhaseTiming struct {
+	Name       string  `json:"name"`
+	StartedAt  string  `json:"started_at"`
+	DurationMs float64 `json:"duration_ms"`
+}
+
+type JSONTiming struct {
+	StartedAt   string            `json:"started_at"`
+	CompletedAt string            `json:"completed_at"`
+	DurationMs  float64           `json:"duration_ms"`
+	DurationSec float64           `json:"duration_seconds"`
+	Phases      []JSONPhaseTiming `json:"phases"`
+}
+
+type JSONSourceMetrics struct {
+	ItemsAnalyzed  int                    `json:"items_analyzed"`
+	ItemsFlagged   int                    `json:"items_flagged"`
+	UniqueAuthors  int                    `json:"unique_authors"`
+	AverageScore   float64                `json:"average_score"`
+	CoverageRate   float64                `json:"coverage_rate"`
+	StrategiesUsed int                    `json:"strategies_used"`
+	StrategiesHit  int                    `json:"strategies_hit"`
+	Extra          map[string]interface{} `json:"extra,omitempty"`
+}
+
+// JSONSourceSummary is one source's part in a multi-source report.
+type JSONSourceSummary struct {
+	SourceType      string            `json:"source_type"`
+	SourceID        string            `json:"source_id"`
+	Weight          float64           `json:"weight"`
+	OverallScore    float64           `json:"overall_score"`
+	Assessment      string            `json:"assessment"`
+	TotalDetections int               `json:"total_detections"`
+	DetectionCount  int               `json:"detection_count"`
+	SourceMetrics   JSONSourceMetrics `json:"source_metrics"`
+}
+
+// JSONScores breaks the overall score into its heuristic and AI-validation
+// parts. Without AI validation, blended equals heuristic.
+type JSONScores struct {
+	Heuristic  float64 `json:"heuristic"`
+	AI         float64 `json:"ai"`
+	AIWeight   float64 `json:"ai_weight"`
+	AIReviewed int     `json:"ai_reviewed"`
+	Blended    float64 `json:"blended"`
+}
+
+// JSONReport is the document written by JSONReporter. Downstream tools can
+// decode it with ParseJSONReport.
+type JSONReport struct {
+	SchemaVersion       string                 `json:"schema_version"`
+	ID                  string                 `json:"id"`
+	SourceType          string                 `json:"source_type"`
+	SourceID            string                 `json:"source_id"`
+	AnalyzedAt          string                 `json:"analyzed_at"`
+	Timing              JSONTiming             `json:"timing"`
+	SourceMetrics       JSONSourceMetrics      `json:"source_metrics"`
+	OverallScore        float64                `json:"overall_score"`
+	Scores              JSONScores             `json:"scores"`
+	Assessment          string                 `json:"assessment"`
+	SuspicionRate       float64                `json:"suspicion_rate"`
+	TotalDetections     int                    `json:"total_detections"`
+	DetectionCount      int                    `json:"detection_count"`
+	PassedDetections    int                    `json:"passed_detections"`
+	HighSeverityCount   int                    `json:"high_severity_count"`
+	MediumSeverityCount int                    `json:"medium_severity_count"`
+	LowSeverityCount    int                    `json:"low_severity_count"`
+	Detections          []JSONDetection        `json:"detections"`
+	Metrics             map[string]interface{} `json:"metrics,omitempty"`
+	Error               string                 `json:"error,omitempty"`
+	MultiSource         bool                   `json:"multi_source,omitempty"`
+	Sources             []JSONSourceSummary    `json:"sources,omitempty"`
+}
+
+// ParseJSONReport decodes output produced by JSONReporter.
+func ParseJSONReport(data []byte) (*JSONReport, error) {
+	var report JSONReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// AnalysisReport converts a parsed report back to the analysis form, with
+// the fields JSONReporter writes. Metrics keep their decoded JSON values.
+func (r *JSONReport) AnalysisReport() *analysis.AnalysisReport {
+	detections := make([]analysis.Detection, len(r.Detections))
+	for i, d := range r.Detections {
+		detections[i] = analysis.Detection{
+			Strategy:    d.Strategy,
+			Detected:    d.Detected,
+			Severity:    d.Severity,
+			Score:       d.Score,
+			Confidence:  d.Confidence,
+			Category:    d.Category,
+			Description: d.Description,
+			Examples:    d.Examples,
+			Strategies:  d.Strategies,
+			Origin:      analysis.SourceType(d.Origin),
+
+			LowConfidence: d.LowConfidence,
+			Caveat:        d.Caveat,
+		}
+	}
+	analyzedAt, _ := time.Parse(jsonTimeFormat, r.AnalyzedAt)
+
+	return &analysis.AnalysisReport{
+		ID:                  r.ID,
+		SourceType:          analysis.SourceType(r.SourceType),
+		SourceID:            r.SourceID,
+		AnalyzedAt:          analyzedAt,
+		Duration:            time.Duration(r.Timing.DurationMs * float64(time.Millisecond)),
+		Detections:          detections,
+		OverallScore:        r.OverallScore,
+		Assessment:          r.Assessment,
+		SuspicionRate:       r.SuspicionRate,
+		TotalDetections:     r.TotalDetections,
+		DetectionCount:      r.DetectionCount,
+		PassedDetections:    r.PassedDetections,
+		HighSeverityCount:   r.HighSeverityCount,
+		MediumSeverityCount: r.MediumSeverityCount,
+		LowSeverityCount:    r.LowSeverityCount,
+		Metrics:             r.Metrics,
+		Error:               r.Error,
+		MultiSource:         r.MultiSource,
+	}
+}
+
 func (r *JSONReporter) FormatAnalysis(report *analysis.AnalysisReport) (string, error) {
-	type jsonDetection struct {
-		Strategy    string   `json:"strategy"`
-		Detected    bool     `json:"detected"`
-		Severity    string   `json:"severity"`
-		Score       float64  `json:"score"`
-		Confidence  float64  `json:"confidence"`
-		Category    string   `json:"category"`
-		Description string   `json:"description"`
-		Examples    []string `json:"examples,omitempty"`
-	}
-
-	type jsonPhaseTiming struct {
-		Name       string  `json:"name"`
-		StartedAt  string  `json:"startedAt"`
-		DurationMs float64 `json:"durationMs"`
-	}
-
-	type jsonTiming struct {
-		StartedAt   string            `json:"startedAt"`
-		CompletedAt string            `json:"completedAt"`
-		DurationMs  float64           `json:"durationMs"`
-		DurationSec float64           `json:"durationSeconds"`
-		Phases      []jsonPhaseTiming `json:"phases,omitempty"`
-	}
-
-	type jsonSourceMetrics struct {
-		ItemsAnalyzed  int                    `json:"itemsAnalyzed"`
-		ItemsFlagged   int                    `json:"itemsFlagged"`
-		UniqueAuthors  int                    `json:"uniqueAuthors,omitempty"`
-		AverageScore   float64                `json:"averageScore"`
-		CoverageRate   float64                `json:"coverageRate"`
-		StrategiesUsed int                    `json:"strategiesUsed"`
-		StrategiesHit  int                    `json:"strategiesHit"`
-		Extra          map[string]interface{} `json:"extra,omitempty"`
-	}
-
-	type jsonAnalysisReport struct {
-		ID                  string                 `json:"id"`
-		SourceType          string                 `json:"sourceType"`
-		SourceID            string                 `json:"sourceId"`
-		Timing              jsonTiming             `json:"timing"`
-		SourceMetrics       jsonSourceMetrics      `json:"sourceMetrics"`
-		Detections          []jsonDetection        `json:"detections"`
-		OverallScore        float64                `json:"overallScore"`
-		Assessment          string                 `json:"assessment"`
-		SuspicionRate       float64                `json:"suspicionRate"`
-		TotalDetections     int                    `json:"totalDetections"`
-		PassedDetections    int                    `json:"passedDetections"`
-		HighSeverityCount   int                    `json:"highSeverityCount"`
-		MediumSeverityCount int                    `json:"mediumSeverityCount"`
-		LowSeverityCount    int                    `json:"lowSeverityCount"`
-		Metrics             map[string]interface{} `json:"metrics,omitempty"`
-		Error               string                 `json:"error,omitempty"`
-	}
-
-	detections := make([]jsonDetection, len(report.Detections))
+	detections := make([]JSONDetection, len(report.Detections))
 	for i, d := range report.Detections {
-		detections[i] = jsonDetection{
+		detections[i] = JSONDetection{
 			Strategy:    d.Strategy,
 			Detected:    d.Detected,
 			Severity:    d.Severity,
@@ -75,51 +173,74 @@ func (r *JSONReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 			Category:    d.Category,
 			Description: d.Description,
 			Examples:    d.Examples,
+			Strategies:  d.Strategies,
+			Origin:      string(d.Origin),
+
+			LowConfidence: d.LowConfidence,
+			Caveat:        d.Caveat,
 		}
 	}
 
-	// Build phase timings
-	phases := make([]jsonPhaseTiming, len(report.Timing.Phases))
+	var sources []JSONSourceSummary
+	for _, src := range report.Sources {
+		sources = append(sources, JSONSourceSummary{
+			SourceType:      string(src.SourceType),
+			SourceID:        src.SourceID,
+			Weight:          src.Weight,
+			OverallScore:    src.OverallScore,
+			Assessment:      src.Assessment,
+			TotalDetections: src.TotalDetections,
+			DetectionCount:  src.DetectionCount,
+			SourceMetrics:   jsonSourceMetrics(src.SourceMetrics),
+		})
+	}
+
+	phases := make([]JSONPhaseTiming, len(report.Timing.Phases))
 	for i, p := range report.Timing.Phases {
-		phases[i] = jsonPhaseTiming{
+		phases[i] = JSONPhaseTiming{
 			Name:       p.Name,
-			StartedAt:  p.StartedAt.Format("2006-01-02T15:04:05.000Z"),
-			DurationMs: float64(p.Duration.Milliseconds()),
+			StartedAt:  formatJSONTime(p.StartedAt),
+			DurationMs: durationMs(p.Duration),
 		}
 	}
 
-	jr := jsonAnalysisReport{
-		ID:         report.ID,
-		SourceType: string(report.SourceType),
-		SourceID:   report.SourceID,
-		Timing: jsonTiming{
-			StartedAt:   report.Timing.StartedAt.Format("2006-01-02T15:04:05.000Z"),
-			CompletedAt: report.Timing.CompletedAt.Format("2006-01-02T15:04:05.000Z"),
-			DurationMs:  float64(report.Timing.Duration.Milliseconds()),
+	scores := JSONScores{
+		Heuristic:  report.Scores.Heuristic,
+		AI:         report.Scores.AI,
+		AIWeight:   report.Scores.AIWeight,
+		AIReviewed: report.Scores.AIReviewed,
+		Blended:    report.Scores.Blended,
+	}
+
+	jr := JSONReport{
+		SchemaVersion: JSONSchemaVersion,
+		ID:            report.ID,
+		SourceType:    string(report.SourceType),
+		SourceID:      report.SourceID,
+		AnalyzedAt:    formatJSONTime(report.AnalyzedAt),
+		Timing: JSONTiming{
+			StartedAt:   formatJSONTime(report.Timing.StartedAt),
+			CompletedAt: formatJSONTime(report.Timing.CompletedAt),
+			DurationMs:  durationMs(report.Timing.Duration),
 			DurationSec: report.Duration.Seconds(),
 			Phases:      phases,
 		},
-		SourceMetrics: jsonSourceMetrics{
-			ItemsAnalyzed:  report.SourceMetrics.ItemsAnalyzed,
-			ItemsFlagged:   report.SourceMetrics.ItemsFlagged,
-			UniqueAuthors:  report.SourceMetrics.UniqueAuthors,
-			AverageScore:   report.SourceMetrics.AverageScore,
-			CoverageRate:   report.SourceMetrics.CoverageRate,
-			StrategiesUsed: report.SourceMetrics.StrategiesUsed,
-			StrategiesHit:  report.SourceMetrics.StrategiesHit,
-			Extra:          report.SourceMetrics.Extra,
-		},
-		Detections:          detections,
+		SourceMetrics:       jsonSourceMetrics(report.SourceMetrics),
 		OverallScore:        report.OverallScore,
+		Scores:              scores,
 		Assessment:          report.Assessment,
 		SuspicionRate:       report.SuspicionRate,
 		TotalDetections:     report.TotalDetections,
+		DetectionCount:      report.DetectionCount,
 		PassedDetections:    report.PassedDetections,
 		HighSeverityCount:   report.HighSeverityCount,
 		MediumSeverityCount: report.MediumSeverityCount,
 		LowSeverityCount:    report.LowSeverityCount,
-		Metrics:             report.Metrics,
+		Detections:          detections,
+		Metrics:             jsonSafeMetrics(report.Metrics),
 		Error:               report.Error,
+		MultiSource:         report.MultiSource,
+		Sources:             sources,
 	}
 
 	data, err := json.MarshalIndent(jr, "", "  ")
@@ -129,3 +250,74 @@ func (r *JSONReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 
 	return string(data), nil
 }
+
+func jsonSourceMetrics(sm analysis.SourceMetrics) JSONSourceMetrics {
+	return JSONSourceMetrics{
+		ItemsAnalyzed:  sm.ItemsAnalyzed,
+		ItemsFlagged:   sm.ItemsFlagged,
+		UniqueAuthors:  sm.UniqueAuthors,
+		AverageScore:   sm.AverageScore,
+		CoverageRate:   sm.CoverageRate,
+		StrategiesUsed: sm.StrategiesUsed,
+		StrategiesHit:  sm.StrategiesHit,
+		Extra:          jsonSafeMetrics(sm.Extra),
+	}
+}
+
+func formatJSONTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(jsonTimeFormat)
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// rawSourceMetrics are source metadata keys that carry the analyzed input
+// itself, such as commit pairs, rather than a measurement of it.
+var rawSourceMetrics = map[string]bool{
+	"commit_pairs":   true,
+	"baseline_pairs": true,
+	"commits":        true,
+}
+
+// jsonSafeMetrics drops the raw source payloads from metrics, and any value
+// that cannot be encoded. Structured metrics such as baseline drift are kept,
+// converted to their decoded JSON form so a parsed report encodes back to the
+// same output.
+func jsonSafeMetrics(metrics map[string]interface{}) map[string]interface{} {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	out := make(map[string]interface{}, len(metrics))
+	for k, v := range metrics {
+		if rawSourceMetrics[k] {
+			continue
+		}
+		if plain, ok := plainJSONValue(v); ok {
+			out[k] = plain
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// plainJSONValue round-trips v through JSON, keeping numbers exact.
+func plainJSONValue(v interface{}) (interface{}, bool) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, false
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var plain interface{}
+	if err := dec.Decode(&plain); err != nil {
+		return nil, false
+	}
+	return plain, true
+}
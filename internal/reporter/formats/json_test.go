@@ -2,10 +2,13 @@ package formats
 
 import (
 	"encoding/json"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
 )
 
 func TestJSONReporter_FormatAnalysis(t *testing.T) {
@@ -121,8 +124,11 @@ func TestJSONReporter_FormatAnalysis(t *testing.T) {
 			if _, ok := result["id"]; !ok {
 				t.Error("Missing 'id' field in JSON output")
 			}
-			if _, ok := result["sourceType"]; !ok {
-				t.Error("Missing 'sourceType' field in JSON output")
+			if v := result["schema_version"]; v != JSONSchemaVersion {
+				t.Errorf("schema_version = %v, want %q", v, JSONSchemaVersion)
+			}
+			if _, ok := result["source_type"]; !ok {
+				t.Error("Missing 'source_type' field in JSON output")
 			}
 			if _, ok := result["detections"]; !ok {
 				t.Error("Missing 'detections' field in JSON output")
@@ -133,32 +139,146 @@ func TestJSONReporter_FormatAnalysis(t *testing.T) {
 			if !ok {
 				t.Error("Missing or invalid 'timing' object in JSON output")
 			} else {
-				if _, ok := timing["startedAt"]; !ok {
-					t.Error("Missing 'timing.startedAt' field")
+				if _, ok := timing["started_at"]; !ok {
+					t.Error("Missing 'timing.started_at' field")
 				}
-				if _, ok := timing["completedAt"]; !ok {
-					t.Error("Missing 'timing.completedAt' field")
+				if _, ok := timing["completed_at"]; !ok {
+					t.Error("Missing 'timing.completed_at' field")
 				}
-				if _, ok := timing["durationMs"]; !ok {
-					t.Error("Missing 'timing.durationMs' field")
+				if _, ok := timing["duration_ms"]; !ok {
+					t.Error("Missing 'timing.duration_ms' field")
 				}
-				if _, ok := timing["durationSeconds"]; !ok {
-					t.Error("Missing 'timing.durationSeconds' field")
+				if _, ok := timing["duration_seconds"]; !ok {
+					t.Error("Missing 'timing.duration_seconds' field")
 				}
 			}
 
 			// Check source metrics fields exist
-			sm, ok := result["sourceMetrics"].(map[string]interface{})
+			sm, ok := result["source_metrics"].(map[string]interface{})
 			if !ok {
-				t.Error("Missing or invalid 'sourceMetrics' object in JSON output")
+				t.Error("Missing or invalid 'source_metrics' object in JSON output")
 			} else {
-				if _, ok := sm["itemsAnalyzed"]; !ok {
-					t.Error("Missing 'sourceMetrics.itemsAnalyzed' field")
+				if _, ok := sm["items_analyzed"]; !ok {
+					t.Error("Missing 'source_metrics.items_analyzed' field")
 				}
-				if _, ok := sm["strategiesUsed"]; !ok {
-					t.Error("Missing 'sourceMetrics.strategiesUsed' field")
+				if _, ok := sm["strategies_used"]; !ok {
+					t.Error("Missing 'source_metrics.strategies_used' field")
 				}
 			}
 		})
 	}
 }
+
+func TestJSONReporter_RoundTrip(t *testing.T) {
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	report := &analysis.AnalysisReport{
+		ID:         "rt-001",
+		SourceType: analysis.SourceTypeGit,
+		SourceID:   "/repo",
+		AnalyzedAt: start,
+		Duration:   1500 * time.Millisecond,
+		Timing: analysis.TimingInfo{
+			StartedAt:   start,
+			CompletedAt: start.Add(1500 * time.Millisecond),
+			Duration:    1500 * time.Millisecond,
+			Phases:      []analysis.PhaseTiming{{Name: "detect", StartedAt: start, Duration: 250 * time.Microsecond}},
+		},
+		Detections: []analysis.Detection{
+			{Strategy: "size_analysis", Detected: true, Severity: "high", Score: 0.9, Confidence: 0.7, Category: "structural", Examples: []string{"abc123"}, Strategies: []string{"size_analysis"}},
+		},
+		OverallScore:    55,
+		Scores:          analysis.ScoreBreakdown{Heuristic: 40, AI: 70, AIWeight: 0.5, AIReviewed: 1, Blended: 55},
+		TotalDetections: 1,
+		DetectionCount:  1,
+		Metrics: map[string]interface{}{
+			"commit_count": 12,
+			"branch":       "main",
+			"commit_pairs": []*git.CommitPair{{}},
+			"commits":      []*git.Commit{{Hash: "abc123"}},
+			"baseline_drift": []analysis.BaselineDrift{
+				{Metric: "avg_additions", Historical: 100, Current: 300, Ratio: 3},
+			},
+		},
+	}
+
+	reporter := &JSONReporter{}
+	first, err := reporter.FormatAnalysis(report)
+	if err != nil {
+		t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+	}
+
+	parsed, err := ParseJSONReport([]byte(first))
+	if err != nil {
+		t.Fatalf("ParseJSONReport() unexpected error = %v", err)
+	}
+	if parsed.Timing.StartedAt != "2026-03-01T11:00:00Z" {
+		t.Errorf("timing.started_at = %q, want UTC RFC3339", parsed.Timing.StartedAt)
+	}
+	if parsed.Timing.Phases[0].DurationMs != 0.25 {
+		t.Errorf("phase duration_ms = %v, want 0.25", parsed.Timing.Phases[0].DurationMs)
+	}
+	for _, raw := range []string{"commit_pairs", "commits"} {
+		if _, ok := parsed.Metrics[raw]; ok {
+			t.Errorf("raw %s payload should not be serialized", raw)
+		}
+	}
+	if drift, ok := parsed.Metrics["baseline_drift"].([]interface{}); !ok || len(drift) != 1 {
+		t.Errorf("metrics.baseline_drift = %v, want the structured drift kept", parsed.Metrics["baseline_drift"])
+	}
+	if parsed.Scores != (JSONScores{Heuristic: 40, AI: 70, AIWeight: 0.5, AIReviewed: 1, Blended: 55}) {
+		t.Errorf("scores = %+v, want the heuristic, AI and blended parts", parsed.Scores)
+	}
+	if parsed.Metrics["branch"] != "main" {
+		t.Errorf("metrics.branch = %v, want main", parsed.Metrics["branch"])
+	}
+
+	back := parsed.AnalysisReport()
+	if !back.AnalyzedAt.Equal(start) || back.Duration != report.Duration || back.OverallScore != 55 {
+		t.Errorf("AnalysisReport() = analyzed %v, duration %v, score %v; want the original values", back.AnalyzedAt, back.Duration, back.OverallScore)
+	}
+	if !reflect.DeepEqual(back.Detections, report.Detections) {
+		t.Errorf("AnalysisReport().Detections = %+v, want %+v", back.Detections, report.Detections)
+	}
+
+	second, err := json.MarshalIndent(parsed, "", "  ")
+	if err != nil {
+		t.Fatalf("re-marshal failed: %v", err)
+	}
+	if string(second) != first {
+		t.Errorf("round-trip output differs:\nfirst:\n%s\nsecond:\n%s", first, second)
+	}
+
+	reparsed, _ := ParseJSONReport(second)
+	if !reflect.DeepEqual(parsed, reparsed) {
+		t.Error("decoded reports differ after round-trip")
+	}
+}
+
+func TestJSONReporter_MultiSource(t *testing.T) {
+	out, err := (&JSONReporter{}).FormatAnalysis(multiSourceReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParseJSONReport([]byte(out))
+	if err != nil {
+		t.Fatalf("ParseJSONReport: %v", err)
+	}
+
+	if !parsed.MultiSource || parsed.SourceType != "multi" || len(parsed.Sources) != 2 {
+		t.Fatalf("multi-source fields = %v %q %+v", parsed.MultiSource, parsed.SourceType, parsed.Sources)
+	}
+	if s := parsed.Sources[1]; s.SourceType != "web" || s.Weight != 0.5 || s.OverallScore != 20 {
+		t.Errorf("Sources[1] = %+v", s)
+	}
+	if parsed.Detections[0].Origin != "git" || parsed.Detections[1].Origin != "web" {
+		t.Errorf("detection origins = %q, %q", parsed.Detections[0].Origin, parsed.Detections[1].Origin)
+	}
+
+	single, err := (&JSONReporter{}).FormatAnalysis(&analysis.AnalysisReport{SourceType: analysis.SourceTypeGit})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(single, "multi_source") || strings.Contains(single, `"sources"`) {
+		t.Errorf("a single-source report should not carry multi-source fields:\n%s", single)
+	}
+}
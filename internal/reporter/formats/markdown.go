@@ -0,0 +1,114 @@
+package formats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+// MarkdownReporter writes a GitHub-flavored Markdown summary suitable for
+// pull request comments, issues and job summaries.
+type MarkdownReporter struct{}
+
+func (r *MarkdownReporter) FormatAnalysis(report *analysis.AnalysisReport) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Cadence Analysis Report (%s)\n\n", report.SourceType))
+
+	sb.WriteString("| | |\n|---|---|\n")
+	sb.WriteString(fmt.Sprintf("| Source | `%s` |\n", markdownCell(report.SourceID)))
+	sb.WriteString(fmt.Sprintf("| Assessment | **%s** |\n", markdownCell(report.Assessment)))
+	sb.WriteString(fmt.Sprintf("| Overall score | %.1f%% |\n", report.OverallScore))
+	if report.Scores.AIReviewed > 0 {
+		sb.WriteString(fmt.Sprintf("| Heuristic score | %.1f%% |\n", report.Scores.Heuristic))
+		sb.WriteString(fmt.Sprintf("| AI score | %.1f%% (%d reviewed, weight %.2f) |\n",
+			report.Scores.AI, report.Scores.AIReviewed, report.Scores.AIWeight))
+	}
+	sb.WriteString(fmt.Sprintf("| Suspicion rate | %.1f%% |\n", report.SuspicionRate*100))
+	sb.WriteString(fmt.Sprintf("| Duration | %s |\n\n", formatDurationPrecise(report.Timing.Duration)))
+
+	if report.MultiSource {
+		sb.WriteString("## Sources\n\n")
+		sb.WriteString("| Source | Score | Weight | Assessment | Detected |\n|---|---|---|---|---|\n")
+		for _, src := range report.Sources {
+			sb.WriteString(fmt.Sprintf("| %s `%s` | %.1f%% | %.2f | %s | %d of %d |\n",
+				src.SourceType, markdownCell(src.SourceID), src.OverallScore, src.Weight,
+				markdownCell(src.Assessment), src.DetectionCount, src.TotalDetections))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("## Statistics\n\n")
+	sb.WriteString("| Detected | Passed | High | Medium | Low |\n|---|---|---|---|---|\n")
+	sb.WriteString(fmt.Sprintf("| %d | %d | %d | %d | %d |\n\n",
+		report.DetectionCount, report.PassedDetections,
+		report.HighSeverityCount, report.MediumSeverityCount, report.LowSeverityCount))
+
+	heading := "##"
+	if report.MultiSource {
+		heading = "###"
+	}
+	for _, group := range detectionGroups(report) {
+		if source := string(group.Source); source != "" {
+			sb.WriteString(fmt.Sprintf("## %s Detections (`%s`)\n\n", strings.ToUpper(source[:1])+source[1:], markdownCell(group.SourceID)))
+		}
+		writeMarkdownDetections(&sb, heading, group.Detections)
+	}
+
+	if len(report.Metrics) > 0 {
+		keys := make([]string, 0, len(report.Metrics))
+		for key := range report.Metrics {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		sb.WriteString("<details>\n<summary>Additional metrics</summary>\n\n")
+		sb.WriteString("| Metric | Value |\n|---|---|\n")
+		for _, key := range keys {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", key, markdownCell(fmt.Sprint(report.Metrics[key]))))
+		}
+		sb.WriteString("\n</details>\n\n")
+	}
+
+	if report.Error != "" {
+		sb.WriteString(fmt.Sprintf("> **Error:** %s\n", singleLine(report.Error)))
+	}
+
+	return sb.String(), nil
+}
+
+// writeMarkdownDetections writes a table of fired detections per severity
+// under heading-level titles.
+func writeMarkdownDetections(sb *strings.Builder, heading string, detections []analysis.Detection) {
+	for _, severity := range []string{"high", "medium", "low"} {
+		var fired []analysis.Detection
+		for _, d := range detectionsBySeverity(detections, severity) {
+			if d.Detected {
+				fired = append(fired, d)
+			}
+		}
+		if len(fired) == 0 {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("%s %s Severity Detections\n\n", heading, strings.ToUpper(severity[:1])+severity[1:]))
+		sb.WriteString("| Strategy | Category | Score | Weight | Description |\n|---|---|---|---|---|\n")
+		for _, d := range fired {
+			description := markdownCell(d.Description)
+			if d.LowConfidence {
+				description += " _(" + markdownCell(d.Caveat) + ")_"
+			}
+			sb.WriteString(fmt.Sprintf("| `%s` | %s | %.0f%% | %.0f%% | %s |\n",
+				d.Strategy, d.Category, d.Score*100, d.Confidence*100, description))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// markdownCell makes s safe for a single table cell: one line, with pipes
+// escaped.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(singleLine(s), "|", `\|`)
+}
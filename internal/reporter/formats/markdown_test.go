@@ -0,0 +1,65 @@
+package formats
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+func TestMarkdownReporter_FormatAnalysis(t *testing.T) {
+	report := &analysis.AnalysisReport{
+		SourceType:        analysis.SourceTypeGit,
+		SourceID:          "/repos/example",
+		Assessment:        "Suspicious",
+		OverallScore:      64,
+		SuspicionRate:     0.25,
+		DetectionCount:    1,
+		HighSeverityCount: 1,
+		Detections: []analysis.Detection{
+			{Strategy: "size_analysis", Category: "volume", Detected: true, Severity: "high", Score: 0.8, Description: "500 additions | in one\ncommit"},
+			{Strategy: "timing_analysis", Detected: false, Severity: "medium"},
+		},
+		Metrics: map[string]interface{}{"commit_count": 4},
+	}
+
+	output, err := (&MarkdownReporter{}).FormatAnalysis(report)
+	if err != nil {
+		t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+	}
+
+	for _, want := range []string{
+		"# Cadence Analysis Report (git)",
+		"| Assessment | **Suspicious** |",
+		"| Overall score | 64.0% |",
+		"| Suspicion rate | 25.0% |",
+		"## High Severity Detections",
+		"| `size_analysis` | volume | 80% | 0% | 500 additions \\| in one commit |",
+		"| commit_count | 4 |",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "timing_analysis") || strings.Contains(output, "Medium Severity") {
+		t.Error("output should only list detections that fired")
+	}
+}
+
+func TestMarkdownReporter_MultiSource(t *testing.T) {
+	out, err := (&MarkdownReporter{}).FormatAnalysis(multiSourceReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"## Sources",
+		"| web `https://example.com` | 20.0% | 0.50 | Low Suspicion | 1 of 1 |",
+		"## Git Detections (`/repo/path`)\n\n### High Severity Detections",
+		"## Web Detections (`https://example.com`)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
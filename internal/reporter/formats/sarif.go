@@ -0,0 +1,282 @@
+package formats
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/version"
+)
+
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFReporter writes a SARIF 2.1.0 log for code-scanning tools such as
+// GitHub. Each strategy that fired on a commit becomes one result whose
+// ruleId is the strategy name, located at the files the commit changed with
+// the commit hash as the logical location.
+type SARIFReporter struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool              `json:"tool"`
+	Results    []sarifResult          `json:"results"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID                   string                 `json:"id"`
+	ShortDescription     sarifMessage           `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration     `json:"defaultConfiguration"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifResult struct {
+	RuleID              string                 `json:"ruleId"`
+	RuleIndex           int                    `json:"ruleIndex"`
+	Level               string                 `json:"level"`
+	Message             sarifMessage           `json:"message"`
+	Locations           []sarifLocation        `json:"locations,omitempty"`
+	PartialFingerprints map[string]string      `json:"partialFingerprints,omitempty"`
+	Properties          map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// sarifLevel maps Cadence severities onto SARIF result levels.
+func sarifLevel(severity string) string {
+	switch severity {
+	case "critical", "high":
+		return "error"
+	case "medium":
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+func (r *SARIFReporter) FormatAnalysis(report *analysis.AnalysisReport) (string, error) {
+	registry := analysis.DefaultGitRegistry()
+	if report.SourceType == analysis.SourceTypeWeb {
+		registry = analysis.DefaultWebRegistry()
+	}
+
+	infos := registry.All()
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+
+	rules := make([]sarifRule, 0, len(infos))
+	ruleIndex := make(map[string]int, len(infos))
+	for _, info := range infos {
+		ruleIndex[info.Name] = len(rules)
+		rules = append(rules, sarifRule{
+			ID:                   info.Name,
+			ShortDescription:     sarifMessage{Text: info.Description},
+			DefaultConfiguration: sarifConfiguration{Level: "warning"},
+			Properties: map[string]interface{}{
+				"category":   info.Category,
+				"confidence": info.Confidence,
+			},
+		})
+	}
+
+	// Detections from strategies outside the registry (plugins, or git
+	// detections without per-strategy attribution) get ad-hoc rules so every
+	// result references a rule.
+	indexFor := func(id, description string) int {
+		if i, ok := ruleIndex[id]; ok {
+			return i
+		}
+		ruleIndex[id] = len(rules)
+		rules = append(rules, sarifRule{
+			ID:                   id,
+			ShortDescription:     sarifMessage{Text: description},
+			DefaultConfiguration: sarifConfiguration{Level: "warning"},
+		})
+		return ruleIndex[id]
+	}
+
+	results := make([]sarifResult, 0)
+	for _, d := range report.Detections {
+		if !d.Detected {
+			continue
+		}
+
+		if report.SourceType == analysis.SourceTypeGit && len(d.Examples) > 0 {
+			results = append(results, gitSARIFResults(d, indexFor)...)
+			continue
+		}
+
+		result := sarifResult{
+			RuleID:    d.Strategy,
+			RuleIndex: indexFor(d.Strategy, d.Strategy),
+			Level:     sarifLevel(d.Severity),
+			Message:   sarifMessage{Text: nonEmpty(d.Description, d.Strategy)},
+			Properties: map[string]interface{}{
+				"score":      d.Score,
+				"confidence": d.Confidence,
+			},
+		}
+		if report.SourceID != "" {
+			result.Locations = []sarifLocation{{
+				PhysicalLocation: &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: report.SourceID}},
+			}}
+		}
+		if len(d.Examples) > 0 {
+			result.Properties["examples"] = d.Examples
+		}
+		addSARIFCaveat(&result, d)
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  SARIFSchema,
+		Version: SARIFVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "Cadence",
+				Version:        version.String(),
+				InformationURI: "https://github.com/TryCadence/Cadence",
+				Rules:          rules,
+			}},
+			Results: results,
+			Properties: map[string]interface{}{
+				"sourceType":    string(report.SourceType),
+				"sourceId":      report.SourceID,
+				"assessment":    report.Assessment,
+				"suspicionRate": report.SuspicionRate,
+			},
+		}},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// gitSARIFResults expands a commit detection into one result per fired
+// strategy. Examples[0] is the commit hash and Examples[1:] the reasons,
+// aligned with Strategies and optionally followed by the top files.
+func gitSARIFResults(d analysis.Detection, indexFor func(id, description string) int) []sarifResult {
+	hash := d.Examples[0]
+	reasons := d.Examples[1:]
+
+	commit := []sarifLogicalLocation{{Name: shortHash(hash), FullyQualifiedName: hash, Kind: "commit"}}
+	location := []sarifLocation{{LogicalLocations: commit}}
+	if len(d.Files) > 0 {
+		// Code-scanning tools only show results with a physical location.
+		location = make([]sarifLocation, len(d.Files))
+		for i, path := range d.Files {
+			location[i] = sarifLocation{
+				PhysicalLocation: &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: path}},
+				LogicalLocations: commit,
+			}
+		}
+	}
+
+	newResult := func(ruleID, message string) sarifResult {
+		result := sarifResult{
+			RuleID:              ruleID,
+			RuleIndex:           indexFor(ruleID, ruleID),
+			Level:               sarifLevel(d.Severity),
+			Message:             sarifMessage{Text: message},
+			Locations:           location,
+			PartialFingerprints: map[string]string{"commitHash/v1": hash},
+			Properties: map[string]interface{}{
+				"commit":        hash,
+				"commitMessage": d.Description,
+				"score":         d.Score,
+				"confidence":    d.Confidence,
+			},
+		}
+		addSARIFCaveat(&result, d)
+		return result
+	}
+
+	if len(d.Strategies) == 0 {
+		message := d.Description
+		if len(reasons) > 0 {
+			message = reasons[0]
+		}
+		return []sarifResult{newResult(d.Strategy, nonEmpty(message, d.Strategy))}
+	}
+
+	// A capped report may carry fewer reasons than strategies; the
+	// strategies past the last reason are reported by name.
+	results := make([]sarifResult, len(d.Strategies))
+	for i, strategy := range d.Strategies {
+		reason := ""
+		if i < len(reasons) {
+			reason = reasons[i]
+		}
+		results[i] = newResult(strategy, nonEmpty(reason, strategy))
+	}
+	return results
+}
+
+// addSARIFCaveat records in the result's properties that its detection rests
+// on a baseline too small to trust.
+func addSARIFCaveat(result *sarifResult, d analysis.Detection) {
+	if d.LowConfidence {
+		result.Properties["lowConfidence"] = true
+		result.Properties["caveat"] = d.Caveat
+	}
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 8 {
+		return hash[:8]
+	}
+	return hash
+}
+
+func nonEmpty(s, fallback string) string {
+	if s != "" {
+		return s
+	}
+	return fallback
+}
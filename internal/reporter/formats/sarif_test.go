@@ -0,0 +1,203 @@
+package formats
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+func TestSARIFReporter_FormatAnalysis(t *testing.T) {
+	report := &analysis.AnalysisReport{
+		SourceType: analysis.SourceTypeGit,
+		SourceID:   "/repos/example",
+		Detections: []analysis.Detection{
+			{
+				Strategy:    "git-velocity-analysis",
+				Detected:    true,
+				Severity:    "high",
+				Score:       0.8,
+				Confidence:  0.9,
+				Description: "Add feature",
+				Examples:    []string{"0123456789abcdef", "500 additions", "5s after previous commit", "Top files: main.go (+500/-0)"},
+				Strategies:  []string{"size_analysis", "timing_analysis"},
+				Files:       []string{"main.go", "internal/util.go"},
+			},
+			{
+				Strategy:    "git-velocity-analysis",
+				Detected:    true,
+				Severity:    "low",
+				Description: "Legacy detection",
+				Examples:    []string{"fedcba9876543210", "some reason"},
+			},
+			{Strategy: "velocity_analysis", Detected: false},
+		},
+	}
+
+	output, err := (&SARIFReporter{}).FormatAnalysis(report)
+	if err != nil {
+		t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+	}
+
+	var log struct {
+		Schema  string `json:"$schema"`
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID               string `json:"id"`
+						ShortDescription struct {
+							Text string `json:"text"`
+						} `json:"shortDescription"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				RuleIndex int    `json:"ruleIndex"`
+				Level     string `json:"level"`
+				Message   struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				Locations []struct {
+					PhysicalLocation *struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+						Kind               string `json:"kind"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal([]byte(output), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	if log.Version != "2.1.0" || log.Schema == "" {
+		t.Errorf("version/$schema = %q/%q, want 2.1.0 with a schema URI", log.Version, log.Schema)
+	}
+	if len(log.Runs) != 1 {
+		t.Fatalf("runs = %d, want 1", len(log.Runs))
+	}
+	run := log.Runs[0]
+	if run.Tool.Driver.Name != "Cadence" {
+		t.Errorf("driver name = %q, want Cadence", run.Tool.Driver.Name)
+	}
+
+	registry := analysis.DefaultGitRegistry()
+	seen := make(map[string]bool)
+	for _, rule := range run.Tool.Driver.Rules {
+		if seen[rule.ID] {
+			t.Errorf("duplicate rule %q", rule.ID)
+		}
+		seen[rule.ID] = true
+		if info, ok := registry.Get(rule.ID); ok && rule.ShortDescription.Text != info.Description {
+			t.Errorf("rule %q description = %q, want registry description", rule.ID, rule.ShortDescription.Text)
+		}
+	}
+	for _, info := range registry.All() {
+		if !seen[info.Name] {
+			t.Errorf("rules missing registry strategy %q", info.Name)
+		}
+	}
+
+	if len(run.Results) != 3 {
+		t.Fatalf("results = %d, want one per fired strategy plus the unattributed detection", len(run.Results))
+	}
+	want := []struct {
+		ruleID, level, message, hash string
+		files                        []string
+	}{
+		{"size_analysis", "error", "500 additions", "0123456789abcdef", []string{"main.go", "internal/util.go"}},
+		{"timing_analysis", "error", "5s after previous commit", "0123456789abcdef", []string{"main.go", "internal/util.go"}},
+		{"git-velocity-analysis", "note", "some reason", "fedcba9876543210", nil},
+	}
+	for i, w := range want {
+		res := run.Results[i]
+		if res.RuleID != w.ruleID || res.Level != w.level || res.Message.Text != w.message {
+			t.Errorf("result %d = %s/%s/%q, want %s/%s/%q", i, res.RuleID, res.Level, res.Message.Text, w.ruleID, w.level, w.message)
+		}
+		if res.RuleIndex < 0 || res.RuleIndex >= len(run.Tool.Driver.Rules) || run.Tool.Driver.Rules[res.RuleIndex].ID != res.RuleID {
+			t.Errorf("result %d ruleIndex %d does not point at rule %s", i, res.RuleIndex, res.RuleID)
+		}
+		if len(res.Locations) != max(len(w.files), 1) {
+			t.Fatalf("result %d has %d locations, want one per file %v", i, len(res.Locations), w.files)
+		}
+		for j, loc := range res.Locations {
+			if len(loc.LogicalLocations) != 1 || loc.LogicalLocations[0].FullyQualifiedName != w.hash || loc.LogicalLocations[0].Kind != "commit" {
+				t.Errorf("result %d location %d = %+v, want commit %s", i, j, loc, w.hash)
+			}
+			switch {
+			case w.files == nil && loc.PhysicalLocation != nil:
+				t.Errorf("result %d location %d has a physical location without changed files", i, j)
+			case w.files != nil && (loc.PhysicalLocation == nil || loc.PhysicalLocation.ArtifactLocation.URI != w.files[j]):
+				t.Errorf("result %d location %d = %+v, want file %s", i, j, loc.PhysicalLocation, w.files[j])
+			}
+		}
+	}
+}
+
+func TestSARIFLevel(t *testing.T) {
+	for severity, want := range map[string]string{"critical": "error", "high": "error", "medium": "warning", "low": "note", "": "note"} {
+		if got := sarifLevel(severity); got != want {
+			t.Errorf("sarifLevel(%q) = %q, want %q", severity, got, want)
+		}
+	}
+}
+
+func TestSARIFReporter_CappedExamples(t *testing.T) {
+	report := &analysis.AnalysisReport{
+		SourceType: analysis.SourceTypeGit,
+		Detections: []analysis.Detection{{
+			Strategy:    "git-velocity-analysis",
+			Detected:    true,
+			Severity:    "high",
+			Description: "Add feature",
+			Examples:    []string{"0123456789abcdef", "500 additions", "5s after previous commit", "Top files: main.go (+500/-0)"},
+			Strategies:  []string{"size_analysis", "timing_analysis"},
+		}},
+	}
+	report = analysis.ExamplePolicy{MaxExamples: 1}.Apply(report)
+
+	output, err := (&SARIFReporter{}).FormatAnalysis(report)
+	if err != nil {
+		t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+	}
+	var log struct {
+		Runs []struct {
+			Results []struct {
+				RuleID  string `json:"ruleId"`
+				Message struct {
+					Text string `json:"text"`
+				} `json:"message"`
+				PartialFingerprints map[string]string `json:"partialFingerprints"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal([]byte(output), &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	results := log.Runs[0].Results
+	want := []struct{ ruleID, message string }{
+		{"size_analysis", "500 additions"},
+		{"timing_analysis", "timing_analysis"},
+	}
+	if len(results) != len(want) {
+		t.Fatalf("results = %d, want one per fired strategy", len(results))
+	}
+	for i, w := range want {
+		if results[i].RuleID != w.ruleID || results[i].Message.Text != w.message {
+			t.Errorf("result %d = %s/%q, want %s/%q", i, results[i].RuleID, results[i].Message.Text, w.ruleID, w.message)
+		}
+		if results[i].PartialFingerprints["commitHash/v1"] != "0123456789abcdef" {
+			t.Errorf("result %d fingerprints = %v, want the commit hash", i, results[i].PartialFingerprints)
+		}
+	}
+}
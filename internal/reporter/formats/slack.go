@@ -0,0 +1,144 @@
+package formats
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+// Slack Block Kit limits the payload has to stay within.
+const (
+	slackMaxHeaderText  = 150
+	slackMaxSectionText = 3000
+	slackMaxContextText = 2000
+	// SlackMaxDetections is how many top detections become context blocks.
+	SlackMaxDetections = 5
+)
+
+// SlackReporter writes a Block Kit payload that can be posted to a Slack
+// incoming webhook or chat.postMessage as-is: a header with the assessment,
+// a summary section with severity counts, and the top detections.
+type SlackReporter struct{}
+
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string       `json:"type"`
+	Text     *slackText   `json:"text,omitempty"`
+	Fields   []*slackText `json:"fields,omitempty"`
+	Elements []*slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func plainText(s string, maxLen int) *slackText {
+	return &slackText{Type: "plain_text", Text: truncateRunes(s, maxLen)}
+}
+
+func mrkdwn(s string, maxLen int) *slackText {
+	return &slackText{Type: "mrkdwn", Text: truncateRunes(s, maxLen)}
+}
+
+func (r *SlackReporter) FormatAnalysis(report *analysis.AnalysisReport) (string, error) {
+	assessment := report.Assessment
+	if assessment == "" {
+		assessment = "No assessment"
+	}
+	summary := fmt.Sprintf("Cadence %s analysis of %s: %s", report.SourceType, report.SourceID, assessment)
+
+	blocks := []slackBlock{
+		{Type: "header", Text: plainText("Cadence: "+assessment, slackMaxHeaderText)},
+		{
+			Type: "section",
+			Text: mrkdwn(fmt.Sprintf("*Source:* %s (%s)\n*Overall score:* %.1f%%   *Suspicion rate:* %.1f%%",
+				slackEscape(report.SourceID), report.SourceType, report.OverallScore, report.SuspicionRate*100), slackMaxSectionText),
+			Fields: []*slackText{
+				mrkdwn(fmt.Sprintf("*High:* %d", report.HighSeverityCount), slackMaxContextText),
+				mrkdwn(fmt.Sprintf("*Medium:* %d", report.MediumSeverityCount), slackMaxContextText),
+				mrkdwn(fmt.Sprintf("*Low:* %d", report.LowSeverityCount), slackMaxContextText),
+			},
+		},
+	}
+
+	top := topDetections(report.Detections)
+	if len(top) > 0 {
+		blocks = append(blocks, slackBlock{Type: "divider"})
+	}
+	for i, d := range top {
+		if i == SlackMaxDetections {
+			blocks = append(blocks, slackBlock{
+				Type:     "context",
+				Elements: []*slackText{mrkdwn(fmt.Sprintf("_…and %d more detections_", len(top)-i), slackMaxContextText)},
+			})
+			break
+		}
+		line := fmt.Sprintf("*%s* `%s` (%.0f%%) %s", strings.ToUpper(d.Severity), d.Strategy, d.Score*100, slackEscape(singleLine(d.Description)))
+		blocks = append(blocks, slackBlock{
+			Type:     "context",
+			Elements: []*slackText{mrkdwn(line, slackMaxContextText)},
+		})
+	}
+
+	output, err := json.MarshalIndent(slackMessage{Text: summary, Blocks: blocks}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(output), nil
+}
+
+// topDetections returns the detections that fired, most severe and highest
+// scoring first.
+func topDetections(detections []analysis.Detection) []analysis.Detection {
+	var fired []analysis.Detection
+	for _, d := range detections {
+		if d.Detected {
+			fired = append(fired, d)
+		}
+	}
+	sort.SliceStable(fired, func(i, j int) bool {
+		ri, rj := severityRank(fired[i].Severity), severityRank(fired[j].Severity)
+		if ri != rj {
+			return ri > rj
+		}
+		return fired[i].Score > fired[j].Score
+	})
+	return fired
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case "critical":
+		return 3
+	case "high":
+		return 2
+	case "medium":
+		return 1
+	default:
+		return 0
+	}
+}
+
+// slackEscape escapes the characters Slack treats as control sequences in
+// mrkdwn text.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// truncateRunes shortens s to at most maxLen characters without splitting a
+// multi-byte character, ending in an ellipsis when cut.
+func truncateRunes(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	return string(runes[:maxLen-1]) + "…"
+}
@@ -0,0 +1,114 @@
+package formats
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+func TestSlackReporter_FormatAnalysis(t *testing.T) {
+	report := &analysis.AnalysisReport{
+		SourceType:          analysis.SourceTypeGit,
+		SourceID:            "/repos/<example>",
+		Assessment:          "Likely AI-generated",
+		OverallScore:        72.5,
+		SuspicionRate:       0.4,
+		HighSeverityCount:   2,
+		MediumSeverityCount: 3,
+		LowSeverityCount:    4,
+		Detections: []analysis.Detection{
+			{Strategy: "passed", Detected: false, Severity: "high", Score: 1},
+			{Strategy: "low_one", Detected: true, Severity: "low", Score: 0.9},
+			{Strategy: "high_one", Detected: true, Severity: "high", Score: 0.5, Description: "big\ncommit"},
+			{Strategy: "high_two", Detected: true, Severity: "high", Score: 0.8},
+			{Strategy: "medium_one", Detected: true, Severity: "medium", Score: 0.7},
+			{Strategy: "medium_two", Detected: true, Severity: "medium", Score: 0.6},
+			{Strategy: "low_two", Detected: true, Severity: "low", Score: 0.1},
+			{Strategy: "low_three", Detected: true, Severity: "low", Score: 0.2},
+		},
+	}
+
+	output, err := (&SlackReporter{}).FormatAnalysis(report)
+	if err != nil {
+		t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+	}
+
+	var msg slackMessage
+	if err := json.Unmarshal([]byte(output), &msg); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if msg.Text == "" {
+		t.Error("missing fallback text")
+	}
+
+	// header, section, divider, 5 detections, "more" note
+	if len(msg.Blocks) != 9 {
+		t.Fatalf("got %d blocks, want 9", len(msg.Blocks))
+	}
+	if msg.Blocks[0].Type != "header" || msg.Blocks[0].Text.Text != "Cadence: Likely AI-generated" {
+		t.Errorf("header = %+v", msg.Blocks[0])
+	}
+	section := msg.Blocks[1]
+	if !strings.Contains(section.Text.Text, "72.5%") || !strings.Contains(section.Text.Text, "40.0%") {
+		t.Errorf("section text %q missing score or suspicion rate", section.Text.Text)
+	}
+	if !strings.Contains(section.Text.Text, "&lt;example&gt;") {
+		t.Errorf("section text %q does not escape the source ID", section.Text.Text)
+	}
+	if len(section.Fields) != 3 || section.Fields[0].Text != "*High:* 2" || section.Fields[2].Text != "*Low:* 4" {
+		t.Errorf("section fields = %+v", section.Fields)
+	}
+
+	wantOrder := []string{"high_two", "high_one", "medium_one", "medium_two", "low_one"}
+	for i, name := range wantOrder {
+		block := msg.Blocks[3+i]
+		if block.Type != "context" || !strings.Contains(block.Elements[0].Text, "`"+name+"`") {
+			t.Errorf("detection block %d = %q, want %s", i, block.Elements[0].Text, name)
+		}
+	}
+	if strings.Contains(msg.Blocks[4].Elements[0].Text, "\n") {
+		t.Error("detection description should be collapsed to one line")
+	}
+	if !strings.Contains(msg.Blocks[8].Elements[0].Text, "2 more") {
+		t.Errorf("last block = %q, want a note about 2 more detections", msg.Blocks[8].Elements[0].Text)
+	}
+}
+
+func TestSlackReporter_TruncatesToBlockLimits(t *testing.T) {
+	report := &analysis.AnalysisReport{
+		SourceType: analysis.SourceTypeWeb,
+		SourceID:   strings.Repeat("é", 4000),
+		Assessment: strings.Repeat("ü", 300),
+		Detections: []analysis.Detection{
+			{Strategy: "long", Detected: true, Severity: "high", Description: strings.Repeat("ß", 5000)},
+		},
+	}
+
+	output, err := (&SlackReporter{}).FormatAnalysis(report)
+	if err != nil {
+		t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+	}
+	var msg slackMessage
+	if err := json.Unmarshal([]byte(output), &msg); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+
+	limits := map[string]int{"header": slackMaxHeaderText, "section": slackMaxSectionText, "context": slackMaxContextText}
+	for _, block := range msg.Blocks {
+		texts := append([]*slackText{block.Text}, block.Elements...)
+		for _, text := range texts {
+			if text == nil {
+				continue
+			}
+			if !utf8.ValidString(text.Text) {
+				t.Errorf("%s block text is not valid UTF-8", block.Type)
+			}
+			if n := utf8.RuneCountInString(text.Text); n > limits[block.Type] {
+				t.Errorf("%s block text has %d characters, limit %d", block.Type, n, limits[block.Type])
+			}
+		}
+	}
+}
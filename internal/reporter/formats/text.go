@@ -66,9 +66,26 @@ func (r *TextReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 	sb.WriteString("ASSESSMENT\n")
 	sb.WriteString("─────────────────────────────────────────────────────────────\n")
 	sb.WriteString(fmt.Sprintf("Overall Score:  %.1f%%\n", report.OverallScore))
+	if report.Scores.AIReviewed > 0 {
+		sb.WriteString(fmt.Sprintf("  ├─ Heuristic:  %.1f%%\n", report.Scores.Heuristic))
+		sb.WriteString(fmt.Sprintf("  └─ AI:         %.1f%% (%d reviewed, weight %.2f)\n",
+			report.Scores.AI, report.Scores.AIReviewed, report.Scores.AIWeight))
+	}
 	sb.WriteString(fmt.Sprintf("Assessment:     %s\n", report.Assessment))
 	sb.WriteString(fmt.Sprintf("Suspicion Rate: %.1f%%\n\n", report.SuspicionRate*100))
 
+	if report.MultiSource {
+		sb.WriteString("─────────────────────────────────────────────────────────────\n")
+		sb.WriteString("SOURCES\n")
+		sb.WriteString("─────────────────────────────────────────────────────────────\n")
+		for _, src := range report.Sources {
+			sb.WriteString(fmt.Sprintf("• %s: %s\n", src.SourceType, src.SourceID))
+			sb.WriteString(fmt.Sprintf("  Score %.1f%% (weight %.2f), %s, %d of %d detections\n",
+				src.OverallScore, src.Weight, src.Assessment, src.DetectionCount, src.TotalDetections))
+		}
+		sb.WriteString("\n")
+	}
+
 	sb.WriteString("─────────────────────────────────────────────────────────────\n")
 	sb.WriteString("STATISTICS\n")
 	sb.WriteString("─────────────────────────────────────────────────────────────\n")
@@ -100,9 +117,78 @@ func (r *TextReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 	}
 	sb.WriteString("\n")
 
-	highSev := report.GetDetectionsBySeverity("high")
-	mediumSev := report.GetDetectionsBySeverity("medium")
-	lowSev := report.GetDetectionsBySeverity("low")
+	for _, group := range detectionGroups(report) {
+		if group.Source != "" {
+			sb.WriteString("═══════════════════════════════════════════════════════════\n")
+			sb.WriteString(fmt.Sprintf("%s DETECTIONS - %s\n", strings.ToUpper(string(group.Source)), group.SourceID))
+			sb.WriteString("═══════════════════════════════════════════════════════════\n\n")
+		}
+		writeTextDetections(&sb, group.Detections)
+	}
+
+	if len(report.Metrics) > 0 {
+		sb.WriteString("─────────────────────────────────────────────────────────────\n")
+		sb.WriteString("ADDITIONAL METRICS\n")
+		sb.WriteString("─────────────────────────────────────────────────────────────\n")
+		for key, value := range report.Metrics {
+			sb.WriteString(fmt.Sprintf("%s: %v\n", key, value))
+		}
+		sb.WriteString("\n")
+	}
+
+	if report.Error != "" {
+		sb.WriteString("─────────────────────────────────────────────────────────────\n")
+		sb.WriteString("ERROR\n")
+		sb.WriteString("─────────────────────────────────────────────────────────────\n")
+		sb.WriteString(fmt.Sprintf("%s\n\n", report.Error))
+	}
+
+	sb.WriteString("═══════════════════════════════════════════════════════════\n")
+
+	return sb.String(), nil
+}
+
+// detectionGroup is the detections of one source of a report.
+type detectionGroup struct {
+	Source     analysis.SourceType // empty for a single-source report
+	SourceID   string
+	Detections []analysis.Detection
+}
+
+// detectionGroups splits a multi-source report's detections by origin, in
+// the order of its sources. A single-source report is one untitled group.
+func detectionGroups(report *analysis.AnalysisReport) []detectionGroup {
+	if !report.MultiSource {
+		return []detectionGroup{{Detections: report.Detections}}
+	}
+	groups := make([]detectionGroup, len(report.Sources))
+	for i, src := range report.Sources {
+		groups[i] = detectionGroup{Source: src.SourceType, SourceID: src.SourceID}
+		for _, d := range report.Detections {
+			if d.Origin == src.SourceType {
+				groups[i].Detections = append(groups[i].Detections, d)
+			}
+		}
+	}
+	return groups
+}
+
+func detectionsBySeverity(detections []analysis.Detection, severity string) []analysis.Detection {
+	var filtered []analysis.Detection
+	for _, d := range detections {
+		if d.Severity == severity {
+			filtered = append(filtered, d)
+		}
+	}
+	return filtered
+}
+
+// writeTextDetections writes the fired detections as one section per
+// severity.
+func writeTextDetections(sb *strings.Builder, detections []analysis.Detection) {
+	highSev := detectionsBySeverity(detections, "high")
+	mediumSev := detectionsBySeverity(detections, "medium")
+	lowSev := detectionsBySeverity(detections, "low")
 
 	if len(highSev) > 0 {
 		sb.WriteString("─────────────────────────────────────────────────────────────\n")
@@ -112,6 +198,7 @@ func (r *TextReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 			if d.Detected {
 				sb.WriteString(fmt.Sprintf("• %s [%s] (%.0f%% score, %.0f%% weight)\n", d.Strategy, d.Category, d.Score*100, d.Confidence*100))
 				sb.WriteString(fmt.Sprintf("  %s\n", d.Description))
+				writeTextCaveat(sb, d)
 				if len(d.Examples) > 0 {
 					examplesStr := strings.Join(d.Examples[:min(len(d.Examples), 2)], ", ")
 					sb.WriteString(fmt.Sprintf("  Examples: %s\n", examplesStr))
@@ -129,6 +216,7 @@ func (r *TextReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 			if d.Detected {
 				sb.WriteString(fmt.Sprintf("• %s [%s] (%.0f%% score, %.0f%% weight)\n", d.Strategy, d.Category, d.Score*100, d.Confidence*100))
 				sb.WriteString(fmt.Sprintf("  %s\n", d.Description))
+				writeTextCaveat(sb, d)
 				if len(d.Examples) > 0 {
 					examplesStr := strings.Join(d.Examples[:min(len(d.Examples), 2)], ", ")
 					sb.WriteString(fmt.Sprintf("  Examples: %s\n", examplesStr))
@@ -145,31 +233,20 @@ func (r *TextReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 		for _, d := range lowSev {
 			if d.Detected {
 				sb.WriteString(fmt.Sprintf("• %s [%s] (%.0f%% score, %.0f%% weight)\n", d.Strategy, d.Category, d.Score*100, d.Confidence*100))
-				sb.WriteString(fmt.Sprintf("  %s\n\n", d.Description))
+				sb.WriteString(fmt.Sprintf("  %s\n", d.Description))
+				writeTextCaveat(sb, d)
+				sb.WriteString("\n")
 			}
 		}
 	}
+}
 
-	if len(report.Metrics) > 0 {
-		sb.WriteString("─────────────────────────────────────────────────────────────\n")
-		sb.WriteString("ADDITIONAL METRICS\n")
-		sb.WriteString("─────────────────────────────────────────────────────────────\n")
-		for key, value := range report.Metrics {
-			sb.WriteString(fmt.Sprintf("%s: %v\n", key, value))
-		}
-		sb.WriteString("\n")
-	}
-
-	if report.Error != "" {
-		sb.WriteString("─────────────────────────────────────────────────────────────\n")
-		sb.WriteString("ERROR\n")
-		sb.WriteString("─────────────────────────────────────────────────────────────\n")
-		sb.WriteString(fmt.Sprintf("%s\n\n", report.Error))
+// writeTextCaveat notes that a detection rests on a baseline too small to
+// trust.
+func writeTextCaveat(sb *strings.Builder, d analysis.Detection) {
+	if d.LowConfidence {
+		sb.WriteString(fmt.Sprintf("  Caveat: %s\n", d.Caveat))
 	}
-
-	sb.WriteString("═══════════════════════════════════════════════════════════\n")
-
-	return sb.String(), nil
 }
 
 func min(a, b int) int {
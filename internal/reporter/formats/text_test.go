@@ -100,6 +100,7 @@ func TestTextReporter_FormatAnalysis(t *testing.T) {
 					},
 				},
 				OverallScore:      85,
+				Scores:            analysis.ScoreBreakdown{Heuristic: 80, AI: 90, AIWeight: 0.5, AIReviewed: 1, Blended: 85},
 				Assessment:        "Suspicious activity detected",
 				SuspicionRate:     1.0,
 				TotalDetections:   1,
@@ -117,6 +118,8 @@ func TestTextReporter_FormatAnalysis(t *testing.T) {
 				"Completed At:",
 				"SOURCE METRICS",
 				"Unique Authors:",
+				"Heuristic:  80.0%",
+				"AI:         90.0% (1 reviewed, weight 0.50)",
 			},
 		},
 	}
@@ -147,3 +150,88 @@ func TestTextReporter_FormatAnalysis(t *testing.T) {
 		})
 	}
 }
+
+// multiSourceReport merges a git and a web report with one detection each.
+func multiSourceReport() *analysis.AnalysisReport {
+	return analysis.MergeReports(nil,
+		&analysis.AnalysisReport{
+			SourceType: analysis.SourceTypeGit, SourceID: "/repo/path", OverallScore: 60,
+			TotalDetections: 1, DetectionCount: 1, HighSeverityCount: 1, Assessment: "Moderate Suspicion",
+			Detections: []analysis.Detection{{Strategy: "burst_pattern", Detected: true, Severity: "high", Description: "Commit burst"}},
+		},
+		&analysis.AnalysisReport{
+			SourceType: analysis.SourceTypeWeb, SourceID: "https://example.com", OverallScore: 20,
+			TotalDetections: 1, DetectionCount: 1, HighSeverityCount: 1, Assessment: "Low Suspicion",
+			Detections: []analysis.Detection{{Strategy: "overused_phrases", Detected: true, Severity: "high", Description: "Stock phrases"}},
+		},
+	)
+}
+
+func TestTextReporter_MultiSource(t *testing.T) {
+	out, err := (&TextReporter{}).FormatAnalysis(multiSourceReport())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"CADENCE ANALYSIS REPORT - multi",
+		"Overall Score:  40.0%",
+		"• git: /repo/path\n  Score 60.0% (weight 0.50)",
+		"GIT DETECTIONS - /repo/path",
+		"WEB DETECTIONS - https://example.com",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+	gitSection := strings.Index(out, "GIT DETECTIONS")
+	webSection := strings.Index(out, "WEB DETECTIONS")
+	if burst := strings.Index(out, "burst_pattern"); burst < gitSection || burst > webSection {
+		t.Error("git detections should be listed under the git section")
+	}
+	if phrases := strings.Index(out, "overused_phrases"); phrases < webSection {
+		t.Error("web detections should be listed under the web section")
+	}
+}
+
+func TestFormatAnalysis_LowConfidence(t *testing.T) {
+	report := &analysis.AnalysisReport{
+		SourceType: analysis.SourceTypeGit,
+		SourceID:   "/repos/example",
+		Detections: []analysis.Detection{{
+			Strategy:      "git-velocity-analysis",
+			Detected:      true,
+			Severity:      "high",
+			Score:         0.8,
+			Description:   "Add feature",
+			Examples:      []string{"0123456789abcdef", "Statistical anomalies detected"},
+			Strategies:    []string{"StatisticalAnomaly"},
+			LowConfidence: true,
+			Caveat:        "low confidence: baseline has only 8 commits",
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		reporter interface {
+			FormatAnalysis(*analysis.AnalysisReport) (string, error)
+		}
+		want string
+	}{
+		{"text", &TextReporter{}, "  Caveat: low confidence: baseline has only 8 commits"},
+		{"markdown", &MarkdownReporter{}, "Add feature _(low confidence: baseline has only 8 commits)_"},
+		{"sarif", &SARIFReporter{}, `"lowConfidence": true`},
+		{"json", &JSONReporter{}, `"caveat": "low confidence: baseline has only 8 commits"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := tt.reporter.FormatAnalysis(report)
+			if err != nil {
+				t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+			}
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("output missing %q:\n%s", tt.want, output)
+			}
+		})
+	}
+}
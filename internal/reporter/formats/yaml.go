@@ -44,6 +44,14 @@ func (r *YAMLReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 		Extra          map[string]interface{} `yaml:"extra,omitempty"`
 	}
 
+	type yamlScores struct {
+		Heuristic  float64 `yaml:"heuristic"`
+		AI         float64 `yaml:"ai"`
+		AIWeight   float64 `yaml:"ai_weight"`
+		AIReviewed int     `yaml:"ai_reviewed"`
+		Blended    float64 `yaml:"blended"`
+	}
+
 	type yamlAnalysisReport struct {
 		ID                  string                 `yaml:"id"`
 		SourceType          string                 `yaml:"source_type"`
@@ -52,6 +60,7 @@ func (r *YAMLReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 		SourceMetrics       yamlSourceMetrics      `yaml:"source_metrics"`
 		Detections          []yamlDetection        `yaml:"detections"`
 		OverallScore        float64                `yaml:"overall_score"`
+		Scores              yamlScores             `yaml:"scores"`
 		Assessment          string                 `yaml:"assessment"`
 		SuspicionRate       float64                `yaml:"suspicion_rate"`
 		TotalDetections     int                    `yaml:"total_detections"`
@@ -86,6 +95,14 @@ func (r *YAMLReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 		}
 	}
 
+	scores := yamlScores{
+		Heuristic:  report.Scores.Heuristic,
+		AI:         report.Scores.AI,
+		AIWeight:   report.Scores.AIWeight,
+		AIReviewed: report.Scores.AIReviewed,
+		Blended:    report.Scores.Blended,
+	}
+
 	yr := yamlAnalysisReport{
 		ID:         report.ID,
 		SourceType: string(report.SourceType),
@@ -109,6 +126,7 @@ func (r *YAMLReporter) FormatAnalysis(report *analysis.AnalysisReport) (string,
 		},
 		Detections:          detections,
 		OverallScore:        report.OverallScore,
+		Scores:              scores,
 		Assessment:          report.Assessment,
 		SuspicionRate:       report.SuspicionRate,
 		TotalDetections:     report.TotalDetections,
@@ -2,6 +2,7 @@ package reporter
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/TryCadence/Cadence/internal/analysis"
 	"github.com/TryCadence/Cadence/internal/reporter/formats"
@@ -11,6 +12,10 @@ type AnalysisFormatter interface {
 	FormatAnalysis(report *analysis.AnalysisReport) (string, error)
 }
 
+// Formats lists the format names NewAnalysisFormatter accepts, not counting
+// aliases such as yml and md.
+var Formats = []string{"text", "json", "html", "markdown", "yaml", "bson", "csv", "sarif", "slack"}
+
 func NewAnalysisFormatter(format string) (AnalysisFormatter, error) {
 	switch format {
 	case "text":
@@ -19,11 +24,59 @@ func NewAnalysisFormatter(format string) (AnalysisFormatter, error) {
 		return &formats.JSONReporter{}, nil
 	case "html":
 		return &formats.HTMLReporter{}, nil
+	case "markdown", "md":
+		return &formats.MarkdownReporter{}, nil
 	case "yaml", "yml":
 		return &formats.YAMLReporter{}, nil
 	case "bson":
 		return &formats.BSONReporter{}, nil
+	case "csv":
+		return &formats.CSVReporter{}, nil
+	case "sarif":
+		return &formats.SARIFReporter{}, nil
+	case "slack":
+		return &formats.SlackReporter{}, nil
 	default:
-		return nil, fmt.Errorf("unsupported report format: %s", format)
+		return nil, fmt.Errorf("unsupported report format: %s (valid formats: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// sanitizingFormatter applies an example policy before formatting.
+type sanitizingFormatter struct {
+	AnalysisFormatter
+	policy analysis.ExamplePolicy
+}
+
+func (f sanitizingFormatter) FormatAnalysis(report *analysis.AnalysisReport) (string, error) {
+	return f.AnalysisFormatter.FormatAnalysis(f.policy.Apply(report))
+}
+
+// labelingFormatter rewords assessments before formatting.
+type labelingFormatter struct {
+	AnalysisFormatter
+	labels analysis.AssessmentLabels
+}
+
+func (f labelingFormatter) FormatAnalysis(report *analysis.AnalysisReport) (string, error) {
+	return f.AnalysisFormatter.FormatAnalysis(f.labels.Apply(report))
+}
+
+// WithAssessmentLabels returns a formatter that rewords the report's
+// assessments with labels (assessment_labels) before f formats it. Without
+// labels it returns f itself.
+func WithAssessmentLabels(f AnalysisFormatter, labels analysis.AssessmentLabels) AnalysisFormatter {
+	if len(labels) == 0 {
+		return f
+	}
+	return labelingFormatter{AnalysisFormatter: f, labels: labels}
+}
+
+// WithExamplePolicy returns a formatter that limits and redacts detection
+// examples with policy (report.max_examples and report.redact) before f
+// formats the report. A zero policy returns f itself.
+func WithExamplePolicy(f AnalysisFormatter, policy analysis.ExamplePolicy) AnalysisFormatter {
+	if policy == (analysis.ExamplePolicy{}) {
+		return f
 	}
+	return sanitizingFormatter{AnalysisFormatter: f, policy: policy}
 }
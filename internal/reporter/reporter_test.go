@@ -1,7 +1,11 @@
 package reporter
 
 import (
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
 )
 
 func TestNewAnalysisFormatter(t *testing.T) {
@@ -25,6 +29,16 @@ func TestNewAnalysisFormatter(t *testing.T) {
 			format:      "html",
 			expectError: false,
 		},
+		{
+			name:        "creates markdown formatter",
+			format:      "markdown",
+			expectError: false,
+		},
+		{
+			name:        "creates md formatter",
+			format:      "md",
+			expectError: false,
+		},
 		{
 			name:        "creates yaml formatter",
 			format:      "yaml",
@@ -40,6 +54,21 @@ func TestNewAnalysisFormatter(t *testing.T) {
 			format:      "bson",
 			expectError: false,
 		},
+		{
+			name:        "creates csv formatter",
+			format:      "csv",
+			expectError: false,
+		},
+		{
+			name:        "creates sarif formatter",
+			format:      "sarif",
+			expectError: false,
+		},
+		{
+			name:        "creates slack formatter",
+			format:      "slack",
+			expectError: false,
+		},
 		{
 			name:        "invalid format",
 			format:      "xml",
@@ -74,3 +103,92 @@ func TestNewAnalysisFormatter(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAnalysisFormatter_ListsValidFormats(t *testing.T) {
+	for _, format := range Formats {
+		if _, err := NewAnalysisFormatter(format); err != nil {
+			t.Errorf("NewAnalysisFormatter(%q) unexpected error = %v", format, err)
+		}
+	}
+
+	_, err := NewAnalysisFormatter("xml")
+	if err == nil {
+		t.Fatal("NewAnalysisFormatter(xml) expected error")
+	}
+	for _, format := range Formats {
+		if !strings.Contains(err.Error(), format) {
+			t.Errorf("error %q does not list %s", err, format)
+		}
+	}
+}
+
+func TestWithExamplePolicy(t *testing.T) {
+	policy := analysis.ExamplePolicy{MaxExamples: 2, Redact: true}
+	for _, format := range []string{"text", "html", "json"} {
+		t.Run(format, func(t *testing.T) {
+			report := &analysis.AnalysisReport{
+				SourceType: analysis.SourceTypeWeb,
+				SourceID:   "https://example.com",
+				Timing:     analysis.TimingInfo{StartedAt: time.Now(), CompletedAt: time.Now()},
+				Detections: []analysis.Detection{{
+					Strategy: "overused_phrases",
+					Detected: true,
+					Severity: "medium",
+					Score:    0.5,
+					Category: "web-pattern",
+					Examples: []string{"write to ops@example.com", "token = abcdef123456", "third example"},
+				}},
+			}
+			base, err := NewAnalysisFormatter(format)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := WithExamplePolicy(base, policy).FormatAnalysis(report)
+			if err != nil {
+				t.Fatalf("FormatAnalysis() unexpected error = %v", err)
+			}
+			for _, leaked := range []string{"ops@example.com", "abcdef123456", "third example"} {
+				if strings.Contains(out, leaked) {
+					t.Errorf("%s output contains %q", format, leaked)
+				}
+			}
+			if !strings.Contains(out, "[REDACTED]") {
+				t.Errorf("%s output has no redacted example", format)
+			}
+			if report.Detections[0].Examples[0] != "write to ops@example.com" {
+				t.Error("the report passed in was modified")
+			}
+		})
+	}
+
+	base, _ := NewAnalysisFormatter("text")
+	if WithExamplePolicy(base, analysis.ExamplePolicy{}) != base {
+		t.Error("a zero policy should return the formatter unchanged")
+	}
+}
+
+func TestWithAssessmentLabels(t *testing.T) {
+	report := &analysis.AnalysisReport{
+		SourceType: analysis.SourceTypeGit,
+		SourceID:   "repo",
+		Assessment: analysis.AssessmentModerateSuspicion,
+		Timing:     analysis.TimingInfo{StartedAt: time.Now(), CompletedAt: time.Now()},
+	}
+	labels := analysis.AssessmentLabels{analysis.AssessmentModerateSuspicion: "Sospecha moderada"}
+	for _, format := range []string{"text", "html", "json", "markdown"} {
+		base, err := NewAnalysisFormatter(format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		out, err := WithAssessmentLabels(base, labels).FormatAnalysis(report)
+		if err != nil {
+			t.Fatalf("%s: FormatAnalysis() unexpected error = %v", format, err)
+		}
+		if !strings.Contains(out, "Sospecha moderada") || strings.Contains(out, analysis.AssessmentModerateSuspicion) {
+			t.Errorf("%s output should use the configured label only", format)
+		}
+	}
+	if report.Assessment != analysis.AssessmentModerateSuspicion {
+		t.Error("the report passed in was modified")
+	}
+}
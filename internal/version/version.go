@@ -1,11 +1,32 @@
 package version
 
+import "runtime"
+
 var (
 	Version   = "unknown"
 	BuildTime = "unknown"
 	GitCommit = "unknown"
 )
 
+// Info describes the running build. Version, GitCommit and BuildTime are
+// set with -ldflags at build time.
+type Info struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"git_commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build information of the running binary.
+func Get() Info {
+	return Info{
+		Version:   Version,
+		GitCommit: GitCommit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
+
 func String() string {
 	return Version
 }
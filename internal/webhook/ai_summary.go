@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/ai/skills"
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/logging"
+)
+
+const (
+	// summaryTimeout bounds the report_summary call that follows a stream.
+	summaryTimeout = 2 * time.Minute
+	// summaryTopDetections is how many detections the summary prompt lists.
+	summaryTopDetections = 5
+)
+
+// SSEAITokenEvent carries one fragment of the AI summary as the model
+// generates it. Concatenated in order, the tokens are the model's raw
+// response.
+type SSEAITokenEvent struct {
+	Token string `json:"token"`
+}
+
+// reportSummaryInput condenses a report into the report_summary skill's
+// input, listing the highest-scoring detections that fired.
+func reportSummaryInput(report *analysis.AnalysisReport) skills.ReportSummaryInput {
+	var fired []analysis.Detection
+	for _, d := range report.Detections {
+		if d.Detected {
+			fired = append(fired, d)
+		}
+	}
+	sort.SliceStable(fired, func(a, b int) bool { return fired[a].Score > fired[b].Score })
+	if len(fired) > summaryTopDetections {
+		fired = fired[:summaryTopDetections]
+	}
+
+	top := make([]skills.DetectionSummary, len(fired))
+	for i, d := range fired {
+		top[i] = skills.DetectionSummary{
+			Strategy:    d.Strategy,
+			Severity:    d.Severity,
+			Score:       d.Score,
+			Description: d.Description,
+		}
+	}
+
+	return skills.ReportSummaryInput{
+		SourceType:      string(report.SourceType),
+		SourceID:        report.SourceID,
+		OverallScore:    report.OverallScore,
+		Assessment:      report.Assessment,
+		DetectionCount:  report.DetectionCount,
+		TotalDetections: report.TotalDetections,
+		HighSeverity:    report.HighSeverityCount,
+		MediumSeverity:  report.MediumSeverityCount,
+		LowSeverity:     report.LowSeverityCount,
+		TopDetections:   top,
+	}
+}
+
+// streamSummary runs the report_summary skill over a finished stream's
+// report, forwarding the model's output as ai_token events while it is
+// generated and then sending the parsed summary as an ai_summary event. It
+// does nothing without a configured analyzer or a report to summarize. The
+// analysis already succeeded, so a failed summary is logged rather than
+// failing the stream.
+func (wh *WebhookHandlers) streamSummary(sw *sseWriter, log *logging.Logger, jobID string, report *analysis.AnalysisReport) {
+	analyzer := wh.processor.Analyzer
+	if analyzer == nil || !analyzer.IsConfigured() || report == nil || report.InsufficientContent() != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), summaryTimeout)
+	defer cancel()
+
+	sw.write(SSEEventProgress, SSEProgressEvent{
+		Phase:   "summarizing",
+		Message: "Generating AI summary",
+	})
+	result, err := analyzer.RunSkillStream(ctx, "report_summary", reportSummaryInput(report), func(token string) {
+		if !sw.write(SSEEventAIToken, SSEAITokenEvent{Token: token}) {
+			cancel()
+		}
+	})
+	if err != nil {
+		log.Warn("AI summary failed", "job_id", jobID, "error", err.Error())
+		return
+	}
+	if result.OutputError != nil {
+		log.Warn("AI summary output did not match schema", "job_id", jobID,
+			"reason", result.OutputError.Reason, "error", result.OutputError.Error())
+	}
+	sw.write(SSEEventAISummary, result.Parsed)
+}
@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/ai"
+	"github.com/TryCadence/Cadence/internal/ai/skills"
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/logging"
+)
+
+// summaryAnalyzer streams a canned report_summary response and records
+// the input it was sent.
+type summaryAnalyzer struct {
+	triageAnalyzer
+	tokens []string
+	input  skills.ReportSummaryInput
+}
+
+func (a *summaryAnalyzer) RunSkillStream(_ context.Context, skillName string, input interface{}, onToken func(string)) (*ai.SkillResult, error) {
+	a.input = input.(skills.ReportSummaryInput)
+	for _, token := range a.tokens {
+		onToken(token)
+	}
+	return &ai.SkillResult{Skill: skillName, Raw: strings.Join(a.tokens, ""), Parsed: &skills.ReportSummaryResult{
+		Title:     "Mostly human-written",
+		RiskLevel: "low",
+	}}, nil
+}
+
+func TestStreamSummary(t *testing.T) {
+	analyzer := &summaryAnalyzer{tokens: []string{`{"title": `, `"Mostly human-written"}`}}
+	wh := NewWebhookHandlers("secret", nil, nil)
+	wh.processor.Analyzer = analyzer
+
+	var buf bytes.Buffer
+	sw := &sseWriter{w: bufio.NewWriter(&buf)}
+	report := &analysis.AnalysisReport{
+		SourceType:     analysis.SourceTypeGit,
+		SourceID:       "https://github.com/example/repo",
+		DetectionCount: 2,
+		Detections: []analysis.Detection{
+			{Strategy: "low", Detected: true, Score: 0.2},
+			{Strategy: "passed", Detected: false, Score: 0.9},
+			{Strategy: "high", Detected: true, Score: 0.8, Severity: "high"},
+		},
+	}
+	wh.streamSummary(sw, logging.Default(), "job", report)
+
+	out := buf.String()
+	if strings.Count(out, "event: ai_token\n") != 2 {
+		t.Errorf("want one ai_token event per token, got %q", out)
+	}
+	if !strings.Contains(out, `data: {"token":"{\"title\": "}`) {
+		t.Errorf("ai_token events should carry the raw fragments, got %q", out)
+	}
+	if !strings.Contains(out, "event: ai_summary\n") || !strings.Contains(out, `"risk_level":"low"`) {
+		t.Errorf("want the parsed summary after the tokens, got %q", out)
+	}
+
+	top := analyzer.input.TopDetections
+	if analyzer.input.SourceID != report.SourceID || len(top) != 2 || top[0].Strategy != "high" || top[1].Strategy != "low" {
+		t.Errorf("summary input = %+v, want the fired detections by score", analyzer.input)
+	}
+}
+
+func TestStreamSummary_Skipped(t *testing.T) {
+	wh := NewWebhookHandlers("secret", nil, nil)
+	report := &analysis.AnalysisReport{}
+
+	for name, analyzer := range map[string]ai.Analyzer{
+		"no analyzer":    nil,
+		"not configured": &ai.NoOpAnalyzer{},
+	} {
+		var buf bytes.Buffer
+		wh.processor.Analyzer = analyzer
+		wh.streamSummary(&sseWriter{w: bufio.NewWriter(&buf)}, logging.Default(), "job", report)
+		if buf.Len() != 0 {
+			t.Errorf("%s: want no events, got %q", name, buf.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	wh.processor.Analyzer = &summaryAnalyzer{}
+	wh.streamSummary(&sseWriter{w: bufio.NewWriter(&buf)}, logging.Default(), "job", nil)
+	if buf.Len() != 0 {
+		t.Errorf("failed stream: want no events, got %q", buf.String())
+	}
+}
@@ -0,0 +1,61 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// StatusIgnored is the status push webhooks answer with when the pushed
+// branch is filtered out and no job is queued.
+const StatusIgnored = "ignored"
+
+// BranchFilter selects which pushed branches the push webhooks analyze.
+// Patterns use path.Match syntax, so "release/*" matches "release/1.2" but
+// "*" does not cross a "/". A branch is analyzed when it matches an Include
+// pattern, or Include is empty, and matches no Exclude pattern.
+type BranchFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// Validate reports the first malformed pattern.
+func (f BranchFilter) Validate() error {
+	for _, patterns := range [][]string{f.Include, f.Exclude} {
+		for _, p := range patterns {
+			if _, err := path.Match(p, ""); err != nil {
+				return fmt.Errorf("invalid branch pattern %q: %w", p, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Allows reports whether a push to branch should be analyzed.
+func (f BranchFilter) Allows(branch string) bool {
+	if len(f.Include) > 0 && !matchesBranch(f.Include, branch) {
+		return false
+	}
+	return !matchesBranch(f.Exclude, branch)
+}
+
+func matchesBranch(patterns []string, branch string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, branch); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// branchIgnored answers a push to a filtered-out branch with 200 and
+// StatusIgnored so the sender does not treat it as a failed delivery.
+func branchIgnored(c *fiber.Ctx, branch string) error {
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"status": StatusIgnored,
+		"branch": branch,
+		"reason": "branch is filtered out by webhook.branches",
+	})
+}
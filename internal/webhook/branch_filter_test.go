@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBranchFilter_Allows(t *testing.T) {
+	f := BranchFilter{Include: []string{"main", "release/*"}, Exclude: []string{"release/*-rc"}}
+
+	tests := map[string]bool{
+		"main":           true,
+		"release/1.2":    true,
+		"release/1.3-rc": false,
+		"feature/login":  false,
+		"release/1/2":    false,
+	}
+	for branch, want := range tests {
+		if got := f.Allows(branch); got != want {
+			t.Errorf("Allows(%q) = %v, want %v", branch, got, want)
+		}
+	}
+
+	if !(BranchFilter{}).Allows("feature/login") {
+		t.Error("an empty filter should allow every branch")
+	}
+	if (BranchFilter{Exclude: []string{"dependabot/*"}}).Allows("dependabot/npm") {
+		t.Error("an excluded branch should be filtered without an include list")
+	}
+}
+
+func TestBranchFilter_Validate(t *testing.T) {
+	if err := (BranchFilter{Include: []string{"main", "release/*"}}).Validate(); err != nil {
+		t.Errorf("Validate() unexpected error = %v", err)
+	}
+	if err := (BranchFilter{Exclude: []string{"feature/[a"}}).Validate(); err == nil {
+		t.Error("Validate() should reject a malformed pattern")
+	}
+	if _, err := NewServer(&ServerConfig{Branches: BranchFilter{Include: []string{"["}}}, NewDefaultProcessor()); err == nil {
+		t.Error("NewServer() should reject a malformed branch pattern")
+	}
+}
+
+func TestHandleGitlabWebhook_FilteredBranch(t *testing.T) {
+	server, err := NewServer(&ServerConfig{
+		Host: "localhost", Port: 9999, WebhookSecret: "test-secret", MaxWorkers: 1,
+		Branches: BranchFilter{Include: []string{"main"}},
+	}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	payload := `{
+		"ref": "refs/heads/feature/login",
+		"project": {"name": "diaspora", "git_http_url": "https://gitlab.example.com/mike/diaspora.git"},
+		"commits": []
+	}`
+	req, _ := http.NewRequest("POST", "/webhooks/gitlab", strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Token", "test-secret")
+	resp, err := server.GetApp().Test(req)
+	if err != nil {
+		t.Fatalf("Test() unexpected error = %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var out map[string]string
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		t.Fatalf("invalid response JSON: %v", err)
+	}
+	if out["status"] != StatusIgnored || out["branch"] != "feature/login" {
+		t.Errorf("response = %v, want status %q for feature/login", out, StatusIgnored)
+	}
+	if jobs := server.GetQueue().ListJobs(0); len(jobs) != 0 {
+		t.Errorf("queued %d jobs, want none for a filtered branch", len(jobs))
+	}
+}
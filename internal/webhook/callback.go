@@ -0,0 +1,181 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/logging"
+	"github.com/TryCadence/Cadence/internal/netguard"
+)
+
+const (
+	// CallbackSignatureHeader carries the HMAC-SHA256 of the callback body,
+	// formatted like GitHub's X-Hub-Signature-256 ("sha256=<hex>").
+	CallbackSignatureHeader = "X-Cadence-Signature-256"
+	// CallbackJobIDHeader names the job the callback reports on.
+	CallbackJobIDHeader = "X-Cadence-Job-ID"
+
+	DefaultCallbackMaxAttempts = 3
+	DefaultCallbackBaseDelay   = time.Second
+	DefaultCallbackTimeout     = 10 * time.Second
+	// maxCallbackRedirects matches net/http's default redirect limit.
+	maxCallbackRedirects = 10
+)
+
+// CallbackNotifier POSTs a job's JobResultResponse to its CallbackURL once the
+// job completes or fails. Network errors and 5xx responses are retried with
+// exponential backoff; other non-2xx responses are not.
+type CallbackNotifier struct {
+	// Secret signs the payload; receivers verify it with the server's webhook secret.
+	Secret string
+	// MaxAttempts is the total number of deliveries tried (0 = DefaultCallbackMaxAttempts).
+	MaxAttempts int
+	// BaseDelay is the wait before the first retry, doubled on each later one
+	// (0 = DefaultCallbackBaseDelay).
+	BaseDelay time.Duration
+	// Guard re-checks the target of every redirect, so a callback URL that
+	// passed validation cannot bounce the request to an internal address.
+	Guard  *netguard.Guard
+	Client *http.Client
+	Logger *logging.Logger
+}
+
+func NewCallbackNotifier(secret string, maxAttempts int) *CallbackNotifier {
+	n := &CallbackNotifier{
+		Secret:      secret,
+		MaxAttempts: maxAttempts,
+		Guard:       &netguard.Guard{},
+		Logger:      logging.Default().With("component", "callback"),
+	}
+	n.Client = n.newClient()
+	return n
+}
+
+// newClient returns an HTTP client that runs checkRedirect on every hop.
+func (n *CallbackNotifier) newClient() *http.Client {
+	return &http.Client{Timeout: DefaultCallbackTimeout, CheckRedirect: n.checkRedirect}
+}
+
+// checkRedirect enforces the redirect limit and re-checks the target of
+// every hop with the guard.
+func (n *CallbackNotifier) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxCallbackRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxCallbackRedirects)
+	}
+	return n.Guard.CheckURL(req.Context(), req.URL.String())
+}
+
+// Notify delivers response to callbackURL, giving up after MaxAttempts or
+// when ctx is cancelled. The final failure is logged and returned.
+func (n *CallbackNotifier) Notify(ctx context.Context, callbackURL string, response JobResultResponse) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to encode callback payload: %w", err)
+	}
+	signature := signPayload(n.Secret, body)
+
+	attempts := n.MaxAttempts
+	if attempts <= 0 {
+		attempts = DefaultCallbackMaxAttempts
+	}
+	delay := n.BaseDelay
+	if delay <= 0 {
+		delay = DefaultCallbackBaseDelay
+	}
+
+	for attempt := 1; ; attempt++ {
+		retryable, err := n.deliver(ctx, callbackURL, response.JobID, body, signature)
+		if err == nil {
+			n.logger().Info("callback delivered", "job_id", response.JobID, "url", callbackURL, "attempt", attempt)
+			return nil
+		}
+
+		if !retryable || attempt >= attempts {
+			n.logger().Error("callback delivery failed", "job_id", response.JobID, "url", callbackURL,
+				"attempts", attempt, "error", err)
+			return err
+		}
+
+		n.logger().Warn("callback attempt failed, retrying", "job_id", response.JobID, "url", callbackURL,
+			"attempt", attempt, "retry_in", delay, "error", err)
+
+		select {
+		case <-time.After(delay):
+			delay *= 2
+		case <-ctx.Done():
+			n.logger().Error("callback delivery abandoned", "job_id", response.JobID, "url", callbackURL,
+				"attempts", attempt, "error", ctx.Err())
+			return ctx.Err()
+		}
+	}
+}
+
+// deliver makes one POST and reports whether a failure is worth retrying.
+func (n *CallbackNotifier) deliver(ctx context.Context, callbackURL, jobID string, body []byte, signature string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create callback request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(CallbackSignatureHeader, signature)
+	req.Header.Set(CallbackJobIDHeader, jobID)
+
+	client := n.Client
+	if client == nil {
+		client = n.newClient()
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("callback request failed: %w", err)
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500:
+		return true, fmt.Errorf("callback returned status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("callback returned status %d", resp.StatusCode)
+	}
+}
+
+func (n *CallbackNotifier) logger() *logging.Logger {
+	if n.Logger != nil {
+		return n.Logger
+	}
+	return logging.Default().With("component", "callback")
+}
+
+// signPayload returns the "sha256=<hex>" HMAC of body, the format
+// verifySignature accepts for incoming webhooks.
+func signPayload(secret string, body []byte) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write(body)
+	return "sha256=" + hex.EncodeToString(h.Sum(nil))
+}
+
+// validateCallbackURL accepts empty strings and absolute http(s) URLs.
+func validateCallbackURL(raw string) error {
+	if raw == "" {
+		return nil
+	}
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("callback_url must be an absolute http or https URL")
+	}
+	return nil
+}
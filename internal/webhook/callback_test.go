@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/netguard"
+)
+
+type processorFunc func(ctx context.Context, job *WebhookJob) error
+
+func (f processorFunc) Process(ctx context.Context, job *WebhookJob) error {
+	return f(ctx, job)
+}
+
+func TestCallbackNotifier_SignsPayload(t *testing.T) {
+	verifier := NewWebhookHandlers("callback-secret", nil, nil)
+
+	var got JobResultResponse
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := verifier.verifySignature(body, r.Header.Get(CallbackSignatureHeader)); err != nil {
+			t.Errorf("signature does not verify: %v", err)
+		}
+		if id := r.Header.Get(CallbackJobIDHeader); id != "job-1" {
+			t.Errorf("%s = %q, want job-1", CallbackJobIDHeader, id)
+		}
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("invalid callback JSON: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	n := NewCallbackNotifier("callback-secret", 1)
+	err := n.Notify(context.Background(), srv.URL, JobResultResponse{JobID: "job-1", Status: StatusCompleted})
+	if err != nil {
+		t.Fatalf("Notify() unexpected error = %v", err)
+	}
+	if got.JobID != "job-1" || got.Status != StatusCompleted {
+		t.Errorf("payload = %+v, want completed job-1", got)
+	}
+}
+
+func TestCallbackNotifier_Retries(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []int // per attempt; the last one repeats
+		maxAttempts int
+		wantCalls   int32
+		wantErr     bool
+	}{
+		{"5xx then success", []int{503, 500, 200}, 3, 3, false},
+		{"gives up after max attempts", []int{502}, 2, 2, true},
+		{"4xx is not retried", []int{400}, 3, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := int(calls.Add(1)) - 1
+				if i >= len(tt.statuses) {
+					i = len(tt.statuses) - 1
+				}
+				w.WriteHeader(tt.statuses[i])
+			}))
+			defer srv.Close()
+
+			n := NewCallbackNotifier("s", tt.maxAttempts)
+			n.BaseDelay = time.Millisecond
+
+			err := n.Notify(context.Background(), srv.URL, JobResultResponse{JobID: "j"})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Notify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := calls.Load(); got != tt.wantCalls {
+				t.Errorf("attempts = %d, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCallbackNotifier_GuardsRedirects(t *testing.T) {
+	var internalCalls int32
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&internalCalls, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer internal.Close()
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, internal.URL+"/metadata", http.StatusTemporaryRedirect)
+	}))
+	defer redirector.Close()
+
+	n := NewCallbackNotifier("s", 1)
+	err := n.Notify(context.Background(), redirector.URL, JobResultResponse{JobID: "job-1"})
+	if !errors.Is(err, netguard.ErrBlockedDestination) {
+		t.Errorf("Notify() error = %v, want the redirect blocked", err)
+	}
+	if got := atomic.LoadInt32(&internalCalls); got != 0 {
+		t.Errorf("internal target called %d times, want 0", got)
+	}
+
+	n.Guard = &netguard.Guard{AllowPrivate: true}
+	if err := n.Notify(context.Background(), redirector.URL, JobResultResponse{JobID: "job-1"}); err != nil {
+		t.Errorf("Notify() with private hosts allowed error = %v", err)
+	}
+	if got := atomic.LoadInt32(&internalCalls); got != 1 {
+		t.Errorf("internal target called %d times, want 1", got)
+	}
+}
+
+func TestJobQueue_DeliversCallbackOnFailure(t *testing.T) {
+	received := make(chan JobResultResponse, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp JobResultResponse
+		_ = json.NewDecoder(r.Body).Decode(&resp)
+		received <- resp
+	}))
+	defer srv.Close()
+
+	queue := NewJobQueue(1, processorFunc(func(ctx context.Context, job *WebhookJob) error {
+		return errors.New("clone failed")
+	}))
+	queue.SetCallbackNotifier(NewCallbackNotifier("s", 1))
+	if err := queue.Start(); err != nil {
+		t.Fatalf("Start() unexpected error = %v", err)
+	}
+	defer func() {
+		_ = queue.Stop()
+	}()
+
+	job := &WebhookJob{EventType: "api_analysis_repo", CallbackURL: srv.URL}
+	if err := queue.Enqueue(job); err != nil {
+		t.Fatalf("Enqueue() unexpected error = %v", err)
+	}
+
+	select {
+	case resp := <-received:
+		if resp.JobID != job.ID || resp.Status != StatusFailed || resp.Error != "clone failed" {
+			t.Errorf("callback payload = %+v, want failed job %s", resp, job.ID)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("callback was not delivered")
+	}
+}
+
+func TestAnalyze_InvalidCallbackURL(t *testing.T) {
+	server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, WebhookSecret: "test-secret", MaxWorkers: 1}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/api/analyze/repository", `{"repository_url":"https://github.com/a/b","callback_url":"ftp://example.com/hook"}`},
+		{"/api/analyze/website", `{"url":"https://example.com","callback_url":"/relative"}`},
+	}
+
+	for _, tt := range tests {
+		req, _ := http.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.GetApp().Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s: Status = %d, want %d", tt.path, resp.StatusCode, http.StatusBadRequest)
+		}
+	}
+}
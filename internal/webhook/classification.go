@@ -0,0 +1,50 @@
+package webhook
+
+import "github.com/TryCadence/Cadence/internal/analysis"
+
+// Assessment labels attached to job results, before any rewording by
+// ClassificationThresholds.Labels.
+const (
+	AssessmentLikelyAI    = analysis.AssessmentLikelyAI
+	AssessmentSuspicious  = analysis.AssessmentSuspicious
+	AssessmentLikelyHuman = analysis.AssessmentLikelyHuman
+)
+
+// ClassificationThresholds are the suspicion-rate cutoffs (0-1) that map a
+// report to an assessment label. Both bounds are inclusive.
+type ClassificationThresholds struct {
+	High   float64
+	Medium float64
+	// Labels rewords the assessments of job results. Queued and streamed
+	// results share the processor's thresholds, so they resolve labels the
+	// same way.
+	Labels analysis.AssessmentLabels
+}
+
+// DefaultClassificationThresholds are used when no thresholds are configured.
+var DefaultClassificationThresholds = ClassificationThresholds{High: 0.7, Medium: 0.4}
+
+// withDefaults fills unset bounds from DefaultClassificationThresholds.
+func (ct ClassificationThresholds) withDefaults() ClassificationThresholds {
+	if ct.High <= 0 {
+		ct.High = DefaultClassificationThresholds.High
+	}
+	if ct.Medium <= 0 {
+		ct.Medium = DefaultClassificationThresholds.Medium
+	}
+	return ct
+}
+
+// Assess returns the assessment label for a suspicion rate. Queued and
+// streamed results both go through it so their labels always agree.
+func (ct ClassificationThresholds) Assess(suspicionRate float64) string {
+	ct = ct.withDefaults()
+	switch {
+	case suspicionRate >= ct.High:
+		return ct.Labels.Label(AssessmentLikelyAI)
+	case suspicionRate >= ct.Medium:
+		return ct.Labels.Label(AssessmentSuspicious)
+	default:
+		return ct.Labels.Label(AssessmentLikelyHuman)
+	}
+}
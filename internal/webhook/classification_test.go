@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+func TestClassificationThresholds_Assess(t *testing.T) {
+	tests := []struct {
+		name       string
+		thresholds ClassificationThresholds
+		rate       float64
+		want       string
+	}{
+		{"default high boundary", ClassificationThresholds{}, 0.7, AssessmentLikelyAI},
+		{"default just below high", ClassificationThresholds{}, 0.6999, AssessmentSuspicious},
+		{"default medium boundary", ClassificationThresholds{}, 0.4, AssessmentSuspicious},
+		{"default just below medium", ClassificationThresholds{}, 0.3999, AssessmentLikelyHuman},
+		{"default zero", ClassificationThresholds{}, 0, AssessmentLikelyHuman},
+		{"custom high boundary", ClassificationThresholds{High: 0.9, Medium: 0.5}, 0.9, AssessmentLikelyAI},
+		{"custom below high", ClassificationThresholds{High: 0.9, Medium: 0.5}, 0.8, AssessmentSuspicious},
+		{"custom medium boundary", ClassificationThresholds{High: 0.9, Medium: 0.5}, 0.5, AssessmentSuspicious},
+		{"custom below medium", ClassificationThresholds{High: 0.9, Medium: 0.5}, 0.45, AssessmentLikelyHuman},
+		{"unset medium uses default", ClassificationThresholds{High: 0.9}, 0.4, AssessmentSuspicious},
+		{"custom label", ClassificationThresholds{Labels: analysis.AssessmentLabels{AssessmentLikelyAI: "Probablemente IA"}}, 0.9, "Probablemente IA"},
+		{"unlabeled assessment keeps default", ClassificationThresholds{Labels: analysis.AssessmentLabels{AssessmentLikelyAI: "Probablemente IA"}}, 0.1, AssessmentLikelyHuman},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.thresholds.Assess(tt.rate); got != tt.want {
+				t.Errorf("Assess(%v) with %+v = %q, want %q", tt.rate, tt.thresholds, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClassification_QueuedAndStreamedAgree(t *testing.T) {
+	thresholds := ClassificationThresholds{High: 0.8, Medium: 0.5, Labels: analysis.AssessmentLabels{
+		AssessmentSuspicious:                   "Verdächtig",
+		analysis.AssessmentInsufficientContent: "Zu wenig Inhalt",
+	}}
+	ap := &AnalysisProcessor{Classification: thresholds}
+
+	for _, rate := range []float64{0.49, 0.5, 0.79, 0.8} {
+		report := &analysis.AnalysisReport{
+			SourceID: "https://example.com",
+			Metrics:  map[string]interface{}{"slop_suspicion_rate": rate},
+		}
+
+		job := &WebhookJob{Result: &JobResult{}}
+		ap.populateWebJobResult(job, report)
+		streamed := buildJobResult(report, "api_analysis_website", thresholds)
+
+		if job.Result.Assessment != streamed.Assessment {
+			t.Errorf("rate %v: queued assessment %q != streamed %q", rate, job.Result.Assessment, streamed.Assessment)
+		}
+		if want := thresholds.Assess(rate); streamed.Assessment != want {
+			t.Errorf("rate %v: assessment = %q, want %q", rate, streamed.Assessment, want)
+		}
+	}
+}
+
+func TestClassification_InsufficientContentLabel(t *testing.T) {
+	thresholds := ClassificationThresholds{Labels: analysis.AssessmentLabels{analysis.AssessmentInsufficientContent: "Zu wenig Inhalt"}}
+	report := &analysis.AnalysisReport{
+		SourceID:   "https://example.com",
+		Assessment: analysis.AssessmentInsufficientContent,
+		Metrics: map[string]interface{}{
+			analysis.MetricInsufficientContent: &analysis.InsufficientContentError{WordCount: 5, MinWordCount: 50},
+		},
+	}
+	if got := buildJobResult(report, "api_analysis_website", thresholds).Assessment; got != "Zu wenig Inhalt" {
+		t.Errorf("streamed assessment = %q, want the configured label", got)
+	}
+	if report.Assessment != analysis.AssessmentInsufficientContent {
+		t.Error("the report should keep its canonical assessment")
+	}
+}
@@ -0,0 +1,168 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// DefaultCloneTimeout bounds a repository clone when CloneOptions.Timeout is unset.
+const DefaultCloneTimeout = 2 * time.Minute
+
+// CloneOptions controls how repositories are cloned for analysis. The zero
+// value performs a full clone of all branches with DefaultCloneTimeout.
+type CloneOptions struct {
+	// Timeout bounds the whole clone. Zero uses DefaultCloneTimeout.
+	Timeout time.Duration
+	// Depth limits history to the given number of commits. Zero clones full history.
+	Depth int
+	// SingleBranch fetches only the default branch when no branch is requested.
+	// A requested branch is always fetched on its own.
+	SingleBranch bool
+}
+
+func (o CloneOptions) timeout() time.Duration {
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+	return DefaultCloneTimeout
+}
+
+// cloneRepo clones url into dest. When branch is set only that branch is
+// fetched; if it does not exist the clone falls back to the default branch,
+// matching how GetCommits treats unknown branches.
+func cloneRepo(ctx context.Context, url, dest, branch string, opts CloneOptions) error {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout())
+	defer cancel()
+
+	cloneOpts := &gogit.CloneOptions{
+		URL:          url,
+		Depth:        opts.Depth,
+		SingleBranch: opts.SingleBranch,
+	}
+	if branch != "" {
+		cloneOpts.ReferenceName = plumbing.NewBranchReferenceName(branch)
+		cloneOpts.SingleBranch = true
+	}
+
+	_, err := gogit.PlainCloneContext(ctx, dest, false, cloneOpts)
+	if err != nil && branch != "" && isMissingBranch(err) {
+		_ = os.RemoveAll(dest)
+		cloneOpts.ReferenceName = ""
+		cloneOpts.SingleBranch = opts.SingleBranch
+		_, err = gogit.PlainCloneContext(ctx, dest, false, cloneOpts)
+	}
+	return err
+}
+
+// CloneLimiter bounds how many repositories are cloned at once across queued
+// jobs and streams, so a burst of pushes waits for clone slots instead of
+// saturating disk and network. A nil limiter allows any number of clones.
+type CloneLimiter struct {
+	slots   chan struct{}
+	waiting atomic.Int64
+}
+
+// NewCloneLimiter allows n concurrent clones (at least one).
+func NewCloneLimiter(n int) *CloneLimiter {
+	return &CloneLimiter{slots: make(chan struct{}, max(n, 1))}
+}
+
+// Acquire blocks until a clone slot is free or ctx ends. The returned
+// function frees the slot.
+func (l *CloneLimiter) Acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+	l.waiting.Add(1)
+	defer l.waiting.Add(-1)
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *CloneLimiter) release() { <-l.slots }
+
+// InUse returns how many clones hold a slot.
+func (l *CloneLimiter) InUse() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.slots)
+}
+
+// Waiting returns how many clones are blocked waiting for a slot.
+func (l *CloneLimiter) Waiting() int {
+	if l == nil {
+		return 0
+	}
+	return int(l.waiting.Load())
+}
+
+// Limit returns the number of slots (0 for a nil limiter, meaning unlimited).
+func (l *CloneLimiter) Limit() int {
+	if l == nil {
+		return 0
+	}
+	return cap(l.slots)
+}
+
+// saturated reports whether every slot is taken, so a new clone would wait.
+func (l *CloneLimiter) saturated() bool {
+	return l != nil && len(l.slots) == cap(l.slots)
+}
+
+// cloneDirs tracks the temporary clone directories of running analyses, so a
+// shutdown that interrupts them can remove whatever they leave behind.
+var cloneDirs = &tempDirSet{dirs: make(map[string]struct{})}
+
+type tempDirSet struct {
+	mu   sync.Mutex
+	dirs map[string]struct{}
+}
+
+// add registers dir and returns it.
+func (s *tempDirSet) add(dir string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dirs[dir] = struct{}{}
+	return dir
+}
+
+// remove deletes dir and stops tracking it.
+func (s *tempDirSet) remove(dir string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = os.RemoveAll(dir)
+	delete(s.dirs, dir)
+}
+
+// removeAll deletes every tracked directory and returns how many there were.
+func (s *tempDirSet) removeAll() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.dirs)
+	for dir := range s.dirs {
+		_ = os.RemoveAll(dir)
+		delete(s.dirs, dir)
+	}
+	return n
+}
+
+func isMissingBranch(err error) bool {
+	return errors.Is(err, plumbing.ErrReferenceNotFound) || errors.Is(err, gogit.NoMatchingRefSpecError{})
+}
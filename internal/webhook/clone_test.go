@@ -0,0 +1,226 @@
+package webhook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// createCloneSource builds a repository with three commits on master and a
+// "feature" branch pointing at the second commit.
+func createCloneSource(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := gogit.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit() failed: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() failed: %v", err)
+	}
+
+	when := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		content := []byte(time.Duration(i).String() + "\n")
+		if err := os.WriteFile(filepath.Join(dir, "file.txt"), content, 0o600); err != nil {
+			t.Fatalf("WriteFile() failed: %v", err)
+		}
+		if _, err := wt.Add("file.txt"); err != nil {
+			t.Fatalf("Add() failed: %v", err)
+		}
+		sig := &object.Signature{Name: "dev", Email: "dev@example.com", When: when.Add(time.Duration(i) * time.Hour)}
+		hash, err := wt.Commit("commit", &gogit.CommitOptions{Author: sig})
+		if err != nil {
+			t.Fatalf("Commit() failed: %v", err)
+		}
+		if i == 1 {
+			ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName("feature"), hash)
+			if err := repo.Storer.SetReference(ref); err != nil {
+				t.Fatalf("SetReference() failed: %v", err)
+			}
+		}
+	}
+
+	return dir
+}
+
+func countCommits(t *testing.T, dir string) int {
+	t.Helper()
+
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("PlainOpen() failed: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head() failed: %v", err)
+	}
+	iter, err := repo.Log(&gogit.LogOptions{From: head.Hash()})
+	if err != nil {
+		t.Fatalf("Log() failed: %v", err)
+	}
+
+	count := 0
+	_ = iter.ForEach(func(*object.Commit) error {
+		count++
+		return nil
+	})
+	return count
+}
+
+func TestCloneRepo(t *testing.T) {
+	source := createCloneSource(t)
+
+	t.Run("full clone", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "clone")
+		if err := cloneRepo(context.Background(), source, dest, "", CloneOptions{}); err != nil {
+			t.Fatalf("cloneRepo() unexpected error = %v", err)
+		}
+		if got := countCommits(t, dest); got != 3 {
+			t.Errorf("commits = %d, want 3", got)
+		}
+	})
+
+	t.Run("shallow clone", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "clone")
+		if err := cloneRepo(context.Background(), "file://"+source, dest, "", CloneOptions{Depth: 1}); err != nil {
+			t.Fatalf("cloneRepo() unexpected error = %v", err)
+		}
+		repo, _ := gogit.PlainOpen(dest)
+		if shallow, _ := repo.Storer.Shallow(); len(shallow) == 0 {
+			t.Error("Depth: 1 should produce a shallow clone")
+		}
+	})
+
+	t.Run("branch is cloned on its own", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "clone")
+		if err := cloneRepo(context.Background(), source, dest, "feature", CloneOptions{}); err != nil {
+			t.Fatalf("cloneRepo() unexpected error = %v", err)
+		}
+		repo, _ := gogit.PlainOpen(dest)
+		head, err := repo.Head()
+		if err != nil {
+			t.Fatalf("Head() failed: %v", err)
+		}
+		if head.Name().Short() != "feature" {
+			t.Errorf("HEAD = %s, want feature", head.Name().Short())
+		}
+		if _, err := repo.Reference(plumbing.NewRemoteReferenceName("origin", "master"), false); err == nil {
+			t.Error("single-branch clone should not fetch origin/master")
+		}
+	})
+
+	t.Run("missing branch falls back to default", func(t *testing.T) {
+		dest := filepath.Join(t.TempDir(), "clone")
+		if err := cloneRepo(context.Background(), source, dest, "does-not-exist", CloneOptions{}); err != nil {
+			t.Fatalf("cloneRepo() unexpected error = %v", err)
+		}
+		if got := countCommits(t, dest); got != 3 {
+			t.Errorf("commits = %d, want 3", got)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		dest := filepath.Join(t.TempDir(), "clone")
+		if err := cloneRepo(ctx, source, dest, "", CloneOptions{Timeout: time.Second}); err == nil {
+			t.Error("cloneRepo() with cancelled context should fail")
+		}
+	})
+}
+
+func TestCloneOptions_Timeout(t *testing.T) {
+	if got := (CloneOptions{}).timeout(); got != DefaultCloneTimeout {
+		t.Errorf("zero timeout = %v, want %v", got, DefaultCloneTimeout)
+	}
+	if got := (CloneOptions{Timeout: 5 * time.Minute}).timeout(); got != 5*time.Minute {
+		t.Errorf("timeout = %v, want 5m", got)
+	}
+}
+
+func TestTempDirSet(t *testing.T) {
+	set := &tempDirSet{dirs: make(map[string]struct{})}
+	root := t.TempDir()
+
+	finished := set.add(filepath.Join(root, "finished"))
+	leftover := set.add(filepath.Join(root, "leftover"))
+	for _, dir := range []string{finished, leftover} {
+		if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	set.remove(finished)
+	if _, err := os.Stat(finished); !os.IsNotExist(err) {
+		t.Errorf("remove() left %s behind", finished)
+	}
+
+	if n := set.removeAll(); n != 1 {
+		t.Errorf("removeAll() = %d, want 1 leftover directory", n)
+	}
+	if _, err := os.Stat(leftover); !os.IsNotExist(err) {
+		t.Errorf("removeAll() left %s behind", leftover)
+	}
+	if n := set.removeAll(); n != 0 {
+		t.Errorf("second removeAll() = %d, want 0", n)
+	}
+}
+
+func TestCloneLimiter(t *testing.T) {
+	l := NewCloneLimiter(1)
+	release, err := l.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() unexpected error = %v", err)
+	}
+	if l.InUse() != 1 || !l.saturated() {
+		t.Errorf("InUse() = %d, saturated() = %v, want 1 and true", l.InUse(), l.saturated())
+	}
+
+	acquired := make(chan func(), 1)
+	go func() {
+		next, err := l.Acquire(context.Background())
+		if err == nil {
+			acquired <- next
+		}
+	}()
+	deadline := time.Now().Add(time.Second)
+	for l.Waiting() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if l.Waiting() != 1 {
+		t.Fatalf("Waiting() = %d, want the second clone to wait", l.Waiting())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := l.Acquire(ctx); err == nil {
+		t.Error("Acquire() with a cancelled context should fail while the slot is taken")
+	}
+
+	release()
+	select {
+	case next := <-acquired:
+		next()
+	case <-time.After(time.Second):
+		t.Fatal("waiting clone did not get the freed slot")
+	}
+	if l.InUse() != 0 || l.Waiting() != 0 {
+		t.Errorf("InUse() = %d, Waiting() = %d, want 0 after release", l.InUse(), l.Waiting())
+	}
+
+	var unlimited *CloneLimiter
+	if release, err := unlimited.Acquire(ctx); err != nil || unlimited.saturated() || unlimited.Limit() != 0 {
+		t.Errorf("nil limiter should allow every clone, got err %v", err)
+	} else {
+		release()
+	}
+}
@@ -0,0 +1,223 @@
+package webhook
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RecordedEvent is a single SSE event exactly as it was sent to a streaming client.
+type RecordedEvent struct {
+	ID        int             `json:"id"`
+	Event     string          `json:"event"`
+	Data      json.RawMessage `json:"data"`
+	Timestamp time.Time       `json:"timestamp"`
+}
+
+// EventLog is an append-only, thread-safe record of the SSE events emitted for a
+// streaming job. It allows a stream to be replayed for debugging or resumed by a
+// reconnecting client. Event IDs start at 1 and increase monotonically.
+type EventLog struct {
+	mu      sync.RWMutex
+	events  []RecordedEvent
+	limit   int // most events kept (0 = all)
+	dropped int // oldest events discarded to stay within limit
+	done    bool
+	updated chan struct{}
+}
+
+// NewEventLog creates an empty event log.
+func NewEventLog() *EventLog {
+	return &EventLog{
+		events:  make([]RecordedEvent, 0, 32),
+		updated: make(chan struct{}),
+	}
+}
+
+// NewBoundedEventLog creates an empty event log that keeps only the latest
+// limit events, discarding older ones as new events are appended.
+func NewBoundedEventLog(limit int) *EventLog {
+	l := NewEventLog()
+	l.limit = limit
+	return l
+}
+
+// Updated returns a channel that is closed the next time an event is appended
+// or the log is marked done. Callers should fetch the channel before reading
+// events so no update is missed.
+func (l *EventLog) Updated() <-chan struct{} {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.updated
+}
+
+// notifyLocked wakes up all waiters. The caller must hold the write lock.
+func (l *EventLog) notifyLocked() {
+	close(l.updated)
+	l.updated = make(chan struct{})
+}
+
+// Append marshals data and records it as the next event in the log.
+func (l *EventLog) Append(event string, data interface{}) (RecordedEvent, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return RecordedEvent{}, fmt.Errorf("failed to marshal %s event: %w", event, err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	rec := RecordedEvent{
+		ID:        l.dropped + len(l.events) + 1,
+		Event:     event,
+		Data:      raw,
+		Timestamp: time.Now(),
+	}
+	l.events = append(l.events, rec)
+	if l.limit > 0 && len(l.events) > l.limit {
+		l.events = append(l.events[:0], l.events[1:]...)
+		l.dropped++
+	}
+	l.notifyLocked()
+	return rec, nil
+}
+
+// Events returns a copy of every recorded event.
+func (l *EventLog) Events() []RecordedEvent {
+	return l.After(0)
+}
+
+// After returns a copy of the events still in the log with an ID greater
+// than id.
+func (l *EventLog) After(id int) []RecordedEvent {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	start := id - l.dropped
+	if start < 0 {
+		start = 0
+	}
+	if start >= len(l.events) {
+		return []RecordedEvent{}
+	}
+
+	out := make([]RecordedEvent, len(l.events)-start)
+	copy(out, l.events[start:])
+	return out
+}
+
+// Retains reports whether every event after id is still in the log, so a
+// client that last saw id can resume without a gap.
+func (l *EventLog) Retains(id int) bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return id >= l.dropped
+}
+
+// Len returns the number of events appended, which is also the ID of the
+// latest one. A bounded log may no longer hold all of them.
+func (l *EventLog) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.dropped + len(l.events)
+}
+
+// MarkDone records that the stream has finished and no further events will be appended.
+func (l *EventLog) MarkDone() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.done {
+		l.done = true
+		l.notifyLocked()
+	}
+}
+
+// Done reports whether the stream that produced this log has finished.
+func (l *EventLog) Done() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.done
+}
+
+// sseWriter writes SSE events to a client and, when an EventLog is attached,
+// records each event before it is sent. Every event carries an "id:" field so
+// reconnecting clients can resume with Last-Event-ID.
+type sseWriter struct {
+	w       *bufio.Writer
+	events  *EventLog
+	failure string
+	seq     int
+
+	// disconnected is set once a write fails. When recording, the analysis keeps
+	// running and events are still logged so a reconnecting client can catch up.
+	disconnected bool
+	// completed is set once the final result event has been written.
+	completed bool
+}
+
+// write records (if enabled) and sends a single SSE event.
+// Returns false if the stream should stop: the write failed and there is no
+// event log to keep filling.
+func (sw *sseWriter) write(event string, data interface{}) bool {
+	var rec RecordedEvent
+	if sw.events != nil {
+		var err error
+		if rec, err = sw.events.Append(event, data); err != nil {
+			return false
+		}
+	} else {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return false
+		}
+		sw.seq++
+		rec = RecordedEvent{ID: sw.seq, Event: event, Data: raw}
+	}
+
+	if event == SSEEventResult {
+		sw.completed = true
+	}
+	if sw.disconnected {
+		return sw.events != nil
+	}
+	if !writeSSERecord(sw.w, rec) {
+		sw.disconnected = true
+		return sw.events != nil
+	}
+	return true
+}
+
+// comment writes an SSE comment line (used for heartbeats).
+// It follows the same continuation rules as write.
+func (sw *sseWriter) comment(text string) bool {
+	if sw.disconnected {
+		return sw.events != nil
+	}
+	if _, err := fmt.Fprintf(sw.w, ":%s\n\n", text); err != nil || sw.w.Flush() != nil {
+		sw.disconnected = true
+		return sw.events != nil
+	}
+	return true
+}
+
+// fail sends an error event and remembers the message so the job can be marked failed.
+func (sw *sseWriter) fail(message string) bool {
+	sw.failure = message
+	return sw.write(SSEEventError, fiber.Map{
+		"message": message,
+	})
+}
+
+// writeSSERecord writes a recorded event as a Server-Sent Event, including its id, and flushes.
+// Returns false if the write or flush failed (broken connection).
+func writeSSERecord(w *bufio.Writer, rec RecordedEvent) bool {
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", rec.ID, rec.Event, rec.Data); err != nil {
+		return false
+	}
+	return w.Flush() == nil
+}
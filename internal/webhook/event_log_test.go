@@ -0,0 +1,429 @@
+package webhook
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/logging"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestEventLog_AppendAndAfter(t *testing.T) {
+	log := NewEventLog()
+
+	for i := 0; i < 3; i++ {
+		rec, err := log.Append(SSEEventProgress, SSEProgressEvent{Phase: "detecting", Current: i})
+		if err != nil {
+			t.Fatalf("Append() unexpected error = %v", err)
+		}
+		if rec.ID != i+1 {
+			t.Errorf("Append() ID = %d, want %d", rec.ID, i+1)
+		}
+	}
+
+	if log.Len() != 3 {
+		t.Fatalf("Len() = %d, want 3", log.Len())
+	}
+
+	after := log.After(1)
+	if len(after) != 2 {
+		t.Fatalf("After(1) returned %d events, want 2", len(after))
+	}
+	if after[0].ID != 2 {
+		t.Errorf("After(1)[0].ID = %d, want 2", after[0].ID)
+	}
+
+	if got := log.After(10); len(got) != 0 {
+		t.Errorf("After(10) returned %d events, want 0", len(got))
+	}
+	if got := log.Events(); len(got) != 3 {
+		t.Errorf("Events() returned %d events, want 3", len(got))
+	}
+
+	var data SSEProgressEvent
+	if err := json.Unmarshal(after[1].Data, &data); err != nil {
+		t.Fatalf("recorded data is not valid JSON: %v", err)
+	}
+	if data.Current != 2 {
+		t.Errorf("recorded Current = %d, want 2", data.Current)
+	}
+}
+
+func TestEventLog_Done(t *testing.T) {
+	log := NewEventLog()
+	if log.Done() {
+		t.Error("new log should not be done")
+	}
+	log.MarkDone()
+	if !log.Done() {
+		t.Error("log should be done after MarkDone()")
+	}
+}
+
+func TestGetJobEvents(t *testing.T) {
+	processor := NewDefaultProcessor()
+	server, err := NewServer(&ServerConfig{
+		Host:               "localhost",
+		Port:               9999,
+		WebhookSecret:      "test-secret",
+		MaxWorkers:         2,
+		RecordStreamEvents: true,
+	}, processor)
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	events := server.handlers.trackStream(&WebhookJob{ID: "stream-job", EventType: "api_analysis_website", RepoURL: "https://example.com"})
+	if events == nil {
+		t.Fatal("trackStream() returned nil log with recording enabled")
+	}
+	_, _ = events.Append(SSEEventProgress, SSEProgressEvent{Phase: "fetching"})
+	_, _ = events.Append(SSEEventProgress, SSEProgressEvent{Phase: "detecting"})
+
+	app := server.GetApp()
+
+	t.Run("returns recorded events", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/jobs/stream-job/events?after=1", http.NoBody)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Status = %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		var out struct {
+			Total  int             `json:"total"`
+			Events []RecordedEvent `json:"events"`
+		}
+		if err := json.Unmarshal(body, &out); err != nil {
+			t.Fatalf("invalid response JSON: %v", err)
+		}
+		if out.Total != 1 || len(out.Events) != 1 || out.Events[0].ID != 2 {
+			t.Errorf("unexpected events response: %s", body)
+		}
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		req, _ := http.NewRequest("GET", "/jobs/missing/events", http.NoBody)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("Status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+		}
+	})
+}
+
+func TestSSEWriter_EmitsIDs(t *testing.T) {
+	var buf bytes.Buffer
+	sw := &sseWriter{w: bufio.NewWriter(&buf), events: NewEventLog()}
+
+	sw.write(SSEEventProgress, SSEProgressEvent{Phase: "fetching"})
+	sw.fail("boom")
+
+	out := buf.String()
+	if !strings.Contains(out, "id: 1\nevent: progress\n") {
+		t.Errorf("first event missing id field: %q", out)
+	}
+	if !strings.Contains(out, "id: 2\nevent: error\n") {
+		t.Errorf("second event missing id field: %q", out)
+	}
+	if sw.failure != "boom" {
+		t.Errorf("failure = %q, want %q", sw.failure, "boom")
+	}
+	if sw.events.Len() != 2 {
+		t.Errorf("recorded %d events, want 2", sw.events.Len())
+	}
+}
+
+func TestStreamEventsToSSE_CommitEvents(t *testing.T) {
+	var buf bytes.Buffer
+	sw := &sseWriter{w: bufio.NewWriter(&buf)}
+
+	events := make(chan analysis.StreamEvent, 2)
+	events <- analysis.StreamEvent{Type: analysis.EventItem, Item: &analysis.ItemProgress{
+		Kind: "commit", ID: "abc123", Current: 2, Total: 4, Suspicious: true, Score: 0.5, Severity: "medium", SuspiciousSoFar: 1,
+	}}
+	events <- analysis.StreamEvent{Type: analysis.EventItem, Item: &analysis.ItemProgress{Kind: "page", ID: "ignored", Current: 1, Total: 1}}
+	close(events)
+
+	streamEventsToSSE(sw, events, logging.Default(), "job", "stream_repository")
+
+	out := buf.String()
+	if strings.Count(out, "event: commit\n") != 1 {
+		t.Fatalf("want exactly one commit event, got %q", out)
+	}
+	data := out[strings.Index(out, "data: ")+len("data: "):]
+	var got SSECommitEvent
+	if err := json.Unmarshal([]byte(strings.TrimSpace(data)), &got); err != nil {
+		t.Fatalf("invalid commit event JSON %q: %v", data, err)
+	}
+	want := SSECommitEvent{Hash: "abc123", Index: 2, Total: 4, Suspicious: true, Score: 0.5, Severity: "medium", SuspiciousSoFar: 1, SuspicionRate: 0.5}
+	if got != want {
+		t.Errorf("commit event = %+v, want %+v", got, want)
+	}
+}
+
+func TestStreamEventsToSSE_ExamplePolicy(t *testing.T) {
+	var buf bytes.Buffer
+	sw := &sseWriter{w: bufio.NewWriter(&buf)}
+
+	detection := analysis.Detection{Strategy: "overused_phrases", Detected: true, Category: "web-pattern",
+		Examples: []string{"Bearer abcdefghijklmnop1234", "second", "third"}}
+	report := &analysis.AnalysisReport{SourceType: analysis.SourceTypeWeb, Detections: []analysis.Detection{detection}}
+	events := make(chan analysis.StreamEvent, 2)
+	events <- analysis.StreamEvent{Type: analysis.EventDetection, Detection: &detection}
+	events <- analysis.StreamEvent{Type: analysis.EventComplete, Report: report}
+	close(events)
+
+	policy := analysis.ExamplePolicy{MaxExamples: 1, Redact: true}
+	got := streamEventsToSSEWithMetrics(sw, events, logging.Default(), "job", "api_analysis_website",
+		analysis.NullMetrics{}, DefaultClassificationThresholds, policy)
+
+	out := buf.String()
+	if strings.Contains(out, "abcdefghijklmnop1234") || strings.Contains(out, "second") {
+		t.Errorf("stream leaks examples the policy removes: %q", out)
+	}
+	if strings.Count(out, "Bearer [REDACTED]") != 2 {
+		t.Errorf("want the redacted example in the detection and result events, got %q", out)
+	}
+	if got != report || len(report.Detections[0].Examples) != 3 {
+		t.Error("the returned report should be the original, with its full examples")
+	}
+}
+
+func TestReplayEvents_AfterLastID(t *testing.T) {
+	events := NewEventLog()
+	for _, phase := range []string{"validating", "fetching", "detecting"} {
+		_, _ = events.Append(SSEEventProgress, SSEProgressEvent{Phase: phase})
+	}
+
+	done := make(chan struct{})
+	var buf bytes.Buffer
+	go func() {
+		replayEvents(context.Background(), bufio.NewWriter(&buf), events, 1)
+		close(done)
+	}()
+
+	// Events recorded while the client is replaying must still be forwarded.
+	_, _ = events.Append(SSEEventResult, fiber.Map{"status": StatusCompleted})
+	events.MarkDone()
+
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("replayEvents did not return after log was marked done")
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "id: 1\n") {
+		t.Errorf("replay should skip events up to Last-Event-ID: %q", out)
+	}
+	for _, want := range []string{"id: 2\n", "id: 3\n", "id: 4\nevent: result\n"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("replay output missing %q: %q", want, out)
+		}
+	}
+}
+
+func TestStreamResume_LastEventID(t *testing.T) {
+	processor := NewDefaultProcessor()
+	server, err := NewServer(&ServerConfig{
+		Host:               "localhost",
+		Port:               9999,
+		WebhookSecret:      "test-secret",
+		MaxWorkers:         2,
+		RecordStreamEvents: true,
+	}, processor)
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	events := server.handlers.trackStream(&WebhookJob{ID: "resume-job", EventType: "api_analysis_website", RepoURL: "https://example.com"})
+	_, _ = events.Append(SSEEventProgress, SSEProgressEvent{Phase: "fetching"})
+	_, _ = events.Append(SSEEventResult, fiber.Map{"status": StatusCompleted})
+	events.MarkDone()
+
+	req, _ := http.NewRequest("POST", "/api/stream/website", http.NoBody)
+	req.Header.Set("Last-Event-ID", "1")
+	req.Header.Set("X-Job-ID", "resume-job")
+
+	resp, err := server.GetApp().Test(req)
+	if err != nil {
+		t.Fatalf("Test() unexpected error = %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, _ := io.ReadAll(resp.Body)
+	out := string(body)
+	if strings.Contains(out, "id: 1\n") || !strings.Contains(out, "id: 2\nevent: result\n") {
+		t.Errorf("unexpected resumed stream: %q", out)
+	}
+}
+
+func TestEventLog_Bounded(t *testing.T) {
+	log := NewBoundedEventLog(2)
+	for _, phase := range []string{"validating", "fetching", "detecting"} {
+		_, _ = log.Append(SSEEventProgress, SSEProgressEvent{Phase: phase})
+	}
+
+	events := log.After(0)
+	if len(events) != 2 || events[0].ID != 2 || events[1].ID != 3 {
+		t.Fatalf("After(0) = %+v, want the latest two events", events)
+	}
+	if after := log.After(2); len(after) != 1 || after[0].ID != 3 {
+		t.Errorf("After(2) = %+v, want event 3", after)
+	}
+	if log.Len() != 3 {
+		t.Errorf("Len() = %d, want 3 events appended", log.Len())
+	}
+	if log.Retains(0) || !log.Retains(1) || !log.Retains(3) {
+		t.Error("Retains should be false only for clients missing a discarded event")
+	}
+}
+
+func TestStreamBuffers_ExpireAfterGrace(t *testing.T) {
+	now := time.Now()
+	buffers := newStreamBuffers(10, time.Minute)
+	buffers.now = func() time.Time { return now }
+
+	events := buffers.start("job", nil)
+	if buffers.get("job") != events {
+		t.Fatal("a running stream should be buffered")
+	}
+
+	now = now.Add(time.Hour)
+	if buffers.get("job") == nil {
+		t.Fatal("a running stream should not expire")
+	}
+
+	buffers.finish("job")
+	now = now.Add(30 * time.Second)
+	if buffers.get("job") == nil {
+		t.Fatal("a finished stream should stay resumable during the grace period")
+	}
+	now = now.Add(time.Minute)
+	if buffers.get("job") != nil {
+		t.Error("a finished stream should be dropped after the grace period")
+	}
+}
+
+func TestStreamResume_WithoutRecording(t *testing.T) {
+	server, err := NewServer(&ServerConfig{
+		Host:          "localhost",
+		Port:          9999,
+		WebhookSecret: "test-secret",
+		MaxWorkers:    2,
+	}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	wh := server.handlers
+
+	events := wh.trackStream(&WebhookJob{ID: "buffered-job", EventType: "api_analysis_website", RepoURL: "https://example.com"})
+	sw := &sseWriter{w: bufio.NewWriter(io.Discard), events: events}
+	sw.write(SSEEventProgress, SSEProgressEvent{Phase: "fetching"})
+	sw.write(SSEEventResult, fiber.Map{"status": StatusCompleted})
+	wh.finishStream("buffered-job", sw)
+
+	if _, err := wh.queue.GetJob("buffered-job"); err == nil {
+		t.Error("without recording the stream should not be tracked in the job store")
+	}
+
+	resume := func() *http.Response {
+		req, _ := http.NewRequest("POST", "/api/stream/website", http.NoBody)
+		req.Header.Set("Last-Event-ID", "1")
+		req.Header.Set("X-Job-ID", "buffered-job")
+		resp, err := server.GetApp().Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		return resp
+	}
+
+	resp := resume()
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if out := string(body); strings.Contains(out, "id: 1\n") || !strings.Contains(out, "id: 2\nevent: result\n") {
+		t.Errorf("unexpected resumed stream: %q", out)
+	}
+
+	wh.streams.now = func() time.Time { return time.Now().Add(DefaultStreamResumeGrace + time.Second) }
+	resp = resume()
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("an expired stream should start a fresh analysis, got status %d", resp.StatusCode)
+	}
+}
+
+// closedWriter fails every write, like a connection the client has dropped.
+type closedWriter struct{}
+
+func (closedWriter) Write([]byte) (int, error) { return 0, io.ErrClosedPipe }
+
+func TestFinishStream_Status(t *testing.T) {
+	server, err := NewServer(&ServerConfig{
+		Host:               "localhost",
+		Port:               9999,
+		WebhookSecret:      "test-secret",
+		MaxWorkers:         2,
+		RecordStreamEvents: true,
+	}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	wh := server.handlers
+
+	tests := []struct {
+		name   string
+		out    io.Writer
+		events func(sw *sseWriter)
+		want   string
+	}{
+		{"result delivered", io.Discard, func(sw *sseWriter) {
+			sw.write(SSEEventResult, fiber.Map{"status": StatusCompleted})
+		}, StatusCompleted},
+		{"analysis failed", io.Discard, func(sw *sseWriter) {
+			sw.fail("analysis timed out")
+		}, StatusFailed},
+		{"client disconnected", closedWriter{}, func(sw *sseWriter) {
+			sw.write(SSEEventProgress, SSEProgressEvent{Phase: "fetching"})
+		}, StatusCancelled},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobID := strings.ReplaceAll(tt.name, " ", "-")
+			events := wh.trackStream(&WebhookJob{ID: jobID, EventType: "api_analysis_website", RepoURL: "https://example.com"})
+			sw := &sseWriter{w: bufio.NewWriter(tt.out), events: events}
+			tt.events(sw)
+			wh.finishStream(jobID, sw)
+
+			job, err := wh.queue.GetJob(jobID)
+			if err != nil {
+				t.Fatalf("GetJob() error = %v", err)
+			}
+			if job.Status != tt.want {
+				t.Errorf("Status = %q, want %q", job.Status, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Feedback is a user's verdict on one strategy's detection. Git feedback
+// names the commit; web feedback applies to the page in SourceID (stored as
+// its normalized URL key).
+type Feedback struct {
+	Strategy        string    `json:"strategy"`
+	SourceID        string    `json:"source_id"`
+	CommitHash      string    `json:"commit_hash,omitempty"`
+	IsFalsePositive bool      `json:"is_false_positive"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// FeedbackRequest is the body of POST /api/feedback.
+type FeedbackRequest struct {
+	Strategy        string `json:"strategy"`
+	SourceID        string `json:"source_id"`
+	CommitHash      string `json:"commit_hash"`
+	IsFalsePositive *bool  `json:"is_false_positive"`
+}
+
+// FeedbackStore persists detection feedback. Both job stores implement it,
+// so feedback lives in the same backend as jobs. Saving feedback for the same
+// strategy, source and commit replaces the earlier verdict.
+type FeedbackStore interface {
+	SaveFeedback(fb *Feedback) error
+	ListFeedback() ([]*Feedback, error)
+}
+
+type feedbackKey struct {
+	strategy, sourceID, commitHash string
+}
+
+// loadSuppressions builds the suppression list from the false-positive
+// verdicts in store.
+func loadSuppressions(store FeedbackStore) (*analysis.SuppressionList, error) {
+	entries, err := store.ListFeedback()
+	if err != nil {
+		return nil, err
+	}
+	list := analysis.NewSuppressionList()
+	for _, fb := range entries {
+		if fb.IsFalsePositive {
+			list.Add(fb.Strategy, fb.SourceID, fb.CommitHash)
+		}
+	}
+	return list, nil
+}
+
+func (s *MemoryJobStore) SaveFeedback(fb *Feedback) error {
+	s.mu.Lock()
+	s.feedback[feedbackKey{fb.Strategy, fb.SourceID, fb.CommitHash}] = fb
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryJobStore) ListFeedback() ([]*Feedback, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make([]*Feedback, 0, len(s.feedback))
+	for _, fb := range s.feedback {
+		entries = append(entries, fb)
+	}
+	return entries, nil
+}
+
+// WithFeedbackStore enables POST /api/feedback and suppression of reported
+// false positives in streamed analyses.
+func (wh *WebhookHandlers) WithFeedbackStore(store FeedbackStore) *WebhookHandlers {
+	wh.feedback = store
+	wh.processor.Feedback = store
+	return wh
+}
+
+// WithAPIToken sets a token that authorizes POST /api/feedback in addition
+// to the webhook secret, so feedback clients need not hold the secret.
+func (wh *WebhookHandlers) WithAPIToken(token string) *WebhookHandlers {
+	wh.apiToken = token
+	return wh
+}
+
+// requireToken admits requests whose "Authorization: Bearer" token is the
+// webhook secret or the API token. With neither configured it admits none.
+func (wh *WebhookHandlers) requireToken(c *fiber.Ctx) error {
+	token, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if ok && token != "" {
+		for _, want := range []string{wh.secret, wh.apiToken} {
+			if want != "" && subtle.ConstantTimeCompare([]byte(token), []byte(want)) == 1 {
+				return c.Next()
+			}
+		}
+	}
+	return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
+		"error": "invalid or missing token",
+	})
+}
+
+// SubmitFeedback handles POST /api/feedback. A false-positive verdict hides
+// that strategy's hit on the commit (git) or page (web) in later analyses;
+// is_false_positive=false confirms the detection and lifts a suppression.
+func (wh *WebhookHandlers) SubmitFeedback(c *fiber.Ctx) error {
+	if wh.feedback == nil {
+		return c.Status(http.StatusNotImplemented).JSON(fiber.Map{
+			"error": "feedback is not supported by the configured job store",
+		})
+	}
+
+	var req FeedbackRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": "invalid request body",
+		})
+	}
+
+	fb, err := wh.feedbackFromRequest(&req)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := wh.feedback.SaveFeedback(fb); err != nil {
+		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
+			"error": "failed to save feedback",
+		})
+	}
+	wh.metrics.RecordFeedback(fb.Strategy, fb.IsFalsePositive)
+
+	// Cached reports predate the verdict. Their keys are hashed, so drop
+	// them all rather than guess which ones it affects.
+	wh.cache.Clear()
+
+	return c.Status(http.StatusCreated).JSON(fiber.Map{
+		"status":   "recorded",
+		"feedback": fb,
+	})
+}
+
+func (wh *WebhookHandlers) feedbackFromRequest(req *FeedbackRequest) (*Feedback, error) {
+	strategy := strings.TrimSpace(req.Strategy)
+	sourceID := strings.TrimSpace(req.SourceID)
+	commitHash := strings.ToLower(strings.TrimSpace(req.CommitHash))
+
+	if strategy == "" {
+		return nil, errors.New("strategy is required")
+	}
+	if req.IsFalsePositive == nil {
+		return nil, errors.New("is_false_positive is required")
+	}
+
+	registry := analysis.DefaultWebRegistry()
+	if commitHash != "" {
+		registry = analysis.DefaultGitRegistry()
+	} else if sourceID == "" {
+		return nil, errors.New("source_id or commit_hash is required")
+	} else {
+		sourceID = wh.urlNormalizer.Key(sourceID)
+	}
+	if _, ok := registry.Get(strategy); !ok {
+		return nil, fmt.Errorf("unknown strategy %s (see GET /api/strategies)", strategy)
+	}
+
+	return &Feedback{
+		Strategy:        strategy,
+		SourceID:        sourceID,
+		CommitHash:      commitHash,
+		IsFalsePositive: *req.IsFalsePositive,
+		CreatedAt:       time.Now(),
+	}, nil
+}
@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFeedbackStore_LatestVerdictWins(t *testing.T) {
+	for name, store := range openTestStores(t) {
+		t.Run(name, func(t *testing.T) {
+			fs := store.(FeedbackStore)
+			entries := []*Feedback{
+				{Strategy: "size_analysis", SourceID: "https://github.com/o/r", CommitHash: "abc123", IsFalsePositive: true},
+				{Strategy: "overused_phrases", SourceID: "https://example.com/page", IsFalsePositive: true},
+				{Strategy: "timing_analysis", CommitHash: "abc123", IsFalsePositive: false},
+			}
+			for _, fb := range entries {
+				if err := fs.SaveFeedback(fb); err != nil {
+					t.Fatalf("SaveFeedback() unexpected error = %v", err)
+				}
+			}
+
+			list, err := loadSuppressions(fs)
+			if err != nil {
+				t.Fatalf("loadSuppressions() unexpected error = %v", err)
+			}
+			if list.Len() != 2 {
+				t.Errorf("Len() = %d, want 2", list.Len())
+			}
+			if !list.Suppressed("size_analysis", "", "abc123") {
+				t.Error("size_analysis on abc123 should be suppressed")
+			}
+			if !list.Suppressed("overused_phrases", "https://example.com/page", "") {
+				t.Error("overused_phrases on the page should be suppressed")
+			}
+			if list.Suppressed("timing_analysis", "", "abc123") {
+				t.Error("confirmed detections should not be suppressed")
+			}
+
+			// Retracting the false positive lifts the suppression.
+			_ = fs.SaveFeedback(&Feedback{Strategy: "size_analysis", SourceID: "https://github.com/o/r", CommitHash: "abc123"})
+			list, _ = loadSuppressions(fs)
+			if list.Suppressed("size_analysis", "", "abc123") {
+				t.Error("size_analysis on abc123 should no longer be suppressed")
+			}
+		})
+	}
+}
+
+func TestSubmitFeedback(t *testing.T) {
+	server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, WebhookSecret: "test-secret", MaxWorkers: 1}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	app := server.GetApp()
+
+	post := func(body string) (*http.Response, map[string]any) {
+		req, _ := http.NewRequest("POST", "/api/feedback", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer test-secret")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer resp.Body.Close()
+		var out map[string]any
+		_ = json.NewDecoder(resp.Body).Decode(&out)
+		return resp, out
+	}
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"git commit", `{"strategy":"size_analysis","source_id":"https://github.com/o/r","commit_hash":"ABC123","is_false_positive":true}`, http.StatusCreated},
+		{"web page", `{"strategy":"overused_phrases","source_id":"https://Example.com/page?utm_source=x","is_false_positive":true}`, http.StatusCreated},
+		{"confirmation", `{"strategy":"size_analysis","commit_hash":"def456","is_false_positive":false}`, http.StatusCreated},
+		{"missing verdict", `{"strategy":"size_analysis","commit_hash":"abc123"}`, http.StatusBadRequest},
+		{"missing target", `{"strategy":"overused_phrases","is_false_positive":true}`, http.StatusBadRequest},
+		{"unknown strategy", `{"strategy":"sise_analysis","commit_hash":"abc123","is_false_positive":true}`, http.StatusBadRequest},
+		{"web strategy on commit", `{"strategy":"overused_phrases","commit_hash":"abc123","is_false_positive":true}`, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, out := post(tt.body)
+			if resp.StatusCode != tt.want {
+				t.Errorf("Status = %d, want %d (%v)", resp.StatusCode, tt.want, out)
+			}
+		})
+	}
+
+	list, err := loadSuppressions(server.store.(FeedbackStore))
+	if err != nil {
+		t.Fatalf("loadSuppressions() unexpected error = %v", err)
+	}
+	if !list.Suppressed("size_analysis", "", "abc123") {
+		t.Error("commit hashes should be matched case-insensitively")
+	}
+	if !list.Suppressed("overused_phrases", server.handlers.urlNormalizer.Key("https://example.com/page"), "") {
+		t.Error("web feedback should be keyed by the normalized URL")
+	}
+
+	feedback := server.Metrics.Snapshot().FeedbackByStrategy
+	if got := feedback["size_analysis"]; got == nil || got.FalsePositives != 1 || got.Confirmed != 1 {
+		t.Errorf("size_analysis feedback = %+v, want 1 false positive and 1 confirmation", got)
+	}
+}
+
+func TestSubmitFeedback_RequiresToken(t *testing.T) {
+	server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, WebhookSecret: "test-secret", APIToken: "feedback-token", MaxWorkers: 1}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	app := server.GetApp()
+
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"wrong token", "Bearer nope", http.StatusUnauthorized},
+		{"secret without scheme", "test-secret", http.StatusUnauthorized},
+		{"webhook secret", "Bearer test-secret", http.StatusCreated},
+		{"api token", "Bearer feedback-token", http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"strategy":"size_analysis","commit_hash":"abc123","is_false_positive":true}`
+			req, _ := http.NewRequest("POST", "/api/feedback", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Test() unexpected error = %v", err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("Status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+
+	list, _ := loadSuppressions(server.store.(FeedbackStore))
+	if list.Len() != 1 {
+		t.Errorf("Len() = %d, want only the authorized verdicts recorded", list.Len())
+	}
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// GitHostAllowlist restricts which hosts repositories are cloned from. An
+// entry is a host name such as "github.com", matched exactly, or a wildcard
+// such as "*.corp.example.com", matching any subdomain but not the domain
+// itself. Matching ignores case, ports and user info. An empty list allows
+// every host.
+type GitHostAllowlist []string
+
+// Validate reports the first malformed entry.
+func (l GitHostAllowlist) Validate() error {
+	for _, entry := range l {
+		host := strings.TrimPrefix(entry, "*.")
+		if host == "" || strings.ContainsAny(host, "*/:@ \t") {
+			return fmt.Errorf("invalid git host %q: want a host name or *.domain", entry)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether repoURL may be cloned. URLs whose host cannot be
+// determined are denied when the list is not empty.
+func (l GitHostAllowlist) Allows(repoURL string) bool {
+	if len(l) == 0 {
+		return true
+	}
+	host := repositoryHost(repoURL)
+	if host == "" {
+		return false
+	}
+	for _, entry := range l {
+		entry = strings.TrimSuffix(strings.ToLower(entry), ".")
+		if domain, ok := strings.CutPrefix(entry, "*."); ok {
+			if strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		} else if host == entry {
+			return true
+		}
+	}
+	return false
+}
+
+// repositoryHost returns the lowercased host of a clone URL with any
+// transport, or of an scp-style "git@host:path" address, or "" for local
+// paths and unparsable URLs.
+func repositoryHost(repoURL string) string {
+	var host string
+	if strings.Contains(repoURL, "://") {
+		u, err := url.Parse(repoURL)
+		if err != nil || u.Scheme == "file" {
+			return ""
+		}
+		host = u.Hostname()
+	} else if at := strings.Index(repoURL, "@"); at >= 0 {
+		if h, _, ok := strings.Cut(repoURL[at+1:], ":"); ok && !strings.Contains(h, "/") {
+			host = h
+		}
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// WithAllowedGitHosts limits the repositories the analysis endpoints and
+// push webhooks accept to those on hosts l allows; others are refused with
+// 403.
+func (wh *WebhookHandlers) WithAllowedGitHosts(l GitHostAllowlist) *WebhookHandlers {
+	wh.gitHosts = l
+	return wh
+}
+
+// gitHostDenied answers a request for a repository on a host outside
+// webhook.allowed_git_hosts with 403.
+func gitHostDenied(c *fiber.Ctx, repoURL string) error {
+	return c.Status(http.StatusForbidden).JSON(fiber.Map{
+		"error": fmt.Sprintf("repository host %q is not allowed by webhook.allowed_git_hosts", repositoryHost(repoURL)),
+	})
+}
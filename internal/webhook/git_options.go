@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+	"github.com/TryCadence/Cadence/internal/analysis/detectors"
+	"github.com/TryCadence/Cadence/internal/analysis/sources"
+)
+
+// GitSourceFilters are the file and author filters applied to every
+// repository analysis, as the analyze command applies them from the config.
+type GitSourceFilters struct {
+	// ExcludeFiles lists file patterns whose changes are left out of commit
+	// sizes.
+	ExcludeFiles []string
+	// GeneratedFiles adds patterns to git.DefaultGeneratedFiles.
+	// CountGeneratedFiles keeps generated files in commit sizes.
+	GeneratedFiles      []string
+	CountGeneratedFiles bool
+	// IgnoreAuthors drops commits by matching authors (name or email globs).
+	IgnoreAuthors []string
+}
+
+// GitStrategyOptions tune the git strategies of every repository analysis.
+// Zero fields keep the detector defaults.
+type GitStrategyOptions struct {
+	// DisabledStrategies names strategies the server never runs; requests
+	// can disable more but not re-enable these.
+	DisabledStrategies  map[string]bool
+	DependencyManifests []string
+	AIAssistants        []string
+	NGramMaxCoverage    float64
+	Churn               patterns.ChurnOptions
+	LanguageProfiles    map[string]patterns.LanguageProfile
+	SampleSizeGate      analysis.SampleSizeGate
+}
+
+// newGitSource returns the source for analyzing branch of the checkout at
+// repoPath with the server's history limits and filters.
+func (ap *AnalysisProcessor) newGitSource(repoPath, branch, since string, maxCommits int) *sources.GitRepositorySource {
+	source := sources.NewGitRepositorySource(repoPath, branch)
+	source.SinceHash = since
+	source.ExcludeFiles = ap.SourceFilters.ExcludeFiles
+	source.GeneratedFiles = ap.SourceFilters.GeneratedFiles
+	source.CountGeneratedFiles = ap.SourceFilters.CountGeneratedFiles
+	source.IgnoreAuthors = ap.SourceFilters.IgnoreAuthors
+	ap.applyHistoryLimits(source, maxCommits)
+	return source
+}
+
+// newGitDetector returns a git detector with the server's strategy options,
+// skipping the strategies in disabled as well as those the server disables.
+func (ap *AnalysisProcessor) newGitDetector(thresholds *patterns.Thresholds, disabled map[string]bool) *detectors.GitDetector {
+	opts := ap.StrategyOptions
+	det := detectors.NewGitDetector(thresholds)
+	det.DisabledStrategies = disabled
+	if len(opts.DisabledStrategies) > 0 {
+		det.DisabledStrategies = make(map[string]bool, len(disabled)+len(opts.DisabledStrategies))
+		for _, set := range []map[string]bool{opts.DisabledStrategies, disabled} {
+			for name, off := range set {
+				if off {
+					det.DisabledStrategies[name] = true
+				}
+			}
+		}
+	}
+	det.DependencyManifests = opts.DependencyManifests
+	det.AIAssistants = opts.AIAssistants
+	det.NGramMaxCoverage = opts.NGramMaxCoverage
+	det.Churn = opts.Churn
+	det.LanguageProfiles = opts.LanguageProfiles
+	det.SampleSizeGate = opts.SampleSizeGate
+	return det
+}
@@ -4,45 +4,262 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/TryCadence/Cadence/internal/ai"
 	"github.com/TryCadence/Cadence/internal/analysis"
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
+	webpatterns "github.com/TryCadence/Cadence/internal/analysis/adapters/web/patterns"
 	"github.com/TryCadence/Cadence/internal/analysis/detectors"
 	"github.com/TryCadence/Cadence/internal/analysis/sources"
 	"github.com/TryCadence/Cadence/internal/logging"
-	gogit "github.com/go-git/go-git/v5"
+	"github.com/TryCadence/Cadence/internal/netguard"
+	"github.com/TryCadence/Cadence/internal/version"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/compress"
 )
 
 type WebhookHandlers struct {
 	secret    string
+	apiToken  string
 	queue     *JobQueue
 	processor *AnalysisProcessor
 	cache     analysis.AnalysisCache
 	metrics   analysis.AnalysisMetrics
 	plugins   *analysis.PluginManager
+
+	recordEvents  bool
+	compress      bool
+	streams       *streamBuffers
+	urlNormalizer *web.URLNormalizer
+	limiter       *RateLimiter
+	feedback      FeedbackStore
+	readyMaxDepth int
+	branches      BranchFilter
+	gitHosts      GitHostAllowlist
+	log           *logging.Logger
 }
 
+// DefaultAnalysisTimeout bounds one analysis run when
+// AnalysisProcessor.AnalysisTimeout is unset.
+const DefaultAnalysisTimeout = 5 * time.Minute
+
 type AnalysisProcessor struct {
 	DetectorThresholds *patterns.Thresholds
 	Logger             *logging.Logger
 	Metrics            analysis.AnalysisMetrics
+	Cache              analysis.AnalysisCache
+	URLNormalizer      *web.URLNormalizer
+	Clone              CloneOptions
+	// CloneLimiter bounds concurrent clones; the webhook server shares one
+	// between queued jobs and streams. Nil allows any number.
+	CloneLimiter *CloneLimiter
+	// RepoCache, when set, keeps clones between analyses and updates them
+	// instead of cloning each repository afresh.
+	RepoCache *RepoCache
+	// Classification maps suspicion rates to assessment labels; zero
+	// bounds fall back to DefaultClassificationThresholds.
+	Classification ClassificationThresholds
+	// AllowPrivateHosts lets repository, website and callback URLs point at
+	// loopback, private and link-local addresses. Leave it off for servers
+	// that accept URLs from untrusted users.
+	AllowPrivateHosts bool
+	// AllowLocalPaths lets analysis requests name a checkout on the server
+	// with local_path instead of a URL. Paths must lie under LocalPathRoot;
+	// with it unset, every local path is refused.
+	AllowLocalPaths bool
+	LocalPathRoot   string
+	// Feedback supplies the false positives reported through
+	// POST /api/feedback, which are suppressed on re-analysis.
+	Feedback FeedbackStore
+	// MaxCommits caps the history analyzed per repository. Zero keeps
+	// sources.DefaultMaxCommits; negative analyzes full history. Requests
+	// may ask for fewer commits, never more.
+	MaxCommits int
+	// MaxDiffBytes caps the diff content held in memory per analysis
+	// (0 = no limit).
+	MaxDiffBytes int64
+	// DiffWorkers is how many commit diffs are computed in parallel
+	// (0 = GOMAXPROCS, 1 = serial).
+	DiffWorkers int
+	// MergeStrategy controls whether merge commits are analyzed against
+	// their first parent. Empty skips them.
+	MergeStrategy git.MergeStrategy
+	// AuthorIdentity overrides how author emails are grouped into
+	// identities. Nil keeps git.DefaultIdentityRules.
+	AuthorIdentity *git.IdentityRules
+	// SourceFilters and StrategyOptions carry the config's file and author
+	// filters and git strategy settings into repository analyses.
+	SourceFilters   GitSourceFilters
+	StrategyOptions GitStrategyOptions
+	// MinWordCount is the fewest words a website needs to be analyzed.
+	// Zero uses patterns.DefaultMinWordCount.
+	MinWordCount int
+	// Vocabulary, when set, replaces the phrase lists of the web vocabulary
+	// strategies (see webpatterns.ReadVocabulary).
+	Vocabulary *webpatterns.Vocabulary
+	// FetcherOptions configure the page fetcher of website analyses, e.g.
+	// its proxy, TLS trust and redirect limit. The SSRF guard is added to
+	// them.
+	FetcherOptions []web.FetcherOption
+	// MinReportConfidence leaves detections from strategies less confident
+	// than this out of reports, unless a request sets its own floor. They
+	// still count toward the totals and score. Zero reports everything.
+	MinReportConfidence float64
+	// ExamplePolicy limits and redacts the detection examples in job
+	// results and streams. Cached reports keep their full examples.
+	ExamplePolicy analysis.ExamplePolicy
+	// IncludePassed reports the git strategies that ran and fired on no
+	// commit in passed_strategies, for every repository job rather than only
+	// those that ask for it.
+	IncludePassed bool
+	// AnalysisTimeout bounds fetching and detection for one job, not
+	// counting the clone. Zero uses DefaultAnalysisTimeout.
+	AnalysisTimeout time.Duration
+	// ConfigFingerprint identifies the configuration the server was started
+	// with (config.Config.Fingerprint) and is part of every cache key, so
+	// reports computed under other settings are never reused.
+	ConfigFingerprint string
+	// Analyzer, when set and configured, gives a second opinion on the top
+	// high-severity suspicions with the detection_triage skill.
+	Analyzer ai.Analyzer
+	// TriageLimit caps how many suspicions are triaged per analysis
+	// (0 = DefaultTriageLimit, negative disables triage).
+	TriageLimit int
+}
+
+// guard checks user-supplied URLs against internal network addresses.
+func (ap *AnalysisProcessor) guard() *netguard.Guard {
+	return &netguard.Guard{AllowPrivate: ap.AllowPrivateHosts}
+}
+
+// suppressions loads the reported false positives, or nil without a
+// feedback store. A store failure is logged and analysis runs unsuppressed.
+func (ap *AnalysisProcessor) suppressions(ctx context.Context, jobID string) *analysis.SuppressionList {
+	if ap.Feedback == nil {
+		return nil
+	}
+	list, err := loadSuppressions(ap.Feedback)
+	if err != nil {
+		ap.log(ctx).LogPhaseError(jobID, "failed to load feedback suppressions", err)
+		return nil
+	}
+	return list
+}
+
+// applyHistoryLimits sets the server's memory guards, diff parallelism,
+// merge strategy and author identity rules on source, lowering the commit
+// cap to requested when the request asks for fewer.
+func (ap *AnalysisProcessor) applyHistoryLimits(source *sources.GitRepositorySource, requested int) {
+	switch {
+	case ap.MaxCommits > 0:
+		source.MaxCommits = ap.MaxCommits
+	case ap.MaxCommits < 0:
+		source.MaxCommits = 0
+	}
+	if requested > 0 && (source.MaxCommits <= 0 || requested < source.MaxCommits) {
+		source.MaxCommits = requested
+	}
+	source.MaxDiffBytes = ap.MaxDiffBytes
+	source.DiffWorkers = ap.DiffWorkers
+	source.MergeStrategy = ap.MergeStrategy
+	if ap.AuthorIdentity != nil {
+		source.Identity = *ap.AuthorIdentity
+	}
+}
+
+func (ap *AnalysisProcessor) analysisTimeout() time.Duration {
+	if ap.AnalysisTimeout > 0 {
+		return ap.AnalysisTimeout
+	}
+	return DefaultAnalysisTimeout
+}
+
+// JobTimeout bounds one Process call: the clone and the analysis back to
+// back. The job queue uses it as the per-job deadline.
+func (ap *AnalysisProcessor) JobTimeout() time.Duration {
+	return ap.Clone.timeout() + ap.analysisTimeout()
+}
+
+// runAnalysis runs source through det under AnalysisTimeout. timedOut
+// reports whether the run failed because that deadline passed.
+func (ap *AnalysisProcessor) runAnalysis(ctx context.Context, source analysis.AnalysisSource, det analysis.Detector) (report *analysis.AnalysisReport, timedOut bool, err error) {
+	runCtx, cancel := context.WithTimeout(ctx, ap.analysisTimeout())
+	defer cancel()
+
+	report, err = analysis.NewDefaultDetectionRunner().Run(runCtx, source, det)
+	return report, err != nil && errors.Is(runCtx.Err(), context.DeadlineExceeded), err
 }
 
-func (ap *AnalysisProcessor) log() *logging.Logger {
+// checkoutRepo clones url at branch once a clone slot is free: into a
+// temporary directory named tmpName, or, with a RepoCache, by updating the
+// repository's cached clone, which stays locked until release is called.
+// release also removes a temporary clone.
+func (ap *AnalysisProcessor) checkoutRepo(ctx context.Context, url, branch, tmpName string) (dir string, release func(), err error) {
+	if ap.RepoCache != nil {
+		// Wait for the repository before taking a clone slot, so analyses
+		// queued behind one of the same repository don't hold slots.
+		dir, unlock, err := ap.RepoCache.lock(ctx, url)
+		if err != nil {
+			return "", nil, err
+		}
+		slot, err := ap.CloneLimiter.Acquire(ctx)
+		if err != nil {
+			unlock()
+			return "", nil, err
+		}
+		err = ap.RepoCache.update(ctx, dir, url, branch, ap.Clone)
+		slot()
+		if err != nil {
+			unlock()
+			return "", nil, err
+		}
+		ap.RepoCache.evict()
+		return dir, unlock, nil
+	}
+
+	slot, err := ap.CloneLimiter.Acquire(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+	defer slot()
+	dir = cloneDirs.add(filepath.Join(os.TempDir(), tmpName))
+	if err := cloneRepo(ctx, url, dir, branch, ap.Clone); err != nil {
+		cloneDirs.remove(dir)
+		return "", nil, err
+	}
+	return dir, func() { cloneDirs.remove(dir) }, nil
+}
+
+// analysisTimedOut records a run that hit AnalysisTimeout and returns the
+// job's error.
+func (ap *AnalysisProcessor) analysisTimedOut(ctx context.Context, job *WebhookJob, sourceType string, err error) error {
+	ap.log(ctx).LogPhaseError(job.ID, "analysis timed out", err, "timeout", ap.analysisTimeout().String())
+	ap.metricsCollector().RecordError(sourceType, "timeout")
+	job.Progress = "timed-out"
+	return fmt.Errorf("analysis timed out after %s", ap.analysisTimeout())
+}
+
+// log returns the processor's logger bound to ctx, so its lines carry the
+// job's trace ID.
+func (ap *AnalysisProcessor) log(ctx context.Context) *logging.Logger {
 	if ap.Logger != nil {
-		return ap.Logger
+		return ap.Logger.WithContext(ctx)
 	}
-	return logging.Default()
+	return logging.Default().WithContext(ctx)
 }
 
 func (ap *AnalysisProcessor) metricsCollector() analysis.AnalysisMetrics {
@@ -52,6 +269,133 @@ func (ap *AnalysisProcessor) metricsCollector() analysis.AnalysisMetrics {
 	return analysis.NullMetrics{}
 }
 
+func (ap *AnalysisProcessor) cacheStore() analysis.AnalysisCache {
+	if ap.Cache != nil {
+		return ap.Cache
+	}
+	return analysis.NullCache{}
+}
+
+// configHash identifies the processor settings that change analysis results,
+// so a report computed under other settings is never served from the cache.
+func (ap *AnalysisProcessor) configHash() string {
+	data, _ := json.Marshal(struct {
+		Config         string
+		Thresholds     *patterns.Thresholds
+		MaxCommits     int
+		MaxDiffBytes   int64
+		MergeStrategy  git.MergeStrategy
+		AuthorIdentity *git.IdentityRules
+		Filters        GitSourceFilters
+		Strategies     GitStrategyOptions
+		MinWordCount   int
+		Vocabulary     *webpatterns.Vocabulary
+		MinConfidence  float64
+		IncludePassed  bool
+	}{ap.ConfigFingerprint, ap.DetectorThresholds, ap.MaxCommits, ap.MaxDiffBytes, ap.MergeStrategy, ap.AuthorIdentity, ap.SourceFilters, ap.StrategyOptions, ap.MinWordCount, ap.Vocabulary, ap.MinReportConfidence, ap.IncludePassed})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// cachedReport returns the cached report under key unless job asks for a
+// fresh analysis.
+func (ap *AnalysisProcessor) cachedReport(job *WebhookJob, key string) (*analysis.AnalysisReport, bool) {
+	if job.SkipCache {
+		return nil, false
+	}
+	return ap.cacheStore().Get(key)
+}
+
+// repoCacheKey identifies a cloned repository analysis in the cache: the
+// normalized URL, branch and everything in the job that narrows the history
+// or the strategies run.
+func (ap *AnalysisProcessor) repoCacheKey(job *WebhookJob) string {
+	return analysis.ReportCacheKey("git", repoSourceKey(ap.normalizer(), job, true), job.Branch, ap.configHash())
+}
+
+// repoSourceKey identifies a repository analysis by its normalized URL and
+// the job options that change its report. withSince adds the SinceHash;
+// push jobs leave it out of their dedup key because a queued push clones the
+// branch head when it starts and so covers the pushes after it.
+func repoSourceKey(normalizer *web.URLNormalizer, job *WebhookJob, withSince bool) string {
+	id := webSourceKey(normalizer, job.RepoURL, job.DisabledStrategies)
+	if withSince && job.SinceHash != "" {
+		id += "#since=" + job.SinceHash
+	}
+	if job.MaxCommits > 0 {
+		id += "#max_commits=" + strconv.Itoa(job.MaxCommits)
+	}
+	if job.IncludePassed {
+		id += "#include_passed"
+	}
+	return confidenceKey(id, job.MinReportConfidence)
+}
+
+// repoDedupKey is the SourceKey of a queued repository job: repoSourceKey
+// plus the branch. Local checkouts are not deduplicated.
+func repoDedupKey(normalizer *web.URLNormalizer, job *WebhookJob, withSince bool) string {
+	if job.LocalPath != "" || job.RepoURL == "" {
+		return ""
+	}
+	return repoSourceKey(normalizer, job, withSince) + "@" + job.Branch
+}
+
+// reportConfidence returns the confidence floor for a job: the requested
+// one when set, else the server's.
+func (ap *AnalysisProcessor) reportConfidence(requested float64) float64 {
+	if requested > 0 {
+		return requested
+	}
+	return ap.MinReportConfidence
+}
+
+// confidenceKey extends a source key with a request's confidence floor, so
+// reports filtered differently are neither shared nor deduplicated.
+func confidenceKey(key string, minConfidence float64) string {
+	if minConfidence <= 0 {
+		return key
+	}
+	return key + "#min_confidence=" + strconv.FormatFloat(minConfidence, 'g', -1, 64)
+}
+
+// cacheableGitReport returns a copy of report that is cheap to keep cached:
+// commit pairs lose their diff content, which job results never read, and
+// the baseline-only pairs are dropped.
+func cacheableGitReport(report *analysis.AnalysisReport) *analysis.AnalysisReport {
+	cached := *report
+	cached.Metrics = make(map[string]interface{}, len(report.Metrics))
+	for key, value := range report.Metrics {
+		cached.Metrics[key] = value
+	}
+	delete(cached.Metrics, "baseline_pairs")
+	if pairs, ok := report.Metrics["commit_pairs"].([]*git.CommitPair); ok {
+		slim := make([]*git.CommitPair, len(pairs))
+		for i, pair := range pairs {
+			p := *pair
+			p.DiffContent = ""
+			slim[i] = &p
+		}
+		cached.Metrics["commit_pairs"] = slim
+	}
+	return &cached
+}
+
+// websiteSource returns the source for a website analysis, fetched with
+// FetcherOptions behind the SSRF guard.
+func (ap *AnalysisProcessor) websiteSource(url string) *sources.WebsiteSource {
+	source := sources.NewWebsiteSource(url)
+	source.FetcherOptions = append(source.FetcherOptions, ap.FetcherOptions...)
+	source.FetcherOptions = append(source.FetcherOptions, web.WithGuard(ap.guard()))
+	return source
+}
+
+func (ap *AnalysisProcessor) normalizer() *web.URLNormalizer {
+	if ap.URLNormalizer != nil {
+		return ap.URLNormalizer
+	}
+	return web.NewURLNormalizer(true)
+}
+
 func NewWebhookHandlers(secret string, queue *JobQueue, thresholds *patterns.Thresholds) *WebhookHandlers {
 	return &WebhookHandlers{
 		secret: secret,
@@ -59,20 +403,166 @@ func NewWebhookHandlers(secret string, queue *JobQueue, thresholds *patterns.Thr
 		processor: &AnalysisProcessor{
 			DetectorThresholds: thresholds,
 		},
-		cache:   analysis.NullCache{},
-		metrics: analysis.NullMetrics{},
-		plugins: analysis.NewPluginManager(),
+		cache:         analysis.NullCache{},
+		metrics:       analysis.NullMetrics{},
+		plugins:       analysis.NewPluginManager(),
+		urlNormalizer: web.NewURLNormalizer(true),
+		streams:       newStreamBuffers(DefaultStreamBufferSize, DefaultStreamResumeGrace),
+		log:           logging.Default(),
+	}
+}
+
+// WithLogger sets the logger of the handlers and of streamed analyses.
+func (wh *WebhookHandlers) WithLogger(l *logging.Logger) *WebhookHandlers {
+	if l != nil {
+		wh.log = l
+		wh.processor.Logger = l.With("component", "processor")
 	}
+	return wh
 }
 
 // WithCache sets the analysis cache implementation.
 func (wh *WebhookHandlers) WithCache(cache analysis.AnalysisCache) *WebhookHandlers {
 	if cache != nil {
 		wh.cache = cache
+		wh.processor.Cache = cache
 	}
 	return wh
 }
 
+// WithURLNormalizer sets how submitted URLs are canonicalized for cache and dedup keys.
+func (wh *WebhookHandlers) WithURLNormalizer(n *web.URLNormalizer) *WebhookHandlers {
+	if n != nil {
+		wh.urlNormalizer = n
+		wh.processor.URLNormalizer = n
+	}
+	return wh
+}
+
+// WithReadyMaxDepth sets the queue depth at which /health/ready reports the
+// server as unavailable. 0 or less means the queue's capacity.
+func (wh *WebhookHandlers) WithReadyMaxDepth(depth int) *WebhookHandlers {
+	wh.readyMaxDepth = depth
+	return wh
+}
+
+// WithCloneOptions sets how streaming analyses clone repositories.
+func (wh *WebhookHandlers) WithCloneOptions(opts CloneOptions) *WebhookHandlers {
+	wh.processor.Clone = opts
+	return wh
+}
+
+// WithCloneLimiter sets the clone slots streaming analyses wait for.
+func (wh *WebhookHandlers) WithCloneLimiter(l *CloneLimiter) *WebhookHandlers {
+	wh.processor.CloneLimiter = l
+	return wh
+}
+
+// WithRepoCache makes streaming analyses update cached clones in c instead
+// of cloning afresh.
+func (wh *WebhookHandlers) WithRepoCache(c *RepoCache) *WebhookHandlers {
+	wh.processor.RepoCache = c
+	return wh
+}
+
+// WithAllowPrivateHosts disables the internal-address check on URLs
+// submitted to the API.
+func (wh *WebhookHandlers) WithAllowPrivateHosts(allow bool) *WebhookHandlers {
+	wh.processor.AllowPrivateHosts = allow
+	return wh
+}
+
+// WithLocalPaths sets whether analysis requests may name a local checkout,
+// and the directory such checkouts must lie under.
+func (wh *WebhookHandlers) WithLocalPaths(allow bool, root string) *WebhookHandlers {
+	wh.processor.AllowLocalPaths = allow
+	wh.processor.LocalPathRoot = root
+	return wh
+}
+
+// WithHistoryLimits sets the commit and diff-size caps and the diff worker
+// count streamed repository analyses run with.
+func (wh *WebhookHandlers) WithHistoryLimits(maxCommits int, maxDiffBytes int64, diffWorkers int) *WebhookHandlers {
+	wh.processor.MaxCommits = maxCommits
+	wh.processor.MaxDiffBytes = maxDiffBytes
+	wh.processor.DiffWorkers = diffWorkers
+	return wh
+}
+
+// WithMergeStrategy sets how streamed repository analyses treat merge
+// commits.
+func (wh *WebhookHandlers) WithMergeStrategy(strategy git.MergeStrategy) *WebhookHandlers {
+	wh.processor.MergeStrategy = strategy
+	return wh
+}
+
+// WithAuthorIdentity sets how streamed repository analyses group author
+// emails into identities. Nil keeps git.DefaultIdentityRules.
+func (wh *WebhookHandlers) WithAuthorIdentity(rules *git.IdentityRules) *WebhookHandlers {
+	wh.processor.AuthorIdentity = rules
+	return wh
+}
+
+// WithSourceFilters sets the file and author filters of streamed
+// repository analyses.
+func (wh *WebhookHandlers) WithSourceFilters(f GitSourceFilters) *WebhookHandlers {
+	wh.processor.SourceFilters = f
+	return wh
+}
+
+// WithStrategyOptions sets the git strategy settings of streamed repository
+// analyses.
+func (wh *WebhookHandlers) WithStrategyOptions(opts GitStrategyOptions) *WebhookHandlers {
+	wh.processor.StrategyOptions = opts
+	return wh
+}
+
+// WithMinWordCount sets the fewest words a streamed website analysis needs.
+func (wh *WebhookHandlers) WithMinWordCount(n int) *WebhookHandlers {
+	wh.processor.MinWordCount = n
+	return wh
+}
+
+// WithVocabulary sets the phrase lists streamed website analyses use for
+// the web vocabulary strategies. Nil keeps the built-in lists.
+func (wh *WebhookHandlers) WithVocabulary(v *webpatterns.Vocabulary) *WebhookHandlers {
+	wh.processor.Vocabulary = v
+	return wh
+}
+
+// WithFetcherOptions sets the page fetcher options of streamed website
+// analyses.
+func (wh *WebhookHandlers) WithFetcherOptions(opts ...web.FetcherOption) *WebhookHandlers {
+	wh.processor.FetcherOptions = opts
+	return wh
+}
+
+// WithMinReportConfidence sets the confidence floor streamed analyses
+// report detections above when the request sets none.
+func (wh *WebhookHandlers) WithMinReportConfidence(c float64) *WebhookHandlers {
+	wh.processor.MinReportConfidence = c
+	return wh
+}
+
+// WithIncludePassed makes streamed repository analyses report passed
+// strategies even when the request does not ask for them.
+func (wh *WebhookHandlers) WithIncludePassed(include bool) *WebhookHandlers {
+	wh.processor.IncludePassed = include
+	return wh
+}
+
+// WithAnalyzer sets the AI analyzer reported by GET /version.
+func (wh *WebhookHandlers) WithAnalyzer(analyzer ai.Analyzer) *WebhookHandlers {
+	wh.processor.Analyzer = analyzer
+	return wh
+}
+
+// WithClassification sets the thresholds streamed results are labelled with.
+func (wh *WebhookHandlers) WithClassification(ct ClassificationThresholds) *WebhookHandlers {
+	wh.processor.Classification = ct
+	return wh
+}
+
 // WithMetrics sets the analysis metrics collector.
 func (wh *WebhookHandlers) WithMetrics(metrics analysis.AnalysisMetrics) *WebhookHandlers {
 	if metrics != nil {
@@ -90,8 +580,45 @@ func (wh *WebhookHandlers) WithPlugins(plugins *analysis.PluginManager) *Webhook
 	return wh
 }
 
+// WithEventRecording enables or disables persisting SSE events for streaming jobs.
+// Recorded events are served by GET /jobs/:id/events.
+func (wh *WebhookHandlers) WithEventRecording(enabled bool) *WebhookHandlers {
+	wh.recordEvents = enabled
+	return wh
+}
+
+// WithCompression enables or disables gzip/deflate compression of responses
+// for clients that send Accept-Encoding. It must be set before RegisterRoutes;
+// the SSE streaming endpoints are never compressed.
+func (wh *WebhookHandlers) WithCompression(enabled bool) *WebhookHandlers {
+	wh.compress = enabled
+	return wh
+}
+
+// WithStreamResumeGrace sets how long a finished stream's events stay
+// available to clients reconnecting with Last-Event-ID (0 =
+// DefaultStreamResumeGrace).
+func (wh *WebhookHandlers) WithStreamResumeGrace(grace time.Duration) *WebhookHandlers {
+	wh.streams = newStreamBuffers(DefaultStreamBufferSize, grace)
+	return wh
+}
+
+// WithRateLimiter throttles the public analysis and streaming endpoints per
+// client IP. A nil limiter disables throttling.
+func (wh *WebhookHandlers) WithRateLimiter(l *RateLimiter) *WebhookHandlers {
+	wh.limiter = l
+	return wh
+}
+
+// WithBranchFilter limits the push webhooks to the branches f allows;
+// pushes to other branches are acknowledged without queuing a job.
+func (wh *WebhookHandlers) WithBranchFilter(f BranchFilter) *WebhookHandlers {
+	wh.branches = f
+	return wh
+}
+
 func (ap *AnalysisProcessor) Process(ctx context.Context, job *WebhookJob) error {
-	ap.log().LogPhase(job.ID, "starting analysis", "event_type", job.EventType)
+	ap.log(ctx).LogPhase(job.ID, "starting analysis", "event_type", job.EventType)
 
 	job.Progress = "initializing"
 
@@ -102,53 +629,107 @@ func (ap *AnalysisProcessor) Process(ctx context.Context, job *WebhookJob) error
 		AnalyzedAt: time.Now(),
 	}
 
-	if job.EventType == "api_analysis_repo" {
+	if job.EventType == "api_analysis_repo" || job.EventType == "github_push" || job.EventType == "gitlab_push" {
 		return ap.processGitAnalysis(ctx, job)
 	} else if job.EventType == "api_analysis_website" {
 		return ap.processWebAnalysis(ctx, job)
 	}
 
-	ap.log().LogPhase(job.ID, "analysis complete")
+	ap.log(ctx).LogPhase(job.ID, "analysis complete")
 	job.Progress = "completed"
 	return nil
 }
 
 func (ap *AnalysisProcessor) processGitAnalysis(ctx context.Context, job *WebhookJob) error {
-	job.Progress = "cloning"
-	ap.log().LogPhase(job.ID, "cloning repository", "repo_url", job.RepoURL)
+	repoPath := job.LocalPath
+	// Only cloned repositories are cached: a local checkout can change in
+	// place and costs no clone to re-analyze.
+	cacheKey := ""
+
+	if repoPath != "" {
+		// A user-supplied checkout is analyzed in place and must never be removed.
+		if _, err := ap.localRepoPath(repoPath); err != nil {
+			ap.log(ctx).LogPhaseError(job.ID, "local path rejected", err, "local_path", repoPath)
+			job.Progress = "analysis-failed"
+			return err
+		}
+		ap.log(ctx).LogPhase(job.ID, "using local repository", "local_path", repoPath)
+	} else {
+		job.Progress = "cloning"
+		ap.log(ctx).LogPhase(job.ID, "cloning repository", "repo_url", job.RepoURL)
 
-	tmpDir := filepath.Join(os.TempDir(), fmt.Sprintf("cadence-analysis-%s", job.ID))
-	defer os.RemoveAll(tmpDir)
+		if err := ap.guard().CheckRepositoryURL(ctx, job.RepoURL); err != nil {
+			ap.log(ctx).LogPhaseError(job.ID, "repository URL rejected", err, "repo_url", job.RepoURL)
+			job.Progress = "clone-failed"
+			return err
+		}
+
+		cacheKey = ap.repoCacheKey(job)
+		if report, cached := ap.cachedReport(job, cacheKey); cached {
+			ap.metricsCollector().RecordCacheHit("git")
+			ap.log(ctx).LogPhase(job.ID, "using cached repository report", "repo_url", job.RepoURL, "branch", job.Branch)
+			job.Progress = "processing-results"
+			ap.populateGitJobResult(ctx, job, report)
+			applyCachedTriage(job, report)
+			job.Progress = "completed"
+			return nil
+		}
+		ap.metricsCollector().RecordCacheMiss("git")
 
-	if err := cloneRepo(job.RepoURL, tmpDir); err != nil {
-		ap.log().LogPhaseError(job.ID, "clone failed", err, "repo_url", job.RepoURL)
-		ap.metricsCollector().RecordError("git", "clone")
-		job.Progress = "clone-failed"
-		return fmt.Errorf("failed to clone repository: %w", err)
+		if ap.CloneLimiter.saturated() {
+			job.Progress = "waiting-for-clone-slot"
+			ap.log(ctx).LogPhaseDebug(job.ID, "waiting for a clone slot", "in_use", ap.CloneLimiter.InUse())
+		}
+		dir, release, err := ap.checkoutRepo(ctx, job.RepoURL, job.Branch, fmt.Sprintf("cadence-analysis-%s", job.ID))
+		if err != nil {
+			ap.log(ctx).LogPhaseError(job.ID, "clone failed", err, "repo_url", job.RepoURL)
+			ap.metricsCollector().RecordError("git", "clone")
+			job.Progress = "clone-failed"
+			return fmt.Errorf("failed to clone repository: %w", err)
+		}
+		defer release()
+
+		ap.log(ctx).LogPhaseDebug(job.ID, "clone completed, running analysis")
+		repoPath = dir
 	}
 
-	ap.log().LogPhase(job.ID, "clone completed, running analysis")
 	job.Progress = "analyzing"
 
-	source := sources.NewGitRepositorySource(tmpDir, job.Branch)
-	det := detectors.NewGitDetector(ap.DetectorThresholds)
-	runner := analysis.NewDefaultDetectionRunner()
-
-	report, err := runner.Run(ctx, source, det)
+	source := ap.newGitSource(repoPath, job.Branch, job.SinceHash, job.MaxCommits)
+	if job.SinceHash != "" {
+		ap.log(ctx).LogPhaseDebug(job.ID, "incremental analysis", "since", job.SinceHash)
+	}
+	det := ap.newGitDetector(ap.DetectorThresholds, job.DisabledStrategies)
+	det.MinReportConfidence = ap.reportConfidence(job.MinReportConfidence)
+	det.IncludePassed = ap.IncludePassed || job.IncludePassed
+	det.Suppressions = ap.suppressions(ctx, job.ID)
+	det.Metrics = ap.metricsCollector()
+
+	report, timedOut, err := ap.runAnalysis(ctx, source, det)
+	if timedOut {
+		return ap.analysisTimedOut(ctx, job, "git", err)
+	}
 	if err != nil {
-		ap.log().LogPhaseError(job.ID, "analysis failed", err)
+		ap.log(ctx).LogPhaseError(job.ID, "analysis failed", err)
 		ap.metricsCollector().RecordError("git", "analysis")
 		job.Progress = "analysis-failed"
 		return fmt.Errorf("analysis failed: %w", err)
 	}
 
 	job.Progress = "processing-results"
-	ap.populateGitJobResult(job, report)
+	ap.populateGitJobResult(ctx, job, report)
+	ap.triageSuspicions(ctx, job, report)
+
+	if cacheKey != "" {
+		cached := cacheableGitReport(report)
+		cacheTriage(cached, job)
+		ap.cacheStore().Set(cacheKey, cached, 0)
+	}
 
 	ap.metricsCollector().RecordAnalysis("git", report.Duration)
 	ap.metricsCollector().RecordDetections("git", report.TotalDetections, report.DetectionCount)
 
-	ap.log().LogPhase(job.ID, "repository analysis complete",
+	ap.log(ctx).LogPhase(job.ID, "repository analysis complete",
 		"total_commits", job.Result.TotalCommits,
 		"suspicious_commits", job.Result.SuspiciousCommits,
 	)
@@ -157,29 +738,49 @@ func (ap *AnalysisProcessor) processGitAnalysis(ctx context.Context, job *Webhoo
 }
 
 func (ap *AnalysisProcessor) processWebAnalysis(ctx context.Context, job *WebhookJob) error {
-	ap.log().LogPhase(job.ID, "starting website analysis", "url", job.RepoURL)
+	ap.log(ctx).LogPhase(job.ID, "starting website analysis", "url", job.RepoURL)
 	job.Progress = "fetching-content"
 
-	source := sources.NewWebsiteSource(job.RepoURL)
-	det := detectors.NewWebDetector()
-	runner := analysis.NewDefaultDetectionRunner()
-
-	report, err := runner.Run(ctx, source, det)
-	if err != nil {
-		ap.log().LogPhaseError(job.ID, "website analysis failed", err, "url", job.RepoURL)
-		ap.metricsCollector().RecordError("web", "analysis")
-		job.Progress = "analysis-failed"
-		return fmt.Errorf("analysis failed: %w", err)
+	sourceKey := confidenceKey(webSourceKey(ap.normalizer(), job.RepoURL, job.DisabledStrategies), job.MinReportConfidence)
+	cacheKey := analysis.ReportCacheKey("web", sourceKey, "", ap.configHash())
+	report, cached := ap.cachedReport(job, cacheKey)
+	if cached {
+		ap.metricsCollector().RecordCacheHit("web")
+		ap.log(ctx).LogPhase(job.ID, "using cached website report", "url", job.RepoURL)
+	} else {
+		ap.metricsCollector().RecordCacheMiss("web")
+
+		source := ap.websiteSource(job.RepoURL)
+		det := detectors.NewWebDetector()
+		det.MinWordCount = ap.MinWordCount
+		det.Vocabulary = ap.Vocabulary
+		det.DisabledStrategies = job.DisabledStrategies
+		det.MinReportConfidence = ap.reportConfidence(job.MinReportConfidence)
+		det.Suppressions = ap.suppressions(ctx, job.ID)
+		det.SourceKey = ap.normalizer().Key(job.RepoURL)
+		det.Metrics = ap.metricsCollector()
+
+		var timedOut bool
+		var err error
+		report, timedOut, err = ap.runAnalysis(ctx, source, det)
+		if timedOut {
+			return ap.analysisTimedOut(ctx, job, "web", err)
+		}
+		if err != nil {
+			ap.log(ctx).LogPhaseError(job.ID, "website analysis failed", err, "url", job.RepoURL)
+			ap.metricsCollector().RecordError("web", "analysis")
+			job.Progress = "analysis-failed"
+			return fmt.Errorf("analysis failed: %w", err)
+		}
+		ap.cacheStore().Set(cacheKey, report, 0) // the cache's default TTL
 	}
 
 	job.Progress = "processing-results"
 
-	if analysisErr, ok := report.Metrics["analysis_error"].(string); ok {
-		ap.log().LogPhase(job.ID, "content analysis note", "note", analysisErr)
-		job.Result.ConfidenceScore = 0
-		job.Result.SuspicionRate = 0
-		job.Result.Assessment = "Content too short for reliable analysis"
-		job.Result.PatternCount = 0
+	if insufficient := report.InsufficientContent(); insufficient != nil {
+		ap.log(ctx).LogPhase(job.ID, "insufficient content", "words", insufficient.WordCount, "min_words", insufficient.MinWordCount)
+		job.Result.Status = StatusInsufficientContent
+		job.Result.Assessment = ap.Classification.Labels.Label(report.Assessment)
 	} else {
 		ap.populateWebJobResult(job, report)
 	}
@@ -200,7 +801,7 @@ func (ap *AnalysisProcessor) processWebAnalysis(ctx context.Context, job *Webhoo
 	ap.metricsCollector().RecordAnalysis("web", report.Duration)
 	ap.metricsCollector().RecordDetections("web", report.TotalDetections, report.DetectionCount)
 
-	ap.log().LogPhase(job.ID, "website analysis complete",
+	ap.log(ctx).LogPhase(job.ID, "website analysis complete",
 		"pattern_count", job.Result.PatternCount,
 		"confidence_score", job.Result.ConfidenceScore,
 	)
@@ -208,46 +809,60 @@ func (ap *AnalysisProcessor) processWebAnalysis(ctx context.Context, job *Webhoo
 	return nil
 }
 
-func (ap *AnalysisProcessor) populateGitJobResult(job *WebhookJob, report *analysis.AnalysisReport) {
+func (ap *AnalysisProcessor) populateGitJobResult(ctx context.Context, job *WebhookJob, report *analysis.AnalysisReport) {
+	report = ap.ExamplePolicy.Apply(report)
 	populateTimingAndMetrics(job.Result, report)
 
 	if commitCount, ok := report.Metrics["commit_count"].(int); ok {
 		job.Result.TotalCommits = commitCount
 	}
 
-	ap.log().LogPhase(job.ID, "populating git results", "total_commits", job.Result.TotalCommits)
+	ap.log(ctx).LogPhaseDebug(job.ID, "populating git results", "total_commits", job.Result.TotalCommits)
 
 	for _, d := range report.Detections {
-		if d.Category == "git-analysis" && d.Detected {
-			hash := ""
-			var reasons []string
-			if len(d.Examples) > 0 {
-				hash = d.Examples[0]
-				if len(d.Examples) > 1 {
-					reasons = d.Examples[1:]
-				}
+		if !d.Detected {
+			job.Result.PassedStrategies = append(job.Result.PassedStrategies, passedStrategy(d))
+			continue
+		}
+		hash := ""
+		var reasons []string
+		if len(d.Examples) > 0 {
+			hash = d.Examples[0]
+			if len(d.Examples) > 1 {
+				reasons = d.Examples[1:]
 			}
+		}
 
-			suspicion := Suspicion{
-				CommitHash: hash,
-				Message:    d.Description,
-				Severity:   d.Severity,
-				Reasons:    reasons,
-				Score:      d.Score * 100,
-			}
-			job.Result.Suspicions = append(job.Result.Suspicions, suspicion)
+		suspicion := Suspicion{
+			CommitHash: hash,
+			Message:    d.Description,
+			Severity:   d.Severity,
+			Reasons:    reasons,
+			Strategies: d.Strategies,
+			Score:      d.Score * 100,
+
+			LowConfidence: d.LowConfidence,
+			Caveat:        d.Caveat,
 		}
+		job.Result.Suspicions = append(job.Result.Suspicions, suspicion)
 	}
 
 	job.Result.SuspiciousCommits = len(job.Result.Suspicions)
-	ap.log().LogPhase(job.ID, "suspicious commits found", "count", job.Result.SuspiciousCommits)
+	ap.log(ctx).LogPhaseDebug(job.ID, "suspicious commits found", "count", job.Result.SuspiciousCommits)
 
 	if pairs, ok := report.Metrics["commit_pairs"].([]*git.CommitPair); ok {
 		calculateMetrics(job.Result, pairs)
 	}
 }
 
+// passedStrategy describes a git strategy's Detected=false record, which
+// the detector adds when asked to include passed strategies.
+func passedStrategy(d analysis.Detection) PassedStrategy {
+	return PassedStrategy{Name: d.Strategy, Category: d.Category, Description: d.Description}
+}
+
 func (ap *AnalysisProcessor) populateWebJobResult(job *WebhookJob, report *analysis.AnalysisReport) {
+	report = ap.ExamplePolicy.Apply(report)
 	populateTimingAndMetrics(job.Result, report)
 
 	job.Result.URL = report.SourceID
@@ -291,13 +906,7 @@ func (ap *AnalysisProcessor) populateWebJobResult(job *WebhookJob, report *analy
 	job.Result.OverallSuspicion = float64(confidenceScore)
 	job.Result.QualityScore = 1.0 - suspicionRate
 
-	if suspicionRate >= 0.7 {
-		job.Result.Assessment = "Likely AI-Generated"
-	} else if suspicionRate >= 0.4 {
-		job.Result.Assessment = "Suspicious Activity"
-	} else {
-		job.Result.Assessment = "Likely Human-Written"
-	}
+	job.Result.Assessment = ap.Classification.Assess(suspicionRate)
 }
 
 // populateTimingAndMetrics copies timing and cross-source metrics from the analysis report into the job result.
@@ -313,9 +922,7 @@ func populateTimingAndMetrics(result *JobResult, report *analysis.AnalysisReport
 	result.StrategiesHit = report.SourceMetrics.StrategiesHit
 	result.AverageScore = report.SourceMetrics.AverageScore
 	result.CoverageRate = report.SourceMetrics.CoverageRate
-	if report.SourceMetrics.UniqueAuthors > 0 {
-		result.UniqueAuthors = report.SourceMetrics.UniqueAuthors
-	}
+	result.UniqueAuthors = report.SourceMetrics.UniqueAuthors
 }
 
 // calculateMetrics computes repository metrics from commit pairs
@@ -345,21 +952,18 @@ func calculateMetrics(result *JobResult, commitPairs []*git.CommitPair) {
 		result.TimeSpan = fmt.Sprintf("%d weeks", weeks)
 	}
 
-	// Calculate total additions/deletions and unique authors
+	// Calculate total additions/deletions. Unique authors come from the
+	// report, which counts identities rather than raw names.
 	totalAdditions := int64(0)
 	totalDeletions := int64(0)
-	authors := make(map[string]bool)
 
 	for _, pair := range commitPairs {
 		if pair.Stats != nil {
 			totalAdditions += pair.Stats.Additions
 			totalDeletions += pair.Stats.Deletions
 		}
-		authors[pair.Current.Author] = true
 	}
 
-	result.UniqueAuthors = len(authors)
-
 	// Calculate velocity (additions per minute)
 	if timeSpan.Minutes() > 0 {
 		velocity := float64(totalAdditions) / timeSpan.Minutes()
@@ -381,38 +985,101 @@ func calculateMetrics(result *JobResult, commitPairs []*git.CommitPair) {
 	}
 }
 
-// cloneRepo is a helper function to clone a git repository
-func cloneRepo(url, dest string) error {
-	// Create a context with 2-minute timeout for clone operations
-	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
-	defer cancel()
+// errLocalPathDenied reports a local_path the server does not allow.
+var errLocalPathDenied = errors.New("local_path not allowed")
+
+// localRepoPath checks that the server accepts local checkouts and that path
+// is a git repository under LocalPathRoot, returning its absolute form.
+func (ap *AnalysisProcessor) localRepoPath(path string) (string, error) {
+	if !ap.AllowLocalPaths || ap.LocalPathRoot == "" {
+		return "", fmt.Errorf("%w: local paths are disabled on this server", errLocalPathDenied)
+	}
+	abs, err := validateLocalRepoPath(path)
+	if err != nil {
+		return "", err
+	}
+	root, err := filepath.Abs(ap.LocalPathRoot)
+	if err != nil {
+		return "", fmt.Errorf("%w: invalid local path root: %v", errLocalPathDenied, err)
+	}
+	// Compare resolved paths so symlinks cannot point outside the root.
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	target, err := filepath.EvalSymlinks(abs)
+	if err != nil {
+		return "", fmt.Errorf("invalid local_path %q: %w", path, err)
+	}
+	if rel, err := filepath.Rel(root, target); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: %q is outside the local path root", errLocalPathDenied, path)
+	}
+	return abs, nil
+}
 
-	// Use go-git to clone
-	_, err := gogit.PlainCloneContext(ctx, dest, false, &gogit.CloneOptions{
-		URL:      url,
-		Progress: nil,
+// localPathError answers a rejected local_path: 403 when the server does not
+// allow it, 400 when it is not a usable repository.
+func localPathError(c *fiber.Ctx, err error) error {
+	status := http.StatusBadRequest
+	if errors.Is(err, errLocalPathDenied) {
+		status = http.StatusForbidden
+	}
+	return c.Status(status).JSON(fiber.Map{
+		"error": err.Error(),
 	})
-	return err
+}
+
+// validateLocalRepoPath checks that path is an existing directory containing a
+// .git entry and returns its absolute form.
+func validateLocalRepoPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid local_path %q: %w", path, err)
+	}
+
+	info, err := os.Stat(abs)
+	if err != nil {
+		return "", fmt.Errorf("local_path %q does not exist", path)
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("local_path %q is not a directory", path)
+	}
+
+	// .git is a directory for normal checkouts and a file for worktrees and submodules.
+	if _, err := os.Stat(filepath.Join(abs, ".git")); err != nil {
+		return "", fmt.Errorf("local_path %q is not a git repository (no .git found)", path)
+	}
+
+	return abs, nil
 }
 
 func (wh *WebhookHandlers) RegisterRoutes(app *fiber.App) {
+	if wh.compress {
+		app.Use(compress.New(compress.Config{Next: isStreamRequest}))
+	}
+	app.Use(wh.traceRequest)
+
 	// Webhook endpoints
 	app.Post("/webhooks/github", wh.HandleGithubWebhook)
 	app.Post("/webhooks/gitlab", wh.HandleGitlabWebhook)
 
-	// Public API endpoints for playground analysis
-	app.Post("/api/analyze/repository", wh.AnalyzeRepository)
-	app.Post("/api/analyze/website", wh.AnalyzeWebsite)
+	// Public API endpoints for playground analysis, rate limited per client IP
+	app.Post("/api/analyze/repository", wh.throttle, wh.AnalyzeRepository)
+	app.Post("/api/analyze/website", wh.throttle, wh.AnalyzeWebsite)
 
 	// SSE streaming endpoints
-	app.Post("/api/stream/repository", wh.StreamAnalyzeRepository)
-	app.Post("/api/stream/website", wh.StreamAnalyzeWebsite)
+	app.Post("/api/stream/repository", wh.throttle, wh.StreamAnalyzeRepository)
+	app.Post("/api/stream/website", wh.throttle, wh.StreamAnalyzeWebsite)
 
 	// Job status endpoints
 	app.Get("/jobs/:id", wh.GetJobStatus)
+	app.Get("/jobs/:id/events", wh.GetJobEvents)
+	app.Post("/jobs/:id/rerun", wh.throttle, wh.RerunJob)
 	app.Get("/jobs", wh.ListJobs)
 	app.Get("/api/results/:id", wh.GetJobResult)
 
+	// Detection feedback
+	app.Post("/api/feedback", wh.throttle, wh.requireToken, wh.SubmitFeedback)
+
 	// Observability endpoints
 	app.Get("/metrics", wh.MetricsEndpoint)
 	app.Get("/api/metrics", wh.MetricsJSON)
@@ -422,7 +1089,19 @@ func (wh *WebhookHandlers) RegisterRoutes(app *fiber.App) {
 	// Plugin endpoints
 	app.Get("/api/plugins", wh.ListPlugins)
 
+	// Strategy metadata
+	app.Get("/api/strategies", wh.ListStrategies)
+
+	app.Get("/version", wh.VersionInfo)
 	app.Get("/health", wh.HealthCheck)
+	app.Get("/health/ready", wh.ReadinessCheck)
+}
+
+// isStreamRequest reports whether c is for an SSE streaming endpoint, whose
+// events must reach the client as they are written rather than be buffered
+// for compression.
+func isStreamRequest(c *fiber.Ctx) bool {
+	return strings.HasPrefix(c.Path(), "/api/stream/")
 }
 
 func (wh *WebhookHandlers) HandleGithubWebhook(c *fiber.Ctx) error {
@@ -447,20 +1126,65 @@ func (wh *WebhookHandlers) HandleGithubWebhook(c *fiber.Ctx) error {
 		})
 	}
 
-	// Extract branch from ref (e.g., "refs/heads/main" -> "main")
-	branch := strings.TrimPrefix(payload.Ref, "refs/heads/")
-
-	job := &WebhookJob{
+	return wh.enqueuePush(c, pushEvent{
 		EventType: "github_push",
+		Ref:       payload.Ref,
+		Before:    payload.Before,
 		RepoURL:   payload.Repository.URL,
 		RepoName:  payload.Repository.Name,
-		Branch:    branch,
 		Author:    payload.Pusher.Name,
-		Commits:   make([]WebhookCommit, 0),
+		Commits:   payload.Commits,
+	})
+}
+
+// pushEvent is the part of a verified GitHub or GitLab push that queues an
+// analysis.
+type pushEvent struct {
+	EventType string
+	Ref       string
+	Before    string
+	RepoURL   string
+	RepoName  string
+	Author    string
+	Commits   []PushCommit
+}
+
+// enqueuePush queues the analysis of a push after checking its callback,
+// branch and repository host, and answers 202 with the job ID.
+func (wh *WebhookHandlers) enqueuePush(c *fiber.Ctx, push pushEvent) error {
+	// Push webhooks cannot carry extra body fields, so a callback is
+	// configured on the webhook URL itself (?callback_url=...).
+	callbackURL := c.Query("callback_url")
+	if err := wh.validateCallback(c, callbackURL); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Extract branch from ref (e.g., "refs/heads/main" -> "main")
+	branch := strings.TrimPrefix(push.Ref, "refs/heads/")
+	if !wh.branches.Allows(branch) {
+		return branchIgnored(c, branch)
+	}
+	if !wh.gitHosts.Allows(push.RepoURL) {
+		return gitHostDenied(c, push.RepoURL)
+	}
+
+	job := &WebhookJob{
+		ID:          requestTraceID(c),
+		EventType:   push.EventType,
+		RepoURL:     push.RepoURL,
+		RepoName:    push.RepoName,
+		Branch:      branch,
+		SinceHash:   pushSinceHash(push.Before),
+		Author:      push.Author,
+		CallbackURL: callbackURL,
+		Commits:     make([]WebhookCommit, 0, len(push.Commits)),
 	}
+	job.SourceKey = repoDedupKey(wh.urlNormalizer, job, false)
 
-	for i := range payload.Commits {
-		commit := &payload.Commits[i]
+	for i := range push.Commits {
+		commit := &push.Commits[i]
 		timestamp, _ := time.Parse(time.RFC3339, commit.Timestamp)
 		job.Commits = append(job.Commits, WebhookCommit{
 			Hash:      commit.ID,
@@ -475,34 +1199,50 @@ func (wh *WebhookHandlers) HandleGithubWebhook(c *fiber.Ctx) error {
 	}
 
 	if err := wh.queue.Enqueue(job); err != nil {
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(enqueueStatus(err)).JSON(fiber.Map{
 			"error": err.Error(),
 		})
 	}
 
 	return c.Status(http.StatusAccepted).JSON(fiber.Map{
 		"job_id": job.ID,
-		"status": StatusPending,
+		"status": job.Status,
 	})
 }
 
+// pushSinceHash returns the incremental anchor for a push. GitHub and GitLab
+// send an all-zero "before" SHA when a branch is created, which means there is no
+// previous head and the whole branch should be analyzed.
+func pushSinceHash(before string) string {
+	if strings.Trim(before, "0") == "" {
+		return ""
+	}
+	return before
+}
+
 func (wh *WebhookHandlers) HandleGitlabWebhook(c *fiber.Ctx) error {
 	token := c.Get("X-Gitlab-Token")
-	if token == "" || token != wh.secret {
+	if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(wh.secret)) != 1 {
 		return c.Status(http.StatusUnauthorized).JSON(fiber.Map{
 			"error": "invalid or missing token",
 		})
 	}
 
-	var payload map[string]interface{}
-	if err := c.BodyParser(&payload); err != nil {
+	var payload GitlabPushPayload
+	if err := json.Unmarshal(c.Body(), &payload); err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"error": "invalid payload",
 		})
 	}
 
-	return c.Status(http.StatusAccepted).JSON(fiber.Map{
-		"status": StatusPending,
+	return wh.enqueuePush(c, pushEvent{
+		EventType: "gitlab_push",
+		Ref:       payload.Ref,
+		Before:    payload.Before,
+		RepoURL:   payload.Project.GitHTTPURL,
+		RepoName:  payload.Project.Name,
+		Author:    payload.UserName,
+		Commits:   payload.Commits,
 	})
 }
 
@@ -524,6 +1264,35 @@ func (wh *WebhookHandlers) GetJobStatus(c *fiber.Ctx) error {
 		"timestamp": job.Timestamp,
 		"error":     job.Error,
 		"result":    job.Result,
+		"rerun_of":  job.RerunOf,
+	})
+}
+
+// GetJobEvents handles GET /jobs/:id/events and returns the recorded SSE event log
+// for a streaming job. The optional ?after=<id> query returns only later events.
+func (wh *WebhookHandlers) GetJobEvents(c *fiber.Ctx) error {
+	jobID := c.Params("id")
+
+	job, err := wh.queue.GetJob(jobID)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"error": "job not found",
+		})
+	}
+
+	if job.Events == nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"error": "no event log recorded for job",
+		})
+	}
+
+	events := job.Events.After(c.QueryInt("after", 0))
+
+	return c.JSON(fiber.Map{
+		"job_id":   job.ID,
+		"complete": job.Events.Done(),
+		"total":    len(events),
+		"events":   events,
 	})
 }
 
@@ -549,6 +1318,15 @@ func (wh *WebhookHandlers) ListJobs(c *fiber.Ctx) error {
 	})
 }
 
+// enqueueStatus is the response status for a failed Enqueue: 503 while the
+// queue shuts down, so clients retry against another instance.
+func enqueueStatus(err error) int {
+	if errors.Is(err, ErrQueueClosed) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
 func (wh *WebhookHandlers) HealthCheck(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(fiber.Map{
 		"status": "ok",
@@ -556,6 +1334,60 @@ func (wh *WebhookHandlers) HealthCheck(c *fiber.Ctx) error {
 	})
 }
 
+// versionResponse is the body of GET /version.
+type versionResponse struct {
+	version.Info
+	// AIProviders are the AI providers compiled into the binary.
+	AIProviders []string `json:"ai_providers"`
+	// AIValidation reports whether an AI analyzer is configured to validate
+	// detections, and AIProvider names its provider.
+	AIValidation bool   `json:"ai_validation"`
+	AIProvider   string `json:"ai_provider,omitempty"`
+}
+
+// VersionInfo returns the build and AI configuration of the running server
+// at GET /version, to tell apart the deployments behind a load balancer.
+func (wh *WebhookHandlers) VersionInfo(c *fiber.Ctx) error {
+	resp := versionResponse{Info: version.Get(), AIProviders: ai.RegisteredProviders()}
+	if analyzer := wh.processor.Analyzer; analyzer != nil && analyzer.IsConfigured() {
+		resp.AIValidation = true
+		resp.AIProvider = analyzer.ProviderName()
+	}
+	return c.JSON(resp)
+}
+
+// ReadinessCheck reports whether the server should receive new work: the
+// queue is accepting jobs and fewer than the configured number are waiting.
+// It answers 503 otherwise, so load balancers and Kubernetes readiness probes
+// stop routing to an overloaded instance while /health keeps it alive.
+func (wh *WebhookHandlers) ReadinessCheck(c *fiber.Ctx) error {
+	stats := wh.queue.Stats()
+	maxDepth := wh.readyMaxDepth
+	if maxDepth <= 0 {
+		maxDepth = stats.Capacity
+	}
+
+	status, code := "ready", http.StatusOK
+	reason := ""
+	switch {
+	case !stats.Accepting:
+		status, code, reason = "unavailable", http.StatusServiceUnavailable, "job queue is not accepting jobs"
+	case stats.Depth >= maxDepth:
+		status, code, reason = "unavailable", http.StatusServiceUnavailable, "job queue is saturated"
+	}
+
+	body := fiber.Map{
+		"status":    status,
+		"queue":     stats,
+		"max_depth": maxDepth,
+		"time":      time.Now(),
+	}
+	if reason != "" {
+		body["reason"] = reason
+	}
+	return c.Status(code).JSON(body)
+}
+
 func (wh *WebhookHandlers) verifySignature(body []byte, signature string) error {
 	parts := strings.Split(signature, "=")
 	if len(parts) != 2 {
@@ -593,15 +1425,89 @@ func NewDefaultProcessor() JobProcessor {
 type AnalyzeRepositoryRequest struct {
 	RepositoryURL string `json:"repository_url"`
 	Branch        string `json:"branch,omitempty"`
+	// LocalPath analyzes a checkout already on the server's filesystem instead
+	// of cloning RepositoryURL. It takes precedence when both are set.
+	LocalPath string `json:"local_path,omitempty"`
+	// Since analyzes only commits newer than this hash; older history is
+	// used as baseline context only.
+	Since string `json:"since,omitempty"`
+	// DisabledStrategies skips git strategies for this request only. Names
+	// must match GET /api/strategies?source_type=git.
+	DisabledStrategies []string `json:"disabled_strategies,omitempty"`
+	// CallbackURL receives the job result as a signed POST once the job
+	// completes or fails.
+	CallbackURL string `json:"callback_url,omitempty"`
+	// MaxCommits analyzes at most this many of the newest commits. It can
+	// only lower the server's limit.
+	MaxCommits int `json:"max_commits,omitempty"`
+	// MinReportConfidence (0-1) leaves detections from less confident
+	// strategies out of the result, in place of the server's
+	// analysis.min_report_confidence. They still count toward the score.
+	MinReportConfidence float64 `json:"min_report_confidence,omitempty"`
+	// IncludePassed lists the strategies that ran and fired on no commit in
+	// passed_strategies, as analysis.include_passed does for every request.
+	IncludePassed bool `json:"include_passed,omitempty"`
+	// AISummary asks the stream endpoint to follow the result with an AI
+	// summary of the report, streamed as ai_token events.
+	AISummary bool `json:"ai_summary,omitempty"`
 }
 
 type AnalyzeWebsiteRequest struct {
 	URL string `json:"url"`
+	// DisabledStrategies skips web strategies for this request only. Names
+	// must match GET /api/strategies?source_type=web.
+	DisabledStrategies []string `json:"disabled_strategies,omitempty"`
+	// MinReportConfidence (0-1) leaves detections from less confident
+	// strategies out of the result, in place of the server's
+	// analysis.min_report_confidence. They still count toward the score.
+	MinReportConfidence float64 `json:"min_report_confidence,omitempty"`
+	// CallbackURL receives the job result as a signed POST once the job
+	// completes or fails.
+	CallbackURL string `json:"callback_url,omitempty"`
+	// AISummary asks the stream endpoint to follow the result with an AI
+	// summary of the report, streamed as ai_token events.
+	AISummary bool `json:"ai_summary,omitempty"`
+}
+
+// disabledStrategySet checks per-request strategy names against registry and
+// returns them as a set, or nil when none were given.
+func disabledStrategySet(registry *analysis.StrategyRegistry, names []string) (map[string]bool, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	if unknown := registry.Unknown(names); len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown strategies in disabled_strategies: %s (see GET /api/strategies)", strings.Join(unknown, ", "))
+	}
+
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set, nil
+}
+
+// webSourceKey identifies a website analysis for dedup and caching. Requests
+// that disable strategies get their own key so they never share a report
+// with a full analysis.
+func webSourceKey(normalizer *web.URLNormalizer, url string, disabled map[string]bool) string {
+	key := normalizer.Key(url)
+	if len(disabled) == 0 {
+		return key
+	}
+
+	names := make([]string, 0, len(disabled))
+	for name := range disabled {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return key + "#disabled=" + strings.Join(names, ",")
 }
 
 type AnalysisResponse struct {
 	JobID  string `json:"job_id"`
 	Status string `json:"status"`
+	// RerunOf is the job a rerun was created from.
+	RerunOf string `json:"rerun_of,omitempty"`
 }
 
 type JobResultResponse struct {
@@ -610,15 +1516,16 @@ type JobResultResponse struct {
 	Error    string `json:"error,omitempty"`
 	Progress string `json:"progress,omitempty"`
 	// Repository fields
-	RepoName          string      `json:"repo_name,omitempty"`
-	TotalCommits      int         `json:"total_commits,omitempty"`
-	SuspiciousCommits int         `json:"suspicious_commits,omitempty"`
-	Suspicions        []Suspicion `json:"suspicions,omitempty"`
-	Velocity          string      `json:"velocity,omitempty"`
-	TimeSpan          string      `json:"time_span,omitempty"`
-	UniqueAuthors     int         `json:"unique_authors,omitempty"`
-	AverageCommitSize int         `json:"average_commit_size,omitempty"`
-	OverallSuspicion  float64     `json:"overall_suspicion,omitempty"`
+	RepoName          string           `json:"repo_name,omitempty"`
+	TotalCommits      int              `json:"total_commits,omitempty"`
+	SuspiciousCommits int              `json:"suspicious_commits,omitempty"`
+	Suspicions        []Suspicion      `json:"suspicions,omitempty"`
+	Velocity          string           `json:"velocity,omitempty"`
+	TimeSpan          string           `json:"time_span,omitempty"`
+	UniqueAuthors     int              `json:"unique_authors,omitempty"`
+	AverageCommitSize int              `json:"average_commit_size,omitempty"`
+	OverallSuspicion  float64          `json:"overall_suspicion,omitempty"`
+	PassedStrategies  []PassedStrategy `json:"passed_strategies,omitempty"`
 	// Website fields
 	URL             string       `json:"url,omitempty"`
 	WordCount       int          `json:"word_count,omitempty"`
@@ -643,6 +1550,56 @@ type JobResultResponse struct {
 	AnalyzedAt time.Time `json:"analyzed_at,omitempty"`
 }
 
+// validateReportConfidence checks a request's min_report_confidence.
+func validateReportConfidence(c float64) error {
+	if c < 0 || c > 1 {
+		return fmt.Errorf("min_report_confidence must be between 0 and 1")
+	}
+	return nil
+}
+
+// resolveRepositoryRequest validates that a repository request names either a
+// URL to clone or a local checkout ap allows, returning the validated local
+// path if set.
+func resolveRepositoryRequest(ap *AnalysisProcessor, req *AnalyzeRepositoryRequest) (string, error) {
+	if req.MaxCommits < 0 {
+		return "", fmt.Errorf("max_commits must not be negative")
+	}
+	if err := validateReportConfidence(req.MinReportConfidence); err != nil {
+		return "", err
+	}
+
+	if req.LocalPath == "" {
+		if req.RepositoryURL == "" {
+			return "", fmt.Errorf("repository_url or local_path is required")
+		}
+		return "", nil
+	}
+
+	localPath, err := ap.localRepoPath(req.LocalPath)
+	if err != nil {
+		return "", err
+	}
+
+	if req.RepositoryURL != "" {
+		logging.Default().With("component", "handlers").Warn("both repository_url and local_path given, using local_path",
+			"repository_url", req.RepositoryURL,
+			"local_path", localPath,
+		)
+	}
+
+	return localPath, nil
+}
+
+// validateCallback checks a callback URL's form and, like analysis targets,
+// keeps it off internal addresses.
+func (wh *WebhookHandlers) validateCallback(c *fiber.Ctx, callbackURL string) error {
+	if err := validateCallbackURL(callbackURL); err != nil || callbackURL == "" {
+		return err
+	}
+	return wh.processor.guard().CheckURL(c.UserContext(), callbackURL)
+}
+
 func (wh *WebhookHandlers) AnalyzeRepository(c *fiber.Ctx) error {
 	var req AnalyzeRepositoryRequest
 	if err := c.BodyParser(&req); err != nil {
@@ -651,29 +1608,61 @@ func (wh *WebhookHandlers) AnalyzeRepository(c *fiber.Ctx) error {
 		})
 	}
 
-	if req.RepositoryURL == "" {
+	localPath, err := resolveRepositoryRequest(wh.processor, &req)
+	if err != nil {
+		return localPathError(c, err)
+	}
+
+	disabled, err := disabledStrategySet(analysis.DefaultGitRegistry(), req.DisabledStrategies)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if localPath == "" {
+		if !wh.gitHosts.Allows(req.RepositoryURL) {
+			return gitHostDenied(c, req.RepositoryURL)
+		}
+		if err := wh.processor.guard().CheckRepositoryURL(c.UserContext(), req.RepositoryURL); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	if err := wh.validateCallback(c, req.CallbackURL); err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "repository_url is required",
+			"error": err.Error(),
 		})
 	}
 
 	job := &WebhookJob{
-		EventType: "api_analysis_repo",
-		RepoURL:   req.RepositoryURL,
-		Branch:    req.Branch,
-		Timestamp: time.Now(),
-		Commits:   make([]WebhookCommit, 0),
+		ID:                  requestTraceID(c),
+		EventType:           "api_analysis_repo",
+		RepoURL:             req.RepositoryURL,
+		LocalPath:           localPath,
+		Branch:              req.Branch,
+		SinceHash:           req.Since,
+		MaxCommits:          req.MaxCommits,
+		MinReportConfidence: req.MinReportConfidence,
+		IncludePassed:       req.IncludePassed,
+		DisabledStrategies:  disabled,
+		CallbackURL:         req.CallbackURL,
+		Timestamp:           time.Now(),
+		Commits:             make([]WebhookCommit, 0),
 	}
+	job.SourceKey = repoDedupKey(wh.urlNormalizer, job, true)
 
 	if err := wh.queue.Enqueue(job); err != nil {
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(enqueueStatus(err)).JSON(fiber.Map{
 			"error": "failed to queue analysis job",
 		})
 	}
 
 	return c.Status(http.StatusAccepted).JSON(AnalysisResponse{
 		JobID:  job.ID,
-		Status: StatusPending,
+		Status: job.Status,
 	})
 }
 
@@ -691,22 +1680,57 @@ func (wh *WebhookHandlers) AnalyzeWebsite(c *fiber.Ctx) error {
 		})
 	}
 
+	if err := validateReportConfidence(req.MinReportConfidence); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	disabled, err := disabledStrategySet(analysis.DefaultWebRegistry(), req.DisabledStrategies)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := wh.processor.guard().CheckURL(c.UserContext(), req.URL); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	if err := wh.validateCallback(c, req.CallbackURL); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// Submissions of the same page (http vs https, trailing slash, tracking
+	// params) share a key so Enqueue reuses an in-flight job instead of
+	// duplicating it.
+	sourceKey := confidenceKey(webSourceKey(wh.urlNormalizer, req.URL, disabled), req.MinReportConfidence)
+
 	job := &WebhookJob{
-		EventType: "api_analysis_website",
-		RepoURL:   req.URL,
-		Timestamp: time.Now(),
-		Commits:   make([]WebhookCommit, 0),
+		ID:                  requestTraceID(c),
+		EventType:           "api_analysis_website",
+		RepoURL:             req.URL,
+		SourceKey:           sourceKey,
+		DisabledStrategies:  disabled,
+		MinReportConfidence: req.MinReportConfidence,
+		CallbackURL:         req.CallbackURL,
+		Timestamp:           time.Now(),
+		Commits:             make([]WebhookCommit, 0),
 	}
 
 	if err := wh.queue.Enqueue(job); err != nil {
-		return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
+		return c.Status(enqueueStatus(err)).JSON(fiber.Map{
 			"error": "failed to queue analysis job",
 		})
 	}
 
 	return c.Status(http.StatusAccepted).JSON(AnalysisResponse{
 		JobID:  job.ID,
-		Status: StatusPending,
+		Status: job.Status,
 	})
 }
 
@@ -725,6 +1749,12 @@ func (wh *WebhookHandlers) GetJobResult(c *fiber.Ctx) error {
 		})
 	}
 
+	return c.JSON(jobResultResponse(job))
+}
+
+// jobResultResponse flattens a job and its result into the GET /api/results/:id
+// shape, which is also the body of result callbacks.
+func jobResultResponse(job *WebhookJob) JobResultResponse {
 	response := JobResultResponse{
 		JobID:    job.ID,
 		Status:   job.Status,
@@ -733,6 +1763,9 @@ func (wh *WebhookHandlers) GetJobResult(c *fiber.Ctx) error {
 	}
 
 	if job.Result != nil {
+		if job.Result.Status != "" && job.Status == StatusCompleted {
+			response.Status = job.Result.Status
+		}
 		// Repository fields
 		response.RepoName = job.Result.RepoName
 		response.TotalCommits = job.Result.TotalCommits
@@ -743,6 +1776,7 @@ func (wh *WebhookHandlers) GetJobResult(c *fiber.Ctx) error {
 		response.UniqueAuthors = job.Result.UniqueAuthors
 		response.AverageCommitSize = job.Result.AverageCommitSize
 		response.OverallSuspicion = job.Result.OverallSuspicion
+		response.PassedStrategies = job.Result.PassedStrategies
 		// Website fields
 		response.URL = job.Result.URL
 		response.WordCount = job.Result.WordCount
@@ -760,7 +1794,7 @@ func (wh *WebhookHandlers) GetJobResult(c *fiber.Ctx) error {
 		response.AnalyzedAt = job.Result.AnalyzedAt
 	}
 
-	return c.JSON(response)
+	return response
 }
 
 // MetricsEndpoint serves Prometheus text format metrics at GET /metrics.
@@ -774,9 +1808,36 @@ func (wh *WebhookHandlers) MetricsJSON(c *fiber.Ctx) error {
 	return c.JSON(wh.metrics.Snapshot())
 }
 
+// CacheStatsResponse is served at GET /api/cache/stats: the cache's own
+// counters plus the hits and misses recorded per source type.
+type CacheStatsResponse struct {
+	analysis.CacheStats
+	Enabled  bool                         `json:"enabled"`
+	BySource map[string]SourceCacheCounts `json:"bySource"`
+}
+
+// SourceCacheCounts holds the cache hits and misses of one source type.
+type SourceCacheCounts struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+}
+
 // CacheStats returns cache statistics at GET /api/cache/stats.
 func (wh *WebhookHandlers) CacheStats(c *fiber.Ctx) error {
-	return c.JSON(wh.cache.Stats())
+	_, disabled := wh.cache.(analysis.NullCache)
+	resp := CacheStatsResponse{
+		CacheStats: wh.cache.Stats(),
+		Enabled:    !disabled,
+		BySource:   make(map[string]SourceCacheCounts),
+	}
+	if snap := wh.metrics.Snapshot(); snap != nil {
+		for source, m := range snap.BySource {
+			if m.CacheHits > 0 || m.CacheMisses > 0 {
+				resp.BySource[source] = SourceCacheCounts{Hits: m.CacheHits, Misses: m.CacheMisses}
+			}
+		}
+	}
+	return c.JSON(resp)
 }
 
 // CacheClear empties the analysis cache at POST /api/cache/clear.
@@ -785,10 +1846,62 @@ func (wh *WebhookHandlers) CacheClear(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{"status": "cleared"})
 }
 
+// ListStrategies returns detection strategy metadata at GET /api/strategies,
+// sorted by name. Optional source_type (git|web), category and
+// min_confidence query parameters narrow the list; filters combine with AND.
+func (wh *WebhookHandlers) ListStrategies(c *fiber.Ctx) error {
+	registry := analysis.DefaultRegistry()
+	strategies := registry.All()
+
+	if sourceType := c.Query("source_type"); sourceType != "" {
+		if sourceType != "git" && sourceType != "web" {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"error": "source_type must be \"git\" or \"web\"",
+			})
+		}
+		strategies = intersectStrategies(strategies, registry.BySourceType(sourceType))
+	}
+	if category := c.Query("category"); category != "" {
+		strategies = intersectStrategies(strategies, registry.ByCategory(category))
+	}
+	if raw := c.Query("min_confidence"); raw != "" {
+		minConfidence, err := strconv.ParseFloat(raw, 64)
+		if err != nil || minConfidence < 0 || minConfidence > 1 {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"error": "min_confidence must be a number between 0 and 1",
+			})
+		}
+		strategies = intersectStrategies(strategies, registry.AboveConfidence(minConfidence))
+	}
+
+	sort.Slice(strategies, func(i, j int) bool { return strategies[i].Name < strategies[j].Name })
+
+	return c.JSON(fiber.Map{
+		"strategies": strategies,
+		"count":      len(strategies),
+	})
+}
+
+// intersectStrategies keeps the entries of infos that also appear in keep.
+func intersectStrategies(infos, keep []analysis.StrategyInfo) []analysis.StrategyInfo {
+	names := make(map[string]bool, len(keep))
+	for _, info := range keep {
+		names[info.Name] = true
+	}
+
+	result := make([]analysis.StrategyInfo, 0, len(infos))
+	for _, info := range infos {
+		if names[info.Name] {
+			result = append(result, info)
+		}
+	}
+	return result
+}
+
 // ListPlugins returns registered plugin metadata at GET /api/plugins.
 func (wh *WebhookHandlers) ListPlugins(c *fiber.Ctx) error {
 	return c.JSON(fiber.Map{
-		"plugins": wh.plugins.List(),
+		"plugins": wh.plugins.Plugins(),
 		"count":   wh.plugins.Count(),
 	})
 }
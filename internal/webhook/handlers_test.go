@@ -1,8 +1,32 @@
 package webhook
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git/patterns"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
+	"github.com/TryCadence/Cadence/internal/analysis/sources"
+	"github.com/TryCadence/Cadence/internal/logging"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/gofiber/fiber/v2"
 )
 
 func TestWebhookHandlers_HealthCheck(t *testing.T) {
@@ -37,6 +61,226 @@ func TestWebhookHandlers_HealthCheck(t *testing.T) {
 	})
 }
 
+func TestWebhookHandlers_VersionInfo(t *testing.T) {
+	get := func(t *testing.T, processor JobProcessor) versionResponse {
+		t.Helper()
+		server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, MaxWorkers: 1}, processor)
+		if err != nil {
+			t.Fatalf("NewServer() failed: %v", err)
+		}
+		resp, err := server.GetApp().Test(httptest.NewRequest("GET", "/version", http.NoBody))
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Status = %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+		var body versionResponse
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return body
+	}
+
+	t.Run("without AI", func(t *testing.T) {
+		body := get(t, NewDefaultProcessor())
+		if body.Version == "" || body.GitCommit == "" || body.BuildTime == "" {
+			t.Errorf("build info = %+v, want version, commit and build time", body.Info)
+		}
+		if !strings.HasPrefix(body.GoVersion, "go") {
+			t.Errorf("GoVersion = %q, want the Go runtime version", body.GoVersion)
+		}
+		if body.AIProviders == nil {
+			t.Error("AIProviders = null, want a list")
+		}
+		if body.AIValidation || body.AIProvider != "" {
+			t.Errorf("AI = %v/%q, want validation off", body.AIValidation, body.AIProvider)
+		}
+	})
+
+	t.Run("with AI", func(t *testing.T) {
+		body := get(t, &AnalysisProcessor{Analyzer: &triageAnalyzer{}})
+		if !body.AIValidation || body.AIProvider != "fake" {
+			t.Errorf("AI = %v/%q, want validation on with provider fake", body.AIValidation, body.AIProvider)
+		}
+	})
+}
+
+func TestNewServer_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&logging.Config{Level: "warn", Format: logging.FormatJSON, Output: &buf})
+	processor := &AnalysisProcessor{}
+	if _, err := NewServer(&ServerConfig{
+		Host:           "localhost",
+		Port:           9999,
+		MaxWorkers:     1,
+		PluginManifest: filepath.Join(t.TempDir(), "missing.yaml"),
+		Logger:         logger,
+	}, processor); err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), `"msg":"plugins not loaded"`) || !strings.Contains(buf.String(), `"component":"server"`) {
+		t.Errorf("expected the server warning in the configured logger, got: %s", buf.String())
+	}
+	buf.Reset()
+	processor.log(context.Background()).LogPhase("job-1", "starting analysis")
+	processor.log(context.Background()).Warn("clone slow")
+	if strings.Contains(buf.String(), "starting analysis") || !strings.Contains(buf.String(), `"component":"processor"`) {
+		t.Errorf("processor should log through the configured logger at its level, got: %s", buf.String())
+	}
+}
+
+func TestServer_ShutdownAfterDrainDeadline(t *testing.T) {
+	server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, MaxWorkers: 1}, &AnalysisProcessor{})
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	app := server.GetApp()
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		time.Sleep(200 * time.Millisecond)
+		return c.SendString("done")
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() failed: %v", err)
+	}
+	go func() { _ = app.Listener(ln) }()
+
+	responses := make(chan error, 1)
+	go func() {
+		resp, err := http.Get("http://" + ln.Addr().String() + "/slow")
+		if err == nil {
+			resp.Body.Close()
+		}
+		responses <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	// A drain that used up the caller's deadline must not cut the HTTP
+	// shutdown short: the in-flight request still completes.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := server.Shutdown(ctx); err != nil && strings.Contains(err.Error(), "http server") {
+		t.Errorf("Shutdown() error = %v, want the HTTP server closed on its own deadline", err)
+	}
+	if err := <-responses; err != nil {
+		t.Errorf("in-flight request failed: %v", err)
+	}
+}
+
+func TestNewServer_CloneLimiter(t *testing.T) {
+	processor := &AnalysisProcessor{}
+	server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, MaxWorkers: 3}, processor)
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	if processor.CloneLimiter.Limit() != 3 {
+		t.Errorf("clone slots = %d, want the worker count", processor.CloneLimiter.Limit())
+	}
+	if server.handlers.processor.CloneLimiter != processor.CloneLimiter {
+		t.Error("streams should share the processor's clone slots")
+	}
+	if clones := server.Metrics.Snapshot().Clones; clones == nil || clones.Limit != 3 {
+		t.Errorf("metrics Clones = %+v, want 3 slots", clones)
+	}
+
+	processor = &AnalysisProcessor{}
+	if _, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, MaxWorkers: 3, MaxConcurrentClones: 1}, processor); err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	if processor.CloneLimiter.Limit() != 1 {
+		t.Errorf("clone slots = %d, want MaxConcurrentClones", processor.CloneLimiter.Limit())
+	}
+}
+
+func TestWebhookHandlers_ReadinessCheck(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 4)
+	server, err := NewServer(&ServerConfig{
+		Host:               "localhost",
+		Port:               9999,
+		WebhookSecret:      "test-secret",
+		MaxWorkers:         1,
+		ReadyMaxQueueDepth: 2,
+	}, processorFunc(func(ctx context.Context, job *WebhookJob) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}))
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	app := server.GetApp()
+	queue := server.GetQueue()
+
+	ready := func(t *testing.T, wantStatus int, wantReason string) {
+		t.Helper()
+		resp, err := app.Test(httptest.NewRequest("GET", "/health/ready", http.NoBody))
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		var body struct {
+			Status   string     `json:"status"`
+			Reason   string     `json:"reason"`
+			Queue    QueueStats `json:"queue"`
+			MaxDepth int        `json:"max_depth"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if resp.StatusCode != wantStatus || body.Reason != wantReason || body.MaxDepth != 2 {
+			t.Errorf("GET /health/ready = %d %+v, want %d with reason %q", resp.StatusCode, body, wantStatus, wantReason)
+		}
+	}
+
+	t.Run("not started", func(t *testing.T) {
+		ready(t, http.StatusServiceUnavailable, "job queue is not accepting jobs")
+	})
+
+	if err := queue.Start(); err != nil {
+		t.Fatalf("Start() unexpected error = %v", err)
+	}
+	defer func() {
+		close(release)
+		_ = queue.Stop()
+	}()
+
+	t.Run("busy worker, short queue", func(t *testing.T) {
+		if err := queue.Enqueue(&WebhookJob{EventType: "github_push"}); err != nil {
+			t.Fatalf("Enqueue() unexpected error = %v", err)
+		}
+		<-started
+		if err := queue.Enqueue(&WebhookJob{EventType: "github_push"}); err != nil {
+			t.Fatalf("Enqueue() unexpected error = %v", err)
+		}
+		ready(t, http.StatusOK, "")
+	})
+
+	t.Run("saturated queue", func(t *testing.T) {
+		if err := queue.Enqueue(&WebhookJob{EventType: "github_push"}); err != nil {
+			t.Fatalf("Enqueue() unexpected error = %v", err)
+		}
+		ready(t, http.StatusServiceUnavailable, "job queue is saturated")
+	})
+
+	t.Run("liveness unaffected", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/health", http.NoBody))
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Errorf("GET /health = %d under load, want 200", resp.StatusCode)
+		}
+	})
+}
+
 func TestWebhookHandlers_Routes(t *testing.T) {
 	processor := NewDefaultProcessor()
 	server, err := NewServer(&ServerConfig{
@@ -112,3 +356,1027 @@ func TestVerifySignature(t *testing.T) {
 		}
 	})
 }
+
+func TestAnalyzeWebsite_DedupsEquivalentURLs(t *testing.T) {
+	server, err := NewServer(&ServerConfig{
+		Host:          "localhost",
+		Port:          9999,
+		WebhookSecret: "test-secret",
+		MaxWorkers:    1,
+	}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	submitBody := func(body string) string {
+		req, _ := http.NewRequest("POST", "/api/analyze/website", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.GetApp().Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("Status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+		}
+		var out AnalysisResponse
+		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+			t.Fatalf("invalid response JSON: %v", err)
+		}
+		return out.JobID
+	}
+	submit := func(url string) string {
+		return submitBody(`{"url":"` + url + `"}`)
+	}
+
+	first := submit("http://example.com/")
+	for _, url := range []string{"https://example.com", "https://example.com/?utm_source=newsletter"} {
+		if got := submit(url); got != first {
+			t.Errorf("submission %q created job %s, want existing job %s", url, got, first)
+		}
+	}
+
+	if got := submit("https://example.com/pricing"); got == first {
+		t.Error("a different page should create a new job")
+	}
+	if got := submitBody(`{"url":"http://example.com/","min_report_confidence":0.5}`); got == first {
+		t.Error("a different confidence floor should create a new job")
+	}
+}
+
+// localPathProcessor returns a default processor that accepts local
+// checkouts under the temporary directory tests create them in.
+func localPathProcessor() *AnalysisProcessor {
+	ap := NewDefaultProcessor().(*AnalysisProcessor)
+	ap.AllowLocalPaths = true
+	ap.LocalPathRoot = os.TempDir()
+	return ap
+}
+
+func TestAnalyzeRepository_LocalPath(t *testing.T) {
+	server, err := NewServer(&ServerConfig{
+		Host:          "localhost",
+		Port:          9999,
+		WebhookSecret: "test-secret",
+		MaxWorkers:    1,
+	}, localPathProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	repoDir := t.TempDir()
+	if _, err := gogit.PlainInit(repoDir, false); err != nil {
+		t.Fatalf("PlainInit() failed: %v", err)
+	}
+	plainDir := t.TempDir()
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{"missing source", `{}`, http.StatusBadRequest},
+		{"path does not exist", `{"local_path":"` + filepath.Join(plainDir, "nope") + `"}`, http.StatusBadRequest},
+		{"not a git repository", `{"local_path":"` + plainDir + `"}`, http.StatusBadRequest},
+		{"valid local repository", `{"local_path":"` + repoDir + `"}`, http.StatusAccepted},
+		{"local path preferred over url", `{"repository_url":"https://github.com/example/repo","local_path":"` + repoDir + `"}`, http.StatusAccepted},
+		{"negative max_commits", `{"local_path":"` + repoDir + `","max_commits":-1}`, http.StatusBadRequest},
+		{"min_report_confidence above 1", `{"local_path":"` + repoDir + `","min_report_confidence":1.5}`, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", "/api/analyze/repository", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := server.GetApp().Test(req)
+			if err != nil {
+				t.Fatalf("Test() unexpected error = %v", err)
+			}
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("Status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusAccepted {
+				return
+			}
+
+			var out AnalysisResponse
+			if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+				t.Fatalf("invalid response JSON: %v", err)
+			}
+			job, err := server.GetQueue().GetJob(out.JobID)
+			if err != nil {
+				t.Fatalf("GetJob() unexpected error = %v", err)
+			}
+			if job.LocalPath != repoDir {
+				t.Errorf("LocalPath = %q, want %q", job.LocalPath, repoDir)
+			}
+		})
+	}
+}
+
+func TestLocalRepoPath_Policy(t *testing.T) {
+	root := t.TempDir()
+	inside := filepath.Join(root, "repo")
+	outside := t.TempDir()
+	for _, dir := range []string{inside, outside} {
+		if _, err := gogit.PlainInit(dir, false); err != nil {
+			t.Fatalf("PlainInit() failed: %v", err)
+		}
+	}
+	escape := filepath.Join(root, "escape")
+	if err := os.Symlink(outside, escape); err != nil {
+		t.Fatalf("Symlink() failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		ap     *AnalysisProcessor
+		path   string
+		denied bool
+	}{
+		{"disabled by default", &AnalysisProcessor{}, inside, true},
+		{"allowed without a root", &AnalysisProcessor{AllowLocalPaths: true}, inside, true},
+		{"inside the root", &AnalysisProcessor{AllowLocalPaths: true, LocalPathRoot: root}, inside, false},
+		{"outside the root", &AnalysisProcessor{AllowLocalPaths: true, LocalPathRoot: root}, outside, true},
+		{"relative escape", &AnalysisProcessor{AllowLocalPaths: true, LocalPathRoot: root}, filepath.Join(root, "..", filepath.Base(outside)), true},
+		{"symlink out of the root", &AnalysisProcessor{AllowLocalPaths: true, LocalPathRoot: root}, escape, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.ap.localRepoPath(tt.path)
+			if got := errors.Is(err, errLocalPathDenied); got != tt.denied {
+				t.Errorf("localRepoPath(%q) error = %v, want denied = %v", tt.path, err, tt.denied)
+			}
+			if !tt.denied && err != nil {
+				t.Errorf("localRepoPath(%q) unexpected error = %v", tt.path, err)
+			}
+		})
+	}
+
+	job := &WebhookJob{ID: "local-denied", EventType: "api_analysis_repo", LocalPath: inside}
+	if err := NewDefaultProcessor().Process(context.Background(), job); !errors.Is(err, errLocalPathDenied) {
+		t.Errorf("Process() error = %v, want the local path refused", err)
+	}
+
+	server, err := NewServer(&ServerConfig{WebhookSecret: "s", MaxWorkers: 1}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	req, _ := http.NewRequest("POST", "/api/analyze/repository", strings.NewReader(`{"local_path":"`+inside+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := server.GetApp().Test(req)
+	if err != nil {
+		t.Fatalf("Test() unexpected error = %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("Status = %d, want 403 with local paths disabled", resp.StatusCode)
+	}
+}
+
+func TestProcessGitAnalysis_LocalPathIsNotRemoved(t *testing.T) {
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	if err != nil {
+		t.Fatalf("PlainInit() failed: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() failed: %v", err)
+	}
+
+	when := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	for i, content := range []string{"package main\n", "package main\n\nfunc main() {}\n"} {
+		if err := os.WriteFile(filepath.Join(repoDir, "main.go"), []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() failed: %v", err)
+		}
+		if _, err := wt.Add("main.go"); err != nil {
+			t.Fatalf("Add() failed: %v", err)
+		}
+		sig := &object.Signature{Name: "dev", Email: "dev@example.com", When: when.Add(time.Duration(i) * time.Hour)}
+		if _, err := wt.Commit("commit "+string(rune('a'+i)), &gogit.CommitOptions{Author: sig}); err != nil {
+			t.Fatalf("Commit() failed: %v", err)
+		}
+	}
+
+	job := &WebhookJob{ID: "local-job", EventType: "api_analysis_repo", LocalPath: repoDir}
+	if err := localPathProcessor().Process(context.Background(), job); err != nil {
+		t.Fatalf("Process() unexpected error = %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(repoDir, ".git")); err != nil {
+		t.Errorf("local repository was modified or removed: %v", err)
+	}
+	if job.Result == nil || job.Result.TotalCommits == 0 {
+		t.Errorf("expected analysis result for local repository, got %+v", job.Result)
+	}
+}
+
+func TestUniqueAuthors_ClustersAliases(t *testing.T) {
+	repoDir := t.TempDir()
+	repo, err := gogit.PlainInit(repoDir, false)
+	if err != nil {
+		t.Fatalf("PlainInit() failed: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() failed: %v", err)
+	}
+
+	when := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+	authors := []object.Signature{
+		{Name: "Jane Doe", Email: "jane@x.com"},
+		{Name: "Jane Doe", Email: "jane+ci@x.com"},
+		{Name: "jane", Email: "1+jane@users.noreply.github.com"},
+	}
+	for i, sig := range authors {
+		content := strings.Repeat("line\n", i+1)
+		if err := os.WriteFile(filepath.Join(repoDir, "main.go"), []byte(content), 0o600); err != nil {
+			t.Fatalf("WriteFile() failed: %v", err)
+		}
+		if _, err := wt.Add("main.go"); err != nil {
+			t.Fatalf("Add() failed: %v", err)
+		}
+		sig.When = when.Add(time.Duration(i) * time.Hour)
+		if _, err := wt.Commit(fmt.Sprintf("commit %d", i), &gogit.CommitOptions{Author: &sig}); err != nil {
+			t.Fatalf("Commit() failed: %v", err)
+		}
+	}
+
+	t.Run("job result", func(t *testing.T) {
+		job := &WebhookJob{ID: "aliases-job", EventType: "api_analysis_repo", LocalPath: repoDir}
+		if err := localPathProcessor().Process(context.Background(), job); err != nil {
+			t.Fatalf("Process() unexpected error = %v", err)
+		}
+		if job.Result == nil {
+			t.Fatal("expected an analysis result")
+		}
+		if job.Result.UniqueAuthors != 1 {
+			t.Errorf("UniqueAuthors = %d, want 1 identity for the aliases", job.Result.UniqueAuthors)
+		}
+	})
+
+	t.Run("stream result", func(t *testing.T) {
+		server, err := NewServer(&ServerConfig{
+			Host:          "localhost",
+			Port:          9999,
+			WebhookSecret: "test-secret",
+			MaxWorkers:    1,
+		}, localPathProcessor())
+		if err != nil {
+			t.Fatalf("NewServer() failed: %v", err)
+		}
+		req, _ := http.NewRequest("POST", "/api/stream/repository", strings.NewReader(`{"local_path":"`+repoDir+`"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := server.GetApp().Test(req, -1)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+
+		_, data, ok := strings.Cut(string(body), "event: result\ndata: ")
+		if !ok {
+			t.Fatalf("no result event in stream: %q", body)
+		}
+		data, _, _ = strings.Cut(data, "\n")
+		var result JobResultResponse
+		if err := json.Unmarshal([]byte(data), &result); err != nil {
+			t.Fatalf("invalid result JSON: %v", err)
+		}
+		if result.UniqueAuthors != 1 {
+			t.Errorf("unique_authors = %d, want 1 identity for the aliases", result.UniqueAuthors)
+		}
+	})
+}
+
+func TestProcessGitAnalysis_SinceHash(t *testing.T) {
+	repoDir := createCloneSource(t)
+	repo, err := gogit.PlainOpen(repoDir)
+	if err != nil {
+		t.Fatalf("PlainOpen() failed: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head() failed: %v", err)
+	}
+	headCommit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject() failed: %v", err)
+	}
+
+	job := &WebhookJob{
+		ID:        "since-job",
+		EventType: "api_analysis_repo",
+		LocalPath: repoDir,
+		SinceHash: headCommit.ParentHashes[0].String(),
+	}
+	if err := localPathProcessor().Process(context.Background(), job); err != nil {
+		t.Fatalf("Process() unexpected error = %v", err)
+	}
+	if job.Result.TotalCommits != 1 {
+		t.Errorf("TotalCommits = %d, want 1 (only the commit after since)", job.Result.TotalCommits)
+	}
+}
+
+func TestProcessGitAnalysis_MaxCommits(t *testing.T) {
+	repoDir := createCloneSource(t)
+
+	job := &WebhookJob{ID: "capped-job", EventType: "api_analysis_repo", LocalPath: repoDir, MaxCommits: 2}
+	if err := localPathProcessor().Process(context.Background(), job); err != nil {
+		t.Fatalf("Process() unexpected error = %v", err)
+	}
+	if job.Result.TotalCommits != 2 {
+		t.Errorf("TotalCommits = %d, want 2 (capped by max_commits)", job.Result.TotalCommits)
+	}
+}
+
+func TestProcessGitAnalysis_IncludePassed(t *testing.T) {
+	repoDir := createCloneSource(t)
+
+	job := &WebhookJob{ID: "passed-job", EventType: "api_analysis_repo", LocalPath: repoDir}
+	if err := localPathProcessor().Process(context.Background(), job); err != nil {
+		t.Fatalf("Process() unexpected error = %v", err)
+	}
+	if len(job.Result.PassedStrategies) != 0 {
+		t.Errorf("PassedStrategies = %v, want none unless requested", job.Result.PassedStrategies)
+	}
+
+	job = &WebhookJob{ID: "passed-job-2", EventType: "api_analysis_repo", LocalPath: repoDir, IncludePassed: true}
+	if err := localPathProcessor().Process(context.Background(), job); err != nil {
+		t.Fatalf("Process() unexpected error = %v", err)
+	}
+	if len(job.Result.PassedStrategies) == 0 {
+		t.Fatal("PassedStrategies is empty, want the strategies that cleared the repository")
+	}
+	for _, p := range job.Result.PassedStrategies {
+		if p.Name == "" || p.Category == "" {
+			t.Errorf("passed strategy %+v should have a name and category", p)
+		}
+	}
+	if job.Result.StrategiesUsed < len(job.Result.PassedStrategies) {
+		t.Errorf("StrategiesUsed = %d, want at least the %d passed strategies", job.Result.StrategiesUsed, len(job.Result.PassedStrategies))
+	}
+}
+
+func TestProcessGitAnalysis_ServerGitSettings(t *testing.T) {
+	repoDir := createCloneSource(t)
+	repo, err := gogit.PlainOpen(repoDir)
+	if err != nil {
+		t.Fatalf("PlainOpen() failed: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(repoDir, "file.txt"), []byte("bot change\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if _, err := wt.Add("file.txt"); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	sig := &object.Signature{Name: "renovate[bot]", Email: "bot@example.com", When: time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)}
+	if _, err := wt.Commit("chore(deps): update", &gogit.CommitOptions{Author: sig}); err != nil {
+		t.Fatalf("Commit() failed: %v", err)
+	}
+
+	run := func(ap *AnalysisProcessor) *JobResult {
+		t.Helper()
+		job := &WebhookJob{ID: "settings-job", EventType: "api_analysis_repo", LocalPath: repoDir, IncludePassed: true}
+		if err := ap.Process(context.Background(), job); err != nil {
+			t.Fatalf("Process() unexpected error = %v", err)
+		}
+		return job.Result
+	}
+	passed := func(result *JobResult, name string) bool {
+		for _, p := range result.PassedStrategies {
+			if p.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	plain := run(localPathProcessor())
+	ap := localPathProcessor()
+	ap.SourceFilters.IgnoreAuthors = []string{"bot@*"}
+	ap.StrategyOptions.DisabledStrategies = map[string]bool{"size_analysis": true}
+	filtered := run(ap)
+
+	if filtered.TotalCommits != plain.TotalCommits-1 {
+		t.Errorf("TotalCommits = %d, want %d with the bot's commit ignored", filtered.TotalCommits, plain.TotalCommits-1)
+	}
+	if !passed(plain, "size_analysis") || passed(filtered, "size_analysis") {
+		t.Errorf("size_analysis passed = %v without and %v with it disabled by the server, want true and false", passed(plain, "size_analysis"), passed(filtered, "size_analysis"))
+	}
+}
+
+func TestPopulateGitJobResult(t *testing.T) {
+	report := &analysis.AnalysisReport{
+		Metrics: map[string]interface{}{"commit_count": 3},
+		Detections: []analysis.Detection{
+			{Strategy: "git-velocity-analysis", Detected: true, Severity: "high", Score: 0.8, Category: "velocity",
+				Description: "big commit", Examples: []string{"abc123", "Large commit"}, Strategies: []string{"size_analysis"}},
+			{Strategy: "timing_analysis", Detected: false, Severity: "none", Category: "velocity", Description: "Detects commits made too quickly"},
+		},
+	}
+	job := &WebhookJob{ID: "populate", Result: &JobResult{}}
+	(&AnalysisProcessor{}).populateGitJobResult(context.Background(), job, report)
+
+	if len(job.Result.Suspicions) != 1 || job.Result.Suspicions[0].CommitHash != "abc123" || job.Result.SuspiciousCommits != 1 {
+		t.Errorf("Suspicions = %+v, want the one flagged commit", job.Result.Suspicions)
+	}
+	want := []PassedStrategy{{Name: "timing_analysis", Category: "velocity", Description: "Detects commits made too quickly"}}
+	if !reflect.DeepEqual(job.Result.PassedStrategies, want) {
+		t.Errorf("PassedStrategies = %+v, want %+v", job.Result.PassedStrategies, want)
+	}
+}
+
+func TestPopulateJobResult_ExamplePolicy(t *testing.T) {
+	ap := &AnalysisProcessor{ExamplePolicy: analysis.ExamplePolicy{MaxExamples: 2, Redact: true}}
+
+	gitReport := &analysis.AnalysisReport{Detections: []analysis.Detection{
+		{Strategy: "git-velocity-analysis", Detected: true, Severity: "high",
+			Examples: []string{"abc123", "Adds api_key = \"live-secret-value\"", "Top files: a.go"}},
+	}}
+	job := &WebhookJob{ID: "git", Result: &JobResult{}}
+	ap.populateGitJobResult(context.Background(), job, gitReport)
+	if s := job.Result.Suspicions[0]; s.CommitHash != "abc123" || !reflect.DeepEqual(s.Reasons, []string{`Adds api_key = "[REDACTED]"`}) {
+		t.Errorf("suspicion = %+v, want one redacted reason", s)
+	}
+
+	webReport := &analysis.AnalysisReport{Detections: []analysis.Detection{
+		{Strategy: "overused_phrases", Detected: true, Category: "web-pattern", Examples: []string{"mail sales@example.com"}},
+	}}
+	job = &WebhookJob{ID: "web", Result: &JobResult{}}
+	ap.populateWebJobResult(job, webReport)
+	if got := job.Result.WebPatterns[0].Examples; !reflect.DeepEqual(got, []string{"mail [REDACTED]"}) {
+		t.Errorf("web examples = %q, want the email redacted", got)
+	}
+	if webReport.Detections[0].Examples[0] != "mail sales@example.com" {
+		t.Error("the report, which may be cached, was modified")
+	}
+}
+
+func TestProcessGitAnalysis_Timeout(t *testing.T) {
+	repoDir := createCloneSource(t)
+	metrics := analysis.NewInMemoryMetrics()
+	ap := localPathProcessor()
+	ap.AnalysisTimeout, ap.Metrics = time.Nanosecond, metrics
+
+	job := &WebhookJob{ID: "slow-job", EventType: "api_analysis_repo", LocalPath: repoDir}
+	err := ap.Process(context.Background(), job)
+	if err == nil || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("Process() error = %v, want a timeout", err)
+	}
+	if job.Progress != "timed-out" {
+		t.Errorf("Progress = %q, want timed-out", job.Progress)
+	}
+	if got := metrics.Snapshot().ErrorsByPhase["timeout"]; got != 1 {
+		t.Errorf("timeout errors = %d, want 1", got)
+	}
+}
+
+func TestProcessGitAnalysis_Cache(t *testing.T) {
+	repoDir := createCloneSource(t)
+	cache := analysis.NewInMemoryCache()
+	metrics := analysis.NewInMemoryMetrics()
+	ap := &AnalysisProcessor{Cache: cache, Metrics: metrics, AllowPrivateHosts: true}
+
+	run := func(job *WebhookJob) {
+		t.Helper()
+		if err := ap.Process(context.Background(), job); err != nil {
+			t.Fatalf("Process() unexpected error = %v", err)
+		}
+	}
+
+	first := &WebhookJob{ID: "cache-1", EventType: "api_analysis_repo", RepoURL: repoDir}
+	run(first)
+	if cache.Size() != 1 {
+		t.Fatalf("cache size = %d after the first clone, want 1", cache.Size())
+	}
+	cached, _ := cache.Get(ap.repoCacheKey(first))
+	if pairs, ok := cached.Metrics["commit_pairs"].([]*git.CommitPair); !ok || len(pairs) == 0 || pairs[0].DiffContent != "" {
+		t.Errorf("cached report should keep commit pairs without diff content")
+	}
+
+	// Removing the source proves the second run never clones.
+	if err := os.RemoveAll(repoDir); err != nil {
+		t.Fatal(err)
+	}
+	second := &WebhookJob{ID: "cache-2", EventType: "api_analysis_repo", RepoURL: repoDir}
+	run(second)
+	if second.Result.TotalCommits != first.Result.TotalCommits || second.Progress != "completed" {
+		t.Errorf("cached result = %+v, want the first run's %d commits", second.Result, first.Result.TotalCommits)
+	}
+
+	src := metrics.Snapshot().BySource["git"]
+	if src == nil || src.CacheHits != 1 || src.CacheMisses != 1 {
+		t.Errorf("git cache metrics = %+v, want 1 hit and 1 miss", src)
+	}
+
+	keys := map[string]string{"first": ap.repoCacheKey(first)}
+	keys["branch"] = ap.repoCacheKey(&WebhookJob{RepoURL: repoDir, Branch: "dev"})
+	keys["since"] = ap.repoCacheKey(&WebhookJob{RepoURL: repoDir, SinceHash: "abc"})
+	keys["disabled"] = ap.repoCacheKey(&WebhookJob{RepoURL: repoDir, DisabledStrategies: map[string]bool{"size_analysis": true}})
+	keys["thresholds"] = (&AnalysisProcessor{DetectorThresholds: &patterns.Thresholds{SuspiciousAdditions: 1}}).repoCacheKey(first)
+	keys["config"] = (&AnalysisProcessor{ConfigFingerprint: "other"}).repoCacheKey(first)
+	keys["min confidence"] = ap.repoCacheKey(&WebhookJob{RepoURL: repoDir, MinReportConfidence: 0.5})
+	keys["server min confidence"] = (&AnalysisProcessor{MinReportConfidence: 0.5}).repoCacheKey(first)
+	keys["include passed"] = ap.repoCacheKey(&WebhookJob{RepoURL: repoDir, IncludePassed: true})
+	keys["server include passed"] = (&AnalysisProcessor{IncludePassed: true}).repoCacheKey(first)
+	seen := map[string]string{}
+	for name, key := range keys {
+		if other, dup := seen[key]; dup {
+			t.Errorf("%s and %s share cache key %s", name, other, key)
+		}
+		seen[key] = name
+	}
+}
+
+func TestCacheStats(t *testing.T) {
+	server, err := NewServer(&ServerConfig{WebhookSecret: "s", MaxWorkers: 1, CacheMaxEntries: 8, CacheTTL: time.Minute}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	server.Metrics.RecordCacheHit("git")
+	server.Metrics.RecordCacheMiss("web")
+
+	resp, err := server.GetApp().Test(httptest.NewRequest("GET", "/api/cache/stats", http.NoBody))
+	if err != nil {
+		t.Fatalf("Test() unexpected error = %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var stats CacheStatsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !stats.Enabled || stats.MaxSize != 8 || stats.TTLSeconds != 60 {
+		t.Errorf("stats = %+v, want enabled with 8 entries and a 60s TTL", stats)
+	}
+	if stats.BySource["git"].Hits != 1 || stats.BySource["web"].Misses != 1 {
+		t.Errorf("bySource = %+v, want a git hit and a web miss", stats.BySource)
+	}
+
+	disabled, err := NewServer(&ServerConfig{WebhookSecret: "s", MaxWorkers: 1, DisableCache: true}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	if _, ok := disabled.Cache.(analysis.NullCache); !ok {
+		t.Errorf("DisableCache server cache = %T, want NullCache", disabled.Cache)
+	}
+}
+
+func TestApplyHistoryLimits(t *testing.T) {
+	tests := []struct {
+		name       string
+		serverMax  int
+		requested  int
+		wantCommit int
+	}{
+		{"source default", 0, 0, sources.DefaultMaxCommits},
+		{"server limit", 200, 0, 200},
+		{"server unlimited", -1, 0, 0},
+		{"request lowers limit", 200, 50, 50},
+		{"request cannot raise limit", 200, 500, 200},
+		{"request limits unlimited server", -1, 500, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ap := &AnalysisProcessor{MaxCommits: tt.serverMax, MaxDiffBytes: 1 << 20}
+			source := sources.NewGitRepositorySource("/repo", "")
+			ap.applyHistoryLimits(source, tt.requested)
+			if source.MaxCommits != tt.wantCommit {
+				t.Errorf("MaxCommits = %d, want %d", source.MaxCommits, tt.wantCommit)
+			}
+			if source.MaxDiffBytes != 1<<20 {
+				t.Errorf("MaxDiffBytes = %d, want %d", source.MaxDiffBytes, 1<<20)
+			}
+		})
+	}
+}
+
+func TestPushSinceHash(t *testing.T) {
+	if got := pushSinceHash("0000000000000000000000000000000000000000"); got != "" {
+		t.Errorf("pushSinceHash(zero) = %q, want empty for a new branch", got)
+	}
+	if got := pushSinceHash("9f8e7d6c"); got != "9f8e7d6c" {
+		t.Errorf("pushSinceHash() = %q, want 9f8e7d6c", got)
+	}
+}
+
+func TestListStrategies(t *testing.T) {
+	server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, WebhookSecret: "test-secret", MaxWorkers: 1}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	app := server.GetApp()
+
+	list := func(t *testing.T, query string) (int, []analysis.StrategyInfo) {
+		t.Helper()
+		req, _ := http.NewRequest("GET", "/api/strategies"+query, http.NoBody)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+
+		var body struct {
+			Strategies []analysis.StrategyInfo `json:"strategies"`
+			Count      int                     `json:"count"`
+		}
+		if resp.StatusCode == http.StatusOK {
+			if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if body.Count != len(body.Strategies) {
+				t.Errorf("count = %d, want %d", body.Count, len(body.Strategies))
+			}
+		}
+		return resp.StatusCode, body.Strategies
+	}
+
+	t.Run("all strategies sorted by name", func(t *testing.T) {
+		status, strategies := list(t, "")
+		if status != http.StatusOK {
+			t.Fatalf("Status = %d, want %d", status, http.StatusOK)
+		}
+		if len(strategies) != analysis.DefaultRegistry().Count() {
+			t.Errorf("got %d strategies, want %d", len(strategies), analysis.DefaultRegistry().Count())
+		}
+		for i := 1; i < len(strategies); i++ {
+			if strategies[i-1].Name >= strategies[i].Name {
+				t.Errorf("not sorted: %q before %q", strategies[i-1].Name, strategies[i].Name)
+			}
+		}
+	})
+
+	t.Run("filters combine", func(t *testing.T) {
+		status, strategies := list(t, "?source_type=git&category=velocity&min_confidence=0.5")
+		if status != http.StatusOK {
+			t.Fatalf("Status = %d, want %d", status, http.StatusOK)
+		}
+		if len(strategies) == 0 {
+			t.Fatal("expected at least one git velocity strategy")
+		}
+		for _, s := range strategies {
+			if s.Category != "velocity" || s.Confidence < 0.5 || len(s.SourceTypes) == 0 || s.SourceTypes[0] != "git" {
+				t.Errorf("strategy %s does not match the filters: %+v", s.Name, s)
+			}
+		}
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		for _, query := range []string{"?source_type=svn", "?min_confidence=high", "?min_confidence=2"} {
+			if status, _ := list(t, query); status != http.StatusBadRequest {
+				t.Errorf("%s: Status = %d, want %d", query, status, http.StatusBadRequest)
+			}
+		}
+	})
+}
+
+func TestAnalyze_DisabledStrategies(t *testing.T) {
+	server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, WebhookSecret: "test-secret", MaxWorkers: 1}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	app := server.GetApp()
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"repository typo", "/api/analyze/repository", `{"repository_url":"https://github.com/a/b","disabled_strategies":["velocity_analysys"]}`},
+		{"repository web name", "/api/analyze/repository", `{"repository_url":"https://github.com/a/b","disabled_strategies":["overused_phrases"]}`},
+		{"website git name", "/api/analyze/website", `{"url":"https://example.com","disabled_strategies":["velocity_analysis"]}`},
+		{"stream repository typo", "/api/stream/repository", `{"repository_url":"https://github.com/a/b","disabled_strategies":["nope"]}`},
+		{"stream website typo", "/api/stream/website", `{"url":"https://example.com","disabled_strategies":["nope"]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest("POST", tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("Test() unexpected error = %v", err)
+			}
+			defer func() {
+				_ = resp.Body.Close()
+			}()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("Status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestDisabledStrategySet(t *testing.T) {
+	set, err := disabledStrategySet(analysis.DefaultGitRegistry(), []string{"velocity_analysis", "TimingAnomaly"})
+	if err != nil {
+		t.Fatalf("disabledStrategySet() unexpected error = %v", err)
+	}
+	if len(set) != 2 || !set["velocity_analysis"] || !set["TimingAnomaly"] {
+		t.Errorf("set = %v", set)
+	}
+
+	if set, err := disabledStrategySet(analysis.DefaultGitRegistry(), nil); err != nil || set != nil {
+		t.Errorf("empty names = %v, %v; want nil, nil", set, err)
+	}
+
+	_, err = disabledStrategySet(analysis.DefaultGitRegistry(), []string{"velocity_analysis", "sise_analysis"})
+	if err == nil || !strings.Contains(err.Error(), "sise_analysis") {
+		t.Errorf("error = %v, want it to name sise_analysis", err)
+	}
+}
+
+func TestWebSourceKey_DisabledStrategies(t *testing.T) {
+	n := web.NewURLNormalizer(true)
+	full := webSourceKey(n, "https://example.com/", nil)
+	a := webSourceKey(n, "https://example.com/", map[string]bool{"emoji_overuse": true, "ai_vocabulary": true})
+	b := webSourceKey(n, "http://example.com", map[string]bool{"ai_vocabulary": true, "emoji_overuse": true})
+
+	if full != n.Key("https://example.com/") {
+		t.Errorf("key without disabled strategies = %q, want the normalized URL", full)
+	}
+	if a == full {
+		t.Error("disabling strategies should change the key")
+	}
+	if a != b {
+		t.Errorf("equivalent requests produced different keys: %q vs %q", a, b)
+	}
+}
+
+func TestHandleGitlabWebhook(t *testing.T) {
+	server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, WebhookSecret: "test-secret", MaxWorkers: 1}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+
+	payload := `{
+		"object_kind": "push",
+		"before": "95790bf891e76fee5e1747ab589903a6a1f80f22",
+		"after": "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+		"ref": "refs/heads/feature/login",
+		"user_name": "Jane Doe",
+		"user_email": "jane@example.com",
+		"project": {
+			"id": 15,
+			"name": "diaspora",
+			"path_with_namespace": "mike/diaspora",
+			"git_http_url": "https://gitlab.example.com/mike/diaspora.git"
+		},
+		"commits": [
+			{
+				"id": "b6568db1bc1dcd7f8b4d5a946b0b91f9dacd7327",
+				"message": "Update Catalan translation to e38cb41.",
+				"timestamp": "2011-12-12T14:27:31+02:00",
+				"author": {"name": "Jordi Mallach", "email": "jordi@softcatala.org"},
+				"added": ["CHANGELOG"],
+				"modified": ["app/controller/application.rb"],
+				"removed": []
+			},
+			{
+				"id": "da1560886d4f094c3e6c9ef40349f7d38b5d27d7",
+				"message": "fixed readme",
+				"timestamp": "2012-01-03T23:36:29+02:00",
+				"author": {"name": "GitLab dev user", "email": "gitlabdev@dv6700.(none)"},
+				"added": [],
+				"modified": ["README.md"],
+				"removed": []
+			}
+		],
+		"total_commits_count": 2
+	}`
+
+	post := func(t *testing.T, token, body string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest("POST", "/webhooks/gitlab", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Gitlab-Event", "Push Hook")
+		if token != "" {
+			req.Header.Set("X-Gitlab-Token", token)
+		}
+		resp, err := server.GetApp().Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		return resp
+	}
+
+	t.Run("push is queued", func(t *testing.T) {
+		resp := post(t, "test-secret", payload)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("Status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+		}
+
+		var out AnalysisResponse
+		if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+			t.Fatalf("invalid response JSON: %v", err)
+		}
+		job, err := server.GetQueue().GetJob(out.JobID)
+		if err != nil {
+			t.Fatalf("GetJob() unexpected error = %v", err)
+		}
+
+		if job.EventType != "gitlab_push" {
+			t.Errorf("EventType = %q, want gitlab_push", job.EventType)
+		}
+		if job.Branch != "feature/login" {
+			t.Errorf("Branch = %q, want feature/login", job.Branch)
+		}
+		if job.RepoURL != "https://gitlab.example.com/mike/diaspora.git" {
+			t.Errorf("RepoURL = %q", job.RepoURL)
+		}
+		if job.SinceHash != "95790bf891e76fee5e1747ab589903a6a1f80f22" {
+			t.Errorf("SinceHash = %q, want the push's before SHA", job.SinceHash)
+		}
+		if len(job.Commits) != 2 {
+			t.Fatalf("Commits = %d, want 2", len(job.Commits))
+		}
+		first := job.Commits[0]
+		if first.Author != "Jordi Mallach" || first.Email != "jordi@softcatala.org" {
+			t.Errorf("first commit author = %q <%s>", first.Author, first.Email)
+		}
+		if len(first.Added) != 1 || len(first.Modified) != 1 || first.Timestamp.IsZero() {
+			t.Errorf("first commit = %+v", first)
+		}
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		resp := post(t, "wrong", payload)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+		}
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		resp := post(t, "test-secret", `{"commits": "nope"}`)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+		}
+	})
+}
+
+func TestAnalyze_RejectsInternalDestinations(t *testing.T) {
+	newApp := func(allowPrivate bool) *fiber.App {
+		processor := NewDefaultProcessor().(*AnalysisProcessor)
+		processor.AllowPrivateHosts = allowPrivate
+		server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, WebhookSecret: "test-secret", MaxWorkers: 1}, processor)
+		if err != nil {
+			t.Fatalf("NewServer() failed: %v", err)
+		}
+		return server.GetApp()
+	}
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"metadata endpoint", "/api/analyze/website", `{"url":"http://169.254.169.254/latest/meta-data/"}`},
+		{"loopback website", "/api/stream/website", `{"url":"http://127.0.0.1:8080/admin"}`},
+		{"private repository", "/api/analyze/repository", `{"repository_url":"https://10.0.0.8/org/repo.git"}`},
+		{"file repository", "/api/stream/repository", `{"repository_url":"file:///etc"}`},
+		{"internal callback", "/api/analyze/website", `{"url":"https://93.184.216.34/","callback_url":"http://localhost:9000/hook"}`},
+	}
+
+	post := func(app *fiber.App, path, body string) int {
+		req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	guarded := newApp(false)
+	for _, tt := range tests {
+		if status := post(guarded, tt.path, tt.body); status != http.StatusBadRequest {
+			t.Errorf("%s: Status = %d, want %d", tt.name, status, http.StatusBadRequest)
+		}
+	}
+
+	open := newApp(true)
+	if status := post(open, "/api/analyze/website", tests[0].body); status != http.StatusAccepted {
+		t.Errorf("with allow_private_hosts: Status = %d, want %d", status, http.StatusAccepted)
+	}
+}
+
+func TestJobResultResponse_InsufficientContent(t *testing.T) {
+	job := &WebhookJob{
+		ID:     "short-page",
+		Status: StatusCompleted,
+		Result: &JobResult{Status: StatusInsufficientContent, URL: "https://example.com", WordCount: 12},
+	}
+	if got := jobResultResponse(job).Status; got != StatusInsufficientContent {
+		t.Errorf("Status = %q, want %q", got, StatusInsufficientContent)
+	}
+
+	job.Status = StatusFailed
+	if got := jobResultResponse(job).Status; got != StatusFailed {
+		t.Errorf("Status = %q, want a failed job to stay %q", got, StatusFailed)
+	}
+}
+
+func TestRegisterRoutes_Compression(t *testing.T) {
+	newServer := func(disable bool) *Server {
+		server, err := NewServer(&ServerConfig{
+			Host:               "localhost",
+			Port:               9999,
+			WebhookSecret:      "test-secret",
+			MaxWorkers:         2,
+			DisableCompression: disable,
+		}, NewDefaultProcessor())
+		if err != nil {
+			t.Fatalf("NewServer() failed: %v", err)
+		}
+
+		headings := make([]string, 50)
+		for i := range headings {
+			headings[i] = fmt.Sprintf("Section %d of a long page", i)
+		}
+		server.handlers.queue.Track(&WebhookJob{
+			ID:     "large-job",
+			Status: StatusCompleted,
+			Result: &JobResult{JobID: "large-job", URL: "https://example.com", Headings: headings},
+		})
+
+		events := server.handlers.trackStream(&WebhookJob{ID: "stream-job", EventType: "api_analysis_website", RepoURL: "https://example.com"})
+		for range 20 {
+			_, _ = events.Append(SSEEventProgress, SSEProgressEvent{Phase: "detecting", Message: "running detection strategies"})
+		}
+		_, _ = events.Append(SSEEventResult, fiber.Map{"status": StatusCompleted})
+		events.MarkDone()
+		return server
+	}
+
+	get := func(server *Server, method, path string, header map[string]string) (*http.Response, []byte) {
+		req, _ := http.NewRequest(method, path, http.NoBody)
+		req.Header.Set("Accept-Encoding", "gzip")
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		resp, err := server.GetApp().Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		body, _ := io.ReadAll(resp.Body)
+		return resp, body
+	}
+
+	server := newServer(false)
+
+	resp, body := get(server, "GET", "/api/results/large-job", nil)
+	if got := resp.Header.Get("Content-Encoding"); got != "gzip" {
+		t.Fatalf("results Content-Encoding = %q, want gzip", got)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("gzip.NewReader: %v", err)
+	}
+	plain, _ := io.ReadAll(zr)
+	if !strings.Contains(string(plain), "Section 49 of a long page") {
+		t.Errorf("decompressed result missing headings: %q", plain)
+	}
+
+	resp, body = get(server, "POST", "/api/stream/website", map[string]string{"Last-Event-ID": "1", "X-Job-ID": "stream-job"})
+	if got := resp.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("stream Content-Encoding = %q, want none", got)
+	}
+	if !strings.Contains(string(body), "id: 21\nevent: result\n") {
+		t.Errorf("stream should be sent as plain SSE: %q", body)
+	}
+	if got := resp.Header.Get(TraceIDHeader); got != "stream-job" {
+		t.Errorf("resumed stream %s = %q, want the job ID", TraceIDHeader, got)
+	}
+
+	resp, _ = get(newServer(true), "GET", "/api/results/large-job", nil)
+	if got := resp.Header.Get("Content-Encoding"); got != "" {
+		t.Errorf("with compression disabled Content-Encoding = %q, want none", got)
+	}
+}
@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/TryCadence/Cadence/internal/monitor"
+)
+
+const (
+	JobStoreMemory = "memory"
+	JobStoreSQLite = "sqlite"
+
+	// DefaultJobStorePath is the SQLite database used when no path is configured.
+	DefaultJobStorePath = "cadence-jobs.db"
+)
+
+// ErrJobNotFound is returned by JobStore.Get for unknown job IDs.
+var ErrJobNotFound = errors.New("job not found")
+
+// JobStore persists jobs and their results for the JobQueue. The queue saves a
+// job whenever its status changes; in-flight progress updates stay in memory.
+type JobStore interface {
+	// Save inserts or replaces job.
+	Save(job *WebhookJob) error
+	// Get returns the job with the given ID or ErrJobNotFound.
+	Get(id string) (*WebhookJob, error)
+	// List returns up to limit jobs, newest first. A limit <= 0 returns all.
+	List(limit int) ([]*WebhookJob, error)
+	// Unfinished returns pending and processing jobs, oldest first, so they
+	// can be resumed after a restart.
+	Unfinished() ([]*WebhookJob, error)
+	Close() error
+}
+
+// OpenJobStore opens the backend named by kind: "memory" (the default) or
+// "sqlite", stored at path (DefaultJobStorePath if empty).
+func OpenJobStore(kind, path string) (JobStore, error) {
+	switch kind {
+	case "", JobStoreMemory:
+		return NewMemoryJobStore(), nil
+	case JobStoreSQLite:
+		if path == "" {
+			path = DefaultJobStorePath
+		}
+		return NewSQLiteJobStore(path)
+	default:
+		return nil, fmt.Errorf("unknown job store %q (want %q or %q)", kind, JobStoreMemory, JobStoreSQLite)
+	}
+}
+
+// MemoryJobStore keeps jobs, feedback and monitor states in maps. All are
+// lost when the process exits.
+type MemoryJobStore struct {
+	mu       sync.RWMutex
+	jobs     map[string]*WebhookJob
+	feedback map[feedbackKey]*Feedback
+	monitor  map[string]monitor.State
+}
+
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{
+		jobs:     make(map[string]*WebhookJob),
+		feedback: make(map[feedbackKey]*Feedback),
+		monitor:  make(map[string]monitor.State),
+	}
+}
+
+func (s *MemoryJobStore) Save(job *WebhookJob) error {
+	s.mu.Lock()
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryJobStore) Get(id string) (*WebhookJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, exists := s.jobs[id]
+	if !exists {
+		return nil, ErrJobNotFound
+	}
+	return job, nil
+}
+
+func (s *MemoryJobStore) List(limit int) ([]*WebhookJob, error) {
+	s.mu.RLock()
+	jobs := make([]*WebhookJob, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Timestamp.After(jobs[j].Timestamp)
+	})
+
+	if limit > 0 && len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	return jobs, nil
+}
+
+func (s *MemoryJobStore) Unfinished() ([]*WebhookJob, error) {
+	s.mu.RLock()
+	jobs := make([]*WebhookJob, 0)
+	for _, job := range s.jobs {
+		if job.Status == StatusPending || job.Status == StatusProcessing {
+			jobs = append(jobs, job)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.SliceStable(jobs, func(i, j int) bool {
+		return jobs[i].Timestamp.Before(jobs[j].Timestamp)
+	})
+	return jobs, nil
+}
+
+func (s *MemoryJobStore) MonitorState(url string) (*monitor.State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, ok := s.monitor[url]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (s *MemoryJobStore) SaveMonitorState(state *monitor.State) error {
+	s.mu.Lock()
+	s.monitor[state.URL] = *state
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryJobStore) Close() error {
+	return nil
+}
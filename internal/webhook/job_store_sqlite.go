@@ -0,0 +1,204 @@
+package webhook
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/monitor"
+
+	// Pure-Go SQLite driver, registered as "sqlite".
+	_ "modernc.org/sqlite"
+)
+
+const sqliteJobSchema = `
+CREATE TABLE IF NOT EXISTS jobs (
+	id         TEXT PRIMARY KEY,
+	status     TEXT NOT NULL,
+	created_at INTEGER NOT NULL,
+	data       BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS jobs_created_at ON jobs (created_at);
+CREATE INDEX IF NOT EXISTS jobs_status ON jobs (status);
+CREATE TABLE IF NOT EXISTS feedback (
+	strategy          TEXT NOT NULL,
+	source_id         TEXT NOT NULL,
+	commit_hash       TEXT NOT NULL,
+	is_false_positive INTEGER NOT NULL,
+	created_at        INTEGER NOT NULL,
+	PRIMARY KEY (strategy, source_id, commit_hash)
+);
+CREATE TABLE IF NOT EXISTS monitor_state (
+	url          TEXT PRIMARY KEY,
+	content_hash TEXT NOT NULL,
+	assessment   TEXT NOT NULL,
+	score        REAL NOT NULL,
+	checked_at   INTEGER NOT NULL,
+	changed_at   INTEGER NOT NULL
+);
+`
+
+// SQLiteJobStore persists jobs as JSON rows in a SQLite database, so job
+// history and pending work survive restarts. Detection feedback is kept in
+// the same database, as are the states of monitored websites. Recorded stream
+// events are not persisted.
+type SQLiteJobStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteJobStore opens (creating if needed) the database at path.
+func NewSQLiteJobStore(path string) (*SQLiteJobStore, error) {
+	dsn := "file:" + path + "?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job store %s: %w", path, err)
+	}
+	// SQLite allows a single writer; one connection avoids SQLITE_BUSY.
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteJobSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to initialize job store %s: %w", path, err)
+	}
+	return &SQLiteJobStore{db: db}, nil
+}
+
+func (s *SQLiteJobStore) Save(job *WebhookJob) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job %s: %w", job.ID, err)
+	}
+
+	_, err = s.db.Exec(`INSERT INTO jobs (id, status, created_at, data) VALUES (?, ?, ?, ?)
+		ON CONFLICT(id) DO UPDATE SET status = excluded.status, data = excluded.data`,
+		job.ID, job.Status, job.Timestamp.UnixNano(), data)
+	if err != nil {
+		return fmt.Errorf("failed to save job %s: %w", job.ID, err)
+	}
+	return nil
+}
+
+func (s *SQLiteJobStore) Get(id string) (*WebhookJob, error) {
+	var data []byte
+	err := s.db.QueryRow(`SELECT data FROM jobs WHERE id = ?`, id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load job %s: %w", id, err)
+	}
+	return decodeStoredJob(data)
+}
+
+func (s *SQLiteJobStore) List(limit int) ([]*WebhookJob, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite: no limit
+	}
+	return s.query(`SELECT data FROM jobs ORDER BY created_at DESC LIMIT ?`, limit)
+}
+
+func (s *SQLiteJobStore) Unfinished() ([]*WebhookJob, error) {
+	return s.query(`SELECT data FROM jobs WHERE status IN (?, ?) ORDER BY created_at ASC`,
+		StatusPending, StatusProcessing)
+}
+
+func (s *SQLiteJobStore) SaveFeedback(fb *Feedback) error {
+	_, err := s.db.Exec(`INSERT INTO feedback (strategy, source_id, commit_hash, is_false_positive, created_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(strategy, source_id, commit_hash) DO UPDATE SET
+			is_false_positive = excluded.is_false_positive, created_at = excluded.created_at`,
+		fb.Strategy, fb.SourceID, fb.CommitHash, fb.IsFalsePositive, fb.CreatedAt.UnixNano())
+	if err != nil {
+		return fmt.Errorf("failed to save feedback for %s: %w", fb.Strategy, err)
+	}
+	return nil
+}
+
+func (s *SQLiteJobStore) ListFeedback() ([]*Feedback, error) {
+	rows, err := s.db.Query(`SELECT strategy, source_id, commit_hash, is_false_positive, created_at FROM feedback`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feedback: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	entries := make([]*Feedback, 0)
+	for rows.Next() {
+		var fb Feedback
+		var createdAt int64
+		if err := rows.Scan(&fb.Strategy, &fb.SourceID, &fb.CommitHash, &fb.IsFalsePositive, &createdAt); err != nil {
+			return nil, fmt.Errorf("failed to read feedback row: %w", err)
+		}
+		fb.CreatedAt = time.Unix(0, createdAt)
+		entries = append(entries, &fb)
+	}
+	return entries, rows.Err()
+}
+
+func (s *SQLiteJobStore) MonitorState(url string) (*monitor.State, error) {
+	state := monitor.State{URL: url}
+	var checkedAt, changedAt int64
+	err := s.db.QueryRow(`SELECT content_hash, assessment, score, checked_at, changed_at FROM monitor_state WHERE url = ?`, url).
+		Scan(&state.ContentHash, &state.Assessment, &state.Score, &checkedAt, &changedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load monitor state for %s: %w", url, err)
+	}
+	state.CheckedAt = time.Unix(0, checkedAt)
+	state.ChangedAt = time.Unix(0, changedAt)
+	return &state, nil
+}
+
+func (s *SQLiteJobStore) SaveMonitorState(state *monitor.State) error {
+	_, err := s.db.Exec(`INSERT INTO monitor_state (url, content_hash, assessment, score, checked_at, changed_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(url) DO UPDATE SET
+			content_hash = excluded.content_hash, assessment = excluded.assessment, score = excluded.score,
+			checked_at = excluded.checked_at, changed_at = excluded.changed_at`,
+		state.URL, state.ContentHash, state.Assessment, state.Score, state.CheckedAt.UnixNano(), state.ChangedAt.UnixNano())
+	if err != nil {
+		return fmt.Errorf("failed to save monitor state for %s: %w", state.URL, err)
+	}
+	return nil
+}
+
+func (s *SQLiteJobStore) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLiteJobStore) query(query string, args ...any) ([]*WebhookJob, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query jobs: %w", err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	jobs := make([]*WebhookJob, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("failed to read job row: %w", err)
+		}
+		job, err := decodeStoredJob(data)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, rows.Err()
+}
+
+func decodeStoredJob(data []byte) (*WebhookJob, error) {
+	var job WebhookJob
+	if err := json.Unmarshal(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode stored job: %w", err)
+	}
+	return &job, nil
+}
@@ -0,0 +1,174 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/monitor"
+)
+
+func openTestStores(t *testing.T) map[string]JobStore {
+	t.Helper()
+	sqlite, err := NewSQLiteJobStore(filepath.Join(t.TempDir(), "jobs.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteJobStore() unexpected error = %v", err)
+	}
+	t.Cleanup(func() {
+		_ = sqlite.Close()
+	})
+	return map[string]JobStore{"memory": NewMemoryJobStore(), "sqlite": sqlite}
+}
+
+func TestJobStore_SaveGetList(t *testing.T) {
+	base := time.Now().Add(-time.Hour)
+
+	for name, store := range openTestStores(t) {
+		t.Run(name, func(t *testing.T) {
+			jobs := []*WebhookJob{
+				{ID: "old", Status: StatusCompleted, Timestamp: base, Result: &JobResult{RepoName: "repo", TotalCommits: 7}},
+				{ID: "mid", Status: StatusPending, Timestamp: base.Add(time.Minute)},
+				{ID: "new", Status: StatusProcessing, Timestamp: base.Add(2 * time.Minute), DisabledStrategies: map[string]bool{"size_analysis": true}},
+			}
+			for _, job := range jobs {
+				if err := store.Save(job); err != nil {
+					t.Fatalf("Save(%s) unexpected error = %v", job.ID, err)
+				}
+			}
+
+			got, err := store.Get("old")
+			if err != nil {
+				t.Fatalf("Get() unexpected error = %v", err)
+			}
+			if got.Result == nil || got.Result.TotalCommits != 7 || got.Status != StatusCompleted {
+				t.Errorf("Get() = %+v, want completed job with result", got)
+			}
+			if _, err := store.Get("missing"); !errors.Is(err, ErrJobNotFound) {
+				t.Errorf("Get(missing) error = %v, want ErrJobNotFound", err)
+			}
+
+			list, err := store.List(2)
+			if err != nil {
+				t.Fatalf("List() unexpected error = %v", err)
+			}
+			if len(list) != 2 || list[0].ID != "new" || list[1].ID != "mid" {
+				t.Errorf("List(2) = %v, want [new mid]", jobIDs(list))
+			}
+
+			unfinished, err := store.Unfinished()
+			if err != nil {
+				t.Fatalf("Unfinished() unexpected error = %v", err)
+			}
+			if len(unfinished) != 2 || unfinished[0].ID != "mid" || !unfinished[1].DisabledStrategies["size_analysis"] {
+				t.Errorf("Unfinished() = %v, want [mid new]", jobIDs(unfinished))
+			}
+
+			// Saving again replaces the stored job.
+			jobs[1].Status = StatusFailed
+			if err := store.Save(jobs[1]); err != nil {
+				t.Fatalf("Save() unexpected error = %v", err)
+			}
+			if unfinished, _ := store.Unfinished(); len(unfinished) != 1 {
+				t.Errorf("Unfinished() after update = %v, want [new]", jobIDs(unfinished))
+			}
+		})
+	}
+}
+
+func TestJobStore_MonitorState(t *testing.T) {
+	checked := time.Date(2024, time.May, 1, 12, 0, 0, 0, time.UTC)
+
+	for name, store := range openTestStores(t) {
+		t.Run(name, func(t *testing.T) {
+			states := store.(monitor.Store)
+			if got, err := states.MonitorState("https://example.com/"); err != nil || got != nil {
+				t.Fatalf("MonitorState() of an unknown URL = %+v, %v; want nil, nil", got, err)
+			}
+
+			state := &monitor.State{URL: "https://example.com/", ContentHash: "abc", Assessment: "Likely Human", Score: 12.5,
+				CheckedAt: checked, ChangedAt: checked}
+			if err := states.SaveMonitorState(state); err != nil {
+				t.Fatalf("SaveMonitorState() unexpected error = %v", err)
+			}
+			state.ContentHash = "def"
+			state.CheckedAt = checked.Add(time.Hour)
+			if err := states.SaveMonitorState(state); err != nil {
+				t.Fatalf("SaveMonitorState() unexpected error = %v", err)
+			}
+
+			got, err := states.MonitorState("https://example.com/")
+			if err != nil {
+				t.Fatalf("MonitorState() unexpected error = %v", err)
+			}
+			if got == nil || got.ContentHash != "def" || got.Assessment != "Likely Human" || got.Score != 12.5 ||
+				!got.CheckedAt.Equal(checked.Add(time.Hour)) || !got.ChangedAt.Equal(checked) {
+				t.Errorf("MonitorState() = %+v, want the latest saved state", got)
+			}
+		})
+	}
+}
+
+func TestOpenJobStore_UnknownBackend(t *testing.T) {
+	if _, err := OpenJobStore("redis", ""); err == nil {
+		t.Error("OpenJobStore(redis) expected error, got nil")
+	}
+}
+
+func TestJobQueue_ResumesUnfinishedJobs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jobs.db")
+	store, err := NewSQLiteJobStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteJobStore() unexpected error = %v", err)
+	}
+	defer func() {
+		_ = store.Close()
+	}()
+
+	// State left behind by a process that died mid-analysis.
+	_ = store.Save(&WebhookJob{ID: "queued", EventType: "api_analysis_repo", Status: StatusProcessing, Timestamp: time.Now()})
+	_ = store.Save(&WebhookJob{ID: "stream", EventType: "api_analysis_website", Status: StatusProcessing, Streaming: true, Timestamp: time.Now()})
+
+	processed := make(chan string, 2)
+	queue := NewJobQueue(1, processorFunc(func(ctx context.Context, job *WebhookJob) error {
+		job.Result = &JobResult{JobID: job.ID, TotalCommits: 3}
+		processed <- job.ID
+		return nil
+	}))
+	queue.SetStore(store)
+	if err := queue.Start(); err != nil {
+		t.Fatalf("Start() unexpected error = %v", err)
+	}
+
+	select {
+	case id := <-processed:
+		if id != "queued" {
+			t.Errorf("resumed job = %s, want queued", id)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("unfinished job was not resumed")
+	}
+	_ = queue.Stop()
+
+	job, err := store.Get("queued")
+	if err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if job.Status != StatusCompleted || job.Result == nil || job.Result.TotalCommits != 3 {
+		t.Errorf("resumed job = %+v, want completed with result", job)
+	}
+
+	stream, _ := store.Get("stream")
+	if stream.Status != StatusFailed {
+		t.Errorf("streaming job status = %s, want %s", stream.Status, StatusFailed)
+	}
+}
+
+func jobIDs(jobs []*WebhookJob) []string {
+	ids := make([]string, len(jobs))
+	for i, job := range jobs {
+		ids[i] = job.ID
+	}
+	return ids
+}
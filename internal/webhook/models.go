@@ -1,6 +1,10 @@
 package webhook
 
-import "time"
+import (
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/ai/skills"
+)
 
 const (
 	// Job status constants
@@ -8,6 +12,11 @@ const (
 	StatusProcessing = "processing"
 	StatusCompleted  = "completed"
 	StatusFailed     = "failed"
+	StatusCancelled  = "cancelled"
+
+	// StatusInsufficientContent is reported in place of StatusCompleted when
+	// a website had too few words to analyze.
+	StatusInsufficientContent = "insufficient_content"
 )
 
 // WebhookJob represents an analysis job triggered by a webhook event
@@ -15,15 +24,37 @@ type WebhookJob struct {
 	ID        string
 	EventType string // "push", "pull_request", etc.
 	RepoURL   string
+	LocalPath string // Existing checkout to analyze in place instead of cloning RepoURL
+	SourceKey string // Normalized identifier used to dedup equivalent submissions
 	RepoName  string
 	Branch    string
+	SinceHash string // Analyze only commits newer than this one (e.g. a push's "before" SHA)
 	Commits   []WebhookCommit
 	Author    string
 	Timestamp time.Time
-	Status    string // StatusPending, StatusProcessing, StatusCompleted, StatusFailed
+	Status    string // StatusPending, StatusProcessing, StatusCompleted, StatusFailed, StatusCancelled
 	Error     string
 	Progress  string // Current step being processed (e.g., "cloning", "analyzing", "detecting")
 	Result    *JobResult
+	Events    *EventLog `json:"-"` // SSE events sent to a streaming client; nil unless recording is enabled
+	Streaming bool      // Registered via Track by a streaming analysis rather than queued
+
+	// MaxCommits lowers the server's history cap for this job (0 = server limit).
+	MaxCommits int
+	// DisabledStrategies names strategies skipped for this job only.
+	DisabledStrategies map[string]bool
+	// MinReportConfidence overrides the server's report confidence floor
+	// for this job (0 = server setting).
+	MinReportConfidence float64
+	// IncludePassed reports the git strategies that ran without firing.
+	IncludePassed bool
+	// CallbackURL receives the JobResultResponse once the job completes or fails.
+	CallbackURL string
+	// RerunOf is the ID of the job this one re-runs (POST /jobs/:id/rerun).
+	RerunOf string
+	// SkipCache analyzes the source again instead of serving a cached
+	// report. The fresh report still replaces the cached one.
+	SkipCache bool
 }
 
 // WebhookCommit represents a commit from webhook payload
@@ -46,6 +77,9 @@ type JobResult struct {
 	SuspiciousCommits int         `json:"suspicious_commits,omitempty"`
 	Suspicions        []Suspicion `json:"suspicions,omitempty"`
 	AnalyzedAt        time.Time   `json:"analyzed_at"`
+	// Status is StatusInsufficientContent when the source was too short to
+	// analyze, and empty otherwise.
+	Status string `json:"status,omitempty"`
 	// Timing fields
 	StartedAt   time.Time `json:"started_at"`
 	CompletedAt time.Time `json:"completed_at"`
@@ -56,6 +90,9 @@ type JobResult struct {
 	UniqueAuthors     int     `json:"unique_authors,omitempty"`
 	AverageCommitSize int     `json:"average_commit_size,omitempty"`
 	OverallSuspicion  float64 `json:"overall_suspicion,omitempty"`
+	// PassedStrategies lists the git strategies that ran and fired on no
+	// commit, when the job asked for them.
+	PassedStrategies []PassedStrategy `json:"passed_strategies,omitempty"`
 	// Web-specific fields
 	WordCount       int          `json:"word_count,omitempty"`
 	CharacterCount  int          `json:"character_count,omitempty"`
@@ -77,6 +114,13 @@ type JobResult struct {
 	CoverageRate   float64 `json:"coverage_rate,omitempty"`
 }
 
+// PassedStrategy is a git strategy that ran and cleared the repository.
+type PassedStrategy struct {
+	Name        string `json:"name"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
 type WebPattern struct {
 	Type        string   `json:"type"`
 	Severity    float64  `json:"severity"`
@@ -91,7 +135,31 @@ type Suspicion struct {
 	Message     string   `json:"message"`
 	Severity    string   `json:"severity"`
 	Reasons     []string `json:"reasons"`
-	Score       float64  `json:"score"`
+	// Strategies names the strategies that fired, for POST /api/feedback.
+	Strategies []string `json:"strategies,omitempty"`
+	Score      float64  `json:"score"`
+	// LowConfidence marks a suspicion resting on a statistical baseline too
+	// small to trust; Caveat says why.
+	LowConfidence bool   `json:"low_confidence,omitempty"`
+	Caveat        string `json:"caveat,omitempty"`
+	// Triage is the AI's second opinion on a high-severity suspicion, set
+	// when the server runs with AI enabled.
+	Triage *skills.DetectionTriageResult `json:"triage,omitempty"`
+}
+
+// PushCommit is one commit of a GitHub or GitLab push event; both send the
+// same fields.
+type PushCommit struct {
+	ID        string `json:"id"`
+	Message   string `json:"message"`
+	Timestamp string `json:"timestamp"`
+	Author    struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+	Added    []string `json:"added"`
+	Modified []string `json:"modified"`
+	Removed  []string `json:"removed"`
 }
 
 type GithubPushPayload struct {
@@ -108,16 +176,22 @@ type GithubPushPayload struct {
 		Name  string `json:"name"`
 		Email string `json:"email"`
 	} `json:"pusher"`
-	Commits []struct {
-		ID        string `json:"id"`
-		Message   string `json:"message"`
-		Timestamp string `json:"timestamp"`
-		Author    struct {
-			Name  string `json:"name"`
-			Email string `json:"email"`
-		} `json:"author"`
-		Added    []string `json:"added"`
-		Modified []string `json:"modified"`
-		Removed  []string `json:"removed"`
-	} `json:"commits"`
+	Commits []PushCommit `json:"commits"`
+}
+
+// GitlabPushPayload is the subset of a GitLab "Push Hook" event used to queue an analysis.
+type GitlabPushPayload struct {
+	ObjectKind string `json:"object_kind"`
+	Ref        string `json:"ref"`
+	Before     string `json:"before"`
+	After      string `json:"after"`
+	UserName   string `json:"user_name"`
+	UserEmail  string `json:"user_email"`
+	Project    struct {
+		ID                int    `json:"id"`
+		Name              string `json:"name"`
+		PathWithNamespace string `json:"path_with_namespace"`
+		GitHTTPURL        string `json:"git_http_url"`
+	} `json:"project"`
+	Commits []PushCommit `json:"commits"`
 }
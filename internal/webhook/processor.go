@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -14,19 +15,44 @@ type JobQueue struct {
 	jobs       chan *WebhookJob
 	maxWorkers int
 	workers    int
+	busy       int // Workers currently running a job, guarded by mu
+	closing    bool
+	closeOnce  sync.Once
+	sending    sync.WaitGroup // Enqueue calls between the closing check and the send
 	wg         sync.WaitGroup
 	ctx        context.Context
 	cancel     context.CancelFunc
 	processor  JobProcessor
 	mu         sync.RWMutex
-	jobStore   map[string]*WebhookJob
+	store      JobStore
+	active     map[string]*WebhookJob // Unfinished jobs of this process, updated in place
+	sources    map[string]*WebhookJob // Latest unfinished job per event type and SourceKey
+	dedup      bool
 	logger     *logging.Logger
+	callbacks  *CallbackNotifier
 }
 
+// ErrQueueClosed is returned by Enqueue once the queue is shutting down.
+var ErrQueueClosed = errors.New("job queue is shutting down")
+
 type JobProcessor interface {
 	Process(ctx context.Context, job *WebhookJob) error
 }
 
+// DefaultJobTimeout bounds one Process call for processors that do not
+// report their own JobTimeout.
+const DefaultJobTimeout = 5 * time.Minute
+
+// jobTimeout returns the processor's JobTimeout, if it has one.
+func jobTimeout(processor JobProcessor) time.Duration {
+	if p, ok := processor.(interface{ JobTimeout() time.Duration }); ok {
+		if d := p.JobTimeout(); d > 0 {
+			return d
+		}
+	}
+	return DefaultJobTimeout
+}
+
 func NewJobQueue(maxWorkers int, processor JobProcessor) *JobQueue {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &JobQueue{
@@ -36,79 +62,403 @@ func NewJobQueue(maxWorkers int, processor JobProcessor) *JobQueue {
 		ctx:        ctx,
 		cancel:     cancel,
 		processor:  processor,
-		jobStore:   make(map[string]*WebhookJob),
+		store:      NewMemoryJobStore(),
+		active:     make(map[string]*WebhookJob),
+		sources:    make(map[string]*WebhookJob),
+		dedup:      true,
 		logger:     logging.Default().With("component", "job_queue"),
 	}
 }
 
+// SetLogger sets the logger of the queue's workers.
+func (q *JobQueue) SetLogger(l *logging.Logger) {
+	q.logger = l
+}
+
+// SetCallbackNotifier sets how result callbacks are delivered for jobs with a
+// CallbackURL. Without one, callback URLs are ignored.
+func (q *JobQueue) SetCallbackNotifier(n *CallbackNotifier) {
+	q.callbacks = n
+}
+
+// SetDedup turns deduplication of equivalent jobs in Enqueue on or off.
+// It is on by default.
+func (q *JobQueue) SetDedup(enabled bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.dedup = enabled
+}
+
+// SetStore replaces the in-memory job store. Call it before Start.
+func (q *JobQueue) SetStore(store JobStore) {
+	if store != nil {
+		q.store = store
+	}
+}
+
 func (q *JobQueue) Start() error {
 	for i := 0; i < q.maxWorkers; i++ {
 		q.wg.Add(1)
 		go q.worker()
+		q.mu.Lock()
 		q.workers++
+		q.mu.Unlock()
 	}
-	return nil
+	return q.resume()
+}
+
+// QueueStats is a point-in-time view of the queue's load.
+type QueueStats struct {
+	Depth       int  `json:"depth"`    // Jobs waiting for a worker
+	Capacity    int  `json:"capacity"` // Jobs that can wait before Enqueue blocks
+	Workers     int  `json:"workers"`
+	BusyWorkers int  `json:"busy_workers"`
+	IdleWorkers int  `json:"idle_workers"`
+	Accepting   bool `json:"accepting"` // Started and not shutting down
+}
+
+// Depth returns the number of jobs waiting for a worker.
+func (q *JobQueue) Depth() int {
+	return len(q.jobs)
+}
+
+// Capacity returns how many jobs can wait before Enqueue blocks.
+func (q *JobQueue) Capacity() int {
+	return cap(q.jobs)
+}
+
+// BusyWorkers returns the number of workers currently running a job.
+func (q *JobQueue) BusyWorkers() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.busy
+}
+
+// Stats returns the queue depth and worker utilization.
+func (q *JobQueue) Stats() QueueStats {
+	q.mu.RLock()
+	workers, busy := q.workers, q.busy
+	q.mu.RUnlock()
+
+	return QueueStats{
+		Depth:       q.Depth(),
+		Capacity:    q.Capacity(),
+		Workers:     workers,
+		BusyWorkers: busy,
+		IdleWorkers: workers - busy,
+		Accepting:   workers > 0 && !q.isClosing(),
+	}
+}
+
+func (q *JobQueue) isClosing() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	return q.closing || q.ctx.Err() != nil
 }
 
+// Stop cancels running jobs and stops the workers at once. Interrupted jobs
+// are left pending so a persistent store resumes them on the next start.
 func (q *JobQueue) Stop() error {
 	q.cancel()
-	close(q.jobs)
+	q.closeJobs()
 	q.wg.Wait()
 	return nil
 }
 
+// Shutdown stops accepting jobs, marks jobs still waiting in the queue as
+// cancelled and waits for running jobs to finish. If ctx expires first the
+// running jobs are interrupted as by Stop, and ctx's error is returned once
+// the workers have exited.
+func (q *JobQueue) Shutdown(ctx context.Context) error {
+	q.mu.Lock()
+	q.closing = true
+	q.mu.Unlock()
+
+	// Enqueue calls that passed the closing check may be blocked on a full
+	// queue; keep draining until they have all sent.
+	sent := make(chan struct{})
+	go func() {
+		q.sending.Wait()
+		close(sent)
+	}()
+	for drained := false; !drained; {
+		select {
+		case job := <-q.jobs:
+			q.cancelQueued(job)
+		case <-sent:
+			drained = true
+		}
+	}
+	for drained := false; !drained; {
+		select {
+		case job := <-q.jobs:
+			q.cancelQueued(job)
+		default:
+			drained = true
+		}
+	}
+	q.closeJobs()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		q.cancel()
+		return nil
+	case <-ctx.Done():
+		q.logger.Warn("drain timeout expired, interrupting running jobs", "busy_workers", q.BusyWorkers())
+		q.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// closeJobs closes the job channel once no Enqueue can send on it.
+func (q *JobQueue) closeJobs() {
+	q.mu.Lock()
+	q.closing = true
+	q.mu.Unlock()
+	q.sending.Wait()
+	q.closeOnce.Do(func() { close(q.jobs) })
+}
+
+// cancelQueued marks a job that never reached a worker as cancelled.
+func (q *JobQueue) cancelQueued(job *WebhookJob) {
+	if job == nil {
+		return
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job.Status = StatusCancelled
+	job.Error = "cancelled by server shutdown"
+	q.save(job)
+	q.untrack(job)
+	q.logger.Info("cancelled queued job", "job_id", job.ID)
+}
+
+// resume re-enqueues jobs a previous process left pending or processing.
+// Streaming jobs cannot be resumed without their client and are marked failed.
+func (q *JobQueue) resume() error {
+	jobs, err := q.store.Unfinished()
+	if err != nil {
+		return fmt.Errorf("failed to load unfinished jobs: %w", err)
+	}
+
+	for _, job := range jobs {
+		q.mu.Lock()
+		if _, running := q.active[job.ID]; running {
+			q.mu.Unlock()
+			continue
+		}
+		if job.Streaming {
+			job.Status = StatusFailed
+			job.Error = "interrupted by server restart"
+			q.save(job)
+			q.mu.Unlock()
+			continue
+		}
+		job.Status = StatusPending
+		job.Progress = ""
+		q.active[job.ID] = job
+		q.indexSource(job)
+		q.save(job)
+		q.mu.Unlock()
+
+		q.logger.Info("resuming job", "job_id", job.ID, "event_type", job.EventType)
+		select {
+		case q.jobs <- job:
+		case <-q.ctx.Done():
+			return fmt.Errorf("job queue is shutting down")
+		}
+	}
+	return nil
+}
+
+// Enqueue queues job for a worker. If an equivalent job (same EventType and
+// SourceKey) is already pending, or running from the same SinceHash, job is
+// not queued: its ID, Status and Timestamp are set to that job's instead.
+// Jobs with a CallbackURL are always queued, since a job delivers a single
+// callback.
 func (q *JobQueue) Enqueue(job *WebhookJob) error {
+	q.mu.Lock()
+	if q.closing {
+		q.mu.Unlock()
+		return ErrQueueClosed
+	}
+	if existing := q.duplicateOf(job); existing != nil {
+		job.ID = existing.ID
+		job.Status = existing.Status
+		job.Timestamp = existing.Timestamp
+		q.mu.Unlock()
+		q.logger.Info("reusing equivalent job", "job_id", job.ID, "event_type", job.EventType)
+		return nil
+	}
 	if job.ID == "" {
 		job.ID = uuid.New().String()
 	}
 	job.Status = StatusPending
 	job.Timestamp = time.Now()
-
-	q.mu.Lock()
-	q.jobStore[job.ID] = job
+	if err := q.store.Save(job); err != nil {
+		q.mu.Unlock()
+		return err
+	}
+	q.active[job.ID] = job
+	q.indexSource(job)
+	q.sending.Add(1)
 	q.mu.Unlock()
+	defer q.sending.Done()
 
 	select {
 	case q.jobs <- job:
 		return nil
 	case <-q.ctx.Done():
-		return fmt.Errorf("job queue is shutting down")
+		return ErrQueueClosed
 	}
 }
 
-func (q *JobQueue) GetJob(jobID string) (*WebhookJob, error) {
+// Track registers a job in the job store without queueing it for a worker.
+// Streaming analyses use this so their status and event log are visible via /jobs.
+func (q *JobQueue) Track(job *WebhookJob) {
+	if job.ID == "" {
+		job.ID = uuid.New().String()
+	}
+	if job.Timestamp.IsZero() {
+		job.Timestamp = time.Now()
+	}
+	job.Streaming = true
+
+	q.mu.Lock()
+	q.active[job.ID] = job
+	q.save(job)
+	q.mu.Unlock()
+}
+
+// SetStatus updates the status and error message of a tracked job.
+func (q *JobQueue) SetStatus(jobID, status, errMsg string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if job, exists := q.active[jobID]; exists {
+		job.Status = status
+		job.Error = errMsg
+		q.save(job)
+		if status == StatusCompleted || status == StatusFailed || status == StatusCancelled {
+			q.untrack(job)
+		}
+	}
+}
+
+// FindActive returns the latest pending or processing job of the given event
+// type whose SourceKey matches, or nil if there is none.
+func (q *JobQueue) FindActive(eventType, sourceKey string) *WebhookJob {
+	if sourceKey == "" {
+		return nil
+	}
+
 	q.mu.RLock()
 	defer q.mu.RUnlock()
+	return q.sources[sourceIndexKey(eventType, sourceKey)]
+}
+
+// duplicateOf returns the unfinished job that job can share, or nil. A
+// running job is shared only if it started from the same SinceHash; a
+// pending one has not read the history yet and covers newer pushes too.
+// Reruns and jobs with a callback are never shared. Callers hold q.mu.
+func (q *JobQueue) duplicateOf(job *WebhookJob) *WebhookJob {
+	if !q.dedup || job.SourceKey == "" || job.CallbackURL != "" || job.RerunOf != "" {
+		return nil
+	}
+	existing := q.sources[sourceIndexKey(job.EventType, job.SourceKey)]
+	if existing == nil {
+		return nil
+	}
+	if existing.Status == StatusPending || existing.SinceHash == job.SinceHash {
+		return existing
+	}
+	return nil
+}
+
+// indexSource makes job the one later equivalent jobs are deduplicated
+// against. Callers hold q.mu.
+func (q *JobQueue) indexSource(job *WebhookJob) {
+	if job.SourceKey != "" {
+		q.sources[sourceIndexKey(job.EventType, job.SourceKey)] = job
+	}
+}
+
+// untrack drops a finished job from the active jobs and the source index.
+// Callers hold q.mu.
+func (q *JobQueue) untrack(job *WebhookJob) {
+	delete(q.active, job.ID)
+	key := sourceIndexKey(job.EventType, job.SourceKey)
+	if q.sources[key] == job {
+		delete(q.sources, key)
+	}
+}
+
+func sourceIndexKey(eventType, sourceKey string) string {
+	return eventType + "\x00" + sourceKey
+}
+
+// GetJob returns a running job as it is being updated, or reads a finished one
+// through the store.
+func (q *JobQueue) GetJob(jobID string) (*WebhookJob, error) {
+	q.mu.RLock()
+	job, exists := q.active[jobID]
+	q.mu.RUnlock()
+	if exists {
+		return job, nil
+	}
 
-	job, exists := q.jobStore[jobID]
-	if !exists {
+	job, err := q.store.Get(jobID)
+	if errors.Is(err, ErrJobNotFound) {
 		return nil, fmt.Errorf("job not found: %s", jobID)
 	}
+	if err != nil {
+		return nil, err
+	}
 	return job, nil
 }
 
+// ListJobs returns up to limit jobs from the store, newest first, with running
+// jobs replaced by their live state.
 func (q *JobQueue) ListJobs(limit int) []*WebhookJob {
-	q.mu.RLock()
-	defer q.mu.RUnlock()
-
-	jobs := make([]*WebhookJob, 0, len(q.jobStore))
-	for _, job := range q.jobStore {
-		jobs = append(jobs, job)
+	jobs, err := q.store.List(limit)
+	if err != nil {
+		q.logger.Error("failed to list jobs", "error", err)
+		return []*WebhookJob{}
 	}
 
-	for i := 0; i < len(jobs)-1; i++ {
-		for j := i + 1; j < len(jobs); j++ {
-			if jobs[j].Timestamp.After(jobs[i].Timestamp) {
-				jobs[i], jobs[j] = jobs[j], jobs[i]
-			}
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	for i, job := range jobs {
+		if live, ok := q.active[job.ID]; ok {
+			jobs[i] = live
 		}
 	}
+	return jobs
+}
 
-	if limit > 0 && len(jobs) > limit {
-		jobs = jobs[:limit]
+// save persists job, logging failures so a store outage does not fail the
+// analysis itself. Callers hold q.mu.
+func (q *JobQueue) save(job *WebhookJob) {
+	if err := q.store.Save(job); err != nil {
+		q.logger.Error("failed to persist job", "job_id", job.ID, "error", err)
 	}
+}
 
-	return jobs
+// notify delivers a result callback in the background so retries do not hold
+// up the worker. Stop cancels pending retries and waits for deliveries.
+func (q *JobQueue) notify(callbackURL string, response JobResultResponse) {
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		_ = q.callbacks.Notify(q.ctx, callbackURL, response)
+	}()
 }
 
 func (q *JobQueue) worker() {
@@ -122,26 +472,64 @@ func (q *JobQueue) worker() {
 			}
 
 			q.mu.Lock()
+			if q.ctx.Err() != nil {
+				// Stopped: leave the job pending for the next start.
+				q.mu.Unlock()
+				continue
+			}
+			if q.closing {
+				// Shutdown began after this job was queued: don't start it.
+				q.mu.Unlock()
+				q.cancelQueued(job)
+				continue
+			}
 			job.Status = StatusProcessing
+			q.save(job)
+			q.busy++
 			q.mu.Unlock()
 
-			q.logger.Info("processing job", "job_id", job.ID, "event_type", job.EventType)
+			// A job's ID is the trace ID of the request that created it.
+			traceCtx := logging.ContextWithTraceID(q.ctx, job.ID)
+			log := q.logger.WithContext(traceCtx)
+			log.Info("processing job", "job_id", job.ID, "event_type", job.EventType)
 
-			ctx, cancel := context.WithTimeout(q.ctx, 5*time.Minute)
+			ctx, cancel := context.WithTimeout(traceCtx, jobTimeout(q.processor))
 			err := q.processor.Process(ctx, job)
 			cancel()
 
 			q.mu.Lock()
+			q.busy--
+			if err != nil && q.ctx.Err() != nil {
+				// Interrupted by Stop: leave the job pending so a persistent
+				// store resumes it on the next start.
+				log.Info("job interrupted by shutdown", "job_id", job.ID)
+				job.Status = StatusPending
+				job.Progress = ""
+				q.save(job)
+				q.mu.Unlock()
+				continue
+			}
 			if err != nil {
-				q.logger.Error("job failed", "job_id", job.ID, "error", err)
+				log.Error("job failed", "job_id", job.ID, "error", err)
 				job.Status = StatusFailed
 				job.Error = err.Error()
 			} else {
-				q.logger.Info("job completed", "job_id", job.ID)
+				log.Info("job completed", "job_id", job.ID)
 				job.Status = StatusCompleted
 			}
+			q.save(job)
+			q.untrack(job)
+			var response JobResultResponse
+			notify := job.CallbackURL != "" && q.callbacks != nil
+			if notify {
+				response = jobResultResponse(job)
+			}
 			q.mu.Unlock()
 
+			if notify {
+				q.notify(job.CallbackURL, response)
+			}
+
 		case <-q.ctx.Done():
 			return
 		}
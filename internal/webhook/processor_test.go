@@ -2,6 +2,8 @@ package webhook
 
 import (
 	"context"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -128,7 +130,6 @@ func TestJobQueue_StartStop(t *testing.T) {
 	})
 
 	t.Run("enqueue after stop returns error", func(t *testing.T) {
-		t.Skip("skipping - closes channel before test")
 		processor2 := NewDefaultProcessor()
 		queue2 := NewJobQueue(2, processor2)
 		queue2.Start()
@@ -180,3 +181,256 @@ func TestAnalysisProcessor_Process(t *testing.T) {
 		}
 	})
 }
+
+func TestJobTimeout(t *testing.T) {
+	ap := &AnalysisProcessor{Clone: CloneOptions{Timeout: time.Minute}, AnalysisTimeout: 10 * time.Minute}
+	if got := jobTimeout(ap); got != 11*time.Minute {
+		t.Errorf("jobTimeout(AnalysisProcessor) = %v, want clone plus analysis timeout (11m)", got)
+	}
+	if got := jobTimeout(NewDefaultProcessor()); got != DefaultCloneTimeout+DefaultAnalysisTimeout {
+		t.Errorf("jobTimeout(default processor) = %v, want %v", got, DefaultCloneTimeout+DefaultAnalysisTimeout)
+	}
+	other := processorFunc(func(ctx context.Context, job *WebhookJob) error { return nil })
+	if got := jobTimeout(other); got != DefaultJobTimeout {
+		t.Errorf("jobTimeout(other processor) = %v, want %v", got, DefaultJobTimeout)
+	}
+}
+
+func TestJobQueue_FindActive(t *testing.T) {
+	queue := NewJobQueue(1, NewDefaultProcessor())
+
+	job := &WebhookJob{
+		EventType: "api_analysis_website",
+		RepoURL:   "https://example.com",
+		SourceKey: "example.com/",
+	}
+	if err := queue.Enqueue(job); err != nil {
+		t.Fatalf("Enqueue() failed: %v", err)
+	}
+
+	if got := queue.FindActive("api_analysis_website", "example.com/"); got == nil || got.ID != job.ID {
+		t.Errorf("FindActive() = %v, want job %s", got, job.ID)
+	}
+	if got := queue.FindActive("api_analysis_repo", "example.com/"); got != nil {
+		t.Error("FindActive() should not match a different event type")
+	}
+
+	queue.SetStatus(job.ID, StatusCompleted, "")
+	if got := queue.FindActive("api_analysis_website", "example.com/"); got != nil {
+		t.Error("FindActive() should ignore completed jobs")
+	}
+}
+
+func TestJobQueue_EnqueueDedup(t *testing.T) {
+	var runs atomic.Int32
+	release := make(chan struct{})
+	started := make(chan struct{}, 4)
+	queue := NewJobQueue(1, processorFunc(func(ctx context.Context, job *WebhookJob) error {
+		runs.Add(1)
+		started <- struct{}{}
+		<-release
+		return nil
+	}))
+
+	newJob := func(since string) *WebhookJob {
+		return &WebhookJob{EventType: "github_push", RepoURL: "https://github.com/o/r", SourceKey: "github.com/o/r@main", SinceHash: since}
+	}
+	first, second := newJob("a1"), newJob("a1")
+	if err := queue.Enqueue(first); err != nil {
+		t.Fatalf("Enqueue() failed: %v", err)
+	}
+	if err := queue.Enqueue(second); err != nil {
+		t.Fatalf("Enqueue() failed: %v", err)
+	}
+	if second.ID != first.ID || second.Status != StatusPending {
+		t.Fatalf("second job = %s (%s), want the pending job %s", second.ID, second.Status, first.ID)
+	}
+	if err := queue.Start(); err != nil {
+		t.Fatalf("Start() failed: %v", err)
+	}
+	defer func() { _ = queue.Stop() }()
+	<-started
+
+	// The running job has read the history from a1, so a later push needs
+	// its own job; a push after that joins the new pending one.
+	third, fourth := newJob("b2"), newJob("c3")
+	for _, job := range []*WebhookJob{third, fourth} {
+		if err := queue.Enqueue(job); err != nil {
+			t.Fatalf("Enqueue() failed: %v", err)
+		}
+	}
+	if third.ID == first.ID || fourth.ID != third.ID {
+		t.Errorf("job IDs = %s, %s, want a new job shared by both later pushes (running %s)", third.ID, fourth.ID, first.ID)
+	}
+
+	withCallback := newJob("c3")
+	withCallback.CallbackURL = "https://example.com/hook"
+	if err := queue.Enqueue(withCallback); err != nil {
+		t.Fatalf("Enqueue() failed: %v", err)
+	}
+	if withCallback.ID == third.ID {
+		t.Error("a job with a callback should not be deduplicated")
+	}
+
+	close(release)
+	deadline := time.Now().Add(2 * time.Second)
+	// The callback job is queued last, so once it leaves the index every
+	// job has finished.
+	for queue.FindActive("github_push", "github.com/o/r@main") != nil {
+		if time.Now().After(deadline) {
+			t.Fatalf("jobs did not finish: %+v", queue.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := runs.Load(); got != 3 {
+		t.Errorf("processor ran %d times, want 3 for 6 submissions", got)
+	}
+}
+
+func TestJobQueue_EnqueueDedupDisabled(t *testing.T) {
+	queue := NewJobQueue(1, NewDefaultProcessor())
+	queue.SetDedup(false)
+
+	first := &WebhookJob{EventType: "api_analysis_website", SourceKey: "example.com/"}
+	second := &WebhookJob{EventType: "api_analysis_website", SourceKey: "example.com/"}
+	for _, job := range []*WebhookJob{first, second} {
+		if err := queue.Enqueue(job); err != nil {
+			t.Fatalf("Enqueue() failed: %v", err)
+		}
+	}
+	if first.ID == second.ID {
+		t.Error("identical jobs should each be queued with dedup disabled")
+	}
+}
+
+func TestJobQueue_Stats(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{}, 4)
+	queue := NewJobQueue(2, processorFunc(func(ctx context.Context, job *WebhookJob) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}))
+
+	if stats := queue.Stats(); stats.Accepting || stats.Workers != 0 || stats.Capacity != 100 {
+		t.Errorf("Stats() before Start = %+v, want not accepting, no workers, capacity 100", stats)
+	}
+
+	if err := queue.Start(); err != nil {
+		t.Fatalf("Start() unexpected error = %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := queue.Enqueue(&WebhookJob{EventType: "github_push"}); err != nil {
+			t.Fatalf("Enqueue() unexpected error = %v", err)
+		}
+	}
+	<-started
+	<-started
+
+	stats := queue.Stats()
+	if !stats.Accepting || stats.Workers != 2 || stats.BusyWorkers != 2 || stats.IdleWorkers != 0 || stats.Depth != 1 {
+		t.Errorf("Stats() under load = %+v, want 2 busy workers and 1 waiting job", stats)
+	}
+
+	close(release)
+	deadline := time.Now().Add(2 * time.Second)
+	for queue.BusyWorkers() > 0 || queue.Depth() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("queue did not drain: %+v", queue.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := queue.Stop(); err != nil {
+		t.Fatalf("Stop() unexpected error = %v", err)
+	}
+	if queue.Stats().Accepting {
+		t.Error("Stats().Accepting = true after Stop, want false")
+	}
+}
+
+func TestJobQueue_Shutdown(t *testing.T) {
+	t.Run("drains running jobs and cancels queued ones", func(t *testing.T) {
+		release := make(chan struct{})
+		started := make(chan struct{}, 4)
+		queue := NewJobQueue(1, processorFunc(func(ctx context.Context, job *WebhookJob) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}))
+		if err := queue.Start(); err != nil {
+			t.Fatalf("Start() unexpected error = %v", err)
+		}
+
+		jobs := make([]*WebhookJob, 3)
+		for i := range jobs {
+			jobs[i] = &WebhookJob{EventType: "github_push"}
+			if err := queue.Enqueue(jobs[i]); err != nil {
+				t.Fatalf("Enqueue() unexpected error = %v", err)
+			}
+		}
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownErr := make(chan error, 1)
+		go func() { shutdownErr <- queue.Shutdown(ctx) }()
+
+		deadline := time.Now().Add(2 * time.Second)
+		for queue.Stats().Accepting {
+			if time.Now().After(deadline) {
+				t.Fatal("queue still accepting jobs after Shutdown")
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		if err := queue.Enqueue(&WebhookJob{EventType: "github_push"}); !errors.Is(err, ErrQueueClosed) {
+			t.Errorf("Enqueue() during shutdown error = %v, want ErrQueueClosed", err)
+		}
+
+		close(release)
+		if err := <-shutdownErr; err != nil {
+			t.Fatalf("Shutdown() unexpected error = %v", err)
+		}
+
+		want := []string{StatusCompleted, StatusCancelled, StatusCancelled}
+		for i, job := range jobs {
+			got, err := queue.GetJob(job.ID)
+			if err != nil {
+				t.Fatalf("GetJob(%d) unexpected error = %v", i, err)
+			}
+			if got.Status != want[i] {
+				t.Errorf("job %d status = %s, want %s", i, got.Status, want[i])
+			}
+		}
+	})
+
+	t.Run("drain timeout interrupts running jobs", func(t *testing.T) {
+		queue := NewJobQueue(1, processorFunc(func(ctx context.Context, job *WebhookJob) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}))
+		if err := queue.Start(); err != nil {
+			t.Fatalf("Start() unexpected error = %v", err)
+		}
+		job := &WebhookJob{EventType: "github_push"}
+		if err := queue.Enqueue(job); err != nil {
+			t.Fatalf("Enqueue() unexpected error = %v", err)
+		}
+		for queue.BusyWorkers() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := queue.Shutdown(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Shutdown() error = %v, want context.DeadlineExceeded", err)
+		}
+		got, err := queue.GetJob(job.ID)
+		if err != nil {
+			t.Fatalf("GetJob() unexpected error = %v", err)
+		}
+		if got.Status != StatusPending {
+			t.Errorf("interrupted job status = %s, want %s so it can be resumed", got.Status, StatusPending)
+		}
+	})
+}
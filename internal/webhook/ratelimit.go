@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// rateLimitSweepInterval is how often idle buckets are dropped.
+const rateLimitSweepInterval = time.Minute
+
+// RateLimiter is a per-key token bucket. Each key (a client IP) may make
+// burst requests at once and regains requestsPerMinute tokens per minute.
+type RateLimiter struct {
+	mu        sync.Mutex
+	perSecond float64
+	burst     float64
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter returns a limiter, or nil (no limiting) when
+// requestsPerMinute is not positive. A burst below 1 defaults to 1.
+func NewRateLimiter(requestsPerMinute, burst int) *RateLimiter {
+	if requestsPerMinute <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		perSecond: float64(requestsPerMinute) / 60,
+		burst:     float64(burst),
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// Allow takes a token for key. When none is left it returns false and how
+// long until the next token is available.
+func (l *RateLimiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	} else {
+		b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.perSecond)
+		b.last = now
+	}
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	wait := time.Duration((1 - b.tokens) / l.perSecond * float64(time.Second))
+	return false, wait
+}
+
+// sweep drops buckets that have refilled completely, since they are
+// indistinguishable from new ones. Callers hold l.mu.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	l.lastSweep = now
+	full := time.Duration(l.burst / l.perSecond * float64(time.Second))
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= full {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// throttle limits requests per client IP, answering 429 with Retry-After once
+// a client's bucket is empty. It passes everything through without a limiter.
+func (wh *WebhookHandlers) throttle(c *fiber.Ctx) error {
+	if wh.limiter == nil {
+		return c.Next()
+	}
+
+	allowed, wait := wh.limiter.Allow(c.IP())
+	if allowed {
+		return c.Next()
+	}
+
+	wh.metrics.RecordThrottled(c.Route().Path)
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+	return c.Status(http.StatusTooManyRequests).JSON(fiber.Map{
+		"error": "rate limit exceeded",
+	})
+}
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	l := NewRateLimiter(60, 2) // one token per second
+	now := time.Unix(1_700_000_000, 0)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		if ok, _ := l.Allow("1.2.3.4"); !ok {
+			t.Fatalf("request %d within burst was rejected", i+1)
+		}
+	}
+
+	ok, wait := l.Allow("1.2.3.4")
+	if ok {
+		t.Fatal("request beyond burst was allowed")
+	}
+	if wait <= 0 || wait > time.Second {
+		t.Errorf("retry after = %v, want (0, 1s]", wait)
+	}
+
+	if ok, _ := l.Allow("5.6.7.8"); !ok {
+		t.Error("other clients should have their own bucket")
+	}
+
+	now = now.Add(time.Second)
+	if ok, _ := l.Allow("1.2.3.4"); !ok {
+		t.Error("token should be refilled after one second")
+	}
+}
+
+func TestNewRateLimiter_DisabledWithoutRate(t *testing.T) {
+	if l := NewRateLimiter(0, 10); l != nil {
+		t.Errorf("NewRateLimiter(0, 10) = %v, want nil", l)
+	}
+}
+
+func TestThrottle_PublicRoutes(t *testing.T) {
+	server, err := NewServer(&ServerConfig{
+		Host: "localhost", Port: 9999, WebhookSecret: "test-secret", MaxWorkers: 1,
+		RateLimitPerMinute: 1, RateLimitBurst: 1,
+	}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	app := server.GetApp()
+
+	post := func(path string) *http.Response {
+		req, _ := http.NewRequest("POST", path, strings.NewReader(`{}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	if resp := post("/api/analyze/website"); resp.StatusCode == http.StatusTooManyRequests {
+		t.Fatal("first request should not be throttled")
+	}
+	resp := post("/api/analyze/repository")
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("Status = %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if got := resp.Header.Get("Retry-After"); got == "" || got == "0" {
+		t.Errorf("Retry-After = %q, want a positive number of seconds", got)
+	}
+
+	// Webhooks are exempt: they fail signature checks rather than being throttled.
+	if resp := post("/webhooks/github"); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("webhook Status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+
+	if got := server.Metrics.Snapshot().ThrottledByRoute["/api/analyze/repository"]; got != 1 {
+		t.Errorf("throttled repository requests = %d, want 1", got)
+	}
+}
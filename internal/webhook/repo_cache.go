@@ -0,0 +1,234 @@
+package webhook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// RepoCache keeps cloned repositories in a directory so later analyses of
+// the same repository fetch what changed and check out the target branch
+// instead of cloning from scratch. Each repository is locked while it is
+// updated and analyzed. When the cache grows beyond its size limit, the
+// least recently used repositories not in use are removed.
+type RepoCache struct {
+	dir      string
+	maxBytes int64
+
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+	inUse map[string]int
+}
+
+// NewRepoCache creates the cache directory if needed. maxBytes bounds the
+// cache's size on disk (0 = no limit).
+func NewRepoCache(dir string, maxBytes int64) (*RepoCache, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create repository cache directory: %w", err)
+	}
+	return &RepoCache{
+		dir:      dir,
+		maxBytes: maxBytes,
+		locks:    make(map[string]chan struct{}),
+		inUse:    make(map[string]int),
+	}, nil
+}
+
+// repoDir returns the cache directory of the repository at url.
+func (c *RepoCache) repoDir(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:16]))
+}
+
+// lock waits until no other analysis uses the repository at url, or ctx
+// ends. It returns the repository's directory and the function unlocking it.
+func (c *RepoCache) lock(ctx context.Context, url string) (string, func(), error) {
+	dir := c.repoDir(url)
+
+	c.mu.Lock()
+	l, ok := c.locks[dir]
+	if !ok {
+		l = make(chan struct{}, 1)
+		c.locks[dir] = l
+	}
+	c.inUse[dir]++
+	c.mu.Unlock()
+
+	done := func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if c.inUse[dir]--; c.inUse[dir] == 0 {
+			delete(c.inUse, dir)
+		}
+	}
+
+	select {
+	case l <- struct{}{}:
+	case <-ctx.Done():
+		done()
+		return "", nil, ctx.Err()
+	}
+	now := time.Now()
+	_ = os.Chtimes(dir, now, now)
+	return dir, func() {
+		<-l
+		done()
+	}, nil
+}
+
+// update brings the cached clone in dir up to date with branch (or the
+// default branch), cloning it first if it is missing or unusable. Like
+// cloneRepo, an unknown branch falls back to the default branch.
+func (c *RepoCache) update(ctx context.Context, dir, url, branch string, opts CloneOptions) error {
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		// First use, or a clone cut short: start over.
+		_ = os.RemoveAll(dir)
+		return cloneRepo(ctx, url, dir, branch, opts)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout())
+	defer cancel()
+
+	target := branch
+	if target == "" {
+		if target, err = defaultBranch(ctx, repo); err != nil {
+			return err
+		}
+	}
+	err = fetchBranch(ctx, repo, target, opts.Depth)
+	if err != nil && branch != "" && isMissingBranch(err) {
+		// Drop a stale local copy of the branch so the analysis reads the
+		// default branch, as it would after a fresh clone.
+		_ = repo.Storer.RemoveReference(plumbing.NewBranchReferenceName(branch))
+		if target, err = defaultBranch(ctx, repo); err != nil {
+			return err
+		}
+		err = fetchBranch(ctx, repo, target, opts.Depth)
+	}
+	if err != nil {
+		return err
+	}
+
+	remote, err := repo.Reference(plumbing.NewRemoteReferenceName("origin", target), true)
+	if err != nil {
+		return fmt.Errorf("fetched branch %s not found: %w", target, err)
+	}
+	local := plumbing.NewBranchReferenceName(target)
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(local, remote.Hash())); err != nil {
+		return err
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		return err
+	}
+	return wt.Checkout(&gogit.CheckoutOptions{Branch: local, Force: true})
+}
+
+// fetchBranch fetches branch from origin into its remote-tracking ref.
+func fetchBranch(ctx context.Context, repo *gogit.Repository, branch string, depth int) error {
+	spec := config.RefSpec(fmt.Sprintf("+%s:%s",
+		plumbing.NewBranchReferenceName(branch), plumbing.NewRemoteReferenceName("origin", branch)))
+	err := repo.FetchContext(ctx, &gogit.FetchOptions{
+		RemoteName: "origin",
+		RefSpecs:   []config.RefSpec{spec},
+		Depth:      depth,
+		Force:      true,
+	})
+	if errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return err
+}
+
+// defaultBranch returns the branch origin's HEAD points to, or the branch
+// the cached clone has checked out when the remote does not say.
+func defaultBranch(ctx context.Context, repo *gogit.Repository) (string, error) {
+	if remote, err := repo.Remote("origin"); err == nil {
+		if refs, err := remote.ListContext(ctx, &gogit.ListOptions{}); err == nil {
+			for _, ref := range refs {
+				if ref.Name() == plumbing.HEAD && ref.Type() == plumbing.SymbolicReference && ref.Target().IsBranch() {
+					return ref.Target().Short(), nil
+				}
+			}
+		}
+	}
+	head, err := repo.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return "", err
+	}
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return "", errors.New("cannot determine the default branch of the cached repository")
+	}
+	return head.Target().Short(), nil
+}
+
+// evict removes the least recently used repositories that are not in use
+// until the cache fits its size limit.
+func (c *RepoCache) evict() {
+	if c.maxBytes <= 0 {
+		return
+	}
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return
+	}
+
+	type cached struct {
+		dir  string
+		used time.Time
+		size int64
+	}
+	repos := make([]cached, 0, len(entries))
+	var total int64
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(c.dir, e.Name())
+		size := dirSize(dir)
+		total += size
+		repos = append(repos, cached{dir: dir, used: info.ModTime(), size: size})
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].used.Before(repos[j].used) })
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range repos {
+		if total <= c.maxBytes {
+			return
+		}
+		if c.inUse[r.dir] > 0 {
+			continue
+		}
+		if os.RemoveAll(r.dir) == nil {
+			total -= r.size
+		}
+	}
+}
+
+func dirSize(dir string) int64 {
+	var size int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
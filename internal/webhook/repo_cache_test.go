@@ -0,0 +1,158 @@
+package webhook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// addCommit commits a change to file.txt on the checked-out branch of dir.
+func addCommit(t *testing.T, dir string) {
+	t.Helper()
+
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("PlainOpen() failed: %v", err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() failed: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "file.txt"), []byte(time.Now().String()), 0o600); err != nil {
+		t.Fatalf("WriteFile() failed: %v", err)
+	}
+	if _, err := wt.Add("file.txt"); err != nil {
+		t.Fatalf("Add() failed: %v", err)
+	}
+	sig := &object.Signature{Name: "dev", Email: "dev@example.com", When: time.Date(2024, time.January, 2, 9, 0, 0, 0, time.UTC)}
+	if _, err := wt.Commit("later commit", &gogit.CommitOptions{Author: sig}); err != nil {
+		t.Fatalf("Commit() failed: %v", err)
+	}
+}
+
+func headBranch(t *testing.T, dir string) string {
+	t.Helper()
+
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("PlainOpen() failed: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Head() failed: %v", err)
+	}
+	return head.Name().Short()
+}
+
+func TestRepoCache_Checkout(t *testing.T) {
+	source := createCloneSource(t)
+	cache, err := NewRepoCache(filepath.Join(t.TempDir(), "repos"), 0)
+	if err != nil {
+		t.Fatalf("NewRepoCache() failed: %v", err)
+	}
+	ap := &AnalysisProcessor{RepoCache: cache}
+	checkout := func(branch string) string {
+		t.Helper()
+		dir, release, err := ap.checkoutRepo(context.Background(), source, branch, "unused")
+		if err != nil {
+			t.Fatalf("checkoutRepo(%q) unexpected error = %v", branch, err)
+		}
+		release()
+		return dir
+	}
+
+	first := checkout("")
+	if got := countCommits(t, first); got != 3 {
+		t.Errorf("commits = %d, want 3", got)
+	}
+
+	addCommit(t, source)
+	if dir := checkout(""); dir != first {
+		t.Errorf("checkout dir = %q, want the cached clone %q", dir, first)
+	}
+	if got := countCommits(t, first); got != 4 {
+		t.Errorf("commits after fetch = %d, want 4", got)
+	}
+
+	checkout("feature")
+	if branch, commits := headBranch(t, first), countCommits(t, first); branch != "feature" || commits != 2 {
+		t.Errorf("HEAD = %s with %d commits, want feature with 2", branch, commits)
+	}
+
+	checkout("does-not-exist")
+	if branch, commits := headBranch(t, first), countCommits(t, first); branch != "master" || commits != 4 {
+		t.Errorf("HEAD = %s with %d commits, want the default branch with 4", branch, commits)
+	}
+
+	if err := os.RemoveAll(filepath.Join(first, ".git")); err != nil {
+		t.Fatalf("RemoveAll() failed: %v", err)
+	}
+	if got := countCommits(t, checkout("")); got != 4 {
+		t.Errorf("commits after re-clone of a broken cache entry = %d, want 4", got)
+	}
+}
+
+func TestRepoCache_Lock(t *testing.T) {
+	cache, err := NewRepoCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewRepoCache() failed: %v", err)
+	}
+	_, unlock, err := cache.lock(context.Background(), "https://example.com/a.git")
+	if err != nil {
+		t.Fatalf("lock() unexpected error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, _, err := cache.lock(ctx, "https://example.com/a.git"); err == nil {
+		t.Error("lock() of a repository in use should wait until ctx ends")
+	}
+	_, unlockOther, err := cache.lock(context.Background(), "https://example.com/b.git")
+	if err != nil {
+		t.Fatalf("lock() of another repository unexpected error = %v", err)
+	}
+	unlockOther()
+
+	unlock()
+	_, unlock, err = cache.lock(context.Background(), "https://example.com/a.git")
+	if err != nil {
+		t.Fatalf("lock() after unlock unexpected error = %v", err)
+	}
+	unlock()
+}
+
+func TestRepoCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	source := createCloneSource(t)
+	other := createCloneSource(t)
+	cache, err := NewRepoCache(t.TempDir(), 1)
+	if err != nil {
+		t.Fatalf("NewRepoCache() failed: %v", err)
+	}
+	ap := &AnalysisProcessor{RepoCache: cache}
+
+	dir, release, err := ap.checkoutRepo(context.Background(), source, "", "unused")
+	if err != nil {
+		t.Fatalf("checkoutRepo() unexpected error = %v", err)
+	}
+	if _, err := os.Stat(dir); err != nil {
+		t.Errorf("a repository in use must not be evicted: %v", err)
+	}
+	release()
+
+	otherDir, release, err := ap.checkoutRepo(context.Background(), other, "", "unused")
+	if err != nil {
+		t.Fatalf("checkoutRepo() unexpected error = %v", err)
+	}
+	defer release()
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("least recently used repository should be evicted, Stat() error = %v", err)
+	}
+	if _, err := os.Stat(otherDir); err != nil {
+		t.Errorf("repository in use was evicted: %v", err)
+	}
+}
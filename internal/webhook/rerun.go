@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// rerunnableEvents are the job types the processor can run from a stored job.
+var rerunnableEvents = map[string]bool{
+	"api_analysis_repo":    true,
+	"api_analysis_website": true,
+	"github_push":          true,
+	"gitlab_push":          true,
+}
+
+// rerunJob returns a new pending job analyzing the same source as job, with
+// the same per-request options, that bypasses the report cache. The result
+// callback is not copied, so the original callback receiver is not notified
+// again.
+func rerunJob(job *WebhookJob, id string) (*WebhookJob, error) {
+	if !rerunnableEvents[job.EventType] {
+		return nil, fmt.Errorf("jobs of type %q cannot be rerun", job.EventType)
+	}
+	if job.Status == StatusPending || job.Status == StatusProcessing {
+		return nil, fmt.Errorf("job %s is still %s", job.ID, job.Status)
+	}
+	return &WebhookJob{
+		ID:                  id,
+		EventType:           job.EventType,
+		RepoURL:             job.RepoURL,
+		LocalPath:           job.LocalPath,
+		SourceKey:           job.SourceKey,
+		RepoName:            job.RepoName,
+		Branch:              job.Branch,
+		SinceHash:           job.SinceHash,
+		Commits:             slices.Clone(job.Commits),
+		Author:              job.Author,
+		MaxCommits:          job.MaxCommits,
+		DisabledStrategies:  maps.Clone(job.DisabledStrategies),
+		MinReportConfidence: job.MinReportConfidence,
+		IncludePassed:       job.IncludePassed,
+		RerunOf:             job.ID,
+		SkipCache:           true,
+		Timestamp:           time.Now(),
+	}, nil
+}
+
+// RerunJob handles POST /jobs/:id/rerun. It queues a new job for the source
+// and options of a finished job, so clients can re-analyze after changing
+// server settings without resubmitting the request. The new job skips the
+// report cache and records the original in rerun_of.
+func (wh *WebhookHandlers) RerunJob(c *fiber.Ctx) error {
+	original, err := wh.queue.GetJob(c.Params("id"))
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{
+			"error": "job not found",
+		})
+	}
+
+	job, err := rerunJob(original, requestTraceID(c))
+	if err != nil {
+		return c.Status(http.StatusConflict).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	// The target must still pass the current URL and local path policy.
+	if job.LocalPath != "" {
+		if _, err := wh.processor.localRepoPath(job.LocalPath); err != nil {
+			return localPathError(c, err)
+		}
+	} else {
+		if job.EventType != "api_analysis_website" && !wh.gitHosts.Allows(job.RepoURL) {
+			return gitHostDenied(c, job.RepoURL)
+		}
+		check := wh.processor.guard().CheckRepositoryURL
+		if job.EventType == "api_analysis_website" {
+			check = wh.processor.guard().CheckURL
+		}
+		if err := check(c.UserContext(), job.RepoURL); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	if err := wh.queue.Enqueue(job); err != nil {
+		return c.Status(enqueueStatus(err)).JSON(fiber.Map{
+			"error": "failed to queue analysis job",
+		})
+	}
+
+	return c.Status(http.StatusAccepted).JSON(AnalysisResponse{
+		JobID:   job.ID,
+		Status:  job.Status,
+		RerunOf: job.RerunOf,
+	})
+}
@@ -0,0 +1,148 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/analysis"
+)
+
+func TestRerunJob(t *testing.T) {
+	original := &WebhookJob{
+		ID:                  "orig",
+		EventType:           "github_push",
+		RepoURL:             "https://github.com/acme/app.git",
+		RepoName:            "acme/app",
+		Branch:              "main",
+		SinceHash:           "abc123",
+		Commits:             []WebhookCommit{{Hash: "def456"}},
+		Author:              "dev",
+		MaxCommits:          50,
+		DisabledStrategies:  map[string]bool{"size_analysis": true},
+		MinReportConfidence: 0.5,
+		IncludePassed:       true,
+		CallbackURL:         "https://hooks.example.com/cadence",
+		Status:              StatusCompleted,
+		Result:              &JobResult{JobID: "orig"},
+	}
+
+	job, err := rerunJob(original, "rerun")
+	if err != nil {
+		t.Fatalf("rerunJob() unexpected error = %v", err)
+	}
+	if job.ID != "rerun" || job.RerunOf != "orig" || !job.SkipCache {
+		t.Errorf("rerun = %+v, want ID rerun, RerunOf orig and SkipCache", job)
+	}
+	if job.RepoURL != original.RepoURL || job.Branch != "main" || job.SinceHash != "abc123" || job.MaxCommits != 50 ||
+		job.MinReportConfidence != 0.5 || !job.IncludePassed || !job.DisabledStrategies["size_analysis"] || len(job.Commits) != 1 {
+		t.Errorf("rerun = %+v, want the original's source and options", job)
+	}
+	if job.CallbackURL != "" || job.Result != nil || job.Status != "" {
+		t.Errorf("rerun should start without callback, result or status: %+v", job)
+	}
+	job.DisabledStrategies["naming_analysis"] = true
+	if original.DisabledStrategies["naming_analysis"] {
+		t.Error("rerun shares its disabled strategies with the original")
+	}
+
+	for _, status := range []string{StatusPending, StatusProcessing} {
+		if _, err := rerunJob(&WebhookJob{ID: "busy", EventType: "api_analysis_repo", Status: status}, "x"); err == nil {
+			t.Errorf("rerunJob() of a %s job should fail", status)
+		}
+	}
+	if _, err := rerunJob(&WebhookJob{ID: "other", EventType: "push", Status: StatusCompleted}, "x"); err == nil {
+		t.Error("rerunJob() of an unknown job type should fail")
+	}
+}
+
+func TestRerunJob_Endpoint(t *testing.T) {
+	server, err := NewServer(&ServerConfig{
+		Host:          "localhost",
+		Port:          9999,
+		WebhookSecret: "test-secret",
+		MaxWorkers:    1,
+	}, NewDefaultProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	for _, job := range []*WebhookJob{
+		{ID: "done", EventType: "api_analysis_website", RepoURL: "https://example.com/", Status: StatusCompleted, Timestamp: time.Now()},
+		{ID: "running", EventType: "api_analysis_website", RepoURL: "https://example.com/", Status: StatusProcessing, Timestamp: time.Now()},
+		{ID: "local", EventType: "api_analysis_repo", LocalPath: t.TempDir(), Status: StatusCompleted, Timestamp: time.Now()},
+	} {
+		if err := server.store.Save(job); err != nil {
+			t.Fatalf("Save() failed: %v", err)
+		}
+	}
+
+	rerun := func(id string) (*http.Response, AnalysisResponse) {
+		t.Helper()
+		req, _ := http.NewRequest("POST", "/jobs/"+id+"/rerun", http.NoBody)
+		resp, err := server.GetApp().Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		var out AnalysisResponse
+		_ = json.NewDecoder(resp.Body).Decode(&out)
+		return resp, out
+	}
+
+	resp, out := rerun("done")
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("Status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+	}
+	if out.JobID == "" || out.JobID == "done" || out.RerunOf != "done" || out.JobID != resp.Header.Get(TraceIDHeader) {
+		t.Errorf("response = %+v, want a new job linked to done", out)
+	}
+	job, err := server.queue.GetJob(out.JobID)
+	if err != nil {
+		t.Fatalf("GetJob() unexpected error = %v", err)
+	}
+	if job.RepoURL != "https://example.com/" || job.RerunOf != "done" || !job.SkipCache {
+		t.Errorf("queued rerun = %+v", job)
+	}
+
+	// Reruns always run, even when an equivalent job is waiting.
+	if _, again := rerun("done"); again.JobID == out.JobID {
+		t.Error("a second rerun should not reuse the first")
+	}
+
+	if resp, _ := rerun("local"); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("rerun of a local checkout with local paths disabled: Status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+	if resp, _ := rerun("running"); resp.StatusCode != http.StatusConflict {
+		t.Errorf("rerun of a running job: Status = %d, want %d", resp.StatusCode, http.StatusConflict)
+	}
+	if resp, _ := rerun("missing"); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("rerun of an unknown job: Status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestProcessGitAnalysis_SkipCache(t *testing.T) {
+	repoDir := createCloneSource(t)
+	cache := analysis.NewInMemoryCache()
+	ap := &AnalysisProcessor{Cache: cache, AllowPrivateHosts: true}
+
+	if err := ap.Process(context.Background(), &WebhookJob{ID: "first", EventType: "api_analysis_repo", RepoURL: repoDir}); err != nil {
+		t.Fatalf("Process() unexpected error = %v", err)
+	}
+	if cache.Size() != 1 {
+		t.Fatalf("cache size = %d, want 1", cache.Size())
+	}
+
+	// With the source gone, only a cached report could succeed.
+	if err := os.RemoveAll(repoDir); err != nil {
+		t.Fatal(err)
+	}
+	job := &WebhookJob{ID: "rerun", EventType: "api_analysis_repo", RepoURL: repoDir, RerunOf: "first", SkipCache: true}
+	if err := ap.Process(context.Background(), job); err == nil || job.Progress != "clone-failed" {
+		t.Errorf("Process() = %v with progress %q, want a clone failure instead of the cached report", err, job.Progress)
+	}
+}
@@ -1,10 +1,14 @@
 package webhook
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/TryCadence/Cadence/internal/ai"
 	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/web"
 	"github.com/TryCadence/Cadence/internal/logging"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -16,11 +20,77 @@ type ServerConfig struct {
 	Host          string
 	Port          int
 	WebhookSecret string
-	MaxWorkers    int
-	ReadTimeout   time.Duration
-	WriteTimeout  time.Duration
+	// APIToken also authorizes POST /api/feedback; the webhook secret always does.
+	APIToken     string
+	MaxWorkers   int
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	// RecordStreamEvents persists the SSE events of streaming analyses in the job store.
+	RecordStreamEvents bool
+	// StreamResumeGrace is how long a finished stream's events are kept for
+	// clients reconnecting with Last-Event-ID (0 = DefaultStreamResumeGrace).
+	StreamResumeGrace time.Duration
+	// DisableCompression turns off gzip/deflate compression of responses.
+	// SSE streams are never compressed.
+	DisableCompression bool
+	// KeepTrackingParams disables stripping utm_* and similar parameters when
+	// normalizing URLs for cache and dedup keys.
+	KeepTrackingParams bool
+	// CallbackMaxAttempts caps deliveries of a job result callback
+	// (0 = DefaultCallbackMaxAttempts).
+	CallbackMaxAttempts int
+	// JobStore selects where jobs and results are kept: "memory" (default)
+	// or "sqlite", persisted at JobStorePath so restarts keep history and
+	// resume unfinished jobs.
+	JobStore     string
+	JobStorePath string
+	// RateLimitPerMinute and RateLimitBurst throttle the public /api/analyze/*
+	// and /api/stream/* endpoints per client IP (0 per minute = unlimited).
+	RateLimitPerMinute int
+	RateLimitBurst     int
+	// ReadyMaxQueueDepth is the number of waiting jobs at which /health/ready
+	// answers 503 (0 = the queue's capacity).
+	ReadyMaxQueueDepth int
+	// DisableCache turns off the in-memory report cache. While it is on,
+	// repeated analyses of the same repository and branch or website are
+	// served from it instead of re-cloning and recomputing.
+	DisableCache bool
+	// CacheMaxEntries bounds the cache, evicting the least recently used
+	// report beyond it (0 = DefaultCacheMaxEntries).
+	CacheMaxEntries int
+	// CacheTTL is how long a cached report is served (0 = analysis.DefaultCacheTTL).
+	CacheTTL time.Duration
+	// PluginManifest is the path of a manifest of strategy plugins to load
+	// at startup (see analysis.PluginManifest). Plugins that fail to load
+	// are logged and skipped.
+	PluginManifest string
+	// WASMPluginDir is a directory of .wasm strategy plugins to load at
+	// startup, each sandboxed by WASMPluginOptions.
+	WASMPluginDir     string
+	WASMPluginOptions analysis.WASMOptions
+	// AIPricing prices AI calls per 1000 tokens by model name (or
+	// "provider/model") for the estimated spend in the metrics.
+	AIPricing map[string]analysis.TokenPrice
+	// DisableJobDedup queues every submission as its own job instead of
+	// reusing a pending or running job for the same source and options.
+	DisableJobDedup bool
+	// Branches limits which pushed branches the GitHub and GitLab webhooks
+	// queue for analysis (empty = all).
+	Branches BranchFilter
+	// AllowedGitHosts limits the hosts repositories are accepted from by the
+	// analysis endpoints and push webhooks (empty = all).
+	AllowedGitHosts GitHostAllowlist
+	// MaxConcurrentClones bounds how many repositories queued jobs and
+	// streams clone at once; the rest wait for a slot (0 = MaxWorkers).
+	MaxConcurrentClones int
+	// Logger is the server's logger; its components log through children of
+	// it (nil = logging.Default()).
+	Logger *logging.Logger
 }
 
+// DefaultCacheMaxEntries is the report cache size when ServerConfig.CacheMaxEntries is unset.
+const DefaultCacheMaxEntries = 256
+
 type Server struct {
 	app      *fiber.App
 	config   *ServerConfig
@@ -30,6 +100,7 @@ type Server struct {
 	Cache    analysis.AnalysisCache
 	Metrics  analysis.AnalysisMetrics
 	Plugins  *analysis.PluginManager
+	store    JobStore
 }
 
 func NewServer(config *ServerConfig, processor JobProcessor) (*Server, error) {
@@ -52,24 +123,126 @@ func NewServer(config *ServerConfig, processor JobProcessor) (*Server, error) {
 	app.Use(recover.New())
 	app.Use(logger.New())
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
+		AllowOrigins:  "*",
+		AllowMethods:  "GET,POST,PUT,DELETE,OPTIONS",
+		ExposeHeaders: "X-Job-ID, " + TraceIDHeader,
 	}))
 
+	if err := config.Branches.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid branch filter: %w", err)
+	}
+	if err := config.AllowedGitHosts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid allowed git hosts: %w", err)
+	}
+
+	log := config.Logger
+	if log == nil {
+		log = logging.Default()
+	}
+
 	maxWorkers := config.MaxWorkers
 	if maxWorkers < 1 {
 		maxWorkers = 4
 	}
+	store, err := OpenJobStore(config.JobStore, config.JobStorePath)
+	if err != nil {
+		return nil, err
+	}
+
 	queue := NewJobQueue(maxWorkers, processor)
+	queue.SetStore(store)
+	queue.SetDedup(!config.DisableJobDedup)
+	queue.SetLogger(log.With("component", "job_queue"))
+	notifier := NewCallbackNotifier(config.WebhookSecret, config.CallbackMaxAttempts)
+	notifier.Logger = log.With("component", "callback")
+	queue.SetCallbackNotifier(notifier)
+
+	handlers := NewWebhookHandlers(config.WebhookSecret, queue, nil).WithLogger(log)
 
-	handlers := NewWebhookHandlers(config.WebhookSecret, queue, nil)
+	maxClones := config.MaxConcurrentClones
+	if maxClones <= 0 {
+		maxClones = maxWorkers
+	}
+	clones := NewCloneLimiter(maxClones)
 
 	// Initialise observability and plugin subsystems
-	cache := analysis.NewInMemoryCache(analysis.WithMaxSize(256))
+	var cache analysis.AnalysisCache = analysis.NullCache{}
+	if !config.DisableCache {
+		maxEntries := config.CacheMaxEntries
+		if maxEntries <= 0 {
+			maxEntries = DefaultCacheMaxEntries
+		}
+		cache = analysis.NewInMemoryCache(analysis.WithMaxSize(maxEntries), analysis.WithDefaultTTL(config.CacheTTL))
+	}
 	metrics := analysis.NewInMemoryMetrics()
+	metrics.AITokens().SetPricing(config.AIPricing)
 	plugins := analysis.NewPluginManager()
+	if config.PluginManifest != "" {
+		if _, err := plugins.LoadManifest(config.PluginManifest); err != nil {
+			log.With("component", "server").Warn("plugins not loaded", "error", err.Error())
+		}
+	}
+	if config.WASMPluginDir != "" {
+		if _, err := plugins.LoadWASMDir(context.Background(), config.WASMPluginDir, config.WASMPluginOptions); err != nil {
+			log.With("component", "server").Warn("WASM plugins not loaded", "error", err.Error())
+		}
+	}
+
+	normalizer := web.NewURLNormalizer(!config.KeepTrackingParams)
+
+	handlers.WithCache(cache).WithMetrics(metrics).WithPlugins(plugins).
+		WithEventRecording(config.RecordStreamEvents).
+		WithStreamResumeGrace(config.StreamResumeGrace).
+		WithCompression(!config.DisableCompression).
+		WithURLNormalizer(normalizer).
+		WithRateLimiter(NewRateLimiter(config.RateLimitPerMinute, config.RateLimitBurst)).
+		WithReadyMaxDepth(config.ReadyMaxQueueDepth).
+		WithBranchFilter(config.Branches).
+		WithAllowedGitHosts(config.AllowedGitHosts).
+		WithAPIToken(config.APIToken)
+	feedback, _ := store.(FeedbackStore)
+	if feedback != nil {
+		handlers.WithFeedbackStore(feedback)
+	}
+
+	// The queue runs the caller's processor, so share the server's cache,
+	// metrics and normalizer with it unless it was configured explicitly.
+	if ap, ok := processor.(*AnalysisProcessor); ok {
+		if ap.Cache == nil {
+			ap.Cache = cache
+		}
+		if ap.Metrics == nil {
+			ap.Metrics = metrics
+		}
+		if ap.Logger == nil {
+			ap.Logger = log.With("component", "processor")
+		}
+		if ap.CloneLimiter == nil {
+			ap.CloneLimiter = clones
+		}
+		clones = ap.CloneLimiter
+		if ap.URLNormalizer == nil {
+			ap.URLNormalizer = normalizer
+		}
+		if ap.Feedback == nil && feedback != nil {
+			ap.Feedback = feedback
+		}
+		if analyzer, ok := ap.Analyzer.(*ai.DefaultAnalyzer); ok {
+			analyzer.WithUsageRecorder(metrics.AITokens())
+		}
+		// Streaming clones and labels must behave exactly like queued ones.
+		handlers.WithCloneOptions(ap.Clone).WithRepoCache(ap.RepoCache).WithClassification(ap.Classification).
+			WithAllowPrivateHosts(ap.AllowPrivateHosts).WithLocalPaths(ap.AllowLocalPaths, ap.LocalPathRoot).WithHistoryLimits(ap.MaxCommits, ap.MaxDiffBytes, ap.DiffWorkers).
+			WithMergeStrategy(ap.MergeStrategy).WithAuthorIdentity(ap.AuthorIdentity).
+			WithSourceFilters(ap.SourceFilters).WithStrategyOptions(ap.StrategyOptions).
+			WithMinWordCount(ap.MinWordCount).WithVocabulary(ap.Vocabulary).WithMinReportConfidence(ap.MinReportConfidence).
+			WithIncludePassed(ap.IncludePassed).WithFetcherOptions(ap.FetcherOptions...).
+			WithAnalyzer(ap.Analyzer)
+	}
 
-	handlers.WithCache(cache).WithMetrics(metrics).WithPlugins(plugins)
+	handlers.WithCloneLimiter(clones)
+	metrics.TrackClones(clones)
+	notifier.Guard = handlers.processor.guard()
 
 	handlers.RegisterRoutes(app)
 
@@ -78,10 +251,11 @@ func NewServer(config *ServerConfig, processor JobProcessor) (*Server, error) {
 		config:   config,
 		handlers: handlers,
 		queue:    queue,
-		log:      logging.Default().With("component", "server"),
+		log:      log.With("component", "server"),
 		Cache:    cache,
 		Metrics:  metrics,
 		Plugins:  plugins,
+		store:    store,
 	}, nil
 }
 
@@ -100,10 +274,42 @@ func (s *Server) Stop() error {
 	if err := s.queue.Stop(); err != nil {
 		return fmt.Errorf("failed to stop job queue: %w", err)
 	}
+	if err := s.store.Close(); err != nil {
+		return fmt.Errorf("failed to close job store: %w", err)
+	}
 
 	return s.app.Shutdown()
 }
 
+// httpShutdownTimeout bounds closing the HTTP server after the queue has
+// drained. It is a deadline of its own, so a drain that used up the caller's
+// still leaves time to close open connections.
+const httpShutdownTimeout = 5 * time.Second
+
+// Shutdown stops the server gracefully. The queue stops accepting jobs and
+// cancels the ones still waiting, running jobs get until ctx expires to
+// finish, and then the HTTP server is shut down within httpShutdownTimeout,
+// leftover clone directories are removed and the job store is closed. A
+// drain cut short by ctx is reported in the returned error.
+func (s *Server) Shutdown(ctx context.Context) error {
+	var errs []error
+	if err := s.queue.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to drain job queue: %w", err))
+	}
+	httpCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), httpShutdownTimeout)
+	defer cancel()
+	if err := s.app.ShutdownWithContext(httpCtx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to shut down http server: %w", err))
+	}
+	if n := cloneDirs.removeAll(); n > 0 {
+		s.log.Info("removed leftover clone directories", "count", n)
+	}
+	if err := s.store.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close job store: %w", err))
+	}
+	return errors.Join(errs...)
+}
+
 func (s *Server) GetApp() *fiber.App {
 	return s.app
 }
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultStreamBufferSize is how many of a stream's latest SSE events are
+// kept for clients that reconnect with Last-Event-ID.
+const DefaultStreamBufferSize = 512
+
+// DefaultStreamResumeGrace is how long a finished stream's events stay
+// available to reconnecting clients.
+const DefaultStreamResumeGrace = 2 * time.Minute
+
+// streamBuffers keeps the recent events of each streaming job, keyed by the
+// job ID sent in X-Job-ID, so a client whose connection drops can resume
+// where it left off instead of starting the analysis over. A job's buffer
+// is dropped once the job has been finished for longer than grace.
+type streamBuffers struct {
+	mu      sync.Mutex
+	size    int
+	grace   time.Duration
+	now     func() time.Time
+	streams map[string]*bufferedStream
+}
+
+type bufferedStream struct {
+	events  *EventLog
+	expires time.Time // zero while the stream is running
+}
+
+func newStreamBuffers(size int, grace time.Duration) *streamBuffers {
+	if size <= 0 {
+		size = DefaultStreamBufferSize
+	}
+	if grace <= 0 {
+		grace = DefaultStreamResumeGrace
+	}
+	return &streamBuffers{
+		size:    size,
+		grace:   grace,
+		now:     time.Now,
+		streams: make(map[string]*bufferedStream),
+	}
+}
+
+// start registers a stream for jobID and returns its event log: events when
+// the job's events are also recorded in full, or a new bounded log.
+func (b *streamBuffers) start(jobID string, events *EventLog) *EventLog {
+	if events == nil {
+		events = NewBoundedEventLog(b.size)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pruneLocked()
+	b.streams[jobID] = &bufferedStream{events: events}
+	return events
+}
+
+// finish starts the grace period after which jobID's events are dropped.
+func (b *streamBuffers) finish(jobID string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if s, ok := b.streams[jobID]; ok {
+		s.expires = b.now().Add(b.grace)
+	}
+	b.pruneLocked()
+}
+
+// get returns jobID's event log, or nil if there is none or it expired.
+func (b *streamBuffers) get(jobID string) *EventLog {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.pruneLocked()
+	if s, ok := b.streams[jobID]; ok {
+		return s.events
+	}
+	return nil
+}
+
+// pruneLocked drops the buffers whose grace period is over. The caller must
+// hold b.mu.
+func (b *streamBuffers) pruneLocked() {
+	now := b.now()
+	for id, s := range b.streams {
+		if !s.expires.IsZero() && now.After(s.expires) {
+			delete(b.streams, id)
+		}
+	}
+}
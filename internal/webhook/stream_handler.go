@@ -3,20 +3,18 @@ package webhook
 import (
 	"bufio"
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"os"
-	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/TryCadence/Cadence/internal/analysis"
 	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
 	"github.com/TryCadence/Cadence/internal/analysis/detectors"
-	"github.com/TryCadence/Cadence/internal/analysis/sources"
 	"github.com/TryCadence/Cadence/internal/logging"
 	"github.com/gofiber/fiber/v2"
-	"github.com/google/uuid"
 )
 
 // SSE event types sent to clients.
@@ -25,6 +23,9 @@ const (
 	SSEEventDetection = "detection"
 	SSEEventResult    = "result"
 	SSEEventError     = "error"
+	SSEEventCommit    = "commit"
+	SSEEventAIToken   = "ai_token"
+	SSEEventAISummary = "ai_summary"
 )
 
 // SSEProgressEvent is sent during analysis phases.
@@ -48,9 +49,29 @@ type SSEDetectionEvent struct {
 	Examples    []string `json:"examples,omitempty"`
 }
 
+// SSECommitEvent is sent after each commit of a repository stream is
+// analyzed, with the running verdict so far.
+type SSECommitEvent struct {
+	Hash       string  `json:"hash"`
+	Index      int     `json:"index"` // 1-based
+	Total      int     `json:"total"`
+	Suspicious bool    `json:"suspicious"`
+	Score      float64 `json:"score,omitempty"`
+	Severity   string  `json:"severity,omitempty"`
+	Skipped    string  `json:"skipped,omitempty"`
+	// SuspiciousSoFar and SuspicionRate are the running tally over the
+	// commits analyzed so far.
+	SuspiciousSoFar int     `json:"suspicious_so_far"`
+	SuspicionRate   float64 `json:"suspicion_rate"`
+}
+
 // StreamAnalyzeRepository handles POST /api/stream/repository
 // It runs analysis using the StreamingRunner and sends SSE events in real-time.
 func (wh *WebhookHandlers) StreamAnalyzeRepository(c *fiber.Ctx) error {
+	if wh.resumeStream(c) {
+		return nil
+	}
+
 	var req AnalyzeRepositoryRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
@@ -58,111 +79,106 @@ func (wh *WebhookHandlers) StreamAnalyzeRepository(c *fiber.Ctx) error {
 		})
 	}
 
-	if req.RepositoryURL == "" {
+	localPath, err := resolveRepositoryRequest(wh.processor, &req)
+	if err != nil {
+		return localPathError(c, err)
+	}
+
+	disabled, err := disabledStrategySet(analysis.DefaultGitRegistry(), req.DisabledStrategies)
+	if err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
-			"error": "repository_url is required",
+			"error": err.Error(),
 		})
 	}
 
-	log := logging.Default().With("component", "stream_handler")
-	jobID := uuid.New().String()
+	if localPath == "" {
+		if !wh.gitHosts.Allows(req.RepositoryURL) {
+			return gitHostDenied(c, req.RepositoryURL)
+		}
+		if err := wh.processor.guard().CheckRepositoryURL(c.UserContext(), req.RepositoryURL); err != nil {
+			return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	jobID := requestTraceID(c)
+	traceCtx := logging.ContextWithTraceID(context.Background(), jobID)
+	log := wh.log.With("component", "stream_handler").WithContext(traceCtx)
 	branch := req.Branch
 	repoURL := req.RepositoryURL
+	target := repoURL
+	if localPath != "" {
+		target = localPath
+	}
 	thresholds := wh.processor.DetectorThresholds
+	eventLog := wh.trackStream(&WebhookJob{
+		ID:                  jobID,
+		EventType:           "api_analysis_repo",
+		RepoURL:             target,
+		LocalPath:           localPath,
+		Branch:              branch,
+		SinceHash:           req.Since,
+		MaxCommits:          req.MaxCommits,
+		MinReportConfidence: req.MinReportConfidence,
+		IncludePassed:       req.IncludePassed,
+		DisabledStrategies:  disabled,
+	})
 
-	c.Set("Content-Type", "text/event-stream")
-	c.Set("Cache-Control", "no-cache")
-	c.Set("Connection", "keep-alive")
-	c.Set("X-Accel-Buffering", "no")
-	c.Set("X-Job-ID", jobID)
-
-	// Clear the per-connection write deadline so fasthttp doesn't kill the SSE stream.
-	// The stream has its own 5-minute context timeout.
-	c.Context().Conn().SetWriteDeadline(time.Time{})
+	setSSEHeaders(c, jobID)
 
 	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		sw := &sseWriter{w: w, events: eventLog}
+		defer wh.finishStream(jobID, sw)
+
 		// Recover from panics so the connection closes cleanly with an error event
 		// instead of an abrupt TCP RST that the browser sees as "network error".
 		defer func() {
 			if r := recover(); r != nil {
 				log.Error("panic in stream writer", "job_id", jobID, "panic", fmt.Sprintf("%v", r))
-				writeSSE(w, SSEEventError, fiber.Map{
-					"message": fmt.Sprintf("Internal server error: %v", r),
-				})
+				sw.fail(fmt.Sprintf("Internal server error: %v", r))
 			}
 		}()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		ctx, cancel := context.WithTimeout(traceCtx, wh.processor.JobTimeout())
 		defer cancel()
 
-		log.Info("SSE stream started", "job_id", jobID, "type", "repository", "url", repoURL)
+		log.Info("SSE stream started", "job_id", jobID, "type", "repository", "url", target)
 
 		// Send initial progress
-		writeSSE(w, SSEEventProgress, SSEProgressEvent{
+		sw.write(SSEEventProgress, SSEProgressEvent{
 			Phase:   "queued",
 			Message: "Analysis job accepted",
 		})
 
-		// Clone repository — run in goroutine with keepalive heartbeats
-		// so the SSE connection doesn't appear idle to browsers/proxies.
-		writeSSE(w, SSEEventProgress, SSEProgressEvent{
-			Phase:   "cloning",
-			Message: fmt.Sprintf("Cloning repository %s", repoURL),
-		})
-
-		tmpDir := filepath.Join(os.TempDir(), fmt.Sprintf("cadence-stream-%s", jobID))
-		defer os.RemoveAll(tmpDir)
-
-		cloneErr := make(chan error, 1)
-		cloneStart := time.Now()
-		go func() {
-			cloneErr <- cloneRepo(repoURL, tmpDir)
-		}()
-
-		// Send keepalive heartbeats every 10s while clone is in progress
-		heartbeat := time.NewTicker(10 * time.Second)
-		defer heartbeat.Stop()
-
-		var err error
-	cloneLoop:
-		for {
-			select {
-			case err = <-cloneErr:
-				break cloneLoop
-			case <-heartbeat.C:
-				elapsed := time.Since(cloneStart)
-				writeSSE(w, SSEEventProgress, SSEProgressEvent{
-					Phase:     "cloning",
-					Message:   fmt.Sprintf("Still cloning repository... (%ds elapsed)", int(elapsed.Seconds())),
-					ElapsedMs: elapsed.Milliseconds(),
-					Percent:   15,
-				})
-			case <-ctx.Done():
-				err = ctx.Err()
-				break cloneLoop
+		repoPath := localPath
+		if repoPath == "" {
+			dir, release, ok := streamClone(ctx, sw, log, jobID, repoURL, branch, wh.processor)
+			if !ok {
+				return
 			}
-		}
-
-		if err != nil {
-			log.Error("clone failed", "error", err, "job_id", jobID)
-			writeSSE(w, SSEEventError, fiber.Map{
-				"message": fmt.Sprintf("Failed to clone repository: %s", err.Error()),
+			defer release()
+			repoPath = dir
+		} else {
+			sw.write(SSEEventProgress, SSEProgressEvent{
+				Phase:   "analyzing",
+				Message: "Using local repository, starting analysis",
 			})
-			return
 		}
 
-		writeSSE(w, SSEEventProgress, SSEProgressEvent{
-			Phase:     "analyzing",
-			Message:   "Repository cloned, starting analysis",
-			ElapsedMs: time.Since(cloneStart).Milliseconds(),
-		})
-
-		source := sources.NewGitRepositorySource(tmpDir, branch)
-		det := detectors.NewGitDetector(thresholds)
+		source := wh.processor.newGitSource(repoPath, branch, req.Since, req.MaxCommits)
+		det := wh.processor.newGitDetector(thresholds, disabled)
+		det.MinReportConfidence = wh.processor.reportConfidence(req.MinReportConfidence)
+		det.IncludePassed = wh.processor.IncludePassed || req.IncludePassed
+		det.Suppressions = wh.processor.suppressions(ctx, jobID)
+		det.Metrics = wh.metrics
 		runner := analysis.NewStreamingRunner()
 
 		events := runner.RunStream(ctx, source, det)
-		streamEventsToSSEWithMetrics(w, events, log, jobID, "api_analysis_repo", wh.metrics)
+		report := streamEventsToSSEWithMetrics(sw, events, log, jobID, "api_analysis_repo", wh.metrics, wh.processor.Classification, wh.processor.ExamplePolicy)
+		if req.AISummary {
+			wh.streamSummary(sw, log, jobID, report)
+		}
 
 		log.Info("SSE stream ended", "job_id", jobID, "type", "repository")
 	})
@@ -173,6 +189,10 @@ func (wh *WebhookHandlers) StreamAnalyzeRepository(c *fiber.Ctx) error {
 // StreamAnalyzeWebsite handles POST /api/stream/website
 // Same as StreamAnalyzeRepository but for web content.
 func (wh *WebhookHandlers) StreamAnalyzeWebsite(c *fiber.Ctx) error {
+	if wh.resumeStream(c) {
+		return nil
+	}
+
 	var req AnalyzeWebsiteRequest
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
@@ -186,47 +206,78 @@ func (wh *WebhookHandlers) StreamAnalyzeWebsite(c *fiber.Ctx) error {
 		})
 	}
 
-	log := logging.Default().With("component", "stream_handler")
-	jobID := uuid.New().String()
-	targetURL := req.URL
+	if err := validateReportConfidence(req.MinReportConfidence); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	c.Set("Content-Type", "text/event-stream")
-	c.Set("Cache-Control", "no-cache")
-	c.Set("Connection", "keep-alive")
-	c.Set("X-Accel-Buffering", "no")
-	c.Set("X-Job-ID", jobID)
+	disabled, err := disabledStrategySet(analysis.DefaultWebRegistry(), req.DisabledStrategies)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
 
-	// Clear the per-connection write deadline so fasthttp doesn't kill the SSE stream.
-	c.Context().Conn().SetWriteDeadline(time.Time{})
+	if err := wh.processor.guard().CheckURL(c.UserContext(), req.URL); err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error": err.Error(),
+		})
+	}
+
+	jobID := requestTraceID(c)
+	traceCtx := logging.ContextWithTraceID(context.Background(), jobID)
+	log := wh.log.With("component", "stream_handler").WithContext(traceCtx)
+	targetURL := req.URL
+	eventLog := wh.trackStream(&WebhookJob{
+		ID:                  jobID,
+		EventType:           "api_analysis_website",
+		RepoURL:             targetURL,
+		DisabledStrategies:  disabled,
+		MinReportConfidence: req.MinReportConfidence,
+	})
+
+	setSSEHeaders(c, jobID)
 
 	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		sw := &sseWriter{w: w, events: eventLog}
+		defer wh.finishStream(jobID, sw)
+
 		// Recover from panics so the connection closes cleanly with an error event
 		// instead of an abrupt TCP RST that the browser sees as "network error".
 		defer func() {
 			if r := recover(); r != nil {
 				log.Error("panic in stream writer", "job_id", jobID, "panic", fmt.Sprintf("%v", r))
-				writeSSE(w, SSEEventError, fiber.Map{
-					"message": fmt.Sprintf("Internal server error: %v", r),
-				})
+				sw.fail(fmt.Sprintf("Internal server error: %v", r))
 			}
 		}()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		ctx, cancel := context.WithTimeout(traceCtx, wh.processor.analysisTimeout())
 		defer cancel()
 
 		log.Info("SSE stream started", "job_id", jobID, "type", "website", "url", targetURL)
 
-		writeSSE(w, SSEEventProgress, SSEProgressEvent{
+		sw.write(SSEEventProgress, SSEProgressEvent{
 			Phase:   "fetching",
 			Message: fmt.Sprintf("Fetching content from %s", targetURL),
 		})
 
-		source := sources.NewWebsiteSource(targetURL)
+		source := wh.processor.websiteSource(targetURL)
 		det := detectors.NewWebDetector()
+		det.MinWordCount = wh.processor.MinWordCount
+		det.Vocabulary = wh.processor.Vocabulary
+		det.DisabledStrategies = disabled
+		det.MinReportConfidence = wh.processor.reportConfidence(req.MinReportConfidence)
+		det.Suppressions = wh.processor.suppressions(ctx, jobID)
+		det.SourceKey = wh.urlNormalizer.Key(targetURL)
+		det.Metrics = wh.metrics
 		runner := analysis.NewStreamingRunner()
 
 		events := runner.RunStream(ctx, source, det)
-		streamEventsToSSEWithMetrics(w, events, log, jobID, "api_analysis_website", wh.metrics)
+		report := streamEventsToSSEWithMetrics(sw, events, log, jobID, "api_analysis_website", wh.metrics, wh.processor.Classification, wh.processor.ExamplePolicy)
+		if req.AISummary {
+			wh.streamSummary(sw, log, jobID, report)
+		}
 
 		log.Info("SSE stream ended", "job_id", jobID, "type", "website")
 	})
@@ -234,15 +285,90 @@ func (wh *WebhookHandlers) StreamAnalyzeWebsite(c *fiber.Ctx) error {
 	return nil
 }
 
+// streamClone clones repoURL with the processor's checkoutRepo, sending
+// keepalive progress events so the SSE connection doesn't appear idle to
+// browsers/proxies. It reports false after sending an error event if the
+// clone failed; otherwise release must be called once the clone is no
+// longer needed.
+func streamClone(ctx context.Context, sw *sseWriter, log *logging.Logger, jobID, repoURL, branch string, ap *AnalysisProcessor) (dir string, release func(), ok bool) {
+	message := fmt.Sprintf("Cloning repository %s", repoURL)
+	if ap.CloneLimiter.saturated() {
+		message = "Waiting for a free clone slot"
+	}
+	sw.write(SSEEventProgress, SSEProgressEvent{
+		Phase:   "cloning",
+		Message: message,
+	})
+
+	type checkout struct {
+		dir     string
+		release func()
+		err     error
+	}
+	cloned := make(chan checkout, 1)
+	cloneStart := time.Now()
+	go func() {
+		dir, release, err := ap.checkoutRepo(ctx, repoURL, branch, fmt.Sprintf("cadence-stream-%s", jobID))
+		cloned <- checkout{dir, release, err}
+	}()
+
+	// Send keepalive heartbeats every 10s while clone is in progress
+	heartbeat := time.NewTicker(10 * time.Second)
+	defer heartbeat.Stop()
+
+	var result checkout
+cloneLoop:
+	for {
+		select {
+		case result = <-cloned:
+			break cloneLoop
+		case <-heartbeat.C:
+			elapsed := time.Since(cloneStart)
+			sw.write(SSEEventProgress, SSEProgressEvent{
+				Phase:     "cloning",
+				Message:   fmt.Sprintf("Still cloning repository... (%ds elapsed)", int(elapsed.Seconds())),
+				ElapsedMs: elapsed.Milliseconds(),
+				Percent:   15,
+			})
+		case <-ctx.Done():
+			// Release the clone once the abandoned checkout returns.
+			go func() {
+				if late := <-cloned; late.err == nil {
+					late.release()
+				}
+			}()
+			result.err = ctx.Err()
+			break cloneLoop
+		}
+	}
+
+	if result.err != nil {
+		log.Error("clone failed", "error", result.err, "job_id", jobID)
+		sw.fail(fmt.Sprintf("Failed to clone repository: %s", result.err.Error()))
+		return "", nil, false
+	}
+
+	sw.write(SSEEventProgress, SSEProgressEvent{
+		Phase:     "analyzing",
+		Message:   "Repository cloned, starting analysis",
+		ElapsedMs: time.Since(cloneStart).Milliseconds(),
+	})
+	return result.dir, result.release, true
+}
+
 // streamEventsToSSE reads from the StreamingRunner channel and writes SSE events to the response writer.
 // It sends heartbeat comments when no events arrive for 15 seconds, keeping the chunked
 // connection alive through proxies and browsers.
-func streamEventsToSSE(w *bufio.Writer, events <-chan analysis.StreamEvent, log *logging.Logger, jobID string, eventType string) {
-	streamEventsToSSEWithMetrics(w, events, log, jobID, eventType, analysis.NullMetrics{})
+func streamEventsToSSE(sw *sseWriter, events <-chan analysis.StreamEvent, log *logging.Logger, jobID string, eventType string) {
+	streamEventsToSSEWithMetrics(sw, events, log, jobID, eventType, analysis.NullMetrics{}, DefaultClassificationThresholds, analysis.ExamplePolicy{})
 }
 
-// streamEventsToSSEWithMetrics is the same as streamEventsToSSE but also records analysis metrics.
-func streamEventsToSSEWithMetrics(w *bufio.Writer, events <-chan analysis.StreamEvent, log *logging.Logger, jobID string, eventType string, metrics analysis.AnalysisMetrics) {
+// streamEventsToSSEWithMetrics is the same as streamEventsToSSE but also records analysis metrics
+// and labels the final result with the given classification thresholds. Detection examples are
+// limited and redacted by examples. It returns the final report, with its full examples, or nil
+// if the analysis failed or the client went away first.
+func streamEventsToSSEWithMetrics(sw *sseWriter, events <-chan analysis.StreamEvent, log *logging.Logger, jobID string, eventType string, metrics analysis.AnalysisMetrics, classification ClassificationThresholds, examples analysis.ExamplePolicy) *analysis.AnalysisReport {
+	var report *analysis.AnalysisReport
 	heartbeat := time.NewTicker(15 * time.Second)
 	defer heartbeat.Stop()
 
@@ -250,14 +376,14 @@ func streamEventsToSSEWithMetrics(w *bufio.Writer, events <-chan analysis.Stream
 		select {
 		case event, ok := <-events:
 			if !ok {
-				return // channel closed — stream complete
+				return report // channel closed — stream complete
 			}
 			heartbeat.Reset(15 * time.Second)
 
 			switch event.Type {
 			case analysis.EventProgress:
 				if event.Progress != nil {
-					if !writeSSE(w, SSEEventProgress, SSEProgressEvent{
+					if !sw.write(SSEEventProgress, SSEProgressEvent{
 						Phase:     event.Progress.Phase,
 						Message:   event.Progress.Message,
 						Current:   event.Progress.Current,
@@ -266,23 +392,41 @@ func streamEventsToSSEWithMetrics(w *bufio.Writer, events <-chan analysis.Stream
 						Percent:   progressPercent(event.Progress),
 					}) {
 						log.Warn("SSE write failed (client disconnected?)", "job_id", jobID)
-						return
+						return nil
 					}
 				}
 
 			case analysis.EventDetection:
 				if event.Detection != nil {
-					if !writeSSE(w, SSEEventDetection, SSEDetectionEvent{
+					if !sw.write(SSEEventDetection, SSEDetectionEvent{
 						Strategy:    event.Detection.Strategy,
 						Detected:    event.Detection.Detected,
 						Severity:    event.Detection.Severity,
 						Score:       event.Detection.Score,
 						Category:    event.Detection.Category,
 						Description: event.Detection.Description,
-						Examples:    event.Detection.Examples,
+						Examples:    examples.DetectionExamples(event.Detection),
 					}) {
 						log.Warn("SSE write failed (client disconnected?)", "job_id", jobID)
-						return
+						return nil
+					}
+				}
+
+			case analysis.EventItem:
+				if event.Item != nil && event.Item.Kind == "commit" {
+					if !sw.write(SSEEventCommit, SSECommitEvent{
+						Hash:            event.Item.ID,
+						Index:           event.Item.Current,
+						Total:           event.Item.Total,
+						Suspicious:      event.Item.Suspicious,
+						Score:           event.Item.Score,
+						Severity:        event.Item.Severity,
+						Skipped:         event.Item.Skipped,
+						SuspiciousSoFar: event.Item.SuspiciousSoFar,
+						SuspicionRate:   float64(event.Item.SuspiciousSoFar) / float64(event.Item.Current),
+					}) {
+						log.Warn("SSE write failed (client disconnected?)", "job_id", jobID)
+						return nil
 					}
 				}
 
@@ -300,37 +444,178 @@ func streamEventsToSSEWithMetrics(w *bufio.Writer, events <-chan analysis.Stream
 					metrics.RecordDetections(sourceType, event.Report.TotalDetections, event.Report.DetectionCount)
 
 					// Build the final result in the same format as the non-streaming endpoint
-					result := buildJobResult(event.Report, eventType)
-					writeSSE(w, SSEEventResult, result)
+					result := buildJobResult(examples.Apply(event.Report), eventType, classification)
+					sw.write(SSEEventResult, result)
+					report = event.Report
 				}
 
 			case analysis.EventError:
-				if event.Error != nil {
+				if errors.Is(event.Error, context.DeadlineExceeded) {
+					log.Error("stream analysis timed out", "job_id", jobID, "error", event.Error)
+					metrics.RecordError(eventType, "timeout")
+					sw.fail("analysis timed out")
+				} else if event.Error != nil {
 					log.Error("stream analysis error", "job_id", jobID, "error", event.Error)
 					metrics.RecordError(eventType, "stream")
-					writeSSE(w, SSEEventError, fiber.Map{
-						"message": event.Error.Error(),
-					})
+					sw.fail(event.Error.Error())
 				}
 			}
 
 		case <-heartbeat.C:
 			// Send an SSE comment to keep the chunked connection alive.
 			// Browsers and proxies may drop idle chunked streams.
-			if _, err := fmt.Fprint(w, ":heartbeat\n\n"); err != nil {
+			if !sw.comment("heartbeat") {
 				log.Warn("heartbeat write failed (client disconnected?)", "job_id", jobID)
+				return nil
+			}
+		}
+	}
+}
+
+// setSSEHeaders prepares the response for an event stream.
+func setSSEHeaders(c *fiber.Ctx, jobID string) {
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+	c.Set("X-Job-ID", jobID)
+
+	// Clear the per-connection write deadline so fasthttp doesn't kill the SSE stream.
+	// The stream has its own 5-minute context timeout.
+	c.Context().Conn().SetWriteDeadline(time.Time{})
+}
+
+// resumeStream serves a reconnecting client from the job's buffered or
+// recorded events instead of re-running the analysis. The job is identified
+// by the X-Job-ID header (or ?job_id=) returned on the original stream, and
+// only events after Last-Event-ID are replayed; if the job is still running,
+// new events are forwarded as they are recorded. It returns false when the
+// request is not a resumable reconnect, in which case the caller starts a
+// fresh analysis: no Last-Event-ID, an unknown or expired job, or a client
+// so far behind that the events it missed are no longer buffered.
+func (wh *WebhookHandlers) resumeStream(c *fiber.Ctx) bool {
+	lastID, err := strconv.Atoi(strings.TrimSpace(c.Get("Last-Event-ID")))
+	if err != nil {
+		return false
+	}
+
+	jobID := c.Get("X-Job-ID")
+	if jobID == "" {
+		jobID = c.Query("job_id")
+	}
+	if jobID == "" {
+		return false
+	}
+
+	events := wh.streams.get(jobID)
+	if events == nil {
+		job, err := wh.queue.GetJob(jobID)
+		if err != nil || job.Events == nil {
+			return false
+		}
+		events = job.Events
+	}
+
+	log := wh.log.With("component", "stream_handler")
+	if !events.Retains(lastID) {
+		log.Info("SSE resume too far behind, restarting", "job_id", jobID, "last_event_id", lastID)
+		return false
+	}
+
+	setRequestTraceID(c, jobID)
+	traceCtx := logging.ContextWithTraceID(context.Background(), jobID)
+	log = log.WithContext(traceCtx)
+	setSSEHeaders(c, jobID)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(traceCtx, 5*time.Minute)
+		defer cancel()
+
+		log.Info("SSE stream resumed", "job_id", jobID, "last_event_id", lastID)
+		replayEvents(ctx, w, events, lastID)
+		log.Info("SSE resumed stream ended", "job_id", jobID)
+	})
+
+	return true
+}
+
+// replayEvents writes every recorded event after lastID and then follows the
+// log until the original stream finishes, the client disconnects, or ctx ends.
+func replayEvents(ctx context.Context, w *bufio.Writer, events *EventLog, lastID int) {
+	heartbeat := time.NewTicker(15 * time.Second)
+	defer heartbeat.Stop()
+
+	for {
+		updated := events.Updated()
+
+		for _, rec := range events.After(lastID) {
+			if !writeSSERecord(w, rec) {
 				return
 			}
-			if err := w.Flush(); err != nil {
-				log.Warn("heartbeat flush failed (client disconnected?)", "job_id", jobID)
+			lastID = rec.ID
+		}
+
+		if events.Done() && events.Len() <= lastID {
+			return
+		}
+
+		select {
+		case <-updated:
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ":heartbeat\n\n"); err != nil || w.Flush() != nil {
 				return
 			}
+		case <-ctx.Done():
+			return
 		}
 	}
 }
 
+// trackStream registers a streaming job and returns the event log its events
+// are written to. The job's latest events are buffered for reconnecting
+// clients; with event recording enabled the job, carrying the request's
+// source and options so it can be rerun, is also tracked in the job store
+// with its full event log.
+func (wh *WebhookHandlers) trackStream(job *WebhookJob) *EventLog {
+	if !wh.recordEvents {
+		return wh.streams.start(job.ID, nil)
+	}
+
+	job.Status = StatusProcessing
+	job.Commits = make([]WebhookCommit, 0)
+	job.Events = NewEventLog()
+	wh.queue.Track(job)
+	return wh.streams.start(job.ID, job.Events)
+}
+
+// finishStream marks a streaming job's events done, starting the grace period
+// its buffer is kept for, and marks a tracked job completed or failed. A
+// stream that ended without a result, such as after the client disconnected,
+// is marked cancelled rather than completed.
+func (wh *WebhookHandlers) finishStream(jobID string, sw *sseWriter) {
+	if sw.events == nil {
+		return
+	}
+	sw.events.MarkDone()
+	wh.streams.finish(jobID)
+	if !wh.recordEvents {
+		return
+	}
+
+	switch {
+	case sw.failure != "":
+		wh.queue.SetStatus(jobID, StatusFailed, sw.failure)
+	case sw.completed:
+		wh.queue.SetStatus(jobID, StatusCompleted, "")
+	case sw.disconnected:
+		wh.queue.SetStatus(jobID, StatusCancelled, "client disconnected before the analysis finished")
+	default:
+		wh.queue.SetStatus(jobID, StatusCancelled, "stream ended without a result")
+	}
+}
+
 // buildJobResult converts an AnalysisReport into a JobResultResponse for the final SSE event.
-func buildJobResult(report *analysis.AnalysisReport, eventType string) *JobResultResponse {
+func buildJobResult(report *analysis.AnalysisReport, eventType string, classification ClassificationThresholds) *JobResultResponse {
 	resp := &JobResultResponse{
 		Status:     StatusCompleted,
 		AnalyzedAt: report.Timing.StartedAt,
@@ -343,37 +628,50 @@ func buildJobResult(report *analysis.AnalysisReport, eventType string) *JobResul
 		}
 
 		for _, d := range report.Detections {
-			if d.Category == "git-analysis" && d.Detected {
-				hash := ""
-				var reasons []string
-				if len(d.Examples) > 0 {
-					hash = d.Examples[0]
-					if len(d.Examples) > 1 {
-						reasons = d.Examples[1:]
-					}
+			if !d.Detected {
+				resp.PassedStrategies = append(resp.PassedStrategies, passedStrategy(d))
+				continue
+			}
+			hash := ""
+			var reasons []string
+			if len(d.Examples) > 0 {
+				hash = d.Examples[0]
+				if len(d.Examples) > 1 {
+					reasons = d.Examples[1:]
 				}
-				resp.Suspicions = append(resp.Suspicions, Suspicion{
-					CommitHash: hash,
-					Message:    d.Description,
-					Severity:   d.Severity,
-					Reasons:    reasons,
-					Score:      d.Score * 100,
-				})
 			}
+			resp.Suspicions = append(resp.Suspicions, Suspicion{
+				CommitHash: hash,
+				Message:    d.Description,
+				Severity:   d.Severity,
+				Reasons:    reasons,
+				Strategies: d.Strategies,
+				Score:      d.Score * 100,
+
+				LowConfidence: d.LowConfidence,
+				Caveat:        d.Caveat,
+			})
 		}
 		resp.SuspiciousCommits = len(resp.Suspicions)
+		resp.UniqueAuthors = report.SourceMetrics.UniqueAuthors
 
 		if pairs, ok := report.Metrics["commit_pairs"].([]*git.CommitPair); ok {
 			jr := mapToJobResult(resp)
 			calculateMetrics(jr, pairs)
 			resp.Velocity = jr.Velocity
 			resp.TimeSpan = jr.TimeSpan
-			resp.UniqueAuthors = jr.UniqueAuthors
 			resp.AverageCommitSize = jr.AverageCommitSize
 			resp.OverallSuspicion = jr.OverallSuspicion
 		} else if resp.TotalCommits > 0 {
 			resp.OverallSuspicion = (float64(resp.SuspiciousCommits) / float64(resp.TotalCommits)) * 100
 		}
+	} else if eventType == "api_analysis_website" && report.InsufficientContent() != nil {
+		resp.Status = StatusInsufficientContent
+		resp.URL = report.SourceID
+		resp.Assessment = classification.Labels.Label(report.Assessment)
+		if wc, ok := report.Metrics["word_count"].(int); ok {
+			resp.WordCount = wc
+		}
 	} else if eventType == "api_analysis_website" {
 		// Populate website fields
 		resp.URL = report.SourceID
@@ -415,13 +713,7 @@ func buildJobResult(report *analysis.AnalysisReport, eventType string) *JobResul
 		resp.OverallSuspicion = float64(confidenceScore)
 		resp.QualityScore = 1.0 - suspicionRate
 
-		if suspicionRate >= 0.7 {
-			resp.Assessment = "Likely AI-Generated"
-		} else if suspicionRate >= 0.4 {
-			resp.Assessment = "Suspicious Activity"
-		} else {
-			resp.Assessment = "Likely Human-Written"
-		}
+		resp.Assessment = classification.Assess(suspicionRate)
 
 		if wc, ok := report.Metrics["word_count"].(int); ok {
 			resp.WordCount = wc
@@ -477,16 +769,3 @@ func progressPercent(p *analysis.ProgressInfo) float64 {
 		return 0
 	}
 }
-
-// writeSSE writes a single Server-Sent Event to the writer and flushes.
-// Returns false if the write or flush failed (broken connection).
-func writeSSE(w *bufio.Writer, event string, data interface{}) bool {
-	jsonBytes, err := json.Marshal(data)
-	if err != nil {
-		return false
-	}
-	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, string(jsonBytes)); err != nil {
-		return false
-	}
-	return w.Flush() == nil
-}
@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/TryCadence/Cadence/internal/logging"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+// TraceIDHeader carries the correlation ID of a request in every response.
+// Jobs created by a request take its trace ID as their job ID, so the
+// handler, queue, processor and detection logs of one analysis share it.
+const TraceIDHeader = "X-Trace-ID"
+
+// traceIDLocal is the fiber.Ctx local holding the request's trace ID.
+const traceIDLocal = "trace_id"
+
+// traceRequest gives the request a fresh trace ID, attaches it to the
+// request's context for logging and returns it in the X-Trace-ID header and
+// in the body of JSON error responses. Client-supplied trace IDs are ignored
+// so a request cannot pick the ID of another job.
+func (wh *WebhookHandlers) traceRequest(c *fiber.Ctx) error {
+	setRequestTraceID(c, uuid.New().String())
+
+	err := c.Next()
+	if err == nil && c.Response().StatusCode() >= fiber.StatusBadRequest {
+		addTraceIDToError(c, requestTraceID(c))
+	}
+	return err
+}
+
+// setRequestTraceID makes id the request's trace ID. A resumed stream takes
+// over the ID of the job it reattaches to.
+func setRequestTraceID(c *fiber.Ctx, id string) {
+	c.Locals(traceIDLocal, id)
+	c.SetUserContext(logging.ContextWithTraceID(c.UserContext(), id))
+	c.Set(TraceIDHeader, id)
+}
+
+// requestTraceID returns the trace ID traceRequest gave the request, or a new
+// ID for requests that did not pass through it.
+func requestTraceID(c *fiber.Ctx) string {
+	if id, ok := c.Locals(traceIDLocal).(string); ok && id != "" {
+		return id
+	}
+	return uuid.New().String()
+}
+
+// addTraceIDToError adds "trace_id" to a JSON error body such as
+// {"error": "..."}, so users can quote it in bug reports. Other bodies are
+// left alone.
+func addTraceIDToError(c *fiber.Ctx, id string) {
+	if !strings.HasPrefix(string(c.Response().Header.ContentType()), fiber.MIMEApplicationJSON) {
+		return
+	}
+	var body map[string]any
+	if err := json.Unmarshal(c.Response().Body(), &body); err != nil {
+		return
+	}
+	if _, ok := body["error"]; !ok {
+		return
+	}
+	body[logging.TraceIDAttr] = id
+	if data, err := json.Marshal(body); err == nil {
+		c.Response().SetBodyRaw(data)
+	}
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestTraceRequest(t *testing.T) {
+	server, err := NewServer(&ServerConfig{Host: "localhost", Port: 9999, MaxWorkers: 1}, localPathProcessor())
+	if err != nil {
+		t.Fatalf("NewServer() failed: %v", err)
+	}
+	send := func(t *testing.T, method, path, body string) (*http.Response, map[string]any) {
+		t.Helper()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(TraceIDHeader, "client-chosen")
+		resp, err := server.GetApp().Test(req)
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		var out map[string]any
+		_ = json.NewDecoder(resp.Body).Decode(&out)
+		return resp, out
+	}
+
+	t.Run("header on every response", func(t *testing.T) {
+		resp, _ := send(t, "GET", "/health", "")
+		id := resp.Header.Get(TraceIDHeader)
+		if id == "" || id == "client-chosen" {
+			t.Errorf("%s = %q, want a server-generated ID", TraceIDHeader, id)
+		}
+	})
+
+	t.Run("error payload", func(t *testing.T) {
+		resp, out := send(t, "POST", "/api/analyze/repository", `{}`)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Fatalf("Status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+		}
+		if out["error"] == nil || out["trace_id"] != resp.Header.Get(TraceIDHeader) {
+			t.Errorf("body = %v, want the error and trace_id %q", out, resp.Header.Get(TraceIDHeader))
+		}
+	})
+
+	t.Run("job ID", func(t *testing.T) {
+		repoDir := t.TempDir()
+		if _, err := gogit.PlainInit(repoDir, false); err != nil {
+			t.Fatalf("PlainInit() failed: %v", err)
+		}
+		resp, out := send(t, "POST", "/api/analyze/repository", `{"local_path":"`+repoDir+`"}`)
+		if resp.StatusCode != http.StatusAccepted {
+			t.Fatalf("Status = %d, want %d", resp.StatusCode, http.StatusAccepted)
+		}
+		if out["job_id"] != resp.Header.Get(TraceIDHeader) {
+			t.Errorf("job_id = %v, want the trace ID %q", out["job_id"], resp.Header.Get(TraceIDHeader))
+		}
+		if _, ok := out["trace_id"]; ok {
+			t.Errorf("body = %v, want no trace_id outside error payloads", out)
+		}
+	})
+}
+
+func TestAddTraceIDToError_SkipsNonErrorBodies(t *testing.T) {
+	app := fiber.New()
+	app.Use((&WebhookHandlers{}).traceRequest)
+	app.Get("/text", func(c *fiber.Ctx) error { return c.Status(http.StatusNotFound).SendString("missing") })
+	app.Get("/json", func(c *fiber.Ctx) error {
+		return c.Status(http.StatusConflict).JSON(fiber.Map{"status": "busy"})
+	})
+
+	for path, want := range map[string]string{"/text": "missing", "/json": `{"status":"busy"}`} {
+		resp, err := app.Test(httptest.NewRequest("GET", path, http.NoBody))
+		if err != nil {
+			t.Fatalf("Test() unexpected error = %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if string(body) != want {
+			t.Errorf("%s body = %q, want %q unchanged", path, body, want)
+		}
+	}
+}
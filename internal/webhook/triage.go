@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/TryCadence/Cadence/internal/ai/skills"
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+const (
+	// DefaultTriageLimit is how many suspicions are triaged per analysis
+	// when AnalysisProcessor.TriageLimit is zero.
+	DefaultTriageLimit = 5
+	// triageTimeout bounds all triage calls for one job.
+	triageTimeout = 2 * time.Minute
+	// triageMetric keeps the verdicts of a cached report, keyed by commit
+	// hash. The cached commit pairs have no diffs to triage with, so a
+	// cache hit reuses these instead of calling the analyzer again.
+	triageMetric = "triage_verdicts"
+)
+
+func (ap *AnalysisProcessor) triageLimit() int {
+	if ap.TriageLimit == 0 {
+		return DefaultTriageLimit
+	}
+	return ap.TriageLimit
+}
+
+// triageSuspicions asks the AI analyzer for a second opinion on the
+// highest-scoring high-severity suspicions, at most triageLimit of them,
+// and attaches each verdict to its suspicion. The commit's diff is sent
+// along when the report still carries the commit pairs. It does nothing
+// without a configured analyzer; a failed call leaves that suspicion
+// untriaged.
+func (ap *AnalysisProcessor) triageSuspicions(ctx context.Context, job *WebhookJob, report *analysis.AnalysisReport) {
+	limit := ap.triageLimit()
+	if ap.Analyzer == nil || !ap.Analyzer.IsConfigured() || limit < 0 || job.Result == nil {
+		return
+	}
+
+	var candidates []int
+	for i, s := range job.Result.Suspicions {
+		if s.Severity == "high" {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	sort.SliceStable(candidates, func(a, b int) bool {
+		return job.Result.Suspicions[candidates[a]].Score > job.Result.Suspicions[candidates[b]].Score
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+
+	detections := make(map[string]analysis.Detection)
+	for _, d := range report.Detections {
+		if d.Detected && len(d.Examples) > 0 {
+			detections[d.Examples[0]] = d
+		}
+	}
+	diffs := make(map[string]string)
+	if pairs, ok := report.Metrics["commit_pairs"].([]*git.CommitPair); ok {
+		for _, p := range pairs {
+			if p != nil && p.Current != nil {
+				diffs[p.Current.Hash] = p.DiffContent
+			}
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, triageTimeout)
+	defer cancel()
+
+	ap.log(ctx).LogPhaseDebug(job.ID, "triaging suspicions", "count", len(candidates))
+	for _, i := range candidates {
+		suspicion := &job.Result.Suspicions[i]
+		input := skills.DetectionTriageInput{
+			Detection: detections[suspicion.CommitHash],
+			Snippet:   diffs[suspicion.CommitHash],
+		}
+		result, err := ap.Analyzer.RunSkill(ctx, "detection_triage", input)
+		if err != nil {
+			ap.log(ctx).LogPhaseError(job.ID, "triage failed", err, "commit", suspicion.CommitHash)
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		if result.OutputError != nil {
+			ap.log(ctx).Warn("triage output did not match schema", "job_id", job.ID, "commit", suspicion.CommitHash,
+				"reason", result.OutputError.Reason, "error", result.OutputError.Error())
+		}
+		if triage, ok := result.Parsed.(*skills.DetectionTriageResult); ok {
+			suspicion.Triage = triage
+		}
+	}
+}
+
+// cacheTriage records the verdicts attached to job's suspicions on the
+// cached copy of its report.
+func cacheTriage(cached *analysis.AnalysisReport, job *WebhookJob) {
+	if job.Result == nil {
+		return
+	}
+	verdicts := make(map[string]*skills.DetectionTriageResult)
+	for _, s := range job.Result.Suspicions {
+		if s.Triage != nil {
+			verdicts[s.CommitHash] = s.Triage
+		}
+	}
+	if len(verdicts) > 0 {
+		cached.Metrics[triageMetric] = verdicts
+	}
+}
+
+// applyCachedTriage attaches the verdicts cached with report to job's
+// suspicions. It makes no analyzer calls.
+func applyCachedTriage(job *WebhookJob, report *analysis.AnalysisReport) {
+	verdicts, ok := report.Metrics[triageMetric].(map[string]*skills.DetectionTriageResult)
+	if !ok || job.Result == nil {
+		return
+	}
+	for i := range job.Result.Suspicions {
+		if triage, ok := verdicts[job.Result.Suspicions[i].CommitHash]; ok {
+			job.Result.Suspicions[i].Triage = triage
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/TryCadence/Cadence/internal/ai"
+	"github.com/TryCadence/Cadence/internal/ai/skills"
+	"github.com/TryCadence/Cadence/internal/analysis"
+	"github.com/TryCadence/Cadence/internal/analysis/adapters/git"
+)
+
+// triageAnalyzer answers detection_triage with a false positive verdict,
+// or fails for the commits in fail, and records the inputs it was sent.
+type triageAnalyzer struct {
+	inputs []skills.DetectionTriageInput
+	fail   map[string]bool
+}
+
+func (a *triageAnalyzer) AnalyzeSuspiciousCode(context.Context, string, string) (string, error) {
+	return "", nil
+}
+
+func (a *triageAnalyzer) AnalyzeCode(context.Context, string, string) (*ai.AnalysisResult, error) {
+	return nil, nil
+}
+
+func (a *triageAnalyzer) AnalyzeWithSystemPrompt(context.Context, string, string) (string, error) {
+	return "", nil
+}
+
+func (a *triageAnalyzer) RunSkill(_ context.Context, skillName string, input interface{}) (*ai.SkillResult, error) {
+	in := input.(skills.DetectionTriageInput)
+	a.inputs = append(a.inputs, in)
+	if skillName != "detection_triage" || a.fail[in.Detection.Examples[0]] {
+		return nil, errors.New("provider unavailable")
+	}
+	return &ai.SkillResult{Skill: skillName, Parsed: &skills.DetectionTriageResult{
+		Verdict:    skills.TriageFalsePositive,
+		Reasoning:  "looks like a vendored migration",
+		Confidence: 0.7,
+	}}, nil
+}
+
+func (a *triageAnalyzer) RunSkillStream(ctx context.Context, skillName string, input interface{}, _ func(string)) (*ai.SkillResult, error) {
+	return a.RunSkill(ctx, skillName, input)
+}
+
+func (a *triageAnalyzer) IsConfigured() bool   { return true }
+func (a *triageAnalyzer) ProviderName() string { return "fake" }
+
+func triageFixture() (*WebhookJob, *analysis.AnalysisReport) {
+	report := &analysis.AnalysisReport{Metrics: map[string]interface{}{
+		"commit_pairs": []*git.CommitPair{
+			{Current: &git.Commit{Hash: "aaa"}, DiffContent: "+INSERT INTO t VALUES (1);"},
+		},
+	}}
+	job := &WebhookJob{ID: "triage", Result: &JobResult{}}
+	for _, c := range []struct {
+		hash     string
+		severity string
+		score    float64
+	}{
+		{"aaa", "high", 0.9},
+		{"bbb", "medium", 0.95},
+		{"ccc", "high", 0.7},
+		{"ddd", "high", 0.8},
+	} {
+		report.Detections = append(report.Detections, analysis.Detection{
+			Strategy: "commit_" + c.hash, Detected: true, Severity: c.severity, Score: c.score,
+			Category: "git-analysis", Examples: []string{c.hash, "Large commit"},
+		})
+		job.Result.Suspicions = append(job.Result.Suspicions, Suspicion{CommitHash: c.hash, Severity: c.severity, Score: c.score * 100})
+	}
+	return job, report
+}
+
+func TestTriageSuspicions(t *testing.T) {
+	t.Run("top high-severity suspicions", func(t *testing.T) {
+		job, report := triageFixture()
+		analyzer := &triageAnalyzer{}
+		ap := &AnalysisProcessor{Analyzer: analyzer, TriageLimit: 2}
+		ap.triageSuspicions(context.Background(), job, report)
+
+		triaged := make(map[string]bool)
+		for _, s := range job.Result.Suspicions {
+			if s.Triage != nil {
+				triaged[s.CommitHash] = true
+			}
+		}
+		if len(triaged) != 2 || !triaged["aaa"] || !triaged["ddd"] {
+			t.Errorf("triaged = %v, want the two highest-scoring high-severity commits aaa and ddd", triaged)
+		}
+		if got := job.Result.Suspicions[0].Triage; got.Verdict != skills.TriageFalsePositive || got.Confidence != 0.7 {
+			t.Errorf("Triage = %+v, want the analyzer's verdict", got)
+		}
+		if !strings.Contains(analyzer.inputs[0].Snippet, "INSERT INTO") {
+			t.Errorf("Snippet = %q, want the commit's diff", analyzer.inputs[0].Snippet)
+		}
+		if analyzer.inputs[0].Detection.Strategy != "commit_aaa" {
+			t.Errorf("Detection = %+v, want the detection behind the suspicion", analyzer.inputs[0].Detection)
+		}
+	})
+
+	t.Run("failed call leaves suspicion untriaged", func(t *testing.T) {
+		job, report := triageFixture()
+		ap := &AnalysisProcessor{Analyzer: &triageAnalyzer{fail: map[string]bool{"aaa": true}}}
+		ap.triageSuspicions(context.Background(), job, report)
+
+		if job.Result.Suspicions[0].Triage != nil {
+			t.Error("failed triage should not attach a verdict")
+		}
+		if job.Result.Suspicions[2].Triage == nil || job.Result.Suspicions[3].Triage == nil {
+			t.Error("the remaining high-severity suspicions should still be triaged")
+		}
+	})
+
+	t.Run("cached verdicts", func(t *testing.T) {
+		job, report := triageFixture()
+		analyzer := &triageAnalyzer{}
+		ap := &AnalysisProcessor{Analyzer: analyzer, TriageLimit: 1}
+		ap.triageSuspicions(context.Background(), job, report)
+		cached := cacheableGitReport(report)
+		cacheTriage(cached, job)
+
+		hit, _ := triageFixture()
+		applyCachedTriage(hit, cached)
+		if len(analyzer.inputs) != 1 {
+			t.Errorf("analyzer called %d times, want only the original triage", len(analyzer.inputs))
+		}
+		for i, s := range hit.Result.Suspicions {
+			if (s.Triage != nil) != (job.Result.Suspicions[i].Triage != nil) {
+				t.Errorf("suspicion %s: Triage = %+v on the cache hit, want %+v", s.CommitHash, s.Triage, job.Result.Suspicions[i].Triage)
+			}
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		for name, ap := range map[string]*AnalysisProcessor{
+			"no analyzer":    {},
+			"negative limit": {Analyzer: &triageAnalyzer{}, TriageLimit: -1},
+		} {
+			job, report := triageFixture()
+			ap.triageSuspicions(context.Background(), job, report)
+			for _, s := range job.Result.Suspicions {
+				if s.Triage != nil {
+					t.Errorf("%s: suspicion %s was triaged", name, s.CommitHash)
+				}
+			}
+		}
+	})
+}